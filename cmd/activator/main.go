@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/sgl-project/ome/pkg/activator"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func main() {
+	zapLogger, _ := zap.NewProduction()
+	defer func() { _ = zapLogger.Sync() }()
+	logger := zapLogger.Sugar()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], logger); err != nil {
+		logger.Errorw("activator failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run proxies each port to the backend Service and serves the KEDA external scaler API until one of the servers
+// fails or the context is done
+func run(ctx context.Context, args []string, logger *zap.SugaredLogger) error {
+	flags := flag.NewFlagSet("activator", flag.ExitOnError)
+	backend := flags.String("backend", "", "The host of the Service selecting the component pods")
+	portList := flags.String("ports", "8080", "Comma separated ports to proxy to the backend")
+	scalerPort := flags.Int("scaler-port", constants.ActivatorScalerPort, "The port to serve the KEDA external scaler API on")
+	timeout := flags.Duration("timeout", 10*time.Minute, "How long to hold a request while the backend scales up")
+	maxBodyBytes := flags.Int64("max-body-bytes", 16<<20, "The largest request body to hold")
+	_ = flags.Parse(args)
+	if *backend == "" {
+		return fmt.Errorf("--backend is required")
+	}
+	ports, err := parsePorts(*portList)
+	if err != nil {
+		return err
+	}
+
+	a := activator.New(*backend, *timeout, *maxBodyBytes, logger)
+	errCh := make(chan error, len(ports)+1)
+	for _, port := range ports {
+		go func() { errCh <- serve(ctx, port, a.Handler(port)) }()
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", *scalerPort))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	externalscaler.RegisterExternalScalerServer(grpcServer, activator.NewScaler(a))
+	go func() { errCh <- grpcServer.Serve(listener) }()
+	logger.Infow("Starting activator", "backend", *backend, "ports", ports, "scalerPort", *scalerPort)
+
+	select {
+	case err := <-errCh:
+		grpcServer.Stop()
+		return err
+	case <-ctx.Done():
+		grpcServer.GracefulStop()
+		// Wait for the held requests to drain
+		for range ports {
+			if err := <-errCh; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func parsePorts(value string) ([]int, error) {
+	var ports []int
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		port, err := strconv.Atoi(field)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", field)
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("--ports requires at least one port")
+	}
+	return ports, nil
+}
+
+func serve(ctx context.Context, port int, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe() }()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
@@ -44,6 +44,8 @@ import (
 	v1beta1benchmarkjobcontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/benchmark"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 	v1beta1isvccontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice"
+	v1beta1mcproutecontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/mcproute"
+	v1beta1mcpservercontroller "github.com/sgl-project/ome/pkg/controller/v1beta1/mcpserver"
 	"github.com/sgl-project/ome/pkg/runtimeselector"
 	"github.com/sgl-project/ome/pkg/utils"
 	"github.com/sgl-project/ome/pkg/version"
@@ -209,6 +211,20 @@ func main() {
 		setupLog.Error(err, "Failed to initialize ingress configuration")
 		os.Exit(1)
 	}
+	runtimeSelectionConfig, err := controllerconfig.NewRuntimeSelectionConfig(clientSet)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize runtime selection configuration")
+		os.Exit(1)
+	}
+	scoringPolicies, err := runtimeselector.NewScoringPolicies(mgr.GetClient(), runtimeSelectionConfig)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize runtime scoring policies")
+		os.Exit(1)
+	}
+	// The controller and the webhook share one selector so both rank runtimes the same way
+	selectorConfig := runtimeselector.NewConfig(mgr.GetClient())
+	selectorConfig.Policies = scoringPolicies
+	runtimeSelector := runtimeselector.NewWithConfig(selectorConfig)
 
 	// Register optional schemes based on CRD availability
 	setupLog.Info("Registering optional CRD schemes")
@@ -252,6 +268,8 @@ func main() {
 		Log:       ctrl.Log.WithName("InferenceService"),
 		Scheme:    mgr.GetScheme(),
 		Recorder:  eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+
+		RuntimeSelector: runtimeSelector,
 	}).SetupWithManager(mgr, deployConfig, ingressConfig); err != nil {
 		setupLog.Error(err, "Failed to create InferenceService controller")
 		os.Exit(1)
@@ -306,6 +324,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup MCPServer and MCPRoute controllers
+	mcpEventBroadcaster := record.NewBroadcaster()
+	setupLog.Info("Setting up MCPServer and MCPRoute controllers")
+	mcpEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+	if err = (&v1beta1mcpservercontroller.MCPServerReconciler{
+		Client:    mgr.GetClient(),
+		Clientset: clientSet,
+		Log:       ctrl.Log.WithName("MCPServer"),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mcpEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create MCPServer controller")
+		os.Exit(1)
+	}
+	if err = (&v1beta1mcproutecontroller.MCPRouteReconciler{
+		Client:    mgr.GetClient(),
+		Clientset: clientSet,
+		Log:       ctrl.Log.WithName("MCPRoute"),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mcpEventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "Failed to create MCPRoute controller")
+		os.Exit(1)
+	}
+
 	if options.enableWebhook {
 		setupLog.Info("Configuring webhook server", "port", options.webhookPort)
 		hookServer := mgr.GetWebhookServer()
@@ -338,7 +381,7 @@ func main() {
 			}).
 			WithValidator(&isvc.InferenceServiceValidator{
 				Client:          mgr.GetClient(),
-				RuntimeSelector: runtimeselector.New(mgr.GetClient()),
+				RuntimeSelector: runtimeSelector,
 			}).
 			Complete(); err != nil {
 			setupLog.Error(err, "Failed to create InferenceService webhook", "webhook", "v1beta1")
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/mcp/bridge"
+	"github.com/sgl-project/ome/pkg/mcp/gateway"
+)
+
+const usage = `Usage:
+  mcp-gateway [flags]                              Serve MCPRoutes from a route table
+  mcp-gateway stdio-bridge [flags] -- CMD [ARGS]   Serve a stdio MCP server over streamable HTTP
+  mcp-gateway install DEST                         Copy this binary to DEST
+`
+
+func main() {
+	zapLogger, _ := zap.NewProduction()
+	defer func() { _ = zapLogger.Sync() }()
+	logger := zapLogger.Sugar()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	args := os.Args[1:]
+	switch {
+	case len(args) > 0 && args[0] == "stdio-bridge":
+		err = runBridge(ctx, args[1:], logger)
+	case len(args) > 0 && args[0] == "install":
+		err = install(args[1:])
+	default:
+		err = runGateway(ctx, args, logger)
+	}
+	if err != nil {
+		logger.Errorw("mcp-gateway failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// runGateway serves the routes of the route table, reloading it when the mounted Secret changes
+func runGateway(ctx context.Context, args []string, logger *zap.SugaredLogger) error {
+	flags := flag.NewFlagSet("mcp-gateway", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage); flags.PrintDefaults() }
+	port := flags.Int("port", constants.MCPDefaultServerPort, "The port to listen on")
+	routesFile := flags.String("routes-file", "/etc/mcp-gateway/"+constants.MCPGatewayRoutesFileName,
+		"The route table rendered by the MCPRoute controller")
+	reloadInterval := flags.Duration("reload-interval", 5*time.Second, "How often to check the route table for changes")
+	_ = flags.Parse(args)
+
+	table, err := gateway.LoadRouteTable(*routesFile)
+	if err != nil {
+		return err
+	}
+	g := gateway.New(table, logger)
+	go g.WatchRouteTable(ctx, *routesFile, *reloadInterval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.Handle(constants.MCPGatewayRoutesPathPrefix+"/", g)
+	logger.Infow("Starting MCP gateway", "port", *port, "routes", len(table.Routes))
+	return serve(ctx, *port, mux)
+}
+
+// runBridge starts a stdio MCP server and serves it over streamable HTTP until it exits
+func runBridge(ctx context.Context, args []string, logger *zap.SugaredLogger) error {
+	flags := flag.NewFlagSet("stdio-bridge", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage); flags.PrintDefaults() }
+	port := flags.Int("port", constants.MCPDefaultServerPort, "The port to listen on")
+	path := flags.String("path", constants.MCPDefaultServerPath, "The path of the MCP endpoint")
+	_ = flags.Parse(args)
+	command := flags.Args()
+	if len(command) == 0 {
+		return errors.New("stdio-bridge requires the command of the MCP server")
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start MCP server: %w", err)
+	}
+
+	b := bridge.New(stdin, logger)
+	serverCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		if err := b.Serve(stdout); err != nil {
+			logger.Errorw("Failed to read from MCP server", "error", err)
+		}
+		cancel()
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.Handle(*path, b)
+	logger.Infow("Starting stdio bridge", "port", *port, "path", *path, "command", command[0])
+	serveErr := serve(serverCtx, *port, mux)
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("MCP server exited: %w", err)
+	}
+	return serveErr
+}
+
+func serve(ctx context.Context, port int, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe() }()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// install copies the running binary, so an init container can provide the bridge to a server image
+func install(args []string) error {
+	if len(args) != 1 {
+		return errors.New("install requires a destination path")
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(self)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if err := os.MkdirAll(filepath.Dir(args[0]), 0o755); err != nil {
+		return err
+	}
+	dst, err := os.OpenFile(args[0], os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
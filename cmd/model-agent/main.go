@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 
 	omev1beta1client "github.com/sgl-project/ome/pkg/client/clientset/versioned"
 	omev1beta1informers "github.com/sgl-project/ome/pkg/client/informers/externalversions"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/modelagent"
 	"github.com/sgl-project/ome/pkg/version"
@@ -43,6 +45,8 @@ type config struct {
 	numDownloadWorker    int
 	namespace            string
 	logLevel             string
+	blobStore            bool
+	hfEndpoints          string
 }
 
 // Logger type alias for zap.SugaredLogger
@@ -73,6 +77,8 @@ func init() {
 	rootCmd.PersistentFlags().IntVar(&cfg.numDownloadWorker, "num-download-worker", 5, "Number of download workers")
 	rootCmd.PersistentFlags().StringVar(&cfg.namespace, "namespace", "ome", "Kubernetes namespace to use")
 	rootCmd.PersistentFlags().StringVar(&cfg.logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&cfg.hfEndpoints, "hf-endpoints", "", "Ordered Hugging Face endpoints to fail over between, comma-separated URLs")
+	rootCmd.PersistentFlags().BoolVar(&cfg.blobStore, "blob-store", false, "Share identical Hugging Face model files across models through a blob store under the models root dir")
 
 	_ = v.BindPFlags(rootCmd.PersistentFlags())
 	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -252,6 +258,34 @@ func initializeComponents(
 
 	logger.Infof("Configured Xet Hugging Face hub client with max concurrent downloads: %d", xetHubConfig.MaxConcurrentDownloads)
 
+	// Share identical files across Hugging Face models through hard links or reflinks into a blob store
+	var blobStore *hub.BlobStore
+	if cfg.blobStore {
+		blobStore, err = hub.NewBlobStore(filepath.Join(cfg.modelsRootDir, hub.DefaultBlobStoreDirName))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create blob store: %w", err)
+		}
+		logger.Infof("Sharing Hugging Face model files through the blob store at %s", blobStore.Root())
+	}
+
+	// Pull Hugging Face models through mirrors or proxies of the Hub, failing over between them
+	hfEndpoints, err := hub.ParseEndpoints(v.GetString("hf-endpoints"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Hugging Face endpoints: %w", err)
+	}
+	// Endpoint tokens come from a secret through the environment only, never from flags or the ConfigMap
+	hfEndpoints, err = modelagent.ApplyHuggingFaceEndpointTokens(hfEndpoints, v.GetString("hf-endpoint-tokens"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set Hugging Face endpoint tokens: %w", err)
+	}
+	if len(hfEndpoints) > 0 {
+		urls := make([]string, 0, len(hfEndpoints))
+		for _, endpoint := range hfEndpoints {
+			urls = append(urls, endpoint.URL)
+		}
+		logger.Infof("Downloading Hugging Face models from endpoints %v", urls)
+	}
+
 	// Create a Gopher instance for downloading models
 	gopher, err := modelagent.NewGopher(
 		modelConfigParser,
@@ -262,6 +296,8 @@ func initializeComponents(
 		cfg.multipartConcurrency,
 		cfg.downloadRetry,
 		cfg.modelsRootDir,
+		blobStore,
+		hfEndpoints,
 		gopherTaskChan,
 		nodeLabelReconciler,
 		metrics,
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics records the outcomes of the probes and their checks
+type Metrics struct {
+	probesTotal   *prometheus.CounterVec
+	checkDuration *prometheus.HistogramVec
+}
+
+// NewMetrics creates the prober metrics and registers them with the registerer
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	return &Metrics{
+		probesTotal: promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+			Name: "multinode_prober_probes_total",
+			Help: "Total number of probes by runtime, probe and result",
+		}, []string{"runtime", "probe", "result"}),
+		checkDuration: promauto.With(registerer).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "multinode_prober_check_duration_seconds",
+			Help:    "Duration of the checks run by the probes in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 15), // From 10ms to ~160s
+		}, []string{"runtime", "probe", "check", "result"}),
+	}
+}
+
+// RecordProbe records the outcome of a probe
+func (m *Metrics) RecordProbe(runtime string, probe Probe, passed bool) {
+	m.probesTotal.WithLabelValues(runtime, string(probe), result(passed)).Inc()
+}
+
+// RecordCheck records the outcome and duration of a check
+func (m *Metrics) RecordCheck(runtime string, probe Probe, check string, passed bool, duration time.Duration) {
+	m.checkDuration.WithLabelValues(runtime, string(probe), check, result(passed)).Observe(duration.Seconds())
+}
+
+func result(passed bool) string {
+	if passed {
+		return "success"
+	}
+	return "failure"
+}
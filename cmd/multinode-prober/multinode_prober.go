@@ -1,54 +1,72 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/sgl-project/ome/pkg/version"
 )
 
-// ChatCompletionRequest represents the request payload for the OpenAI-compatible API
-type ChatCompletionRequest struct {
-	Model    string        `json:"model"`
-	Messages []ChatMessage `json:"messages"`
-}
+// Probe is a Kubernetes probe served by the prober
+type Probe string
 
-// ChatMessage represents a single message in the chat conversation
-type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
+const (
+	Liveness  Probe = "liveness"
+	Readiness Probe = "readiness"
+	Startup   Probe = "startup"
+)
 
 type Options struct {
-	VLLMEndpoint     string
-	ReadTimeout      time.Duration
-	WriteTimeout     time.Duration
-	IdleTimeout      time.Duration
-	Addr             string
-	inferenceTimeout time.Duration
+	// Runtime selects the health contract of the serving runtime: sglang, vllm, ray or openai
+	Runtime string
+	// Endpoint is the base URL of the serving runtime
+	Endpoint string
+	// RayEndpoint is the base URL of the Ray dashboard on the head node, checked by the ray runtime
+	RayEndpoint string
+	// Model is the model of the startup inference request, the first served model when empty
+	Model string
+
+	LivenessTimeout  time.Duration
+	ReadinessTimeout time.Duration
+	StartupTimeout   time.Duration
+
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	Addr         string
 }
 
 func DefaultOptions() *Options {
 	return &Options{
-		VLLMEndpoint:     "http://localhost:8081/health",
+		Runtime:          RuntimeVLLM,
+		Endpoint:         "http://localhost:8081",
+		LivenessTimeout:  5 * time.Second,
+		ReadinessTimeout: 5 * time.Second,
+		StartupTimeout:   100 * time.Second,
 		ReadTimeout:      10 * time.Second,
 		WriteTimeout:     10 * time.Second,
 		IdleTimeout:      120 * time.Second,
-		inferenceTimeout: 100 * time.Second,
 		Addr:             ":8081",
 	}
 }
 
 func GetOptions() *Options {
 	opt := DefaultOptions()
-	flag.StringVar(&opt.VLLMEndpoint, "vllm-endpoint", opt.VLLMEndpoint, "The vLLM health endpoint")
+	flag.StringVar(&opt.Runtime, "runtime", opt.Runtime, "The serving runtime probed: sglang, vllm, ray or openai")
+	flag.StringVar(&opt.Endpoint, "endpoint", opt.Endpoint, "The base URL of the serving runtime")
+	flag.StringVar(&opt.Endpoint, "vllm-endpoint", opt.Endpoint, "Deprecated: use --endpoint")
+	flag.StringVar(&opt.RayEndpoint, "ray-endpoint", opt.RayEndpoint, "The base URL of the Ray dashboard, required by the ray runtime")
+	flag.StringVar(&opt.Model, "model", opt.Model, "The model of the startup inference request, the first served model by default")
+	flag.DurationVar(&opt.LivenessTimeout, "liveness-timeout", opt.LivenessTimeout, "The timeout of the liveness checks")
+	flag.DurationVar(&opt.ReadinessTimeout, "readiness-timeout", opt.ReadinessTimeout, "The timeout of the readiness checks")
+	flag.DurationVar(&opt.StartupTimeout, "startup-timeout", opt.StartupTimeout, "The timeout of the startup checks")
 	flag.DurationVar(&opt.ReadTimeout, "read-timeout", opt.ReadTimeout, "The read timeout for the server")
 	flag.DurationVar(&opt.WriteTimeout, "write-timeout", opt.WriteTimeout, "The write timeout for the server")
 	flag.DurationVar(&opt.IdleTimeout, "idle-timeout", opt.IdleTimeout, "The idle timeout for the server")
@@ -57,161 +75,120 @@ func GetOptions() *Options {
 	return opt
 }
 
-// Liveness Probe Handler
-func livenessHandler(opt *Options) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if isServiceAlive(opt) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = fmt.Fprintln(w, "Liveness check passed")
-			log.Println("Liveness check passed")
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = fmt.Fprintln(w, "Liveness check failed")
-			log.Println("Liveness check failed")
-		}
-	}
+// Prober runs the checks of the health contract of a serving runtime
+type Prober struct {
+	runtime  string
+	contract Contract
+	timeouts map[Probe]time.Duration
+	client   *http.Client
+	metrics  *Metrics
 }
 
-// Readiness Probe Handler
-func readinessHandler(opt *Options) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if isServiceReady(opt) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = fmt.Fprintln(w, "Readiness check passed")
-			log.Println("Readiness check passed")
-		} else {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, _ = fmt.Fprintln(w, "Readiness check failed")
-			log.Println("Readiness check failed")
-		}
-	}
+// NewProber creates a Prober for the runtime of the options
+func NewProber(opt *Options, metrics *Metrics) (*Prober, error) {
+	contract, err := NewContract(opt)
+	if err != nil {
+		return nil, err
+	}
+	return &Prober{
+		runtime:  opt.Runtime,
+		contract: contract,
+		timeouts: map[Probe]time.Duration{
+			Liveness:  opt.LivenessTimeout,
+			Readiness: opt.ReadinessTimeout,
+			Startup:   opt.StartupTimeout,
+		},
+		client:  &http.Client{},
+		metrics: metrics,
+	}, nil
 }
 
-// Startup Probe Handler
-func startupHandler(opt *Options) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if isServiceStarted(opt) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = fmt.Fprintln(w, "Startup check passed")
-			log.Println("Startup check passed")
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-			_, _ = fmt.Fprintln(w, "Startup check failed")
-			log.Println("Startup check failed")
-		}
+// Probe runs the checks of the probe in order and reports whether all of them passed. The timeout of the probe bounds
+// all of its checks together, so that the prober answers within the timeout of the kubelet probe.
+func (p *Prober) Probe(ctx context.Context, probe Probe) bool {
+	if timeout := p.timeouts[probe]; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
-}
 
-// Custom logic for checking if the service is alive
-func isServiceAlive(opt *Options) bool {
-	vllmHealthy := checkEndpoint(opt.VLLMEndpoint)
-
-	if !vllmHealthy {
-		log.Printf("vLLM endpoint %s is not responding", opt.VLLMEndpoint)
-		return false
+	passed := true
+	for _, check := range p.checks(probe) {
+		if !p.runCheck(ctx, probe, check) {
+			passed = false
+			break
+		}
 	}
-
-	return true
+	p.metrics.RecordProbe(p.runtime, probe, passed)
+	return passed
 }
 
-func isServiceReady(opt *Options) bool {
-	vllmHealthy := checkEndpoint(opt.VLLMEndpoint)
-
-	if !vllmHealthy {
-		log.Printf("vLLM endpoint %s is not responding", opt.VLLMEndpoint)
-		return false
+func (p *Prober) checks(probe Probe) []Check {
+	switch probe {
+	case Liveness:
+		return p.contract.Liveness
+	case Readiness:
+		return p.contract.Readiness
+	default:
+		return p.contract.Startup
 	}
-
-	return true
 }
 
-// Custom logic for checking if the service has started correctly
-func isServiceStarted(opt *Options) bool {
-	// Check if the Ray head node has started
-
-	vllmStarted := sendInferenceRequest(opt)
-
-	if !vllmStarted {
-		log.Printf("Inference request to vLLM endpoint %s failed", opt.VLLMEndpoint)
+func (p *Prober) runCheck(ctx context.Context, probe Probe, check Check) bool {
+	start := time.Now()
+	err := check.Run(ctx, p.client)
+	p.metrics.RecordCheck(p.runtime, probe, check.Name, err == nil, time.Since(start))
+	if err != nil {
+		log.Printf("%s check %s of %s runtime failed: %v", probe, check.Name, p.runtime, err)
 		return false
 	}
 	return true
 }
 
-// Helper function to send an inference request to the vLLM endpoint
-func sendInferenceRequest(opt *Options) bool {
-	// Create the request payload
-	requestPayload := ChatCompletionRequest{
-		Model: "vllm-model",
-		Messages: []ChatMessage{
-			{
-				Role:    "system",
-				Content: "You are a helpful assistant.",
-			},
-			{
-				Role:    "user",
-				Content: "Hello, how are you?",
-			},
-		},
-	}
-
-	// Convert the payload to JSON
-	payloadBytes, err := json.Marshal(requestPayload)
-	if err != nil {
-		log.Printf("Failed to marshal request payload: %v", err)
-		return false
-	}
-
-	// Send the POST request
-	client := &http.Client{
-		Timeout: opt.inferenceTimeout, // Set a timeout for the request
-	}
-	resp, err := client.Post(opt.VLLMEndpoint+"/v1/chat/completions", "application/json", bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		log.Printf("Failed to send inference request: %v", err)
-		return false
-	}
-	defer resp.Body.Close()
-
-	// Check if the response status code is 200 OK
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Inference request failed with status code: %d", resp.StatusCode)
-		return false
+// probeHandler serves a probe, answering failureStatus when it fails
+func probeHandler(prober *Prober, probe Probe, failureStatus int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if prober.Probe(r.Context(), probe) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, "%s check passed\n", probe)
+			log.Printf("%s check passed", probe)
+		} else {
+			w.WriteHeader(failureStatus)
+			_, _ = fmt.Fprintf(w, "%s check failed\n", probe)
+			log.Printf("%s check failed", probe)
+		}
 	}
-
-	// Optionally, we can check the content of the response body to ensure it's a valid response
-	// For simplicity, we're only checking the status code here
-
-	return true
 }
 
-// Helper function to check an HTTP endpoint
-func checkEndpoint(endpoint string) bool {
-	resp, err := http.Get(endpoint + "/health")
-	if err != nil {
-		log.Printf("Error reaching endpoint %s: %v", endpoint, err)
-		return false
-	}
-	defer resp.Body.Close()
+// Liveness Probe Handler
+func livenessHandler(prober *Prober) http.HandlerFunc {
+	return probeHandler(prober, Liveness, http.StatusInternalServerError)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("Endpoint %s returned non-OK status: %d", endpoint, resp.StatusCode)
-		return false
-	}
+// Readiness Probe Handler
+func readinessHandler(prober *Prober) http.HandlerFunc {
+	return probeHandler(prober, Readiness, http.StatusServiceUnavailable)
+}
 
-	log.Printf("Endpoint %s is healthy", endpoint)
-	return true
+// Startup Probe Handler
+func startupHandler(prober *Prober) http.HandlerFunc {
+	return probeHandler(prober, Startup, http.StatusInternalServerError)
 }
 
 func main() {
-	// Initialize configuration using Viper
 	options := GetOptions()
 
 	log.Printf("Starting multinode-prober, gitVersion=%s, gitCommit=%s", version.GitVersion, version.GitCommit)
 
-	http.HandleFunc("/healthz", livenessHandler(options))
-	http.HandleFunc("/readyz", readinessHandler(options))
-	http.HandleFunc("/startupz", startupHandler(options))
+	prober, err := NewProber(options, NewMetrics(prometheus.DefaultRegisterer))
+	if err != nil {
+		log.Fatalf("Failed to create prober: %v", err)
+	}
+	log.Printf("Probing %s runtime at %s", options.Runtime, options.Endpoint)
+
+	http.HandleFunc("/healthz", livenessHandler(prober))
+	http.HandleFunc("/readyz", readinessHandler(prober))
+	http.HandleFunc("/startupz", startupHandler(prober))
 
 	http.Handle("/metrics", promhttp.Handler())
 
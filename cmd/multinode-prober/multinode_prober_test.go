@@ -1,47 +1,74 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 // Mock implementation of the Options struct for testing purposes
 func mockOptions() *Options {
 	return &Options{
-		VLLMEndpoint:     "http://localhost:8081",
+		Runtime:          RuntimeVLLM,
+		Endpoint:         "http://localhost:8081",
+		LivenessTimeout:  5 * time.Second,
+		ReadinessTimeout: 5 * time.Second,
+		StartupTimeout:   100 * time.Second,
 		ReadTimeout:      10 * time.Second,
 		WriteTimeout:     10 * time.Second,
 		IdleTimeout:      120 * time.Second,
-		inferenceTimeout: 100 * time.Second,
 		Addr:             ":8081",
 	}
 }
 
-// Mock server for testing endpoint checking
-func startMockServer() *httptest.Server {
+// Mock server for testing endpoint checking. Paths in failing return 503.
+func startMockServer(failing ...string) *httptest.Server {
 	handler := http.NewServeMux()
-	handler.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
-
-	handler.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"response": "success"}`))
-	})
+	ok := func(path string, body string) {
+		handler.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			for _, failingPath := range failing {
+				if failingPath == path {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	ok("/health", "")
+	ok("/health_generate", "")
+	ok("/api/gcs_healthz", "success")
+	ok("/v1/models", `{"data": [{"id": "llama-3-70b"}]}`)
+	ok("/v1/chat/completions", `{"response": "success"}`)
 
 	return httptest.NewServer(handler)
 }
 
+func mockProber(t *testing.T, opt *Options) (*Prober, *Metrics) {
+	metrics := NewMetrics(prometheus.NewRegistry())
+	prober, err := NewProber(opt, metrics)
+	if err != nil {
+		t.Fatalf("Could not create prober: %v", err)
+	}
+	return prober, metrics
+}
+
 func TestLivenessHandler(t *testing.T) {
 	opt := mockOptions()
 	server := startMockServer()
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
+	opt.Endpoint = server.URL
+	prober, _ := mockProber(t, opt)
 
 	req, err := http.NewRequest("GET", "/healthz", nil)
 	if err != nil {
@@ -49,7 +76,7 @@ func TestLivenessHandler(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	handler := livenessHandler(opt)
+	handler := livenessHandler(prober)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -59,10 +86,11 @@ func TestLivenessHandler(t *testing.T) {
 
 func TestReadinessHandler(t *testing.T) {
 	opt := mockOptions()
-	server := startMockServer()
+	server := startMockServer("/health")
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
+	opt.Endpoint = server.URL
+	prober, _ := mockProber(t, opt)
 
 	req, err := http.NewRequest("GET", "/readyz", nil)
 	if err != nil {
@@ -70,11 +98,11 @@ func TestReadinessHandler(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	handler := readinessHandler(opt)
+	handler := readinessHandler(prober)
 	handler.ServeHTTP(rr, req)
 
-	if rr.Code != http.StatusOK {
-		t.Errorf("Expected status OK, got %v", rr.Code)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status Service Unavailable, got %v", rr.Code)
 	}
 }
 
@@ -83,7 +111,8 @@ func TestStartupHandler(t *testing.T) {
 	server := startMockServer()
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
+	opt.Endpoint = server.URL
+	prober, _ := mockProber(t, opt)
 
 	req, err := http.NewRequest("GET", "/startupz", nil)
 	if err != nil {
@@ -91,7 +120,7 @@ func TestStartupHandler(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	handler := startupHandler(opt)
+	handler := startupHandler(prober)
 	handler.ServeHTTP(rr, req)
 
 	if rr.Code != http.StatusOK {
@@ -99,76 +128,144 @@ func TestStartupHandler(t *testing.T) {
 	}
 }
 
-func TestSendInferenceRequest(t *testing.T) {
-	opt := mockOptions()
-	server := startMockServer()
-	defer server.Close()
-
-	opt.VLLMEndpoint = server.URL
+func TestGetOptions(t *testing.T) {
+	options := GetOptions()
 
-	success := sendInferenceRequest(opt)
-	if !success {
-		t.Errorf("Expected inference request to succeed, but it failed")
+	if options.Runtime != RuntimeVLLM {
+		t.Errorf("Expected default Runtime, got %v", options.Runtime)
+	}
+	if options.Endpoint != "http://localhost:8081" {
+		t.Errorf("Expected default Endpoint, got %v", options.Endpoint)
+	}
+	if options.Addr != ":8081" {
+		t.Errorf("Expected default Addr, got %v", options.Addr)
+	}
+	if options.ReadTimeout != 10*time.Second {
+		t.Errorf("Expected default ReadTimeout, got %v", options.ReadTimeout)
+	}
+	if options.StartupTimeout != 100*time.Second {
+		t.Errorf("Expected default StartupTimeout, got %v", options.StartupTimeout)
 	}
 }
 
-func TestCheckEndpoint(t *testing.T) {
-	server := startMockServer()
+func TestProbeTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
 	defer server.Close()
 
-	success := checkEndpoint(server.URL)
-	if !success {
-		t.Errorf("Expected endpoint to be healthy, but it was not")
+	opt := mockOptions()
+	opt.Endpoint = server.URL
+	opt.LivenessTimeout = 50 * time.Millisecond
+	prober, _ := mockProber(t, opt)
+
+	start := time.Now()
+	if prober.Probe(context.Background(), Liveness) {
+		t.Errorf("Expected liveness check to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected liveness check to be bounded by its timeout, took %v", elapsed)
 	}
 }
 
-func TestGetOptions(t *testing.T) {
-	options := GetOptions()
+func TestProbeTimeoutBoundsAllChecks(t *testing.T) {
+	// The Ray head answers slowly and the chat completion hangs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/gcs_healthz" {
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte("success"))
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
 
-	if options.VLLMEndpoint != "http://localhost:8081/health" {
-		t.Errorf("Expected default VLLMEndpoint, got %v", options.VLLMEndpoint)
-	}
-	if options.Addr != ":8081" {
-		t.Errorf("Expected default Addr, got %v", options.Addr)
+	opt := mockOptions()
+	opt.Runtime = RuntimeRay
+	opt.Endpoint = server.URL
+	opt.RayEndpoint = server.URL
+	opt.Model = "llama-3-70b"
+	opt.StartupTimeout = 300 * time.Millisecond
+	prober, _ := mockProber(t, opt)
+
+	start := time.Now()
+	if prober.Probe(context.Background(), Startup) {
+		t.Errorf("Expected startup probe to time out")
 	}
-	if options.ReadTimeout != 10*time.Second {
-		t.Errorf("Expected default ReadTimeout, got %v", options.ReadTimeout)
+	if elapsed := time.Since(start); elapsed >= 450*time.Millisecond {
+		t.Errorf("Expected the startup checks to be bounded together by the probe timeout, took %v", elapsed)
 	}
 }
 
-func TestIsServiceAlive(t *testing.T) {
+func TestProbeMetrics(t *testing.T) {
 	opt := mockOptions()
-	server := startMockServer()
+	server := startMockServer("/health")
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
+	opt.Endpoint = server.URL
+	prober, metrics := mockProber(t, opt)
+
+	prober.Probe(context.Background(), Liveness)
+	prober.Probe(context.Background(), Startup)
 
-	if !isServiceAlive(opt) {
-		t.Errorf("Expected service to be alive")
+	if got := testutil.ToFloat64(metrics.probesTotal.WithLabelValues(RuntimeVLLM, "liveness", "failure")); got != 1 {
+		t.Errorf("Expected 1 failed liveness probe, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.probesTotal.WithLabelValues(RuntimeVLLM, "startup", "success")); got != 1 {
+		t.Errorf("Expected 1 successful startup probe, got %v", got)
+	}
+	if got := testutil.CollectAndCount(metrics.checkDuration); got != 2 {
+		t.Errorf("Expected durations of 2 checks, got %v", got)
 	}
 }
 
-func TestIsServiceReady(t *testing.T) {
-	opt := mockOptions()
-	server := startMockServer()
+func TestInferenceCheckModel(t *testing.T) {
+	var model string
+	handler := http.NewServeMux()
+	handler.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": [{"id": "llama-3-70b"}, {"id": "llama-3-8b"}]}`))
+	})
+	handler.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		request := ChatCompletionRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		model = request.Model
+	})
+	server := httptest.NewServer(handler)
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
+	check := inferenceCheck(server.URL, "")
+	if err := check.Run(context.Background(), server.Client()); err != nil {
+		t.Fatalf("Expected inference request to succeed, got %v", err)
+	}
+	if model != "llama-3-70b" {
+		t.Errorf("Expected the first served model, got %q", model)
+	}
 
-	if !isServiceReady(opt) {
-		t.Errorf("Expected service to be ready")
+	check = inferenceCheck(server.URL, "llama-3-8b")
+	if err := check.Run(context.Background(), server.Client()); err != nil {
+		t.Fatalf("Expected inference request to succeed, got %v", err)
+	}
+	if model != "llama-3-8b" {
+		t.Errorf("Expected the configured model, got %q", model)
 	}
 }
 
-func TestIsServiceStarted(t *testing.T) {
-	opt := mockOptions()
-	server := startMockServer()
+func TestGetCheck(t *testing.T) {
+	server := startMockServer("/health_generate")
 	defer server.Close()
 
-	opt.VLLMEndpoint = server.URL
-
-	if !isServiceStarted(opt) {
-		t.Errorf("Expected service to be started")
+	if err := getCheck("health", server.URL+"/health").Run(context.Background(), server.Client()); err != nil {
+		t.Errorf("Expected endpoint to be healthy, got %v", err)
+	}
+	err := getCheck("health_generate", server.URL+"/health_generate").Run(context.Background(), server.Client())
+	if err == nil || !strings.Contains(err.Error(), "non-OK status: 503") {
+		t.Errorf("Expected endpoint to be unhealthy, got %v", err)
 	}
 }
 
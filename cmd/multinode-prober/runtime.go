@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Runtimes supported by the prober
+const (
+	RuntimeSGLang = "sglang"
+	RuntimeVLLM   = "vllm"
+	RuntimeRay    = "ray"
+	RuntimeOpenAI = "openai"
+)
+
+// ChatCompletionRequest represents the request payload for the OpenAI-compatible API
+type ChatCompletionRequest struct {
+	Model     string        `json:"model"`
+	Messages  []ChatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+}
+
+// ChatMessage represents a single message in the chat conversation
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// modelList is the response of the OpenAI-compatible models API
+type modelList struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// Check is a single health check of the serving runtime
+type Check struct {
+	// Name labels the metrics of the check
+	Name string
+	Run  func(ctx context.Context, client *http.Client) error
+}
+
+// Contract is the health contract of a serving runtime: the checks run by each probe, all of which must pass
+type Contract struct {
+	Liveness  []Check
+	Readiness []Check
+	Startup   []Check
+}
+
+// NewContract returns the health contract of the runtime
+func NewContract(opt *Options) (Contract, error) {
+	endpoint := strings.TrimSuffix(opt.Endpoint, "/")
+	health := getCheck("health", endpoint+"/health")
+	inference := inferenceCheck(endpoint, opt.Model)
+
+	switch opt.Runtime {
+	case RuntimeSGLang:
+		// SGLang runs a single token generation on /health_generate, which fails while the workers are not connected
+		healthGenerate := getCheck("health_generate", endpoint+"/health_generate")
+		return Contract{
+			Liveness:  []Check{health},
+			Readiness: []Check{healthGenerate},
+			Startup:   []Check{healthGenerate},
+		}, nil
+	case RuntimeVLLM:
+		return Contract{
+			Liveness:  []Check{health},
+			Readiness: []Check{health},
+			Startup:   []Check{inference},
+		}, nil
+	case RuntimeRay:
+		if opt.RayEndpoint == "" {
+			return Contract{}, fmt.Errorf("the %s runtime requires a Ray endpoint", RuntimeRay)
+		}
+		rayHealth := getCheck("ray_gcs", strings.TrimSuffix(opt.RayEndpoint, "/")+"/api/gcs_healthz")
+		return Contract{
+			Liveness:  []Check{health},
+			Readiness: []Check{health},
+			Startup:   []Check{rayHealth, inference},
+		}, nil
+	case RuntimeOpenAI:
+		models := getCheck("models", endpoint+"/v1/models")
+		return Contract{
+			Liveness:  []Check{models},
+			Readiness: []Check{models},
+			Startup:   []Check{inference},
+		}, nil
+	default:
+		return Contract{}, fmt.Errorf("unsupported runtime %q, supported runtimes are %s, %s, %s and %s",
+			opt.Runtime, RuntimeSGLang, RuntimeVLLM, RuntimeRay, RuntimeOpenAI)
+	}
+}
+
+// getCheck passes when a GET request to the url returns 200 OK
+func getCheck(name, url string) Check {
+	return Check{
+		Name: name,
+		Run: func(ctx context.Context, client *http.Client) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return err
+			}
+			return do(client, req, nil)
+		},
+	}
+}
+
+// inferenceCheck passes when a chat completion succeeds. The first served model is used when no model is set.
+func inferenceCheck(endpoint, model string) Check {
+	return Check{
+		Name: "inference",
+		Run: func(ctx context.Context, client *http.Client) error {
+			if model == "" {
+				var err error
+				if model, err = servedModel(ctx, client, endpoint); err != nil {
+					return err
+				}
+			}
+			payloadBytes, err := json.Marshal(ChatCompletionRequest{
+				Model: model,
+				Messages: []ChatMessage{
+					{Role: "system", Content: "You are a helpful assistant."},
+					{Role: "user", Content: "Hello, how are you?"},
+				},
+				MaxTokens: 16,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to marshal request payload: %w", err)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/v1/chat/completions", bytes.NewReader(payloadBytes))
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			return do(client, req, nil)
+		},
+	}
+}
+
+// servedModel returns the first model listed by the OpenAI-compatible models API
+func servedModel(ctx context.Context, client *http.Client, endpoint string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v1/models", nil)
+	if err != nil {
+		return "", err
+	}
+	models := &modelList{}
+	if err := do(client, req, models); err != nil {
+		return "", err
+	}
+	if len(models.Data) == 0 {
+		return "", fmt.Errorf("no models served by %s", endpoint)
+	}
+	return models.Data[0].ID, nil
+}
+
+// do sends the request and decodes the response into out, if set. Responses other than 200 OK are errors.
+func do(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error reaching %s: %w", req.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s returned non-OK status: %d", req.Method, req.URL, resp.StatusCode)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", req.URL, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRuntimeContracts(t *testing.T) {
+	tests := []struct {
+		name      string
+		runtime   string
+		failing   []string
+		liveness  bool
+		readiness bool
+		startup   bool
+	}{
+		{
+			name:      "sglang is healthy",
+			runtime:   RuntimeSGLang,
+			liveness:  true,
+			readiness: true,
+			startup:   true,
+		},
+		{
+			name:      "sglang is not ready while it cannot generate",
+			runtime:   RuntimeSGLang,
+			failing:   []string{"/health_generate"},
+			liveness:  true,
+			readiness: false,
+			startup:   false,
+		},
+		{
+			name:      "sglang does not depend on Ray",
+			runtime:   RuntimeSGLang,
+			failing:   []string{"/api/gcs_healthz", "/v1/chat/completions"},
+			liveness:  true,
+			readiness: true,
+			startup:   true,
+		},
+		{
+			name:      "vllm has not started while inference fails",
+			runtime:   RuntimeVLLM,
+			failing:   []string{"/v1/chat/completions"},
+			liveness:  true,
+			readiness: true,
+			startup:   false,
+		},
+		{
+			name:      "ray has not started while the head is unhealthy",
+			runtime:   RuntimeRay,
+			failing:   []string{"/api/gcs_healthz"},
+			liveness:  true,
+			readiness: true,
+			startup:   false,
+		},
+		{
+			name:      "ray is healthy",
+			runtime:   RuntimeRay,
+			liveness:  true,
+			readiness: true,
+			startup:   true,
+		},
+		{
+			name:      "openai checks the models API",
+			runtime:   RuntimeOpenAI,
+			failing:   []string{"/health", "/health_generate"},
+			liveness:  true,
+			readiness: true,
+			startup:   true,
+		},
+		{
+			name:      "openai is not alive while the models API fails",
+			runtime:   RuntimeOpenAI,
+			failing:   []string{"/v1/models"},
+			liveness:  false,
+			readiness: false,
+			startup:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startMockServer(tt.failing...)
+			defer server.Close()
+
+			opt := mockOptions()
+			opt.Runtime = tt.runtime
+			opt.Endpoint = server.URL
+			opt.RayEndpoint = server.URL
+			prober, _ := mockProber(t, opt)
+
+			for probe, expected := range map[Probe]bool{Liveness: tt.liveness, Readiness: tt.readiness, Startup: tt.startup} {
+				if got := prober.Probe(context.Background(), probe); got != expected {
+					t.Errorf("Expected %s probe to return %v, got %v", probe, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewContractErrors(t *testing.T) {
+	opt := mockOptions()
+	opt.Runtime = "tgi"
+	if _, err := NewContract(opt); err == nil || !strings.Contains(err.Error(), `unsupported runtime "tgi"`) {
+		t.Errorf("Expected unsupported runtime error, got %v", err)
+	}
+
+	opt.Runtime = RuntimeRay
+	if _, err := NewContract(opt); err == nil || !strings.Contains(err.Error(), "requires a Ray endpoint") {
+		t.Errorf("Expected missing Ray endpoint error, got %v", err)
+	}
+}
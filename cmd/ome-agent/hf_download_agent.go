@@ -11,6 +11,7 @@ import (
 	"github.com/sgl-project/ome/pkg/afero"
 	"github.com/sgl-project/ome/pkg/hfutil/hub"
 	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/xet"
 )
 
 // hfDownloadAgentParams represents the parameters for dependency injection
@@ -56,7 +57,8 @@ func (h *HFDownloadAgent) FxModules() []fx.Option {
 		logging.Module,
 		logging.ModuleNamed("another_log"),
 		logging.ModuleNamed("hub_logger"),
-		hub.Module, // Hub module handles all configuration via viper
+		xet.HubDownloaderModule, // Downloads the files of Xet repositories by chunks
+		hub.Module,              // Hub module handles all configuration via viper
 		fx.Invoke(func(params hfDownloadAgentParams, hubClient *hub.HubClient, v *viper.Viper) {
 			h.hubClient = hubClient
 			h.viper = v
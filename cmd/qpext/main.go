@@ -2,13 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -27,6 +29,7 @@ const (
 	// aggregate scraping env vars from ome/pkg/constants
 	ContainerPrometheusMetricsPortEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PORT"
 	ContainerPrometheusMetricsPathEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PATH"
+	ContainerPrometheusMetricsTargetsEnvVarKey        = "CONTAINER_PROMETHEUS_METRICS_TARGETS"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
 	QueueProxyMetricsPort                             = "9091"
 	DefaultQueueProxyMetricsPath                      = "/metrics"
@@ -35,10 +38,13 @@ const (
 
 type ScrapeConfigurations struct {
 	logger         *zap.Logger
+	metrics        *scrapeMetrics
 	QueueProxyPath string `json:"path"`
 	QueueProxyPort string `json:"port"`
 	AppPort        string
 	AppPath        string
+	// Targets are scraped instead of the app port and path when set
+	Targets []ScrapeTarget
 }
 
 type Logger = zap.Logger
@@ -81,9 +87,16 @@ func getServerlessLabelVals() []string {
 
 // addServerlessLabels adds the serverless labels to the prometheus metrics that are imported in from the application.
 // this is done so that the prometheus metrics (both queue-proxy's and main-container's) can be easily queried together.
+// A label of the application with the same name is kept as exported_<name>, like Prometheus does.
 func addServerlessLabels(metric *ioprometheusclient.Metric, labelKeys []string, labelValues []string) *ioprometheusclient.Metric {
 	// LabelKeys, EnvVars, and LabelVals are []string to enforce setting them in order (helps with testing)
 	for idx, name := range labelKeys {
+		for _, label := range metric.Label {
+			if label.GetName() == name {
+				exported := "exported_" + name
+				label.Name = &exported
+			}
+		}
 		labelName := name
 		labelValue := labelValues[idx]
 		newLabelPair := &ioprometheusclient.LabelPair{
@@ -95,84 +108,61 @@ func addServerlessLabels(metric *ioprometheusclient.Metric, labelKeys []string,
 	return metric
 }
 
-// sanitizeMetrics attempts to convert UNTYPED metrics into either a gauge or counter.
-// counter metric names with _created and gauge metric names with _total are converted due to irregularities
-// observed in the conversion of these metrics from text to metric families.
-func sanitizeMetrics(mf *ioprometheusclient.MetricFamily) *ioprometheusclient.MetricFamily {
-	if strings.HasSuffix(*mf.Name, "_created") {
-		counter := ioprometheusclient.MetricType_COUNTER
-		var newMetric []*ioprometheusclient.Metric
-		for _, metric := range mf.Metric {
-			newMetric = append(newMetric, &ioprometheusclient.Metric{
-				Label: metric.Label,
-				Counter: &ioprometheusclient.Counter{
-					Value: metric.Untyped.Value,
-				},
-				TimestampMs: metric.TimestampMs,
-			})
-		}
-		return &ioprometheusclient.MetricFamily{
-			Name:   mf.Name,
-			Help:   mf.Help,
-			Type:   &counter,
-			Metric: newMetric,
-		}
+// exposition returns the exposition format of a scrape from its content type. Anything but OpenMetrics and the
+// delimited protobuf format is read as the text format.
+func exposition(contentType string) expfmt.FormatType {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == expfmt.OpenMetricsType {
+		return expfmt.TypeOpenMetrics
+	}
+	if expfmt.Format(contentType).FormatType() == expfmt.TypeProtoDelim {
+		return expfmt.TypeProtoDelim
 	}
+	return expfmt.TypeTextPlain
+}
 
-	if strings.HasSuffix(*mf.Name, "_total") {
-		gauge := ioprometheusclient.MetricType_GAUGE
-		var newMetric []*ioprometheusclient.Metric
-		for _, metric := range mf.Metric {
-			newMetric = append(newMetric, &ioprometheusclient.Metric{
-				Label: metric.Label,
-				Gauge: &ioprometheusclient.Gauge{
-					Value: metric.Untyped.Value,
-				},
-				TimestampMs: metric.TimestampMs,
-			})
+// decodeMetricFamilies decodes the metric families of a scrape in the format of its content type, keeping the types,
+// exemplars and native histograms the format carries. The families decoded before an error are returned with it.
+func decodeMetricFamilies(r io.Reader, contentType string) ([]*ioprometheusclient.MetricFamily, error) {
+	switch exposition(contentType) {
+	case expfmt.TypeOpenMetrics:
+		return parseOpenMetrics(r)
+	case expfmt.TypeProtoDelim:
+		var mfs []*ioprometheusclient.MetricFamily
+		decoder := expfmt.NewDecoder(r, expfmt.NewFormat(expfmt.TypeProtoDelim))
+		for {
+			mf := &ioprometheusclient.MetricFamily{}
+			if err := decoder.Decode(mf); err != nil {
+				if errors.Is(err, io.EOF) {
+					return mfs, nil
+				}
+				return mfs, err
+			}
+			mfs = append(mfs, mf)
 		}
-		return &ioprometheusclient.MetricFamily{
-			Name:   mf.Name,
-			Help:   mf.Help,
-			Type:   &gauge,
-			Metric: newMetric,
+	default:
+		var parser expfmt.TextParser
+		parsed, err := parser.TextToMetricFamilies(r)
+		mfs := make([]*ioprometheusclient.MetricFamily, 0, len(parsed))
+		for _, mf := range parsed {
+			mfs = append(mfs, mf)
 		}
+		sort.Slice(mfs, func(i, j int) bool { return mfs[i].GetName() < mfs[j].GetName() })
+		return mfs, err
 	}
-	return nil
 }
 
-func scrapeAndWriteAppMetrics(mfs map[string]*ioprometheusclient.MetricFamily, w io.Writer, format expfmt.Format, logger *zap.Logger) error {
+// writeMetricFamilies encodes the metric families, skipping the ones that cannot be encoded. Gauge histograms only
+// have a representation in the protobuf formats.
+func writeMetricFamilies(mfs []*ioprometheusclient.MetricFamily, enc expfmt.Encoder, format expfmt.Format, logger *zap.Logger) error {
 	var errs error
-	labelValues := getServerlessLabelVals()
-
-	for _, metricFamily := range mfs {
-		var newMetric []*ioprometheusclient.Metric
-		var mf *ioprometheusclient.MetricFamily
-
-		// Some metrics from main-container are UNTYPED. This can cause errors in the promtheus scraper.
-		// These metrics seem to be either gauges or counters. For now, avoid these errors by sanitizing the metrics
-		// based on the metric name. If the metric can't be converted, we log an error. In the future, we should
-		// figure out the root cause of this. (Possibly due to open metrics being read in as text and converted to MetricFamily)
-		if *metricFamily.Type == ioprometheusclient.MetricType_UNTYPED {
-			mf = sanitizeMetrics(metricFamily)
-			if mf == nil {
-				// if the metric fails to convert, discard it and keep exporting the rest of the metrics
-				logger.Error("failed to parse untyped metric", zap.Any("metric name", metricFamily.Name))
-				continue
-			}
-		} else {
-			mf = metricFamily
+	for _, mf := range mfs {
+		if mf.GetType() == ioprometheusclient.MetricType_GAUGE_HISTOGRAM &&
+			(format.FormatType() == expfmt.TypeTextPlain || format.FormatType() == expfmt.TypeOpenMetrics) {
+			logger.Warn("skipping gauge histogram that the format cannot represent", zap.String("metric name", mf.GetName()),
+				zap.String("format", string(format)))
+			continue
 		}
-
-		// create a new list of Metric with the added serverless labels to each individual Metric
-		for _, metric := range mf.Metric {
-			m := addServerlessLabels(metric, LabelKeys, labelValues)
-			newMetric = append(newMetric, m)
-		}
-		mf.Metric = newMetric
-
-		_, err := expfmt.MetricFamilyToText(w, mf)
-		if err != nil {
+		if err := enc.Encode(mf); err != nil {
 			logger.Error("multierr", zap.Error(err))
 			errs = multierror.Append(errs, err)
 		}
@@ -184,16 +174,24 @@ func scrapeAndWriteAppMetrics(mfs map[string]*ioprometheusclient.MetricFamily, w
 // This will attempt to mimic some of Prometheus functionality by passing some headers through
 // scrape returns the scraped metrics reader as well as the response's "Content-Type" header to determine the metrics format
 func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser, context.CancelFunc, string, error) {
+	return scrapeWithTimeout(url, header, 0, logger)
+}
+
+// scrapeWithTimeout scrapes like scrape, bounded by the timeout when it is shorter than the Prometheus scrape timeout
+func scrapeWithTimeout(url string, header http.Header, timeout time.Duration, logger *zap.Logger) (io.ReadCloser, context.CancelFunc, string, error) {
 	var cancel context.CancelFunc
 	ctx := context.Background()
 	if timeoutString := header.Get(prometheusTimeoutHeader); timeoutString != "" {
-		timeout, err := getHeaderTimeout(timeoutString)
+		headerTimeout, err := getHeaderTimeout(timeoutString)
 		if err != nil {
 			logger.Error("Failed to parse timeout header", zap.Error(err), zap.String("timeout", timeoutString))
-		} else {
-			ctx, cancel = context.WithTimeout(ctx, timeout)
+		} else if timeout <= 0 || headerTimeout < timeout {
+			timeout = headerTimeout
 		}
 	}
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, cancel, "", err
@@ -210,7 +208,9 @@ func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser,
 	}
 	if resp.StatusCode != http.StatusOK {
 		if err := resp.Body.Close(); err != nil {
-			cancel()
+			if cancel != nil {
+				cancel()
+			}
 			return nil, nil, "", err
 		}
 		return nil, cancel, "", fmt.Errorf("error scraping %s, status code: %v", url, resp.StatusCode)
@@ -222,6 +222,7 @@ func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser,
 func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string, appPath string) *ScrapeConfigurations {
 	return &ScrapeConfigurations{
 		logger:         logger,
+		metrics:        newScrapeMetrics(),
 		QueueProxyPath: DefaultQueueProxyMetricsPath,
 		QueueProxyPort: queueProxyPort,
 		AppPort:        appPort,
@@ -229,10 +230,22 @@ func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string,
 	}
 }
 
+// targets returns the application targets to scrape, the app port and path unless targets are set
+func (sc *ScrapeConfigurations) targets() []ScrapeTarget {
+	if len(sc.Targets) > 0 {
+		return sc.Targets
+	}
+	if sc.AppPort != "" {
+		return []ScrapeTarget{{Name: "app", Port: sc.AppPort, Path: sc.AppPath}}
+	}
+	return nil
+}
+
 func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Request) {
 	var err error
-	var queueProxy, application io.ReadCloser
-	var queueProxyCancel, appCancel context.CancelFunc
+	var queueProxy io.ReadCloser
+	var queueProxyCancel context.CancelFunc
+	var queueProxyContentType string
 
 	defer func() {
 		if queueProxy != nil {
@@ -241,59 +254,68 @@ func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Reque
 				sc.logger.Error("queue proxy connection is not closed", zap.Error(err))
 			}
 		}
-		if application != nil {
-			err = application.Close()
-			if err != nil {
-				sc.logger.Error("application connection is not closed", zap.Error(err))
-			}
-		}
 		if queueProxyCancel != nil {
 			queueProxyCancel()
 		}
-		if appCancel != nil {
-			appCancel()
-		}
 	}()
 
 	// Gather all the metrics we will merge
 	if sc.QueueProxyPort != "" {
 		queueProxyURL := getURL(sc.QueueProxyPort, sc.QueueProxyPath)
-		if queueProxy, queueProxyCancel, _, err = scrape(queueProxyURL, r.Header, sc.logger); err != nil {
+		if queueProxy, queueProxyCancel, queueProxyContentType, err = scrape(queueProxyURL, r.Header, sc.logger); err != nil {
 			sc.logger.Error("failed scraping queue proxy metrics", zap.Error(err))
 		}
 	}
 
-	// Scrape app metrics if defined
-	if sc.AppPort != "" {
-		containerURL := getURL(sc.AppPort, sc.AppPath)
-		var contentType string
-		if application, appCancel, contentType, err = scrape(containerURL, r.Header, sc.logger); err != nil {
-			sc.logger.Error("failed scraping application metrics", zap.Error(err), zap.String("content type", contentType))
+	// Scrape the application targets in parallel, keeping the metrics of the ones that succeed
+	results := sc.scrapeTargets(sc.targets(), r.Header)
+	for _, result := range results {
+		if result.err != nil {
+			sc.logger.Error("failed scraping application metrics", zap.String("target", result.target.Name), zap.Error(result.err))
 		}
 	}
 
-	// Since we convert the scraped metrics to text, set the format as text even if
-	// the content type is originally open metrics.
-	format := expfmt.NewFormat(expfmt.TypeTextPlain)
+	// Answer in the format Prometheus asked for, so exemplars, created timestamps and native histograms of the
+	// application survive when Prometheus accepts OpenMetrics or protobuf
+	format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
 	w.Header().Set("Content-Type", string(format))
+	enc := expfmt.NewEncoder(w, format, expfmt.WithCreatedLines(), expfmt.WithUnit())
 
 	if queueProxy != nil {
-		_, err = io.Copy(w, queueProxy)
-		if err != nil {
-			sc.logger.Error("failed to scraping and writing queue proxy metrics", zap.Error(err))
+		// The queue-proxy metrics are copied as is when they are already in a format that can be concatenated
+		if outputType := format.FormatType(); exposition(queueProxyContentType) == outputType &&
+			(outputType == expfmt.TypeTextPlain || outputType == expfmt.TypeProtoDelim) {
+			if _, err = io.Copy(w, queueProxy); err != nil {
+				sc.logger.Error("failed to scraping and writing queue proxy metrics", zap.Error(err))
+			}
+		} else {
+			mfs, err := decodeMetricFamilies(queueProxy, queueProxyContentType)
+			if err != nil {
+				sc.logger.Error("error decoding queue proxy metrics", zap.Error(err))
+			}
+			if err = writeMetricFamilies(mfs, enc, format, sc.logger); err != nil {
+				sc.logger.Error("failed writing queue proxy metrics", zap.Error(err))
+			}
 		}
 	}
 
-	if application != nil {
-		var err error
-		var parser expfmt.TextParser
-		var mfs map[string]*ioprometheusclient.MetricFamily
-		mfs, err = parser.TextToMetricFamilies(application)
+	if err = writeMetricFamilies(mergeMetricFamilies(results, sc.logger), enc, format, sc.logger); err != nil {
+		sc.logger.Error("failed scraping and writing metrics", zap.Error(err))
+	}
+
+	if sc.metrics != nil {
+		selfMetrics, err := sc.metrics.registry.Gather()
 		if err != nil {
-			sc.logger.Error("error converting text to metric families", zap.Error(err), zap.Any("metric families return value", mfs))
+			sc.logger.Error("failed gathering scrape metrics", zap.Error(err))
 		}
-		if err = scrapeAndWriteAppMetrics(mfs, w, format, sc.logger); err != nil {
-			sc.logger.Error("failed scraping and writing metrics", zap.Error(err))
+		if err = writeMetricFamilies(selfMetrics, enc, format, sc.logger); err != nil {
+			sc.logger.Error("failed writing scrape metrics", zap.Error(err))
+		}
+	}
+
+	if closer, ok := enc.(expfmt.Closer); ok {
+		if err = closer.Close(); err != nil {
+			sc.logger.Error("failed to finalize metrics", zap.Error(err))
 		}
 	}
 }
@@ -303,14 +325,21 @@ func main() {
 	mux := http.NewServeMux()
 	ctx, cancel := context.WithCancel(context.Background())
 	aggregateMetricsPort := os.Getenv(QueueProxyAggregatePrometheusMetricsPortEnvVarKey)
+	var err error
+	var l net.Listener
 	sc := NewScrapeConfigs(
 		zapLogger,
 		QueueProxyMetricsPort,
 		os.Getenv(ContainerPrometheusMetricsPortEnvVarKey),
 		os.Getenv(ContainerPrometheusMetricsPathEnvVarKey),
 	)
+	if targets := os.Getenv(ContainerPrometheusMetricsTargetsEnvVarKey); targets != "" {
+		if sc.Targets, err = parseScrapeTargets(targets); err != nil {
+			zapLogger.Error("failed to parse scrape targets, scraping the app port", zap.Error(err))
+		}
+	}
 	mux.HandleFunc(`/metrics`, sc.handleStats)
-	l, err := net.Listen("tcp", fmt.Sprintf(":%v", aggregateMetricsPort))
+	l, err = net.Listen("tcp", fmt.Sprintf(":%v", aggregateMetricsPort))
 	if err != nil {
 		zapLogger.Error("error listening on status port", zap.Error(err))
 		return
@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"reflect"
 	"strings"
 	"testing"
 
@@ -136,98 +135,6 @@ func TestScrapeErr(t *testing.T) {
 	assert.Nil(t, queueProxy)
 }
 
-func TestSanitizeMetrics(t *testing.T) {
-	testCreated := "testing_created"
-	testTotal := "testing_total"
-	testNoConvert := "random_metric"
-	untyped := ioprometheusclient.MetricType_UNTYPED
-	counter := ioprometheusclient.MetricType_COUNTER
-	gauge := ioprometheusclient.MetricType_GAUGE
-	value := float64(4.5)
-
-	tests := []struct {
-		name     string
-		input    *ioprometheusclient.MetricFamily
-		expected *ioprometheusclient.MetricFamily
-	}{
-		{
-			name: "test sanitize counter",
-			input: &ioprometheusclient.MetricFamily{
-				Name: &testCreated,
-				Help: nil,
-				Type: &untyped,
-				Metric: []*ioprometheusclient.Metric{
-					{
-						Untyped: &ioprometheusclient.Untyped{
-							Value: &value,
-						},
-					},
-				},
-			},
-			expected: &ioprometheusclient.MetricFamily{
-				Name: &testCreated,
-				Help: nil,
-				Type: &counter,
-				Metric: []*ioprometheusclient.Metric{
-					{
-						Counter: &ioprometheusclient.Counter{
-							Value: &value,
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "test sanitize gauge",
-			input: &ioprometheusclient.MetricFamily{
-				Name: &testTotal,
-				Help: nil,
-				Type: &untyped,
-				Metric: []*ioprometheusclient.Metric{
-					{
-						Untyped: &ioprometheusclient.Untyped{
-							Value: &value,
-						},
-					},
-				},
-			},
-			expected: &ioprometheusclient.MetricFamily{
-				Name: &testTotal,
-				Help: nil,
-				Type: &gauge,
-				Metric: []*ioprometheusclient.Metric{
-					{
-						Gauge: &ioprometheusclient.Gauge{
-							Value: &value,
-						},
-					},
-				},
-			},
-		},
-		{
-			name: "test not able to convert",
-			input: &ioprometheusclient.MetricFamily{
-				Name: &testNoConvert,
-				Help: nil,
-				Type: &untyped,
-				Metric: []*ioprometheusclient.Metric{
-					{
-						Untyped: &ioprometheusclient.Untyped{
-							Value: &value,
-						},
-					},
-				},
-			},
-			expected: nil,
-		},
-	}
-
-	for _, test := range tests {
-		actual := sanitizeMetrics(test.input)
-		assert.True(t, reflect.DeepEqual(test.expected, actual))
-	}
-}
-
 func TestAppMetrics(t *testing.T) {
 	metricExample := `# HELP request_preprocess_seconds pre-process request latency
 # TYPE request_preprocess_seconds histogram
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// omFamily is a metric family being parsed from the OpenMetrics exposition
+type omFamily struct {
+	name    string
+	omType  string
+	mf      *ioprometheusclient.MetricFamily
+	metrics map[string]*ioprometheusclient.Metric
+}
+
+// omSample is a sample line of the OpenMetrics exposition
+type omSample struct {
+	name        string
+	labels      []*ioprometheusclient.LabelPair
+	value       float64
+	timestampMs *int64
+	exemplar    *ioprometheusclient.Exemplar
+}
+
+// parseOpenMetrics parses the OpenMetrics text exposition into metric families, in the order of the exposition.
+// Counters are named with their _total suffix, info metrics are gauges named with their _info suffix and state sets are
+// gauges, like the Prometheus client libraries expose them. Created timestamps and exemplars are kept. The families
+// parsed before an error are returned with it.
+func parseOpenMetrics(r io.Reader) ([]*ioprometheusclient.MetricFamily, error) {
+	var families []*omFamily
+	byName := map[string]*omFamily{}
+	var current *omFamily
+
+	result := func() []*ioprometheusclient.MetricFamily {
+		mfs := make([]*ioprometheusclient.MetricFamily, 0, len(families))
+		for _, family := range families {
+			if len(family.mf.Metric) > 0 {
+				mfs = append(mfs, family.mf)
+			}
+		}
+		return mfs
+	}
+	family := func(name string) *omFamily {
+		if f, ok := byName[name]; ok {
+			return f
+		}
+		f := &omFamily{name: name, omType: "unknown", mf: &ioprometheusclient.MetricFamily{Name: &name}, metrics: map[string]*ioprometheusclient.Metric{}}
+		f.setType("unknown")
+		families = append(families, f)
+		byName[name] = f
+		return f
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if line == "# EOF" {
+			return result(), nil
+		}
+		if strings.HasPrefix(line, "#") {
+			fields := strings.SplitN(line, " ", 4)
+			if len(fields) < 4 || fields[0] != "#" {
+				continue
+			}
+			f := family(fields[2])
+			switch fields[1] {
+			case "TYPE":
+				if err := f.setType(fields[3]); err != nil {
+					return result(), fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+			case "HELP":
+				help := unescapeOpenMetrics(fields[3])
+				f.mf.Help = &help
+			case "UNIT":
+				unit := fields[3]
+				f.mf.Unit = &unit
+			}
+			current = f
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		sample, err := parseOpenMetricsSample(line)
+		if err != nil {
+			return result(), fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if current == nil || !current.owns(sample.name) {
+			// Samples without metadata belong to a family of unknown type
+			current = family(sample.name)
+		}
+		if err := current.add(sample); err != nil {
+			return result(), fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result(), err
+	}
+	return result(), fmt.Errorf("missing # EOF")
+}
+
+// setType sets the type of the family, and names it like the Prometheus client libraries do
+func (f *omFamily) setType(omType string) error {
+	name := f.name
+	var metricType ioprometheusclient.MetricType
+	switch omType {
+	case "counter":
+		metricType = ioprometheusclient.MetricType_COUNTER
+		name += "_total"
+	case "gauge", "stateset":
+		metricType = ioprometheusclient.MetricType_GAUGE
+	case "info":
+		metricType = ioprometheusclient.MetricType_GAUGE
+		name += "_info"
+	case "histogram":
+		metricType = ioprometheusclient.MetricType_HISTOGRAM
+	case "gaugehistogram":
+		metricType = ioprometheusclient.MetricType_GAUGE_HISTOGRAM
+	case "summary":
+		metricType = ioprometheusclient.MetricType_SUMMARY
+	case "unknown":
+		metricType = ioprometheusclient.MetricType_UNTYPED
+	default:
+		return fmt.Errorf("unknown metric type %q of %s", omType, f.name)
+	}
+	f.omType = omType
+	f.mf.Name = &name
+	f.mf.Type = &metricType
+	return nil
+}
+
+// owns reports whether a sample name belongs to the family
+func (f *omFamily) owns(name string) bool {
+	suffix, ok := strings.CutPrefix(name, f.name)
+	if !ok {
+		return false
+	}
+	switch f.omType {
+	case "counter":
+		return suffix == "_total" || suffix == "_created"
+	case "info":
+		return suffix == "_info"
+	case "histogram":
+		return suffix == "_bucket" || suffix == "_count" || suffix == "_sum" || suffix == "_created"
+	case "gaugehistogram":
+		return suffix == "_bucket" || suffix == "_gcount" || suffix == "_gsum"
+	case "summary":
+		return suffix == "" || suffix == "_count" || suffix == "_sum" || suffix == "_created"
+	default:
+		return suffix == ""
+	}
+}
+
+// add adds a sample to the metric of its label set, which excludes the le and quantile labels
+func (f *omFamily) add(sample omSample) error {
+	suffix := strings.TrimPrefix(sample.name, f.name)
+	var labels []*ioprometheusclient.LabelPair
+	var bound *float64
+	var key strings.Builder
+	for _, label := range sample.labels {
+		if (f.omType == "histogram" || f.omType == "gaugehistogram") && suffix == "_bucket" && label.GetName() == "le" ||
+			f.omType == "summary" && suffix == "" && label.GetName() == "quantile" {
+			value, err := strconv.ParseFloat(label.GetValue(), 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s label %q of %s", label.GetName(), label.GetValue(), sample.name)
+			}
+			bound = &value
+			continue
+		}
+		labels = append(labels, label)
+		key.WriteString(label.GetName())
+		key.WriteByte(0)
+		key.WriteString(label.GetValue())
+		key.WriteByte(0)
+	}
+
+	metric, ok := f.metrics[key.String()]
+	if !ok {
+		metric = &ioprometheusclient.Metric{Label: labels}
+		f.metrics[key.String()] = metric
+		f.mf.Metric = append(f.mf.Metric, metric)
+	}
+	if suffix != "_created" && sample.timestampMs != nil {
+		metric.TimestampMs = sample.timestampMs
+	}
+	var created *timestamppb.Timestamp
+	if suffix == "_created" {
+		created = timestamppb.New(timeFromSeconds(sample.value))
+	}
+
+	switch f.omType {
+	case "counter":
+		if metric.Counter == nil {
+			metric.Counter = &ioprometheusclient.Counter{}
+		}
+		if suffix == "_created" {
+			metric.Counter.CreatedTimestamp = created
+			return nil
+		}
+		metric.Counter.Value = &sample.value
+		metric.Counter.Exemplar = sample.exemplar
+	case "histogram", "gaugehistogram":
+		if metric.Histogram == nil {
+			metric.Histogram = &ioprometheusclient.Histogram{}
+		}
+		switch suffix {
+		case "_bucket":
+			if bound == nil {
+				return fmt.Errorf("missing le label of %s", sample.name)
+			}
+			count := uint64(sample.value)
+			metric.Histogram.Bucket = append(metric.Histogram.Bucket, &ioprometheusclient.Bucket{
+				UpperBound:      bound,
+				CumulativeCount: &count,
+				Exemplar:        sample.exemplar,
+			})
+		case "_count", "_gcount":
+			count := uint64(sample.value)
+			metric.Histogram.SampleCount = &count
+		case "_sum", "_gsum":
+			metric.Histogram.SampleSum = &sample.value
+		case "_created":
+			metric.Histogram.CreatedTimestamp = created
+		}
+	case "summary":
+		if metric.Summary == nil {
+			metric.Summary = &ioprometheusclient.Summary{}
+		}
+		switch suffix {
+		case "":
+			if bound == nil {
+				return fmt.Errorf("missing quantile label of %s", sample.name)
+			}
+			metric.Summary.Quantile = append(metric.Summary.Quantile, &ioprometheusclient.Quantile{Quantile: bound, Value: &sample.value})
+		case "_count":
+			count := uint64(sample.value)
+			metric.Summary.SampleCount = &count
+		case "_sum":
+			metric.Summary.SampleSum = &sample.value
+		case "_created":
+			metric.Summary.CreatedTimestamp = created
+		}
+	case "unknown":
+		metric.Untyped = &ioprometheusclient.Untyped{Value: &sample.value}
+	default:
+		metric.Gauge = &ioprometheusclient.Gauge{Value: &sample.value}
+	}
+	return nil
+}
+
+// parseOpenMetricsSample parses a sample line: name, optional labels, value, optional timestamp and optional exemplar
+func parseOpenMetricsSample(line string) (omSample, error) {
+	var sample omSample
+	rest := line
+	end := strings.IndexAny(rest, "{ ")
+	if end <= 0 {
+		return sample, fmt.Errorf("invalid sample %q", line)
+	}
+	sample.name, rest = rest[:end], rest[end:]
+
+	var err error
+	if strings.HasPrefix(rest, "{") {
+		if sample.labels, rest, err = parseOpenMetricsLabels(rest); err != nil {
+			return sample, fmt.Errorf("invalid labels of %s: %w", sample.name, err)
+		}
+	}
+
+	exemplar := ""
+	if i := strings.Index(rest, " # "); i >= 0 {
+		rest, exemplar = rest[:i], rest[i+3:]
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("invalid value of %s: %q", sample.name, rest)
+	}
+	if sample.value, err = parseOpenMetricsFloat(fields[0]); err != nil {
+		return sample, fmt.Errorf("invalid value of %s: %w", sample.name, err)
+	}
+	if len(fields) == 2 {
+		seconds, err := parseOpenMetricsFloat(fields[1])
+		if err != nil {
+			return sample, fmt.Errorf("invalid timestamp of %s: %w", sample.name, err)
+		}
+		timestampMs := int64(math.Round(seconds * 1000))
+		sample.timestampMs = &timestampMs
+	}
+
+	if exemplar != "" {
+		if sample.exemplar, err = parseOpenMetricsExemplar(exemplar); err != nil {
+			return sample, fmt.Errorf("invalid exemplar of %s: %w", sample.name, err)
+		}
+	}
+	return sample, nil
+}
+
+// parseOpenMetricsExemplar parses an exemplar: labels, value and optional timestamp
+func parseOpenMetricsExemplar(exemplar string) (*ioprometheusclient.Exemplar, error) {
+	labels, rest, err := parseOpenMetricsLabels(exemplar)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid value %q", rest)
+	}
+	value, err := parseOpenMetricsFloat(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	result := &ioprometheusclient.Exemplar{Label: labels, Value: &value}
+	if len(fields) == 2 {
+		seconds, err := parseOpenMetricsFloat(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		result.Timestamp = timestamppb.New(timeFromSeconds(seconds))
+	}
+	return result, nil
+}
+
+// parseOpenMetricsLabels parses a label set starting with { and returns the rest of the line after the closing }
+func parseOpenMetricsLabels(s string) ([]*ioprometheusclient.LabelPair, string, error) {
+	if !strings.HasPrefix(s, "{") {
+		return nil, s, fmt.Errorf("expected { in %q", s)
+	}
+	var labels []*ioprometheusclient.LabelPair
+	i := 1
+	for {
+		for i < len(s) && s[i] == ',' {
+			i++
+		}
+		if i >= len(s) {
+			return nil, "", fmt.Errorf("unterminated label set")
+		}
+		if s[i] == '}' {
+			return labels, s[i+1:], nil
+		}
+		eq := strings.Index(s[i:], "=\"")
+		if eq <= 0 {
+			return nil, "", fmt.Errorf("invalid label in %q", s[i:])
+		}
+		name := s[i : i+eq]
+		i += eq + 2
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, "", fmt.Errorf("unterminated value of label %s", name)
+		}
+		i++
+		labelValue := value.String()
+		labels = append(labels, &ioprometheusclient.LabelPair{Name: &name, Value: &labelValue})
+	}
+}
+
+func parseOpenMetricsFloat(s string) (float64, error) {
+	switch s {
+	case "+Inf":
+		return math.Inf(+1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// timeFromSeconds converts a timestamp in seconds, as OpenMetrics exposes them, to a time
+func timeFromSeconds(seconds float64) time.Time {
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(math.Round(fraction*1e9)))
+}
+
+// unescapeOpenMetrics unescapes a HELP text
+func unescapeOpenMetrics(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\"`, `"`).Replace(s)
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+const (
+	openMetricsAccept = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5"
+	protobufAccept    = "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited"
+)
+
+func TestParseOpenMetrics(t *testing.T) {
+	mfs, err := parseOpenMetrics(strings.NewReader(`# HELP requests Requests.
+# TYPE requests counter
+requests_total{path="/a\"b"} 7 # {trace_id="abc"} 1.5 1729469011.5
+requests_created{path="/a\"b"} 1729468800.25
+# TYPE latency_seconds gaugehistogram
+latency_seconds_bucket{le="1"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_gcount 3
+latency_seconds_gsum 2.5
+# TYPE rpc summary
+rpc{quantile="0.5"} 0.2
+rpc_sum 10
+rpc_count 40
+# TYPE mode stateset
+mode{mode="prefill"} 1
+mode{mode="decode"} 0
+untyped_metric NaN 1729469011
+# EOF
+`))
+	require.NoError(t, err)
+	require.Len(t, mfs, 5)
+
+	requests := mfs[0]
+	assert.Equal(t, "requests_total", requests.GetName())
+	assert.Equal(t, ioprometheusclient.MetricType_COUNTER, requests.GetType())
+	assert.Equal(t, `/a"b`, requests.Metric[0].Label[0].GetValue())
+	assert.Equal(t, float64(7), requests.Metric[0].Counter.GetValue())
+	assert.Equal(t, int64(1729468800), requests.Metric[0].Counter.CreatedTimestamp.GetSeconds())
+	assert.Equal(t, int32(250000000), requests.Metric[0].Counter.CreatedTimestamp.GetNanos())
+	assert.Equal(t, 1.5, requests.Metric[0].Counter.Exemplar.GetValue())
+	assert.Equal(t, "abc", requests.Metric[0].Counter.Exemplar.Label[0].GetValue())
+
+	latency := mfs[1]
+	assert.Equal(t, ioprometheusclient.MetricType_GAUGE_HISTOGRAM, latency.GetType())
+	require.Len(t, latency.Metric, 1)
+	assert.Len(t, latency.Metric[0].Histogram.Bucket, 2)
+	assert.Equal(t, uint64(3), latency.Metric[0].Histogram.GetSampleCount())
+
+	rpc := mfs[2]
+	assert.Equal(t, ioprometheusclient.MetricType_SUMMARY, rpc.GetType())
+	require.Len(t, rpc.Metric, 1)
+	assert.Equal(t, 0.5, rpc.Metric[0].Summary.Quantile[0].GetQuantile())
+	assert.Equal(t, uint64(40), rpc.Metric[0].Summary.GetSampleCount())
+
+	mode := mfs[3]
+	assert.Equal(t, ioprometheusclient.MetricType_GAUGE, mode.GetType())
+	assert.Len(t, mode.Metric, 2)
+
+	untyped := mfs[4]
+	assert.Equal(t, "untyped_metric", untyped.GetName())
+	assert.Equal(t, ioprometheusclient.MetricType_UNTYPED, untyped.GetType())
+	assert.True(t, math.IsNaN(untyped.Metric[0].Untyped.GetValue()))
+	assert.Equal(t, int64(1729469011000), untyped.Metric[0].GetTimestampMs())
+}
+
+func TestParseOpenMetricsErrors(t *testing.T) {
+	mfs, err := parseOpenMetrics(strings.NewReader("# TYPE up gauge\nup 1\n"))
+	assert.EqualError(t, err, "missing # EOF")
+	assert.Len(t, mfs, 1, "families parsed before the error are kept")
+
+	_, err = parseOpenMetrics(strings.NewReader("# TYPE up gauge\nup{job=\"a} 1\n# EOF\n"))
+	assert.ErrorContains(t, err, "line 2: invalid labels of up")
+
+	_, err = parseOpenMetrics(strings.NewReader("# TYPE up histogram\nup_bucket 1\n# EOF\n"))
+	assert.ErrorContains(t, err, "missing le label of up_bucket")
+
+	_, err = parseOpenMetrics(strings.NewReader("# TYPE up enum\n# EOF\n"))
+	assert.ErrorContains(t, err, `unknown metric type "enum" of up`)
+}
+
+func TestExposition(t *testing.T) {
+	assert.Equal(t, expfmt.TypeOpenMetrics, exposition("application/openmetrics-text; version=1.0.0; charset=utf-8"))
+	assert.Equal(t, expfmt.TypeOpenMetrics, exposition("application/openmetrics-text"))
+	assert.Equal(t, expfmt.TypeProtoDelim, exposition(string(expfmt.FmtProtoDelim)))
+	assert.Equal(t, expfmt.TypeTextPlain, exposition("text/plain; version=0.0.4; charset=utf-8"))
+	assert.Equal(t, expfmt.TypeTextPlain, exposition(""))
+}
+
+// TestHandleStatsGolden re-emits the outputs of the engines in the format Prometheus accepts and compares them with
+// the golden files in testdata. Run the test with -update to regenerate them.
+func TestHandleStatsGolden(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		contentType string
+		accept      string
+		golden      string
+	}{
+		{
+			name:        "sglang openmetrics as openmetrics",
+			fixture:     "sglang.openmetrics",
+			contentType: string(expfmt.FmtOpenMetrics_1_0_0),
+			accept:      openMetricsAccept,
+			golden:      "sglang.openmetrics.golden",
+		},
+		{
+			name:        "sglang openmetrics as text",
+			fixture:     "sglang.openmetrics",
+			contentType: string(expfmt.FmtOpenMetrics_1_0_0),
+			golden:      "sglang.text.golden",
+		},
+		{
+			name:        "vllm text as openmetrics",
+			fixture:     "vllm.prom",
+			contentType: string(expfmt.FmtText),
+			accept:      openMetricsAccept,
+			golden:      "vllm.openmetrics.golden",
+		},
+		{
+			name:        "vllm text as text",
+			fixture:     "vllm.prom",
+			contentType: string(expfmt.FmtText),
+			golden:      "vllm.text.golden",
+		},
+	}
+
+	setEnvVars(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fixture, err := os.ReadFile(filepath.Join("testdata", test.fixture))
+			require.NoError(t, err)
+			app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.contentType)
+				_, _ = w.Write(fixture)
+			}))
+			defer app.Close()
+
+			sc := &ScrapeConfigurations{logger: initializeLogger(), AppPort: serverPort(app)}
+			rec := httptest.NewRecorder()
+			sc.handleStats(rec, &http.Request{Header: http.Header{"Accept": {test.accept}}})
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			golden := filepath.Join("testdata", test.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, rec.Body.Bytes(), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), rec.Body.String())
+		})
+	}
+}
+
+// TestHandleStatsNativeHistogram checks that native histograms and their exemplars are kept through the protobuf format
+func TestHandleStatsNativeHistogram(t *testing.T) {
+	setEnvVars(t)
+	readFamily := func(name string) *ioprometheusclient.MetricFamily {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		mf := &ioprometheusclient.MetricFamily{}
+		require.NoError(t, prototext.Unmarshal(data, mf))
+		return mf
+	}
+	fixture := readFamily("native_histogram.prototext")
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", string(expfmt.FmtProtoDelim))
+		_, _ = protodelim.MarshalTo(w, fixture)
+	}))
+	defer app.Close()
+
+	sc := &ScrapeConfigurations{logger: initializeLogger(), AppPort: serverPort(app)}
+	rec := httptest.NewRecorder()
+	sc.handleStats(rec, &http.Request{Header: http.Header{"Accept": {protobufAccept}}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, expfmt.TypeProtoDelim, expfmt.Format(rec.Header().Get("Content-Type")).FormatType())
+
+	mfs, err := decodeMetricFamilies(bytes.NewReader(rec.Body.Bytes()), rec.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	require.Len(t, mfs, 1)
+	expected := readFamily("native_histogram.golden.prototext")
+	assert.True(t, proto.Equal(expected, mfs[0]), "expected %v, got %v", expected, mfs[0])
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ioprometheusclient "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+)
+
+// ScrapeTarget is an application endpoint whose metrics are merged with the queue-proxy metrics
+type ScrapeTarget struct {
+	// Name identifies the target in logs and self-metrics
+	Name string `json:"name"`
+	// Host defaults to localhost, set it to scrape another pod such as a multi-node worker
+	Host string `json:"host,omitempty"`
+	Port string `json:"port"`
+	Path string `json:"path,omitempty"`
+	// Labels are added to every metric scraped from the target
+	Labels map[string]string `json:"labels,omitempty"`
+	// TimeoutSeconds bounds the scrape of the target, within the Prometheus scrape timeout
+	TimeoutSeconds float64 `json:"timeoutSeconds,omitempty"`
+}
+
+func (t ScrapeTarget) url() string {
+	host := t.Host
+	if host == "" {
+		host = "localhost"
+	}
+	path := t.Path
+	if path == "" {
+		path = DefaultQueueProxyMetricsPath
+	}
+	return fmt.Sprintf("http://%s:%s%s", host, t.Port, path)
+}
+
+// parseScrapeTargets parses the JSON list of scrape targets
+func parseScrapeTargets(value string) ([]ScrapeTarget, error) {
+	var targets []ScrapeTarget
+	if err := json.Unmarshal([]byte(value), &targets); err != nil {
+		return nil, fmt.Errorf("invalid scrape targets: %w", err)
+	}
+	for i, target := range targets {
+		if target.Port == "" {
+			return nil, fmt.Errorf("invalid scrape target %d: port is required", i)
+		}
+		if target.Name == "" {
+			targets[i].Name = fmt.Sprintf("%s:%s", target.Host, target.Port)
+		}
+	}
+	return targets, nil
+}
+
+// scrapeMetrics are the self-metrics of qpext on the scrapes of its targets
+type scrapeMetrics struct {
+	registry       *prometheus.Registry
+	scrapeDuration *prometheus.HistogramVec
+	scrapeFailures *prometheus.CounterVec
+}
+
+func newScrapeMetrics() *scrapeMetrics {
+	m := &scrapeMetrics{
+		registry: prometheus.NewRegistry(),
+		scrapeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "qpext_scrape_duration_seconds",
+			Help:    "Duration of the scrapes of the application targets in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12), // From 5ms to ~10s
+		}, []string{"target"}),
+		scrapeFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "qpext_scrape_failures_total",
+			Help: "Total number of failed scrapes of the application targets",
+		}, []string{"target"}),
+	}
+	m.registry.MustRegister(m.scrapeDuration, m.scrapeFailures)
+	return m
+}
+
+// targetResult holds the metric families scraped from a target, labeled for the target
+type targetResult struct {
+	target ScrapeTarget
+	mfs    map[string]*ioprometheusclient.MetricFamily
+	err    error
+}
+
+// scrapeTargets scrapes the targets in parallel. A target failing does not fail the others.
+func (sc *ScrapeConfigurations) scrapeTargets(targets []ScrapeTarget, header http.Header) []targetResult {
+	results := make([]targetResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target ScrapeTarget) {
+			defer wg.Done()
+			start := time.Now()
+			mfs, err := sc.scrapeTarget(target, header)
+			if sc.metrics != nil {
+				sc.metrics.scrapeDuration.WithLabelValues(target.Name).Observe(time.Since(start).Seconds())
+				if err != nil {
+					sc.metrics.scrapeFailures.WithLabelValues(target.Name).Inc()
+				}
+			}
+			results[i] = targetResult{target: target, mfs: mfs, err: err}
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// scrapeTarget scrapes a target and adds the serverless labels and the labels of the target to its metrics
+func (sc *ScrapeConfigurations) scrapeTarget(target ScrapeTarget, header http.Header) (map[string]*ioprometheusclient.MetricFamily, error) {
+	timeout := time.Duration(target.TimeoutSeconds * float64(time.Second))
+	body, cancel, contentType, err := scrapeWithTimeout(target.url(), header, timeout, sc.logger)
+	if cancel != nil {
+		defer cancel()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := body.Close(); err != nil {
+			sc.logger.Error("application connection is not closed", zap.String("target", target.Name), zap.Error(err))
+		}
+	}()
+
+	mfs, err := decodeMetricFamilies(body, contentType)
+	if err != nil {
+		// Keep the metric families decoded before the error
+		sc.logger.Error("error decoding metric families", zap.String("target", target.Name), zap.Error(err))
+	}
+
+	labelKeys, labelValues := append([]string{}, LabelKeys...), getServerlessLabelVals()
+	targetLabels := make([]string, 0, len(target.Labels))
+	for name := range target.Labels {
+		targetLabels = append(targetLabels, name)
+	}
+	sort.Strings(targetLabels)
+	for _, name := range targetLabels {
+		labelKeys = append(labelKeys, name)
+		labelValues = append(labelValues, target.Labels[name])
+	}
+
+	labeled := make(map[string]*ioprometheusclient.MetricFamily, len(mfs))
+	for _, mf := range mfs {
+		for i, metric := range mf.Metric {
+			mf.Metric[i] = addServerlessLabels(metric, labelKeys, labelValues)
+		}
+		labeled[mf.GetName()] = mf
+	}
+	return labeled, nil
+}
+
+// mergeMetricFamilies merges the metric families of the targets by name, in the order of the targets. A family whose
+// type differs from the one scraped first under its name is dropped, since Prometheus rejects the exposition.
+func mergeMetricFamilies(results []targetResult, logger *zap.Logger) []*ioprometheusclient.MetricFamily {
+	merged := map[string]*ioprometheusclient.MetricFamily{}
+	for _, result := range results {
+		names := make([]string, 0, len(result.mfs))
+		for name := range result.mfs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			mf := result.mfs[name]
+			existing, ok := merged[name]
+			if !ok {
+				merged[name] = mf
+				continue
+			}
+			if existing.GetType() != mf.GetType() {
+				logger.Error("dropping metric family with conflicting type", zap.String("target", result.target.Name),
+					zap.String("metric name", name), zap.String("type", mf.GetType().String()),
+					zap.String("existing type", existing.GetType().String()))
+				continue
+			}
+			existing.Metric = append(existing.Metric, mf.Metric...)
+		}
+	}
+
+	families := make([]*ioprometheusclient.MetricFamily, 0, len(merged))
+	for _, mf := range merged {
+		families = append(families, mf)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].GetName() < families[j].GetName() })
+	return families
+}
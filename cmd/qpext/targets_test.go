@@ -0,0 +1,149 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func metricsServer(body string, status int, delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func serverPort(server *httptest.Server) string {
+	return strings.Split(server.URL, ":")[2]
+}
+
+func TestParseScrapeTargets(t *testing.T) {
+	targets, err := parseScrapeTargets(`[
+		{"name": "engine", "port": "8080", "path": "/metrics", "labels": {"container": "ome-container"}},
+		{"host": "llama-0-1.llama", "port": "8080", "timeoutSeconds": 2.5}
+	]`)
+	require.NoError(t, err)
+	assert.Equal(t, []ScrapeTarget{
+		{Name: "engine", Port: "8080", Path: "/metrics", Labels: map[string]string{"container": "ome-container"}},
+		{Name: "llama-0-1.llama:8080", Host: "llama-0-1.llama", Port: "8080", TimeoutSeconds: 2.5},
+	}, targets)
+	assert.Equal(t, "http://localhost:8080/metrics", targets[0].url())
+	assert.Equal(t, "http://llama-0-1.llama:8080/metrics", targets[1].url())
+
+	_, err = parseScrapeTargets(`[{"name": "engine"}]`)
+	assert.EqualError(t, err, "invalid scrape target 0: port is required")
+
+	_, err = parseScrapeTargets(`{"name": "engine"}`)
+	assert.Error(t, err)
+}
+
+func TestHandleStatsTargets(t *testing.T) {
+	setEnvVars(t)
+	engine := metricsServer(`# TYPE sglang:num_running_reqs gauge
+sglang:num_running_reqs{model_name="llama"} 3
+`, http.StatusOK, 0)
+	defer engine.Close()
+	worker := metricsServer(`# TYPE sglang:num_running_reqs gauge
+sglang:num_running_reqs{model_name="llama"} 2
+`, http.StatusOK, 0)
+	defer worker.Close()
+	router := metricsServer(`# TYPE router_requests_total counter
+router_requests_total{container="router"} 7
+`, http.StatusOK, 0)
+	defer router.Close()
+	failing := metricsServer("", http.StatusInternalServerError, 0)
+	defer failing.Close()
+	slow := metricsServer(`# TYPE slow_metric gauge
+slow_metric 1
+`, http.StatusOK, 5*time.Second)
+	defer slow.Close()
+
+	sc := NewScrapeConfigs(initializeLogger(), "", "", "")
+	sc.Targets = []ScrapeTarget{
+		{Name: "engine", Port: serverPort(engine), Labels: map[string]string{"container": "ome-container", "node": "0"}},
+		{Name: "worker", Port: serverPort(worker), Labels: map[string]string{"container": "ome-container", "node": "1"}},
+		{Name: "router", Port: serverPort(router), Labels: map[string]string{"container": "serving-agent"}},
+		{Name: "failing", Port: serverPort(failing)},
+		{Name: "slow", Port: serverPort(slow), TimeoutSeconds: 0.1},
+	}
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	sc.handleStats(rec, &http.Request{Header: http.Header{}})
+	assert.Less(t, time.Since(start), 2*time.Second, "the slow target is bounded by its timeout")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Metrics of the same name from several targets are merged into a single family
+	assert.Contains(t, rec.Body.String(), `# TYPE sglang:num_running_reqs gauge
+sglang:num_running_reqs{model_name="llama",service_name="something",configuration_name="something",revision_name="something",container="ome-container",node="0"} 3
+sglang:num_running_reqs{model_name="llama",service_name="something",configuration_name="something",revision_name="something",container="ome-container",node="1"} 2
+`)
+	// Labels of the target take precedence over the labels of the application
+	assert.Contains(t, rec.Body.String(),
+		`router_requests_total{exported_container="router",service_name="something",configuration_name="something",revision_name="something",container="serving-agent"} 7`)
+	assert.NotContains(t, rec.Body.String(), "slow_metric")
+
+	parser := expfmt.TextParser{}
+	_, err := parser.TextToMetricFamilies(strings.NewReader(rec.Body.String()))
+	assert.NoError(t, err)
+
+	// Self-metrics record the scrapes and failures of each target
+	assert.Equal(t, float64(1), testutil.ToFloat64(sc.metrics.scrapeFailures.WithLabelValues("failing")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sc.metrics.scrapeFailures.WithLabelValues("slow")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(sc.metrics.scrapeFailures.WithLabelValues("engine")))
+	assert.Contains(t, rec.Body.String(), `qpext_scrape_failures_total{target="failing"} 1`)
+	assert.Contains(t, rec.Body.String(), `qpext_scrape_duration_seconds_count{target="engine"} 1`)
+}
+
+func TestMergeMetricFamiliesTypeConflict(t *testing.T) {
+	setEnvVars(t)
+	gauge := metricsServer(`# TYPE shared_metric gauge
+shared_metric 1
+`, http.StatusOK, 0)
+	defer gauge.Close()
+	counter := metricsServer(`# TYPE shared_metric counter
+shared_metric 2
+`, http.StatusOK, 0)
+	defer counter.Close()
+
+	sc := NewScrapeConfigs(initializeLogger(), "", "", "")
+	results := sc.scrapeTargets([]ScrapeTarget{
+		{Name: "gauge", Port: serverPort(gauge)},
+		{Name: "counter", Port: serverPort(counter)},
+	}, http.Header{})
+	families := mergeMetricFamilies(results, sc.logger)
+	require.Len(t, families, 1)
+	assert.Equal(t, "GAUGE", families[0].GetType().String())
+	assert.Len(t, families[0].Metric, 1)
+}
+
+func TestScrapeWithTimeout(t *testing.T) {
+	server := metricsServer("", http.StatusOK, 0)
+	defer server.Close()
+
+	url := getURL(serverPort(server), "/metrics")
+	body, cancel, _, err := scrapeWithTimeout(url, http.Header{}, 0, initializeLogger())
+	require.NoError(t, err)
+	assert.NoError(t, body.Close())
+	assert.Nil(t, cancel, "no timeout without a target or header timeout")
+
+	body, cancel, _, err = scrapeWithTimeout(url, http.Header{prometheusTimeoutHeader: {"10"}}, time.Second, initializeLogger())
+	require.NoError(t, err)
+	assert.NoError(t, body.Close())
+	assert.NotNil(t, cancel)
+	cancel()
+}
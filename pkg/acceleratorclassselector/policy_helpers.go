@@ -27,15 +27,20 @@ func candidatesFromNames(
 ) ([]v1beta1.AcceleratorClass, error) {
 	logger := log.FromContext(ctx)
 
-	// Deduplicate names
-	uniqueNames := make(map[string]struct{})
+	// Deduplicate names, preserving the runtime order that FirstAvailable relies on
+	uniqueNames := make([]string, 0, len(names))
+	seen := make(map[string]struct{})
 	for _, name := range names {
-		uniqueNames[name] = struct{}{}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		uniqueNames = append(uniqueNames, name)
 	}
 
 	candidates := make([]v1beta1.AcceleratorClass, 0, len(uniqueNames))
 
-	for name := range uniqueNames {
+	for _, name := range uniqueNames {
 		ac, found, err := fetcher.GetAcceleratorClass(ctx, name)
 		if err != nil {
 			logger.Error(err, "Failed to fetch AcceleratorClass", "name", name)
@@ -78,6 +83,60 @@ func filterCandidates(
 	return filtered
 }
 
+// preferLiveCapacity keeps the candidates that can currently fit a pod on one of their nodes.
+// When none can, all candidates are returned so that selection still succeeds and the pods
+// wait for capacity, or the scheduling fallback moves them elsewhere.
+func preferLiveCapacity(
+	ctx context.Context,
+	candidates []v1beta1.AcceleratorClass,
+) []v1beta1.AcceleratorClass {
+	logger := log.FromContext(ctx)
+	withCapacity := make([]v1beta1.AcceleratorClass, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if hasLiveCapacity(candidate) {
+			withCapacity = append(withCapacity, candidate)
+		} else {
+			logger.V(1).Info("Candidate has no free capacity", "name", candidate.Name,
+				"largestFreeBlock", candidate.Status.LargestFreeBlock, "required", requiredAccelerators(candidate))
+		}
+	}
+
+	if len(withCapacity) == 0 {
+		logger.Info("No candidate has free capacity, ignoring live capacity", "total", len(candidates))
+		return candidates
+	}
+	return withCapacity
+}
+
+// hasLiveCapacity checks whether a pod of the candidate fits on a single node given the accelerators
+// in use. Candidates whose status does not report accelerator capacity are assumed to have capacity.
+func hasLiveCapacity(candidate v1beta1.AcceleratorClass) bool {
+	status := candidate.Status
+	if status.LastUpdated.IsZero() {
+		return true
+	}
+	if len(status.Nodes) == 0 {
+		return false
+	}
+	if status.TotalAccelerators == 0 {
+		return true
+	}
+	return status.LargestFreeBlock >= requiredAccelerators(candidate)
+}
+
+// requiredAccelerators returns the accelerators a single pod of the candidate requests,
+// which is the largest resource quantity of the class and at least one.
+func requiredAccelerators(candidate v1beta1.AcceleratorClass) int32 {
+	required := int32(1)
+	for _, res := range candidate.Spec.Resources {
+		if v := int32(res.Quantity.Value()); v > required {
+			required = v
+		}
+	}
+	return required
+}
+
 // meetsRequirements checks if a candidate satisfies all requirements from InferenceService
 func meetsRequirements(
 	candidate v1beta1.AcceleratorClass,
@@ -986,3 +986,60 @@ func TestMeetsRequirements(t *testing.T) {
 		})
 	}
 }
+
+// TestHasLiveCapacity tests the hasLiveCapacity helper function
+func TestHasLiveCapacity(t *testing.T) {
+	updated := metav1.Now()
+	resources := []v1beta1.AcceleratorResource{
+		{Name: "nvidia.com/gpu", Quantity: resource.MustParse("4")},
+		{Name: "rdma/ib", Quantity: resource.MustParse("1")},
+	}
+
+	tests := []struct {
+		name     string
+		status   v1beta1.AcceleratorClassStatus
+		expected bool
+	}{
+		{
+			name:     "status not reported",
+			expected: true,
+		},
+		{
+			name:     "no matching nodes",
+			status:   v1beta1.AcceleratorClassStatus{LastUpdated: updated},
+			expected: false,
+		},
+		{
+			name:     "nodes without accelerator accounting",
+			status:   v1beta1.AcceleratorClassStatus{LastUpdated: updated, Nodes: []string{"n1"}},
+			expected: true,
+		},
+		{
+			name: "free block fits the pod",
+			status: v1beta1.AcceleratorClassStatus{
+				LastUpdated: updated, Nodes: []string{"n1"}, TotalAccelerators: 8, AvailableAccelerators: 4, LargestFreeBlock: 4,
+			},
+			expected: true,
+		},
+		{
+			name: "fragmented free capacity",
+			status: v1beta1.AcceleratorClassStatus{
+				LastUpdated: updated, Nodes: []string{"n1", "n2"}, TotalAccelerators: 16, AvailableAccelerators: 6, LargestFreeBlock: 3,
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := v1beta1.AcceleratorClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "h100"},
+				Spec:       v1beta1.AcceleratorClassSpec{Resources: resources},
+				Status:     tt.status,
+			}
+			if got := hasLiveCapacity(candidate); got != tt.expected {
+				t.Errorf("hasLiveCapacity() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
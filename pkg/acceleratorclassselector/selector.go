@@ -136,6 +136,9 @@ func (s *defaultSelector) getAcceleratorClassByPolicy(ctx context.Context, isvc
 		return nil
 	}
 
+	// Prefer candidates with free accelerators according to the AcceleratorClass status
+	validCandidates = preferLiveCapacity(ctx, validCandidates)
+
 	logger.Info("Candidates after filtering", "count", len(validCandidates), "policy", acceleratorPolicy)
 
 	// Select by policy
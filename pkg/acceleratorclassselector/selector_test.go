@@ -632,3 +632,95 @@ func TestEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+// TestGetAcceleratorClassByPolicy_LiveCapacity tests that policies skip classes without free accelerators
+func TestGetAcceleratorClassByPolicy_LiveCapacity(t *testing.T) {
+	updated := metav1.Now()
+	tests := []struct {
+		name         string
+		policy       v1beta1.AcceleratorSelectionPolicy
+		status       map[string]v1beta1.AcceleratorClassStatus
+		expectedName string
+	}{
+		{
+			name:   "FirstAvailable skips a full class",
+			policy: v1beta1.FirstAvailablePolicy,
+			status: map[string]v1beta1.AcceleratorClassStatus{
+				"nvidia-h100-80gb": {LastUpdated: updated, Nodes: []string{"n1"}, TotalAccelerators: 8, AllocatedAccelerators: 8},
+			},
+			expectedName: "nvidia-a100-40gb",
+		},
+		{
+			name:   "FirstAvailable skips a class without a large enough free block",
+			policy: v1beta1.FirstAvailablePolicy,
+			status: map[string]v1beta1.AcceleratorClassStatus{
+				"nvidia-h100-80gb": {
+					LastUpdated: updated, Nodes: []string{"n1", "n2"}, TotalAccelerators: 16,
+					AllocatedAccelerators: 12, AvailableAccelerators: 4, LargestFreeBlock: 2,
+				},
+			},
+			expectedName: "nvidia-a100-40gb",
+		},
+		{
+			name:   "BestFit skips a class without matching nodes",
+			policy: v1beta1.BestFitPolicy,
+			status: map[string]v1beta1.AcceleratorClassStatus{
+				"nvidia-a100-40gb": {LastUpdated: updated},
+			},
+			expectedName: "nvidia-h100-80gb",
+		},
+		{
+			name:   "all classes full keeps the policy choice",
+			policy: v1beta1.FirstAvailablePolicy,
+			status: map[string]v1beta1.AcceleratorClassStatus{
+				"nvidia-h100-80gb": {LastUpdated: updated, Nodes: []string{"n1"}, TotalAccelerators: 8, AllocatedAccelerators: 8},
+				"nvidia-a100-40gb": {LastUpdated: updated, Nodes: []string{"n2"}, TotalAccelerators: 8, AllocatedAccelerators: 8},
+			},
+			expectedName: "nvidia-h100-80gb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFetcher := newMockFetcher()
+			accelerators := createRealisticAccelerators()
+			for _, name := range []string{"nvidia-h100-80gb", "nvidia-a100-40gb"} {
+				spec := accelerators[name]
+				spec.Resources = []v1beta1.AcceleratorResource{
+					{Name: "nvidia.com/gpu", Quantity: resource.MustParse("4")},
+				}
+				mockFetcher.addAccelerator(name, spec)
+				if status, ok := tt.status[name]; ok {
+					mockFetcher.accelerators[name].Status = status
+				}
+			}
+
+			selector := &defaultSelector{
+				config:  &Config{},
+				fetcher: mockFetcher,
+			}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default"},
+				Spec: v1beta1.InferenceServiceSpec{
+					AcceleratorSelector: &v1beta1.AcceleratorSelector{
+						Policy:      tt.policy,
+						Constraints: &v1beta1.AcceleratorConstraints{MinMemory: int64Ptr(40)},
+					},
+				},
+			}
+			runtime := &v1beta1.ServingRuntimeSpec{
+				AcceleratorRequirements: &v1beta1.AcceleratorRequirements{
+					AcceleratorClasses: []string{"nvidia-h100-80gb", "nvidia-a100-40gb"},
+				},
+			}
+
+			selected := selector.getAcceleratorClassByPolicy(context.Background(), isvc, runtime, tt.policy)
+			if selected == nil {
+				t.Fatalf("getAcceleratorClassByPolicy() returned nil")
+			}
+			if *selected != tt.expectedName {
+				t.Errorf("getAcceleratorClassByPolicy() = %s, want %s", *selected, tt.expectedName)
+			}
+		})
+	}
+}
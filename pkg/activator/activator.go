@@ -0,0 +1,192 @@
+// Package activator holds requests for a component scaled to zero until it is back up, and reports the held and
+// in-flight requests to KEDA through its external scaler API so the component is scaled up on demand.
+package activator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	initialBackoff = 100 * time.Millisecond
+	maxBackoff     = 2 * time.Second
+)
+
+var (
+	errBackendUnavailable = errors.New("backend did not become available in time")
+	errRequestTooLarge    = errors.New("request body is too large to hold")
+)
+
+// Activator proxies requests to the Service of a component, holding them while the component has no ready pods
+type Activator struct {
+	backend      string
+	timeout      time.Duration
+	maxBodyBytes int64
+	logger       *zap.SugaredLogger
+
+	mu       sync.Mutex
+	inflight int64
+	// changed is closed and replaced whenever the activator becomes active or idle
+	changed chan struct{}
+}
+
+// New returns an activator for the backend host, holding each request for up to timeout and buffering request
+// bodies up to maxBodyBytes so they can be replayed once the backend accepts connections
+func New(backend string, timeout time.Duration, maxBodyBytes int64, logger *zap.SugaredLogger) *Activator {
+	return &Activator{
+		backend:      backend,
+		timeout:      timeout,
+		maxBodyBytes: maxBodyBytes,
+		logger:       logger,
+		changed:      make(chan struct{}),
+	}
+}
+
+// Handler proxies requests to the port of the backend
+func (a *Activator) Handler(port int) http.Handler {
+	target := &url.URL{Scheme: "http", Host: net.JoinHostPort(a.backend, strconv.Itoa(port))}
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	// Stream responses, e.g. server-sent events of chat completions, as they are written
+	proxy.FlushInterval = -1
+	proxy.Transport = &holdingTransport{
+		base:    http.DefaultTransport.(*http.Transport).Clone(),
+		timeout: a.timeout,
+	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, errBackendUnavailable):
+			status = http.StatusServiceUnavailable
+		case errors.Is(err, context.Canceled):
+			// The client went away while the request was held
+			return
+		}
+		a.logger.Warnw("Failed to proxy request", "backend", target.Host, "path", r.URL.Path, "error", err)
+		w.WriteHeader(status)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.begin()
+		defer a.end()
+		if err := a.bufferBody(w, r); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errRequestTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+// InFlight returns the number of requests being held or proxied
+func (a *Activator) InFlight() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.inflight
+}
+
+// activity returns whether requests are in flight, and a channel closed once that changes
+func (a *Activator) activity() (bool, <-chan struct{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.inflight > 0, a.changed
+}
+
+func (a *Activator) begin() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inflight++
+	if a.inflight == 1 {
+		a.notify()
+	}
+}
+
+func (a *Activator) end() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inflight--
+	if a.inflight == 0 {
+		a.notify()
+	}
+}
+
+// notify wakes up the streams watching the activity, and must be called with the lock held
+func (a *Activator) notify() {
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
+// bufferBody reads the request body into memory so the request can be retried until the backend is up
+func (a *Activator) bufferBody(w http.ResponseWriter, r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, a.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return errRequestTooLarge
+		}
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	r.ContentLength = int64(len(body))
+	return nil
+}
+
+// holdingTransport retries a request while connections to the backend are refused, which is the case while the
+// Service has no ready endpoints
+type holdingTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *holdingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	deadline := time.Now().Add(t.timeout)
+	backoff := initialBackoff
+	for {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil || !isDialError(err) {
+			return resp, err
+		}
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			return nil, errBackendUnavailable
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, maxBackoff)
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// isDialError returns whether the request failed before reaching the backend, so it is safe to retry
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
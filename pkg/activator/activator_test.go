@@ -0,0 +1,111 @@
+package activator
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// freePort returns a local port nothing listens on, so connections to it are refused
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
+
+func TestActivatorHoldsRequestsUntilBackendIsUp(t *testing.T) {
+	port := freePort(t)
+	a := New("127.0.0.1", 10*time.Second, 1024, zap.NewNop().Sugar())
+	front := httptest.NewServer(a.Handler(port))
+	defer front.Close()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := http.Post(front.URL+"/v1/completions", "application/json", strings.NewReader(`{"prompt":"hi"}`))
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		results <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	require.Eventually(t, func() bool { return a.InFlight() == 1 }, 5*time.Second, 10*time.Millisecond)
+	active, _ := a.activity()
+	assert.True(t, active)
+
+	// The backend comes up while the request is held
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	require.NoError(t, err)
+	backend := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write([]byte(r.URL.Path + " " + string(body)))
+		})},
+	}
+	backend.Start()
+	defer backend.Close()
+
+	select {
+	case res := <-results:
+		require.NoError(t, res.err)
+		assert.Equal(t, http.StatusOK, res.status)
+		assert.Equal(t, `/v1/completions {"prompt":"hi"}`, res.body)
+	case <-time.After(10 * time.Second):
+		t.Fatal("request was not proxied once the backend was up")
+	}
+	assert.Equal(t, int64(0), a.InFlight())
+}
+
+func TestActivatorErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		body     string
+		expected int
+	}{
+		{
+			name:     "backend does not come up in time",
+			timeout:  300 * time.Millisecond,
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "body too large to hold",
+			timeout:  time.Second,
+			body:     strings.Repeat("x", 2048),
+			expected: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New("127.0.0.1", tt.timeout, 1024, zap.NewNop().Sugar())
+			front := httptest.NewServer(a.Handler(freePort(t)))
+			defer front.Close()
+
+			resp, err := http.Post(front.URL, "application/json", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.expected, resp.StatusCode)
+			assert.Equal(t, int64(0), a.InFlight())
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package activator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+const (
+	// MetricName is the metric reported to KEDA, the number of requests held or proxied by the activator
+	MetricName = "inflight-requests"
+	// TargetRequestsMetadataKey sets the in-flight requests per replica in the metadata of the KEDA trigger
+	TargetRequestsMetadataKey = "targetRequests"
+	// DefaultTargetRequests is the in-flight requests per replica when the trigger does not set it
+	DefaultTargetRequests = 100
+)
+
+// Scaler implements the KEDA external scaler API on the in-flight requests of an activator. The component is
+// active while requests are in flight, and the activity is pushed to KEDA as soon as a request comes in so the
+// component scales up from zero without waiting for the next polling interval.
+type Scaler struct {
+	externalscaler.UnimplementedExternalScalerServer
+	activator *Activator
+}
+
+// NewScaler returns the external scaler of the activator
+func NewScaler(activator *Activator) *Scaler {
+	return &Scaler{activator: activator}
+}
+
+func (s *Scaler) IsActive(_ context.Context, _ *externalscaler.ScaledObjectRef) (*externalscaler.IsActiveResponse, error) {
+	return &externalscaler.IsActiveResponse{Result: s.activator.InFlight() > 0}, nil
+}
+
+func (s *Scaler) StreamIsActive(_ *externalscaler.ScaledObjectRef, stream externalscaler.ExternalScaler_StreamIsActiveServer) error {
+	for {
+		active, changed := s.activator.activity()
+		if err := stream.Send(&externalscaler.IsActiveResponse{Result: active}); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func (s *Scaler) GetMetricSpec(_ context.Context, ref *externalscaler.ScaledObjectRef) (*externalscaler.GetMetricSpecResponse, error) {
+	target := int64(DefaultTargetRequests)
+	if value, ok := ref.GetScalerMetadata()[TargetRequestsMetadataKey]; ok {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			target = parsed
+		}
+	}
+	return &externalscaler.GetMetricSpecResponse{
+		MetricSpecs: []*externalscaler.MetricSpec{{MetricName: MetricName, TargetSize: target}},
+	}, nil
+}
+
+func (s *Scaler) GetMetrics(_ context.Context, _ *externalscaler.GetMetricsRequest) (*externalscaler.GetMetricsResponse, error) {
+	return &externalscaler.GetMetricsResponse{
+		MetricValues: []*externalscaler.MetricValue{{MetricName: MetricName, MetricValue: s.activator.InFlight()}},
+	}, nil
+}
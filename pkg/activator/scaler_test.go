@@ -0,0 +1,86 @@
+package activator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestScalerMetrics(t *testing.T) {
+	a := New("127.0.0.1", time.Second, 1024, zap.NewNop().Sugar())
+	s := NewScaler(a)
+
+	active, err := s.IsActive(context.Background(), &externalscaler.ScaledObjectRef{})
+	require.NoError(t, err)
+	assert.False(t, active.Result)
+
+	a.begin()
+	a.begin()
+	active, err = s.IsActive(context.Background(), &externalscaler.ScaledObjectRef{})
+	require.NoError(t, err)
+	assert.True(t, active.Result)
+
+	metrics, err := s.GetMetrics(context.Background(), &externalscaler.GetMetricsRequest{MetricName: MetricName})
+	require.NoError(t, err)
+	require.Len(t, metrics.MetricValues, 1)
+	assert.Equal(t, int64(2), metrics.MetricValues[0].MetricValue)
+}
+
+func TestScalerMetricSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+	}{
+		{name: "default target", expected: DefaultTargetRequests},
+		{name: "target from trigger metadata", metadata: map[string]string{TargetRequestsMetadataKey: "8"}, expected: 8},
+		{name: "invalid target", metadata: map[string]string{TargetRequestsMetadataKey: "-1"}, expected: DefaultTargetRequests},
+	}
+
+	s := NewScaler(New("127.0.0.1", time.Second, 1024, zap.NewNop().Sugar()))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := s.GetMetricSpec(context.Background(), &externalscaler.ScaledObjectRef{ScalerMetadata: tt.metadata})
+			require.NoError(t, err)
+			require.Len(t, spec.MetricSpecs, 1)
+			assert.Equal(t, MetricName, spec.MetricSpecs[0].MetricName)
+			assert.Equal(t, tt.expected, spec.MetricSpecs[0].TargetSize)
+		})
+	}
+}
+
+type fakeActiveStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan bool
+}
+
+func (f *fakeActiveStream) Context() context.Context { return f.ctx }
+
+func (f *fakeActiveStream) Send(resp *externalscaler.IsActiveResponse) error {
+	f.sent <- resp.Result
+	return nil
+}
+
+func TestScalerStreamIsActive(t *testing.T) {
+	a := New("127.0.0.1", time.Second, 1024, zap.NewNop().Sugar())
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeActiveStream{ctx: ctx, sent: make(chan bool, 3)}
+	done := make(chan error, 1)
+	go func() { done <- NewScaler(a).StreamIsActive(&externalscaler.ScaledObjectRef{}, stream) }()
+
+	assert.False(t, <-stream.sent)
+	a.begin()
+	assert.True(t, <-stream.sent)
+	a.end()
+	assert.False(t, <-stream.sent)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
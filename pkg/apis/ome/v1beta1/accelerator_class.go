@@ -15,6 +15,7 @@ import (
 // +kubebuilder:printcolumn:name="Family",type=string,JSONPath=`.spec.family`
 // +kubebuilder:printcolumn:name="Memory",type=string,JSONPath=`.spec.capabilities.memoryGB`
 // +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.availableNodes`
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableAccelerators`
 
 type AcceleratorClass struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -202,6 +203,22 @@ type AcceleratorClassStatus struct {
 	// +optional
 	AvailableAccelerators int32 `json:"availableAccelerators,omitempty"`
 
+	// AllocatedAccelerators is the number of accelerators requested by non-terminated pods
+	// +optional
+	AllocatedAccelerators int32 `json:"allocatedAccelerators,omitempty"`
+
+	// LargestFreeBlock is the largest number of free accelerators on a single node.
+	// A pod requesting more accelerators than this cannot be scheduled even when
+	// AvailableAccelerators is larger, because the free accelerators are fragmented across nodes.
+	// +optional
+	LargestFreeBlock int32 `json:"largestFreeBlock,omitempty"`
+
+	// NodeCapacity is the per-node breakdown of accelerator capacity
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	NodeCapacity []AcceleratorNodeCapacity `json:"nodeCapacity,omitempty"`
+
 	// Last update time
 	// +optional
 	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
@@ -216,6 +233,24 @@ type AcceleratorClassStatus struct {
 	AvailableNodes int32 `json:"availableNodes,omitempty"`
 }
 
+// AcceleratorNodeCapacity is the accelerator capacity of a single node
+type AcceleratorNodeCapacity struct {
+	// Name of the node
+	Name string `json:"name"`
+
+	// Allocatable accelerators on the node
+	// +optional
+	Allocatable int32 `json:"allocatable,omitempty"`
+
+	// Allocated accelerators requested by non-terminated pods on the node
+	// +optional
+	Allocated int32 `json:"allocated,omitempty"`
+
+	// Available accelerators on the node
+	// +optional
+	Available int32 `json:"available,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&AcceleratorClass{}, &AcceleratorClassList{})
 }
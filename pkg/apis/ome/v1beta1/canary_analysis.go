@@ -0,0 +1,119 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CanaryAnalysisSpec configures the automated analysis of a canary rollout. The canary traffic percent is
+// stepped up while the error rate, time to first token and throughput of the canary stay within the thresholds
+// relative to the stable workload. The canary is promoted once it reaches 100 percent and rolled back as soon
+// as a threshold is breached or too many analyses in a row are inconclusive. Only applicable for RawDeployment
+// and MultiNode components.
+type CanaryAnalysisSpec struct {
+	// StepPercent is the percent of traffic added to the canary after each successful analysis.
+	// The first step starts at canaryTrafficPercent when it is set.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepPercent int64 `json:"stepPercent,omitempty"`
+
+	// IntervalSeconds is how long the canary serves each step before its metrics are analyzed.
+	// It is also the window of the metric queries.
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+
+	// MaxErrorRateIncrease is the largest increase of the canary error rate over the stable error rate,
+	// as a fraction of requests.
+	//
+	// Example:
+	//   "0.01" - The canary may fail at most one more request in a hundred than the stable workload.
+	// +optional
+	MaxErrorRateIncrease string `json:"maxErrorRateIncrease,omitempty"`
+
+	// MaxTTFTIncreasePercent is the largest increase of the canary p95 time to first token over the stable
+	// p95 time to first token, in percent.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxTTFTIncreasePercent *int64 `json:"maxTTFTIncreasePercent,omitempty"`
+
+	// MinThroughputPercent is the smallest canary generation throughput, in percent of the stable
+	// throughput. Throughput is normalized by the share of traffic each workload receives.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinThroughputPercent *int64 `json:"minThroughputPercent,omitempty"`
+
+	// MaxInconclusiveAnalyses is the number of consecutive inconclusive analyses, e.g. because the canary serves
+	// no requests or Prometheus is unreachable, after which the canary is rolled back.
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInconclusiveAnalyses int64 `json:"maxInconclusiveAnalyses,omitempty"`
+
+	// Queries overrides the Prometheus queries of the analyzed metrics. They are required for runtimes
+	// other than SGLang and vLLM, and for routers, which have no default queries.
+	// +optional
+	Queries *CanaryAnalysisQueries `json:"queries,omitempty"`
+}
+
+// CanaryAnalysisQueries holds the Prometheus queries of the canary analysis. Queries are Go templates rendered
+// once for the stable and once for the canary workload with {{.Namespace}}, {{.App}} (the app label of the
+// workload pods) and {{.Window}} (the analysis interval as a Prometheus duration), and must return a single value.
+type CanaryAnalysisQueries struct {
+	// ErrorRate returns the fraction of failed requests
+	// +optional
+	ErrorRate string `json:"errorRate,omitempty"`
+
+	// TimeToFirstToken returns the time to first token in seconds
+	// +optional
+	TimeToFirstToken string `json:"timeToFirstToken,omitempty"`
+
+	// Throughput returns the generated tokens per second
+	// +optional
+	Throughput string `json:"throughput,omitempty"`
+}
+
+// CanaryAnalysisPhase is the phase of a canary analysis
+// +kubebuilder:validation:Enum=Progressing;Promoted;RolledBack
+type CanaryAnalysisPhase string
+
+// CanaryAnalysisPhase Enum values
+const (
+	// CanaryAnalysisProgressing the canary is analyzed and its traffic percent stepped up
+	CanaryAnalysisProgressing CanaryAnalysisPhase = "Progressing"
+	// CanaryAnalysisPromoted the canary passed every step and replaces the stable workload
+	CanaryAnalysisPromoted CanaryAnalysisPhase = "Promoted"
+	// CanaryAnalysisRolledBack the canary breached a threshold, stayed inconclusive or could not be analyzed and was removed
+	CanaryAnalysisRolledBack CanaryAnalysisPhase = "RolledBack"
+)
+
+// CanaryAnalysisStatus is the state of the canary analysis of a component revision
+type CanaryAnalysisStatus struct {
+	// Revision is the analyzed canary revision
+	Revision string `json:"revision"`
+
+	// Phase of the analysis
+	Phase CanaryAnalysisPhase `json:"phase"`
+
+	// TrafficPercent is the percent of traffic the analysis routes to the canary
+	// +optional
+	TrafficPercent int64 `json:"trafficPercent,omitempty"`
+
+	// LastTransitionTime is when the traffic percent or phase last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// NextAnalysisTime is when the canary metrics are analyzed next
+	// +optional
+	NextAnalysisTime *metav1.Time `json:"nextAnalysisTime,omitempty"`
+
+	// InconclusiveAnalyses is the number of consecutive inconclusive analyses at the current traffic percent
+	// +optional
+	InconclusiveAnalyses int64 `json:"inconclusiveAnalyses,omitempty"`
+
+	// Message describes the result of the last analysis
+	// +optional
+	Message string `json:"message,omitempty"`
+}
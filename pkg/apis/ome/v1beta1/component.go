@@ -19,8 +19,9 @@ type ComponentExtensionSpec struct {
 	// +optional
 	ScaleTarget *int `json:"scaleTarget,omitempty"`
 	// ScaleMetric defines the scaling metric type watched by autoscaler
-	// possible values are concurrency, rps, cpu, memory. concurrency, rps are supported via
+	// possible values are concurrency, rps, tps, cpu, memory, kv-cache, queue-depth, ttft-p90. concurrency, rps are supported via
 	// Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).
+	// kv-cache, queue-depth and ttft-p90 are read from the serving engine metrics by the KEDA autoscaler.
 	// +optional
 	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
 	// ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container
@@ -33,6 +34,10 @@ type ComponentExtensionSpec struct {
 	// CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision
 	// +optional
 	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
+	// CanaryAnalysis steps the canary traffic percent up, promotes or rolls back the canary based on its metrics.
+	// Only applicable for raw deployment and multi node modes.
+	// +optional
+	CanaryAnalysis *CanaryAnalysisSpec `json:"canaryAnalysis,omitempty"`
 	// Labels that will be added to the component pod.
 	// More info: http://kubernetes.io/docs/user-guide/labels
 	// +optional
@@ -56,7 +61,7 @@ type ComponentExtensionSpec struct {
 }
 
 // ScaleMetric enum
-// +kubebuilder:validation:Enum=cpu;memory;concurrency;rps
+// +kubebuilder:validation:Enum=cpu;memory;concurrency;rps;tps;kv-cache;queue-depth;ttft-p90
 type ScaleMetric string
 
 const (
@@ -65,4 +70,16 @@ const (
 	MetricConcurrency ScaleMetric = "concurrency"
 	MetricRPS         ScaleMetric = "rps"
 	MetricTPS         ScaleMetric = "tps"
+	// MetricKVCache is the fraction of the KV cache used per replica
+	MetricKVCache ScaleMetric = "kv-cache"
+	// MetricQueueDepth is the number of requests waiting to be scheduled per replica
+	MetricQueueDepth ScaleMetric = "queue-depth"
+	// MetricTTFTP90 is the p90 time to first token in seconds
+	MetricTTFTP90 ScaleMetric = "ttft-p90"
 )
+
+// IsLLMMetric returns whether the metric is read from the serving engine metrics, which only the KEDA autoscaler
+// scales on
+func (m ScaleMetric) IsLLMMetric() bool {
+	return m == MetricKVCache || m == MetricQueueDepth || m == MetricTTFTP90
+}
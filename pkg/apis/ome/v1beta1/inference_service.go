@@ -53,6 +53,30 @@ type InferenceServiceSpec struct {
 	// AcceleratorSelector specifies accelerator selection preferences
 	// +optional
 	AcceleratorSelector *AcceleratorSelector `json:"acceleratorSelector,omitempty"`
+
+	// MCPServers lists the MCP tool servers the model can use.
+	// The operator creates an MCPRoute named after the InferenceService for them and injects
+	// the gateway endpoint of the route into the engine and router as MCP_GATEWAY_URL.
+	// Mutually exclusive with MCPRoute.RouteRef.
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=atomic
+	MCPServers []MCPServerReference `json:"mcpServers,omitempty"`
+
+	// MCPRoute configures the MCP route of the InferenceService, either by referencing an
+	// existing MCPRoute or by setting the policies of the route created for MCPServers.
+	// +optional
+	MCPRoute *MCPRouteConfig `json:"mcpRoute,omitempty"`
+
+	// ModelPlacement pulls the model weights onto nodes of the selected accelerator classes ahead of scale-out
+	// and defines how pods are scheduled onto nodes with the weights ready.
+	// +optional
+	ModelPlacement *ModelPlacementSpec `json:"modelPlacement,omitempty"`
+
+	// PDAutoscaling scales the engine and decoder together in PD-disaggregated mode, keeping the ratio of
+	// prefill to decode nodes within a band, instead of scaling each component on its own metrics.
+	// +optional
+	PDAutoscaling *PDAutoscalingSpec `json:"pdAutoscaling,omitempty"`
 }
 
 // AcceleratorSelector defines how to select accelerators for the InferenceService
@@ -291,6 +315,14 @@ type ModelRef struct {
 	// +kubebuilder:default="ome.io"
 	APIGroup *string `json:"apiGroup,omitempty"`
 
+	// Revision pins the model to a revision of its weights
+	// Matches either the version of the referenced model or the commit sha of its weights. The model agent keeps a
+	// single copy of the weights per node and replaces it in place, so a pin does not download another revision. It
+	// only holds back restarting the pods until the revision is ready on enough nodes.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Revision *string `json:"revision,omitempty"`
+
 	// Optional FineTunedWeights references
 	// References to fine-tuned weights that should be applied to the base model.
 	// +optional
@@ -6,6 +6,8 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/sgl-project/ome/pkg/constants"
 )
 
 // InferenceServiceStatus defines the observed state of InferenceService
@@ -29,6 +31,124 @@ type InferenceServiceStatus struct {
 	Components map[ComponentType]ComponentStatusSpec `json:"components,omitempty"`
 	// Model related statuses
 	ModelStatus ModelStatus `json:"modelStatus,omitempty"`
+	// RuntimeSelection explains which serving runtime was chosen and why
+	// +optional
+	RuntimeSelection *RuntimeSelectionStatus `json:"runtimeSelection,omitempty"`
+	// SchedulingFallback records runtime and accelerator combinations abandoned because their pods could not be scheduled
+	// +optional
+	SchedulingFallback *SchedulingFallbackStatus `json:"schedulingFallback,omitempty"`
+	// PDAutoscaling reports the replicas chosen by the coordinated autoscaling of the engine and decoder
+	// +optional
+	PDAutoscaling *PDAutoscalingStatus `json:"pdAutoscaling,omitempty"`
+}
+
+// SchedulingFallbackStatus records the runtime and accelerator class combinations that were
+// abandoned because their pods stayed unschedulable past the configured timeout
+type SchedulingFallbackStatus struct {
+	// ObservedGeneration is the InferenceService generation the attempts apply to.
+	// Attempts are discarded when the spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Attempts lists the abandoned combinations in the order they were tried
+	// +optional
+	// +listType=atomic
+	Attempts []SchedulingAttempt `json:"attempts,omitempty"`
+
+	// Exhausted is true when every compatible combination has been tried
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+}
+
+// SchedulingAttempt describes a runtime and accelerator class combination that failed to schedule
+type SchedulingAttempt struct {
+	// Runtime is the name of the serving runtime
+	Runtime string `json:"runtime"`
+
+	// AcceleratorClass is the name of the accelerator class, empty if none was selected
+	// +optional
+	AcceleratorClass string `json:"acceleratorClass,omitempty"`
+
+	// Reason is the scheduler message reported for the pending pods
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// AbandonedTime is when the controller fell back from this combination
+	AbandonedTime metav1.Time `json:"abandonedTime"`
+}
+
+// RuntimeSelectionStatus explains which serving runtime was chosen for the model and why
+type RuntimeSelectionStatus struct {
+	// Runtime is the name of the selected runtime
+	// +optional
+	Runtime string `json:"runtime,omitempty"`
+
+	// IsClusterRuntime indicates whether the selected runtime is a ClusterServingRuntime
+	// +optional
+	IsClusterRuntime bool `json:"isClusterRuntime,omitempty"`
+
+	// UserSpecified is true when the runtime was named in the spec rather than auto-selected
+	// +optional
+	UserSpecified bool `json:"userSpecified,omitempty"`
+
+	// Score is the compatibility score of the selected runtime
+	// +optional
+	Score int64 `json:"score,omitempty"`
+
+	// Reason explains why the selected runtime ranked above the other candidates
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// TotalRuntimes is the number of runtimes that were evaluated
+	// +optional
+	TotalRuntimes int32 `json:"totalRuntimes,omitempty"`
+
+	// Candidates lists the top compatible runtimes in ranking order
+	// +optional
+	// +listType=atomic
+	Candidates []RuntimeCandidate `json:"candidates,omitempty"`
+
+	// Rejected lists runtimes that were excluded from selection and why
+	// +optional
+	// +listType=atomic
+	Rejected []RuntimeRejection `json:"rejected,omitempty"`
+}
+
+// RuntimeCandidate describes a compatible runtime and its ranking score
+type RuntimeCandidate struct {
+	// Name of the runtime
+	Name string `json:"name"`
+
+	// IsClusterRuntime indicates whether the runtime is a ClusterServingRuntime
+	// +optional
+	IsClusterRuntime bool `json:"isClusterRuntime,omitempty"`
+
+	// Score is the compatibility score of the runtime
+	Score int64 `json:"score"`
+
+	// Priority is the runtime's priority for the matched model format
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Reasons contains human-readable details about the match
+	// +optional
+	// +listType=atomic
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// RuntimeRejection describes a runtime that was excluded from selection
+type RuntimeRejection struct {
+	// Name of the runtime
+	Name string `json:"name"`
+
+	// IsClusterRuntime indicates whether the runtime is a ClusterServingRuntime
+	// +optional
+	IsClusterRuntime bool `json:"isClusterRuntime,omitempty"`
+
+	// Reasons contains human-readable reasons for the rejection
+	// +optional
+	// +listType=atomic
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // ComponentStatusSpec describes the state of the component
@@ -63,6 +183,9 @@ type ComponentStatusSpec struct {
 	// SelectedAccelerator shows which AcceleratorClass was selected
 	// +optional
 	SelectedAccelerator *AcceleratorSelection `json:"selectedAccelerator,omitempty"`
+	// CanaryAnalysis is the state of the automated analysis of the canary revision
+	// +optional
+	CanaryAnalysis *CanaryAnalysisStatus `json:"canaryAnalysis,omitempty"`
 }
 
 // AcceleratorSelection shows what accelerator was selected and why
@@ -120,6 +243,10 @@ const (
 	RoutesReady apis.ConditionType = "RoutesReady"
 	// LatestDeploymentReady is set when underlying configurations for all components have reported readiness.
 	LatestDeploymentReady apis.ConditionType = "LatestDeploymentReady"
+	// RuntimeSelected is set when a serving runtime has been resolved for the model.
+	RuntimeSelected apis.ConditionType = "RuntimeSelected"
+	// ModelRevisionResolved is set when the target model revision is ready on at least one node.
+	ModelRevisionResolved apis.ConditionType = "ModelRevisionResolved"
 )
 
 // RouterConditionType represents a Router condition value
@@ -132,6 +259,16 @@ const (
 	RouterReady apis.ConditionType = "RouterReady"
 )
 
+// CanaryAnalysisConditionType represents the canary analysis condition of a component
+const (
+	// EngineCanaryAnalysis is set when the engine canary is analyzed, promoted or rolled back.
+	EngineCanaryAnalysis apis.ConditionType = "EngineCanaryAnalysis"
+	// DecoderCanaryAnalysis is set when the decoder canary is analyzed, promoted or rolled back.
+	DecoderCanaryAnalysis apis.ConditionType = "DecoderCanaryAnalysis"
+	// RouterCanaryAnalysis is set when the router canary is analyzed, promoted or rolled back.
+	RouterCanaryAnalysis apis.ConditionType = "RouterCanaryAnalysis"
+)
+
 type ModelStatus struct {
 	// Whether the available predictor endpoints reflect the current Spec or is in transition
 	// +kubebuilder:default=UpToDate
@@ -156,6 +293,12 @@ type ModelRevisionStates struct {
 	ActiveModelState ModelState `json:"activeModelState"`
 	// +kubebuilder:default=""
 	TargetModelState ModelState `json:"targetModelState,omitempty"`
+	// Revision of the model weights the pods were last rolled to
+	// +optional
+	ActiveModelRevision string `json:"activeModelRevision,omitempty"`
+	// Revision of the model weights the pods are rolled to once it is ready on enough nodes
+	// +optional
+	TargetModelRevision string `json:"targetModelRevision,omitempty"`
 }
 
 type ModelCopies struct {
@@ -303,6 +446,11 @@ func (ss *InferenceServiceStatus) IsConditionUnknown(t apis.ConditionType) bool
 	return condition == nil || condition.Status == v1.ConditionUnknown
 }
 
+// ClearCondition removes a condition from the status
+func (ss *InferenceServiceStatus) ClearCondition(conditionType apis.ConditionType) {
+	_ = conditionSet.Manage(ss).ClearCondition(conditionType)
+}
+
 // SetCondition sets a condition on the status using the conditionSet
 func (ss *InferenceServiceStatus) SetCondition(conditionType apis.ConditionType, condition *apis.Condition) {
 	switch {
@@ -333,3 +481,18 @@ func (ss *InferenceServiceStatus) SetCondition(conditionType apis.ConditionType,
 		conditionSet.Manage(ss).MarkFalse(conditionType, condition.Reason, condition.Message)
 	}
 }
+
+// GetCanaryTrafficPercent returns the percent of traffic routed to the canary workload of a RawDeployment or
+// MultiNode component, and whether the component is rolled out with a canary workload
+func (ss *InferenceServiceStatus) GetCanaryTrafficPercent(component ComponentType) (int64, bool) {
+	componentStatus, ok := ss.Components[component]
+	if !ok {
+		return 0, false
+	}
+	for _, target := range componentStatus.Traffic {
+		if target.Tag == constants.CanaryTrafficTag && target.Percent != nil {
+			return *target.Percent, true
+		}
+	}
+	return 0, false
+}
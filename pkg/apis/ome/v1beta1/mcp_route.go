@@ -0,0 +1,409 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MCPRoute defines how requests reaching the managed MCP gateway are routed to MCPServers
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=mcproutes,shortName=mcpr
+// +kubebuilder:printcolumn:name="Gateway",type=string,JSONPath=`.status.gatewayURL`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type MCPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPRouteSpec   `json:"spec"`
+	Status MCPRouteStatus `json:"status,omitempty"`
+}
+
+// MCPRouteList contains a list of MCPRoute
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type MCPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPRoute `json:"items"`
+}
+
+// MCPRouteSpec defines the backends and policies of a route
+type MCPRouteSpec struct {
+	// BackendRefs defines where requests are routed.
+	// All backends must be MCPServers in the same namespace as the route.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	BackendRefs []MCPBackendRef `json:"backendRefs"`
+
+	// Matches routes matching requests to their own backends.
+	// Requests that match no rule are routed to BackendRefs.
+	// +optional
+	// +listType=atomic
+	Matches []MCPRouteMatch `json:"matches,omitempty"`
+
+	MCPRoutePolicy `json:",inline"`
+}
+
+// MCPRoutePolicy defines the policies applied by the gateway to requests of a route
+type MCPRoutePolicy struct {
+	// Authentication policy of the route
+	// +optional
+	Authentication *MCPAuthentication `json:"authentication,omitempty"`
+
+	// Authorization policy of the route
+	// +optional
+	Authorization *MCPAuthorization `json:"authorization,omitempty"`
+
+	// RateLimit of the route
+	// +optional
+	RateLimit *MCPRateLimit `json:"rateLimit,omitempty"`
+
+	// Filters modify the requests and responses of the route
+	// +optional
+	// +listType=atomic
+	Filters []MCPRouteFilter `json:"filters,omitempty"`
+}
+
+// MCPBackendRef references an MCPServer backend of a route
+type MCPBackendRef struct {
+	// ServerRef references an MCPServer in the same namespace
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// Weight of the backend when splitting traffic across backends
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// MCPRouteMatch routes requests matching all of its conditions to its backends
+type MCPRouteMatch struct {
+	// Tools to match, supporting "*" wildcards such as "db_*" or "*_query"
+	// +optional
+	// +listType=atomic
+	Tools []string `json:"tools,omitempty"`
+
+	// ToolMatch defines advanced tool matching, as an alternative to Tools
+	// +optional
+	ToolMatch *MCPToolMatcher `json:"toolMatch,omitempty"`
+
+	// Method is the JSON-RPC method to match, such as tools/call or tools/list
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Headers to match
+	// +optional
+	// +listType=atomic
+	Headers []MCPHeaderMatch `json:"headers,omitempty"`
+
+	// BackendRefs for matching requests, overriding the route backends
+	// +optional
+	// +listType=atomic
+	BackendRefs []MCPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// MCPToolMatcher matches tool names
+type MCPToolMatcher struct {
+	// PrefixMatch matches tools with this prefix
+	// +optional
+	PrefixMatch *string `json:"prefixMatch,omitempty"`
+
+	// ExactMatch matches the exact tool name
+	// +optional
+	ExactMatch *string `json:"exactMatch,omitempty"`
+
+	// RegexMatch matches tools using a regular expression
+	// +optional
+	RegexMatch *string `json:"regexMatch,omitempty"`
+}
+
+// MCPHeaderMatchType is how a header value is matched
+type MCPHeaderMatchType string
+
+const (
+	MCPHeaderMatchExact  MCPHeaderMatchType = "Exact"
+	MCPHeaderMatchPrefix MCPHeaderMatchType = "Prefix"
+	MCPHeaderMatchRegex  MCPHeaderMatchType = "Regex"
+)
+
+// MCPHeaderMatch matches a request header
+type MCPHeaderMatch struct {
+	// Name of the header
+	Name string `json:"name"`
+
+	// Value to match
+	Value string `json:"value"`
+
+	// Type of match
+	// +kubebuilder:validation:Enum=Exact;Prefix;Regex
+	// +kubebuilder:default=Exact
+	// +optional
+	Type MCPHeaderMatchType `json:"type,omitempty"`
+}
+
+// MCPRouteFilterType is the type of a route filter
+type MCPRouteFilterType string
+
+const (
+	MCPRequestHeaderModifier  MCPRouteFilterType = "RequestHeaderModifier"
+	MCPResponseHeaderModifier MCPRouteFilterType = "ResponseHeaderModifier"
+)
+
+// MCPRouteFilter modifies the requests or responses of a route
+type MCPRouteFilter struct {
+	// Type of the filter
+	// +kubebuilder:validation:Enum=RequestHeaderModifier;ResponseHeaderModifier
+	Type MCPRouteFilterType `json:"type"`
+
+	// RequestHeaderModifier modifies request headers
+	// +optional
+	RequestHeaderModifier *MCPHeaderModifier `json:"requestHeaderModifier,omitempty"`
+
+	// ResponseHeaderModifier modifies response headers
+	// +optional
+	ResponseHeaderModifier *MCPHeaderModifier `json:"responseHeaderModifier,omitempty"`
+}
+
+// MCPHeaderModifier sets, adds and removes headers
+type MCPHeaderModifier struct {
+	// Set headers, replacing existing values
+	// +optional
+	// +listType=atomic
+	Set []MCPHeader `json:"set,omitempty"`
+
+	// Add headers, appending to existing values
+	// +optional
+	// +listType=atomic
+	Add []MCPHeader `json:"add,omitempty"`
+
+	// Remove headers
+	// +optional
+	// +listType=atomic
+	Remove []string `json:"remove,omitempty"`
+}
+
+// MCPHeader is a header name and value
+type MCPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// MCPAuthentication defines how the gateway authenticates clients of a route.
+// When several methods are set, requests must satisfy all of them.
+type MCPAuthentication struct {
+	// OIDC defines OpenID Connect authentication
+	// +optional
+	OIDC *OIDCAuthentication `json:"oidc,omitempty"`
+
+	// JWT defines JWT bearer token authentication
+	// +optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// APIKey defines API key authentication
+	// +optional
+	APIKey *APIKeyAuthentication `json:"apiKey,omitempty"`
+}
+
+// OIDCAuthentication defines OpenID Connect authentication
+type OIDCAuthentication struct {
+	// Issuer is the OIDC issuer URL
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OAuth2 client ID
+	ClientID string `json:"clientID"`
+
+	// ClientSecretRef references a Secret key containing the client secret
+	ClientSecretRef v1.SecretKeySelector `json:"clientSecretRef"`
+
+	// Scopes are the OAuth2 scopes to request
+	// +optional
+	// +listType=atomic
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// JWTAuthentication defines JWT bearer token authentication
+type JWTAuthentication struct {
+	// Audiences are the accepted JWT audiences
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Audiences []string `json:"audiences"`
+
+	// JWKSURI is the URI of the JSON Web Key Set used to verify tokens
+	JWKSURI string `json:"jwksURI"`
+
+	// Issuer is the expected JWT issuer
+	// +optional
+	Issuer *string `json:"issuer,omitempty"`
+}
+
+// APIKeyAuthentication defines API key authentication
+type APIKeyAuthentication struct {
+	// Header is the name of the header containing the API key
+	// +kubebuilder:default="X-API-Key"
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// SecretRefs reference Secret keys containing valid API keys
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	SecretRefs []v1.SecretKeySelector `json:"secretRefs"`
+}
+
+// MCPAuthorization defines which principals may use which tools.
+// A request is allowed when any rule matches its principal and tool.
+type MCPAuthorization struct {
+	// Rules of the authorization policy
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Rules []MCPAuthorizationRule `json:"rules"`
+}
+
+// MCPAuthorizationRule grants permissions to principals
+type MCPAuthorizationRule struct {
+	// Principals the rule applies to, such as "user:alice", "group:developers" or "serviceaccount:my-sa"
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Principals []string `json:"principals"`
+
+	// Permissions granted to the principals
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Permissions []MCPPermission `json:"permissions"`
+}
+
+// MCPPermission allows actions on tools
+type MCPPermission struct {
+	// Tools the permission applies to, supporting "*" wildcards
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Tools []string `json:"tools"`
+
+	// Actions allowed, tools/call or tools/list
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Actions []string `json:"actions"`
+}
+
+// MCPRateLimit defines the rate limits of a route
+type MCPRateLimit struct {
+	// Limits of the route, all of which must be satisfied
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Limits []MCPRateLimitRule `json:"limits"`
+}
+
+// MCPRateLimitDimension is what requests are counted by
+// +kubebuilder:validation:Enum=user;ip;tool;principal;namespace
+type MCPRateLimitDimension string
+
+const (
+	MCPRateLimitByUser      MCPRateLimitDimension = "user"
+	MCPRateLimitByIP        MCPRateLimitDimension = "ip"
+	MCPRateLimitByTool      MCPRateLimitDimension = "tool"
+	MCPRateLimitByPrincipal MCPRateLimitDimension = "principal"
+	MCPRateLimitByNamespace MCPRateLimitDimension = "namespace"
+)
+
+// MCPRateLimitUnit is the time window of a rate limit
+// +kubebuilder:validation:Enum=second;minute;hour;day
+type MCPRateLimitUnit string
+
+const (
+	MCPRateLimitPerSecond MCPRateLimitUnit = "second"
+	MCPRateLimitPerMinute MCPRateLimitUnit = "minute"
+	MCPRateLimitPerHour   MCPRateLimitUnit = "hour"
+	MCPRateLimitPerDay    MCPRateLimitUnit = "day"
+)
+
+// MCPRateLimitRule limits the requests per unit of time for each value of a dimension
+type MCPRateLimitRule struct {
+	// Dimension requests are counted by
+	Dimension MCPRateLimitDimension `json:"dimension"`
+
+	// Tools restricts the limit to specific tools, supporting "*" wildcards
+	// +optional
+	// +listType=atomic
+	Tools []string `json:"tools,omitempty"`
+
+	// Requests is the maximum number of requests per unit
+	// +kubebuilder:validation:Minimum=1
+	Requests int32 `json:"requests"`
+
+	// Unit is the time window of the limit
+	Unit MCPRateLimitUnit `json:"unit"`
+}
+
+// MCPRouteStatus defines the observed state of an MCPRoute
+type MCPRouteStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// GatewayURL is the endpoint MCP clients use for this route
+	// +optional
+	GatewayURL string `json:"gatewayURL,omitempty"`
+
+	// BackendStatuses shows the state of each backend
+	// +optional
+	// +listType=atomic
+	BackendStatuses []MCPBackendStatus `json:"backendStatuses,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MCPBackendStatus is the state of a route backend
+type MCPBackendStatus struct {
+	// ServerRef is the MCPServer backend
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// Ready indicates the backend can serve requests
+	Ready bool `json:"ready"`
+
+	// Endpoint the gateway routes to
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Message explains why the backend is not ready
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MCPServerReference references an MCPServer used by an InferenceService
+type MCPServerReference struct {
+	// ServerRef references an MCPServer in the same namespace as the InferenceService
+	ServerRef v1.LocalObjectReference `json:"serverRef"`
+
+	// Weight of the server when splitting traffic
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// MCPRouteConfig configures the MCP route of an InferenceService.
+// Either RouteRef references an existing MCPRoute, or the remaining fields configure the route
+// created from the InferenceService MCPServers.
+type MCPRouteConfig struct {
+	// RouteRef references an existing MCPRoute in the same namespace
+	// +optional
+	RouteRef *v1.LocalObjectReference `json:"routeRef,omitempty"`
+
+	// Matches of the created route
+	// +optional
+	// +listType=atomic
+	Matches []MCPRouteMatch `json:"matches,omitempty"`
+
+	MCPRoutePolicy `json:",inline"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MCPRoute{}, &MCPRouteList{})
+}
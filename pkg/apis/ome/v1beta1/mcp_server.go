@@ -0,0 +1,256 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MCPServer defines a Model Context Protocol tool server, either hosted in the cluster or reached at a remote URL
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=mcpservers,shortName=mcps
+// +kubebuilder:printcolumn:name="Transport",type=string,JSONPath=`.spec.transport`
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type MCPServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPServerSpec   `json:"spec,omitempty"`
+	Status MCPServerStatus `json:"status,omitempty"`
+}
+
+// MCPServerList contains a list of MCPServer
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+type MCPServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPServer `json:"items"`
+}
+
+// MCPServerSpec defines the desired state of an MCPServer.
+// An MCPServer is either Hosted within the cluster or a Remote external service.
+// +kubebuilder:validation:XValidation:rule="has(self.hosted) || has(self.remote)",message="either hosted or remote must be specified"
+// +kubebuilder:validation:XValidation:rule="!(has(self.hosted) && has(self.remote))",message="hosted and remote are mutually exclusive"
+type MCPServerSpec struct {
+	// Hosted defines a server that runs as pods within the cluster
+	// +optional
+	Hosted *HostedMCPServer `json:"hosted,omitempty"`
+
+	// Remote defines a server that is accessed via an external URL
+	// +optional
+	Remote *RemoteMCPServer `json:"remote,omitempty"`
+
+	// Transport specifies the transport protocol the server speaks.
+	// Hosted stdio servers are exposed over streamable HTTP through a bridge injected by the controller.
+	// +kubebuilder:validation:Enum=stdio;streamable-http;sse
+	// +kubebuilder:default=stdio
+	// +optional
+	Transport MCPTransportType `json:"transport,omitempty"`
+
+	// Capabilities defines the MCP features supported by this server
+	// +optional
+	Capabilities *MCPCapabilities `json:"capabilities,omitempty"`
+
+	// Version of the MCP server software
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// PermissionProfile defines the Kubernetes API and network access granted to a hosted server
+	// +optional
+	PermissionProfile *PermissionProfileSource `json:"permissionProfile,omitempty"`
+
+	// ToolsFilter restricts the tools exposed by this server to the listed names
+	// +optional
+	// +listType=set
+	ToolsFilter []string `json:"toolsFilter,omitempty"`
+}
+
+// MCPTransportType is the transport protocol used for MCP communication
+type MCPTransportType string
+
+const (
+	// MCPTransportStdio is a server that reads requests from stdin and writes responses to stdout
+	MCPTransportStdio MCPTransportType = "stdio"
+	// MCPTransportStreamableHTTP is a server that serves the MCP streamable HTTP transport
+	MCPTransportStreamableHTTP MCPTransportType = "streamable-http"
+	// MCPTransportSSE is a server that serves the legacy MCP HTTP with server-sent events transport
+	MCPTransportSSE MCPTransportType = "sse"
+)
+
+// HostedMCPServer defines a server that runs as pods within the cluster
+type HostedMCPServer struct {
+	// PodSpec defines the pod template of the server.
+	// The container running the MCP server must be named "mcp-server".
+	PodSpec v1.PodTemplateSpec `json:"podSpec"`
+
+	// Replicas is the number of desired replicas of the server.
+	// Stdio servers are limited to a single replica.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Port the server listens on for HTTP transports, and the port the stdio bridge listens on.
+	// Defaults to the first container port of the mcp-server container, or 8080.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// Path the server serves MCP requests on
+	// +kubebuilder:default="/mcp"
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// RemoteMCPServer defines a server that is accessed via an external URL
+type RemoteMCPServer struct {
+	// URL is the external URL of the remote MCP server
+	// +kubebuilder:validation:Pattern=`^https?://.*`
+	URL string `json:"url"`
+}
+
+// MCPCapabilities defines the MCP features supported by a server
+type MCPCapabilities struct {
+	// Tools indicates the server exposes tools
+	// +optional
+	Tools bool `json:"tools,omitempty"`
+
+	// Resources indicates the server exposes resources
+	// +optional
+	Resources bool `json:"resources,omitempty"`
+
+	// Prompts indicates the server exposes prompts
+	// +optional
+	Prompts bool `json:"prompts,omitempty"`
+}
+
+// PermissionProfileSource selects the permission profile of a hosted server
+// +kubebuilder:validation:XValidation:rule="(has(self.builtin) ? 1 : 0) + (has(self.configMap) ? 1 : 0) + (has(self.inline) ? 1 : 0) <= 1",message="at most one of builtin, configMap, or inline can be set"
+type PermissionProfileSource struct {
+	// Builtin selects a permission profile provided by OME
+	// +optional
+	Builtin *BuiltinPermissionProfile `json:"builtin,omitempty"`
+
+	// ConfigMap selects a key of a ConfigMap in the server namespace holding a PermissionProfileSpec in YAML or JSON
+	// +optional
+	ConfigMap *v1.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// Inline defines the permission profile directly
+	// +optional
+	Inline *PermissionProfileSpec `json:"inline,omitempty"`
+}
+
+// BuiltinPermissionProfile is the name of a permission profile provided by OME
+// +kubebuilder:validation:Enum=none;network
+type BuiltinPermissionProfile string
+
+const (
+	// BuiltinPermissionProfileNone grants no Kubernetes API access and blocks all egress except DNS
+	BuiltinPermissionProfileNone BuiltinPermissionProfile = "none"
+	// BuiltinPermissionProfileNetwork grants no Kubernetes API access and leaves egress unrestricted
+	BuiltinPermissionProfileNetwork BuiltinPermissionProfile = "network"
+)
+
+// PermissionProfileSpec defines the permissions granted to a hosted server
+type PermissionProfileSpec struct {
+	// Allow specifies the permissions granted to the server
+	// +listType=atomic
+	Allow []PermissionRule `json:"allow"`
+}
+
+// PermissionRule grants Kubernetes API or network access
+type PermissionRule struct {
+	// KubeResources grants access to Kubernetes API resources through a Role bound to the server service account
+	// +optional
+	KubeResources *KubeResourcePermission `json:"kubeResources,omitempty"`
+
+	// Network allows egress to the listed destinations
+	// +optional
+	Network *NetworkPermission `json:"network,omitempty"`
+}
+
+// KubeResourcePermission grants access to Kubernetes API resources
+type KubeResourcePermission struct {
+	// APIGroups of the resources, "" for the core group
+	// +listType=atomic
+	APIGroups []string `json:"apiGroups"`
+
+	// Resources the server may access
+	// +listType=atomic
+	Resources []string `json:"resources"`
+
+	// Verbs the server may use
+	// +listType=atomic
+	Verbs []string `json:"verbs"`
+
+	// Namespaces restricts the permissions to specific namespaces.
+	// Only the namespace of the MCPServer is supported; if empty, permissions apply to that namespace.
+	// +optional
+	// +listType=atomic
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// NetworkPermission allows egress to the listed destinations
+type NetworkPermission struct {
+	// AllowHost specifies allowed destination hosts.
+	// In-cluster service hosts such as "postgres.my-team.svc.cluster.local" can be enforced with a NetworkPolicy.
+	// +optional
+	// +listType=atomic
+	AllowHost []string `json:"allowHost,omitempty"`
+
+	// AllowCIDR specifies allowed destination CIDR blocks
+	// +optional
+	// +listType=atomic
+	AllowCIDR []string `json:"allowCIDR,omitempty"`
+}
+
+// NetworkEnforcementMode is how the network permissions of a server are enforced
+// +kubebuilder:validation:Enum=NetworkPolicy;ServiceMesh;None
+type NetworkEnforcementMode string
+
+const (
+	// NetworkEnforcementNetworkPolicy means egress is restricted with a Kubernetes NetworkPolicy
+	NetworkEnforcementNetworkPolicy NetworkEnforcementMode = "NetworkPolicy"
+	// NetworkEnforcementServiceMesh means egress is restricted by a service mesh
+	NetworkEnforcementServiceMesh NetworkEnforcementMode = "ServiceMesh"
+	// NetworkEnforcementNone means egress is not restricted
+	NetworkEnforcementNone NetworkEnforcementMode = "None"
+)
+
+// MCPServerStatus defines the observed state of an MCPServer
+type MCPServerStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// URL is the endpoint MCP clients and the gateway use to reach the server
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ReadyReplicas is the number of ready replicas of a hosted server
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// NetworkEnforcement indicates how the network permissions of the server are enforced
+	// +optional
+	NetworkEnforcement *NetworkEnforcementMode `json:"networkEnforcement,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MCP condition types
+const (
+	// MCPConditionReady indicates the server or route can serve requests
+	MCPConditionReady = "Ready"
+)
+
+func init() {
+	SchemeBuilder.Register(&MCPServer{}, &MCPServerList{})
+}
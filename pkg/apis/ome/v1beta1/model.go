@@ -401,6 +401,25 @@ type ModelStatusSpec struct {
 
 	// +listType=atomic
 	NodesFailed []string `json:"nodesFailed,omitempty"`
+
+	// Revisions of the model weights and the nodes they are ready on
+	// +listType=map
+	// +listMapKey=revision
+	// +optional
+	Revisions []ModelRevisionStatus `json:"revisions,omitempty"`
+}
+
+// ModelRevisionStatus defines the nodes a revision of the model weights is ready on
+type ModelRevisionStatus struct {
+	// Revision is the commit sha of the weights, or the model version when the sha is unknown
+	Revision string `json:"revision"`
+
+	// Version of the model the weights were downloaded for
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +listType=atomic
+	NodesReady []string `json:"nodesReady,omitempty"`
 }
 
 // BaseModel is the Schema for the basemodels API
@@ -0,0 +1,29 @@
+package v1beta1
+
+// ModelPlacementSpec configures the nodes the model weights are pulled onto ahead of scale-out, and how the
+// engine and decoder pods are scheduled onto nodes with the weights ready.
+type ModelPlacementSpec struct {
+	// PrewarmNodes is the number of nodes in the accelerator class of the engine and decoder the model weights are
+	// kept ready on, counting the nodes they are already ready on. Defaults to the nodes needed to run the maximum
+	// replicas of the engine and decoder.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PrewarmNodes *int32 `json:"prewarmNodes,omitempty"`
+
+	// Scheduling defines whether pods require a node with the model weights ready, or only prefer one.
+	// +kubebuilder:default=Required
+	// +optional
+	Scheduling ModelReadyScheduling `json:"scheduling,omitempty"`
+}
+
+// ModelReadyScheduling defines how pods are scheduled onto nodes with the model weights ready
+// +kubebuilder:validation:Enum=Required;Preferred
+type ModelReadyScheduling string
+
+const (
+	// ModelReadyRequired only schedules pods onto nodes with the model weights ready.
+	ModelReadyRequired ModelReadyScheduling = "Required"
+	// ModelReadyPreferred prefers nodes with the model weights ready, so pods can still be scheduled while the
+	// weights are pulled onto more nodes.
+	ModelReadyPreferred ModelReadyScheduling = "Preferred"
+)
@@ -0,0 +1,59 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PDAutoscalingSpec coordinates the autoscaling of the engine, which runs prefill, and the decoder in
+// PD-disaggregated mode. The controller scales both components together within their min and max replicas:
+// the engine on its queue of waiting requests and the decoder on its KV cache utilization, while the number of
+// prefill nodes per decode node is kept within the ratio band. Nodes count every pod of a multi-node replica.
+// The autoscalers of the engine and decoder are pinned to the chosen replicas.
+// Only applicable for RawDeployment and MultiNode components.
+type PDAutoscalingSpec struct {
+	// MinRatio is the smallest number of prefill nodes per decode node, as a decimal number.
+	//
+	// Example:
+	//   "0.5" - At least one prefill node for every two decode nodes.
+	// +optional
+	MinRatio string `json:"minRatio,omitempty"`
+
+	// MaxRatio is the largest number of prefill nodes per decode node, as a decimal number.
+	// +optional
+	MaxRatio string `json:"maxRatio,omitempty"`
+
+	// PrefillQueueDepth is the target number of requests waiting per engine replica. Defaults to 5.
+	// +optional
+	PrefillQueueDepth string `json:"prefillQueueDepth,omitempty"`
+
+	// DecodeKVCacheUtilization is the target fraction of the KV cache used per decoder replica. Defaults to 0.8.
+	// +optional
+	DecodeKVCacheUtilization string `json:"decodeKVCacheUtilization,omitempty"`
+
+	// IntervalSeconds is how often the replicas of the engine and decoder are evaluated.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+}
+
+// PDAutoscalingStatus is the state of the coordinated autoscaling of the engine and decoder
+type PDAutoscalingStatus struct {
+	// PrefillReplicas is the number of engine replicas chosen by the last evaluation
+	PrefillReplicas int32 `json:"prefillReplicas"`
+
+	// DecodeReplicas is the number of decoder replicas chosen by the last evaluation
+	DecodeReplicas int32 `json:"decodeReplicas"`
+
+	// LastScaleTime is when the replicas of the engine or decoder last changed
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// NextEvaluationTime is when the replicas are evaluated next
+	// +optional
+	NextEvaluationTime *metav1.Time `json:"nextEvaluationTime,omitempty"`
+
+	// Message describes the result of the last evaluation
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -16,6 +16,28 @@ import (
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyAuthentication) DeepCopyInto(out *APIKeyAuthentication) {
+	*out = *in
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]v1.SecretKeySelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyAuthentication.
+func (in *APIKeyAuthentication) DeepCopy() *APIKeyAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceleratorCapabilities) DeepCopyInto(out *AcceleratorCapabilities) {
 	*out = *in
@@ -157,6 +179,11 @@ func (in *AcceleratorClassStatus) DeepCopyInto(out *AcceleratorClassStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeCapacity != nil {
+		in, out := &in.NodeCapacity, &out.NodeCapacity
+		*out = make([]AcceleratorNodeCapacity, len(*in))
+		copy(*out, *in)
+	}
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -371,6 +398,21 @@ func (in *AcceleratorModelConfig) DeepCopy() *AcceleratorModelConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorNodeCapacity) DeepCopyInto(out *AcceleratorNodeCapacity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorNodeCapacity.
+func (in *AcceleratorNodeCapacity) DeepCopy() *AcceleratorNodeCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorNodeCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceleratorPerformance) DeepCopyInto(out *AcceleratorPerformance) {
 	*out = *in
@@ -824,6 +866,74 @@ func (in *BenchmarkJobStatus) DeepCopy() *BenchmarkJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysisQueries) DeepCopyInto(out *CanaryAnalysisQueries) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisQueries.
+func (in *CanaryAnalysisQueries) DeepCopy() *CanaryAnalysisQueries {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysisQueries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysisSpec) DeepCopyInto(out *CanaryAnalysisSpec) {
+	*out = *in
+	if in.MaxTTFTIncreasePercent != nil {
+		in, out := &in.MaxTTFTIncreasePercent, &out.MaxTTFTIncreasePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinThroughputPercent != nil {
+		in, out := &in.MinThroughputPercent, &out.MinThroughputPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = new(CanaryAnalysisQueries)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisSpec.
+func (in *CanaryAnalysisSpec) DeepCopy() *CanaryAnalysisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryAnalysisStatus) DeepCopyInto(out *CanaryAnalysisStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextAnalysisTime != nil {
+		in, out := &in.NextAnalysisTime, &out.NextAnalysisTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryAnalysisStatus.
+func (in *CanaryAnalysisStatus) DeepCopy() *CanaryAnalysisStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryAnalysisStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBaseModel) DeepCopyInto(out *ClusterBaseModel) {
 	*out = *in
@@ -975,6 +1085,11 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CanaryAnalysis != nil {
+		in, out := &in.CanaryAnalysis, &out.CanaryAnalysis
+		*out = new(CanaryAnalysisSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -1051,6 +1166,11 @@ func (in *ComponentStatusSpec) DeepCopyInto(out *ComponentStatusSpec) {
 		*out = new(AcceleratorSelection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CanaryAnalysis != nil {
+		in, out := &in.CanaryAnalysis, &out.CanaryAnalysis
+		*out = new(CanaryAnalysisStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusSpec.
@@ -1352,6 +1472,32 @@ func (in *FineTunedWeightSpec) DeepCopy() *FineTunedWeightSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedMCPServer) DeepCopyInto(out *HostedMCPServer) {
+	*out = *in
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedMCPServer.
+func (in *HostedMCPServer) DeepCopy() *HostedMCPServer {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedMCPServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HuggingFaceSecretReference) DeepCopyInto(out *HuggingFaceSecretReference) {
 	*out = *in
@@ -1480,6 +1626,28 @@ func (in *InferenceServiceSpec) DeepCopyInto(out *InferenceServiceSpec) {
 		*out = new(AcceleratorSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MCPServers != nil {
+		in, out := &in.MCPServers, &out.MCPServers
+		*out = make([]MCPServerReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MCPRoute != nil {
+		in, out := &in.MCPRoute, &out.MCPRoute
+		*out = new(MCPRouteConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelPlacement != nil {
+		in, out := &in.ModelPlacement, &out.ModelPlacement
+		*out = new(ModelPlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDAutoscaling != nil {
+		in, out := &in.PDAutoscaling, &out.PDAutoscaling
+		*out = new(PDAutoscalingSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceSpec.
@@ -1514,6 +1682,21 @@ func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
 		}
 	}
 	in.ModelStatus.DeepCopyInto(&out.ModelStatus)
+	if in.RuntimeSelection != nil {
+		in, out := &in.RuntimeSelection, &out.RuntimeSelection
+		*out = new(RuntimeSelectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulingFallback != nil {
+		in, out := &in.SchedulingFallback, &out.SchedulingFallback
+		*out = new(SchedulingFallbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDAutoscaling != nil {
+		in, out := &in.PDAutoscaling, &out.PDAutoscaling
+		*out = new(PDAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceServiceStatus.
@@ -1526,6 +1709,31 @@ func (in *InferenceServiceStatus) DeepCopy() *InferenceServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthentication) DeepCopyInto(out *JWTAuthentication) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthentication.
+func (in *JWTAuthentication) DeepCopy() *JWTAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KedaConfig) DeepCopyInto(out *KedaConfig) {
 	*out = *in
@@ -1546,6 +1754,41 @@ func (in *KedaConfig) DeepCopy() *KedaConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeResourcePermission) DeepCopyInto(out *KubeResourcePermission) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeResourcePermission.
+func (in *KubeResourcePermission) DeepCopy() *KubeResourcePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeResourcePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderSpec) DeepCopyInto(out *LeaderSpec) {
 	*out = *in
@@ -1568,162 +1811,890 @@ func (in *LeaderSpec) DeepCopy() *LeaderSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
+func (in *MCPAuthentication) DeepCopyInto(out *MCPAuthentication) {
 	*out = *in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(APIKeyAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCopies.
-func (in *ModelCopies) DeepCopy() *ModelCopies {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuthentication.
+func (in *MCPAuthentication) DeepCopy() *MCPAuthentication {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelCopies)
+	out := new(MCPAuthentication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelExtensionSpec) DeepCopyInto(out *ModelExtensionSpec) {
+func (in *MCPAuthorization) DeepCopyInto(out *MCPAuthorization) {
 	*out = *in
-	if in.DisplayName != nil {
-		in, out := &in.DisplayName, &out.DisplayName
-		*out = new(string)
-		**out = **in
-	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(string)
-		**out = **in
-	}
-	if in.Disabled != nil {
-		in, out := &in.Disabled, &out.Disabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Vendor != nil {
-		in, out := &in.Vendor, &out.Vendor
-		*out = new(string)
-		**out = **in
-	}
-	if in.CompartmentID != nil {
-		in, out := &in.CompartmentID, &out.CompartmentID
-		*out = new(string)
-		**out = **in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]MCPAuthorizationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelExtensionSpec.
-func (in *ModelExtensionSpec) DeepCopy() *ModelExtensionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuthorization.
+func (in *MCPAuthorization) DeepCopy() *MCPAuthorization {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelExtensionSpec)
+	out := new(MCPAuthorization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelFormat) DeepCopyInto(out *ModelFormat) {
+func (in *MCPAuthorizationRule) DeepCopyInto(out *MCPAuthorizationRule) {
 	*out = *in
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(string)
-		**out = **in
+	if in.Principals != nil {
+		in, out := &in.Principals, &out.Principals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Operator != nil {
-		in, out := &in.Operator, &out.Operator
-		*out = new(RuntimeSelectorOperator)
-		**out = **in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]MCPPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFormat.
-func (in *ModelFormat) DeepCopy() *ModelFormat {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuthorizationRule.
+func (in *MCPAuthorizationRule) DeepCopy() *MCPAuthorizationRule {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelFormat)
+	out := new(MCPAuthorizationRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelFrameworkSpec) DeepCopyInto(out *ModelFrameworkSpec) {
+func (in *MCPBackendRef) DeepCopyInto(out *MCPBackendRef) {
 	*out = *in
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(string)
-		**out = **in
-	}
-	if in.Operator != nil {
-		in, out := &in.Operator, &out.Operator
-		*out = new(RuntimeSelectorOperator)
+	out.ServerRef = in.ServerRef
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFrameworkSpec.
-func (in *ModelFrameworkSpec) DeepCopy() *ModelFrameworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPBackendRef.
+func (in *MCPBackendRef) DeepCopy() *MCPBackendRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelFrameworkSpec)
+	out := new(MCPBackendRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+func (in *MCPBackendStatus) DeepCopyInto(out *MCPBackendStatus) {
 	*out = *in
-	if in.Kind != nil {
-		in, out := &in.Kind, &out.Kind
-		*out = new(string)
-		**out = **in
-	}
-	if in.APIGroup != nil {
-		in, out := &in.APIGroup, &out.APIGroup
-		*out = new(string)
-		**out = **in
-	}
-	if in.FineTunedWeights != nil {
-		in, out := &in.FineTunedWeights, &out.FineTunedWeights
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.ServerRef = in.ServerRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
-func (in *ModelRef) DeepCopy() *ModelRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPBackendStatus.
+func (in *MCPBackendStatus) DeepCopy() *MCPBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRef)
+	out := new(MCPBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRevisionStates) DeepCopyInto(out *ModelRevisionStates) {
+func (in *MCPCapabilities) DeepCopyInto(out *MCPCapabilities) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRevisionStates.
-func (in *ModelRevisionStates) DeepCopy() *ModelRevisionStates {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPCapabilities.
+func (in *MCPCapabilities) DeepCopy() *MCPCapabilities {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRevisionStates)
+	out := new(MCPCapabilities)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSizeRangeSpec) DeepCopyInto(out *ModelSizeRangeSpec) {
+func (in *MCPHeader) DeepCopyInto(out *MCPHeader) {
 	*out = *in
-	if in.Min != nil {
-		in, out := &in.Min, &out.Min
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeader.
+func (in *MCPHeader) DeepCopy() *MCPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPHeaderMatch) DeepCopyInto(out *MCPHeaderMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeaderMatch.
+func (in *MCPHeaderMatch) DeepCopy() *MCPHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPHeaderModifier) DeepCopyInto(out *MCPHeaderModifier) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make([]MCPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]MCPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeaderModifier.
+func (in *MCPHeaderModifier) DeepCopy() *MCPHeaderModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPHeaderModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPermission) DeepCopyInto(out *MCPPermission) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPermission.
+func (in *MCPPermission) DeepCopy() *MCPPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRateLimit) DeepCopyInto(out *MCPRateLimit) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]MCPRateLimitRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRateLimit.
+func (in *MCPRateLimit) DeepCopy() *MCPRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRateLimitRule) DeepCopyInto(out *MCPRateLimitRule) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRateLimitRule.
+func (in *MCPRateLimitRule) DeepCopy() *MCPRateLimitRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRateLimitRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRoute) DeepCopyInto(out *MCPRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRoute.
+func (in *MCPRoute) DeepCopy() *MCPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteConfig) DeepCopyInto(out *MCPRouteConfig) {
+	*out = *in
+	if in.RouteRef != nil {
+		in, out := &in.RouteRef, &out.RouteRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]MCPRouteMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.MCPRoutePolicy.DeepCopyInto(&out.MCPRoutePolicy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteConfig.
+func (in *MCPRouteConfig) DeepCopy() *MCPRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteFilter) DeepCopyInto(out *MCPRouteFilter) {
+	*out = *in
+	if in.RequestHeaderModifier != nil {
+		in, out := &in.RequestHeaderModifier, &out.RequestHeaderModifier
+		*out = new(MCPHeaderModifier)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaderModifier != nil {
+		in, out := &in.ResponseHeaderModifier, &out.ResponseHeaderModifier
+		*out = new(MCPHeaderModifier)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteFilter.
+func (in *MCPRouteFilter) DeepCopy() *MCPRouteFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteList) DeepCopyInto(out *MCPRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteList.
+func (in *MCPRouteList) DeepCopy() *MCPRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteMatch) DeepCopyInto(out *MCPRouteMatch) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToolMatch != nil {
+		in, out := &in.ToolMatch, &out.ToolMatch
+		*out = new(MCPToolMatcher)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = new(string)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]MCPHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackendRefs != nil {
+		in, out := &in.BackendRefs, &out.BackendRefs
+		*out = make([]MCPBackendRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteMatch.
+func (in *MCPRouteMatch) DeepCopy() *MCPRouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRoutePolicy) DeepCopyInto(out *MCPRoutePolicy) {
+	*out = *in
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(MCPAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(MCPAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(MCPRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]MCPRouteFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRoutePolicy.
+func (in *MCPRoutePolicy) DeepCopy() *MCPRoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteSpec) DeepCopyInto(out *MCPRouteSpec) {
+	*out = *in
+	if in.BackendRefs != nil {
+		in, out := &in.BackendRefs, &out.BackendRefs
+		*out = make([]MCPBackendRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]MCPRouteMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.MCPRoutePolicy.DeepCopyInto(&out.MCPRoutePolicy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteSpec.
+func (in *MCPRouteSpec) DeepCopy() *MCPRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRouteStatus) DeepCopyInto(out *MCPRouteStatus) {
+	*out = *in
+	if in.BackendStatuses != nil {
+		in, out := &in.BackendStatuses, &out.BackendStatuses
+		*out = make([]MCPBackendStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRouteStatus.
+func (in *MCPRouteStatus) DeepCopy() *MCPRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServer) DeepCopyInto(out *MCPServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServer.
+func (in *MCPServer) DeepCopy() *MCPServer {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerList) DeepCopyInto(out *MCPServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerList.
+func (in *MCPServerList) DeepCopy() *MCPServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerReference) DeepCopyInto(out *MCPServerReference) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerReference.
+func (in *MCPServerReference) DeepCopy() *MCPServerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
+	*out = *in
+	if in.Hosted != nil {
+		in, out := &in.Hosted, &out.Hosted
+		*out = new(HostedMCPServer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteMCPServer)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(MCPCapabilities)
+		**out = **in
+	}
+	if in.PermissionProfile != nil {
+		in, out := &in.PermissionProfile, &out.PermissionProfile
+		*out = new(PermissionProfileSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolsFilter != nil {
+		in, out := &in.ToolsFilter, &out.ToolsFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerSpec.
+func (in *MCPServerSpec) DeepCopy() *MCPServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
+	*out = *in
+	if in.NetworkEnforcement != nil {
+		in, out := &in.NetworkEnforcement, &out.NetworkEnforcement
+		*out = new(NetworkEnforcementMode)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
+func (in *MCPServerStatus) DeepCopy() *MCPServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolMatcher) DeepCopyInto(out *MCPToolMatcher) {
+	*out = *in
+	if in.PrefixMatch != nil {
+		in, out := &in.PrefixMatch, &out.PrefixMatch
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExactMatch != nil {
+		in, out := &in.ExactMatch, &out.ExactMatch
+		*out = new(string)
+		**out = **in
+	}
+	if in.RegexMatch != nil {
+		in, out := &in.RegexMatch, &out.RegexMatch
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolMatcher.
+func (in *MCPToolMatcher) DeepCopy() *MCPToolMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelCopies.
+func (in *ModelCopies) DeepCopy() *ModelCopies {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelCopies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelExtensionSpec) DeepCopyInto(out *ModelExtensionSpec) {
+	*out = *in
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Vendor != nil {
+		in, out := &in.Vendor, &out.Vendor
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompartmentID != nil {
+		in, out := &in.CompartmentID, &out.CompartmentID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelExtensionSpec.
+func (in *ModelExtensionSpec) DeepCopy() *ModelExtensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelExtensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFormat) DeepCopyInto(out *ModelFormat) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(RuntimeSelectorOperator)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFormat.
+func (in *ModelFormat) DeepCopy() *ModelFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFrameworkSpec) DeepCopyInto(out *ModelFrameworkSpec) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(RuntimeSelectorOperator)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFrameworkSpec.
+func (in *ModelFrameworkSpec) DeepCopy() *ModelFrameworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFrameworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPlacementSpec) DeepCopyInto(out *ModelPlacementSpec) {
+	*out = *in
+	if in.PrewarmNodes != nil {
+		in, out := &in.PrewarmNodes, &out.PrewarmNodes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPlacementSpec.
+func (in *ModelPlacementSpec) DeepCopy() *ModelPlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRef) DeepCopyInto(out *ModelRef) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIGroup != nil {
+		in, out := &in.APIGroup, &out.APIGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(string)
+		**out = **in
+	}
+	if in.FineTunedWeights != nil {
+		in, out := &in.FineTunedWeights, &out.FineTunedWeights
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRef.
+func (in *ModelRef) DeepCopy() *ModelRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRevisionStates) DeepCopyInto(out *ModelRevisionStates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRevisionStates.
+func (in *ModelRevisionStates) DeepCopy() *ModelRevisionStates {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRevisionStates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRevisionStatus) DeepCopyInto(out *ModelRevisionStatus) {
+	*out = *in
+	if in.NodesReady != nil {
+		in, out := &in.NodesReady, &out.NodesReady
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRevisionStatus.
+func (in *ModelRevisionStatus) DeepCopy() *ModelRevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSizeRangeSpec) DeepCopyInto(out *ModelSizeRangeSpec) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(string)
+		**out = **in
 	}
 	if in.Max != nil {
 		in, out := &in.Max, &out.Max
@@ -1821,6 +2792,13 @@ func (in *ModelStatusSpec) DeepCopyInto(out *ModelStatusSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]ModelRevisionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatusSpec.
@@ -1833,6 +2811,52 @@ func (in *ModelStatusSpec) DeepCopy() *ModelStatusSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPermission) DeepCopyInto(out *NetworkPermission) {
+	*out = *in
+	if in.AllowHost != nil {
+		in, out := &in.AllowHost, &out.AllowHost
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowCIDR != nil {
+		in, out := &in.AllowCIDR, &out.AllowCIDR
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPermission.
+func (in *NetworkPermission) DeepCopy() *NetworkPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAuthentication) DeepCopyInto(out *OIDCAuthentication) {
+	*out = *in
+	in.ClientSecretRef.DeepCopyInto(&out.ClientSecretRef)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthentication.
+func (in *OIDCAuthentication) DeepCopy() *OIDCAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -1858,6 +2882,121 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDAutoscalingSpec) DeepCopyInto(out *PDAutoscalingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDAutoscalingSpec.
+func (in *PDAutoscalingSpec) DeepCopy() *PDAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PDAutoscalingStatus) DeepCopyInto(out *PDAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextEvaluationTime != nil {
+		in, out := &in.NextEvaluationTime, &out.NextEvaluationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PDAutoscalingStatus.
+func (in *PDAutoscalingStatus) DeepCopy() *PDAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PDAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionProfileSource) DeepCopyInto(out *PermissionProfileSource) {
+	*out = *in
+	if in.Builtin != nil {
+		in, out := &in.Builtin, &out.Builtin
+		*out = new(BuiltinPermissionProfile)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(PermissionProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionProfileSource.
+func (in *PermissionProfileSource) DeepCopy() *PermissionProfileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionProfileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionProfileSpec) DeepCopyInto(out *PermissionProfileSpec) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]PermissionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionProfileSpec.
+func (in *PermissionProfileSpec) DeepCopy() *PermissionProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionRule) DeepCopyInto(out *PermissionRule) {
+	*out = *in
+	if in.KubeResources != nil {
+		in, out := &in.KubeResources, &out.KubeResources
+		*out = new(KubeResourcePermission)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkPermission)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionRule.
+func (in *PermissionRule) DeepCopy() *PermissionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodOverride) DeepCopyInto(out *PodOverride) {
 	*out = *in
@@ -2153,6 +3292,21 @@ func (in *PredictorSpec) DeepCopy() *PredictorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteMCPServer) DeepCopyInto(out *RemoteMCPServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteMCPServer.
+func (in *RemoteMCPServer) DeepCopy() *RemoteMCPServer {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteMCPServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouterSpec) DeepCopyInto(out *RouterSpec) {
 	*out = *in
@@ -2198,6 +3352,75 @@ func (in *RunnerSpec) DeepCopy() *RunnerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeCandidate) DeepCopyInto(out *RuntimeCandidate) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeCandidate.
+func (in *RuntimeCandidate) DeepCopy() *RuntimeCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeRejection) DeepCopyInto(out *RuntimeRejection) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeRejection.
+func (in *RuntimeRejection) DeepCopy() *RuntimeRejection {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeRejection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeSelectionStatus) DeepCopyInto(out *RuntimeSelectionStatus) {
+	*out = *in
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]RuntimeCandidate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rejected != nil {
+		in, out := &in.Rejected, &out.Rejected
+		*out = make([]RuntimeRejection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeSelectionStatus.
+func (in *RuntimeSelectionStatus) DeepCopy() *RuntimeSelectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeSelectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalerAuthenticationRef) DeepCopyInto(out *ScalerAuthenticationRef) {
 	*out = *in
@@ -2213,6 +3436,44 @@ func (in *ScalerAuthenticationRef) DeepCopy() *ScalerAuthenticationRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingAttempt) DeepCopyInto(out *SchedulingAttempt) {
+	*out = *in
+	in.AbandonedTime.DeepCopyInto(&out.AbandonedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingAttempt.
+func (in *SchedulingAttempt) DeepCopy() *SchedulingAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingFallbackStatus) DeepCopyInto(out *SchedulingFallbackStatus) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]SchedulingAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingFallbackStatus.
+func (in *SchedulingFallbackStatus) DeepCopy() *SchedulingFallbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingFallbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceMetadata) DeepCopyInto(out *ServiceMetadata) {
 	*out = *in
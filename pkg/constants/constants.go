@@ -90,6 +90,9 @@ var (
 	DeploymentMode                           = OMEAPIGroupName + "/deploymentMode"
 	EnableRoutingTagAnnotationKey            = OMEAPIGroupName + "/enable-tag-routing"
 	AutoscalerClass                          = OMEAPIGroupName + "/autoscalerClass"
+	RoleBasedGroupRoleRatio                  = OMEAPIGroupName + "/rbg-role-ratio"
+	RoleBasedGroupSpecHash                   = OMEAPIGroupName + "/rbg-spec-hash"
+	WorkloadRevisionAnnotationKey            = OMEAPIGroupName + "/revision"
 	AutoscalerMetrics                        = OMEAPIGroupName + "/metrics"
 	TargetUtilizationPercentage              = OMEAPIGroupName + "/targetUtilizationPercentage"
 	DeprecationWarning                       = OMEAPIGroupName + "/deprecation-warning"
@@ -110,12 +113,17 @@ var (
 	ServingRuntimeKeyName                    = OMEAPIGroupName + "/serving-runtime"
 	BaseModelFormat                          = OMEAPIGroupName + "/base-model-format"
 	BaseModelFormatVersion                   = OMEAPIGroupName + "/base-model-format-version"
+	BaseModelRevisionAnnotationKey           = OMEAPIGroupName + "/base-model-revision"
 	FTServingWithMergedWeightsAnnotationKey  = OMEAPIGroupName + "/fine-tuned-serving-with-merged-weights"
 	ServiceType                              = OMEAPIGroupName + "/service-type"
 	LoadBalancerIP                           = OMEAPIGroupName + "/load-balancer-ip"
 	EntrypointComponent                      = OMEAPIGroupName + "/entrypoint-component"
+	DisableSchedulingFallbackAnnotationKey   = OMEAPIGroupName + "/disable-scheduling-fallback"
+	AutoParallelismAnnotationKey             = OMEAPIGroupName + "/auto-parallelism"
+	MultiNodeProberRuntimeAnnotationKey      = OMEAPIGroupName + "/multinode-prober-runtime"
 	ContainerPrometheusPortKey               = "prometheus.ome.io/port"
 	ContainerPrometheusPathKey               = "prometheus.ome.io/path"
+	ContainerPrometheusTargetsKey            = "prometheus.ome.io/targets"
 	PrometheusPortAnnotationKey              = "prometheus.io/port"
 	PrometheusPathAnnotationKey              = "prometheus.io/path"
 	PrometheusScrapeAnnotationKey            = "prometheus.io/scrape"
@@ -262,6 +270,7 @@ const (
 const (
 	ContainerPrometheusMetricsPortEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PORT"
 	ContainerPrometheusMetricsPathEnvVarKey           = "CONTAINER_PROMETHEUS_METRICS_PATH"
+	ContainerPrometheusMetricsTargetsEnvVarKey        = "CONTAINER_PROMETHEUS_METRICS_TARGETS"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
 
 	TFewWeightPathEnvVarKey = "TFEW_PATH"
@@ -350,6 +359,8 @@ const (
 	FineTunedAdapterContainerName   = "fine-tuned-adapter"
 	ServingSidecarContainerName     = "serving-sidecar"
 	MultiNodeProberContainerPort    = 8080
+	MultiNodeProberSidecarPort      = 8089
+	RayDashboardPort                = 8265
 )
 
 // Model Agents Constants
@@ -444,12 +455,13 @@ const (
 	PDDisaggregated   DeploymentModeType = "PDDisaggregated"
 	MultiNode         DeploymentModeType = "MultiNode"
 	VirtualDeployment DeploymentModeType = "VirtualDeployment"
+	RoleBasedGroup    DeploymentModeType = "RoleBasedGroup"
 )
 
 // IsValid checks if the deployment mode is valid
 func (d DeploymentModeType) IsValid() bool {
 	switch d {
-	case Serverless, RawDeployment, MultiNodeRayVLLM, MultiNode, VirtualDeployment:
+	case Serverless, RawDeployment, MultiNodeRayVLLM, MultiNode, VirtualDeployment, RoleBasedGroup:
 		return true
 	default:
 		return false
@@ -466,6 +478,12 @@ const (
 	RawDeploymentAppLabel = "app"
 )
 
+// traffic target tags of RawDeployment and MultiNode components rolled out with a canary workload
+const (
+	PreviousTrafficTag = "prev"
+	CanaryTrafficTag   = "canary"
+)
+
 // container state reason
 const (
 	StateReasonRunning          = "Running"
@@ -483,10 +501,62 @@ const (
 	KEDAScaledObjectKind    = "ScaledObject"
 	VolcanoJobKind          = "Job"
 	LWSKind                 = "LeaderWorkerSet"
+	RoleBasedGroupKind      = "RoleBasedGroup"
 	GatewayKind             = "Gateway"
 	ServiceKind             = "Service"
 )
 
+// MCP (Model Context Protocol) constants
+const (
+	MCPServerKind = "MCPServer"
+	MCPRouteKind  = "MCPRoute"
+
+	// MCPServerContainerName is the container of a hosted MCPServer pod that runs the server process
+	MCPServerContainerName = "mcp-server"
+	// MCPDefaultServerPort is the port hosted MCP servers and the gateway listen on by default
+	MCPDefaultServerPort = 8080
+	// MCPDefaultServerPath is the path hosted MCP servers serve MCP requests on by default
+	MCPDefaultServerPath = "/mcp"
+
+	// MCPGatewayName is the name of the gateway Deployment and Service in each namespace with MCPRoutes
+	MCPGatewayName = "mcp-gateway"
+	// MCPGatewayRoutesSecretName is the Secret holding the route table of the gateway
+	MCPGatewayRoutesSecretName = "mcp-gateway-routes"
+	// MCPGatewayRoutesFileName is the key of the route table in MCPGatewayRoutesSecretName
+	MCPGatewayRoutesFileName = "routes.json"
+	// MCPGatewayRoutesPathPrefix prefixes the gateway path of each route, followed by namespace and route name
+	MCPGatewayRoutesPathPrefix = "/routes"
+
+	// MCPGatewayURLEnvVarKey is the environment variable that tells the model where to reach its MCP tools
+	MCPGatewayURLEnvVarKey = "MCP_GATEWAY_URL"
+	// MCPRouteNameSuffix is appended to the InferenceService name to name the MCPRoute created for it
+	MCPRouteNameSuffix = "-route"
+)
+
+// MCP labels
+var (
+	// MCPServerLabelKey labels the workloads of a hosted MCPServer with the server name
+	MCPServerLabelKey = OMEAPIGroupName + "/mcp-server"
+	// MCPGatewayLabelKey labels the workloads of the managed MCP gateway
+	MCPGatewayLabelKey = OMEAPIGroupName + "/mcp-gateway"
+)
+
+// Activator constants
+const (
+	// ActivatorNameSuffix is appended to the component name to name the activator Deployment and Service
+	ActivatorNameSuffix = "-activator"
+	// PrivateServiceNameSuffix is appended to the component Service name to name the Service that selects the
+	// component pods while the activator is in front of the component
+	PrivateServiceNameSuffix = "-private"
+	// ActivatorScalerPort is the port the activator serves the KEDA external scaler API on
+	ActivatorScalerPort = 9095
+	// ActivatorDefaultTimeout is how long the activator holds a request while the component scales up
+	ActivatorDefaultTimeout = "10m"
+)
+
+// ActivatorLabelKey labels the activator pods with the name of the component they are in front of
+var ActivatorLabelKey = OMEAPIGroupName + "/activator"
+
 // Volcano Job Labels
 const (
 	VolcanoJobLabelName = "volcano.sh/job-name"
@@ -511,6 +581,10 @@ var (
 	ModelLabelDomain          = "models.ome.io"
 	ClusterBaseModelLabelType = "clusterbasemodel"
 	BaseModelLabelType        = "basemodel"
+
+	// ModelPrewarmNodesAnnotationPrefix prefixes the annotations listing the nodes the model agent pulls a model onto
+	// ahead of scale-out, one annotation per InferenceService suffixed with its UID
+	ModelPrewarmNodesAnnotationPrefix = ModelLabelDomain + "/prewarm-nodes."
 )
 
 type TrainingStrategy string
@@ -677,6 +751,11 @@ func EngineServiceName(name string) string {
 	return name + "-engine"
 }
 
+// CanaryServiceName returns the name of the canary workload and service of a RawDeployment or MultiNode component
+func CanaryServiceName(name string) string {
+	return name + "-canary"
+}
+
 func DecoderPrefix() string {
 	return "^/v1/.*$"
 }
@@ -2,6 +2,7 @@ package acceleratorclass
 
 import (
 	"context"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -25,14 +26,19 @@ import (
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/utils"
 )
 
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ome.io,resources=acceleratorclasses/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
+// podNodeNameIndex indexes pods by the node they are bound to
+const podNodeNameIndex = "spec.nodeName"
+
 type AcceleratorClassReconciler struct {
 	client.Client
 	Log      logr.Logger
@@ -79,6 +85,8 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	matchedNodes := make([]string, 0, len(nodeList.Items))
+	nodeCapacity := make([]v1beta1.AcceleratorNodeCapacity, 0, len(nodeList.Items))
+	resourceNames := acceleratorResourceNames(ac)
 	for _, node := range nodeList.Items {
 		if !nodePassesDiscovery(ac, &node) {
 			continue
@@ -87,8 +95,16 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			continue
 		}
 		matchedNodes = append(matchedNodes, node.Name)
+
+		capacity, err := r.getNodeCapacity(ctx, &node, resourceNames)
+		if err != nil {
+			log.Error(err, "failed to compute accelerator capacity", "node", node.Name)
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		nodeCapacity = append(nodeCapacity, capacity)
 	}
 	sort.Strings(matchedNodes)
+	sort.Slice(nodeCapacity, func(i, j int) bool { return nodeCapacity[i].Name < nodeCapacity[j].Name })
 
 	// In Reconcile, after computing desired fields (without setting LastUpdated yet):
 	latest := &v1beta1.AcceleratorClass{}
@@ -98,6 +114,7 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	desired := latest.DeepCopy()
 	desired.Status.Nodes = matchedNodes
 	desired.Status.AvailableNodes = int32(len(matchedNodes))
+	setCapacityStatus(&desired.Status, nodeCapacity)
 
 	// Only update status if something changed (except LastUpdated):
 	if !acceleratorClassStatusEqualIgnoreTime(latest.Status, desired.Status) {
@@ -113,29 +130,62 @@ func (r *AcceleratorClassReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
-// SetupWithManager wires the controller and watches nodes to trigger reconciles
+// SetupWithManager wires the controller and watches nodes and pods to trigger reconciles
 func (r *AcceleratorClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameIndex, indexPodByNodeName); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.AcceleratorClass{}).
 		Watches(
 			&corev1.Node{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 				// Any node change could affect any AcceleratorClass; requeue all
-				acList := &v1beta1.AcceleratorClassList{}
-				if err := r.List(ctx, acList); err != nil {
+				return r.acceleratorClassRequests(ctx, "")
+			}),
+			builder.WithPredicates(),
+		).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok || pod.Spec.NodeName == "" || !podRequestsAccelerators(pod) {
 					return nil
 				}
-				requests := make([]reconcile.Request, 0, len(acList.Items))
-				for i := range acList.Items {
-					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&acList.Items[i])})
-				}
-				return requests
+				// Only classes that include the pod's node are affected
+				return r.acceleratorClassRequests(ctx, pod.Spec.NodeName)
 			}),
-			builder.WithPredicates(),
 		).
 		Complete(r)
 }
 
+// acceleratorClassRequests returns reconcile requests for all AcceleratorClasses, or only
+// the ones whose status includes the given node when nodeName is set.
+func (r *AcceleratorClassReconciler) acceleratorClassRequests(ctx context.Context, nodeName string) []reconcile.Request {
+	acList := &v1beta1.AcceleratorClassList{}
+	if err := r.List(ctx, acList); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(acList.Items))
+	for i := range acList.Items {
+		if nodeName != "" && !slices.Contains(acList.Items[i].Status.Nodes, nodeName) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&acList.Items[i])})
+	}
+	return requests
+}
+
+// indexPodByNodeName is the field indexer for podNodeNameIndex
+func indexPodByNodeName(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil
+	}
+	return []string{pod.Spec.NodeName}
+}
+
 func nodePassesDiscovery(ac *v1beta1.AcceleratorClass, node *corev1.Node) bool {
 	// NodeSelector map: all key=value must match
 	if len(ac.Spec.Discovery.NodeSelector) > 0 {
@@ -245,48 +295,130 @@ func nodeMatchCapabilities(ac *v1beta1.AcceleratorClass, node *corev1.Node) bool
 func getGPUCapacity(node *corev1.Node) (total int64, byResource map[string]int64) {
 	byResource = make(map[string]int64)
 
-	// Prefer Capacity if you want physical capacity; use Allocatable if you care about schedulable.
-	res := node.Status.Capacity
+	// Use Allocatable since it is what the scheduler can place pods on; fall back to Capacity.
+	res := node.Status.Allocatable
 	if len(res) == 0 {
-		res = node.Status.Allocatable
+		res = node.Status.Capacity
 	}
 
 	for name, q := range res {
 		n := string(name)
-
-		// NVIDIA classic
-		if n == "nvidia.com/gpu" {
-			v := q.Value()
-			byResource[n] = v
-			total += v
+		if !utils.IsAcceleratorResource(n) {
 			continue
 		}
+		v := q.Value()
+		byResource[n] += v
+		total += v
+	}
+	return total, byResource
+}
 
-		// NVIDIA MIG profiles (treat as accelerators; not equivalent to card count)
-		if strings.HasPrefix(n, "nvidia.com/mig-") {
-			v := q.Value()
-			byResource[n] += v
-			total += v
-			continue
+// acceleratorResourceNames returns the accelerator resources the class requests. When the class does not
+// declare any, every accelerator resource on its nodes is counted.
+func acceleratorResourceNames(ac *v1beta1.AcceleratorClass) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, res := range ac.Spec.Resources {
+		if utils.IsAcceleratorResource(res.Name) {
+			names[res.Name] = struct{}{}
+		}
+	}
+	return names
+}
+
+// getNodeCapacity computes the allocatable accelerators of a node and the accelerators requested by
+// the non-terminated pods bound to it.
+func (r *AcceleratorClassReconciler) getNodeCapacity(ctx context.Context, node *corev1.Node, resourceNames map[string]struct{}) (v1beta1.AcceleratorNodeCapacity, error) {
+	counted := func(name string) bool {
+		if len(resourceNames) == 0 {
+			return utils.IsAcceleratorResource(name)
 		}
+		_, ok := resourceNames[name]
+		return ok
+	}
 
-		// AMD (common)
-		if n == "amd.com/gpu" {
-			v := q.Value()
-			byResource[n] = v
-			total += v
-			continue
+	capacity := v1beta1.AcceleratorNodeCapacity{Name: node.Name}
+	_, byResource := getGPUCapacity(node)
+	for name, v := range byResource {
+		if counted(name) {
+			capacity.Allocatable += int32(v)
 		}
+	}
 
-		// Intel (common plugin exposes under gpu.intel.com/*; skip memory-only resources)
-		if strings.HasPrefix(n, "gpu.intel.com/") && !strings.Contains(n, "memory") {
-			v := q.Value()
-			byResource[n] += v
-			total += v
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.MatchingFields{podNodeNameIndex: node.Name}); err != nil {
+		return capacity, err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
 			continue
 		}
+		for name, v := range podAcceleratorRequests(pod) {
+			if counted(name) {
+				capacity.Allocated += int32(v)
+			}
+		}
+	}
+
+	capacity.Available = max(capacity.Allocatable-capacity.Allocated, 0)
+	return capacity, nil
+}
+
+// podAcceleratorRequests returns the effective accelerator requests of a pod, which is the larger of
+// the sum over its containers and the largest init container request, as the scheduler computes it.
+func podAcceleratorRequests(pod *corev1.Pod) map[string]int64 {
+	requests := make(map[string]int64)
+	for _, container := range pod.Spec.Containers {
+		for name, v := range containerAcceleratorRequests(&container) {
+			requests[name] += v
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, v := range containerAcceleratorRequests(&container) {
+			requests[name] = max(requests[name], v)
+		}
+	}
+	return requests
+}
+
+// containerAcceleratorRequests returns the accelerator requests of a container. Extended resources
+// may be set in limits only, in which case the request defaults to the limit.
+func containerAcceleratorRequests(container *corev1.Container) map[string]int64 {
+	requests := make(map[string]int64)
+	for name, q := range container.Resources.Limits {
+		if utils.IsAcceleratorResource(string(name)) {
+			requests[string(name)] = q.Value()
+		}
+	}
+	for name, q := range container.Resources.Requests {
+		if utils.IsAcceleratorResource(string(name)) {
+			requests[string(name)] = q.Value()
+		}
+	}
+	return requests
+}
+
+// podRequestsAccelerators returns whether any container of the pod requests accelerators
+func podRequestsAccelerators(pod *corev1.Pod) bool {
+	return len(podAcceleratorRequests(pod)) > 0
+}
+
+// setCapacityStatus aggregates the per-node capacity into the AcceleratorClass status
+func setCapacityStatus(status *v1beta1.AcceleratorClassStatus, nodeCapacity []v1beta1.AcceleratorNodeCapacity) {
+	status.NodeCapacity = nodeCapacity
+	status.TotalAccelerators = 0
+	status.AllocatedAccelerators = 0
+	status.AvailableAccelerators = 0
+	status.LargestFreeBlock = 0
+	for _, capacity := range nodeCapacity {
+		status.TotalAccelerators += capacity.Allocatable
+		status.AllocatedAccelerators += capacity.Allocated
+		status.AvailableAccelerators += capacity.Available
+		status.LargestFreeBlock = max(status.LargestFreeBlock, capacity.Available)
+	}
+	if len(nodeCapacity) == 0 {
+		status.NodeCapacity = nil
 	}
-	return total, byResource
 }
 
 // returns true if equal when ignoring LastUpdated
@@ -57,6 +57,7 @@ func TestAcceleratorClass_Reconcile_AddsFinalizerAndUpdatesStatus(t *testing.T)
 		WithScheme(scheme).
 		WithObjects(ac, node).
 		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodByNodeName).
 		Build()
 
 	reconciler := &AcceleratorClassReconciler{
@@ -105,6 +106,7 @@ func TestAcceleratorClass_Reconcile_MatchDiscovery(t *testing.T) {
 		WithScheme(scheme).
 		WithObjects(ac, nodeA, nodeB).
 		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodByNodeName).
 		Build()
 
 	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
@@ -160,6 +162,7 @@ func TestAcceleratorClass_Reconcile_MatchMemoryGB(t *testing.T) {
 		WithScheme(scheme).
 		WithObjects(ac, nodeA, nodeB).
 		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodByNodeName).
 		Build()
 
 	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
@@ -197,6 +200,7 @@ func TestAcceleratorClass_Reconcile_DoesNotUpdateTimestampOnNoChange(t *testing.
 		WithScheme(scheme).
 		WithObjects(ac, node).
 		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodByNodeName).
 		Build()
 
 	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
@@ -244,3 +248,111 @@ func Test_getGPUCapacity_Helper(t *testing.T) {
 	g.Expect(byRes).To(HaveKeyWithValue("amd.com/gpu", int64(1)))
 	g.Expect(byRes).To(HaveKeyWithValue("gpu.intel.com/cards", int64(3)))
 }
+
+func TestAcceleratorClass_Reconcile_InUseAccounting(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+	ac := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ac-usage"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Discovery: v1beta1.AcceleratorDiscovery{NodeSelector: map[string]string{"accel": "nvidia"}},
+			Resources: []v1beta1.AcceleratorResource{{Name: constants.NvidiaGPUResourceType, Quantity: resource.MustParse("1")}},
+		},
+	}
+
+	gpuNode := func(name string, gpus string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"accel": "nvidia"}},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("8")},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse(gpus),
+					corev1.ResourceName("amd.com/gpu"):                   resource.MustParse("4"),
+				},
+			},
+		}
+	}
+	gpuPod := func(name, node string, gpus string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: node,
+				Containers: []corev1.Container{{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse(gpus)},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			ac,
+			gpuNode("node-a", "8"),
+			gpuNode("node-b", "7"), // one GPU unhealthy, so allocatable is below capacity
+			gpuPod("running-a", "node-a", "6", corev1.PodRunning),
+			gpuPod("pending-b", "node-b", "2", corev1.PodPending),
+			gpuPod("done-b", "node-b", "4", corev1.PodSucceeded),
+			gpuPod("unscheduled", "", "8", corev1.PodPending),
+		).
+		WithStatusSubresource(&v1beta1.AcceleratorClass{}).
+		WithIndex(&corev1.Pod{}, podNodeNameIndex, indexPodByNodeName).
+		Build()
+
+	reconciler := &AcceleratorClassReconciler{Client: c, Log: ctrl.Log.WithName("AcceleratorClassTest"), Scheme: scheme, Recorder: record.NewFakeRecorder(5)}
+
+	ctx := context.TODO()
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: ac.Name}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	curr := &v1beta1.AcceleratorClass{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: ac.Name}, curr)).To(Succeed())
+	// Only nvidia.com/gpu is counted because the class declares it
+	g.Expect(curr.Status.TotalAccelerators).To(Equal(int32(15)))
+	g.Expect(curr.Status.AllocatedAccelerators).To(Equal(int32(8)))
+	g.Expect(curr.Status.AvailableAccelerators).To(Equal(int32(7)))
+	g.Expect(curr.Status.LargestFreeBlock).To(Equal(int32(5)))
+	g.Expect(curr.Status.NodeCapacity).To(Equal([]v1beta1.AcceleratorNodeCapacity{
+		{Name: "node-a", Allocatable: 8, Allocated: 6, Available: 2},
+		{Name: "node-b", Allocatable: 7, Allocated: 2, Available: 5},
+	}))
+}
+
+func Test_podAcceleratorRequests_Helper(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("4")},
+				},
+			}},
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("1")},
+				}},
+				{Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceName(constants.NvidiaGPUResourceType): resource.MustParse("2"),
+						corev1.ResourceCPU: resource.MustParse("8"),
+					},
+				}},
+			},
+		},
+	}
+
+	// Containers sum to 3, but the init container needs 4
+	g.Expect(podAcceleratorRequests(pod)).To(Equal(map[string]int64{constants.NvidiaGPUResourceType: 4}))
+	g.Expect(podRequestsAccelerators(pod)).To(BeTrue())
+	g.Expect(podRequestsAccelerators(&corev1.Pod{})).To(BeFalse())
+}
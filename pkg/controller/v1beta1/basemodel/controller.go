@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -253,8 +255,8 @@ func (r *BaseModelReconciler) updateModelStatus(ctx context.Context, baseModel *
 		func(ctx context.Context, config *modelagent.ModelConfig) error {
 			return r.updateModelSpecWithRetry(ctx, baseModel, config)
 		},
-		func(ctx context.Context, nodesReady, nodesFailed []string) error {
-			return r.updateStatusWithRetry(ctx, baseModel, nodesReady, nodesFailed)
+		func(ctx context.Context, nodesReady, nodesFailed []string, revisions []v1beta1.ModelRevisionStatus) error {
+			return r.updateStatusWithRetry(ctx, baseModel, nodesReady, nodesFailed, revisions)
 		})
 }
 
@@ -264,15 +266,15 @@ func (r *ClusterBaseModelReconciler) updateModelStatus(ctx context.Context, clus
 		func(ctx context.Context, config *modelagent.ModelConfig) error {
 			return r.updateModelSpecWithRetry(ctx, clusterBaseModel, config)
 		},
-		func(ctx context.Context, nodesReady, nodesFailed []string) error {
-			return r.updateStatusWithRetry(ctx, clusterBaseModel, nodesReady, nodesFailed)
+		func(ctx context.Context, nodesReady, nodesFailed []string, revisions []v1beta1.ModelRevisionStatus) error {
+			return r.updateStatusWithRetry(ctx, clusterBaseModel, nodesReady, nodesFailed, revisions)
 		})
 }
 
 // processModelStatus is a shared utility function for processing ConfigMaps and updating model status
 func processModelStatus(ctx context.Context, kubeClient client.Client, log logr.Logger, namespace, name string, isClusterScope bool,
 	specUpdateFunc func(context.Context, *modelagent.ModelConfig) error,
-	statusUpdateFunc func(context.Context, []string, []string) error) error {
+	statusUpdateFunc func(context.Context, []string, []string, []v1beta1.ModelRevisionStatus) error) error {
 
 	modelInfo := name
 	if !isClusterScope {
@@ -298,6 +300,7 @@ func processModelStatus(ctx context.Context, kubeClient client.Client, log logr.
 	var nodesReady []string
 	var nodesFailed []string
 	var specUpdateErrors []string
+	revisions := map[string]*v1beta1.ModelRevisionStatus{}
 
 	// Process each ConfigMap to find this model's status
 	for _, configMap := range configMaps.Items {
@@ -346,6 +349,7 @@ func processModelStatus(ctx context.Context, kubeClient client.Client, log logr.
 		case modelagent.ModelStatusReady:
 			nodesReady = addToSlice(nodesReady, configMap.Name)
 			readyNodes++
+			addRevisionNode(revisions, modelEntry.Config, configMap.Name)
 		case modelagent.ModelStatusFailed:
 			nodesFailed = addToSlice(nodesFailed, configMap.Name)
 			failedNodes++
@@ -375,7 +379,44 @@ func processModelStatus(ctx context.Context, kubeClient client.Client, log logr.
 	}
 
 	// Update the model status with retry logic
-	return statusUpdateFunc(ctx, nodesReady, nodesFailed)
+	return statusUpdateFunc(ctx, nodesReady, nodesFailed, sortedRevisions(revisions))
+}
+
+// addRevisionNode records a node as ready for the revision of the weights it downloaded.
+// Weights without a commit sha or version can't be told apart and are not tracked.
+func addRevisionNode(revisions map[string]*v1beta1.ModelRevisionStatus, config *modelagent.ModelConfig, node string) {
+	if config == nil {
+		return
+	}
+	revision := config.Artifact.Sha
+	if revision == "" {
+		revision = config.Artifact.Version
+	}
+	if revision == "" {
+		return
+	}
+	status, ok := revisions[revision]
+	if !ok {
+		status = &v1beta1.ModelRevisionStatus{Revision: revision, Version: config.Artifact.Version}
+		revisions[revision] = status
+	}
+	status.NodesReady = addToSlice(status.NodesReady, node)
+}
+
+// sortedRevisions returns the tracked revisions sorted by revision, with their nodes sorted
+func sortedRevisions(revisions map[string]*v1beta1.ModelRevisionStatus) []v1beta1.ModelRevisionStatus {
+	if len(revisions) == 0 {
+		return nil
+	}
+	sorted := make([]v1beta1.ModelRevisionStatus, 0, len(revisions))
+	for _, revision := range revisions {
+		slices.Sort(revision.NodesReady)
+		sorted = append(sorted, *revision)
+	}
+	slices.SortFunc(sorted, func(a, b v1beta1.ModelRevisionStatus) int {
+		return strings.Compare(a.Revision, b.Revision)
+	})
+	return sorted
 }
 
 // updateModelSpec updates BaseModel spec with configuration from ConfigMap
@@ -623,13 +664,13 @@ func (r *ClusterBaseModelReconciler) updateModelSpecWithRetry(ctx context.Contex
 }
 
 // updateStatusWithRetry updates ClusterBaseModel status with retry logic for resource conflicts
-func (r *ClusterBaseModelReconciler) updateStatusWithRetry(ctx context.Context, clusterBaseModel *v1beta1.ClusterBaseModel, nodesReady, nodesFailed []string) error {
-	return updateModelStatusWithRetry(ctx, r.Client, r.Log, clusterBaseModel, nodesReady, nodesFailed, "ClusterBaseModel")
+func (r *ClusterBaseModelReconciler) updateStatusWithRetry(ctx context.Context, clusterBaseModel *v1beta1.ClusterBaseModel, nodesReady, nodesFailed []string, revisions []v1beta1.ModelRevisionStatus) error {
+	return updateModelStatusWithRetry(ctx, r.Client, r.Log, clusterBaseModel, nodesReady, nodesFailed, revisions, "ClusterBaseModel")
 }
 
 // updateStatusWithRetry updates BaseModel status with retry logic for resource conflicts
-func (r *BaseModelReconciler) updateStatusWithRetry(ctx context.Context, baseModel *v1beta1.BaseModel, nodesReady, nodesFailed []string) error {
-	return updateModelStatusWithRetry(ctx, r.Client, r.Log, baseModel, nodesReady, nodesFailed, "BaseModel")
+func (r *BaseModelReconciler) updateStatusWithRetry(ctx context.Context, baseModel *v1beta1.BaseModel, nodesReady, nodesFailed []string, revisions []v1beta1.ModelRevisionStatus) error {
+	return updateModelStatusWithRetry(ctx, r.Client, r.Log, baseModel, nodesReady, nodesFailed, revisions, "BaseModel")
 }
 
 // updateModelSpecWithRetry updates BaseModel spec with retry logic for resource conflicts
@@ -672,10 +713,11 @@ func retryUpdate(ctx context.Context, kubeClient client.Client, log logr.Logger,
 }
 
 // updateModelStatusWithRetry is a shared utility function for updating model status with retry logic
-func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, log logr.Logger, obj client.Object, nodesReady, nodesFailed []string, modelType string) error {
+func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, log logr.Logger, obj client.Object, nodesReady, nodesFailed []string, revisions []v1beta1.ModelRevisionStatus, modelType string) error {
 	updateFunc := func(ctx context.Context, client client.Client, obj client.Object) error {
 		// Get current status and update it
 		var currentNodesReady, currentNodesFailed []string
+		var currentRevisions []v1beta1.ModelRevisionStatus
 		var currentState v1beta1.LifeCycleState
 
 		// Type switch to handle both BaseModel and ClusterBaseModel
@@ -683,10 +725,12 @@ func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, l
 		case *v1beta1.BaseModel:
 			currentNodesReady = model.Status.NodesReady
 			currentNodesFailed = model.Status.NodesFailed
+			currentRevisions = model.Status.Revisions
 			currentState = model.Status.State
 		case *v1beta1.ClusterBaseModel:
 			currentNodesReady = model.Status.NodesReady
 			currentNodesFailed = model.Status.NodesFailed
+			currentRevisions = model.Status.Revisions
 			currentState = model.Status.State
 		default:
 			return fmt.Errorf("unsupported model type: %T", obj)
@@ -700,6 +744,9 @@ func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, l
 		if !slices.Equal(currentNodesFailed, nodesFailed) {
 			updated = true
 		}
+		if !equality.Semantic.DeepEqual(currentRevisions, revisions) {
+			updated = true
+		}
 
 		// Update lifecycle state
 		newState := calculateLifecycleState(nodesReady, nodesFailed)
@@ -714,10 +761,12 @@ func updateModelStatusWithRetry(ctx context.Context, kubeClient client.Client, l
 			case *v1beta1.BaseModel:
 				model.Status.NodesReady = nodesReady
 				model.Status.NodesFailed = nodesFailed
+				model.Status.Revisions = revisions
 				model.Status.State = newState
 			case *v1beta1.ClusterBaseModel:
 				model.Status.NodesReady = nodesReady
 				model.Status.NodesFailed = nodesFailed
+				model.Status.Revisions = revisions
 				model.Status.State = newState
 			}
 
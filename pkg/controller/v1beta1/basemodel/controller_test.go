@@ -233,6 +233,72 @@ func TestBaseModelReconcile(t *testing.T) {
 				g.Expect(updated.Status.NodesFailed).To(gomega.HaveLen(1))
 			},
 		},
+		{
+			name: "BaseModel tracks ready nodes per revision",
+			baseModel: &v1beta1.BaseModel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "revision-model",
+					Namespace:  "default",
+					Finalizers: []string{constants.BaseModelFinalizer},
+				},
+				Spec: v1beta1.BaseModelSpec{
+					ModelFormat: v1beta1.ModelFormat{
+						Name: "safetensors",
+					},
+				},
+			},
+			setupMocks: func(c client.Client) {
+				omeNamespace := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: constants.OMENamespace,
+					},
+				}
+				err := c.Create(context.TODO(), omeNamespace)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// node-1 and node-2 have the old weights ready, node-3 the new ones and node-4 is still downloading
+				entries := map[string]modelagent.ModelEntry{
+					"node-1": {Status: modelagent.ModelStatusReady, Config: &modelagent.ModelConfig{Artifact: modelagent.Artifact{Sha: "abc123", Version: "v1"}}},
+					"node-2": {Status: modelagent.ModelStatusReady, Config: &modelagent.ModelConfig{Artifact: modelagent.Artifact{Sha: "abc123", Version: "v1"}}},
+					"node-3": {Status: modelagent.ModelStatusReady, Config: &modelagent.ModelConfig{Artifact: modelagent.Artifact{Version: "v2"}}},
+					"node-4": {Status: modelagent.ModelStatusUpdating, Config: &modelagent.ModelConfig{Artifact: modelagent.Artifact{Version: "v2"}}},
+				}
+				for nodeName, modelEntry := range entries {
+					err := c.Create(context.TODO(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+					g.Expect(err).NotTo(gomega.HaveOccurred())
+
+					entryData, _ := json.Marshal(modelEntry)
+					configMap := &corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nodeName,
+							Namespace: constants.OMENamespace,
+							Labels: map[string]string{
+								constants.ModelStatusConfigMapLabel: "true",
+							},
+						},
+						Data: map[string]string{
+							"default.basemodel.revision-model": string(entryData),
+						},
+					}
+					err = c.Create(context.TODO(), configMap)
+					g.Expect(err).NotTo(gomega.HaveOccurred())
+				}
+			},
+			validate: func(t *testing.T, c client.Client, baseModel *v1beta1.BaseModel, result ctrl.Result, reconcileErr error) {
+				updated := &v1beta1.BaseModel{}
+				err := c.Get(context.TODO(), types.NamespacedName{
+					Name:      baseModel.Name,
+					Namespace: baseModel.Namespace,
+				}, updated)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+
+				g.Expect(updated.Status.NodesReady).To(gomega.Equal([]string{"node-1", "node-2", "node-3"}))
+				g.Expect(updated.Status.Revisions).To(gomega.Equal([]v1beta1.ModelRevisionStatus{
+					{Revision: "abc123", Version: "v1", NodesReady: []string{"node-1", "node-2"}},
+					{Revision: "v2", Version: "v2", NodesReady: []string{"node-3"}},
+				}))
+			},
+		},
 		{
 			name: "BaseModel deletion removes finalizer when no ConfigMaps exist",
 			baseModel: &v1beta1.BaseModel{
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"text/template"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
 )
 
 const (
@@ -18,11 +21,33 @@ const (
 	DeployConfigName       = "deploy"
 	MultiNodeProberName    = "multinodeProber"
 	BenchmarkJobConfigName = "benchmarkjob"
+	RuntimeSelectionName   = "runtimeSelection"
+	SchedulingFallbackName = "schedulingFallback"
+	AutoParallelismName    = "autoParallelism"
+	MCPConfigName          = "mcp"
+	ActivatorConfigName    = "activator"
 
 	DefaultDomainTemplate = "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
 	DefaultIngressDomain  = "example.com"
 
 	DefaultUrlScheme = "http"
+
+	DefaultUnschedulableTimeoutSeconds = 600
+
+	DefaultMemoryUtilization   = 0.9
+	DefaultAcceleratorsPerNode = 8
+
+	DefaultMCPGatewayReplicas = 1
+
+	DefaultMultiNodeProberTimeoutSeconds = 5
+)
+
+// Serving runtimes whose health contract the multinode prober checks
+const (
+	MultiNodeProberRuntimeSGLang = "sglang"
+	MultiNodeProberRuntimeVLLM   = "vllm"
+	MultiNodeProberRuntimeRay    = "ray"
+	MultiNodeProberRuntimeOpenAI = "openai"
 )
 
 type SecretConfig struct {
@@ -81,6 +106,65 @@ type MultiNodeProberConfig struct {
 	StartupInitialDelaySeconds  int32  `json:"startupInitialDelaySeconds"`
 	StartupTimeoutSeconds       int32  `json:"startupTimeoutSeconds"`
 	UnavailableThresholdSeconds int32  `json:"unavailableThresholdSeconds"`
+	// Runtime selects the health contract of the probed serving runtime: sglang, vllm, ray or openai.
+	// Defaults to vllm.
+	Runtime string `json:"runtime,omitempty"`
+	// LivenessTimeoutSeconds and ReadinessTimeoutSeconds bound the liveness and readiness checks. Default to 5.
+	LivenessTimeoutSeconds  int32 `json:"livenessTimeoutSeconds,omitempty"`
+	ReadinessTimeoutSeconds int32 `json:"readinessTimeoutSeconds,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type SchedulingFallbackConfig struct {
+	// Enabled turns on falling back to the next runtime or accelerator class when pods cannot be scheduled
+	Enabled bool `json:"enabled"`
+	// UnschedulableTimeoutSeconds is how long pods may stay unschedulable before falling back
+	UnschedulableTimeoutSeconds int64 `json:"unschedulableTimeoutSeconds,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type AutoParallelismConfig struct {
+	// Enabled turns on sizing tensor and pipeline parallelism from the model size and accelerator memory
+	Enabled bool `json:"enabled"`
+	// MemoryUtilization is the fraction of accelerator memory available for weights and KV cache
+	MemoryUtilization float64 `json:"memoryUtilization,omitempty"`
+	// AcceleratorsPerNode is used when the AcceleratorClass status does not report accelerators per node
+	AcceleratorsPerNode int64 `json:"acceleratorsPerNode,omitempty"`
+}
+
+// +kubebuilder:object:generate=false
+type MCPConfig struct {
+	// GatewayImage is the image of the managed MCP gateway. The same image provides the stdio bridge
+	// that exposes hosted stdio MCP servers over streamable HTTP.
+	GatewayImage string `json:"gatewayImage"`
+	// GatewayReplicas is the number of gateway replicas in each namespace with MCPRoutes
+	GatewayReplicas int32 `json:"gatewayReplicas,omitempty"`
+	// GatewayPort is the port the gateway Service listens on
+	GatewayPort int32 `json:"gatewayPort,omitempty"`
+	// AllowedKubeResources is the Kubernetes API access the permission profiles of hosted servers may grant.
+	// Profiles requesting access these rules do not cover are rejected. The controller must hold this access
+	// itself, it cannot grant access it does not have.
+	AllowedKubeResources []rbacv1.PolicyRule `json:"allowedKubeResources,omitempty"`
+	CPURequest           string              `json:"cpuRequest"`
+	MemoryRequest        string              `json:"memoryRequest"`
+	CPULimit             string              `json:"cpuLimit"`
+	MemoryLimit          string              `json:"memoryLimit"`
+}
+
+// +kubebuilder:object:generate=false
+type ActivatorConfig struct {
+	// Image is the image of the activator put in front of RawDeployment and MultiNode components scaled to zero.
+	// Components are not scaled to zero when it is empty.
+	Image string `json:"image"`
+	// Timeout is how long the activator holds a request while the component scales up, as a duration
+	Timeout string `json:"timeout,omitempty"`
+	// MaxBodyBytes is the largest request body the activator buffers to retry while the component scales up. The
+	// activator default applies when it is zero.
+	MaxBodyBytes  int64  `json:"maxBodyBytes,omitempty"`
+	CPURequest    string `json:"cpuRequest"`
+	MemoryRequest string `json:"memoryRequest"`
+	CPULimit      string `json:"cpuLimit"`
+	MemoryLimit   string `json:"memoryLimit"`
 }
 
 // +kubebuilder:object:generate=false
@@ -196,9 +280,45 @@ func NewMultiNodeProberConfig(clientset kubernetes.Interface) (*MultiNodeProberC
 			return nil, err
 		}
 	}
+	if multiNodeProberConfig.Runtime == "" {
+		multiNodeProberConfig.Runtime = MultiNodeProberRuntimeVLLM
+	}
+	if err := validateMultiNodeProberRuntime(multiNodeProberConfig.Runtime); err != nil {
+		return nil, err
+	}
+	if multiNodeProberConfig.LivenessTimeoutSeconds == 0 {
+		multiNodeProberConfig.LivenessTimeoutSeconds = DefaultMultiNodeProberTimeoutSeconds
+	}
+	if multiNodeProberConfig.ReadinessTimeoutSeconds == 0 {
+		multiNodeProberConfig.ReadinessTimeoutSeconds = DefaultMultiNodeProberTimeoutSeconds
+	}
 	return multiNodeProberConfig, nil
 }
 
+// ForComponent returns the prober configuration of a component, probing the runtime set by the
+// ome.io/multinode-prober-runtime annotation of its InferenceService or serving runtime instead of the cluster default
+func (c *MultiNodeProberConfig) ForComponent(annotations map[string]string) (*MultiNodeProberConfig, error) {
+	runtime, ok := annotations[constants.MultiNodeProberRuntimeAnnotationKey]
+	if !ok {
+		return c, nil
+	}
+	if err := validateMultiNodeProberRuntime(runtime); err != nil {
+		return nil, err
+	}
+	config := *c
+	config.Runtime = runtime
+	return &config, nil
+}
+
+func validateMultiNodeProberRuntime(runtime string) error {
+	switch runtime {
+	case MultiNodeProberRuntimeSGLang, MultiNodeProberRuntimeVLLM, MultiNodeProberRuntimeRay, MultiNodeProberRuntimeOpenAI:
+		return nil
+	}
+	return fmt.Errorf("invalid multinode prober runtime %q. Supported runtimes are %s, %s, %s and %s", runtime,
+		MultiNodeProberRuntimeSGLang, MultiNodeProberRuntimeVLLM, MultiNodeProberRuntimeRay, MultiNodeProberRuntimeOpenAI)
+}
+
 func NewBenchmarkJobConfig(clientset kubernetes.Interface) (*BenchmarkJobConfig, error) {
 	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.BenchmarkJobConfigMapName, metav1.GetOptions{})
 	if err != nil {
@@ -214,3 +334,97 @@ func NewBenchmarkJobConfig(clientset kubernetes.Interface) (*BenchmarkJobConfig,
 	}
 	return benchmarkJobConfig, nil
 }
+
+// NewRuntimeSelectionConfig loads the runtime selection scoring policies. A missing
+// runtimeSelection key leaves the default scorer as the only ranking criterion.
+func NewRuntimeSelectionConfig(clientset kubernetes.Interface) (*runtimeselector.PolicyConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	policyConfig := &runtimeselector.PolicyConfig{}
+	if err := getComponentConfig(RuntimeSelectionName, configMap, policyConfig); err != nil {
+		return nil, err
+	}
+	return policyConfig, nil
+}
+
+// NewSchedulingFallbackConfig loads the scheduling fallback configuration. Fallback is enabled
+// with the default timeout when the schedulingFallback key is missing.
+func NewSchedulingFallbackConfig(clientset kubernetes.Interface) (*SchedulingFallbackConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	fallbackConfig := &SchedulingFallbackConfig{
+		Enabled:                     true,
+		UnschedulableTimeoutSeconds: DefaultUnschedulableTimeoutSeconds,
+	}
+	if err := getComponentConfig(SchedulingFallbackName, configMap, fallbackConfig); err != nil {
+		return nil, err
+	}
+	if fallbackConfig.UnschedulableTimeoutSeconds <= 0 {
+		fallbackConfig.UnschedulableTimeoutSeconds = DefaultUnschedulableTimeoutSeconds
+	}
+	return fallbackConfig, nil
+}
+
+func NewAutoParallelismConfig(clientset kubernetes.Interface) (*AutoParallelismConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	parallelismConfig := &AutoParallelismConfig{
+		MemoryUtilization:   DefaultMemoryUtilization,
+		AcceleratorsPerNode: DefaultAcceleratorsPerNode,
+	}
+	if err := getComponentConfig(AutoParallelismName, configMap, parallelismConfig); err != nil {
+		return nil, err
+	}
+	if parallelismConfig.MemoryUtilization <= 0 || parallelismConfig.MemoryUtilization > 1 {
+		parallelismConfig.MemoryUtilization = DefaultMemoryUtilization
+	}
+	if parallelismConfig.AcceleratorsPerNode <= 0 {
+		parallelismConfig.AcceleratorsPerNode = DefaultAcceleratorsPerNode
+	}
+	return parallelismConfig, nil
+}
+
+func NewMCPConfig(clientset kubernetes.Interface) (*MCPConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	mcpConfig := &MCPConfig{
+		GatewayReplicas: DefaultMCPGatewayReplicas,
+		GatewayPort:     constants.MCPDefaultServerPort,
+	}
+	if err := getComponentConfig(MCPConfigName, configMap, mcpConfig); err != nil {
+		return nil, err
+	}
+	if mcpConfig.GatewayReplicas <= 0 {
+		mcpConfig.GatewayReplicas = DefaultMCPGatewayReplicas
+	}
+	if mcpConfig.GatewayPort <= 0 {
+		mcpConfig.GatewayPort = constants.MCPDefaultServerPort
+	}
+	return mcpConfig, nil
+}
+
+func NewActivatorConfig(clientset kubernetes.Interface) (*ActivatorConfig, error) {
+	configMap, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	activatorConfig := &ActivatorConfig{Timeout: constants.ActivatorDefaultTimeout}
+	if err := getComponentConfig(ActivatorConfigName, configMap, activatorConfig); err != nil {
+		return nil, err
+	}
+	if _, err := time.ParseDuration(activatorConfig.Timeout); err != nil {
+		return nil, fmt.Errorf("invalid activator timeout %q: %w", activatorConfig.Timeout, err)
+	}
+	if activatorConfig.MaxBodyBytes < 0 {
+		return nil, fmt.Errorf("invalid activator maxBodyBytes %d", activatorConfig.MaxBodyBytes)
+	}
+	return activatorConfig, nil
+}
@@ -12,6 +12,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
 )
 
 const (
@@ -272,6 +273,309 @@ func TestNewDeployConfig(t *testing.T) {
 	}
 }
 
+func TestNewRuntimeSelectionConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		configMapData  map[string]string
+		expectedError  bool
+		validateConfig func(t *testing.T, cfg *runtimeselector.PolicyConfig)
+	}{
+		{
+			name: "valid config",
+			configMapData: map[string]string{
+				RuntimeSelectionName: `{
+					"policies": [{"name": "cost", "weight": 5}, {"name": "namespacePreference", "weight": 20}],
+					"namespacePreferredRuntimes": {"team-a": ["srt-llama", "vllm-llama"]}
+				}`,
+			},
+			validateConfig: func(t *testing.T, cfg *runtimeselector.PolicyConfig) {
+				require.Len(t, cfg.Policies, 2)
+				assert.Equal(t, "cost", cfg.Policies[0].Name)
+				assert.Equal(t, float64(20), cfg.Policies[1].Weight)
+				assert.Equal(t, []string{"srt-llama", "vllm-llama"}, cfg.NamespacePreferredRuntimes["team-a"])
+			},
+		},
+		{
+			name:          "missing key uses defaults",
+			configMapData: map[string]string{},
+			validateConfig: func(t *testing.T, cfg *runtimeselector.PolicyConfig) {
+				assert.Empty(t, cfg.Policies)
+			},
+		},
+		{
+			name: "invalid json",
+			configMapData: map[string]string{
+				RuntimeSelectionName: `{"policies": [}`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewRuntimeSelectionConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.validateConfig != nil {
+				tt.validateConfig(t, config)
+			}
+		})
+	}
+}
+
+func TestNewSchedulingFallbackConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		configMapData   map[string]string
+		expectedError   bool
+		expectedEnabled bool
+		expectedTimeout int64
+	}{
+		{
+			name:            "missing key uses defaults",
+			configMapData:   map[string]string{},
+			expectedEnabled: true,
+			expectedTimeout: DefaultUnschedulableTimeoutSeconds,
+		},
+		{
+			name: "custom timeout",
+			configMapData: map[string]string{
+				SchedulingFallbackName: `{"enabled": true, "unschedulableTimeoutSeconds": 120}`,
+			},
+			expectedEnabled: true,
+			expectedTimeout: 120,
+		},
+		{
+			name: "disabled without timeout",
+			configMapData: map[string]string{
+				SchedulingFallbackName: `{"enabled": false}`,
+			},
+			expectedEnabled: false,
+			expectedTimeout: DefaultUnschedulableTimeoutSeconds,
+		},
+		{
+			name: "invalid json",
+			configMapData: map[string]string{
+				SchedulingFallbackName: `{"enabled": }`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewSchedulingFallbackConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEnabled, config.Enabled)
+			assert.Equal(t, tt.expectedTimeout, config.UnschedulableTimeoutSeconds)
+		})
+	}
+}
+
+func TestNewMultiNodeProberConfig(t *testing.T) {
+	tests := []struct {
+		name                     string
+		configMapData            map[string]string
+		expectedError            bool
+		expectedRuntime          string
+		expectedLivenessTimeout  int32
+		expectedReadinessTimeout int32
+	}{
+		{
+			name: "defaults to the vllm runtime",
+			configMapData: map[string]string{
+				MultiNodeProberName: `{"image": "test-image"}`,
+			},
+			expectedRuntime:          MultiNodeProberRuntimeVLLM,
+			expectedLivenessTimeout:  DefaultMultiNodeProberTimeoutSeconds,
+			expectedReadinessTimeout: DefaultMultiNodeProberTimeoutSeconds,
+		},
+		{
+			name: "sglang runtime with custom timeouts",
+			configMapData: map[string]string{
+				MultiNodeProberName: `{"image": "test-image", "runtime": "sglang", "livenessTimeoutSeconds": 3, "readinessTimeoutSeconds": 30}`,
+			},
+			expectedRuntime:          MultiNodeProberRuntimeSGLang,
+			expectedLivenessTimeout:  3,
+			expectedReadinessTimeout: 30,
+		},
+		{
+			name: "unsupported runtime",
+			configMapData: map[string]string{
+				MultiNodeProberName: `{"image": "test-image", "runtime": "tgi"}`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewMultiNodeProberConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "test-image", config.Image)
+			assert.Equal(t, tt.expectedRuntime, config.Runtime)
+			assert.Equal(t, tt.expectedLivenessTimeout, config.LivenessTimeoutSeconds)
+			assert.Equal(t, tt.expectedReadinessTimeout, config.ReadinessTimeoutSeconds)
+		})
+	}
+}
+
+func TestMultiNodeProberConfigForComponent(t *testing.T) {
+	defaultConfig := &MultiNodeProberConfig{Image: "test-image", Runtime: MultiNodeProberRuntimeVLLM}
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectedError   bool
+		expectedRuntime string
+	}{
+		{
+			name:            "cluster default",
+			expectedRuntime: MultiNodeProberRuntimeVLLM,
+		},
+		{
+			name:            "runtime annotation",
+			annotations:     map[string]string{constants.MultiNodeProberRuntimeAnnotationKey: "sglang"},
+			expectedRuntime: MultiNodeProberRuntimeSGLang,
+		},
+		{
+			name:          "unsupported runtime annotation",
+			annotations:   map[string]string{constants.MultiNodeProberRuntimeAnnotationKey: "tgi"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := defaultConfig.ForComponent(tt.annotations)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRuntime, config.Runtime)
+			assert.Equal(t, "test-image", config.Image)
+			// The cluster default is left as is
+			assert.Equal(t, MultiNodeProberRuntimeVLLM, defaultConfig.Runtime)
+		})
+	}
+}
+
+func TestNewAutoParallelismConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		configMapData       map[string]string
+		expectedError       bool
+		expectedEnabled     bool
+		expectedUtilization float64
+		expectedPerNode     int64
+	}{
+		{
+			name:                "missing key uses defaults",
+			configMapData:       map[string]string{},
+			expectedUtilization: DefaultMemoryUtilization,
+			expectedPerNode:     DefaultAcceleratorsPerNode,
+		},
+		{
+			name: "custom values",
+			configMapData: map[string]string{
+				AutoParallelismName: `{"enabled": true, "memoryUtilization": 0.85, "acceleratorsPerNode": 4}`,
+			},
+			expectedEnabled:     true,
+			expectedUtilization: 0.85,
+			expectedPerNode:     4,
+		},
+		{
+			name: "out of range values use defaults",
+			configMapData: map[string]string{
+				AutoParallelismName: `{"enabled": true, "memoryUtilization": 1.5, "acceleratorsPerNode": -1}`,
+			},
+			expectedEnabled:     true,
+			expectedUtilization: DefaultMemoryUtilization,
+			expectedPerNode:     DefaultAcceleratorsPerNode,
+		},
+		{
+			name: "invalid json",
+			configMapData: map[string]string{
+				AutoParallelismName: `{"enabled": }`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewAutoParallelismConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEnabled, config.Enabled)
+			assert.Equal(t, tt.expectedUtilization, config.MemoryUtilization)
+			assert.Equal(t, tt.expectedPerNode, config.AcceleratorsPerNode)
+		})
+	}
+}
+
 func TestGetComponentConfig(t *testing.T) {
 	type testStruct struct {
 		Field string `json:"field"`
@@ -323,3 +627,148 @@ func TestGetComponentConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewMCPConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		configMapData    map[string]string
+		expectedError    bool
+		expectedImage    string
+		expectedReplicas int32
+		expectedPort     int32
+		expectedAllowed  int
+	}{
+		{
+			name:             "missing key uses defaults",
+			configMapData:    map[string]string{},
+			expectedReplicas: DefaultMCPGatewayReplicas,
+			expectedPort:     constants.MCPDefaultServerPort,
+		},
+		{
+			name: "custom values",
+			configMapData: map[string]string{
+				MCPConfigName: `{"gatewayImage": "ome/mcp-gateway:v1", "gatewayReplicas": 3, "gatewayPort": 9000}`,
+			},
+			expectedImage:    "ome/mcp-gateway:v1",
+			expectedReplicas: 3,
+			expectedPort:     9000,
+		},
+		{
+			name: "allowed kube resources",
+			configMapData: map[string]string{
+				MCPConfigName: `{"gatewayImage": "ome/mcp-gateway:v1", "allowedKubeResources": [{"apiGroups": [""], "resources": ["pods"], "verbs": ["get", "list"]}]}`,
+			},
+			expectedImage:    "ome/mcp-gateway:v1",
+			expectedReplicas: DefaultMCPGatewayReplicas,
+			expectedPort:     constants.MCPDefaultServerPort,
+			expectedAllowed:  1,
+		},
+		{
+			name: "invalid values use defaults",
+			configMapData: map[string]string{
+				MCPConfigName: `{"gatewayImage": "ome/mcp-gateway:v1", "gatewayReplicas": 0, "gatewayPort": -1}`,
+			},
+			expectedImage:    "ome/mcp-gateway:v1",
+			expectedReplicas: DefaultMCPGatewayReplicas,
+			expectedPort:     constants.MCPDefaultServerPort,
+		},
+		{
+			name: "invalid json",
+			configMapData: map[string]string{
+				MCPConfigName: `{"gatewayImage": }`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewMCPConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedImage, config.GatewayImage)
+			assert.Equal(t, tt.expectedReplicas, config.GatewayReplicas)
+			assert.Equal(t, tt.expectedPort, config.GatewayPort)
+			assert.Len(t, config.AllowedKubeResources, tt.expectedAllowed)
+		})
+	}
+}
+
+func TestNewActivatorConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		configMapData   map[string]string
+		expectedError   bool
+		expectedImage   string
+		expectedTimeout string
+		expectedMaxBody int64
+	}{
+		{
+			name:            "missing key uses defaults",
+			configMapData:   map[string]string{},
+			expectedTimeout: constants.ActivatorDefaultTimeout,
+		},
+		{
+			name: "custom values",
+			configMapData: map[string]string{
+				ActivatorConfigName: `{"image": "ome/activator:v1", "timeout": "5m", "maxBodyBytes": 67108864}`,
+			},
+			expectedImage:   "ome/activator:v1",
+			expectedTimeout: "5m",
+			expectedMaxBody: 64 << 20,
+		},
+		{
+			name: "invalid timeout",
+			configMapData: map[string]string{
+				ActivatorConfigName: `{"image": "ome/activator:v1", "timeout": "forever"}`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative max body bytes",
+			configMapData: map[string]string{
+				ActivatorConfigName: `{"image": "ome/activator:v1", "maxBodyBytes": -1}`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.OMENamespace,
+				},
+				Data: tt.configMapData,
+			}
+			_, err := clientset.CoreV1().ConfigMaps(constants.OMENamespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			config, err := NewActivatorConfig(clientset)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedImage, config.Image)
+			assert.Equal(t, tt.expectedTimeout, config.Timeout)
+			assert.Equal(t, tt.expectedMaxBody, config.MaxBodyBytes)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package inferenceservice
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+const (
+	// AutoParallelismReason is used when the controller sizes tensor and pipeline parallelism for a component.
+	AutoParallelismReason = "AutoParallelism"
+	// AutoParallelismSkippedReason is used when parallelism cannot be sized from the model and accelerator.
+	AutoParallelismSkippedReason = "AutoParallelismSkipped"
+	// AutoParallelismSingleNodeReason is used when a model needs more than one node but the component can't span nodes.
+	AutoParallelismSingleNodeReason = "AutoParallelismSingleNode"
+)
+
+// autoParallelismEnabled returns whether tensor and pipeline parallelism should be sized automatically.
+// The InferenceService annotation takes precedence over the controller configuration.
+func autoParallelismEnabled(isvc *v1beta1.InferenceService, parallelismConfig *controllerconfig.AutoParallelismConfig) bool {
+	if value, ok := isvc.Annotations[constants.AutoParallelismAnnotationKey]; ok {
+		return value == "true"
+	}
+	return parallelismConfig != nil && parallelismConfig.Enabled
+}
+
+// componentTemplate is what the merged spec of a component tells about how its parallelism can be applied
+type componentTemplate struct {
+	// multiNode is set when the component has leader and worker templates to spread the model across nodes
+	multiNode bool
+	// containers are the runner containers, whose accelerator requests name the accelerator resource
+	containers []*v1.Container
+}
+
+// planParallelism computes the parallelism for a component on its accelerator class. It returns nil when
+// automatic sizing is disabled, the serving runtime sets parallelism explicitly, or the model or accelerator
+// lacks the information needed to size it. A plan that needs more than one node is capped to a single node unless
+// the component has a multi-node leader and worker template to spread it across nodes.
+func (r *InferenceServiceReconciler) planParallelism(isvc *v1beta1.InferenceService, component v1beta1.ComponentType, baseModel *v1beta1.BaseModelSpec,
+	format *v1beta1.SupportedModelFormat, acceleratorClass *v1beta1.AcceleratorClass, acName string, parallelismConfig *controllerconfig.AutoParallelismConfig,
+	template componentTemplate,
+) *isvcutils.ParallelismPlan {
+	if !autoParallelismEnabled(isvc, parallelismConfig) || acceleratorClass == nil ||
+		isvcutils.HasTensorParallelismOverride(format, acName) {
+		return nil
+	}
+
+	perNode := isvcutils.AcceleratorsPerNode(acceleratorClass, parallelismConfig.AcceleratorsPerNode)
+	plan, err := isvcutils.ComputeParallelism(baseModel, &acceleratorClass.Spec, perNode, parallelismConfig.MemoryUtilization)
+	if err == nil {
+		plan.ResourceName = isvcutils.AcceleratorResourceName(&acceleratorClass.Spec, template.containers...)
+		if plan.ResourceName == "" {
+			err = fmt.Errorf("neither the accelerator class nor the %s containers request an accelerator resource", component)
+		}
+	}
+	if err != nil {
+		r.Log.Info("Skipping automatic parallelism sizing", "namespace", isvc.Namespace, "inferenceService", isvc.Name,
+			"component", component, "acceleratorClass", acName, "reason", err.Error())
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, AutoParallelismSkippedReason,
+			"Cannot size parallelism for %s on accelerator class %s: %v", component, acName, err)
+		return nil
+	}
+
+	if plan.MultiNode() && !template.multiNode {
+		r.Log.Info("Keeping component on a single node without a multi-node template", "namespace", isvc.Namespace,
+			"inferenceService", isvc.Name, "component", component, "acceleratorClass", acName, "nodes", plan.Nodes)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, AutoParallelismSingleNodeReason,
+			"%s needs %d nodes of accelerator class %s, but the serving runtime has no multi-node leader and worker template, keeping it on a single node",
+			component, plan.Nodes, acName)
+		plan = plan.SingleNode()
+	}
+
+	r.Log.Info("Sized parallelism from model and accelerator memory", "namespace", isvc.Namespace, "inferenceService", isvc.Name,
+		"component", component, "acceleratorClass", acName, "tensorParallelSize", plan.TensorParallelSize,
+		"nodes", plan.Nodes, "requiredMemoryGB", plan.RequiredMemoryGB)
+	r.Recorder.Eventf(isvc, v1.EventTypeNormal, AutoParallelismReason,
+		"Sized %s to tensor parallel %d on %d nodes of accelerator class %s for %.1f GB of weights and KV cache",
+		component, plan.TensorParallelSize, plan.Nodes, acName, plan.RequiredMemoryGB)
+	return plan
+}
+
+// engineTemplate returns the template of the engine. It is multi-node when the engine has leader and worker templates
+// and is deployed as multi-node. The templates come from the serving runtime or the InferenceService and carry the
+// distributed launch arguments.
+func engineTemplate(engine *v1beta1.EngineSpec) componentTemplate {
+	if engine == nil {
+		return componentTemplate{}
+	}
+	return componentTemplate{
+		multiNode: engine.Leader != nil && engine.Worker != nil &&
+			isvcutils.DetermineEngineDeploymentMode(engine) == constants.MultiNode,
+		containers: runnerContainers(engine.Runner, engine.Leader, engine.Worker),
+	}
+}
+
+// decoderTemplate returns the template of the decoder, multi-node when it has leader and worker templates
+func decoderTemplate(decoder *v1beta1.DecoderSpec) componentTemplate {
+	if decoder == nil {
+		return componentTemplate{}
+	}
+	return componentTemplate{
+		multiNode:  decoder.Leader != nil && decoder.Worker != nil,
+		containers: runnerContainers(decoder.Runner, decoder.Leader, decoder.Worker),
+	}
+}
+
+// runnerContainers returns the runner containers of a component and its leader and worker templates
+func runnerContainers(runner *v1beta1.RunnerSpec, leader *v1beta1.LeaderSpec, worker *v1beta1.WorkerSpec) []*v1.Container {
+	var containers []*v1.Container
+	if runner != nil {
+		containers = append(containers, &runner.Container)
+	}
+	if leader != nil && leader.Runner != nil {
+		containers = append(containers, &leader.Runner.Container)
+	}
+	if worker != nil && worker.Runner != nil {
+		containers = append(containers, &worker.Runner.Container)
+	}
+	return containers
+}
+
+// applyMultiNodePlan sizes the worker group of a multi-node template to the nodes of the plan.
+// Workers that already have a size keep it.
+func applyMultiNodePlan(worker *v1beta1.WorkerSpec, plan *isvcutils.ParallelismPlan) {
+	if worker == nil || plan == nil || !plan.MultiNode() || worker.Size != nil {
+		return
+	}
+	size := int(plan.Nodes - 1)
+	worker.Size = &size
+}
@@ -0,0 +1,269 @@
+package inferenceservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+func TestPlanParallelism(t *testing.T) {
+	h100 := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "h100"},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Capabilities: v1beta1.AcceleratorCapabilities{MemoryGB: resource.NewQuantity(80*1024*1024*1024, resource.BinarySI)},
+		},
+	}
+	size := "405B"
+	model := &v1beta1.BaseModelSpec{
+		ModelParameterSize: &size,
+		ModelConfiguration: runtime.RawExtension{Raw: []byte(`{"num_hidden_layers":126,"num_key_value_heads":8,"head_dim":128}`)},
+	}
+	gpuRunner := []*v1.Container{{Resources: v1.ResourceRequirements{
+		Limits: v1.ResourceList{constants.NvidiaGPUResourceType: resource.MustParse("8")},
+	}}}
+	multiNode := componentTemplate{multiNode: true, containers: gpuRunner}
+	singleNode := componentTemplate{containers: gpuRunner}
+	explicitTP := int64(8)
+	explicitFormat := &v1beta1.SupportedModelFormat{
+		AcceleratorConfig: map[string]*v1beta1.AcceleratorModelConfig{
+			"h100": {TensorParallelismOverride: &v1beta1.TensorParallelismConfig{TensorParallelSize: &explicitTP}},
+		},
+	}
+	enabled := &controllerconfig.AutoParallelismConfig{Enabled: true, MemoryUtilization: 0.9, AcceleratorsPerNode: 8}
+	disabled := &controllerconfig.AutoParallelismConfig{MemoryUtilization: 0.9, AcceleratorsPerNode: 8}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		config       *controllerconfig.AutoParallelismConfig
+		model        *v1beta1.BaseModelSpec
+		format       *v1beta1.SupportedModelFormat
+		accelerator  *v1beta1.AcceleratorClass
+		template     componentTemplate
+		expectPlan   bool
+		expectNodes  int64
+		expectEvents []string
+	}{
+		{
+			name:         "enabled in config",
+			config:       enabled,
+			model:        model,
+			accelerator:  h100,
+			template:     multiNode,
+			expectPlan:   true,
+			expectNodes:  2,
+			expectEvents: []string{AutoParallelismReason},
+		},
+		{
+			name:         "without a multi-node template the plan stays on a single node",
+			config:       enabled,
+			model:        model,
+			accelerator:  h100,
+			template:     singleNode,
+			expectPlan:   true,
+			expectNodes:  1,
+			expectEvents: []string{AutoParallelismSingleNodeReason, AutoParallelismReason},
+		},
+		{
+			name:        "disabled in config",
+			config:      disabled,
+			model:       model,
+			accelerator: h100,
+		},
+		{
+			name:         "annotation enables",
+			annotations:  map[string]string{constants.AutoParallelismAnnotationKey: "true"},
+			config:       disabled,
+			model:        model,
+			accelerator:  h100,
+			template:     multiNode,
+			expectPlan:   true,
+			expectNodes:  2,
+			expectEvents: []string{AutoParallelismReason},
+		},
+		{
+			name:        "annotation disables",
+			annotations: map[string]string{constants.AutoParallelismAnnotationKey: "false"},
+			config:      enabled,
+			model:       model,
+			accelerator: h100,
+		},
+		{
+			name:        "explicit runtime override wins",
+			config:      enabled,
+			model:       model,
+			format:      explicitFormat,
+			accelerator: h100,
+		},
+		{
+			name:   "no accelerator class",
+			config: enabled,
+			model:  model,
+		},
+		{
+			name:         "unknown attention geometry emits an event",
+			config:       enabled,
+			model:        &v1beta1.BaseModelSpec{ModelParameterSize: &size},
+			accelerator:  h100,
+			template:     multiNode,
+			expectEvents: []string{AutoParallelismSkippedReason},
+		},
+		{
+			name:         "no accelerator resource emits an event",
+			config:       enabled,
+			model:        model,
+			accelerator:  h100,
+			template:     componentTemplate{multiNode: true},
+			expectEvents: []string{AutoParallelismSkippedReason},
+		},
+		{
+			name:         "unknown model size emits an event",
+			config:       enabled,
+			model:        &v1beta1.BaseModelSpec{},
+			accelerator:  h100,
+			expectEvents: []string{AutoParallelismSkippedReason},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &InferenceServiceReconciler{Log: ctrl.Log.WithName("test"), Recorder: recorder}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default", Annotations: tt.annotations},
+			}
+			acName := ""
+			if tt.accelerator != nil {
+				acName = tt.accelerator.Name
+			}
+
+			plan := r.planParallelism(isvc, v1beta1.EngineComponent, tt.model, tt.format, tt.accelerator, acName, tt.config, tt.template)
+			if tt.expectPlan {
+				require.NotNil(t, plan)
+				assert.Equal(t, int64(8), plan.TensorParallelSize)
+				assert.Equal(t, tt.expectNodes, plan.Nodes)
+				assert.Equal(t, constants.NvidiaGPUResourceType, plan.ResourceName)
+			} else {
+				assert.Nil(t, plan)
+			}
+			for _, event := range tt.expectEvents {
+				assert.Contains(t, <-recorder.Events, event)
+			}
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestComponentTemplate(t *testing.T) {
+	runner := &v1beta1.RunnerSpec{}
+	runner.Name = "ome-container"
+	leader := &v1beta1.LeaderSpec{Runner: runner.DeepCopy()}
+	worker := &v1beta1.WorkerSpec{Runner: runner.DeepCopy()}
+
+	tests := []struct {
+		name             string
+		engine           *v1beta1.EngineSpec
+		expectMultiNode  bool
+		expectContainers int
+	}{
+		{
+			name:             "leader and worker templates",
+			engine:           &v1beta1.EngineSpec{Leader: leader, Worker: worker},
+			expectMultiNode:  true,
+			expectContainers: 2,
+		},
+		{
+			name:             "single node engine",
+			engine:           &v1beta1.EngineSpec{Runner: runner},
+			expectContainers: 1,
+		},
+		{
+			name:             "worker without leader",
+			engine:           &v1beta1.EngineSpec{Worker: worker},
+			expectContainers: 1,
+		},
+		{
+			name: "deployment mode annotation wins over the templates",
+			engine: &v1beta1.EngineSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+					Annotations: map[string]string{constants.DeploymentMode: string(constants.RawDeployment)},
+				},
+				Leader: leader,
+				Worker: worker,
+			},
+			expectContainers: 2,
+		},
+		{
+			name: "no engine",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := engineTemplate(tt.engine)
+			assert.Equal(t, tt.expectMultiNode, template.multiNode)
+			assert.Len(t, template.containers, tt.expectContainers)
+		})
+	}
+
+	assert.True(t, decoderTemplate(&v1beta1.DecoderSpec{Leader: leader, Worker: worker}).multiNode)
+	assert.False(t, decoderTemplate(&v1beta1.DecoderSpec{Leader: leader}).multiNode)
+	assert.Equal(t, componentTemplate{}, decoderTemplate(nil))
+}
+
+func TestApplyMultiNodePlan(t *testing.T) {
+	multiNodePlan := &isvcutils.ParallelismPlan{TensorParallelSize: 8, Nodes: 3}
+	singleNodePlan := &isvcutils.ParallelismPlan{TensorParallelSize: 4, Nodes: 1}
+	existingSize := 1
+
+	tests := []struct {
+		name             string
+		worker           *v1beta1.WorkerSpec
+		plan             *isvcutils.ParallelismPlan
+		expectWorkerSize *int
+	}{
+		{
+			name:             "runtime worker without size gets the plan nodes",
+			worker:           &v1beta1.WorkerSpec{},
+			plan:             multiNodePlan,
+			expectWorkerSize: ptr.To(2),
+		},
+		{
+			name:             "existing worker size is kept",
+			worker:           &v1beta1.WorkerSpec{Size: &existingSize},
+			plan:             multiNodePlan,
+			expectWorkerSize: ptr.To(1),
+		},
+		{
+			name:   "single node plan leaves the worker alone",
+			worker: &v1beta1.WorkerSpec{},
+			plan:   singleNodePlan,
+		},
+		{
+			name: "no worker",
+			plan: multiNodePlan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyMultiNodePlan(tt.worker, tt.plan)
+			if tt.worker != nil {
+				assert.Equal(t, tt.expectWorkerSize, tt.worker.Size)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+const (
+	// DefaultStepPercent is the traffic percent added to the canary after each successful analysis
+	DefaultStepPercent int64 = 10
+	// DefaultIntervalSeconds is how long the canary serves each step before it is analyzed
+	DefaultIntervalSeconds int64 = 300
+	// DefaultMaxInconclusiveAnalyses is the number of consecutive inconclusive analyses after which the canary is
+	// rolled back
+	DefaultMaxInconclusiveAnalyses int64 = 3
+)
+
+// defaultQueries are the Prometheus queries of the analyzed metrics per runtime family. The error rate is the
+// fraction of aborted requests, the time to first token the p95 in seconds and the throughput the generated
+// tokens per second.
+var defaultQueries = map[utils.RuntimeFamily]v1beta1.CanaryAnalysisQueries{
+	utils.RuntimeFamilySGLang: {
+		ErrorRate: `sum(rate(sglang:num_aborted_requests_total{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]))` +
+			` / sum(rate(sglang:num_requests_total{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]))`,
+		TimeToFirstToken: `histogram_quantile(0.95, sum by (le) (rate(sglang:time_to_first_token_seconds_bucket{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}])))`,
+		Throughput:       `sum(rate(sglang:generation_tokens_total{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]))`,
+	},
+	utils.RuntimeFamilyVLLM: {
+		ErrorRate: `sum(rate(vllm:request_success_total{namespace="{{.Namespace}}",app="{{.App}}",finished_reason="abort"}[{{.Window}}]))` +
+			` / sum(rate(vllm:request_success_total{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]))`,
+		TimeToFirstToken: `histogram_quantile(0.95, sum by (le) (rate(vllm:time_to_first_token_seconds_bucket{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}])))`,
+		Throughput:       `sum(rate(vllm:generation_tokens_total{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]))`,
+	},
+}
+
+// Target describes the stable and canary workloads of a component
+type Target struct {
+	Component v1beta1.ComponentType
+	Namespace string
+	// Revision is the desired revision of the component
+	Revision string
+	// StableRevision is the revision of the stable workload
+	StableRevision string
+	// StableApp and CanaryApp are the app labels of the stable and canary pods
+	StableApp string
+	CanaryApp string
+	// CanaryReady reports whether the canary workload finished rolling out the revision
+	CanaryReady bool
+	// RuntimeFamily is the serving engine of the component, which selects the default queries. It is empty when
+	// the component does not run a recognized serving engine.
+	RuntimeFamily utils.RuntimeFamily
+	// PromServerAddress is the address of the Prometheus server the metrics are queried from
+	PromServerAddress string
+}
+
+// Decision is the canary rollout chosen by the analysis
+type Decision struct {
+	// TrafficPercent is the percent of traffic routed to the canary, nil when the canary is not analyzed
+	TrafficPercent *int64
+	// RolledBack reports that the canary failed the analysis and is removed
+	RolledBack bool
+}
+
+// Analyzer steps a canary through its traffic percents based on the metrics of the canary and stable workloads
+type Analyzer struct {
+	NewMetricsClient func(address string) (prometheus.MetricsClient, error)
+	Now              func() time.Time
+}
+
+// NewAnalyzer creates an Analyzer that queries Prometheus
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{
+		NewMetricsClient: prometheus.NewClient,
+		Now:              time.Now,
+	}
+}
+
+type verdict int
+
+const (
+	inconclusive verdict = iota
+	passed
+	failed
+)
+
+// Analyze advances the canary analysis of a component and records it in the component status and its canary
+// analysis condition. A new revision starts at the canary traffic percent of the component, or at the step percent.
+// Once the canary served an interval and is ready, its metrics are compared with the stable workload: the percent
+// is stepped up when every threshold holds, the canary is promoted at 100 percent and rolled back on the first breach.
+// Inconclusive analyses, e.g. without traffic, keep the percent and analyze again after the next interval until
+// too many of them in a row roll the canary back. A revision whose metrics have no query is rolled back right away.
+func (a *Analyzer) Analyze(ctx context.Context, isvc *v1beta1.InferenceService, componentSpec *v1beta1.ComponentExtensionSpec, target Target) Decision {
+	if target.StableRevision == "" || target.StableRevision == target.Revision {
+		// There is no canary to analyze, or it was promoted and the stable workload rolled out its revision
+		return Decision{TrafficPercent: componentSpec.CanaryTrafficPercent}
+	}
+
+	spec := componentSpec.CanaryAnalysis
+	now := a.Now()
+	interval := time.Duration(intervalSeconds(spec)) * time.Second
+	componentStatus := isvc.Status.Components[target.Component]
+	analysis := componentStatus.CanaryAnalysis
+
+	if analysis == nil || analysis.Revision != target.Revision {
+		percent := stepPercent(spec)
+		if componentSpec.CanaryTrafficPercent != nil {
+			percent = *componentSpec.CanaryTrafficPercent
+		}
+		analysis = &v1beta1.CanaryAnalysisStatus{
+			Revision:           target.Revision,
+			Phase:              v1beta1.CanaryAnalysisProgressing,
+			TrafficPercent:     percent,
+			LastTransitionTime: &metav1.Time{Time: now},
+			NextAnalysisTime:   &metav1.Time{Time: now.Add(interval)},
+			Message:            fmt.Sprintf("Canary revision %s started at %d percent of traffic", target.Revision, percent),
+		}
+		if percent >= 100 {
+			analysis.Phase = v1beta1.CanaryAnalysisPromoted
+			analysis.TrafficPercent = 100
+			analysis.NextAnalysisTime = nil
+			analysis.Message = fmt.Sprintf("Canary revision %s promoted without analysis", target.Revision)
+		} else if _, err := buildChecks(spec, target.RuntimeFamily, percent); err != nil {
+			// The analysis can never conclude, so the revision gets no traffic
+			rollBack(analysis, target.Revision, now, err.Error())
+		}
+	} else if analysis.Phase == v1beta1.CanaryAnalysisProgressing {
+		analysis = analysis.DeepCopy()
+		switch {
+		case analysis.NextAnalysisTime != nil && now.Before(analysis.NextAnalysisTime.Time):
+		case !target.CanaryReady:
+			// A canary that is not ready serves no traffic to analyze
+			analysis.NextAnalysisTime = &metav1.Time{Time: now.Add(interval)}
+			analysis.Message = fmt.Sprintf("Waiting for canary revision %s to become ready", target.Revision)
+		default:
+			a.step(ctx, analysis, spec, target, now, interval)
+		}
+	}
+
+	setStatus(isvc, target.Component, analysis)
+	switch analysis.Phase {
+	case v1beta1.CanaryAnalysisRolledBack:
+		return Decision{RolledBack: true}
+	case v1beta1.CanaryAnalysisPromoted:
+		return Decision{TrafficPercent: ptr.To(int64(100))}
+	default:
+		return Decision{TrafficPercent: ptr.To(analysis.TrafficPercent)}
+	}
+}
+
+// step analyzes the canary metrics and updates the analysis with the verdict
+func (a *Analyzer) step(ctx context.Context, analysis *v1beta1.CanaryAnalysisStatus, spec *v1beta1.CanaryAnalysisSpec, target Target, now time.Time, interval time.Duration) {
+	result, message := a.evaluate(ctx, spec, target, analysis.TrafficPercent, interval)
+	switch result {
+	case failed:
+		rollBack(analysis, target.Revision, now, message)
+	case passed:
+		analysis.InconclusiveAnalyses = 0
+		analysis.TrafficPercent += stepPercent(spec)
+		analysis.LastTransitionTime = &metav1.Time{Time: now}
+		if analysis.TrafficPercent >= 100 {
+			analysis.Phase = v1beta1.CanaryAnalysisPromoted
+			analysis.TrafficPercent = 100
+			analysis.NextAnalysisTime = nil
+			analysis.Message = fmt.Sprintf("Canary revision %s promoted: %s", target.Revision, message)
+		} else {
+			analysis.NextAnalysisTime = &metav1.Time{Time: now.Add(interval)}
+			analysis.Message = fmt.Sprintf("Canary revision %s stepped to %d percent of traffic: %s", target.Revision, analysis.TrafficPercent, message)
+		}
+	default:
+		analysis.InconclusiveAnalyses++
+		limit := maxInconclusiveAnalyses(spec)
+		if analysis.InconclusiveAnalyses >= limit {
+			rollBack(analysis, target.Revision, now, fmt.Sprintf("%d consecutive analyses were inconclusive, last: %s", limit, message))
+			return
+		}
+		analysis.NextAnalysisTime = &metav1.Time{Time: now.Add(interval)}
+		analysis.Message = fmt.Sprintf("Canary revision %s analysis inconclusive (%d of %d): %s",
+			target.Revision, analysis.InconclusiveAnalyses, limit, message)
+	}
+}
+
+// rollBack moves the analysis to the rolled back phase, which removes the canary
+func rollBack(analysis *v1beta1.CanaryAnalysisStatus, revision string, now time.Time, message string) {
+	analysis.Phase = v1beta1.CanaryAnalysisRolledBack
+	analysis.TrafficPercent = 0
+	analysis.LastTransitionTime = &metav1.Time{Time: now}
+	analysis.NextAnalysisTime = nil
+	analysis.Message = fmt.Sprintf("Canary revision %s rolled back: %s", revision, message)
+}
+
+// check compares a metric of the canary with the stable workload
+type check struct {
+	name  string
+	query string
+	// compare returns a description of the breach when the canary value violates the threshold
+	compare func(stable, canary float64) (string, bool)
+}
+
+// evaluate runs the configured checks. The canary fails on the first breached threshold or when the checks are
+// misconfigured, and the analysis is inconclusive when a metric cannot be queried for both workloads.
+func (a *Analyzer) evaluate(ctx context.Context, spec *v1beta1.CanaryAnalysisSpec, target Target, percent int64, interval time.Duration) (verdict, string) {
+	checks, err := buildChecks(spec, target.RuntimeFamily, percent)
+	if err != nil {
+		return failed, err.Error()
+	}
+	if len(checks) == 0 {
+		return passed, "no thresholds configured"
+	}
+
+	client, err := a.NewMetricsClient(target.PromServerAddress)
+	if err != nil {
+		return inconclusive, err.Error()
+	}
+	window := fmt.Sprintf("%ds", int64(interval.Seconds()))
+	var results []string
+	for _, c := range checks {
+		stable, ok, err := queryWorkload(ctx, client, c.query, target.Namespace, target.StableApp, window)
+		if err != nil || !ok {
+			return inconclusive, noData(c.name, "stable", err)
+		}
+		canary, ok, err := queryWorkload(ctx, client, c.query, target.Namespace, target.CanaryApp, window)
+		if err != nil || !ok {
+			return inconclusive, noData(c.name, "canary", err)
+		}
+		if breach, ok := c.compare(stable, canary); ok {
+			return failed, breach
+		}
+		results = append(results, fmt.Sprintf("%s %.4g (stable %.4g)", c.name, canary, stable))
+	}
+	return passed, strings.Join(results, ", ")
+}
+
+// buildChecks returns the checks of the configured thresholds. Metrics without a configured query use the default
+// query of the runtime family, and it is an error when there is none.
+func buildChecks(spec *v1beta1.CanaryAnalysisSpec, family utils.RuntimeFamily, percent int64) ([]check, error) {
+	queries := v1beta1.CanaryAnalysisQueries{}
+	if spec.Queries != nil {
+		queries = *spec.Queries
+	}
+	defaults := defaultQueries[family]
+	query := func(field, configured, defaultQuery string) (string, error) {
+		if configured != "" {
+			return configured, nil
+		}
+		if defaultQuery != "" {
+			return defaultQuery, nil
+		}
+		return "", fmt.Errorf("there is no default %s query for the runtime of the component, set queries.%s", field, field)
+	}
+
+	var checks []check
+	if spec.MaxErrorRateIncrease != "" {
+		maxIncrease, err := strconv.ParseFloat(spec.MaxErrorRateIncrease, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid maxErrorRateIncrease %q", spec.MaxErrorRateIncrease)
+		}
+		errorRateQuery, err := query("errorRate", queries.ErrorRate, defaults.ErrorRate)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check{
+			name:  "error rate",
+			query: errorRateQuery,
+			compare: func(stable, canary float64) (string, bool) {
+				if canary-stable > maxIncrease {
+					return fmt.Sprintf("error rate %.4g exceeds stable error rate %.4g by more than %s", canary, stable, spec.MaxErrorRateIncrease), true
+				}
+				return "", false
+			},
+		})
+	}
+	if spec.MaxTTFTIncreasePercent != nil {
+		maxIncrease := *spec.MaxTTFTIncreasePercent
+		timeToFirstTokenQuery, err := query("timeToFirstToken", queries.TimeToFirstToken, defaults.TimeToFirstToken)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check{
+			name:  "time to first token",
+			query: timeToFirstTokenQuery,
+			compare: func(stable, canary float64) (string, bool) {
+				if canary > stable*float64(100+maxIncrease)/100 {
+					return fmt.Sprintf("time to first token %.4gs exceeds stable %.4gs by more than %d percent", canary, stable, maxIncrease), true
+				}
+				return "", false
+			},
+		})
+	}
+	if spec.MinThroughputPercent != nil && percent > 0 && percent < 100 {
+		minPercent := *spec.MinThroughputPercent
+		throughputQuery, err := query("throughput", queries.Throughput, defaults.Throughput)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check{
+			name:  "throughput",
+			query: throughputQuery,
+			compare: func(stable, canary float64) (string, bool) {
+				// Each workload serves its share of the traffic
+				canaryShare := canary * 100 / float64(percent)
+				stableShare := stable * 100 / float64(100-percent)
+				if canaryShare < stableShare*float64(minPercent)/100 {
+					return fmt.Sprintf("throughput %.4g tokens/s at %d percent of traffic is below %d percent of stable %.4g tokens/s at %d percent",
+						canary, percent, minPercent, stable, 100-percent), true
+				}
+				return "", false
+			},
+		})
+	}
+	return checks, nil
+}
+
+// queryWorkload renders the query for a workload and runs it
+func queryWorkload(ctx context.Context, client prometheus.MetricsClient, queryTemplate, namespace, app, window string) (float64, bool, error) {
+	tmpl, err := template.New("query").Parse(queryTemplate)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "invalid query template")
+	}
+	var query bytes.Buffer
+	values := struct{ Namespace, App, Window string }{Namespace: namespace, App: app, Window: window}
+	if err := tmpl.Execute(&query, values); err != nil {
+		return 0, false, errors.Wrap(err, "invalid query template")
+	}
+	return client.Query(ctx, query.String())
+}
+
+// setStatus records the analysis in the component status and its canary analysis condition
+func setStatus(isvc *v1beta1.InferenceService, component v1beta1.ComponentType, analysis *v1beta1.CanaryAnalysisStatus) {
+	if isvc.Status.Components == nil {
+		isvc.Status.Components = make(map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec)
+	}
+	componentStatus := isvc.Status.Components[component]
+	componentStatus.CanaryAnalysis = analysis
+	isvc.Status.Components[component] = componentStatus
+
+	conditionType, ok := conditionTypes[component]
+	if !ok {
+		return
+	}
+	condition := &apis.Condition{Type: conditionType, Reason: string(analysis.Phase), Message: analysis.Message}
+	switch analysis.Phase {
+	case v1beta1.CanaryAnalysisPromoted:
+		condition.Status = v1.ConditionTrue
+	case v1beta1.CanaryAnalysisRolledBack:
+		condition.Status = v1.ConditionFalse
+	default:
+		condition.Status = v1.ConditionUnknown
+	}
+	isvc.Status.SetCondition(conditionType, condition)
+}
+
+// conditionTypes maps components to their canary analysis condition
+var conditionTypes = map[v1beta1.ComponentType]apis.ConditionType{
+	v1beta1.EngineComponent:  v1beta1.EngineCanaryAnalysis,
+	v1beta1.DecoderComponent: v1beta1.DecoderCanaryAnalysis,
+	v1beta1.RouterComponent:  v1beta1.RouterCanaryAnalysis,
+}
+
+// NextAnalysisTime returns the earliest time a component canary of the InferenceService is analyzed next
+func NextAnalysisTime(isvc *v1beta1.InferenceService) *time.Time {
+	var next *time.Time
+	for _, componentStatus := range isvc.Status.Components {
+		analysis := componentStatus.CanaryAnalysis
+		if analysis == nil || analysis.Phase != v1beta1.CanaryAnalysisProgressing || analysis.NextAnalysisTime == nil {
+			continue
+		}
+		if next == nil || analysis.NextAnalysisTime.Time.Before(*next) {
+			t := analysis.NextAnalysisTime.Time
+			next = &t
+		}
+	}
+	return next
+}
+
+func stepPercent(spec *v1beta1.CanaryAnalysisSpec) int64 {
+	if spec.StepPercent > 0 {
+		return spec.StepPercent
+	}
+	return DefaultStepPercent
+}
+
+func intervalSeconds(spec *v1beta1.CanaryAnalysisSpec) int64 {
+	if spec.IntervalSeconds > 0 {
+		return spec.IntervalSeconds
+	}
+	return DefaultIntervalSeconds
+}
+
+func maxInconclusiveAnalyses(spec *v1beta1.CanaryAnalysisSpec) int64 {
+	if spec.MaxInconclusiveAnalyses > 0 {
+		return spec.MaxInconclusiveAnalyses
+	}
+	return DefaultMaxInconclusiveAnalyses
+}
+
+func noData(metric, workload string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("failed to query %s of %s: %v", metric, workload, err)
+	}
+	return fmt.Sprintf("no %s samples for %s", metric, workload)
+}
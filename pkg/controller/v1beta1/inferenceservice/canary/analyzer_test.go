@@ -0,0 +1,468 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+// fakeMetrics returns the values of the metric queries of the stable and canary workloads
+type fakeMetrics struct {
+	stable  map[string]float64
+	canary  map[string]float64
+	err     error
+	queries []string
+}
+
+func (f *fakeMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	f.queries = append(f.queries, query)
+	if f.err != nil {
+		return 0, false, f.err
+	}
+	values := f.stable
+	if strings.Contains(query, `app="llama-engine-canary"`) {
+		values = f.canary
+	}
+	for metric, value := range values {
+		if strings.Contains(query, metric) {
+			return value, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func healthyMetrics() *fakeMetrics {
+	return &fakeMetrics{
+		stable: map[string]float64{"num_aborted": 0.01, "time_to_first_token": 0.2, "generation_tokens": 900},
+		canary: map[string]float64{"num_aborted": 0.012, "time_to_first_token": 0.21, "generation_tokens": 100},
+	}
+}
+
+func analysisTestSpec() *v1beta1.ComponentExtensionSpec {
+	return &v1beta1.ComponentExtensionSpec{
+		CanaryAnalysis: &v1beta1.CanaryAnalysisSpec{
+			StepPercent:            10,
+			IntervalSeconds:        60,
+			MaxErrorRateIncrease:   "0.01",
+			MaxTTFTIncreasePercent: ptr.To(int64(20)),
+			MinThroughputPercent:   ptr.To(int64(80)),
+		},
+	}
+}
+
+func analysisTestTarget(canaryReady bool) Target {
+	return Target{
+		Component:         v1beta1.EngineComponent,
+		Namespace:         "default",
+		Revision:          "llama-engine-2",
+		StableRevision:    "llama-engine-1",
+		StableApp:         "llama-engine",
+		CanaryApp:         "llama-engine-canary",
+		CanaryReady:       canaryReady,
+		RuntimeFamily:     utils.RuntimeFamilySGLang,
+		PromServerAddress: "http://prometheus:9090",
+	}
+}
+
+func analysisTestService(analysis *v1beta1.CanaryAnalysisStatus) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Status: v1beta1.InferenceServiceStatus{
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.EngineComponent: {CanaryAnalysis: analysis},
+			},
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	due := &metav1.Time{Time: now.Add(-time.Second)}
+	progressing := func(percent int64, next *metav1.Time) *v1beta1.CanaryAnalysisStatus {
+		return &v1beta1.CanaryAnalysisStatus{
+			Revision:         "llama-engine-2",
+			Phase:            v1beta1.CanaryAnalysisProgressing,
+			TrafficPercent:   percent,
+			NextAnalysisTime: next,
+		}
+	}
+	inconclusiveBefore := func(analyses int64) *v1beta1.CanaryAnalysisStatus {
+		analysis := progressing(10, due)
+		analysis.InconclusiveAnalyses = analyses
+		return analysis
+	}
+	unknownRuntime := analysisTestTarget(false)
+	unknownRuntime.RuntimeFamily = ""
+
+	tests := []struct {
+		name                 string
+		canaryTrafficPercent *int64
+		analysis             *v1beta1.CanaryAnalysisStatus
+		target               Target
+		metrics              *fakeMetrics
+		expected             Decision
+		expectedPhase        v1beta1.CanaryAnalysisPhase
+		expectedCondition    v1.ConditionStatus
+		expectedMessage      string
+		expectedInconclusive int64
+		expectNoQueries      bool
+	}{
+		{
+			name:              "new revision starts at the step percent",
+			target:            analysisTestTarget(false),
+			metrics:           healthyMetrics(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(10))},
+			expectedPhase:     v1beta1.CanaryAnalysisProgressing,
+			expectedCondition: v1.ConditionUnknown,
+			expectedMessage:   "started at 10 percent",
+			expectNoQueries:   true,
+		},
+		{
+			name:                 "new revision starts at the canary traffic percent",
+			canaryTrafficPercent: ptr.To(int64(5)),
+			analysis:             &v1beta1.CanaryAnalysisStatus{Revision: "llama-engine-1", Phase: v1beta1.CanaryAnalysisPromoted},
+			target:               analysisTestTarget(false),
+			metrics:              healthyMetrics(),
+			expected:             Decision{TrafficPercent: ptr.To(int64(5))},
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedCondition:    v1.ConditionUnknown,
+			expectedMessage:      "started at 5 percent",
+			expectNoQueries:      true,
+		},
+		{
+			name:              "analysis waits for the interval",
+			analysis:          progressing(10, &metav1.Time{Time: now.Add(time.Minute)}),
+			target:            analysisTestTarget(true),
+			metrics:           healthyMetrics(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(10))},
+			expectedPhase:     v1beta1.CanaryAnalysisProgressing,
+			expectedCondition: v1.ConditionUnknown,
+			expectNoQueries:   true,
+		},
+		{
+			name:              "analysis waits for the canary to become ready",
+			analysis:          progressing(10, due),
+			target:            analysisTestTarget(false),
+			metrics:           healthyMetrics(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(10))},
+			expectedPhase:     v1beta1.CanaryAnalysisProgressing,
+			expectedCondition: v1.ConditionUnknown,
+			expectedMessage:   "Waiting for canary revision llama-engine-2 to become ready",
+			expectNoQueries:   true,
+		},
+		{
+			name:              "healthy canary is stepped up",
+			analysis:          progressing(10, due),
+			target:            analysisTestTarget(true),
+			metrics:           healthyMetrics(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(20))},
+			expectedPhase:     v1beta1.CanaryAnalysisProgressing,
+			expectedCondition: v1.ConditionUnknown,
+			expectedMessage:   "stepped to 20 percent",
+		},
+		{
+			name:     "healthy canary is promoted at the last step",
+			analysis: progressing(90, due),
+			target:   analysisTestTarget(true),
+			metrics: func() *fakeMetrics {
+				m := healthyMetrics()
+				m.stable["generation_tokens"], m.canary["generation_tokens"] = 100, 900
+				return m
+			}(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(100))},
+			expectedPhase:     v1beta1.CanaryAnalysisPromoted,
+			expectedCondition: v1.ConditionTrue,
+			expectedMessage:   "promoted",
+		},
+		{
+			name:     "error rate increase rolls back",
+			analysis: progressing(10, due),
+			target:   analysisTestTarget(true),
+			metrics: func() *fakeMetrics {
+				m := healthyMetrics()
+				m.canary["num_aborted"] = 0.05
+				return m
+			}(),
+			expected:          Decision{RolledBack: true},
+			expectedPhase:     v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition: v1.ConditionFalse,
+			expectedMessage:   "error rate 0.05 exceeds stable error rate 0.01",
+		},
+		{
+			name:     "time to first token increase rolls back",
+			analysis: progressing(10, due),
+			target:   analysisTestTarget(true),
+			metrics: func() *fakeMetrics {
+				m := healthyMetrics()
+				m.canary["time_to_first_token"] = 0.3
+				return m
+			}(),
+			expected:          Decision{RolledBack: true},
+			expectedPhase:     v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition: v1.ConditionFalse,
+			expectedMessage:   "exceeds stable 0.2s by more than 20 percent",
+		},
+		{
+			name:     "throughput drop at the traffic share rolls back",
+			analysis: progressing(10, due),
+			target:   analysisTestTarget(true),
+			metrics: func() *fakeMetrics {
+				m := healthyMetrics()
+				m.canary["generation_tokens"] = 50
+				return m
+			}(),
+			expected:          Decision{RolledBack: true},
+			expectedPhase:     v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition: v1.ConditionFalse,
+			expectedMessage:   "throughput 50 tokens/s at 10 percent of traffic",
+		},
+		{
+			name:     "missing samples are inconclusive",
+			analysis: progressing(10, due),
+			target:   analysisTestTarget(true),
+			metrics: func() *fakeMetrics {
+				m := healthyMetrics()
+				delete(m.canary, "time_to_first_token")
+				return m
+			}(),
+			expected:             Decision{TrafficPercent: ptr.To(int64(10))},
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedCondition:    v1.ConditionUnknown,
+			expectedMessage:      "inconclusive (1 of 3): no time to first token samples for canary",
+			expectedInconclusive: 1,
+		},
+		{
+			name:                 "unreachable Prometheus is inconclusive",
+			analysis:             progressing(10, due),
+			target:               analysisTestTarget(true),
+			metrics:              &fakeMetrics{err: errors.New("connection refused")},
+			expected:             Decision{TrafficPercent: ptr.To(int64(10))},
+			expectedPhase:        v1beta1.CanaryAnalysisProgressing,
+			expectedCondition:    v1.ConditionUnknown,
+			expectedMessage:      "connection refused",
+			expectedInconclusive: 1,
+		},
+		{
+			name:                 "inconclusive analyses roll back at the limit",
+			analysis:             inconclusiveBefore(2),
+			target:               analysisTestTarget(true),
+			metrics:              &fakeMetrics{err: errors.New("connection refused")},
+			expected:             Decision{RolledBack: true},
+			expectedPhase:        v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition:    v1.ConditionFalse,
+			expectedMessage:      "3 consecutive analyses were inconclusive, last: failed to query error rate of stable: connection refused",
+			expectedInconclusive: 3,
+		},
+		{
+			name:              "conclusive analysis resets the inconclusive analyses",
+			analysis:          inconclusiveBefore(2),
+			target:            analysisTestTarget(true),
+			metrics:           healthyMetrics(),
+			expected:          Decision{TrafficPercent: ptr.To(int64(20))},
+			expectedPhase:     v1beta1.CanaryAnalysisProgressing,
+			expectedCondition: v1.ConditionUnknown,
+			expectedMessage:   "stepped to 20 percent",
+		},
+		{
+			name:              "new revision of a runtime without default queries is rolled back",
+			target:            unknownRuntime,
+			metrics:           healthyMetrics(),
+			expected:          Decision{RolledBack: true},
+			expectedPhase:     v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition: v1.ConditionFalse,
+			expectedMessage:   "there is no default errorRate query for the runtime of the component, set queries.errorRate",
+			expectNoQueries:   true,
+		},
+		{
+			name:              "rolled back revision stays rolled back",
+			analysis:          &v1beta1.CanaryAnalysisStatus{Revision: "llama-engine-2", Phase: v1beta1.CanaryAnalysisRolledBack},
+			target:            analysisTestTarget(true),
+			metrics:           healthyMetrics(),
+			expected:          Decision{RolledBack: true},
+			expectedPhase:     v1beta1.CanaryAnalysisRolledBack,
+			expectedCondition: v1.ConditionFalse,
+			expectNoQueries:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := &Analyzer{
+				NewMetricsClient: func(address string) (prometheus.MetricsClient, error) {
+					assert.Equal(t, "http://prometheus:9090", address)
+					return tt.metrics, nil
+				},
+				Now: func() time.Time { return now },
+			}
+			componentSpec := analysisTestSpec()
+			componentSpec.CanaryTrafficPercent = tt.canaryTrafficPercent
+			isvc := analysisTestService(tt.analysis)
+
+			decision := analyzer.Analyze(context.TODO(), isvc, componentSpec, tt.target)
+			assert.Equal(t, tt.expected, decision)
+
+			analysis := isvc.Status.Components[v1beta1.EngineComponent].CanaryAnalysis
+			require.NotNil(t, analysis)
+			assert.Equal(t, "llama-engine-2", analysis.Revision)
+			assert.Equal(t, tt.expectedPhase, analysis.Phase)
+			assert.Contains(t, analysis.Message, tt.expectedMessage)
+			assert.Equal(t, tt.expectedInconclusive, analysis.InconclusiveAnalyses)
+			condition := isvc.Status.GetCondition(v1beta1.EngineCanaryAnalysis)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedCondition, condition.Status)
+			if tt.expectNoQueries {
+				assert.Empty(t, tt.metrics.queries)
+			}
+		})
+	}
+}
+
+func TestAnalyzeWithoutCanary(t *testing.T) {
+	analyzer := &Analyzer{Now: time.Now}
+	componentSpec := analysisTestSpec()
+	componentSpec.CanaryTrafficPercent = ptr.To(int64(30))
+
+	for _, stableRevision := range []string{"", "llama-engine-2"} {
+		target := analysisTestTarget(true)
+		target.StableRevision = stableRevision
+		isvc := analysisTestService(nil)
+		decision := analyzer.Analyze(context.TODO(), isvc, componentSpec, target)
+		assert.Equal(t, Decision{TrafficPercent: ptr.To(int64(30))}, decision)
+		assert.Nil(t, isvc.Status.Components[v1beta1.EngineComponent].CanaryAnalysis)
+	}
+}
+
+func TestAnalyzeRendersQueries(t *testing.T) {
+	now := time.Now()
+	metrics := healthyMetrics()
+	metrics.stable["ttft_custom"] = 0.2
+	metrics.canary["ttft_custom"] = 0.2
+	analyzer := &Analyzer{
+		NewMetricsClient: func(string) (prometheus.MetricsClient, error) { return metrics, nil },
+		Now:              func() time.Time { return now },
+	}
+	componentSpec := &v1beta1.ComponentExtensionSpec{
+		CanaryAnalysis: &v1beta1.CanaryAnalysisSpec{
+			IntervalSeconds:        120,
+			MaxTTFTIncreasePercent: ptr.To(int64(10)),
+			Queries: &v1beta1.CanaryAnalysisQueries{
+				TimeToFirstToken: `ttft_custom{namespace="{{.Namespace}}",app="{{.App}}"}[{{.Window}}]`,
+			},
+		},
+	}
+	isvc := analysisTestService(&v1beta1.CanaryAnalysisStatus{
+		Revision:       "llama-engine-2",
+		Phase:          v1beta1.CanaryAnalysisProgressing,
+		TrafficPercent: 10,
+	})
+
+	analyzer.Analyze(context.TODO(), isvc, componentSpec, analysisTestTarget(true))
+	assert.Equal(t, []string{
+		`ttft_custom{namespace="default",app="llama-engine"}[120s]`,
+		`ttft_custom{namespace="default",app="llama-engine-canary"}[120s]`,
+	}, metrics.queries)
+}
+
+func TestAnalyzeRuntimeQueries(t *testing.T) {
+	tests := []struct {
+		name            string
+		runtimeFamily   utils.RuntimeFamily
+		queries         *v1beta1.CanaryAnalysisQueries
+		expectedQueries []string
+	}{
+		{
+			name:          "sglang defaults",
+			runtimeFamily: utils.RuntimeFamilySGLang,
+			expectedQueries: []string{
+				`histogram_quantile(0.95, sum by (le) (rate(sglang:time_to_first_token_seconds_bucket{namespace="default",app="llama-engine"}[60s])))`,
+				`histogram_quantile(0.95, sum by (le) (rate(sglang:time_to_first_token_seconds_bucket{namespace="default",app="llama-engine-canary"}[60s])))`,
+			},
+		},
+		{
+			name:          "vllm defaults",
+			runtimeFamily: utils.RuntimeFamilyVLLM,
+			expectedQueries: []string{
+				`histogram_quantile(0.95, sum by (le) (rate(vllm:time_to_first_token_seconds_bucket{namespace="default",app="llama-engine"}[60s])))`,
+				`histogram_quantile(0.95, sum by (le) (rate(vllm:time_to_first_token_seconds_bucket{namespace="default",app="llama-engine-canary"}[60s])))`,
+			},
+		},
+		{
+			name:    "configured queries of another runtime",
+			queries: &v1beta1.CanaryAnalysisQueries{TimeToFirstToken: `ttft{app="{{.App}}"}`},
+			expectedQueries: []string{
+				`ttft{app="llama-engine"}`,
+				`ttft{app="llama-engine-canary"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			metrics := &fakeMetrics{
+				stable: map[string]float64{"ttft": 0.2, "time_to_first_token": 0.2},
+				canary: map[string]float64{"ttft": 0.2, "time_to_first_token": 0.2},
+			}
+			analyzer := &Analyzer{
+				NewMetricsClient: func(string) (prometheus.MetricsClient, error) { return metrics, nil },
+				Now:              func() time.Time { return now },
+			}
+			componentSpec := &v1beta1.ComponentExtensionSpec{
+				CanaryAnalysis: &v1beta1.CanaryAnalysisSpec{
+					IntervalSeconds:        60,
+					MaxTTFTIncreasePercent: ptr.To(int64(10)),
+					Queries:                tt.queries,
+				},
+			}
+			target := analysisTestTarget(true)
+			target.RuntimeFamily = tt.runtimeFamily
+			isvc := analysisTestService(&v1beta1.CanaryAnalysisStatus{
+				Revision:       "llama-engine-2",
+				Phase:          v1beta1.CanaryAnalysisProgressing,
+				TrafficPercent: 10,
+			})
+
+			analyzer.Analyze(context.TODO(), isvc, componentSpec, target)
+			assert.Equal(t, tt.expectedQueries, metrics.queries)
+		})
+	}
+}
+
+func TestNextAnalysisTime(t *testing.T) {
+	now := time.Now()
+	isvc := &v1beta1.InferenceService{
+		Status: v1beta1.InferenceServiceStatus{
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.EngineComponent: {CanaryAnalysis: &v1beta1.CanaryAnalysisStatus{
+					Phase:            v1beta1.CanaryAnalysisProgressing,
+					NextAnalysisTime: &metav1.Time{Time: now.Add(5 * time.Minute)},
+				}},
+				v1beta1.DecoderComponent: {CanaryAnalysis: &v1beta1.CanaryAnalysisStatus{
+					Phase:            v1beta1.CanaryAnalysisProgressing,
+					NextAnalysisTime: &metav1.Time{Time: now.Add(time.Minute)},
+				}},
+				v1beta1.RouterComponent: {CanaryAnalysis: &v1beta1.CanaryAnalysisStatus{
+					Phase:            v1beta1.CanaryAnalysisRolledBack,
+					NextAnalysisTime: &metav1.Time{Time: now},
+				}},
+			},
+		},
+	}
+	next := NextAnalysisTime(isvc)
+	require.NotNil(t, next)
+	assert.True(t, next.Equal(now.Add(time.Minute)))
+
+	assert.Nil(t, NextAnalysisTime(&v1beta1.InferenceService{}))
+}
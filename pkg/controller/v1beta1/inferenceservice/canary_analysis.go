@@ -0,0 +1,68 @@
+package inferenceservice
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/canary"
+)
+
+const (
+	// CanaryStartedReason is used when the canary analysis of a new revision starts.
+	CanaryStartedReason = "CanaryStarted"
+	// CanarySteppedReason is used when the canary passed an analysis and receives more traffic.
+	CanarySteppedReason = "CanaryStepped"
+	// CanaryPromotedReason is used when the canary passed every analysis and replaces the stable workload.
+	CanaryPromotedReason = "CanaryPromoted"
+	// CanaryRolledBackReason is used when the canary breached a threshold, stayed inconclusive or could not be analyzed and was removed.
+	CanaryRolledBackReason = "CanaryRolledBack"
+
+	// minCanaryAnalysisRequeue bounds how soon a due canary analysis is requeued
+	minCanaryAnalysisRequeue = time.Second
+)
+
+// recordCanaryAnalysisEvents emits an event for every canary analysis step persisted in the status
+func (r *InferenceServiceReconciler) recordCanaryAnalysisEvents(existing, desired *v1beta1.InferenceService) {
+	for component, componentStatus := range desired.Status.Components {
+		analysis := componentStatus.CanaryAnalysis
+		if analysis == nil {
+			continue
+		}
+		previous := existing.Status.Components[component].CanaryAnalysis
+		if previous != nil && previous.Revision == analysis.Revision &&
+			previous.Phase == analysis.Phase && previous.TrafficPercent == analysis.TrafficPercent {
+			continue
+		}
+
+		switch {
+		case analysis.Phase == v1beta1.CanaryAnalysisRolledBack:
+			r.Recorder.Event(desired, v1.EventTypeWarning, CanaryRolledBackReason, analysis.Message)
+		case analysis.Phase == v1beta1.CanaryAnalysisPromoted:
+			r.Recorder.Event(desired, v1.EventTypeNormal, CanaryPromotedReason, analysis.Message)
+		case previous == nil || previous.Revision != analysis.Revision:
+			r.Recorder.Event(desired, v1.EventTypeNormal, CanaryStartedReason, analysis.Message)
+		default:
+			r.Recorder.Event(desired, v1.EventTypeNormal, CanarySteppedReason, analysis.Message)
+		}
+	}
+}
+
+// requeueForCanaryAnalysis requeues the InferenceService when its next canary analysis is due, unless the result
+// already requeues it sooner
+func requeueForCanaryAnalysis(isvc *v1beta1.InferenceService, result ctrl.Result) ctrl.Result {
+	next := canary.NextAnalysisTime(isvc)
+	if next == nil || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+	after := time.Until(*next)
+	if after < minCanaryAnalysisRequeue {
+		after = minCanaryAnalysisRequeue
+	}
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
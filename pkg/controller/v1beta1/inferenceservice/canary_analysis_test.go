@@ -0,0 +1,137 @@
+package inferenceservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func canaryAnalysisTestService(analysis *v1beta1.CanaryAnalysisStatus) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"}}
+	if analysis != nil {
+		isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+			v1beta1.EngineComponent: {CanaryAnalysis: analysis},
+		}
+	}
+	return isvc
+}
+
+func TestRecordCanaryAnalysisEvents(t *testing.T) {
+	analysis := func(revision string, phase v1beta1.CanaryAnalysisPhase, percent int64) *v1beta1.CanaryAnalysisStatus {
+		return &v1beta1.CanaryAnalysisStatus{Revision: revision, Phase: phase, TrafficPercent: percent, Message: "analysis message"}
+	}
+
+	tests := []struct {
+		name          string
+		existing      *v1beta1.CanaryAnalysisStatus
+		desired       *v1beta1.CanaryAnalysisStatus
+		expectedEvent string
+	}{
+		{
+			name:          "analysis started",
+			desired:       analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 10),
+			expectedEvent: "Normal CanaryStarted analysis message",
+		},
+		{
+			name:          "analysis of a newer revision started",
+			existing:      analysis("llama-2", v1beta1.CanaryAnalysisRolledBack, 0),
+			desired:       analysis("llama-3", v1beta1.CanaryAnalysisProgressing, 10),
+			expectedEvent: "Normal CanaryStarted analysis message",
+		},
+		{
+			name:          "canary stepped",
+			existing:      analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 10),
+			desired:       analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 20),
+			expectedEvent: "Normal CanaryStepped analysis message",
+		},
+		{
+			name:          "canary promoted",
+			existing:      analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 90),
+			desired:       analysis("llama-2", v1beta1.CanaryAnalysisPromoted, 100),
+			expectedEvent: "Normal CanaryPromoted analysis message",
+		},
+		{
+			name:          "canary rolled back",
+			existing:      analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 30),
+			desired:       analysis("llama-2", v1beta1.CanaryAnalysisRolledBack, 0),
+			expectedEvent: "Warning CanaryRolledBack analysis message",
+		},
+		{
+			name:     "analysis unchanged",
+			existing: analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 30),
+			desired:  analysis("llama-2", v1beta1.CanaryAnalysisProgressing, 30),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &InferenceServiceReconciler{Recorder: recorder}
+			r.recordCanaryAnalysisEvents(canaryAnalysisTestService(tt.existing), canaryAnalysisTestService(tt.desired))
+
+			if tt.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			assert.Len(t, recorder.Events, 1)
+			assert.Equal(t, tt.expectedEvent, <-recorder.Events)
+		})
+	}
+}
+
+func TestRequeueForCanaryAnalysis(t *testing.T) {
+	progressing := func(next time.Time) *v1beta1.CanaryAnalysisStatus {
+		return &v1beta1.CanaryAnalysisStatus{
+			Phase:            v1beta1.CanaryAnalysisProgressing,
+			NextAnalysisTime: &metav1.Time{Time: next},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		analysis *v1beta1.CanaryAnalysisStatus
+		result   ctrl.Result
+		min, max time.Duration
+	}{
+		{
+			name: "no canary analysis",
+		},
+		{
+			name:     "requeued when the analysis is due",
+			analysis: progressing(time.Now().Add(5 * time.Minute)),
+			min:      4 * time.Minute,
+			max:      5 * time.Minute,
+		},
+		{
+			name:     "overdue analysis is requeued shortly",
+			analysis: progressing(time.Now().Add(-time.Minute)),
+			min:      time.Second,
+			max:      time.Second,
+		},
+		{
+			name:     "sooner requeue is kept",
+			analysis: progressing(time.Now().Add(5 * time.Minute)),
+			result:   ctrl.Result{RequeueAfter: time.Minute},
+			min:      time.Minute,
+			max:      time.Minute,
+		},
+		{
+			name:     "finished analysis is not requeued",
+			analysis: &v1beta1.CanaryAnalysisStatus{Phase: v1beta1.CanaryAnalysisPromoted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := requeueForCanaryAnalysis(canaryAnalysisTestService(tt.analysis), tt.result)
+			assert.GreaterOrEqual(t, result.RequeueAfter, tt.min)
+			assert.LessOrEqual(t, result.RequeueAfter, tt.max)
+		})
+	}
+}
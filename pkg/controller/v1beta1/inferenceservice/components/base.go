@@ -204,8 +204,12 @@ func UpdatePodSpecNodeSelector(b *BaseComponentFields, isvc *v1beta1.InferenceSe
 		return
 	}
 
-	// Add preferred node affinity for model readiness using the shared utility function
-	isvcutils.AddNodeSelectorForModelReadyNode(podSpec, b.BaseModelMeta)
+	// Schedule onto nodes with the model ready, preferring rather than requiring them when the model placement asks to
+	if isvc.Spec.ModelPlacement != nil && isvc.Spec.ModelPlacement.Scheduling == v1beta1.ModelReadyPreferred {
+		isvcutils.AddPreferredNodeAffinityForModelReadyNode(podSpec, b.BaseModelMeta)
+	} else {
+		isvcutils.AddNodeSelectorForModelReadyNode(podSpec, b.BaseModelMeta)
+	}
 
 	// Add node selector merged from AcceleratorClass if applicable
 	// Only add mergedNodeSelector to engine and decoder component.
@@ -350,7 +354,7 @@ func UpdateEngineAffinity(b *BaseComponentFields, isvc *v1beta1.InferenceService
 		isvc.Spec.Engine.PodSpec.Affinity == nil {
 		if b.AcceleratorClass != nil && b.AcceleratorClass.Discovery.Affinity != nil {
 			b.Log.Info("Merging affinity from accelerator class into engine pod spec as user did not specify affinity in InferenceService")
-			podSpec.Affinity = b.AcceleratorClass.Discovery.Affinity
+			podSpec.Affinity = b.AcceleratorClass.Discovery.Affinity.DeepCopy()
 		}
 	}
 }
@@ -362,7 +366,7 @@ func UpdateDecoderAffinity(b *BaseComponentFields, isvc *v1beta1.InferenceServic
 		isvc.Spec.Decoder.PodSpec.Affinity == nil {
 		if b.AcceleratorClass != nil && b.AcceleratorClass.Discovery.Affinity != nil {
 			b.Log.Info("Merging affinity from accelerator class into decoder pod spec as user did not specify affinity in InferenceService")
-			podSpec.Affinity = b.AcceleratorClass.Discovery.Affinity
+			podSpec.Affinity = b.AcceleratorClass.Discovery.Affinity.DeepCopy()
 		}
 	}
 }
@@ -171,6 +171,63 @@ func TestUpdatePodSpecNodeSelector(t *testing.T) {
 	}
 }
 
+func TestUpdatePodSpecNodeSelectorPreferredModelPlacement(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	acAffinity := &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{
+						Key:      "nvidia.com/gpu.product",
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{"H100"},
+					}},
+				}},
+			},
+		},
+	}
+	b := &BaseComponentFields{
+		BaseModel:     &v1beta1.BaseModelSpec{},
+		BaseModelMeta: &metav1.ObjectMeta{Name: "test-model"},
+		AcceleratorClass: &v1beta1.AcceleratorClassSpec{
+			Discovery: v1beta1.AcceleratorDiscovery{Affinity: acAffinity},
+		},
+		Log: logr.Discard(),
+	}
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			Engine:         &v1beta1.EngineSpec{},
+			ModelPlacement: &v1beta1.ModelPlacementSpec{Scheduling: v1beta1.ModelReadyPreferred},
+		},
+	}
+
+	// Reconciling twice adds the preferred term once
+	podSpec := &v1.PodSpec{}
+	for i := 0; i < 2; i++ {
+		UpdateEngineAffinity(b, isvc, podSpec)
+		UpdatePodSpecNodeSelector(b, isvc, podSpec, v1beta1.EngineComponent)
+	}
+
+	g.Expect(podSpec.NodeSelector).NotTo(gomega.HaveKey(constants.GetClusterBaseModelLabel("test-model")))
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	g.Expect(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(gomega.Equal(acAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution))
+	g.Expect(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(gomega.Equal([]v1.PreferredSchedulingTerm{{
+		Weight: 100,
+		Preference: v1.NodeSelectorTerm{
+			MatchExpressions: []v1.NodeSelectorRequirement{{
+				Key:      constants.GetClusterBaseModelLabel("test-model"),
+				Operator: v1.NodeSelectorOpIn,
+				Values:   []string{"Ready"},
+			}},
+		},
+	}}))
+
+	// The accelerator class affinity is left untouched
+	g.Expect(acAffinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(gomega.BeEmpty())
+}
+
 func TestProcessBaseLabels(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
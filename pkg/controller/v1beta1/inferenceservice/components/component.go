@@ -1,6 +1,7 @@
 package components
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -28,6 +29,30 @@ type ComponentConfig interface {
 	ValidateSpec() error
 }
 
+// ComponentConfigExtractor exposes the rendered configuration of a component, so that workload strategies
+// can deploy components together instead of through the Reconcile method of each component
+type ComponentConfigExtractor interface {
+	ComponentConfig
+
+	// GetDeploymentMode returns the deployment mode of the component
+	GetDeploymentMode() constants.DeploymentModeType
+
+	// GetObjectMeta returns the object metadata of the component workload. It is called before the other methods.
+	GetObjectMeta(isvc *v1beta1.InferenceService) (metav1.ObjectMeta, error)
+
+	// ReconcileDependencies reconciles the resources the component workload depends on, such as service accounts
+	ReconcileDependencies(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) error
+
+	// GetPodSpec returns the pod spec of the component, or of the leader pods of multi-node deployments
+	GetPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error)
+
+	// GetWorkerPodSpec returns the worker pod spec of multi-node deployments, or nil
+	GetWorkerPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error)
+
+	// GetWorkerSize returns the number of worker pods of multi-node deployments
+	GetWorkerSize() int
+}
+
 // PodSpecProvider defines the interface for providing pod specifications
 type PodSpecProvider interface {
 	// GetPodSpec returns the pod spec for the component
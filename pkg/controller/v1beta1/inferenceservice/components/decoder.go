@@ -24,6 +24,7 @@ import (
 
 var _ Component = &Decoder{}
 var _ ComponentConfig = &Decoder{}
+var _ ComponentConfigExtractor = &Decoder{}
 
 // Decoder reconciles resources for the decoder component
 type Decoder struct {
@@ -302,8 +303,8 @@ func (d *Decoder) reconcilePodSpec(isvc *v1beta1.InferenceService, objectMeta *m
 	}
 
 	UpdatePodSpecVolumes(&d.BaseComponentFields, isvc, podSpec, objectMeta)
-	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, podSpec, v1beta1.DecoderComponent)
 	UpdateDecoderAffinity(&d.BaseComponentFields, isvc, podSpec)
+	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, podSpec, v1beta1.DecoderComponent)
 
 	d.Log.Info("Decoder PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return podSpec, nil
@@ -337,8 +338,8 @@ func (d *Decoder) reconcileWorkerPodSpec(isvc *v1beta1.InferenceService, objectM
 		return nil, err
 	}
 	UpdatePodSpecVolumes(&d.BaseComponentFields, isvc, workerPodSpec, objectMeta)
-	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, workerPodSpec, v1beta1.DecoderComponent)
 	UpdateDecoderAffinity(&d.BaseComponentFields, isvc, workerPodSpec)
+	UpdatePodSpecNodeSelector(&d.BaseComponentFields, isvc, workerPodSpec, v1beta1.DecoderComponent)
 
 	d.Log.Info("Decoder Worker PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return workerPodSpec, nil
@@ -377,6 +378,42 @@ func (d *Decoder) setParallelismEnvVarForDecoder(container *v1.Container, worker
 	}
 }
 
+// GetDeploymentMode implements ComponentConfigExtractor interface
+func (d *Decoder) GetDeploymentMode() constants.DeploymentModeType {
+	return d.DeploymentMode
+}
+
+// GetObjectMeta implements ComponentConfigExtractor interface. It loads the fine-tuned weights first,
+// since they determine the labels and annotations of the decoder.
+func (d *Decoder) GetObjectMeta(isvc *v1beta1.InferenceService) (metav1.ObjectMeta, error) {
+	if isvc.Spec.Model != nil && len(isvc.Spec.Model.FineTunedWeights) > 0 {
+		if err := ReconcileFineTunedWeights(&d.BaseComponentFields, isvc); err != nil {
+			return metav1.ObjectMeta{}, errors.Wrap(err, "failed to reconcile fine-tuned weights")
+		}
+	}
+	return d.reconcileObjectMeta(isvc)
+}
+
+// ReconcileDependencies implements ComponentConfigExtractor interface, the decoder has no dependencies
+func (d *Decoder) ReconcileDependencies(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) error {
+	return nil
+}
+
+// GetPodSpec implements ComponentConfigExtractor interface
+func (d *Decoder) GetPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	return d.reconcilePodSpec(isvc, objectMeta)
+}
+
+// GetWorkerPodSpec implements ComponentConfigExtractor interface
+func (d *Decoder) GetWorkerPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	return d.reconcileWorkerPodSpec(isvc, objectMeta)
+}
+
+// GetWorkerSize implements ComponentConfigExtractor interface
+func (d *Decoder) GetWorkerSize() int {
+	return d.getWorkerSize()
+}
+
 // GetComponentType implements ComponentConfig interface
 func (d *Decoder) GetComponentType() v1beta1.ComponentType {
 	return v1beta1.DecoderComponent
@@ -25,6 +25,7 @@ import (
 
 var _ Component = &Engine{}
 var _ ComponentConfig = &Engine{}
+var _ ComponentConfigExtractor = &Engine{}
 
 // Engine reconciles resources for the engine component
 type Engine struct {
@@ -305,8 +306,8 @@ func (e *Engine) reconcilePodSpec(isvc *v1beta1.InferenceService, objectMeta *me
 		return nil, err
 	}
 	UpdatePodSpecVolumes(&e.BaseComponentFields, isvc, podSpec, objectMeta)
-	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, podSpec, v1beta1.EngineComponent)
 	UpdateEngineAffinity(&e.BaseComponentFields, isvc, podSpec)
+	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, podSpec, v1beta1.EngineComponent)
 
 	e.Log.Info("Engine PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return podSpec, nil
@@ -340,8 +341,8 @@ func (e *Engine) reconcileWorkerPodSpec(isvc *v1beta1.InferenceService, objectMe
 		return nil, err
 	}
 	UpdatePodSpecVolumes(&e.BaseComponentFields, isvc, workerPodSpec, objectMeta)
-	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, workerPodSpec, v1beta1.EngineComponent)
 	UpdateEngineAffinity(&e.BaseComponentFields, isvc, workerPodSpec)
+	UpdatePodSpecNodeSelector(&e.BaseComponentFields, isvc, workerPodSpec, v1beta1.EngineComponent)
 	e.Log.Info("Engine Worker PodSpec updated", "inference service", isvc.Name, "namespace", isvc.Namespace)
 	return workerPodSpec, nil
 }
@@ -372,6 +373,42 @@ func (e *Engine) setParallelismEnvVarForEngine(container *v1.Container, workerRe
 	}
 }
 
+// GetDeploymentMode implements ComponentConfigExtractor interface
+func (e *Engine) GetDeploymentMode() constants.DeploymentModeType {
+	return e.DeploymentMode
+}
+
+// GetObjectMeta implements ComponentConfigExtractor interface. It loads the fine-tuned weights first,
+// since they determine the labels and annotations of the engine.
+func (e *Engine) GetObjectMeta(isvc *v1beta1.InferenceService) (metav1.ObjectMeta, error) {
+	if isvc.Spec.Model != nil && len(isvc.Spec.Model.FineTunedWeights) > 0 {
+		if err := ReconcileFineTunedWeights(&e.BaseComponentFields, isvc); err != nil {
+			return metav1.ObjectMeta{}, errors.Wrap(err, "failed to reconcile fine-tuned weights")
+		}
+	}
+	return e.reconcileObjectMeta(isvc)
+}
+
+// ReconcileDependencies implements ComponentConfigExtractor interface, the engine has no dependencies
+func (e *Engine) ReconcileDependencies(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) error {
+	return nil
+}
+
+// GetPodSpec implements ComponentConfigExtractor interface
+func (e *Engine) GetPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	return e.reconcilePodSpec(isvc, objectMeta)
+}
+
+// GetWorkerPodSpec implements ComponentConfigExtractor interface
+func (e *Engine) GetWorkerPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	return e.reconcileWorkerPodSpec(isvc, objectMeta)
+}
+
+// GetWorkerSize implements ComponentConfigExtractor interface
+func (e *Engine) GetWorkerSize() int {
+	return e.getWorkerSize()
+}
+
 // GetComponentType implements ComponentConfig interface
 func (e *Engine) GetComponentType() v1beta1.ComponentType {
 	return v1beta1.EngineComponent
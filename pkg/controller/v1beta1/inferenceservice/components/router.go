@@ -24,6 +24,7 @@ import (
 
 var _ Component = &Router{}
 var _ ComponentConfig = &Router{}
+var _ ComponentConfigExtractor = &Router{}
 
 // Router reconciles resources for the router component
 type Router struct {
@@ -93,26 +94,16 @@ func (r *Router) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error)
 	}
 
 	// Reconcile RBAC resources (ServiceAccount, Role, RoleBinding)
-	r.rbacReconciler = rbac.NewRBACReconciler(
-		r.Client,
-		r.Scheme,
-		objectMeta,
-		v1beta1.RouterComponent,
-		isvc,
-	)
-	if err := r.rbacReconciler.Reconcile(); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile RBAC resources")
+	if err := r.ReconcileDependencies(isvc, objectMeta); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// Reconcile pod spec
-	podSpec, err := r.reconcilePodSpec(isvc, &objectMeta)
+	// Reconcile pod spec, running as the service account of the router
+	podSpec, err := r.GetPodSpec(isvc, &objectMeta)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile pod spec")
 	}
 
-	// Set the service account name in the pod spec
-	podSpec.ServiceAccountName = r.rbacReconciler.GetServiceAccountName()
-
 	// Reconcile deployment based on deployment mode
 	if result, err := r.reconcileDeployment(isvc, objectMeta, podSpec); err != nil {
 		return result, err
@@ -262,6 +253,48 @@ func (r *Router) reconcilePodSpec(isvc *v1beta1.InferenceService, objectMeta *me
 	return podSpec, nil
 }
 
+// GetDeploymentMode implements ComponentConfigExtractor interface
+func (r *Router) GetDeploymentMode() constants.DeploymentModeType {
+	return r.DeploymentMode
+}
+
+// GetObjectMeta implements ComponentConfigExtractor interface
+func (r *Router) GetObjectMeta(isvc *v1beta1.InferenceService) (metav1.ObjectMeta, error) {
+	return r.reconcileObjectMeta(isvc)
+}
+
+// ReconcileDependencies implements ComponentConfigExtractor interface
+func (r *Router) ReconcileDependencies(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) error {
+	r.rbacReconciler = rbac.NewRBACReconciler(r.Client, r.Scheme, objectMeta, v1beta1.RouterComponent, isvc)
+	if err := r.rbacReconciler.Reconcile(); err != nil {
+		return errors.Wrap(err, "failed to reconcile RBAC resources")
+	}
+	return nil
+}
+
+// GetPodSpec implements ComponentConfigExtractor interface. The pod runs as the service account of the
+// router once ReconcileDependencies created it.
+func (r *Router) GetPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	podSpec, err := r.reconcilePodSpec(isvc, objectMeta)
+	if err != nil {
+		return nil, err
+	}
+	if r.rbacReconciler != nil {
+		podSpec.ServiceAccountName = r.rbacReconciler.GetServiceAccountName()
+	}
+	return podSpec, nil
+}
+
+// GetWorkerPodSpec implements ComponentConfigExtractor interface, routers have no workers
+func (r *Router) GetWorkerPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*v1.PodSpec, error) {
+	return nil, nil
+}
+
+// GetWorkerSize implements ComponentConfigExtractor interface, routers have no workers
+func (r *Router) GetWorkerSize() int {
+	return 0
+}
+
 // GetComponentType implements ComponentConfig interface
 func (r *Router) GetComponentType() v1beta1.ComponentType {
 	return v1beta1.RouterComponent
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -39,11 +40,14 @@ import (
 	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/pdautoscaler"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/external_service"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
 	multimodelconfig "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbg"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
 	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/workload"
 	"github.com/sgl-project/ome/pkg/runtimeselector"
 	"github.com/sgl-project/ome/pkg/utils"
 )
@@ -59,6 +63,7 @@ import (
 // +kubebuilder:rbac:groups=ome.io,resources=finetunedweights;finetunedweights/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=clusterbasemodels/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ome.io,resources=clusterbasemodels;basemodels/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ome.io,resources=mcproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ome.io,resources=inferenceservices/status,verbs=get;update;patch
@@ -91,6 +96,9 @@ import (
 // +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=workloads.x-k8s.io,resources=rolebasedgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=workloads.x-k8s.io,resources=rolebasedgroups/status,verbs=get
+// +kubebuilder:rbac:groups=workloads.x-k8s.io,resources=rolebasedgroupscalingadapters,verbs=get;list;watch
 
 // InferenceServiceState describes the Readiness of the InferenceService
 type InferenceServiceState string
@@ -112,6 +120,9 @@ type InferenceServiceReconciler struct {
 	StatusManager            *status.StatusReconciler
 	RuntimeSelector          runtimeselector.Selector
 	AcceleratorClassSelector acceleratorclassselector.Selector
+	WorkloadStrategyManager  *workload.WorkloadStrategyManager
+	// PDAutoscaler scales the engine and decoder together in PD-disaggregated mode, defaults to querying Prometheus
+	PDAutoscaler *pdautoscaler.PDAutoscaler
 }
 
 func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -135,6 +146,16 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create DeployConfig")
 	}
 
+	fallbackConfig, err := controllerconfig.NewSchedulingFallbackConfig(r.Clientset)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create SchedulingFallbackConfig")
+	}
+
+	parallelismConfig, err := controllerconfig.NewAutoParallelismConfig(r.Clientset)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to create AutoParallelismConfig")
+	}
+
 	// For backward compatibility with predictor-based architecture
 	deploymentMode := isvcutils.GetDeploymentMode(annotations, deployConfig)
 	r.Log.Info("Inference service deployment mode ", "namespace", isvc.Namespace, "inference service", isvc.Name, "deployment mode", deploymentMode)
@@ -156,6 +177,10 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	} else {
 		// The object is being deleted
 		if controllerutil.ContainsFinalizer(isvc, finalizerName) {
+			// release the model pre-warm requests, so the model agent evicts the weights pulled for it
+			if err := r.releaseModelPrewarm(ctx, isvc, nil); err != nil {
+				return ctrl.Result{}, err
+			}
 			// remove our finalizer from the list and update it.
 			controllerutil.RemoveFinalizer(isvc, finalizerName)
 			if err := r.Update(context.Background(), isvc); err != nil {
@@ -225,6 +250,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	// Step 2: Get runtime spec (either specified or auto-selected based on model)
 	var rt *v1beta1.ServingRuntimeSpec
 	var rtName string
+	var choice *schedulingChoice
 	userSpecifiedRuntime := false
 
 	if isvc.Spec.Runtime != nil && isvc.Spec.Runtime.Name != "" {
@@ -239,25 +265,50 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 
 		// Get the runtime spec using selector
-		rtSpec, _, err := r.RuntimeSelector.GetRuntime(ctx, rtName, isvc.Namespace)
+		rtSpec, isCluster, err := r.RuntimeSelector.GetRuntime(ctx, rtName, isvc.Namespace)
 		if err != nil {
 			r.Log.Error(err, "Failed to get runtime spec", "runtime", rtName)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "RuntimeFetchError", err.Error())
 			return reconcile.Result{}, err
 		}
 		rt = rtSpec
+		r.setUserSpecifiedRuntimeStatus(isvc, rtName, isCluster)
+
+		// Only the accelerator class can change for a user-specified runtime
+		choice, err = r.chooseSchedulableRuntime(ctx, isvc, []runtimeselector.RuntimeMatch{{
+			RuntimeSelection: runtimeselector.RuntimeSelection{Name: rtName, Spec: rtSpec, IsCluster: isCluster},
+		}}, fallbackConfig)
+		if err != nil {
+			r.Log.Error(err, "Failed to apply scheduling fallback", "runtime", rtName)
+			return reconcile.Result{}, err
+		}
 	} else {
-		// Auto-select runtime
-		selection, err := r.RuntimeSelector.SelectRuntime(ctx, baseModel, isvc)
+		// Auto-select runtime and record why it was chosen
+		explanation, err := r.RuntimeSelector.ExplainSelection(ctx, baseModel, isvc)
 		if err != nil {
 			r.Log.Error(err, "Failed to auto-select runtime", "model", isvc.Spec.Model.Name)
 			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "RuntimeSelectionError",
 				"Failed to find runtime for model %s: %v", isvc.Spec.Model.Name, err)
+			if explanation != nil {
+				r.setRuntimeSelectionStatus(isvc, explanation)
+				if statusErr := r.updateStatus(isvc, deploymentMode); statusErr != nil {
+					r.Log.Error(statusErr, "Failed to record runtime selection status", "inferenceService", isvc.Name)
+				}
+			}
+			return reconcile.Result{}, err
+		}
+
+		// Skip runtime and accelerator combinations that previously failed to schedule
+		choice, err = r.chooseSchedulableRuntime(ctx, isvc, explanation.Candidates, fallbackConfig)
+		if err != nil {
+			r.Log.Error(err, "Failed to apply scheduling fallback", "model", isvc.Spec.Model.Name)
 			return reconcile.Result{}, err
 		}
-		rt = selection.Spec
-		rtName = selection.Name
-		r.Log.Info("Auto-selected runtime", "runtime", rtName, "model", isvc.Spec.Model.Name)
+		explanation.SelectFallback(choice.runtimeIndex, choice.skipReasons)
+		r.setRuntimeSelectionStatus(isvc, explanation)
+		rt = explanation.Selected.Spec
+		rtName = explanation.Selected.Name
+		r.Log.Info("Auto-selected runtime", "runtime", rtName, "model", isvc.Spec.Model.Name, "reason", explanation.WinReason)
 	}
 
 	// Step 3: Merge rt and isvc specs to get final engine, decoder, and router specs
@@ -268,6 +319,85 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return reconcile.Result{}, err
 	}
 
+	// Select accelerator classes before determining deployment modes, since a model that does not fit
+	// on a single node sizes the worker group of a multi-node component.
+	// Accelerator classes abandoned by the scheduling fallback are excluded from policy-based selection
+	acceleratorTarget := withExcludedAcceleratorClasses(isvc, choice.excludedClasses)
+	var selectedAcName string
+	var engineAC, decoderAC *v1beta1.AcceleratorClassSpec
+	var engineACObj, decoderACObj *v1beta1.AcceleratorClass
+	var engineAcName, decoderAcName string
+	var engineSupportedModelFormats, decoderSupportedModelFormats *v1beta1.SupportedModelFormat
+	if mergedEngine != nil {
+		var acName string
+		engineACObj, acName, err = r.AcceleratorClassSelector.GetAcceleratorClass(ctx, acceleratorTarget, rt, v1beta1.EngineComponent)
+		if err != nil {
+			r.Log.Error(err, "Failed to get accelerator class for engine component", "Name", isvc.Name)
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for engine: %v", err)
+			return reconcile.Result{}, err
+		}
+		engineAcName = acName
+		selectedAcName = engineAcName
+		if engineACObj == nil {
+			r.Log.Info("Accelerator class not specified for engine component", "inferenceService", isvc.Name)
+		} else {
+			engineAC = &engineACObj.Spec
+		}
+		engineSupportedModelFormats = r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
+		if plan := r.planParallelism(isvc, v1beta1.EngineComponent, baseModel, engineSupportedModelFormats, engineACObj, engineAcName, parallelismConfig,
+			engineTemplate(mergedEngine)); plan != nil {
+			engineSupportedModelFormats, engineAC = isvcutils.ApplyParallelismPlan(plan, engineSupportedModelFormats, engineAC, engineAcName)
+			applyMultiNodePlan(mergedEngine.Worker, plan)
+		}
+	}
+
+	if mergedDecoder != nil {
+		var acName string
+		decoderACObj, acName, err = r.AcceleratorClassSelector.GetAcceleratorClass(ctx, acceleratorTarget, rt, v1beta1.DecoderComponent)
+		if err != nil {
+			r.Log.Error(err, "Failed to get accelerator class for decoder component", "Name", isvc.Name)
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for decoder: %v", err)
+			return reconcile.Result{}, err
+		}
+		decoderAcName = acName
+		if decoderACObj == nil {
+			r.Log.Info("Accelerator class not specified for decoder component", "inferenceService", isvc.Name)
+		} else {
+			decoderAC = &decoderACObj.Spec
+		}
+		decoderSupportedModelFormats = r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
+		if plan := r.planParallelism(isvc, v1beta1.DecoderComponent, baseModel, decoderSupportedModelFormats, decoderACObj, decoderAcName, parallelismConfig,
+			decoderTemplate(mergedDecoder)); plan != nil {
+			decoderSupportedModelFormats, decoderAC = isvcutils.ApplyParallelismPlan(plan, decoderSupportedModelFormats, decoderAC, decoderAcName)
+			applyMultiNodePlan(mergedDecoder.Worker, plan)
+		}
+	}
+
+	// Point the model at its MCP tools through the gateway route
+	mcpGatewayURL, err := r.reconcileMCPRoute(ctx, isvc)
+	if err != nil {
+		r.Log.Error(err, "Failed to reconcile MCP route", "Name", isvc.Name)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "MCPRouteError", err.Error())
+		return reconcile.Result{}, err
+	}
+	injectMCPGatewayURL(mcpGatewayURL, mergedEngine, mergedDecoder, mergedRouter)
+
+	// Keep the pods on their model revision until the target revision is ready on enough nodes
+	modelRevision, err := r.reconcileModelRevision(isvc, baseModel, mergedEngine, mergedDecoder)
+	if err != nil {
+		r.Log.Error(err, "Failed to reconcile model revision", "Name", isvc.Name)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "ModelRevisionError", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	// Pull the model weights onto nodes of the accelerator classes ahead of scale-out
+	prewarmTargets := modelPrewarmTargets(isvc, mergedEngine, engineACObj, mergedDecoder, decoderACObj)
+	if err := r.reconcileModelPrewarm(ctx, isvc, prewarmTargets); err != nil {
+		r.Log.Error(err, "Failed to reconcile model pre-warm", "Name", isvc.Name)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "ModelPrewarmError", err.Error())
+		return reconcile.Result{}, err
+	}
+
 	// Step 4: Determine deployment modes based on merged specs
 	engineDeploymentMode, decoderDeploymentMode, routerDeploymentMode, err := isvcutils.DetermineDeploymentModes(mergedEngine, mergedDecoder, mergedRouter, rt)
 	if err != nil {
@@ -280,22 +410,10 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if mergedEngine != nil && mergedDecoder != nil {
 		r.Log.Info("PD-disaggregated deployment detected", "namespace", isvc.Namespace, "inferenceService", isvc.Name)
 	}
+	r.reconcilePDAutoscaling(ctx, isvc, mergedEngine, mergedDecoder, engineDeploymentMode, decoderDeploymentMode)
 
 	// Step 5: Create reconcilers based on merged specs
 	if mergedEngine != nil {
-		engineACObj, engineAcName, err := r.AcceleratorClassSelector.GetAcceleratorClass(ctx, isvc, rt, v1beta1.EngineComponent)
-		if err != nil {
-			r.Log.Error(err, "Failed to get accelerator class for engine component", "Name", isvc.Name)
-			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for engine: %v", err)
-			return reconcile.Result{}, err
-		}
-		var engineAC *v1beta1.AcceleratorClassSpec
-		if engineACObj == nil {
-			r.Log.Info("Accelerator class not specified for engine component", "inferenceService", isvc.Name)
-		} else {
-			engineAC = &engineACObj.Spec
-		}
-		engineSupportedModelFormats := r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
 		r.Log.Info("Creating engine reconciler",
 			"deploymentMode", engineDeploymentMode,
 			"namespace", isvc.Namespace,
@@ -317,19 +435,6 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	if mergedDecoder != nil {
-		decoderACObj, decoderAcName, err := r.AcceleratorClassSelector.GetAcceleratorClass(ctx, isvc, rt, v1beta1.DecoderComponent)
-		if err != nil {
-			r.Log.Error(err, "Failed to get accelerator class for decoder component", "Name", isvc.Name)
-			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "AcceleratorClassError", "Failed to get accelerator class for decoder: %v", err)
-			return reconcile.Result{}, err
-		}
-		var decoderAC *v1beta1.AcceleratorClassSpec
-		if decoderACObj == nil {
-			r.Log.Info("Accelerator class not specified for decoder component", "inferenceService", isvc.Name)
-		} else {
-			decoderAC = &decoderACObj.Spec
-		}
-		decoderSupportedModelFormats := r.RuntimeSelector.GetSupportedModelFormat(ctx, rt, baseModel, userSpecifiedRuntime)
 		r.Log.Info("Creating decoder reconciler",
 			"deploymentMode", decoderDeploymentMode,
 			"namespace", isvc.Namespace,
@@ -383,19 +488,37 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		"namespace", isvc.Namespace,
 		"inferenceService", isvc.Name)
 
-	// Step 6: Run all reconcilers
-	for _, reconciler := range reconcilers {
-		result, err := reconciler.Reconcile(isvc)
-		if err != nil {
-			r.Log.Error(err, "Failed to reconcile component",
-				"component", fmt.Sprintf("%T", reconciler),
-				"namespace", isvc.Namespace,
-				"inferenceService", isvc.Name)
-			return result, err
-		}
-		if result.Requeue || result.RequeueAfter > 0 {
-			return result, nil
-		}
+	// Step 6: Deploy the components with the workload strategy selected for the inference service
+	deploymentModes := &workload.ComponentDeploymentModes{}
+	if mergedEngine != nil {
+		deploymentModes.Engine = engineDeploymentMode
+	}
+	if mergedDecoder != nil {
+		deploymentModes.Decoder = decoderDeploymentMode
+	}
+	if mergedRouter != nil {
+		deploymentModes.Router = routerDeploymentMode
+	}
+	strategy, err := r.workloadStrategyManager().SelectStrategy(isvc, annotations, deploymentModes)
+	if err != nil {
+		r.Log.Error(err, "Failed to select workload strategy", "namespace", isvc.Namespace, "inferenceService", isvc.Name)
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "WorkloadStrategyError", err.Error())
+		return reconcile.Result{}, err
+	}
+	result, err = strategy.ReconcileWorkload(ctx, &workload.WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components:       reconcilers,
+		DeploymentModes:  deploymentModes,
+	})
+	if err != nil {
+		r.Log.Error(err, "Failed to reconcile workload",
+			"strategy", strategy.GetStrategyName(),
+			"namespace", isvc.Namespace,
+			"inferenceService", isvc.Name)
+		return result, err
+	}
+	if result.Requeue || result.RequeueAfter > 0 {
+		return result, nil
 	}
 
 	// Now reconcile ingress and external service after components have created their services
@@ -494,12 +617,20 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		r.StatusManager.PropagateCrossComponentStatus(&isvc.Status, componentList, v1beta1.LatestDeploymentReady)
 	}
 
+	r.setModelRevisionStatus(isvc, modelRevision)
+
+	// Fall back to the next runtime or accelerator class if pods cannot be scheduled
+	fallbackResult, err := r.checkSchedulingFallback(ctx, isvc, rtName, selectedAcName, fallbackConfig)
+	if err != nil {
+		r.Log.Error(err, "Failed to check pod scheduling", "namespace", isvc.Namespace, "inferenceService", isvc.Name)
+	}
+
 	if err = r.updateStatus(isvc, deploymentMode); err != nil {
 		r.Recorder.Event(isvc, v1.EventTypeWarning, "InternalError", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return requeueForPDAutoscaling(isvc, requeueForModelRevision(modelRevision, requeueForCanaryAnalysis(isvc, fallbackResult))), nil
 }
 
 func (r *InferenceServiceReconciler) handleVirtualDeployment(isvc *v1beta1.InferenceService) (ctrl.Result, error) {
@@ -574,6 +705,7 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1.Infere
 		return errors.Wrapf(err, "fails to update InferenceService status")
 	} else {
 		// If there was a difference and there was no error.
+		r.recordCanaryAnalysisEvents(existingService, desiredService)
 		isReady := inferenceServiceReadiness(desiredService.Status)
 		if wasReady && !isReady { // Moved to NotReady State
 			r.Recorder.Eventf(desiredService, v1.EventTypeWarning, string(InferenceServiceNotReadyState),
@@ -615,14 +747,26 @@ func (r *InferenceServiceReconciler) ensureIngressDisableAnnotation(isvc *v1beta
 	return nil
 }
 
+// workloadStrategyManager returns the workload strategy manager, creating one with the built-in strategies
+// unless one was provided
+func (r *InferenceServiceReconciler) workloadStrategyManager() *workload.WorkloadStrategyManager {
+	if r.WorkloadStrategyManager == nil {
+		r.WorkloadStrategyManager = workload.NewWorkloadStrategyManager(workload.NewSingleComponentStrategy(r.Client, r.Log), r.Log)
+		r.WorkloadStrategyManager.RegisterStrategy(workload.NewRBGStrategy(r.Client, r.Clientset, r.Scheme, r.StatusManager, r.Log))
+	}
+	return r.WorkloadStrategyManager
+}
+
 func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployConfig *controllerconfig.DeployConfig, ingressConfig *controllerconfig.IngressConfig) error {
 	r.ClientConfig = mgr.GetConfig()
 
 	// NEW: Initialize StatusReconciler
 	r.StatusManager = status.NewStatusReconciler()
 
-	// Initialize RuntimeSelector
-	r.RuntimeSelector = runtimeselector.New(mgr.GetClient())
+	// Initialize RuntimeSelector unless one with scoring policies was provided
+	if r.RuntimeSelector == nil {
+		r.RuntimeSelector = runtimeselector.New(mgr.GetClient())
+	}
 
 	// Initialize AcceleratorClassSelector
 	r.AcceleratorClassSelector = acceleratorclassselector.New(mgr.GetClient())
@@ -652,6 +796,11 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		return err
 	}
 
+	rbgFound, err := utils.IsCrdAvailable(r.ClientConfig, rbg.GroupVersion.String(), constants.RoleBasedGroupKind)
+	if err != nil {
+		return err
+	}
+
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.InferenceService{}).
 		Owns(&appsv1.Deployment{}).
@@ -660,7 +809,8 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		Owns(&v1.PersistentVolume{}).
 		Owns(&v1.PersistentVolumeClaim{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&policyv1.PodDisruptionBudget{})
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&v1beta1.MCPRoute{})
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -680,6 +830,14 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch keda.sh/v1/ScaledObject resources because the CRD is not available.")
 	}
 
+	if rbgFound {
+		group := &unstructured.Unstructured{}
+		group.SetGroupVersionKind(rbg.GroupVersionKind)
+		ctrlBuilder = ctrlBuilder.Owns(group)
+	} else {
+		r.Log.Info("The InferenceService controller won't watch workloads.x-k8s.io/v1alpha1/RoleBasedGroup resources because the CRD is not available.")
+	}
+
 	if lwsFound {
 		ctrlBuilder = ctrlBuilder.Owns(&lws.LeaderWorkerSet{})
 	} else {
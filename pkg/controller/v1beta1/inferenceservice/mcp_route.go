@@ -0,0 +1,152 @@
+package inferenceservice
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1beta1 "github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/mcp/gateway"
+)
+
+// mcpRouteName returns the name of the MCPRoute the InferenceService uses, or "" when it uses no MCP tools
+func mcpRouteName(isvc *v1beta1.InferenceService) string {
+	if isvc.Spec.MCPRoute != nil && isvc.Spec.MCPRoute.RouteRef != nil {
+		return isvc.Spec.MCPRoute.RouteRef.Name
+	}
+	if len(isvc.Spec.MCPServers) > 0 {
+		return isvc.Name + constants.MCPRouteNameSuffix
+	}
+	return ""
+}
+
+// reconcileMCPRoute creates the MCPRoute for the MCPServers of an InferenceService, or checks the referenced
+// MCPRoute exists, and returns the gateway URL of the route. Routes the InferenceService no longer needs are deleted.
+func (r *InferenceServiceReconciler) reconcileMCPRoute(ctx context.Context, isvc *v1beta1.InferenceService) (string, error) {
+	routeName := mcpRouteName(isvc)
+	if err := r.deleteStaleMCPRoute(ctx, isvc, routeName); err != nil {
+		return "", err
+	}
+	if routeName == "" {
+		return "", nil
+	}
+
+	if isvc.Spec.MCPRoute != nil && isvc.Spec.MCPRoute.RouteRef != nil {
+		route := &v1beta1.MCPRoute{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: routeName}, route); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", err
+			}
+			// The route may be created later, the gateway URL does not depend on it
+			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "MCPRouteNotFound", "MCPRoute %s not found", routeName)
+		}
+	} else {
+		route := &v1beta1.MCPRoute{ObjectMeta: metav1.ObjectMeta{Name: routeName, Namespace: isvc.Namespace}}
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+			route.Spec = mcpRouteSpec(isvc)
+			return controllerutil.SetControllerReference(isvc, route, r.Scheme)
+		})
+		if err != nil {
+			return "", err
+		}
+		if op != controllerutil.OperationResultNone {
+			r.Log.Info("Reconciled MCPRoute", "namespace", isvc.Namespace, "route", routeName, "operation", op)
+		}
+	}
+
+	mcpConfig, err := controllerconfig.NewMCPConfig(r.Clientset)
+	if err != nil {
+		return "", err
+	}
+	return gateway.GatewayURL(isvc.Namespace, routeName, mcpConfig.GatewayPort), nil
+}
+
+// deleteStaleMCPRoute deletes the MCPRoute created for the InferenceService once it uses another route or none
+func (r *InferenceServiceReconciler) deleteStaleMCPRoute(ctx context.Context, isvc *v1beta1.InferenceService, routeName string) error {
+	ownedName := isvc.Name + constants.MCPRouteNameSuffix
+	if routeName == ownedName && (isvc.Spec.MCPRoute == nil || isvc.Spec.MCPRoute.RouteRef == nil) {
+		return nil
+	}
+	route := &v1beta1.MCPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: isvc.Namespace, Name: ownedName}, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(route, isvc) {
+		return nil
+	}
+	r.Log.Info("Deleting MCPRoute no longer used by InferenceService", "namespace", isvc.Namespace, "route", ownedName)
+	if err := r.Delete(ctx, route); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// mcpRouteSpec builds the spec of the MCPRoute created for the MCPServers of an InferenceService
+func mcpRouteSpec(isvc *v1beta1.InferenceService) v1beta1.MCPRouteSpec {
+	spec := v1beta1.MCPRouteSpec{}
+	for _, server := range isvc.Spec.MCPServers {
+		spec.BackendRefs = append(spec.BackendRefs, v1beta1.MCPBackendRef{
+			ServerRef: server.ServerRef,
+			Weight:    server.Weight,
+		})
+	}
+	if config := isvc.Spec.MCPRoute; config != nil {
+		spec.Matches = config.Matches
+		spec.MCPRoutePolicy = config.MCPRoutePolicy
+	}
+	return spec
+}
+
+// injectMCPGatewayURL sets MCP_GATEWAY_URL on the runner containers of the engine, decoder and router
+func injectMCPGatewayURL(url string, engine *v1beta1.EngineSpec, decoder *v1beta1.DecoderSpec, router *v1beta1.RouterSpec) {
+	if url == "" {
+		return
+	}
+	var runners []*v1beta1.RunnerSpec
+	if engine != nil {
+		runners = append(runners, engine.Runner)
+		if engine.Leader != nil {
+			runners = append(runners, engine.Leader.Runner)
+		}
+		if engine.Worker != nil {
+			runners = append(runners, engine.Worker.Runner)
+		}
+	}
+	if decoder != nil {
+		runners = append(runners, decoder.Runner)
+		if decoder.Leader != nil {
+			runners = append(runners, decoder.Leader.Runner)
+		}
+		if decoder.Worker != nil {
+			runners = append(runners, decoder.Worker.Runner)
+		}
+	}
+	if router != nil {
+		runners = append(runners, router.Runner)
+	}
+	for _, runner := range runners {
+		if runner != nil {
+			setEnvVar(&runner.Container, constants.MCPGatewayURLEnvVarKey, url)
+		}
+	}
+}
+
+func setEnvVar(container *v1.Container, name, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i].Value = value
+			container.Env[i].ValueFrom = nil
+			return
+		}
+	}
+	container.Env = append(container.Env, v1.EnvVar{Name: name, Value: value})
+}
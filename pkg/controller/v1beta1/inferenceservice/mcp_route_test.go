@@ -0,0 +1,130 @@
+package inferenceservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestReconcileMCPRoute(t *testing.T) {
+	servers := []v1beta1.MCPServerReference{
+		{ServerRef: corev1.LocalObjectReference{Name: "search"}, Weight: ptr.To(int32(3))},
+		{ServerRef: corev1.LocalObjectReference{Name: "database"}},
+	}
+	policy := v1beta1.MCPRoutePolicy{RateLimit: &v1beta1.MCPRateLimit{}}
+
+	tests := []struct {
+		name        string
+		mcpServers  []v1beta1.MCPServerReference
+		mcpRoute    *v1beta1.MCPRouteConfig
+		existing    bool
+		expectURL   string
+		expectRoute bool
+	}{
+		{
+			name:      "no MCP tools",
+			expectURL: "",
+		},
+		{
+			name:        "route created for MCP servers",
+			mcpServers:  servers,
+			mcpRoute:    &v1beta1.MCPRouteConfig{MCPRoutePolicy: policy},
+			expectURL:   "http://mcp-gateway.default.svc.cluster.local:8080/routes/default/test-isvc-route",
+			expectRoute: true,
+		},
+		{
+			name:      "referenced route replaces the created route",
+			mcpRoute:  &v1beta1.MCPRouteConfig{RouteRef: &corev1.LocalObjectReference{Name: "shared"}},
+			existing:  true,
+			expectURL: "http://mcp-gateway.default.svc.cluster.local:8080/routes/default/shared",
+		},
+		{
+			name:     "created route removed with MCP servers",
+			existing: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default", UID: "isvc-uid"},
+				Spec: v1beta1.InferenceServiceSpec{
+					MCPServers: tt.mcpServers,
+					MCPRoute:   tt.mcpRoute,
+				},
+			}
+			builder := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(isvc)
+			if tt.existing {
+				owned := &v1beta1.MCPRoute{ObjectMeta: metav1.ObjectMeta{Name: "test-isvc-route", Namespace: "default"}}
+				require.NoError(t, ctrl.SetControllerReference(isvc, owned, scheme))
+				builder = builder.WithObjects(owned)
+			}
+			r := &InferenceServiceReconciler{
+				Client: builder.Build(),
+				Clientset: fake.NewSimpleClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+					Data:       map[string]string{"mcp": `{"gatewayImage": "ome/mcp-gateway:v1"}`},
+				}),
+				Log:      ctrl.Log.WithName("test"),
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			url, err := r.reconcileMCPRoute(ctx, isvc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectURL, url)
+
+			route := &v1beta1.MCPRoute{}
+			err = r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-isvc-route"}, route)
+			if !tt.expectRoute {
+				assert.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, metav1.IsControlledBy(route, isvc))
+			assert.Equal(t, []v1beta1.MCPBackendRef{
+				{ServerRef: corev1.LocalObjectReference{Name: "search"}, Weight: ptr.To(int32(3))},
+				{ServerRef: corev1.LocalObjectReference{Name: "database"}},
+			}, route.Spec.BackendRefs)
+			assert.Equal(t, policy, route.Spec.MCPRoutePolicy)
+		})
+	}
+}
+
+func TestInjectMCPGatewayURL(t *testing.T) {
+	engine := &v1beta1.EngineSpec{
+		Runner: &v1beta1.RunnerSpec{Container: corev1.Container{
+			Env: []corev1.EnvVar{{Name: constants.MCPGatewayURLEnvVarKey, Value: "stale"}},
+		}},
+		Leader: &v1beta1.LeaderSpec{Runner: &v1beta1.RunnerSpec{}},
+		Worker: &v1beta1.WorkerSpec{},
+	}
+	router := &v1beta1.RouterSpec{Runner: &v1beta1.RunnerSpec{}}
+
+	injectMCPGatewayURL("http://gateway/routes/default/tools", engine, nil, router)
+
+	expected := []corev1.EnvVar{{Name: constants.MCPGatewayURLEnvVarKey, Value: "http://gateway/routes/default/tools"}}
+	assert.Equal(t, expected, engine.Runner.Env)
+	assert.Equal(t, expected, engine.Leader.Runner.Env)
+	assert.Nil(t, engine.Worker.Runner)
+	assert.Equal(t, expected, router.Runner.Env)
+}
@@ -0,0 +1,267 @@
+package inferenceservice
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// ModelPrewarmRequestedReason is used when the model agent is asked to pull the model weights onto more nodes.
+const ModelPrewarmRequestedReason = "ModelPrewarmRequested"
+
+// modelPrewarmTarget is an accelerator class the model serving pods are scheduled on, and the number of its nodes
+// the model weights are kept ready on
+type modelPrewarmTarget struct {
+	acceleratorClass *v1beta1.AcceleratorClass
+	nodes            int
+}
+
+// modelPrewarmTargets returns the accelerator classes of the engine and decoder with the number of nodes to pre-warm
+// in each, which defaults to the nodes running the maximum replicas of the components scheduled on the class
+func modelPrewarmTargets(isvc *v1beta1.InferenceService, engine *v1beta1.EngineSpec, engineAC *v1beta1.AcceleratorClass,
+	decoder *v1beta1.DecoderSpec, decoderAC *v1beta1.AcceleratorClass) []modelPrewarmTarget {
+	placement := isvc.Spec.ModelPlacement
+	if placement == nil {
+		return nil
+	}
+
+	var targets []modelPrewarmTarget
+	addTarget := func(ac *v1beta1.AcceleratorClass, component *v1beta1.ComponentExtensionSpec, worker *v1beta1.WorkerSpec) {
+		if ac == nil {
+			return
+		}
+		nodes := componentModelNodes(max(component.MaxReplicas, ptr.Deref(component.MinReplicas, 1)), worker)
+		if placement.PrewarmNodes != nil {
+			nodes = int(*placement.PrewarmNodes)
+		}
+		for i := range targets {
+			if targets[i].acceleratorClass.Name == ac.Name {
+				if placement.PrewarmNodes == nil {
+					targets[i].nodes += nodes
+				}
+				return
+			}
+		}
+		targets = append(targets, modelPrewarmTarget{acceleratorClass: ac, nodes: nodes})
+	}
+	if engine != nil {
+		addTarget(engineAC, &engine.ComponentExtensionSpec, engine.Worker)
+	}
+	if decoder != nil {
+		addTarget(decoderAC, &decoder.ComponentExtensionSpec, decoder.Worker)
+	}
+	return targets
+}
+
+// reconcileModelPrewarm asks the model agent to pull the model weights onto nodes of the accelerator classes until
+// they are ready on enough nodes of each class. Each inference service requests nodes through its own annotation on
+// the BaseModel or ClusterBaseModel, which is pruned when it needs fewer nodes and removed from the models it does
+// not pre-warm anymore. Nodes the model failed on are dropped from the request.
+func (r *InferenceServiceReconciler) reconcileModelPrewarm(ctx context.Context, isvc *v1beta1.InferenceService, targets []modelPrewarmTarget) error {
+	if len(targets) == 0 || isvc.Spec.Model == nil {
+		return r.releaseModelPrewarm(ctx, isvc, nil)
+	}
+	model, status, err := r.getBaseModelObject(ctx, isvc.Spec.Model.Name, isvc.Namespace)
+	if err != nil {
+		return err
+	}
+
+	key := modelPrewarmAnnotationKey(isvc)
+	current := model.GetAnnotations()[key]
+	requested := planModelPrewarm(parseModelPrewarmNodes(current), status, targets)
+	value := strings.Join(requested, ",")
+	if value != current {
+		if err := r.patchModelPrewarm(ctx, model, key, value); err != nil {
+			return err
+		}
+		if value != "" {
+			r.Recorder.Eventf(isvc, v1.EventTypeNormal, ModelPrewarmRequestedReason,
+				"Requested model %s to be pulled onto nodes %s", isvc.Spec.Model.Name, value)
+		}
+	}
+	return r.releaseModelPrewarm(ctx, isvc, model)
+}
+
+// releaseModelPrewarm removes the pre-warm request of an inference service from every BaseModel and ClusterBaseModel
+// it could have requested, except the model it pre-warms, so the model agent evicts the weights it no longer needs
+func (r *InferenceServiceReconciler) releaseModelPrewarm(ctx context.Context, isvc *v1beta1.InferenceService, keep client.Object) error {
+	key := modelPrewarmAnnotationKey(isvc)
+
+	var models []client.Object
+	baseModels := &v1beta1.BaseModelList{}
+	if err := r.List(ctx, baseModels, client.InNamespace(isvc.Namespace)); err != nil {
+		return errors.Wrapf(err, "fails to list BaseModels to release model pre-warm")
+	}
+	for i := range baseModels.Items {
+		models = append(models, &baseModels.Items[i])
+	}
+	clusterBaseModels := &v1beta1.ClusterBaseModelList{}
+	if err := r.List(ctx, clusterBaseModels); err != nil {
+		return errors.Wrapf(err, "fails to list ClusterBaseModels to release model pre-warm")
+	}
+	for i := range clusterBaseModels.Items {
+		models = append(models, &clusterBaseModels.Items[i])
+	}
+
+	for _, model := range models {
+		if _, ok := model.GetAnnotations()[key]; !ok || isSameModel(model, keep) {
+			continue
+		}
+		if err := r.patchModelPrewarm(ctx, model, key, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchModelPrewarm sets the nodes of a pre-warm annotation of a model, removing the annotation when there are none
+func (r *InferenceServiceReconciler) patchModelPrewarm(ctx context.Context, model client.Object, key, value string) error {
+	patch := client.MergeFrom(model.DeepCopyObject().(client.Object))
+	annotations := model.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if value == "" {
+		delete(annotations, key)
+	} else {
+		annotations[key] = value
+	}
+	model.SetAnnotations(annotations)
+	if err := r.Patch(ctx, model, patch); err != nil {
+		return errors.Wrapf(err, "fails to request model pre-warm on nodes %q", value)
+	}
+	return nil
+}
+
+// modelPrewarmAnnotationKey returns the annotation an inference service requests model pre-warm nodes with
+func modelPrewarmAnnotationKey(isvc *v1beta1.InferenceService) string {
+	return constants.ModelPrewarmNodesAnnotationPrefix + string(isvc.UID)
+}
+
+// isSameModel reports whether two models are the same BaseModel or ClusterBaseModel
+func isSameModel(a, b client.Object) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	_, aCluster := a.(*v1beta1.ClusterBaseModel)
+	_, bCluster := b.(*v1beta1.ClusterBaseModel)
+	return aCluster == bCluster && client.ObjectKeyFromObject(a) == client.ObjectKeyFromObject(b)
+}
+
+// getBaseModelObject gets the BaseModel or ClusterBaseModel by name, looked up the same way as GetBaseModel
+func (r *InferenceServiceReconciler) getBaseModelObject(ctx context.Context, name string, namespace string) (client.Object, *v1beta1.ModelStatusSpec, error) {
+	baseModel := &v1beta1.BaseModel{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, baseModel)
+	if err == nil {
+		return baseModel, &baseModel.Status, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	clusterBaseModel := &v1beta1.ClusterBaseModel{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, clusterBaseModel); err != nil {
+		return nil, nil, err
+	}
+	return clusterBaseModel, &clusterBaseModel.Status, nil
+}
+
+// planModelPrewarm returns the sorted nodes an inference service requests the model on: for each accelerator class,
+// enough of its nodes to reach the target, preferring nodes the model is ready on, then nodes already requested, then
+// the nodes with the most available accelerators. Ready nodes are requested too, so they are kept when the request
+// that pulled the model onto them goes away. Nodes the model failed on are never requested.
+func planModelPrewarm(requested []string, status *v1beta1.ModelStatusSpec, targets []modelPrewarmTarget) []string {
+	failed := toSet(status.NodesFailed)
+	ready := toSet(status.NodesReady)
+	current := toSet(requested)
+
+	// tier orders the nodes to request: ready and requested, ready, requested, then the others
+	tier := func(node string) int {
+		_, isReady := ready[node]
+		_, isRequested := current[node]
+		switch {
+		case isReady && isRequested:
+			return 0
+		case isReady:
+			return 1
+		case isRequested:
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	nodes := map[string]struct{}{}
+	for _, target := range targets {
+		var candidates []v1beta1.AcceleratorNodeCapacity
+		for _, capacity := range acceleratorClassNodes(target.acceleratorClass) {
+			if _, isFailed := failed[capacity.Name]; !isFailed {
+				candidates = append(candidates, capacity)
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if ti, tj := tier(candidates[i].Name), tier(candidates[j].Name); ti != tj {
+				return ti < tj
+			}
+			if candidates[i].Available != candidates[j].Available {
+				return candidates[i].Available > candidates[j].Available
+			}
+			return candidates[i].Name < candidates[j].Name
+		})
+		for i := 0; i < len(candidates) && i < target.nodes; i++ {
+			nodes[candidates[i].Name] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(nodes))
+	for node := range nodes {
+		result = append(result, node)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// acceleratorClassNodes returns the capacity of every node of the accelerator class, including nodes without a
+// capacity breakdown
+func acceleratorClassNodes(ac *v1beta1.AcceleratorClass) []v1beta1.AcceleratorNodeCapacity {
+	nodes := make([]v1beta1.AcceleratorNodeCapacity, 0, len(ac.Status.Nodes))
+	seen := map[string]struct{}{}
+	for _, capacity := range ac.Status.NodeCapacity {
+		nodes = append(nodes, capacity)
+		seen[capacity.Name] = struct{}{}
+	}
+	for _, node := range ac.Status.Nodes {
+		if _, ok := seen[node]; !ok {
+			nodes = append(nodes, v1beta1.AcceleratorNodeCapacity{Name: node})
+		}
+	}
+	return nodes
+}
+
+// parseModelPrewarmNodes returns the nodes listed in a pre-warm annotation
+func parseModelPrewarmNodes(value string) []string {
+	var nodes []string
+	for _, node := range strings.Split(value, ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,219 @@
+package inferenceservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func prewarmTestAcceleratorClass(name string, nodes ...v1beta1.AcceleratorNodeCapacity) *v1beta1.AcceleratorClass {
+	ac := &v1beta1.AcceleratorClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, node := range nodes {
+		ac.Status.Nodes = append(ac.Status.Nodes, node.Name)
+	}
+	ac.Status.NodeCapacity = nodes
+	return ac
+}
+
+func TestModelPrewarmTargets(t *testing.T) {
+	h100 := prewarmTestAcceleratorClass("h100")
+	a100 := prewarmTestAcceleratorClass("a100")
+	two := 2
+	engine := &v1beta1.EngineSpec{ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two, MaxReplicas: 4}}
+	decoder := &v1beta1.DecoderSpec{
+		ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two},
+		Worker:                 &v1beta1.WorkerSpec{Size: &two},
+	}
+
+	tests := []struct {
+		name      string
+		placement *v1beta1.ModelPlacementSpec
+		engineAC  *v1beta1.AcceleratorClass
+		decoderAC *v1beta1.AcceleratorClass
+		expected  []modelPrewarmTarget
+	}{
+		{
+			name:     "no model placement",
+			engineAC: h100,
+		},
+		{
+			name:      "nodes for the maximum replicas of each accelerator class",
+			placement: &v1beta1.ModelPlacementSpec{},
+			engineAC:  h100,
+			decoderAC: a100,
+			expected:  []modelPrewarmTarget{{acceleratorClass: h100, nodes: 4}, {acceleratorClass: a100, nodes: 6}},
+		},
+		{
+			name:      "components sharing an accelerator class",
+			placement: &v1beta1.ModelPlacementSpec{},
+			engineAC:  h100,
+			decoderAC: h100,
+			expected:  []modelPrewarmTarget{{acceleratorClass: h100, nodes: 10}},
+		},
+		{
+			name:      "explicit number of nodes per accelerator class",
+			placement: &v1beta1.ModelPlacementSpec{PrewarmNodes: ptr.To[int32](3)},
+			engineAC:  h100,
+			decoderAC: h100,
+			expected:  []modelPrewarmTarget{{acceleratorClass: h100, nodes: 3}},
+		},
+		{
+			name:      "no accelerator class selected",
+			placement: &v1beta1.ModelPlacementSpec{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{Spec: v1beta1.InferenceServiceSpec{ModelPlacement: tt.placement}}
+			assert.Equal(t, tt.expected, modelPrewarmTargets(isvc, engine, tt.engineAC, decoder, tt.decoderAC))
+		})
+	}
+}
+
+func TestPlanModelPrewarm(t *testing.T) {
+	h100 := prewarmTestAcceleratorClass("h100",
+		v1beta1.AcceleratorNodeCapacity{Name: "node-1", Available: 8},
+		v1beta1.AcceleratorNodeCapacity{Name: "node-2", Available: 2},
+		v1beta1.AcceleratorNodeCapacity{Name: "node-3", Available: 8},
+		v1beta1.AcceleratorNodeCapacity{Name: "node-4", Available: 4},
+	)
+	// node-5 is discovered but has no capacity breakdown yet
+	h100.Status.Nodes = append(h100.Status.Nodes, "node-5")
+
+	tests := []struct {
+		name      string
+		requested []string
+		status    v1beta1.ModelStatusSpec
+		nodes     int
+		expected  []string
+	}{
+		{
+			name:     "nodes with the most available accelerators first",
+			nodes:    3,
+			expected: []string{"node-1", "node-3", "node-4"},
+		},
+		{
+			name:     "ready nodes are requested first",
+			status:   v1beta1.ModelStatusSpec{NodesReady: []string{"node-2", "node-4"}},
+			nodes:    3,
+			expected: []string{"node-1", "node-2", "node-4"},
+		},
+		{
+			name:      "requested nodes are kept",
+			requested: []string{"node-2"},
+			nodes:     2,
+			expected:  []string{"node-1", "node-2"},
+		},
+		{
+			name:      "failed nodes are dropped and replaced",
+			requested: []string{"node-1", "node-3"},
+			status:    v1beta1.ModelStatusSpec{NodesFailed: []string{"node-3"}},
+			nodes:     2,
+			expected:  []string{"node-1", "node-4"},
+		},
+		{
+			name:      "requested nodes beyond the target are dropped on scale-in",
+			requested: []string{"node-1", "node-2", "node-3", "node-4"},
+			status:    v1beta1.ModelStatusSpec{NodesReady: []string{"node-2", "node-4"}},
+			nodes:     2,
+			expected:  []string{"node-2", "node-4"},
+		},
+		{
+			name:      "nodes outside the accelerator class are dropped",
+			requested: []string{"node-9"},
+			nodes:     1,
+			expected:  []string{"node-1"},
+		},
+		{
+			name:     "every node of the accelerator class",
+			nodes:    10,
+			expected: []string{"node-1", "node-2", "node-3", "node-4", "node-5"},
+		},
+		{
+			name:     "no nodes",
+			nodes:    0,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets := []modelPrewarmTarget{{acceleratorClass: h100, nodes: tt.nodes}}
+			assert.Equal(t, tt.expected, planModelPrewarm(tt.requested, &tt.status, targets))
+		})
+	}
+}
+
+func TestReconcileModelPrewarm(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+
+	model := &v1beta1.ClusterBaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-3"},
+		Status:     v1beta1.ModelStatusSpec{NodesReady: []string{"node-1"}},
+	}
+	previousModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-2", Namespace: "default", Annotations: map[string]string{
+			constants.ModelPrewarmNodesAnnotationPrefix + "isvc-uid":   "node-1",
+			constants.ModelPrewarmNodesAnnotationPrefix + "other-isvc": "node-2",
+		}},
+	}
+	r := &InferenceServiceReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(model, previousModel).WithStatusSubresource(model).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"},
+		Spec:       v1beta1.InferenceServiceSpec{Model: &v1beta1.ModelRef{Name: "llama-3"}},
+	}
+	h100 := prewarmTestAcceleratorClass("h100",
+		v1beta1.AcceleratorNodeCapacity{Name: "node-1", Available: 8},
+		v1beta1.AcceleratorNodeCapacity{Name: "node-2", Available: 8},
+		v1beta1.AcceleratorNodeCapacity{Name: "node-3", Available: 4},
+	)
+	targets := []modelPrewarmTarget{{acceleratorClass: h100, nodes: 3}}
+	key := constants.ModelPrewarmNodesAnnotationPrefix + "isvc-uid"
+
+	assert.NoError(t, r.reconcileModelPrewarm(t.Context(), isvc, targets))
+	updated := &v1beta1.ClusterBaseModel{}
+	assert.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: "llama-3"}, updated))
+	assert.Equal(t, "node-1,node-2,node-3", updated.Annotations[key])
+	assert.Equal(t, "Normal ModelPrewarmRequested Requested model llama-3 to be pulled onto nodes node-1,node-2,node-3",
+		<-r.Recorder.(*record.FakeRecorder).Events)
+
+	// The request on the model the inference service used before is released, other requests are kept
+	previous := &v1beta1.BaseModel{}
+	assert.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: "llama-2", Namespace: "default"}, previous))
+	assert.Equal(t, map[string]string{constants.ModelPrewarmNodesAnnotationPrefix + "other-isvc": "node-2"}, previous.Annotations)
+
+	// Nothing changes once the model is requested on enough nodes
+	assert.NoError(t, r.reconcileModelPrewarm(t.Context(), isvc, targets))
+	assert.Empty(t, r.Recorder.(*record.FakeRecorder).Events)
+
+	// A node the model failed on is dropped from the request
+	updated.Status.NodesFailed = []string{"node-3"}
+	assert.NoError(t, r.Status().Update(t.Context(), updated))
+	assert.NoError(t, r.reconcileModelPrewarm(t.Context(), isvc, targets))
+	assert.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: "llama-3"}, updated))
+	assert.Equal(t, "node-1,node-2", updated.Annotations[key])
+
+	// Nodes are released on scale-in
+	assert.NoError(t, r.reconcileModelPrewarm(t.Context(), isvc, []modelPrewarmTarget{{acceleratorClass: h100, nodes: 1}}))
+	assert.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: "llama-3"}, updated))
+	assert.Equal(t, "node-1", updated.Annotations[key])
+
+	// The request is removed once the inference service does not pre-warm the model anymore
+	assert.NoError(t, r.reconcileModelPrewarm(t.Context(), isvc, nil))
+	assert.NoError(t, r.Get(t.Context(), types.NamespacedName{Name: "llama-3"}, updated))
+	assert.NotContains(t, updated.Annotations, key)
+}
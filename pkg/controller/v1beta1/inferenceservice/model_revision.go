@@ -0,0 +1,214 @@
+package inferenceservice
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	knapis "knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/utils"
+)
+
+const (
+	// ModelRevisionPendingReason is used when the pods wait for a new model revision to be ready on enough nodes.
+	ModelRevisionPendingReason = "ModelRevisionPending"
+	// ModelRevisionRolloutReason is used when the pods are rolled to a new model revision.
+	ModelRevisionRolloutReason = "ModelRevisionRollout"
+	// ModelRevisionFoundReason is used when the target model revision is ready on at least one node.
+	ModelRevisionFoundReason = "ModelRevisionFound"
+	// ModelRevisionNotFoundReason is used when no node has the target model revision ready.
+	ModelRevisionNotFoundReason = "ModelRevisionNotFound"
+
+	// modelRevisionRequeueInterval is how often the readiness of a pending model revision is checked
+	modelRevisionRequeueInterval = 30 * time.Second
+)
+
+// modelRevisionPlan is the revision of the model weights the model serving pods are rolled to. The model agent keeps a
+// single copy of the weights per node and replaces it in place, so the plan only decides when the pods are restarted,
+// not which weights a pod reads when it starts.
+type modelRevisionPlan struct {
+	// active is the revision the pods were last rolled to, empty when no node has it ready yet
+	active string
+	// target is the revision the inference service asks for
+	target string
+	// nodesReady is the number of nodes the target revision is ready on
+	nodesReady int
+	// nodesRequired is the number of nodes the target revision must be ready on before the pods are rolled
+	nodesRequired int
+}
+
+// pending reports whether the pods wait for the target revision to be ready on enough nodes
+func (p *modelRevisionPlan) pending() bool {
+	return p != nil && p.active != p.target
+}
+
+// resolved reports whether the target revision is ready on at least one node. The model agent downloads the
+// weights of the base model, so a revision no node has ready is only resolved once the base model moves to it.
+func (p *modelRevisionPlan) resolved() bool {
+	return p.nodesReady > 0
+}
+
+// reconcileModelRevision plans the model revision and annotates the engine and decoder pods with it, so they are only
+// rolled when the plan moves to a new revision
+func (r *InferenceServiceReconciler) reconcileModelRevision(isvc *v1beta1.InferenceService, baseModel *v1beta1.BaseModelSpec,
+	engine *v1beta1.EngineSpec, decoder *v1beta1.DecoderSpec) (*modelRevisionPlan, error) {
+	target := targetModelRevision(isvc, baseModel)
+	if target == "" {
+		return nil, nil
+	}
+	modelStatus, err := isvcutils.GetBaseModelStatus(r.Client, isvc.Spec.Model.Name, isvc.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planModelRevision(isvc, target, modelStatus.Revisions, modelRevisionNodesRequired(engine, decoder))
+	if plan.active == "" {
+		return plan, nil
+	}
+	revisionAnnotation := map[string]string{constants.BaseModelRevisionAnnotationKey: plan.active}
+	if engine != nil {
+		engine.Annotations = utils.Union(engine.Annotations, revisionAnnotation)
+	}
+	if decoder != nil {
+		decoder.Annotations = utils.Union(decoder.Annotations, revisionAnnotation)
+	}
+	return plan, nil
+}
+
+// targetModelRevision returns the model revision the inference service is pinned to, or the version of the model
+func targetModelRevision(isvc *v1beta1.InferenceService, baseModel *v1beta1.BaseModelSpec) string {
+	if isvc.Spec.Model != nil && isvc.Spec.Model.Revision != nil {
+		return *isvc.Spec.Model.Revision
+	}
+	if baseModel != nil && baseModel.Version != nil {
+		return *baseModel.Version
+	}
+	return ""
+}
+
+// planModelRevision holds back rolling the pods until the target revision is ready on enough nodes.
+// A revision only becomes active once the model status shows it ready on enough nodes, so new inference services and
+// models whose revisions are not tracked by the model agent have no active revision until then.
+func planModelRevision(isvc *v1beta1.InferenceService, target string, revisions []v1beta1.ModelRevisionStatus, nodesRequired int) *modelRevisionPlan {
+	plan := &modelRevisionPlan{
+		target:        target,
+		nodesReady:    modelRevisionNodesReady(revisions, target),
+		nodesRequired: nodesRequired,
+	}
+
+	var active string
+	if states := isvc.Status.ModelStatus.ModelRevisionStates; states != nil {
+		active = states.ActiveModelRevision
+	}
+	if plan.nodesReady >= nodesRequired {
+		plan.active = target
+	} else {
+		plan.active = active
+	}
+	return plan
+}
+
+// modelRevisionNodesReady counts the nodes the revision is ready on, matching either its commit sha or its version
+func modelRevisionNodesReady(revisions []v1beta1.ModelRevisionStatus, revision string) int {
+	nodes := map[string]struct{}{}
+	for _, status := range revisions {
+		if status.Revision != revision && status.Version != revision {
+			continue
+		}
+		for _, node := range status.NodesReady {
+			nodes[node] = struct{}{}
+		}
+	}
+	return len(nodes)
+}
+
+// modelRevisionNodesRequired returns the number of nodes running the minimum replicas of the engine and decoder,
+// counting every leader and worker pod of a multi-node replica
+func modelRevisionNodesRequired(engine *v1beta1.EngineSpec, decoder *v1beta1.DecoderSpec) int {
+	nodes := 0
+	if engine != nil {
+		nodes += componentModelNodes(ptr.Deref(engine.MinReplicas, 1), engine.Worker)
+	}
+	if decoder != nil {
+		nodes += componentModelNodes(ptr.Deref(decoder.MinReplicas, 1), decoder.Worker)
+	}
+	return max(nodes, 1)
+}
+
+// componentModelNodes returns the number of nodes running the replicas of a component, at least one replica
+func componentModelNodes(replicas int, worker *v1beta1.WorkerSpec) int {
+	podsPerReplica := 1
+	if worker != nil && worker.Size != nil {
+		podsPerReplica += *worker.Size
+	}
+	return max(replicas, 1) * podsPerReplica
+}
+
+// setModelRevisionStatus reports the active and target model revisions, keeping the target state loading while the
+// pods wait for the target revision
+func (r *InferenceServiceReconciler) setModelRevisionStatus(isvc *v1beta1.InferenceService, plan *modelRevisionPlan) {
+	states := isvc.Status.ModelStatus.ModelRevisionStates
+	if plan == nil {
+		if states != nil {
+			states.ActiveModelRevision = ""
+			states.TargetModelRevision = ""
+		}
+		isvc.Status.ClearCondition(v1beta1.ModelRevisionResolved)
+		return
+	}
+	if states == nil {
+		states = &v1beta1.ModelRevisionStates{}
+		isvc.Status.ModelStatus.ModelRevisionStates = states
+	}
+
+	if plan.pending() && states.TargetModelRevision != plan.target {
+		r.Recorder.Eventf(isvc, v1.EventTypeNormal, ModelRevisionPendingReason,
+			"Waiting for model revision %s to be ready on %d nodes, ready on %d", plan.target, plan.nodesRequired, plan.nodesReady)
+	}
+	if states.ActiveModelRevision != "" && plan.active != "" && states.ActiveModelRevision != plan.active {
+		r.Recorder.Eventf(isvc, v1.EventTypeNormal, ModelRevisionRolloutReason,
+			"Rolling pods from model revision %s to %s", states.ActiveModelRevision, plan.active)
+	}
+
+	states.ActiveModelRevision = plan.active
+	states.TargetModelRevision = plan.target
+	if plan.pending() {
+		states.TargetModelState = v1beta1.Loading
+		isvc.Status.ModelStatus.TransitionStatus = v1beta1.InProgress
+	}
+
+	if !plan.resolved() {
+		isvc.Status.SetCondition(v1beta1.ModelRevisionResolved, &knapis.Condition{
+			Type:   v1beta1.ModelRevisionResolved,
+			Status: v1.ConditionFalse,
+			Reason: ModelRevisionNotFoundReason,
+			Message: fmt.Sprintf("Model revision %s does not match the commit sha or version of the weights ready on any node",
+				plan.target),
+		})
+		return
+	}
+	isvc.Status.SetCondition(v1beta1.ModelRevisionResolved, &knapis.Condition{
+		Type:    v1beta1.ModelRevisionResolved,
+		Status:  v1.ConditionTrue,
+		Reason:  ModelRevisionFoundReason,
+		Message: fmt.Sprintf("Model revision %s is ready on %d of %d nodes", plan.target, plan.nodesReady, plan.nodesRequired),
+	})
+}
+
+// requeueForModelRevision requeues the InferenceService while its pods wait for a model revision, unless the result
+// already requeues it sooner
+func requeueForModelRevision(plan *modelRevisionPlan, result ctrl.Result) ctrl.Result {
+	if !plan.pending() || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+	if result.RequeueAfter == 0 || modelRevisionRequeueInterval < result.RequeueAfter {
+		result.RequeueAfter = modelRevisionRequeueInterval
+	}
+	return result
+}
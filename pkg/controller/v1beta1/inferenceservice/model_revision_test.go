@@ -0,0 +1,260 @@
+package inferenceservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func modelRevisionTestService(pinned *string, activeRevision string) *v1beta1.InferenceService {
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec:       v1beta1.InferenceServiceSpec{Model: &v1beta1.ModelRef{Name: "llama-3"}},
+	}
+	isvc.Spec.Model.Revision = pinned
+	if activeRevision != "" {
+		isvc.Status.ModelStatus.ModelRevisionStates = &v1beta1.ModelRevisionStates{
+			ActiveModelState:    v1beta1.Loaded,
+			TargetModelState:    v1beta1.Loaded,
+			ActiveModelRevision: activeRevision,
+			TargetModelRevision: activeRevision,
+		}
+	}
+	return isvc
+}
+
+func TestPlanModelRevision(t *testing.T) {
+	revisions := []v1beta1.ModelRevisionStatus{
+		{Revision: "abc123", Version: "v1", NodesReady: []string{"node-1", "node-2", "node-3"}},
+		{Revision: "def456", Version: "v2", NodesReady: []string{"node-3"}},
+	}
+
+	tests := []struct {
+		name           string
+		activeRevision string
+		target         string
+		revisions      []v1beta1.ModelRevisionStatus
+		nodesRequired  int
+		expected       *modelRevisionPlan
+	}{
+		{
+			name:          "new inference service has no active revision until the target is ready on enough nodes",
+			target:        "v2",
+			revisions:     revisions,
+			nodesRequired: 2,
+			expected:      &modelRevisionPlan{target: "v2", nodesReady: 1, nodesRequired: 2},
+		},
+		{
+			name:          "new inference service starts on a target ready on enough nodes",
+			target:        "v1",
+			revisions:     revisions,
+			nodesRequired: 2,
+			expected:      &modelRevisionPlan{active: "v1", target: "v1", nodesReady: 3, nodesRequired: 2},
+		},
+		{
+			name:           "active revision is kept",
+			activeRevision: "v1",
+			target:         "v1",
+			revisions:      revisions,
+			nodesRequired:  2,
+			expected:       &modelRevisionPlan{active: "v1", target: "v1", nodesReady: 3, nodesRequired: 2},
+		},
+		{
+			name:           "pods wait until the target is ready on enough nodes",
+			activeRevision: "v1",
+			target:         "v2",
+			revisions:      revisions,
+			nodesRequired:  2,
+			expected:       &modelRevisionPlan{active: "v1", target: "v2", nodesReady: 1, nodesRequired: 2},
+		},
+		{
+			name:           "pods roll once the target is ready on enough nodes",
+			activeRevision: "v1",
+			target:         "v2",
+			revisions:      revisions,
+			nodesRequired:  1,
+			expected:       &modelRevisionPlan{active: "v2", target: "v2", nodesReady: 1, nodesRequired: 1},
+		},
+		{
+			name:           "revision pinned by commit sha",
+			activeRevision: "def456",
+			target:         "abc123",
+			revisions:      revisions,
+			nodesRequired:  3,
+			expected:       &modelRevisionPlan{active: "abc123", target: "abc123", nodesReady: 3, nodesRequired: 3},
+		},
+		{
+			name:           "untracked revisions do not become active",
+			activeRevision: "v1",
+			target:         "v2",
+			nodesRequired:  2,
+			expected:       &modelRevisionPlan{active: "v1", target: "v2", nodesRequired: 2},
+		},
+		{
+			name:          "pins matching no revision are not active",
+			target:        "0123abc",
+			revisions:     revisions,
+			nodesRequired: 1,
+			expected:      &modelRevisionPlan{target: "0123abc", nodesRequired: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := modelRevisionTestService(nil, tt.activeRevision)
+			assert.Equal(t, tt.expected, planModelRevision(isvc, tt.target, tt.revisions, tt.nodesRequired))
+		})
+	}
+}
+
+func TestTargetModelRevision(t *testing.T) {
+	version := "v2"
+	pinned := "abc123"
+	baseModel := &v1beta1.BaseModelSpec{ModelExtensionSpec: v1beta1.ModelExtensionSpec{Version: &version}}
+
+	assert.Equal(t, "abc123", targetModelRevision(modelRevisionTestService(&pinned, ""), baseModel))
+	assert.Equal(t, "v2", targetModelRevision(modelRevisionTestService(nil, ""), baseModel))
+	assert.Equal(t, "", targetModelRevision(modelRevisionTestService(nil, ""), &v1beta1.BaseModelSpec{}))
+}
+
+func TestModelRevisionNodesRequired(t *testing.T) {
+	two := 2
+	three := 3
+
+	tests := []struct {
+		name     string
+		engine   *v1beta1.EngineSpec
+		decoder  *v1beta1.DecoderSpec
+		expected int
+	}{
+		{
+			name:     "engine with default replicas",
+			engine:   &v1beta1.EngineSpec{},
+			expected: 1,
+		},
+		{
+			name:     "engine and decoder replicas",
+			engine:   &v1beta1.EngineSpec{ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two}},
+			decoder:  &v1beta1.DecoderSpec{ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &three}},
+			expected: 5,
+		},
+		{
+			name: "multi-node replicas count leader and workers",
+			engine: &v1beta1.EngineSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two},
+				Worker:                 &v1beta1.WorkerSpec{Size: &three},
+			},
+			expected: 8,
+		},
+		{
+			name:     "no model serving component",
+			expected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, modelRevisionNodesRequired(tt.engine, tt.decoder))
+		})
+	}
+}
+
+func TestReconcileModelRevision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+
+	version := "v2"
+	baseModel := &v1beta1.BaseModel{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-3", Namespace: "default"},
+		Spec:       v1beta1.BaseModelSpec{ModelExtensionSpec: v1beta1.ModelExtensionSpec{Version: &version}},
+		Status: v1beta1.ModelStatusSpec{Revisions: []v1beta1.ModelRevisionStatus{
+			{Revision: "v1", Version: "v1", NodesReady: []string{"node-1", "node-2"}},
+			{Revision: "v2", Version: "v2", NodesReady: []string{"node-2"}},
+		}},
+	}
+	r := &InferenceServiceReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(baseModel).WithStatusSubresource(baseModel).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	isvc := modelRevisionTestService(nil, "v1")
+	two := 2
+	engine := &v1beta1.EngineSpec{ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: &two}}
+	decoder := &v1beta1.DecoderSpec{ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+		Annotations: map[string]string{"foo": "bar"},
+	}}
+
+	plan, err := r.reconcileModelRevision(isvc, &baseModel.Spec, engine, decoder)
+	assert.NoError(t, err)
+	assert.True(t, plan.pending())
+	assert.Equal(t, "v1", engine.Annotations[constants.BaseModelRevisionAnnotationKey])
+	assert.Equal(t, map[string]string{"foo": "bar", constants.BaseModelRevisionAnnotationKey: "v1"}, decoder.Annotations)
+
+	r.setModelRevisionStatus(isvc, plan)
+	states := isvc.Status.ModelStatus.ModelRevisionStates
+	assert.Equal(t, "v1", states.ActiveModelRevision)
+	assert.Equal(t, "v2", states.TargetModelRevision)
+	assert.Equal(t, v1beta1.Loaded, states.ActiveModelState)
+	assert.Equal(t, v1beta1.Loading, states.TargetModelState)
+	assert.Equal(t, v1beta1.InProgress, isvc.Status.ModelStatus.TransitionStatus)
+	assert.Equal(t, "Normal ModelRevisionPending Waiting for model revision v2 to be ready on 3 nodes, ready on 1",
+		<-r.Recorder.(*record.FakeRecorder).Events)
+	assert.True(t, isvc.Status.IsConditionReady(v1beta1.ModelRevisionResolved))
+
+	// The pods roll once the target revision is ready on enough nodes
+	baseModel.Status.Revisions[1].NodesReady = []string{"node-1", "node-2", "node-3"}
+	assert.NoError(t, r.Client.Status().Update(t.Context(), baseModel))
+
+	plan, err = r.reconcileModelRevision(isvc, &baseModel.Spec, engine, decoder)
+	assert.NoError(t, err)
+	assert.False(t, plan.pending())
+	assert.Equal(t, "v2", engine.Annotations[constants.BaseModelRevisionAnnotationKey])
+
+	r.setModelRevisionStatus(isvc, plan)
+	assert.Equal(t, "v2", states.ActiveModelRevision)
+	assert.Equal(t, "v2", states.TargetModelRevision)
+	assert.Equal(t, "Normal ModelRevisionRollout Rolling pods from model revision v1 to v2",
+		<-r.Recorder.(*record.FakeRecorder).Events)
+}
+
+func TestSetModelRevisionStatusUnresolved(t *testing.T) {
+	r := &InferenceServiceReconciler{Recorder: record.NewFakeRecorder(10)}
+	pinned := "0123abc"
+	isvc := modelRevisionTestService(&pinned, "")
+
+	r.setModelRevisionStatus(isvc, planModelRevision(isvc, pinned, []v1beta1.ModelRevisionStatus{
+		{Revision: "abc123", Version: "v1", NodesReady: []string{"node-1"}},
+	}, 1))
+	states := isvc.Status.ModelStatus.ModelRevisionStates
+	assert.Equal(t, "", states.ActiveModelRevision)
+	assert.Equal(t, "0123abc", states.TargetModelRevision)
+	assert.Equal(t, v1beta1.Loading, states.TargetModelState)
+	condition := isvc.Status.GetCondition(v1beta1.ModelRevisionResolved)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, corev1.ConditionFalse, condition.Status)
+		assert.Equal(t, ModelRevisionNotFoundReason, condition.Reason)
+	}
+
+	// The condition is cleared once the inference service does not target a revision anymore
+	r.setModelRevisionStatus(isvc, nil)
+	assert.Nil(t, isvc.Status.GetCondition(v1beta1.ModelRevisionResolved))
+}
+
+func TestRequeueForModelRevision(t *testing.T) {
+	pending := &modelRevisionPlan{active: "v1", target: "v2"}
+
+	assert.Equal(t, ctrl.Result{}, requeueForModelRevision(nil, ctrl.Result{}))
+	assert.Equal(t, ctrl.Result{}, requeueForModelRevision(&modelRevisionPlan{active: "v2", target: "v2"}, ctrl.Result{}))
+	assert.Equal(t, ctrl.Result{RequeueAfter: modelRevisionRequeueInterval}, requeueForModelRevision(pending, ctrl.Result{}))
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Second}, requeueForModelRevision(pending, ctrl.Result{RequeueAfter: time.Second}))
+}
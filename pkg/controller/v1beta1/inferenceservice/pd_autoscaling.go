@@ -0,0 +1,117 @@
+package inferenceservice
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/pdautoscaler"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+const (
+	// PDAutoscaledReason is used when the coordinated autoscaling changes the replicas of the engine or decoder.
+	PDAutoscaledReason = "PDAutoscaled"
+
+	// minPDAutoscalingRequeue bounds how soon a due evaluation of the coordinated autoscaling is requeued
+	minPDAutoscalingRequeue = time.Second
+)
+
+// reconcilePDAutoscaling scales the engine and decoder of a PD-disaggregated InferenceService together, records
+// the chosen replicas in status and pins the min and max replicas of the merged engine and decoder specs to them,
+// so that their Deployments or LeaderWorkerSets and autoscalers run exactly those replicas
+func (r *InferenceServiceReconciler) reconcilePDAutoscaling(ctx context.Context, isvc *v1beta1.InferenceService,
+	engine *v1beta1.EngineSpec, decoder *v1beta1.DecoderSpec, engineMode, decoderMode constants.DeploymentModeType) {
+	spec := isvc.Spec.PDAutoscaling
+	if spec == nil || engine == nil || decoder == nil || !pdAutoscalingMode(engineMode) || !pdAutoscalingMode(decoderMode) {
+		isvc.Status.PDAutoscaling = nil
+		return
+	}
+
+	target := pdautoscaler.Target{
+		Namespace: isvc.Namespace,
+		Prefill: pdAutoscalingComponent(constants.EngineServiceName(isvc.Name), &engine.ComponentExtensionSpec,
+			engineMode, engine.Runner, engine.Leader, engine.Worker),
+		Decode: pdAutoscalingComponent(constants.DecoderServiceName(isvc.Name), &decoder.ComponentExtensionSpec,
+			decoderMode, decoder.Runner, decoder.Leader, decoder.Worker),
+		PromServerAddress: keda.GetPrometheusServerAddress(metav1.ObjectMeta{Annotations: isvc.Annotations}, isvc.Spec.KedaConfig),
+	}
+	previous := isvc.Status.PDAutoscaling
+	status := r.pdAutoscaler().Scale(ctx, spec, previous, target)
+	if previous == nil || status.PrefillReplicas != previous.PrefillReplicas || status.DecodeReplicas != previous.DecodeReplicas {
+		r.Log.Info("Scaling engine and decoder together", "namespace", isvc.Namespace, "inferenceService", isvc.Name,
+			"prefillReplicas", status.PrefillReplicas, "decodeReplicas", status.DecodeReplicas)
+		r.Recorder.Eventf(isvc, v1.EventTypeNormal, PDAutoscaledReason, "Scaled to %d prefill and %d decode replicas",
+			status.PrefillReplicas, status.DecodeReplicas)
+	}
+	isvc.Status.PDAutoscaling = status
+
+	pinReplicas(&engine.ComponentExtensionSpec, status.PrefillReplicas)
+	pinReplicas(&decoder.ComponentExtensionSpec, status.DecodeReplicas)
+}
+
+// pdAutoscalingMode reports whether the replicas of a component in the deployment mode can be pinned
+func pdAutoscalingMode(mode constants.DeploymentModeType) bool {
+	return mode == constants.RawDeployment || mode == constants.MultiNode
+}
+
+// pdAutoscalingComponent describes the engine or decoder to the PDAutoscaler. Components scale between one replica
+// and their max replicas, and a multi-node replica counts its leader and workers as nodes.
+func pdAutoscalingComponent(name string, componentSpec *v1beta1.ComponentExtensionSpec, mode constants.DeploymentModeType,
+	runner *v1beta1.RunnerSpec, leader *v1beta1.LeaderSpec, worker *v1beta1.WorkerSpec) pdautoscaler.Component {
+	minReplicas := int32(max(ptr.Deref(componentSpec.MinReplicas, 1), 1))
+	component := pdautoscaler.Component{
+		App:             constants.TruncateNameWithMaxLength(name, 63),
+		MinReplicas:     minReplicas,
+		MaxReplicas:     max(int32(componentSpec.MaxReplicas), minReplicas),
+		NodesPerReplica: 1,
+	}
+	if mode == constants.MultiNode {
+		if worker != nil && worker.Size != nil {
+			component.NodesPerReplica += int32(*worker.Size)
+		}
+		if leader != nil && leader.Runner != nil {
+			runner = leader.Runner
+		}
+	}
+	podSpec := &v1.PodSpec{}
+	if runner != nil {
+		podSpec.Containers = []v1.Container{runner.Container}
+	}
+	component.RuntimeFamily = isvcutils.GetRuntimeFamily(podSpec)
+	return component
+}
+
+// pinReplicas fixes the min and max replicas of a component
+func pinReplicas(componentSpec *v1beta1.ComponentExtensionSpec, replicas int32) {
+	componentSpec.MinReplicas = ptr.To(int(replicas))
+	componentSpec.MaxReplicas = int(replicas)
+}
+
+func (r *InferenceServiceReconciler) pdAutoscaler() *pdautoscaler.PDAutoscaler {
+	if r.PDAutoscaler == nil {
+		r.PDAutoscaler = pdautoscaler.NewPDAutoscaler()
+	}
+	return r.PDAutoscaler
+}
+
+// requeueForPDAutoscaling requeues the InferenceService when the next evaluation of its coordinated autoscaling is
+// due, unless the result already requeues it sooner
+func requeueForPDAutoscaling(isvc *v1beta1.InferenceService, result ctrl.Result) ctrl.Result {
+	status := isvc.Status.PDAutoscaling
+	if status == nil || status.NextEvaluationTime == nil || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+	after := max(time.Until(status.NextEvaluationTime.Time), minPDAutoscalingRequeue)
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+package inferenceservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/pdautoscaler"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+type pdAutoscalingTestMetrics map[string]float64
+
+func (m pdAutoscalingTestMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	value, ok := m[query]
+	return value, ok, nil
+}
+
+func TestReconcilePDAutoscaling(t *testing.T) {
+	now := time.Now()
+	recorder := record.NewFakeRecorder(10)
+	r := &InferenceServiceReconciler{
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+		PDAutoscaler: &pdautoscaler.PDAutoscaler{
+			NewMetricsClient: func(string) (prometheus.MetricsClient, error) {
+				return pdAutoscalingTestMetrics{
+					`sum(sglang:num_queue_reqs{namespace="default",app="llama-engine"})`:      30,
+					`sum(vllm:gpu_cache_usage_perc{namespace="default",app="llama-decoder"})`: 0.4,
+				}, nil
+			},
+			Now: func() time.Time { return now },
+		},
+	}
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: v1beta1.InferenceServiceSpec{
+			PDAutoscaling: &v1beta1.PDAutoscalingSpec{MaxRatio: "1"},
+		},
+	}
+	engine := &v1beta1.EngineSpec{
+		ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(1), MaxReplicas: 10},
+		Runner:                 &v1beta1.RunnerSpec{Container: v1.Container{Image: "lmsysorg/sglang:v0.4.6"}},
+	}
+	decoder := &v1beta1.DecoderSpec{
+		ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(1), MaxReplicas: 2},
+		Leader: &v1beta1.LeaderSpec{
+			Runner: &v1beta1.RunnerSpec{Container: v1.Container{Image: "vllm/vllm-openai:v0.9.0"}},
+		},
+		Worker: &v1beta1.WorkerSpec{Size: ptr.To(2)},
+	}
+
+	r.reconcilePDAutoscaling(context.TODO(), isvc, engine, decoder, constants.RawDeployment, constants.MultiNode)
+
+	// Prefill scales to 6 replicas on its queue, and decode to 2 replicas of 3 nodes to keep the max ratio
+	status := isvc.Status.PDAutoscaling
+	require.NotNil(t, status)
+	assert.Equal(t, int32(6), status.PrefillReplicas)
+	assert.Equal(t, int32(2), status.DecodeReplicas)
+	assert.Equal(t, ptr.To(6), engine.MinReplicas)
+	assert.Equal(t, 6, engine.MaxReplicas)
+	assert.Equal(t, ptr.To(2), decoder.MinReplicas)
+	assert.Equal(t, 2, decoder.MaxReplicas)
+	assert.Equal(t, "Normal PDAutoscaled Scaled to 6 prefill and 2 decode replicas", <-recorder.Events)
+
+	// Serverless components are not scaled together
+	r.reconcilePDAutoscaling(context.TODO(), isvc, engine, decoder, constants.Serverless, constants.MultiNode)
+	assert.Nil(t, isvc.Status.PDAutoscaling)
+}
+
+func TestPDAutoscalingComponent(t *testing.T) {
+	runner := &v1beta1.RunnerSpec{Container: v1.Container{Image: "vllm/vllm-openai:v0.9.0"}}
+	component := pdAutoscalingComponent("llama-engine", &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(0)},
+		constants.RawDeployment, runner, nil, &v1beta1.WorkerSpec{Size: ptr.To(3)})
+	assert.Equal(t, pdautoscaler.Component{
+		App:             "llama-engine",
+		MinReplicas:     1,
+		MaxReplicas:     1,
+		NodesPerReplica: 1,
+		RuntimeFamily:   isvcutils.RuntimeFamilyVLLM,
+	}, component)
+
+	component = pdAutoscalingComponent("llama-decoder", &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(2), MaxReplicas: 4},
+		constants.MultiNode, nil, nil, &v1beta1.WorkerSpec{Size: ptr.To(3)})
+	assert.Equal(t, pdautoscaler.Component{
+		App:             "llama-decoder",
+		MinReplicas:     2,
+		MaxReplicas:     4,
+		NodesPerReplica: 4,
+		RuntimeFamily:   isvcutils.RuntimeFamilySGLang,
+	}, component)
+}
+
+func TestRequeueForPDAutoscaling(t *testing.T) {
+	isvc := &v1beta1.InferenceService{}
+	assert.Equal(t, ctrl.Result{}, requeueForPDAutoscaling(isvc, ctrl.Result{}))
+
+	isvc.Status.PDAutoscaling = &v1beta1.PDAutoscalingStatus{
+		NextEvaluationTime: &metav1.Time{Time: time.Now().Add(time.Minute)},
+	}
+	result := requeueForPDAutoscaling(isvc, ctrl.Result{})
+	assert.Greater(t, result.RequeueAfter, 50*time.Second)
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Second}, requeueForPDAutoscaling(isvc, ctrl.Result{RequeueAfter: time.Second}))
+
+	isvc.Status.PDAutoscaling.NextEvaluationTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	assert.Equal(t, ctrl.Result{RequeueAfter: minPDAutoscalingRequeue}, requeueForPDAutoscaling(isvc, ctrl.Result{}))
+}
@@ -0,0 +1,228 @@
+package pdautoscaler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+var log = logf.Log.WithName("PDAutoscaler")
+
+const (
+	// DefaultPrefillQueueDepth is the default target number of requests waiting per engine replica
+	DefaultPrefillQueueDepth = 5.0
+	// DefaultDecodeKVCacheUtilization is the default target fraction of the KV cache used per decoder replica
+	DefaultDecodeKVCacheUtilization = 0.8
+	// DefaultIntervalSeconds is how often the replicas are evaluated by default
+	DefaultIntervalSeconds int64 = 30
+
+	// tolerance is the relative distance to the target below which the replicas are kept, like the HPA tolerance
+	tolerance = 0.1
+)
+
+// Component describes the engine or decoder scaled by the PDAutoscaler
+type Component struct {
+	// App is the app label of the component pods
+	App string
+	// MinReplicas and MaxReplicas bound the replicas of the component
+	MinReplicas int32
+	MaxReplicas int32
+	// NodesPerReplica is the number of pods of a replica, the leader and workers of a multi-node replica
+	NodesPerReplica int32
+	// RuntimeFamily is the serving engine of the component, which names its metrics
+	RuntimeFamily utils.RuntimeFamily
+}
+
+// Target describes the engine and decoder of a PD-disaggregated InferenceService
+type Target struct {
+	Namespace string
+	Prefill   Component
+	Decode    Component
+	// PromServerAddress is the address of the Prometheus server the metrics are queried from
+	PromServerAddress string
+}
+
+// PDAutoscaler scales the engine and decoder of a PD-disaggregated InferenceService together
+type PDAutoscaler struct {
+	NewMetricsClient func(address string) (prometheus.MetricsClient, error)
+	Now              func() time.Time
+}
+
+// NewPDAutoscaler creates a PDAutoscaler that queries Prometheus
+func NewPDAutoscaler() *PDAutoscaler {
+	return &PDAutoscaler{
+		NewMetricsClient: prometheus.NewClient,
+		Now:              time.Now,
+	}
+}
+
+// Scale returns the replicas of the engine and decoder. The replicas of the last evaluation recorded in status are
+// kept until the next evaluation is due, then the engine is sized on its queue of waiting requests and the decoder
+// on its KV cache utilization, and the ratio band is applied. A component keeps its replicas when its metric has no
+// samples or cannot be queried.
+func (a *PDAutoscaler) Scale(ctx context.Context, spec *v1beta1.PDAutoscalingSpec, status *v1beta1.PDAutoscalingStatus, target Target) *v1beta1.PDAutoscalingStatus {
+	now := a.Now()
+	prefill, decode := target.Prefill.MinReplicas, target.Decode.MinReplicas
+	if status != nil {
+		prefill, decode = status.PrefillReplicas, status.DecodeReplicas
+		if status.NextEvaluationTime != nil && now.Before(status.NextEvaluationTime.Time) {
+			// The bounds may have changed since the last evaluation
+			prefill, decode = applyBounds(spec, target, prefill, decode)
+			if prefill == status.PrefillReplicas && decode == status.DecodeReplicas {
+				return status
+			}
+			next := status.DeepCopy()
+			next.PrefillReplicas, next.DecodeReplicas = prefill, decode
+			next.LastScaleTime = &metav1.Time{Time: now}
+			return next
+		}
+	}
+
+	signals := a.querySignals(ctx, target, prefill, decode)
+	desiredPrefill, desiredDecode := Plan(spec, target, prefill, decode, signals)
+	next := &v1beta1.PDAutoscalingStatus{
+		PrefillReplicas:    desiredPrefill,
+		DecodeReplicas:     desiredDecode,
+		NextEvaluationTime: &metav1.Time{Time: now.Add(time.Duration(intervalSeconds(spec)) * time.Second)},
+		Message:            signals.message(desiredPrefill, desiredDecode),
+	}
+	if status != nil {
+		next.LastScaleTime = status.LastScaleTime
+	}
+	if status == nil || desiredPrefill != status.PrefillReplicas || desiredDecode != status.DecodeReplicas {
+		next.LastScaleTime = &metav1.Time{Time: now}
+	}
+	return next
+}
+
+// Signals are the metrics the replicas are sized on, each valid only when its ok field is set
+type Signals struct {
+	// PrefillQueue is the number of requests waiting in the engine
+	PrefillQueue   float64
+	PrefillQueueOK bool
+	// DecodeKVCache is the sum of the KV cache utilization of the decoder replicas
+	DecodeKVCache   float64
+	DecodeKVCacheOK bool
+}
+
+func (s Signals) message(prefill, decode int32) string {
+	queue, kvCache := "unknown", "unknown"
+	if s.PrefillQueueOK {
+		queue = strconv.FormatFloat(s.PrefillQueue, 'g', 4, 64)
+	}
+	if s.DecodeKVCacheOK {
+		kvCache = strconv.FormatFloat(s.DecodeKVCache, 'g', 4, 64)
+	}
+	return fmt.Sprintf("%d prefill and %d decode replicas for %s waiting prefill requests and %s decode KV cache utilization",
+		prefill, decode, queue, kvCache)
+}
+
+// querySignals queries the prefill queue and the decode KV cache utilization. Failed queries leave the signal unset.
+func (a *PDAutoscaler) querySignals(ctx context.Context, target Target, prefill, decode int32) Signals {
+	var signals Signals
+	client, err := a.NewMetricsClient(target.PromServerAddress)
+	if err != nil {
+		log.Error(err, "Failed to create metrics client", "address", target.PromServerAddress)
+		return signals
+	}
+
+	if query, ok := keda.LLMMetricQuery(v1beta1.MetricQueueDepth, target.Prefill.RuntimeFamily, target.Namespace, target.Prefill.App); ok {
+		signals.PrefillQueue, signals.PrefillQueueOK, err = client.Query(ctx, query)
+		if err != nil {
+			log.Error(err, "Failed to query prefill queue", "namespace", target.Namespace, "app", target.Prefill.App)
+		}
+	}
+	if query, ok := keda.LLMMetricQuery(v1beta1.MetricKVCache, target.Decode.RuntimeFamily, target.Namespace, target.Decode.App); ok {
+		signals.DecodeKVCache, signals.DecodeKVCacheOK, err = client.Query(ctx, query)
+		if err != nil {
+			log.Error(err, "Failed to query decode KV cache", "namespace", target.Namespace, "app", target.Decode.App)
+		}
+	}
+	return signals
+}
+
+// Plan sizes the engine on its queue of waiting requests and the decoder on its KV cache utilization, starting from
+// their current replicas, then applies the replica bounds and the ratio band
+func Plan(spec *v1beta1.PDAutoscalingSpec, target Target, prefill, decode int32, signals Signals) (int32, int32) {
+	if signals.PrefillQueueOK {
+		prefill = desiredReplicas(prefill, signals.PrefillQueue, parseFloat(spec.PrefillQueueDepth, DefaultPrefillQueueDepth))
+	}
+	if signals.DecodeKVCacheOK {
+		decode = desiredReplicas(decode, signals.DecodeKVCache, parseFloat(spec.DecodeKVCacheUtilization, DefaultDecodeKVCacheUtilization))
+	}
+	return applyBounds(spec, target, prefill, decode)
+}
+
+// desiredReplicas returns the replicas bringing the per replica value of a metric summed over the current replicas
+// to the target, keeping the current replicas within the tolerance
+func desiredReplicas(current int32, total, targetPerReplica float64) int32 {
+	current = max(current, 1)
+	usage := total / float64(current) / targetPerReplica
+	if math.Abs(usage-1) <= tolerance {
+		return current
+	}
+	return int32(math.Ceil(total / targetPerReplica))
+}
+
+// applyBounds clamps the replicas of each component to its bounds, then brings the ratio of prefill to decode nodes
+// into the band. The lagging component is scaled up, and when it is at its max replicas the other one is scaled
+// down instead.
+func applyBounds(spec *v1beta1.PDAutoscalingSpec, target Target, prefill, decode int32) (int32, int32) {
+	p, d := target.Prefill, target.Decode
+	prefill = clamp(prefill, p.MinReplicas, p.MaxReplicas)
+	decode = clamp(decode, d.MinReplicas, d.MaxReplicas)
+	prefillNodes := float64(max(p.NodesPerReplica, 1))
+	decodeNodes := float64(max(d.NodesPerReplica, 1))
+
+	if minRatio := parseFloat(spec.MinRatio, 0); minRatio > 0 && float64(prefill)*prefillNodes < minRatio*float64(decode)*decodeNodes {
+		needed := int32(math.Ceil(minRatio * float64(decode) * decodeNodes / prefillNodes))
+		if needed <= p.MaxReplicas {
+			prefill = needed
+		} else {
+			prefill = p.MaxReplicas
+			decode = clamp(int32(math.Floor(float64(prefill)*prefillNodes/(minRatio*decodeNodes))), d.MinReplicas, d.MaxReplicas)
+		}
+	}
+	if maxRatio := parseFloat(spec.MaxRatio, 0); maxRatio > 0 && float64(prefill)*prefillNodes > maxRatio*float64(decode)*decodeNodes {
+		needed := int32(math.Ceil(float64(prefill) * prefillNodes / (maxRatio * decodeNodes)))
+		if needed <= d.MaxReplicas {
+			decode = needed
+		} else {
+			decode = d.MaxReplicas
+			prefill = clamp(int32(math.Floor(maxRatio*float64(decode)*decodeNodes/prefillNodes)), p.MinReplicas, p.MaxReplicas)
+		}
+	}
+	return prefill, decode
+}
+
+func clamp(replicas, minReplicas, maxReplicas int32) int32 {
+	return min(max(replicas, minReplicas), max(minReplicas, maxReplicas))
+}
+
+func parseFloat(value string, defaultValue float64) float64 {
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed <= 0 {
+		return defaultValue
+	}
+	return parsed
+}
+
+func intervalSeconds(spec *v1beta1.PDAutoscalingSpec) int64 {
+	if spec.IntervalSeconds > 0 {
+		return spec.IntervalSeconds
+	}
+	return DefaultIntervalSeconds
+}
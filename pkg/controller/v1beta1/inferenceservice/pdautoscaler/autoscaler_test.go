@@ -0,0 +1,213 @@
+package pdautoscaler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+// fakeMetrics returns the value of the first metric name contained in a query
+type fakeMetrics struct {
+	values  map[string]float64
+	err     error
+	queries []string
+}
+
+func (m *fakeMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	m.queries = append(m.queries, query)
+	if m.err != nil {
+		return 0, false, m.err
+	}
+	for name, value := range m.values {
+		if strings.Contains(query, name) {
+			return value, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func testTarget() Target {
+	return Target{
+		Namespace:         "default",
+		Prefill:           Component{App: "llama-engine", MinReplicas: 1, MaxReplicas: 8, NodesPerReplica: 1, RuntimeFamily: utils.RuntimeFamilySGLang},
+		Decode:            Component{App: "llama-decoder", MinReplicas: 1, MaxReplicas: 8, NodesPerReplica: 1, RuntimeFamily: utils.RuntimeFamilySGLang},
+		PromServerAddress: "http://prometheus:9090",
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            *v1beta1.PDAutoscalingSpec
+		target          func(*Target)
+		prefill, decode int32
+		signals         Signals
+		expectedPrefill int32
+		expectedDecode  int32
+	}{
+		{
+			name:            "no signals keep the replicas",
+			spec:            &v1beta1.PDAutoscalingSpec{},
+			prefill:         2,
+			decode:          3,
+			expectedPrefill: 2,
+			expectedDecode:  3,
+		},
+		{
+			name:            "prefill scales on its queue and decode on its KV cache",
+			spec:            &v1beta1.PDAutoscalingSpec{},
+			prefill:         2,
+			decode:          2,
+			signals:         Signals{PrefillQueue: 20, PrefillQueueOK: true, DecodeKVCache: 0.6, DecodeKVCacheOK: true},
+			expectedPrefill: 4,
+			expectedDecode:  1,
+		},
+		{
+			name:            "signals within the tolerance keep the replicas",
+			spec:            &v1beta1.PDAutoscalingSpec{PrefillQueueDepth: "10", DecodeKVCacheUtilization: "0.5"},
+			prefill:         2,
+			decode:          4,
+			signals:         Signals{PrefillQueue: 21, PrefillQueueOK: true, DecodeKVCache: 1.9, DecodeKVCacheOK: true},
+			expectedPrefill: 2,
+			expectedDecode:  4,
+		},
+		{
+			name:            "replicas are clamped to the component bounds",
+			spec:            &v1beta1.PDAutoscalingSpec{},
+			prefill:         4,
+			decode:          4,
+			signals:         Signals{PrefillQueue: 100, PrefillQueueOK: true, DecodeKVCache: 0, DecodeKVCacheOK: true},
+			expectedPrefill: 8,
+			expectedDecode:  1,
+		},
+		{
+			name:            "starving prefill is scaled up to the min ratio",
+			spec:            &v1beta1.PDAutoscalingSpec{MinRatio: "0.5", MaxRatio: "2"},
+			prefill:         1,
+			decode:          4,
+			signals:         Signals{PrefillQueue: 0, PrefillQueueOK: true, DecodeKVCache: 6.4, DecodeKVCacheOK: true},
+			expectedPrefill: 4,
+			expectedDecode:  8,
+		},
+		{
+			name:            "decode is scaled up to the max ratio",
+			spec:            &v1beta1.PDAutoscalingSpec{MinRatio: "0.5", MaxRatio: "1"},
+			prefill:         2,
+			decode:          2,
+			signals:         Signals{PrefillQueue: 30, PrefillQueueOK: true, DecodeKVCache: 1.6, DecodeKVCacheOK: true},
+			expectedPrefill: 6,
+			expectedDecode:  6,
+		},
+		{
+			name:            "prefill is scaled down when decode is at its max replicas",
+			spec:            &v1beta1.PDAutoscalingSpec{MaxRatio: "1"},
+			target:          func(target *Target) { target.Decode.MaxReplicas = 3 },
+			prefill:         2,
+			decode:          2,
+			signals:         Signals{PrefillQueue: 30, PrefillQueueOK: true},
+			expectedPrefill: 3,
+			expectedDecode:  3,
+		},
+		{
+			name: "the ratio counts the nodes of multi-node replicas",
+			spec: &v1beta1.PDAutoscalingSpec{MinRatio: "1", MaxRatio: "1"},
+			target: func(target *Target) {
+				target.Prefill.NodesPerReplica = 1
+				target.Decode.NodesPerReplica = 2
+			},
+			prefill:         2,
+			decode:          2,
+			expectedPrefill: 4,
+			expectedDecode:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := testTarget()
+			if tt.target != nil {
+				tt.target(&target)
+			}
+			prefill, decode := Plan(tt.spec, target, tt.prefill, tt.decode, tt.signals)
+			assert.Equal(t, tt.expectedPrefill, prefill, "prefill replicas")
+			assert.Equal(t, tt.expectedDecode, decode, "decode replicas")
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	metrics := &fakeMetrics{values: map[string]float64{"num_queue_reqs": 20, "token_usage": 1.6}}
+	autoscaler := &PDAutoscaler{
+		NewMetricsClient: func(address string) (prometheus.MetricsClient, error) {
+			assert.Equal(t, "http://prometheus:9090", address)
+			return metrics, nil
+		},
+		Now: func() time.Time { return now },
+	}
+	spec := &v1beta1.PDAutoscalingSpec{IntervalSeconds: 60}
+
+	// The first evaluation starts from the min replicas
+	status := autoscaler.Scale(context.TODO(), spec, nil, testTarget())
+	require.NotNil(t, status)
+	assert.Equal(t, int32(4), status.PrefillReplicas)
+	assert.Equal(t, int32(2), status.DecodeReplicas)
+	assert.Equal(t, now, status.LastScaleTime.Time)
+	assert.Equal(t, now.Add(time.Minute), status.NextEvaluationTime.Time)
+	assert.Equal(t, "4 prefill and 2 decode replicas for 20 waiting prefill requests and 1.6 decode KV cache utilization", status.Message)
+	assert.Equal(t, []string{
+		`sum(sglang:num_queue_reqs{namespace="default",app="llama-engine"})`,
+		`sum(sglang:token_usage{namespace="default",app="llama-decoder"})`,
+	}, metrics.queries)
+
+	// The replicas are kept until the next evaluation, within the current bounds
+	metrics.queries = nil
+	now = now.Add(30 * time.Second)
+	assert.Same(t, status, autoscaler.Scale(context.TODO(), spec, status, testTarget()))
+	target := testTarget()
+	target.Prefill.MaxReplicas = 3
+	bounded := autoscaler.Scale(context.TODO(), spec, status, target)
+	assert.Equal(t, int32(3), bounded.PrefillReplicas)
+	assert.Equal(t, now, bounded.LastScaleTime.Time)
+	assert.Empty(t, metrics.queries)
+
+	// Failed queries keep the replicas
+	now = now.Add(time.Minute)
+	metrics.err = errors.New("prometheus unavailable")
+	next := autoscaler.Scale(context.TODO(), spec, status, testTarget())
+	assert.Equal(t, int32(4), next.PrefillReplicas)
+	assert.Equal(t, int32(2), next.DecodeReplicas)
+	assert.Equal(t, status.LastScaleTime, next.LastScaleTime)
+	assert.Equal(t, now.Add(time.Minute), next.NextEvaluationTime.Time)
+	assert.Contains(t, next.Message, "unknown waiting prefill requests")
+}
+
+func TestScaleVLLMQueries(t *testing.T) {
+	metrics := &fakeMetrics{}
+	autoscaler := &PDAutoscaler{
+		NewMetricsClient: func(string) (prometheus.MetricsClient, error) { return metrics, nil },
+		Now:              time.Now,
+	}
+	target := testTarget()
+	target.Prefill.RuntimeFamily = utils.RuntimeFamilyVLLM
+	target.Decode.RuntimeFamily = utils.RuntimeFamilyVLLM
+	status := autoscaler.Scale(context.TODO(), &v1beta1.PDAutoscalingSpec{},
+		&v1beta1.PDAutoscalingStatus{PrefillReplicas: 2, DecodeReplicas: 3, NextEvaluationTime: &metav1.Time{}}, target)
+	assert.Equal(t, int32(2), status.PrefillReplicas)
+	assert.Equal(t, int32(3), status.DecodeReplicas)
+	assert.Nil(t, status.LastScaleTime)
+	assert.Equal(t, []string{
+		`sum(vllm:num_requests_waiting{namespace="default",app="llama-engine"})`,
+		`sum(vllm:gpu_cache_usage_perc{namespace="default",app="llama-decoder"})`,
+	}, metrics.queries)
+}
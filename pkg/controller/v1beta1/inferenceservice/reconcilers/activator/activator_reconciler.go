@@ -0,0 +1,285 @@
+package activator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+var log = logf.Log.WithName("ActivatorReconciler")
+
+const (
+	activatorContainerName = "activator"
+	scalerPortName         = "scaler"
+)
+
+// ActivatorReconciler puts the activator in front of a component scaled to zero. While the component has no ready
+// replica, the component Service selects the activator pods, which hold requests until the component is scaled up and
+// proxy them to a private Service selecting the component pods. Once a replica is ready, the component Service selects
+// the component pods again, so the activator is only on the request path while scaling up from zero.
+type ActivatorReconciler struct {
+	client         client.Client
+	scheme         *runtime.Scheme
+	Deployment     *appsv1.Deployment
+	Service        *corev1.Service
+	PrivateService *corev1.Service
+}
+
+// NewActivatorReconciler creates the activator objects of a component, and points the component Service at the
+// activator pods unless the component has a ready replica
+func NewActivatorReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	service *corev1.Service,
+	config *controllerconfig.ActivatorConfig,
+	componentReady bool,
+) *ActivatorReconciler {
+	privateService := createPrivateService(service)
+	if !componentReady {
+		service.Spec.Selector = activatorLabels(componentMeta.Name)
+	}
+
+	return &ActivatorReconciler{
+		client:         client,
+		scheme:         scheme,
+		Deployment:     createDeployment(componentMeta, privateService, config),
+		Service:        createService(componentMeta),
+		PrivateService: privateService,
+	}
+}
+
+// ScalerAddress returns the address of the KEDA external scaler API served by the activator of the component
+func ScalerAddress(componentMeta metav1.ObjectMeta) string {
+	return fmt.Sprintf("%s.%s.%s:%d", activatorName(componentMeta.Name), componentMeta.Namespace,
+		constants.ClusterLocalDomain, constants.ActivatorScalerPort)
+}
+
+func activatorName(componentName string) string {
+	return constants.TruncateNameWithMaxLength(componentName+constants.ActivatorNameSuffix, 63)
+}
+
+func privateServiceName(serviceName string) string {
+	return constants.TruncateNameWithMaxLength(serviceName+constants.PrivateServiceNameSuffix, 63)
+}
+
+func activatorLabels(componentName string) map[string]string {
+	return map[string]string{constants.ActivatorLabelKey: constants.TruncateNameWithMaxLength(componentName, 63)}
+}
+
+// createPrivateService copies the component Service as a ClusterIP Service selecting the component pods. Each port
+// is exposed on its target port, which is also the port the activator listens on for it.
+func createPrivateService(service *corev1.Service) *corev1.Service {
+	private := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      privateServiceName(service.Name),
+			Namespace: service.Namespace,
+			Labels:    service.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: service.Spec.Selector,
+		},
+	}
+	for _, port := range service.Spec.Ports {
+		if port.TargetPort.Type != intstr.Int || (port.Protocol != "" && port.Protocol != corev1.ProtocolTCP) {
+			continue
+		}
+		private.Spec.Ports = append(private.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.TargetPort.IntVal,
+			TargetPort: port.TargetPort,
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+	return private
+}
+
+// createDeployment renders the activator Deployment. A single replica serves the external scaler API, so that KEDA
+// sees every request held for the component. It is only on the request path while the component has no ready replica.
+func createDeployment(componentMeta metav1.ObjectMeta, privateService *corev1.Service, config *controllerconfig.ActivatorConfig) *appsv1.Deployment {
+	labels := activatorLabels(componentMeta.Name)
+	ports := make([]string, 0, len(privateService.Spec.Ports))
+	containerPorts := make([]corev1.ContainerPort, 0, len(privateService.Spec.Ports)+1)
+	for _, port := range privateService.Spec.Ports {
+		ports = append(ports, strconv.Itoa(int(port.Port)))
+		containerPorts = append(containerPorts, corev1.ContainerPort{ContainerPort: port.Port, Protocol: corev1.ProtocolTCP})
+	}
+	containerPorts = append(containerPorts, corev1.ContainerPort{
+		Name:          scalerPortName,
+		ContainerPort: constants.ActivatorScalerPort,
+		Protocol:      corev1.ProtocolTCP,
+	})
+
+	args := []string{
+		"--backend", fmt.Sprintf("%s.%s.%s", privateService.Name, privateService.Namespace, constants.ClusterLocalDomain),
+		"--ports", strings.Join(ports, ","),
+		"--scaler-port", strconv.Itoa(constants.ActivatorScalerPort),
+		"--timeout", config.Timeout,
+	}
+	if config.MaxBodyBytes > 0 {
+		args = append(args, "--max-body-bytes", strconv.FormatInt(config.MaxBodyBytes, 10))
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      activatorName(componentMeta.Name),
+			Namespace: componentMeta.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: ptr.To(false),
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
+					},
+					Containers: []corev1.Container{{
+						Name:  activatorContainerName,
+						Image: config.Image,
+						Args:  args,
+						Ports: containerPorts,
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(constants.ActivatorScalerPort)},
+							},
+						},
+						Resources: activatorResources(config),
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							ReadOnlyRootFilesystem:   ptr.To(true),
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// createService renders the Service KEDA reaches the external scaler API of the activator through
+func createService(componentMeta metav1.ObjectMeta) *corev1.Service {
+	labels := activatorLabels(componentMeta.Name)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      activatorName(componentMeta.Name),
+			Namespace: componentMeta.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       scalerPortName,
+				Port:       constants.ActivatorScalerPort,
+				TargetPort: intstr.FromInt32(constants.ActivatorScalerPort),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
+
+// activatorResources returns the configured resources of the activator, skipping invalid quantities
+func activatorResources(config *controllerconfig.ActivatorConfig) corev1.ResourceRequirements {
+	requirements := corev1.ResourceRequirements{}
+	set := func(list *corev1.ResourceList, name corev1.ResourceName, value string) {
+		if value == "" {
+			return
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[name] = quantity
+	}
+	set(&requirements.Requests, corev1.ResourceCPU, config.CPURequest)
+	set(&requirements.Requests, corev1.ResourceMemory, config.MemoryRequest)
+	set(&requirements.Limits, corev1.ResourceCPU, config.CPULimit)
+	set(&requirements.Limits, corev1.ResourceMemory, config.MemoryLimit)
+	return requirements
+}
+
+// Reconcile creates or updates the activator Deployment, its Service and the private Service of the component
+func (r *ActivatorReconciler) Reconcile(ctx context.Context) error {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: r.Deployment.Name, Namespace: r.Deployment.Namespace}}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.client, deployment, func() error {
+		deployment.Labels = r.Deployment.Labels
+		deployment.OwnerReferences = r.Deployment.OwnerReferences
+		deployment.Spec = r.Deployment.Spec
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile activator deployment: %w", err)
+	}
+	log.Info("Reconciled activator deployment", "namespace", deployment.Namespace, "name", deployment.Name, "result", result)
+
+	for _, desired := range []*corev1.Service{r.Service, r.PrivateService} {
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		result, err := controllerutil.CreateOrUpdate(ctx, r.client, service, func() error {
+			service.Labels = desired.Labels
+			service.OwnerReferences = desired.OwnerReferences
+			service.Spec.Type = desired.Spec.Type
+			service.Spec.Selector = desired.Spec.Selector
+			service.Spec.Ports = desired.Spec.Ports
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile service %s: %w", desired.Name, err)
+		}
+		log.Info("Reconciled activator service", "namespace", service.Namespace, "name", service.Name, "result", result)
+	}
+	return nil
+}
+
+// SetControllerReferences sets the owner of the activator objects
+func (r *ActivatorReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	for _, obj := range []client.Object{r.Deployment, r.Service, r.PrivateService} {
+		if err := controllerutil.SetControllerReference(owner, obj, scheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the activator objects of a component once it no longer scales to zero
+func Delete(ctx context.Context, c client.Client, componentMeta metav1.ObjectMeta, serviceName string) error {
+	objects := []client.Object{
+		&appsv1.Deployment{},
+		&corev1.Service{},
+		&corev1.Service{},
+	}
+	names := []string{activatorName(componentMeta.Name), activatorName(componentMeta.Name), privateServiceName(serviceName)}
+	for i, obj := range objects {
+		err := c.Get(ctx, types.NamespacedName{Namespace: componentMeta.Namespace, Name: names[i]}, obj)
+		if apierr.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		log.Info("Deleting activator object", "namespace", componentMeta.Namespace, "name", names[i])
+		if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package activator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+func testService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-engine", Namespace: "default", Labels: map[string]string{"component": "engine"}},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: map[string]string{"app": "llama-engine"},
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+				{Name: "metrics", Port: 9090, TargetPort: intstr.FromString("metrics")},
+			},
+		},
+	}
+}
+
+func TestNewActivatorReconciler(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{Name: "llama-engine", Namespace: "default"}
+	service := testService()
+	config := &controllerconfig.ActivatorConfig{Image: "ome/activator:v1", Timeout: "5m", CPURequest: "100m", MemoryLimit: "bad"}
+
+	r := NewActivatorReconciler(nil, nil, componentMeta, service, config, false)
+
+	// The component Service selects the activator pods while the component has no ready replica
+	assert.Equal(t, map[string]string{constants.ActivatorLabelKey: "llama-engine"}, service.Spec.Selector)
+
+	// The private Service selects the component pods on the target ports
+	assert.Equal(t, "llama-engine-private", r.PrivateService.Name)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, r.PrivateService.Spec.Type)
+	assert.Equal(t, map[string]string{"app": "llama-engine"}, r.PrivateService.Spec.Selector)
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+	}, r.PrivateService.Spec.Ports)
+
+	assert.Equal(t, "llama-engine-activator", r.Deployment.Name)
+	container := r.Deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "ome/activator:v1", container.Image)
+	assert.Equal(t, []string{
+		"--backend", "llama-engine-private.default.svc.cluster.local",
+		"--ports", "8080",
+		"--scaler-port", "9095",
+		"--timeout", "5m",
+	}, container.Args)
+	assert.Equal(t, "100m", container.Resources.Requests.Cpu().String())
+	assert.Empty(t, container.Resources.Limits)
+
+	assert.Equal(t, "llama-engine-activator", r.Service.Name)
+	assert.Equal(t, "llama-engine-activator.default.svc.cluster.local:9095", ScalerAddress(componentMeta))
+}
+
+func TestNewActivatorReconcilerComponentReady(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{Name: "llama-engine", Namespace: "default"}
+	service := testService()
+	config := &controllerconfig.ActivatorConfig{Image: "ome/activator:v1", Timeout: "5m", MaxBodyBytes: 64 << 20}
+
+	r := NewActivatorReconciler(nil, nil, componentMeta, service, config, true)
+
+	// The component Service keeps selecting the component pods once a replica is ready
+	assert.Equal(t, map[string]string{"app": "llama-engine"}, service.Spec.Selector)
+	assert.Equal(t, map[string]string{"app": "llama-engine"}, r.PrivateService.Spec.Selector)
+	assert.Equal(t, []string{
+		"--backend", "llama-engine-private.default.svc.cluster.local",
+		"--ports", "8080",
+		"--scaler-port", "9095",
+		"--timeout", "5m",
+		"--max-body-bytes", "67108864",
+	}, r.Deployment.Spec.Template.Spec.Containers[0].Args)
+}
+
+func TestActivatorReconcileAndDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	componentMeta := metav1.ObjectMeta{Name: "llama-engine", Namespace: "default"}
+	r := NewActivatorReconciler(c, scheme, componentMeta, testService(),
+		&controllerconfig.ActivatorConfig{Image: "ome/activator:v1", Timeout: "5m"}, false)
+	require.NoError(t, r.Reconcile(ctx))
+	// Reconciling again updates the objects in place
+	require.NoError(t, r.Reconcile(ctx))
+
+	services := []string{"llama-engine-activator", "llama-engine-private"}
+	for _, name := range services {
+		assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &corev1.Service{}))
+	}
+	assert.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "llama-engine-activator"}, &appsv1.Deployment{}))
+
+	require.NoError(t, Delete(ctx, c, componentMeta, "llama-engine"))
+	for _, name := range services {
+		err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &corev1.Service{})
+		assert.True(t, apierr.IsNotFound(err))
+	}
+	err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "llama-engine-activator"}, &appsv1.Deployment{})
+	assert.True(t, apierr.IsNotFound(err))
+	// Nothing left to delete
+	assert.NoError(t, Delete(ctx, c, componentMeta, "llama-engine"))
+}
@@ -6,6 +6,7 @@ import (
 
 	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -54,8 +55,9 @@ func NewAutoscalerReconciler(
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	podSpec *corev1.PodSpec,
 ) (*AutoscalerReconciler, error) {
-	as, err := createAutoscaler(client, scheme, componentMeta, inferenceServiceSpec)
+	as, err := createAutoscaler(client, scheme, componentMeta, inferenceServiceSpec, podSpec)
 	if err != nil {
 		return nil, err
 	}
@@ -67,10 +69,17 @@ func NewAutoscalerReconciler(
 	}, err
 }
 
-func getAutoscalerClass(metadata metav1.ObjectMeta) constants.AutoscalerClassType {
+// getAutoscalerClass returns the autoscaler class set on the component, which defaults to KEDA for components
+// scaled to zero since HPA can not scale a Deployment to zero, and for components scaled on the serving engine
+// metrics since HPA only reads resource metrics
+func getAutoscalerClass(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) constants.AutoscalerClassType {
 	annotations := metadata.Annotations
 	if value, ok := annotations[constants.AutoscalerClass]; ok {
 		return constants.AutoscalerClassType(value)
+	} else if componentExt != nil && componentExt.MinReplicas != nil && *componentExt.MinReplicas == 0 {
+		return constants.AutoscalerClassKEDA
+	} else if componentExt != nil && componentExt.ScaleMetric != nil && componentExt.ScaleMetric.IsLLMMetric() {
+		return constants.AutoscalerClassKEDA
 	} else {
 		return constants.DefaultAutoscalerClass
 	}
@@ -79,15 +88,16 @@ func getAutoscalerClass(metadata metav1.ObjectMeta) constants.AutoscalerClassTyp
 func createAutoscaler(client client.Client,
 	scheme *runtime.Scheme, componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	podSpec *corev1.PodSpec,
 ) (Autoscaler, error) {
-	ac := getAutoscalerClass(componentMeta)
+	ac := getAutoscalerClass(componentMeta, &inferenceServiceSpec.Predictor.ComponentExtensionSpec)
 
 	switch ac {
 	// HPA and KEDA can not coexist for the same deployment
 	case constants.AutoscalerClassHPA, constants.AutoscalerClassExternal:
 		if scheme.IsGroupRegistered(kedav1.SchemeGroupVersion.Group) {
 			// Before creating HPA, ensure any existing ScaledObject is deleted
-			err := deleteExistingScaledObject(client, componentMeta)
+			err := DeleteExistingScaledObject(client, componentMeta)
 			if err != nil {
 				return nil, fmt.Errorf("failed to delete existing ScaledObject: %w", err)
 			}
@@ -99,14 +109,14 @@ func createAutoscaler(client client.Client,
 		if err != nil {
 			return nil, fmt.Errorf("failed to delete existing HPA: %w", err)
 		}
-		return keda.NewKEDAReconciler(client, scheme, componentMeta, inferenceServiceSpec)
+		return keda.NewKEDAReconciler(client, scheme, componentMeta, inferenceServiceSpec, utils.GetRuntimeFamily(podSpec))
 	default:
 		return nil, fmt.Errorf("unknown autoscaler class type: %v", ac)
 	}
 }
 
-// deleteExistingScaledObject deletes any existing ScaledObject for the Deployment
-func deleteExistingScaledObject(client client.Client, componentMeta metav1.ObjectMeta) error {
+// DeleteExistingScaledObject deletes any existing ScaledObject for the component
+func DeleteExistingScaledObject(client client.Client, componentMeta metav1.ObjectMeta) error {
 	scaledObjectName := utils.GetScaledObjectName(componentMeta.Name)
 	scaledObject := &kedav1.ScaledObject{}
 	err := client.Get(context.TODO(), types.NamespacedName{
@@ -11,9 +11,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
 )
 
@@ -23,6 +25,7 @@ func TestGetAutoscalerClass(t *testing.T) {
 	testCases := []struct {
 		name                   string
 		isvcMetaData           *metav1.ObjectMeta
+		componentExt           *v1beta1.ComponentExtensionSpec
 		expectedAutoScalerType constants.AutoscalerClassType
 	}{
 		{
@@ -54,11 +57,51 @@ func TestGetAutoscalerClass(t *testing.T) {
 			},
 			expectedAutoScalerType: constants.AutoscalerClassExternal,
 		},
+		{
+			name: "Return KEDA AutoScaler,if the component scales to zero",
+			isvcMetaData: &metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				Annotations: map[string]string{},
+			},
+			componentExt:           &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(0)},
+			expectedAutoScalerType: constants.AutoscalerClassKEDA,
+		},
+		{
+			name: "Return annotated AutoScaler,if the component scales to zero",
+			isvcMetaData: &metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				Annotations: map[string]string{"ome.io/autoscalerClass": "hpa"},
+			},
+			componentExt:           &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(0)},
+			expectedAutoScalerType: constants.AutoscalerClassHPA,
+		},
+		{
+			name: "Return KEDA AutoScaler,if the component scales on a serving engine metric",
+			isvcMetaData: &metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				Annotations: map[string]string{},
+			},
+			componentExt:           &v1beta1.ComponentExtensionSpec{ScaleMetric: ptr.To(v1beta1.MetricKVCache)},
+			expectedAutoScalerType: constants.AutoscalerClassKEDA,
+		},
+		{
+			name: "Return default AutoScaler,if the component scales on a resource metric",
+			isvcMetaData: &metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				Annotations: map[string]string{},
+			},
+			componentExt:           &v1beta1.ComponentExtensionSpec{ScaleMetric: ptr.To(v1beta1.MetricCPU)},
+			expectedAutoScalerType: constants.AutoscalerClassHPA,
+		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getAutoscalerClass(*tt.isvcMetaData)
+			result := getAutoscalerClass(*tt.isvcMetaData, tt.componentExt)
 			if diff := cmp.Diff(tt.expectedAutoScalerType, result); diff != "" {
 				t.Errorf("Test %q unexpected result (-want +got): %v", t.Name(), diff)
 			}
@@ -134,7 +177,7 @@ func TestDeleteExistingScaledObject(t *testing.T) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := tt.setupClient()
-			err := deleteExistingScaledObject(fakeClient, tt.componentMeta)
+			err := DeleteExistingScaledObject(fakeClient, tt.componentMeta)
 
 			if tt.expectError && err == nil {
 				t.Errorf("Test %q expected error but got nil", t.Name())
@@ -1,8 +1,11 @@
 package common
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -10,8 +13,12 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/canary"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/activator"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/knative"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinode"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinodevllm"
@@ -26,9 +33,12 @@ type DeploymentReconciler struct {
 	Scheme        *runtime.Scheme
 	StatusManager *status.StatusReconciler
 	Log           logr.Logger
+	// CanaryAnalyzer analyzes canaries of components with a canary analysis, defaults to querying Prometheus
+	CanaryAnalyzer *canary.Analyzer
 }
 
-// ReconcileRawDeployment handles raw Kubernetes deployment
+// ReconcileRawDeployment handles raw Kubernetes deployment. New revisions are rolled out through a canary
+// Deployment with its own Service when the component sets a canary traffic percent.
 func (r *DeploymentReconciler) ReconcileRawDeployment(
 	isvc *v1beta1.InferenceService,
 	objectMeta metav1.ObjectMeta,
@@ -44,25 +54,107 @@ func (r *DeploymentReconciler) ReconcileRawDeployment(
 		KedaConfig: isvc.Spec.KedaConfig,
 	}
 
+	revision, err := workloadRevision(objectMeta.Name, podSpec)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to compute %s revision", componentType)
+	}
+	canaryMeta := canaryObjectMeta(objectMeta)
+	stable := &appsv1.Deployment{}
+	stableExists, err := r.getWorkload(objectMeta.Namespace, objectMeta.Name, stable)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get %s deployment", componentType)
+	}
+	if !stableExists {
+		stable = nil
+	}
+	canary := &appsv1.Deployment{}
+	canaryExists, err := r.getWorkload(canaryMeta.Namespace, canaryMeta.Name, canary)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get %s canary deployment", componentType)
+	}
+	if !canaryExists {
+		canary = nil
+	}
+	stableApp := constants.TruncateNameWithMaxLength(objectMeta.Name, 63)
+	canaryApp := constants.TruncateNameWithMaxLength(canaryMeta.Name, 63)
+	canaryPercent, rolledBack := r.canaryTrafficPercent(isvc, objectMeta, componentSpec, componentType, revision,
+		deploymentState(stable), deploymentState(canary), stableApp, canaryApp, podSpec)
+	plan := planRollout(canaryPercent, rolledBack, revision, deploymentState(stable), deploymentState(canary))
+	rollout := status.RolloutStatus{LatestCreatedRevision: revision, CanaryPercent: plan.canaryPercent}
+
+	if plan.reconcileCanary {
+		canaryReconciler, err := raw.NewRawKubeReconciler(r.Client, r.Clientset, r.Scheme, canaryMeta, inferenceServiceSpec, podSpec.DeepCopy())
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create canary RawKubeReconciler for %s", componentType)
+		}
+		setWorkloadRevision(canaryReconciler.Deployment.Deployment, revision)
+		if err := r.setRawReferences(isvc, canaryReconciler); err != nil {
+			return ctrl.Result{}, err
+		}
+		canaryDeployment, err := canaryReconciler.Reconcile()
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s canary", componentType)
+		}
+		canaryState := deploymentState(canaryDeployment)
+		rollout.CanaryRevision, rollout.CanaryReady = canaryState.revision, canaryState.ready
+	}
+
 	reconciler, err := raw.NewRawKubeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, inferenceServiceSpec, podSpec)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create RawKubeReconciler for %s", componentType)
 	}
+	if plan.updateStable {
+		setWorkloadRevision(reconciler.Deployment.Deployment, revision)
+	} else {
+		// The stable Deployment keeps its revision while the canary is analyzed
+		reconciler.Deployment.Deployment.Spec.Template = stable.Spec.Template
+		setWorkloadRevision(reconciler.Deployment.Deployment, stable.Annotations[constants.WorkloadRevisionAnnotationKey])
+	}
+
+	activatorReconciler, err := r.rawActivator(isvc, objectMeta, componentSpec, reconciler,
+		stable != nil && stable.Status.ReadyReplicas > 0)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create activator for %s", componentType)
+	}
 
 	if err := r.setRawReferences(isvc, reconciler); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// The activator is up before the component Service selects it
+	if activatorReconciler != nil {
+		if err := activatorReconciler.Reconcile(context.TODO()); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s activator", componentType)
+		}
+	}
+
 	deployment, err := reconciler.Reconcile()
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s", componentType)
 	}
 
+	// The component Service selects the component pods again before the activator is deleted
+	if activatorReconciler == nil {
+		if err := activator.Delete(context.TODO(), r.Client, objectMeta, reconciler.Service.Service.Name); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete %s activator", componentType)
+		}
+	}
+
+	if plan.deleteCanary {
+		if err := r.deleteCanary(isvc, canaryMeta, canary); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete %s canary", componentType)
+		}
+	}
+
 	r.StatusManager.PropagateRawStatus(&isvc.Status, componentType, deployment, reconciler.URL)
+	stableState := deploymentState(deployment)
+	rollout.StableRevision, rollout.StableReady = stableState.revision, stableState.ready
+	r.StatusManager.PropagateRolloutStatus(&isvc.Status, componentType, rollout)
 	return ctrl.Result{}, nil
 }
 
-// ReconcileMultiNodeDeployment handles multi-node deployment using LeaderWorkerSet
+// ReconcileMultiNodeDeployment handles multi-node deployment using LeaderWorkerSet. New revisions are rolled
+// out through a canary LeaderWorkerSet with its own Service when the component sets a canary traffic percent.
 func (r *DeploymentReconciler) ReconcileMultiNodeDeployment(
 	isvc *v1beta1.InferenceService,
 	objectMeta metav1.ObjectMeta,
@@ -74,21 +166,109 @@ func (r *DeploymentReconciler) ReconcileMultiNodeDeployment(
 ) (ctrl.Result, error) {
 	r.Log.Info("Reconciling multi-node deployment", "component", componentType, "inferenceService", isvc.Name)
 
+	revision, err := workloadRevision(objectMeta.Name, leaderPodSpec, workerPodSpec, workerSize)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to compute %s revision", componentType)
+	}
+	canaryMeta := canaryObjectMeta(objectMeta)
+	stable := &lwsspec.LeaderWorkerSet{}
+	stableExists, err := r.getWorkload(objectMeta.Namespace, constants.LWSName(objectMeta.Name), stable)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get %s lws", componentType)
+	}
+	if !stableExists {
+		stable = nil
+	}
+	canary := &lwsspec.LeaderWorkerSet{}
+	canaryExists, err := r.getWorkload(canaryMeta.Namespace, constants.LWSName(canaryMeta.Name), canary)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get %s canary lws", componentType)
+	}
+	if !canaryExists {
+		canary = nil
+	}
+	canaryPercent, rolledBack := r.canaryTrafficPercent(isvc, objectMeta, componentSpec, componentType, revision,
+		lwsState(stable), lwsState(canary), constants.GetRawServiceLabel(objectMeta.Name), constants.GetRawServiceLabel(canaryMeta.Name),
+		leaderPodSpec)
+	plan := planRollout(canaryPercent, rolledBack, revision, lwsState(stable), lwsState(canary))
+	rollout := status.RolloutStatus{LatestCreatedRevision: revision, CanaryPercent: plan.canaryPercent}
+
+	if plan.reconcileCanary {
+		canaryReconciler, err := multinode.NewMultiNodeReconciler(r.Client, r.Clientset, r.Scheme, canaryMeta, componentSpec,
+			leaderPodSpec.DeepCopy(), workerSize, workerPodSpec.DeepCopy())
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create canary MultiNodeReconciler for %s", componentType)
+		}
+		setWorkloadRevision(canaryReconciler.LWS.LWS, revision)
+		if err := r.setMultiNodeReferences(isvc, canaryReconciler); err != nil {
+			return ctrl.Result{}, err
+		}
+		canaryLWS, err := canaryReconciler.Reconcile()
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s canary", componentType)
+		}
+		canaryState := lwsState(canaryLWS)
+		rollout.CanaryRevision, rollout.CanaryReady = canaryState.revision, canaryState.ready
+	}
+
 	reconciler, err := multinode.NewMultiNodeReconciler(r.Client, r.Clientset, r.Scheme, objectMeta, componentSpec, leaderPodSpec, workerSize, workerPodSpec)
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create MultiNodeReconciler for %s", componentType)
 	}
+	if plan.updateStable {
+		setWorkloadRevision(reconciler.LWS.LWS, revision)
+	} else {
+		// The stable LeaderWorkerSet keeps its revision while the canary is analyzed
+		reconciler.LWS.LWS.Spec.LeaderWorkerTemplate = stable.Spec.LeaderWorkerTemplate
+		setWorkloadRevision(reconciler.LWS.LWS, stable.Annotations[constants.WorkloadRevisionAnnotationKey])
+	}
+
+	activatorReconciler, scaler, err := r.multiNodeActivator(isvc, objectMeta, componentSpec, leaderPodSpec, reconciler,
+		stable != nil && stable.Status.ReadyReplicas > 0)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create activator for %s", componentType)
+	}
 
 	if err := r.setMultiNodeReferences(isvc, reconciler); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// The activator is up before the component Service selects it
+	if activatorReconciler != nil {
+		if err := activatorReconciler.Reconcile(context.TODO()); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s activator", componentType)
+		}
+	}
+
 	lws, err := reconciler.Reconcile()
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s", componentType)
 	}
 
+	if scaler != nil {
+		if _, err := scaler.Reconcile(); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile %s scaledobject", componentType)
+		}
+	} else {
+		if err := r.deleteMultiNodeScaler(objectMeta); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete %s scaledobject", componentType)
+		}
+		// The component Service selects the component pods again before the activator is deleted
+		if err := activator.Delete(context.TODO(), r.Client, objectMeta, reconciler.Service.Service.Name); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete %s activator", componentType)
+		}
+	}
+
+	if plan.deleteCanary {
+		if err := r.deleteCanary(isvc, canaryMeta, canary); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete %s canary", componentType)
+		}
+	}
+
 	r.StatusManager.PropagateMultiNodeStatus(&isvc.Status, componentType, lws, reconciler.URL)
+	stableState := lwsState(lws)
+	rollout.StableRevision, rollout.StableReady = stableState.revision, stableState.ready
+	r.StatusManager.PropagateRolloutStatus(&isvc.Status, componentType, rollout)
 	return ctrl.Result{}, nil
 }
 
@@ -164,6 +344,9 @@ func (r *DeploymentReconciler) setMultiNodeReferences(isvc *v1beta1.InferenceSer
 	if err != nil {
 		return errors.Wrapf(err, "failed to set lws owner reference")
 	}
+	if err := controllerutil.SetControllerReference(isvc, mnr.IstioSidecar.Sidecar, r.Scheme); err != nil {
+		return errors.Wrapf(err, "failed to set sidecar owner reference")
+	}
 	return controllerutil.SetControllerReference(isvc, mnr.Service.Service, r.Scheme)
 }
 
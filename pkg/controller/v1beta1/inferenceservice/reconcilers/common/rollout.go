@@ -0,0 +1,235 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/canary"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// workloadState is the state of the stable or canary workload of a component
+type workloadState struct {
+	exists   bool
+	revision string
+	// ready reports whether the workload finished rolling out its revision
+	ready bool
+}
+
+// rolloutPlan is what reconciliation does with the stable and canary workloads of a component
+type rolloutPlan struct {
+	// updateStable rolls the desired revision out to the stable workload
+	updateStable bool
+	// reconcileCanary creates or updates the canary workload with the desired revision
+	reconcileCanary bool
+	// deleteCanary deletes the canary workload and its service
+	deleteCanary bool
+	// canaryPercent is the percent of traffic routed to the canary workload
+	canaryPercent int64
+}
+
+// planRollout decides how the desired revision is rolled out. Without a canary traffic percent a new revision
+// updates the stable workload in place. With one, the revision is deployed as a canary workload that receives the
+// percent of traffic once it is ready. At 100 percent all traffic moves to the canary while the stable workload is
+// updated behind it, and the canary is deleted once the stable workload has rolled out the revision. A revision
+// rolled back by the canary analysis keeps the stable workload and deletes the canary.
+func planRollout(canaryTrafficPercent *int64, rolledBack bool, revision string, stable, canary workloadState) rolloutPlan {
+	plan := rolloutPlan{updateStable: true}
+	switch {
+	case !stable.exists || stable.revision == "":
+		// Workloads created before revisions were recorded are adopted in place
+		plan.deleteCanary = canary.exists
+	case stable.revision == revision:
+		// Keep serving from a canary of the same revision until the stable workload finishes rolling out
+		if canary.exists && canary.revision == revision && canary.ready && !stable.ready {
+			plan.reconcileCanary = true
+			plan.canaryPercent = 100
+		} else {
+			plan.deleteCanary = canary.exists
+		}
+	case rolledBack:
+		plan.updateStable = false
+		plan.deleteCanary = canary.exists
+	case canaryTrafficPercent == nil && !canary.exists:
+	default:
+		// Removing the canary traffic percent promotes the canary like in serverless mode
+		percent := int64(100)
+		if canaryTrafficPercent != nil {
+			percent = *canaryTrafficPercent
+		}
+		plan.updateStable = false
+		plan.reconcileCanary = true
+		if canary.exists && canary.revision == revision && canary.ready {
+			plan.canaryPercent = percent
+			plan.updateStable = percent == 100
+		}
+	}
+	return plan
+}
+
+// workloadRevision names the revision of a component after a hash of its pod specs
+func workloadRevision(name string, specs ...interface{}) (string, error) {
+	data, err := json.Marshal(specs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(sum[:])[:10]), nil
+}
+
+// setWorkloadRevision records the revision on a workload without sharing the annotations of its pod template
+func setWorkloadRevision(obj metav1.Object, revision string) {
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[constants.WorkloadRevisionAnnotationKey] = revision
+	obj.SetAnnotations(annotations)
+}
+
+// canaryObjectMeta returns the metadata of the canary workload of a component
+func canaryObjectMeta(objectMeta metav1.ObjectMeta) metav1.ObjectMeta {
+	canaryMeta := *objectMeta.DeepCopy()
+	canaryMeta.Name = constants.CanaryServiceName(objectMeta.Name)
+	return canaryMeta
+}
+
+func deploymentState(deployment *appsv1.Deployment) workloadState {
+	if deployment == nil {
+		return workloadState{}
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return workloadState{
+		exists:   true,
+		revision: deployment.Annotations[constants.WorkloadRevisionAnnotationKey],
+		ready: deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == replicas &&
+			deployment.Status.Replicas == replicas &&
+			deployment.Status.AvailableReplicas == replicas,
+	}
+}
+
+func lwsState(lws *lwsspec.LeaderWorkerSet) workloadState {
+	if lws == nil {
+		return workloadState{}
+	}
+	replicas := int32(1)
+	if lws.Spec.Replicas != nil {
+		replicas = *lws.Spec.Replicas
+	}
+	return workloadState{
+		exists:   true,
+		revision: lws.Annotations[constants.WorkloadRevisionAnnotationKey],
+		ready: meta.IsStatusConditionTrue(lws.Status.Conditions, string(lwsspec.LeaderWorkerSetAvailable)) &&
+			lws.Status.UpdatedReplicas == replicas &&
+			lws.Status.ReadyReplicas == replicas,
+	}
+}
+
+// canaryTrafficPercent returns the canary traffic percent of a component. When the component configures a canary
+// analysis the percent is stepped by the analysis, which also reports whether it rolled the canary back.
+func (r *DeploymentReconciler) canaryTrafficPercent(
+	isvc *v1beta1.InferenceService,
+	objectMeta metav1.ObjectMeta,
+	componentSpec *v1beta1.ComponentExtensionSpec,
+	componentType v1beta1.ComponentType,
+	revision string,
+	stable, canaryWorkload workloadState,
+	stableApp, canaryApp string,
+	podSpec *v1.PodSpec,
+) (*int64, bool) {
+	if componentSpec.CanaryAnalysis == nil {
+		// Drop the state of an analysis that was removed from the spec
+		if componentStatus, ok := isvc.Status.Components[componentType]; ok && componentStatus.CanaryAnalysis != nil {
+			componentStatus.CanaryAnalysis = nil
+			isvc.Status.Components[componentType] = componentStatus
+		}
+		return componentSpec.CanaryTrafficPercent, false
+	}
+
+	analyzer := r.CanaryAnalyzer
+	if analyzer == nil {
+		analyzer = canary.NewAnalyzer()
+	}
+	// Routers do not export the serving engine metrics the default queries read
+	var runtimeFamily utils.RuntimeFamily
+	if componentType != v1beta1.RouterComponent {
+		runtimeFamily, _ = utils.DetectRuntimeFamily(podSpec)
+	}
+	decision := analyzer.Analyze(context.TODO(), isvc, componentSpec, canary.Target{
+		Component:         componentType,
+		Namespace:         objectMeta.Namespace,
+		Revision:          revision,
+		StableRevision:    stable.revision,
+		StableApp:         stableApp,
+		CanaryApp:         canaryApp,
+		CanaryReady:       canaryWorkload.exists && canaryWorkload.revision == revision && canaryWorkload.ready,
+		RuntimeFamily:     runtimeFamily,
+		PromServerAddress: keda.GetPrometheusServerAddress(objectMeta, isvc.Spec.KedaConfig),
+	})
+	return decision.TrafficPercent, decision.RolledBack
+}
+
+// getWorkload gets a workload, returning false when it does not exist
+func (r *DeploymentReconciler) getWorkload(namespace, name string, obj client.Object) (bool, error) {
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// deleteCanary deletes the canary workload of a component with the service, autoscaler and disruption budget
+// created for it
+func (r *DeploymentReconciler) deleteCanary(isvc *v1beta1.InferenceService, canaryMeta metav1.ObjectMeta, workload client.Object) error {
+	objects := []client.Object{
+		workload,
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: canaryMeta.Namespace, Name: canaryMeta.Name}},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Namespace: canaryMeta.Namespace, Name: canaryMeta.Name}},
+		&kedav1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Namespace: canaryMeta.Namespace, Name: utils.GetScaledObjectName(canaryMeta.Name)}},
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Namespace: canaryMeta.Namespace, Name: canaryMeta.Name}},
+		&istioclientv1beta1.Sidecar{ObjectMeta: metav1.ObjectMeta{Namespace: canaryMeta.Namespace, Name: canaryMeta.Name}},
+	}
+	for _, obj := range objects {
+		if err := r.Client.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj); err != nil {
+			// Autoscaler and sidecar types are only registered when their CRDs are installed
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, isvc) {
+			continue
+		}
+		r.Log.Info("Deleting canary", "namespace", obj.GetNamespace(), "name", obj.GetName(), "type", fmt.Sprintf("%T", obj))
+		if err := r.Client.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
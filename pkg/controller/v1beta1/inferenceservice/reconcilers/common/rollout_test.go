@@ -0,0 +1,337 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/canary"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+	"github.com/sgl-project/ome/pkg/prometheus"
+)
+
+func TestPlanRollout(t *testing.T) {
+	ready := func(revision string) workloadState {
+		return workloadState{exists: true, revision: revision, ready: true}
+	}
+	rolling := func(revision string) workloadState {
+		return workloadState{exists: true, revision: revision}
+	}
+
+	tests := []struct {
+		name                 string
+		canaryTrafficPercent *int64
+		rolledBack           bool
+		revision             string
+		stable               workloadState
+		canary               workloadState
+		expected             rolloutPlan
+	}{
+		{
+			name:     "first revision",
+			expected: rolloutPlan{updateStable: true},
+		},
+		{
+			name:     "stable without recorded revision is adopted",
+			stable:   ready(""),
+			expected: rolloutPlan{updateStable: true},
+		},
+		{
+			name:     "new revision without canary updates stable in place",
+			stable:   ready("llama-1"),
+			expected: rolloutPlan{updateStable: true},
+		},
+		{
+			name:                 "canary is created without traffic",
+			canaryTrafficPercent: ptr.To(int64(10)),
+			stable:               ready("llama-1"),
+			expected:             rolloutPlan{reconcileCanary: true},
+		},
+		{
+			name:                 "canary not ready receives no traffic",
+			canaryTrafficPercent: ptr.To(int64(10)),
+			stable:               ready("llama-1"),
+			canary:               rolling("llama-2"),
+			expected:             rolloutPlan{reconcileCanary: true},
+		},
+		{
+			name:                 "ready canary receives its percent",
+			canaryTrafficPercent: ptr.To(int64(10)),
+			stable:               ready("llama-1"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{reconcileCanary: true, canaryPercent: 10},
+		},
+		{
+			name:                 "canary of an older revision is updated",
+			canaryTrafficPercent: ptr.To(int64(10)),
+			stable:               ready("llama-1"),
+			canary:               ready("llama-3"),
+			expected:             rolloutPlan{reconcileCanary: true},
+		},
+		{
+			name:                 "full traffic switches to canary and updates stable",
+			canaryTrafficPercent: ptr.To(int64(100)),
+			stable:               ready("llama-1"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{updateStable: true, reconcileCanary: true, canaryPercent: 100},
+		},
+		{
+			name:     "removing the canary percent promotes the canary",
+			stable:   ready("llama-1"),
+			canary:   ready("llama-2"),
+			expected: rolloutPlan{updateStable: true, reconcileCanary: true, canaryPercent: 100},
+		},
+		{
+			name:                 "canary serves until stable rolls out",
+			canaryTrafficPercent: ptr.To(int64(100)),
+			stable:               rolling("llama-2"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{updateStable: true, reconcileCanary: true, canaryPercent: 100},
+		},
+		{
+			name:                 "canary is deleted once stable rolls out",
+			canaryTrafficPercent: ptr.To(int64(100)),
+			stable:               ready("llama-2"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{updateStable: true, deleteCanary: true},
+		},
+		{
+			name:                 "reverting to the stable revision deletes the canary",
+			canaryTrafficPercent: ptr.To(int64(10)),
+			revision:             "llama-1",
+			stable:               ready("llama-1"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{updateStable: true, deleteCanary: true},
+		},
+		{
+			name:                 "rolled back canary is deleted and stable is kept",
+			canaryTrafficPercent: ptr.To(int64(30)),
+			rolledBack:           true,
+			stable:               ready("llama-1"),
+			canary:               ready("llama-2"),
+			expected:             rolloutPlan{deleteCanary: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision := tt.revision
+			if revision == "" {
+				revision = "llama-2"
+			}
+			assert.Equal(t, tt.expected, planRollout(tt.canaryTrafficPercent, tt.rolledBack, revision, tt.stable, tt.canary))
+		})
+	}
+}
+
+func newRolloutTestReconciler(t *testing.T, objs ...client.Object) *DeploymentReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local",
+				"ingressDomain": "svc.cluster.local",
+				"domainTemplate": "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
+			}`,
+		},
+	})
+	return &DeploymentReconciler{
+		Client:        fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Clientset:     clientset,
+		Scheme:        scheme,
+		StatusManager: status.NewStatusReconciler(),
+		Log:           ctrl.Log.WithName("test"),
+	}
+}
+
+func rolloutTestPodSpec(image string) *v1.PodSpec {
+	return &v1.PodSpec{Containers: []v1.Container{{Name: constants.MainContainerName, Image: image}}}
+}
+
+func rolloutTestObjectMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        "llama",
+		Namespace:   "default",
+		Labels:      map[string]string{constants.OMEComponentLabel: string(v1beta1.EngineComponent)},
+		Annotations: map[string]string{},
+	}
+}
+
+// markDeploymentReady reports every replica of a Deployment as updated and available
+func markDeploymentReady(t *testing.T, c client.Client, name string) {
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, deployment))
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  1,
+		Conditions:         []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue}},
+	}
+	require.NoError(t, c.Status().Update(context.TODO(), deployment))
+}
+
+func getDeploymentImage(t *testing.T, c client.Client, name string) string {
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, deployment))
+	return deployment.Spec.Template.Spec.Containers[0].Image
+}
+
+func TestReconcileRawDeploymentCanary(t *testing.T) {
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"}}
+	r := newRolloutTestReconciler(t, isvc)
+	componentSpec := &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(1), MaxReplicas: 1}
+	reconcile := func(image string) {
+		_, err := r.ReconcileRawDeployment(isvc, rolloutTestObjectMeta(), rolloutTestPodSpec(image), componentSpec, v1beta1.EngineComponent)
+		require.NoError(t, err)
+	}
+
+	// The first revision is rolled out to the stable Deployment
+	reconcile("sglang:v1")
+	markDeploymentReady(t, r.Client, "llama")
+	reconcile("sglang:v1")
+	engineStatus := isvc.Status.Components[v1beta1.EngineComponent]
+	stableRevision := engineStatus.LatestRolledoutRevision
+	require.NotEmpty(t, stableRevision)
+
+	// A new revision with a canary percent is deployed as a canary next to the stable Deployment
+	componentSpec.CanaryTrafficPercent = ptr.To(int64(20))
+	reconcile("sglang:v2")
+	assert.Equal(t, "sglang:v1", getDeploymentImage(t, r.Client, "llama"))
+	assert.Equal(t, "sglang:v2", getDeploymentImage(t, r.Client, "llama-canary"))
+	require.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-canary"}, &v1.Service{}))
+	percent, ok := isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), percent)
+
+	markDeploymentReady(t, r.Client, "llama-canary")
+	reconcile("sglang:v2")
+	percent, _ = isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.Equal(t, int64(20), percent)
+	engineStatus = isvc.Status.Components[v1beta1.EngineComponent]
+	assert.Equal(t, stableRevision, engineStatus.LatestRolledoutRevision)
+	assert.NotEqual(t, stableRevision, engineStatus.LatestReadyRevision)
+
+	// Full traffic moves to the canary while the stable Deployment is updated behind it
+	componentSpec.CanaryTrafficPercent = ptr.To(int64(100))
+	reconcile("sglang:v2")
+	assert.Equal(t, "sglang:v2", getDeploymentImage(t, r.Client, "llama"))
+	percent, _ = isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.Equal(t, int64(100), percent)
+
+	// The canary is deleted once the stable Deployment rolled out the revision
+	markDeploymentReady(t, r.Client, "llama")
+	reconcile("sglang:v2")
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-canary"}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-canary"}, &v1.Service{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, ok = isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.False(t, ok)
+	engineStatus = isvc.Status.Components[v1beta1.EngineComponent]
+	assert.Equal(t, stableRevision, engineStatus.PreviousRolledoutRevision)
+	assert.Equal(t, engineStatus.LatestCreatedRevision, engineStatus.LatestRolledoutRevision)
+}
+
+// rolloutTestMetrics reports a canary error rate to the canary analysis
+type rolloutTestMetrics struct {
+	canaryErrorRate float64
+}
+
+func (m *rolloutTestMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	if strings.Contains(query, `app="llama-canary"`) {
+		return m.canaryErrorRate, true, nil
+	}
+	return 0.01, true, nil
+}
+
+func TestReconcileRawDeploymentCanaryAnalysis(t *testing.T) {
+	now := time.Now()
+	metrics := &rolloutTestMetrics{canaryErrorRate: 0.01}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"}}
+	r := newRolloutTestReconciler(t, isvc)
+	r.CanaryAnalyzer = &canary.Analyzer{
+		NewMetricsClient: func(string) (prometheus.MetricsClient, error) { return metrics, nil },
+		Now:              func() time.Time { return now },
+	}
+	componentSpec := &v1beta1.ComponentExtensionSpec{
+		MinReplicas: ptr.To(1),
+		MaxReplicas: 1,
+		CanaryAnalysis: &v1beta1.CanaryAnalysisSpec{
+			StepPercent:          50,
+			IntervalSeconds:      60,
+			MaxErrorRateIncrease: "0.01",
+		},
+	}
+	reconcile := func(image string) *v1beta1.CanaryAnalysisStatus {
+		_, err := r.ReconcileRawDeployment(isvc, rolloutTestObjectMeta(), rolloutTestPodSpec(image), componentSpec, v1beta1.EngineComponent)
+		require.NoError(t, err)
+		return isvc.Status.Components[v1beta1.EngineComponent].CanaryAnalysis
+	}
+
+	// The first revision is rolled out without analysis
+	assert.Nil(t, reconcile("sglang:v1"))
+	markDeploymentReady(t, r.Client, "llama")
+	reconcile("sglang:v1")
+
+	// A new revision starts as a canary at the step percent once it is ready
+	analysis := reconcile("sglang:v2")
+	require.NotNil(t, analysis)
+	assert.Equal(t, v1beta1.CanaryAnalysisProgressing, analysis.Phase)
+	markDeploymentReady(t, r.Client, "llama-canary")
+	reconcile("sglang:v2")
+	percent, _ := isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.Equal(t, int64(50), percent)
+
+	// A healthy canary is promoted after the last step and the stable Deployment is updated behind it
+	now = now.Add(2 * time.Minute)
+	analysis = reconcile("sglang:v2")
+	assert.Equal(t, v1beta1.CanaryAnalysisPromoted, analysis.Phase)
+	assert.Equal(t, "sglang:v2", getDeploymentImage(t, r.Client, "llama"))
+	markDeploymentReady(t, r.Client, "llama")
+	reconcile("sglang:v2")
+	_, ok := isvc.Status.GetCanaryTrafficPercent(v1beta1.EngineComponent)
+	assert.False(t, ok)
+
+	// A canary with a higher error rate is rolled back and the stable Deployment keeps serving
+	metrics.canaryErrorRate = 0.2
+	reconcile("sglang:v3")
+	markDeploymentReady(t, r.Client, "llama-canary")
+	now = now.Add(2 * time.Minute)
+	analysis = reconcile("sglang:v3")
+	assert.Equal(t, v1beta1.CanaryAnalysisRolledBack, analysis.Phase)
+	assert.Equal(t, "sglang:v2", getDeploymentImage(t, r.Client, "llama"))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-canary"}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+	condition := isvc.Status.GetCondition(v1beta1.EngineCanaryAnalysis)
+	require.NotNil(t, condition)
+	assert.Equal(t, v1.ConditionFalse, condition.Status)
+
+	// The rolled back revision is not deployed again
+	reconcile("sglang:v3")
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-canary"}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
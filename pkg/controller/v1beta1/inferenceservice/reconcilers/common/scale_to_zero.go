@@ -0,0 +1,110 @@
+package common
+
+import (
+	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/activator"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinode"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/raw"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// activatorConfig returns the activator configuration of a component scaled to zero. It is nil when the component
+// keeps at least one replica, or when no activator image is configured and the component keeps one replica instead.
+func (r *DeploymentReconciler) activatorConfig(componentSpec *v1beta1.ComponentExtensionSpec) (*controllerconfig.ActivatorConfig, error) {
+	if componentSpec.MinReplicas == nil || *componentSpec.MinReplicas != 0 {
+		return nil, nil
+	}
+	config, err := controllerconfig.NewActivatorConfig(r.Clientset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get activator config")
+	}
+	if config.Image == "" {
+		r.Log.Info("No activator image is configured, the component is not scaled up from zero on demand")
+		return nil, nil
+	}
+	return config, nil
+}
+
+// rawActivator puts the activator in front of a RawDeployment component scaled to zero by KEDA, and lets KEDA scale
+// the Deployment up on the requests held by the activator. The activator only receives requests while the Deployment
+// has no ready replica.
+func (r *DeploymentReconciler) rawActivator(
+	isvc *v1beta1.InferenceService,
+	objectMeta metav1.ObjectMeta,
+	componentSpec *v1beta1.ComponentExtensionSpec,
+	reconciler *raw.RawKubeReconciler,
+	componentReady bool,
+) (*activator.ActivatorReconciler, error) {
+	config, err := r.activatorConfig(componentSpec)
+	if err != nil || config == nil {
+		return nil, err
+	}
+	scaler, ok := reconciler.Scaler.Autoscaler.(*keda.KEDAReconciler)
+	if !ok {
+		r.Log.Info("Only the KEDA autoscaler scales a component to zero", "inferenceService", isvc.Name, "component", objectMeta.Name)
+		return nil, nil
+	}
+	activatorReconciler := activator.NewActivatorReconciler(r.Client, r.Scheme, objectMeta, reconciler.Service.Service, config, componentReady)
+	scaler.ScaleFromZero(activator.ScalerAddress(objectMeta))
+	if err := activatorReconciler.SetControllerReferences(isvc, r.Scheme); err != nil {
+		return nil, errors.Wrapf(err, "failed to set activator owner reference")
+	}
+	return activatorReconciler, nil
+}
+
+// multiNodeActivator puts the activator in front of a MultiNode component scaled to zero, and returns the KEDA
+// scaler of its LeaderWorkerSet. The activator only receives requests while the LeaderWorkerSet has no ready group.
+func (r *DeploymentReconciler) multiNodeActivator(
+	isvc *v1beta1.InferenceService,
+	objectMeta metav1.ObjectMeta,
+	componentSpec *v1beta1.ComponentExtensionSpec,
+	leaderPodSpec *v1.PodSpec,
+	reconciler *multinode.MultiNodeReconciler,
+	componentReady bool,
+) (*activator.ActivatorReconciler, *keda.KEDAReconciler, error) {
+	config, err := r.activatorConfig(componentSpec)
+	if err != nil || config == nil {
+		return nil, nil, err
+	}
+	if !r.Scheme.IsGroupRegistered(kedav1.SchemeGroupVersion.Group) {
+		r.Log.Info("KEDA is not installed, keeping the component scaled up", "inferenceService", isvc.Name, "component", objectMeta.Name)
+		return nil, nil, nil
+	}
+	scaler, err := keda.NewKEDAReconciler(r.Client, r.Scheme, objectMeta, &v1beta1.InferenceServiceSpec{
+		Predictor:  v1beta1.PredictorSpec{ComponentExtensionSpec: *componentSpec},
+		KedaConfig: isvc.Spec.KedaConfig,
+	}, utils.GetRuntimeFamily(leaderPodSpec))
+	if err != nil {
+		return nil, nil, err
+	}
+	scaler.SetScaleTarget(lwsspec.GroupVersion.String(), constants.LWSKind, constants.LWSName(objectMeta.Name))
+	scaler.ScaleFromZero(activator.ScalerAddress(objectMeta))
+	if err := scaler.SetControllerReferences(isvc, r.Scheme); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to set scaledobject owner reference")
+	}
+	reconciler.LWS.PreserveReplicas()
+
+	activatorReconciler := activator.NewActivatorReconciler(r.Client, r.Scheme, objectMeta, reconciler.Service.Service, config, componentReady)
+	if err := activatorReconciler.SetControllerReferences(isvc, r.Scheme); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to set activator owner reference")
+	}
+	return activatorReconciler, scaler, nil
+}
+
+// deleteMultiNodeScaler deletes the KEDA scaler of a MultiNode component that no longer scales to zero
+func (r *DeploymentReconciler) deleteMultiNodeScaler(objectMeta metav1.ObjectMeta) error {
+	if !r.Scheme.IsGroupRegistered(kedav1.SchemeGroupVersion.Group) {
+		return nil
+	}
+	return autoscaler.DeleteExistingScaledObject(r.Client, objectMeta)
+}
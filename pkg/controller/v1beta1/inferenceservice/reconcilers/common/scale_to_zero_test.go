@@ -0,0 +1,87 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+)
+
+func TestReconcileRawDeploymentScaleToZero(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, kedav1.AddToScheme(scheme))
+
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"}}
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local",
+				"ingressDomain": "svc.cluster.local",
+				"domainTemplate": "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
+			}`,
+			"activator": `{"image": "ome/activator:v1", "timeout": "5m"}`,
+		},
+	})
+	r := &DeploymentReconciler{
+		Client:        fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(isvc).Build(),
+		Clientset:     clientset,
+		Scheme:        scheme,
+		StatusManager: status.NewStatusReconciler(),
+		Log:           ctrl.Log.WithName("test"),
+	}
+	podSpec := rolloutTestPodSpec("sglang:v1")
+	podSpec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: 8080, Protocol: v1.ProtocolTCP}}
+	componentSpec := &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(0), MaxReplicas: 3}
+	reconcile := func() {
+		_, err := r.ReconcileRawDeployment(isvc, rolloutTestObjectMeta(), podSpec.DeepCopy(), componentSpec, v1beta1.EngineComponent)
+		require.NoError(t, err)
+	}
+	get := func(name string, obj client.Object) error {
+		return r.Client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, obj)
+	}
+
+	// The component scaled to zero is fronted by the activator and scaled by KEDA on the held requests
+	reconcile()
+	scaledObject := &kedav1.ScaledObject{}
+	require.NoError(t, get("scaledobject-llama", scaledObject))
+	assert.Equal(t, int32(0), *scaledObject.Spec.MinReplicaCount)
+	assert.Equal(t, "external-push", scaledObject.Spec.Triggers[len(scaledObject.Spec.Triggers)-1].Type)
+	require.NoError(t, get("llama-activator", &appsv1.Deployment{}))
+	service := &v1.Service{}
+	require.NoError(t, get("llama", service))
+	assert.Equal(t, map[string]string{constants.ActivatorLabelKey: "llama"}, service.Spec.Selector)
+	private := &v1.Service{}
+	require.NoError(t, get("llama-private", private))
+	assert.Equal(t, map[string]string{"app": "llama"}, private.Spec.Selector)
+
+	// The activator is removed once the component keeps a replica
+	componentSpec.MinReplicas = ptr.To(1)
+	reconcile()
+	require.NoError(t, get("llama", service))
+	assert.Equal(t, map[string]string{"app": "llama"}, service.Spec.Selector)
+	assert.True(t, apierrors.IsNotFound(get("llama-activator", &appsv1.Deployment{})))
+	assert.True(t, apierrors.IsNotFound(get("llama-private", &v1.Service{})))
+	assert.True(t, apierrors.IsNotFound(get("scaledobject-llama", &kedav1.ScaledObject{})))
+}
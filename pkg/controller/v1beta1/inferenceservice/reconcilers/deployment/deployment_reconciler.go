@@ -111,6 +111,10 @@ func (r *DeploymentReconciler) checkDeploymentExist() (constants.CheckResultType
 		log.Info("Deployments differ", "namespace", r.Deployment.Namespace, "name", r.Deployment.Name, "diff", diff)
 		return constants.CheckResultUpdate, existingDeployment, nil
 	}
+	if r.Deployment.Annotations[constants.WorkloadRevisionAnnotationKey] != existingDeployment.Annotations[constants.WorkloadRevisionAnnotationKey] {
+		log.Info("Deployment revisions differ", "namespace", r.Deployment.Namespace, "name", r.Deployment.Name)
+		return constants.CheckResultUpdate, existingDeployment, nil
+	}
 	return constants.CheckResultExisted, existingDeployment, nil
 }
 
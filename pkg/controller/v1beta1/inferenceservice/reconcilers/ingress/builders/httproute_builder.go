@@ -104,23 +104,8 @@ func (b *HTTPRouteBuilder) addIsvcHeaders(name string, namespace string) gateway
 }
 
 func (b *HTTPRouteBuilder) createHTTPRouteRule(routeMatches []gatewayapiv1.HTTPRouteMatch, filters []gatewayapiv1.HTTPRouteFilter,
-	serviceName, namespace string, port int32, timeout *gatewayapiv1.Duration,
+	backendRefs []gatewayapiv1.HTTPBackendRef, timeout *gatewayapiv1.Duration,
 ) gatewayapiv1.HTTPRouteRule {
-	var backendRefs []gatewayapiv1.HTTPBackendRef
-	if serviceName != "" {
-		backendRefs = []gatewayapiv1.HTTPBackendRef{
-			{
-				BackendRef: gatewayapiv1.BackendRef{
-					BackendObjectReference: gatewayapiv1.BackendObjectReference{
-						Kind:      ptr.To(gatewayapiv1.Kind(constants.ServiceKind)),
-						Name:      gatewayapiv1.ObjectName(serviceName),
-						Namespace: (*gatewayapiv1.Namespace)(&namespace),
-						Port:      (*gatewayapiv1.PortNumber)(&port),
-					},
-				},
-			},
-		}
-	}
 	return gatewayapiv1.HTTPRouteRule{
 		Matches:     routeMatches,
 		Filters:     filters,
@@ -131,6 +116,42 @@ func (b *HTTPRouteBuilder) createHTTPRouteRule(routeMatches []gatewayapiv1.HTTPR
 	}
 }
 
+// createHTTPBackendRefs returns the service of a component, weighted against the service of its canary workload
+// when the component is rolled out with a canary
+func (b *HTTPRouteBuilder) createHTTPBackendRefs(isvc *v1beta1.InferenceService, component v1beta1.ComponentType,
+	serviceName, namespace string, port int32,
+) []gatewayapiv1.HTTPBackendRef {
+	if serviceName == "" {
+		return nil
+	}
+	canaryPercent, ok := isvc.Status.GetCanaryTrafficPercent(component)
+	switch {
+	case !ok || canaryPercent <= 0:
+		return []gatewayapiv1.HTTPBackendRef{b.createHTTPBackendRef(serviceName, namespace, port, nil)}
+	case canaryPercent >= 100:
+		return []gatewayapiv1.HTTPBackendRef{b.createHTTPBackendRef(constants.CanaryServiceName(serviceName), namespace, port, nil)}
+	default:
+		return []gatewayapiv1.HTTPBackendRef{
+			b.createHTTPBackendRef(serviceName, namespace, port, ptr.To(int32(100-canaryPercent))),
+			b.createHTTPBackendRef(constants.CanaryServiceName(serviceName), namespace, port, ptr.To(int32(canaryPercent))),
+		}
+	}
+}
+
+func (b *HTTPRouteBuilder) createHTTPBackendRef(serviceName, namespace string, port int32, weight *int32) gatewayapiv1.HTTPBackendRef {
+	return gatewayapiv1.HTTPBackendRef{
+		BackendRef: gatewayapiv1.BackendRef{
+			BackendObjectReference: gatewayapiv1.BackendObjectReference{
+				Kind:      ptr.To(gatewayapiv1.Kind(constants.ServiceKind)),
+				Name:      gatewayapiv1.ObjectName(serviceName),
+				Namespace: (*gatewayapiv1.Namespace)(&namespace),
+				Port:      (*gatewayapiv1.PortNumber)(&port),
+			},
+			Weight: weight,
+		},
+	}
+}
+
 func (b *HTTPRouteBuilder) buildEngineHTTPRoute(isvc *v1beta1.InferenceService) (*gatewayapiv1.HTTPRoute, error) {
 	if !isvc.Status.IsConditionReady(v1beta1.EngineReady) {
 		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
@@ -158,7 +179,7 @@ func (b *HTTPRouteBuilder) buildEngineHTTPRoute(isvc *v1beta1.InferenceService)
 	}
 
 	httpRouteRules := []gatewayapiv1.HTTPRouteRule{
-		b.createHTTPRouteRule(routeMatch, filters, engineName, isvc.Namespace, constants.CommonISVCPort, timeout),
+		b.createHTTPRouteRule(routeMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.EngineComponent, engineName, isvc.Namespace, constants.CommonISVCPort), timeout),
 	}
 
 	return b.buildHTTPRouteResource(isvc, constants.PredictorServiceName(isvc.Name), allowedHosts, httpRouteRules), nil
@@ -191,7 +212,7 @@ func (b *HTTPRouteBuilder) buildRouterHTTPRoute(isvc *v1beta1.InferenceService)
 	}
 
 	httpRouteRules := []gatewayapiv1.HTTPRouteRule{
-		b.createHTTPRouteRule(routeMatch, filters, routerName, isvc.Namespace, constants.CommonISVCPort, timeout),
+		b.createHTTPRouteRule(routeMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.RouterComponent, routerName, isvc.Namespace, constants.CommonISVCPort), timeout),
 	}
 
 	return b.buildHTTPRouteResource(isvc, constants.RouterServiceName(isvc.Name), allowedHosts, httpRouteRules), nil
@@ -224,7 +245,7 @@ func (b *HTTPRouteBuilder) buildDecoderHTTPRoute(isvc *v1beta1.InferenceService)
 	}
 
 	httpRouteRules := []gatewayapiv1.HTTPRouteRule{
-		b.createHTTPRouteRule(routeMatch, filters, decoderName, isvc.Namespace, constants.CommonISVCPort, timeout),
+		b.createHTTPRouteRule(routeMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.DecoderComponent, decoderName, isvc.Namespace, constants.CommonISVCPort), timeout),
 	}
 
 	return b.buildHTTPRouteResource(isvc, constants.DecoderServiceName(isvc.Name), allowedHosts, httpRouteRules), nil
@@ -285,7 +306,7 @@ func (b *HTTPRouteBuilder) buildTopLevelHTTPRoute(isvc *v1beta1.InferenceService
 			timeout = toGatewayAPIDuration(*isvc.Spec.Decoder.TimeoutSeconds)
 		}
 		explainRouteMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(constants.DecoderPrefix())}
-		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(explainRouteMatch, filters, decoderName, isvc.Namespace, constants.CommonISVCPort, timeout))
+		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(explainRouteMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.DecoderComponent, decoderName, isvc.Namespace, constants.CommonISVCPort), timeout))
 	}
 
 	if isvc.Spec.Router != nil {
@@ -302,14 +323,14 @@ func (b *HTTPRouteBuilder) buildTopLevelHTTPRoute(isvc *v1beta1.InferenceService
 			timeout = toGatewayAPIDuration(*isvc.Spec.Router.TimeoutSeconds)
 		}
 		routeMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(constants.FallbackPrefix())}
-		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(routeMatch, filters, routerName, isvc.Namespace, constants.CommonISVCPort, timeout))
+		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(routeMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.RouterComponent, routerName, isvc.Namespace, constants.CommonISVCPort), timeout))
 	} else {
 		timeout := DefaultTimeout
 		if isvc.Spec.Predictor.TimeoutSeconds != nil {
 			timeout = toGatewayAPIDuration(*isvc.Spec.Predictor.TimeoutSeconds)
 		}
 		routeMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(constants.FallbackPrefix())}
-		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(routeMatch, filters, engineName, isvc.Namespace, constants.CommonISVCPort, timeout))
+		httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(routeMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.EngineComponent, engineName, isvc.Namespace, constants.CommonISVCPort), timeout))
 	}
 
 	// Add path-based routing if configured
@@ -327,7 +348,7 @@ func (b *HTTPRouteBuilder) buildTopLevelHTTPRoute(isvc *v1beta1.InferenceService
 				timeout = toGatewayAPIDuration(*isvc.Spec.Decoder.TimeoutSeconds)
 			}
 			decoderPathRouteMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(path + constants.PathBasedExplainPrefix())}
-			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(decoderPathRouteMatch, filters, decoderName, isvc.Namespace, constants.CommonISVCPort, timeout))
+			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(decoderPathRouteMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.DecoderComponent, decoderName, isvc.Namespace, constants.CommonISVCPort), timeout))
 		}
 
 		if isvc.Spec.Router != nil {
@@ -336,14 +357,14 @@ func (b *HTTPRouteBuilder) buildTopLevelHTTPRoute(isvc *v1beta1.InferenceService
 				timeout = toGatewayAPIDuration(*isvc.Spec.Router.TimeoutSeconds)
 			}
 			pathRouteMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(path + "/")}
-			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(pathRouteMatch, filters, routerName, isvc.Namespace, constants.CommonISVCPort, timeout))
+			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(pathRouteMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.RouterComponent, routerName, isvc.Namespace, constants.CommonISVCPort), timeout))
 		} else {
 			timeout := DefaultTimeout
 			if isvc.Spec.Predictor.TimeoutSeconds != nil {
 				timeout = toGatewayAPIDuration(*isvc.Spec.Predictor.TimeoutSeconds)
 			}
 			pathRouteMatch := []gatewayapiv1.HTTPRouteMatch{b.createHTTPRouteMatch(path + "/")}
-			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(pathRouteMatch, filters, engineName, isvc.Namespace, constants.CommonISVCPort, timeout))
+			httpRouteRules = append(httpRouteRules, b.createHTTPRouteRule(pathRouteMatch, filters, b.createHTTPBackendRefs(isvc, v1beta1.EngineComponent, engineName, isvc.Namespace, constants.CommonISVCPort), timeout))
 		}
 	}
 
@@ -8,11 +8,14 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/services"
 )
@@ -371,7 +374,8 @@ func TestHTTPRouteBuilder_CreateHTTPRouteRule(t *testing.T) {
 	}
 	timeout := toGatewayAPIDuration(30)
 
-	rule := builder.createHTTPRouteRule(matches, filters, "test-service", "test-namespace", 8080, timeout)
+	backendRefs := []gatewayapiv1.HTTPBackendRef{builder.createHTTPBackendRef("test-service", "test-namespace", 8080, nil)}
+	rule := builder.createHTTPRouteRule(matches, filters, backendRefs, timeout)
 
 	assert.Len(t, rule.Matches, 1)
 	assert.Len(t, rule.Filters, 1)
@@ -384,6 +388,67 @@ func TestHTTPRouteBuilder_CreateHTTPRouteRule(t *testing.T) {
 	assert.Equal(t, int32(8080), int32(*backend.BackendRef.BackendObjectReference.Port))
 }
 
+func TestHTTPRouteBuilder_CreateHTTPBackendRefs(t *testing.T) {
+	tests := []struct {
+		name            string
+		traffic         []knservingv1.TrafficTarget
+		expectedNames   []string
+		expectedWeights []*int32
+	}{
+		{
+			name:            "without canary",
+			traffic:         []knservingv1.TrafficTarget{{RevisionName: "test-isvc-1", Percent: ptr.To(int64(100))}},
+			expectedNames:   []string{"test-isvc"},
+			expectedWeights: []*int32{nil},
+		},
+		{
+			name: "canary receives part of the traffic",
+			traffic: []knservingv1.TrafficTarget{
+				{Tag: constants.PreviousTrafficTag, RevisionName: "test-isvc-1", Percent: ptr.To(int64(80))},
+				{Tag: constants.CanaryTrafficTag, RevisionName: "test-isvc-2", Percent: ptr.To(int64(20))},
+			},
+			expectedNames:   []string{"test-isvc", "test-isvc-canary"},
+			expectedWeights: []*int32{ptr.To(int32(80)), ptr.To(int32(20))},
+		},
+		{
+			name: "canary not ready",
+			traffic: []knservingv1.TrafficTarget{
+				{Tag: constants.PreviousTrafficTag, RevisionName: "test-isvc-1", Percent: ptr.To(int64(100))},
+				{Tag: constants.CanaryTrafficTag, RevisionName: "test-isvc-2", Percent: ptr.To(int64(0))},
+			},
+			expectedNames:   []string{"test-isvc"},
+			expectedWeights: []*int32{nil},
+		},
+		{
+			name: "canary receives all traffic",
+			traffic: []knservingv1.TrafficTarget{
+				{Tag: constants.PreviousTrafficTag, RevisionName: "test-isvc-1", Percent: ptr.To(int64(0))},
+				{Tag: constants.CanaryTrafficTag, RevisionName: "test-isvc-2", Percent: ptr.To(int64(100))},
+			},
+			expectedNames:   []string{"test-isvc-canary"},
+			expectedWeights: []*int32{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := createHTTPRouteBuilder()
+			isvc := createTestInferenceServiceHTTPRoute("test-isvc", "default")
+			isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.EngineComponent: {Traffic: tt.traffic},
+			}
+
+			backendRefs := builder.createHTTPBackendRefs(isvc, v1beta1.EngineComponent, "test-isvc", "default", 8080)
+
+			require.Len(t, backendRefs, len(tt.expectedNames))
+			for i, backendRef := range backendRefs {
+				assert.Equal(t, gatewayapiv1.ObjectName(tt.expectedNames[i]), backendRef.Name)
+				assert.Equal(t, tt.expectedWeights[i], backendRef.Weight)
+			}
+		})
+	}
+}
+
 func TestHTTPRouteBuilder_ToGatewayAPIDuration(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -213,9 +213,7 @@ func (b *VirtualServiceBuilder) buildHTTPRoutes(isvc *v1beta1.InferenceService,
 		decoderRouter := istiov1beta1.HTTPRoute{
 			Match: b.createHTTPMatchRequest(constants.DecoderPrefix(), serviceHost,
 				network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts, isInternal),
-			Route: []*istiov1beta1.HTTPRouteDestination{
-				b.createHTTPRouteDestination(b.ingressConfig.KnativeLocalGatewayService),
-			},
+			Route: b.createHTTPRouteDestinations(isvc, v1beta1.DecoderComponent, expBackend),
 			Headers: &istiov1beta1.Headers{
 				Request: &istiov1beta1.Headers_HeaderOperations{
 					Set: map[string]string{
@@ -233,9 +231,7 @@ func (b *VirtualServiceBuilder) buildHTTPRoutes(isvc *v1beta1.InferenceService,
 	httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
 		Match: b.createHTTPMatchRequest("", serviceHost,
 			network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts, isInternal),
-		Route: []*istiov1beta1.HTTPRouteDestination{
-			b.createHTTPRouteDestination(b.ingressConfig.KnativeLocalGatewayService),
-		},
+		Route: b.createHTTPRouteDestinations(isvc, b.backendComponent(isvc), backend),
 		Headers: &istiov1beta1.Headers{
 			Request: &istiov1beta1.Headers_HeaderOperations{
 				Set: map[string]string{
@@ -289,9 +285,7 @@ func (b *VirtualServiceBuilder) buildPathBasedRoutes(isvc *v1beta1.InferenceServ
 					Rewrite: `\1`,
 				},
 			},
-			Route: []*istiov1beta1.HTTPRouteDestination{
-				b.createHTTPRouteDestination(b.ingressConfig.KnativeLocalGatewayService),
-			},
+			Route: b.createHTTPRouteDestinations(isvc, v1beta1.DecoderComponent, expBackend),
 			Headers: &istiov1beta1.Headers{
 				Request: &istiov1beta1.Headers_HeaderOperations{
 					Set: map[string]string{
@@ -337,9 +331,7 @@ func (b *VirtualServiceBuilder) buildPathBasedRoutes(isvc *v1beta1.InferenceServ
 		Rewrite: &istiov1beta1.HTTPRewrite{
 			Uri: "/",
 		},
-		Route: []*istiov1beta1.HTTPRouteDestination{
-			b.createHTTPRouteDestination(b.ingressConfig.KnativeLocalGatewayService),
-		},
+		Route: b.createHTTPRouteDestinations(isvc, b.backendComponent(isvc), backend),
 		Headers: &istiov1beta1.Headers{
 			Request: &istiov1beta1.Headers_HeaderOperations{
 				Set: map[string]string{
@@ -370,6 +362,40 @@ func (b *VirtualServiceBuilder) addAdditionalHosts(hosts []string, additionalHos
 	return hosts
 }
 
+// backendComponent returns the component the predict routes go to
+func (b *VirtualServiceBuilder) backendComponent(isvc *v1beta1.InferenceService) v1beta1.ComponentType {
+	if isvc.Spec.Router != nil {
+		return v1beta1.RouterComponent
+	}
+	return v1beta1.EngineComponent
+}
+
+// createHTTPRouteDestinations routes through the local gateway, or straight to the services of a component rolled
+// out with a canary workload, weighted by the canary traffic percent of the component
+func (b *VirtualServiceBuilder) createHTTPRouteDestinations(isvc *v1beta1.InferenceService, component v1beta1.ComponentType, backend string) []*istiov1beta1.HTTPRouteDestination {
+	canaryPercent, ok := isvc.Status.GetCanaryTrafficPercent(component)
+	if !ok {
+		return []*istiov1beta1.HTTPRouteDestination{b.createHTTPRouteDestination(b.ingressConfig.KnativeLocalGatewayService)}
+	}
+
+	var destinations []*istiov1beta1.HTTPRouteDestination
+	for _, target := range []struct {
+		service string
+		weight  int64
+	}{
+		{service: backend, weight: 100 - canaryPercent},
+		{service: constants.CanaryServiceName(backend), weight: canaryPercent},
+	} {
+		if target.weight <= 0 {
+			continue
+		}
+		destination := b.createHTTPRouteDestination(network.GetServiceHostname(target.service, isvc.Namespace))
+		destination.Weight = int32(target.weight)
+		destinations = append(destinations, destination)
+	}
+	return destinations
+}
+
 func (b *VirtualServiceBuilder) createHTTPRouteDestination(gatewayService string) *istiov1beta1.HTTPRouteDestination {
 	return &istiov1beta1.HTTPRouteDestination{
 		Destination: &istiov1beta1.Destination{
@@ -10,8 +10,10 @@ import (
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
@@ -325,6 +327,58 @@ func TestVirtualServiceBuilder_CreateHTTPRouteDestination(t *testing.T) {
 	assert.Equal(t, int32(100), destination.Weight)
 }
 
+func TestVirtualServiceBuilder_CreateHTTPRouteDestinations(t *testing.T) {
+	tests := []struct {
+		name            string
+		traffic         []knservingv1.TrafficTarget
+		expectedHosts   []string
+		expectedWeights []int32
+	}{
+		{
+			name:            "through local gateway",
+			traffic:         []knservingv1.TrafficTarget{{LatestRevision: ptr.To(true), Percent: ptr.To(int64(100))}},
+			expectedHosts:   []string{"knative-local-gateway.istio-system.svc.cluster.local"},
+			expectedWeights: []int32{100},
+		},
+		{
+			name: "weighted to canary",
+			traffic: []knservingv1.TrafficTarget{
+				{Tag: constants.PreviousTrafficTag, RevisionName: "test-isvc-1", Percent: ptr.To(int64(70))},
+				{Tag: constants.CanaryTrafficTag, RevisionName: "test-isvc-2", Percent: ptr.To(int64(30))},
+			},
+			expectedHosts:   []string{"test-isvc.default.svc.cluster.local", "test-isvc-canary.default.svc.cluster.local"},
+			expectedWeights: []int32{70, 30},
+		},
+		{
+			name: "all traffic to canary",
+			traffic: []knservingv1.TrafficTarget{
+				{Tag: constants.PreviousTrafficTag, RevisionName: "test-isvc-1", Percent: ptr.To(int64(0))},
+				{Tag: constants.CanaryTrafficTag, RevisionName: "test-isvc-2", Percent: ptr.To(int64(100))},
+			},
+			expectedHosts:   []string{"test-isvc-canary.default.svc.cluster.local"},
+			expectedWeights: []int32{100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := createVirtualServiceBuilder()
+			isvc := createTestInferenceServiceVirtualService("test-isvc", "default")
+			isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.EngineComponent: {Traffic: tt.traffic},
+			}
+
+			destinations := builder.createHTTPRouteDestinations(isvc, v1beta1.EngineComponent, "test-isvc")
+
+			require.Len(t, destinations, len(tt.expectedHosts))
+			for i, destination := range destinations {
+				assert.Equal(t, tt.expectedHosts[i], destination.Destination.Host)
+				assert.Equal(t, tt.expectedWeights[i], destination.Weight)
+			}
+		})
+	}
+}
+
 func TestVirtualServiceBuilder_CreateHTTPMatchRequest(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,9 +36,10 @@ func NewKEDAReconciler(
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec *v1beta1.InferenceServiceSpec,
+	runtimeFamily utils.RuntimeFamily,
 ) (*KEDAReconciler, error) {
 
-	scaledObject := createScaledObject(componentMeta, *inferenceServiceSpec)
+	scaledObject := createScaledObject(componentMeta, *inferenceServiceSpec, runtimeFamily)
 
 	return &KEDAReconciler{
 		client:       client,
@@ -51,6 +53,7 @@ func NewKEDAReconciler(
 func createScaledObject(
 	componentMeta metav1.ObjectMeta,
 	inferenceServiceSpec v1beta1.InferenceServiceSpec,
+	runtimeFamily utils.RuntimeFamily,
 ) *kedav1.ScaledObject {
 	filteredLabels := make(map[string]string)
 	for key, value := range componentMeta.Labels {
@@ -62,7 +65,7 @@ func createScaledObject(
 	componentExt := &inferenceServiceSpec.Predictor.ComponentExtensionSpec
 	minReplicas := calculateMinReplicas(componentExt)
 	maxReplicas := calculateMaxReplicas(componentExt, minReplicas)
-	triggers := getScaledObjectTriggers(componentMeta, inferenceServiceSpec)
+	triggers := getScaledObjectTriggers(componentMeta, inferenceServiceSpec, runtimeFamily)
 
 	return &kedav1.ScaledObject{
 		ObjectMeta: metav1.ObjectMeta{
@@ -84,6 +87,26 @@ func createScaledObject(
 	}
 }
 
+// ScaleFromZero lets KEDA scale the component to zero, and back up as soon as the activator serving the KEDA external
+// scaler API at scalerAddress holds a request
+func (r *KEDAReconciler) ScaleFromZero(scalerAddress string) {
+	minReplicas := int32(0)
+	r.ScaledObject.Spec.MinReplicaCount = &minReplicas
+	r.ScaledObject.Spec.Triggers = append(r.ScaledObject.Spec.Triggers, kedav1.ScaleTriggers{
+		Type:     "external-push",
+		Metadata: map[string]string{"scalerAddress": scalerAddress},
+	})
+}
+
+// SetScaleTarget scales the workload of the given kind instead of the component Deployment
+func (r *KEDAReconciler) SetScaleTarget(apiVersion, kind, name string) {
+	r.ScaledObject.Spec.ScaleTargetRef = &kedav1.ScaleTarget{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}
+}
+
 // calculateMinReplicas calculates the minimum replicas
 func calculateMinReplicas(componentExt *v1beta1.ComponentExtensionSpec) int32 {
 	if componentExt.MinReplicas != nil && *componentExt.MinReplicas > 0 {
@@ -109,13 +132,17 @@ func getDeploymentName(metadata metav1.ObjectMeta) string {
 }
 
 // getScaledObjectTriggers constructs the triggers for the ScaledObject
-func getScaledObjectTriggers(metadata metav1.ObjectMeta, inferenceServiceSpec v1beta1.InferenceServiceSpec) []kedav1.ScaleTriggers {
+func getScaledObjectTriggers(
+	metadata metav1.ObjectMeta,
+	inferenceServiceSpec v1beta1.InferenceServiceSpec,
+	runtimeFamily utils.RuntimeFamily,
+) []kedav1.ScaleTriggers {
 	kedaConfig := inferenceServiceSpec.KedaConfig
-	threshold := getScalingThreshold(metadata, kedaConfig)
-	operator := getScalingOperator(metadata, kedaConfig)
-	prometheusServerAddress := getPrometheusServerAddress(metadata, kedaConfig)
-	prometheusQuery := getPrometheusQuery(metadata, kedaConfig)
 	scaleMetric := getScaleMetric(inferenceServiceSpec)
+	threshold := getScalingThreshold(metadata, kedaConfig, v1beta1.ScaleMetric(scaleMetric))
+	operator := getScalingOperator(metadata, kedaConfig)
+	prometheusServerAddress := GetPrometheusServerAddress(metadata, kedaConfig)
+	prometheusQuery := getPrometheusQuery(metadata, kedaConfig, v1beta1.ScaleMetric(scaleMetric), runtimeFamily)
 
 	triggerMetadata := map[string]string{
 		"serverAddress": prometheusServerAddress,
@@ -129,6 +156,10 @@ func getScaledObjectTriggers(metadata metav1.ObjectMeta, inferenceServiceSpec v1
 		Type:     "prometheus",
 		Metadata: triggerMetadata,
 	}
+	// TTFT is a latency of the component as a whole, which is not divided among its replicas
+	if v1beta1.ScaleMetric(scaleMetric) == v1beta1.MetricTTFTP90 {
+		trigger.MetricType = autoscalingv2.ValueMetricType
+	}
 
 	// Add authenticationRef if configured
 	if kedaConfig != nil && kedaConfig.AuthenticationRef != nil {
@@ -151,13 +182,16 @@ func getScaledObjectTriggers(metadata metav1.ObjectMeta, inferenceServiceSpec v1
 }
 
 // getScalingThreshold retrieves the scaling threshold
-func getScalingThreshold(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConfig) string {
+func getScalingThreshold(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConfig, scaleMetric v1beta1.ScaleMetric) string {
 	if value, ok := metadata.Annotations[constants.KedaScalingThreshold]; ok {
 		return value
 	}
 	if kedaConfig != nil && kedaConfig.ScalingThreshold != "" {
 		return kedaConfig.ScalingThreshold
 	}
+	if threshold, ok := llmMetricThresholds[scaleMetric]; ok {
+		return threshold
+	}
 	return "10" // Default threshold
 }
 
@@ -182,8 +216,8 @@ func getScalingOperator(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConf
 	return "LessThanOrEqual" // Default operator
 }
 
-// getPrometheusServerAddress retrieves the Prometheus server address
-func getPrometheusServerAddress(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConfig) string {
+// GetPrometheusServerAddress retrieves the Prometheus server address from the component annotations or KEDA config
+func GetPrometheusServerAddress(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConfig) string {
 	if value, ok := metadata.Annotations[constants.KedaPrometheusServerAddress]; ok {
 		return value
 	}
@@ -193,14 +227,55 @@ func getPrometheusServerAddress(metadata metav1.ObjectMeta, kedaConfig *v1beta1.
 	return "http://prometheus-operated.monitoring.svc.cluster.local:9090" // Default address
 }
 
+// llmMetricQueries are the Prometheus queries of the LLM scale metrics per runtime family. They are formatted with the
+// label matchers selecting the component pods.
+var llmMetricQueries = map[utils.RuntimeFamily]map[v1beta1.ScaleMetric]string{
+	utils.RuntimeFamilySGLang: {
+		v1beta1.MetricKVCache:    `sum(sglang:token_usage{%s})`,
+		v1beta1.MetricQueueDepth: `sum(sglang:num_queue_reqs{%s})`,
+		v1beta1.MetricTTFTP90:    `histogram_quantile(0.9, sum by (le) (rate(sglang:time_to_first_token_seconds_bucket{%s}[1m])))`,
+	},
+	utils.RuntimeFamilyVLLM: {
+		v1beta1.MetricKVCache:    `sum(vllm:gpu_cache_usage_perc{%s})`,
+		v1beta1.MetricQueueDepth: `sum(vllm:num_requests_waiting{%s})`,
+		v1beta1.MetricTTFTP90:    `histogram_quantile(0.9, sum by (le) (rate(vllm:time_to_first_token_seconds_bucket{%s}[1m])))`,
+	},
+}
+
+// llmMetricThresholds are the default thresholds of the LLM scale metrics. KV cache utilization and queue depth are
+// averaged over the replicas, TTFT p90 is in seconds.
+var llmMetricThresholds = map[v1beta1.ScaleMetric]string{
+	v1beta1.MetricKVCache:    "0.8",
+	v1beta1.MetricQueueDepth: "5",
+	v1beta1.MetricTTFTP90:    "2",
+}
+
+// LLMMetricQuery returns the Prometheus query of an LLM scale metric over the pods with the app label in the
+// namespace, and false when the metric is not an LLM scale metric
+func LLMMetricQuery(scaleMetric v1beta1.ScaleMetric, runtimeFamily utils.RuntimeFamily, namespace, app string) (string, bool) {
+	query, ok := llmMetricQueries[runtimeFamily][scaleMetric]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(query, fmt.Sprintf(`namespace="%s",app="%s"`, namespace, app)), true
+}
+
 // getPrometheusQuery constructs the Prometheus query
-func getPrometheusQuery(metadata metav1.ObjectMeta, kedaConfig *v1beta1.KedaConfig) string {
+func getPrometheusQuery(
+	metadata metav1.ObjectMeta,
+	kedaConfig *v1beta1.KedaConfig,
+	scaleMetric v1beta1.ScaleMetric,
+	runtimeFamily utils.RuntimeFamily,
+) string {
 	if value, ok := metadata.Annotations[constants.KedaPrometheusQuery]; ok {
 		return value
 	}
 	if kedaConfig != nil && kedaConfig.CustomPromQuery != "" {
 		return fmt.Sprintf(kedaConfig.CustomPromQuery, metadata.Name)
 	}
+	if query, ok := LLMMetricQuery(scaleMetric, runtimeFamily, metadata.Namespace, metadata.Name); ok {
+		return query
+	}
 	// Default VLLM Prometheus query
 	// Scale up condition: Low token throughput during high request load
 	throughputThreshold := 10   // Token throughput in TPS
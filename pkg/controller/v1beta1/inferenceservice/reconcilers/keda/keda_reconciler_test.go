@@ -5,9 +5,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	kedav1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
 )
 
 func TestGetScaledObjectTriggers(t *testing.T) {
@@ -149,7 +152,7 @@ func TestGetScaledObjectTriggers(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			triggers := getScaledObjectTriggers(tt.metadata, tt.inferenceServiceSpec)
+			triggers := getScaledObjectTriggers(tt.metadata, tt.inferenceServiceSpec, utils.RuntimeFamilySGLang)
 
 			if len(triggers) != 1 {
 				t.Fatalf("Expected 1 trigger, got %d", len(triggers))
@@ -199,6 +202,99 @@ func TestGetScaledObjectTriggers(t *testing.T) {
 	}
 }
 
+func TestLLMMetricTriggers(t *testing.T) {
+	metadata := metav1.ObjectMeta{Name: "llama-engine", Namespace: "test"}
+	scaleMetric := func(metric v1beta1.ScaleMetric) v1beta1.InferenceServiceSpec {
+		return v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{ScaleMetric: &metric},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		metadata           metav1.ObjectMeta
+		spec               v1beta1.InferenceServiceSpec
+		runtimeFamily      utils.RuntimeFamily
+		expectedQuery      string
+		expectedThreshold  string
+		expectedMetricType autoscalingv2.MetricTargetType
+	}{
+		{
+			name:              "SGLang KV cache utilization",
+			metadata:          metadata,
+			spec:              scaleMetric(v1beta1.MetricKVCache),
+			runtimeFamily:     utils.RuntimeFamilySGLang,
+			expectedQuery:     `sum(sglang:token_usage{namespace="test",app="llama-engine"})`,
+			expectedThreshold: "0.8",
+		},
+		{
+			name:              "vLLM KV cache utilization",
+			metadata:          metadata,
+			spec:              scaleMetric(v1beta1.MetricKVCache),
+			runtimeFamily:     utils.RuntimeFamilyVLLM,
+			expectedQuery:     `sum(vllm:gpu_cache_usage_perc{namespace="test",app="llama-engine"})`,
+			expectedThreshold: "0.8",
+		},
+		{
+			name:              "SGLang queue depth",
+			metadata:          metadata,
+			spec:              scaleMetric(v1beta1.MetricQueueDepth),
+			runtimeFamily:     utils.RuntimeFamilySGLang,
+			expectedQuery:     `sum(sglang:num_queue_reqs{namespace="test",app="llama-engine"})`,
+			expectedThreshold: "5",
+		},
+		{
+			name:              "vLLM queue depth",
+			metadata:          metadata,
+			spec:              scaleMetric(v1beta1.MetricQueueDepth),
+			runtimeFamily:     utils.RuntimeFamilyVLLM,
+			expectedQuery:     `sum(vllm:num_requests_waiting{namespace="test",app="llama-engine"})`,
+			expectedThreshold: "5",
+		},
+		{
+			name:               "vLLM TTFT p90 is not averaged over the replicas",
+			metadata:           metadata,
+			spec:               scaleMetric(v1beta1.MetricTTFTP90),
+			runtimeFamily:      utils.RuntimeFamilyVLLM,
+			expectedQuery:      `histogram_quantile(0.9, sum by (le) (rate(vllm:time_to_first_token_seconds_bucket{namespace="test",app="llama-engine"}[1m])))`,
+			expectedThreshold:  "2",
+			expectedMetricType: autoscalingv2.ValueMetricType,
+		},
+		{
+			name: "Annotations take precedence",
+			metadata: metav1.ObjectMeta{Name: "llama-engine", Namespace: "test", Annotations: map[string]string{
+				constants.KedaPrometheusQuery:  "sum(custom)",
+				constants.KedaScalingThreshold: "0.5",
+			}},
+			spec:              scaleMetric(v1beta1.MetricKVCache),
+			runtimeFamily:     utils.RuntimeFamilySGLang,
+			expectedQuery:     "sum(custom)",
+			expectedThreshold: "0.5",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			triggers := getScaledObjectTriggers(tt.metadata, tt.spec, tt.runtimeFamily)
+			if len(triggers) != 1 {
+				t.Fatalf("Expected 1 trigger, got %d", len(triggers))
+			}
+			trigger := triggers[0]
+			if trigger.Metadata["query"] != tt.expectedQuery {
+				t.Errorf("Expected query '%s', got '%s'", tt.expectedQuery, trigger.Metadata["query"])
+			}
+			if trigger.Metadata["threshold"] != tt.expectedThreshold {
+				t.Errorf("Expected threshold '%s', got '%s'", tt.expectedThreshold, trigger.Metadata["threshold"])
+			}
+			if trigger.MetricType != tt.expectedMetricType {
+				t.Errorf("Expected metric type '%s', got '%s'", tt.expectedMetricType, trigger.MetricType)
+			}
+		})
+	}
+}
+
 func TestCalculateMinMaxReplicas(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -250,6 +346,40 @@ func TestCalculateMinMaxReplicas(t *testing.T) {
 	}
 }
 
+func TestScaleFromZero(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{Name: "llama", Namespace: "default"}
+	inferenceServiceSpec := &v1beta1.InferenceServiceSpec{
+		Predictor: v1beta1.PredictorSpec{
+			ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: intPtr(0), MaxReplicas: 4},
+		},
+	}
+	reconciler, err := NewKEDAReconciler(nil, nil, componentMeta, inferenceServiceSpec, utils.RuntimeFamilySGLang)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconciler.SetScaleTarget("leaderworkerset.x-k8s.io/v1", "LeaderWorkerSet", "llama")
+	reconciler.ScaleFromZero("llama-activator.default.svc.cluster.local:9095")
+
+	spec := reconciler.ScaledObject.Spec
+	if *spec.MinReplicaCount != 0 || *spec.MaxReplicaCount != 4 {
+		t.Errorf("Expected replicas between 0 and 4, got %d and %d", *spec.MinReplicaCount, *spec.MaxReplicaCount)
+	}
+	expectedTarget := &kedav1.ScaleTarget{APIVersion: "leaderworkerset.x-k8s.io/v1", Kind: "LeaderWorkerSet", Name: "llama"}
+	if diff := cmp.Diff(expectedTarget, spec.ScaleTargetRef); diff != "" {
+		t.Errorf("Unexpected scale target (-want +got): %v", diff)
+	}
+	if len(spec.Triggers) != 2 {
+		t.Fatalf("Expected the prometheus and external-push triggers, got %d triggers", len(spec.Triggers))
+	}
+	expectedTrigger := kedav1.ScaleTriggers{
+		Type:     "external-push",
+		Metadata: map[string]string{"scalerAddress": "llama-activator.default.svc.cluster.local:9095"},
+	}
+	if diff := cmp.Diff(expectedTrigger, spec.Triggers[1]); diff != "" {
+		t.Errorf("Unexpected trigger (-want +got): %v", diff)
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
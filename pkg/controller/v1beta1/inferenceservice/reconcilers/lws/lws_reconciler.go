@@ -26,6 +26,8 @@ type LWSReconciler struct {
 	scheme       *runtime.Scheme
 	LWS          *lws.LeaderWorkerSet
 	ComponentExt *v1beta1.ComponentExtensionSpec
+	// preserveReplicas keeps the replicas of an existing LeaderWorkerSet, which are owned by its autoscaler
+	preserveReplicas bool
 }
 
 func NewLWSReconciler(client client.Client,
@@ -150,6 +152,11 @@ func setDefaultContainerSettings(podSpec *corev1.PodSpec) {
 	}
 }
 
+// PreserveReplicas leaves the replicas of an existing LeaderWorkerSet to its autoscaler
+func (r *LWSReconciler) PreserveReplicas() {
+	r.preserveReplicas = true
+}
+
 func (r *LWSReconciler) Reconcile() (*lws.LeaderWorkerSet, error) {
 	checkResult, existingLWS, err := r.checkLeaderWorkerSetExist()
 	if err != nil {
@@ -186,6 +193,9 @@ func (r *LWSReconciler) checkLeaderWorkerSetExist() (constants.CheckResultType,
 		return constants.CheckResultUnknown, nil, err
 	}
 
+	if r.preserveReplicas && leaderWorkerSet.Spec.Replicas != nil {
+		r.LWS.Spec.Replicas = leaderWorkerSet.Spec.Replicas
+	}
 	diff, err := kmp.SafeDiff(r.LWS.Spec, leaderWorkerSet.Spec)
 	if err != nil {
 		return constants.CheckResultUnknown, nil, err
@@ -194,5 +204,9 @@ func (r *LWSReconciler) checkLeaderWorkerSetExist() (constants.CheckResultType,
 		log.Info("LWS diff", "namespace", r.LWS.Namespace, "name", r.LWS.Name, "diff", diff)
 		return constants.CheckResultUpdate, leaderWorkerSet, nil
 	}
+	if r.LWS.Annotations[constants.WorkloadRevisionAnnotationKey] != leaderWorkerSet.Annotations[constants.WorkloadRevisionAnnotationKey] {
+		log.Info("LWS revisions differ", "namespace", r.LWS.Namespace, "name", r.LWS.Name)
+		return constants.CheckResultUpdate, leaderWorkerSet, nil
+	}
 	return constants.CheckResultExisted, leaderWorkerSet, nil
 }
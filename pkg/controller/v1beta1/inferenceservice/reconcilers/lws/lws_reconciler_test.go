@@ -258,7 +258,23 @@ func TestReconcile(t *testing.T) {
 		assert.Equal(t, existingLWS, result)
 	})
 
-	// 4. Test case: Error handling for client.Get failure
+	// 4. Test case: LWS scaled by its autoscaler keeps its replicas
+	t.Run("LWS replicas preserved", func(t *testing.T) {
+		existingLWS := createLWS(headPod, workerPod, 3, componentExt, componentMeta)
+		existingLWS.Spec.Replicas = ptr.Int32(0)
+
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingLWS).Build()
+
+		reconciler := NewLWSReconciler(client, scheme, headPod, workerPod, 3, componentExt, componentMeta)
+		reconciler.PreserveReplicas()
+
+		result, _, err := reconciler.checkLeaderWorkerSetExist()
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CheckResultExisted, result)
+		assert.Equal(t, int32(0), *reconciler.LWS.Spec.Replicas)
+	})
+
+	// 5. Test case: Error handling for client.Get failure
 	t.Run("Get error", func(t *testing.T) {
 		// Create a fake client with a custom client that will return error on Get
 		client := &mockClient{
@@ -411,7 +427,23 @@ func TestCheckLeaderWorkerSetExist(t *testing.T) {
 		assert.Equal(t, existingLWS.Name, lwsObj.Name)
 	})
 
-	// 4. Test case: Error handling for client.Get failure
+	// 4. Test case: LWS scaled by its autoscaler keeps its replicas
+	t.Run("LWS replicas preserved", func(t *testing.T) {
+		existingLWS := createLWS(headPod, workerPod, 3, componentExt, componentMeta)
+		existingLWS.Spec.Replicas = ptr.Int32(0)
+
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingLWS).Build()
+
+		reconciler := NewLWSReconciler(client, scheme, headPod, workerPod, 3, componentExt, componentMeta)
+		reconciler.PreserveReplicas()
+
+		result, _, err := reconciler.checkLeaderWorkerSetExist()
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CheckResultExisted, result)
+		assert.Equal(t, int32(0), *reconciler.LWS.Spec.Replicas)
+	})
+
+	// 5. Test case: Error handling for client.Get failure
 	t.Run("Get error", func(t *testing.T) {
 		// Create a fake client with a custom client that will return error on Get
 		client := &mockClient{
@@ -17,6 +17,7 @@ import (
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/services"
 	raycluster "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/istiosidecar"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/lws"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/multinodevllm"
 	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
 )
 
@@ -42,23 +43,50 @@ func NewMultiNodeReconciler(client client.Client,
 	if err != nil {
 		return nil, err
 	}
+	leaderPodSpec, err := withProberSidecar(clientset, componentMeta, headPodSpec)
+	if err != nil {
+		return nil, err
+	}
 	var enabled bool
 	istioSidecarInjection, ok := componentMeta.Labels[constants.IstioSidecarInjectionLabel]
 	if ok && istioSidecarInjection == "true" {
 		enabled = true
 	}
-	selector := map[string]string{"ray.io/node-type": "head"}
+	// Select the head pods of this LeaderWorkerSet only, so stable and canary services do not share pods
+	selector := map[string]string{
+		constants.RawDeploymentAppLabel: constants.GetRawServiceLabel(componentMeta.Name),
+		"ray.io/node-type":              "head",
+	}
 
 	return &MultiNodeReconciler{
 		client:       client,
 		scheme:       scheme,
-		LWS:          lws.NewLWSReconciler(client, scheme, headPodSpec, workerPodSpec, int32(workerSize), componentExt, componentMeta),
+		LWS:          lws.NewLWSReconciler(client, scheme, leaderPodSpec, workerPodSpec, int32(workerSize), componentExt, componentMeta),
 		URL:          url,
 		IstioSidecar: raycluster.NewIstioSidecarReconciler(client, scheme, componentMeta, enabled),
 		Service:      service.NewServiceReconciler(client, scheme, componentMeta, componentExt, headPodSpec, selector),
 	}, nil
 }
 
+// withProberSidecar adds the multinode prober to the leader pods, so that a group is only ready once the serving runtime
+// passes the readiness checks of its health contract. The leader pods are left as is without a prober image.
+func withProberSidecar(clientset kubernetes.Interface, componentMeta metav1.ObjectMeta, headPodSpec *corev1.PodSpec) (*corev1.PodSpec, error) {
+	proberConfig, err := controllerconfig.NewMultiNodeProberConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+	if proberConfig.Image == "" {
+		return headPodSpec, nil
+	}
+	proberConfig, err = proberConfig.ForComponent(componentMeta.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	leaderPodSpec := headPodSpec.DeepCopy()
+	leaderPodSpec.Containers = append(leaderPodSpec.Containers, multinodevllm.ProberSidecar(proberConfig))
+	return leaderPodSpec, nil
+}
+
 func createRawURL(clientset kubernetes.Interface, metadata metav1.ObjectMeta) (*knapis.URL, error) {
 	ingressConfig, err := controllerconfig.NewIngressConfig(clientset)
 	if err != nil {
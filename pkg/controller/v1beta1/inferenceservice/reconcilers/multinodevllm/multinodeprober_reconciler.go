@@ -3,6 +3,7 @@ package multinodevllm
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	rayutils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
@@ -95,64 +96,115 @@ func getDefaultPodSpec(
 	url *knapis.URL,
 ) *corev1.PodSpec {
 	return &corev1.PodSpec{
-		Containers: []corev1.Container{
+		Containers: []corev1.Container{proberContainer(multiNodeProberConfig, url, constants.MultiNodeProberContainerPort)},
+	}
+}
+
+// ProberSidecar renders the prober as a sidecar of the leader pod of a LeaderWorkerSet, checking the serving runtime
+// of the pod on localhost. The leader pod, and so its group, is only ready once the readiness checks pass. The sidecar
+// has no liveness probe, since restarting it would not restart the serving runtime.
+func ProberSidecar(multiNodeProberConfig *controllerconfig.MultiNodeProberConfig) corev1.Container {
+	url := &knapis.URL{Scheme: "http", Host: "localhost"}
+	container := proberContainer(multiNodeProberConfig, url, constants.MultiNodeProberSidecarPort)
+	container.ReadinessProbe = createProbe("/readyz", multiNodeProberConfig.ReadinessTimeoutSeconds, constants.MultiNodeProberSidecarPort)
+	container.LivenessProbe = nil
+	return container
+}
+
+func proberContainer(multiNodeProberConfig *controllerconfig.MultiNodeProberConfig, url *knapis.URL, port int32) corev1.Container {
+	return corev1.Container{
+		Name:            constants.MultiNodeProberContainerName,
+		Image:           multiNodeProberConfig.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(multiNodeProberConfig.CPULimit),
+				corev1.ResourceMemory: resource.MustParse(multiNodeProberConfig.MemoryLimit),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(multiNodeProberConfig.CPURequest),
+				corev1.ResourceMemory: resource.MustParse(multiNodeProberConfig.MemoryRequest),
+			},
+		},
+		ReadinessProbe: createProbe("/healthz", multiNodeProberConfig.ReadinessTimeoutSeconds, port),
+		LivenessProbe:  createProbe("/readyz", multiNodeProberConfig.LivenessTimeoutSeconds, port),
+		StartupProbe:   createStartupProbe(multiNodeProberConfig, port),
+		Args:           getProberArgs(multiNodeProberConfig, url, port),
+		Ports: []corev1.ContainerPort{
 			{
-				Name:            constants.MultiNodeProberContainerName,
-				Image:           multiNodeProberConfig.Image,
-				ImagePullPolicy: corev1.PullIfNotPresent,
-				Resources: corev1.ResourceRequirements{
-					Limits: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse(multiNodeProberConfig.CPULimit),
-						corev1.ResourceMemory: resource.MustParse(multiNodeProberConfig.MemoryLimit),
-					},
-					Requests: corev1.ResourceList{
-						corev1.ResourceCPU:    resource.MustParse(multiNodeProberConfig.CPURequest),
-						corev1.ResourceMemory: resource.MustParse(multiNodeProberConfig.MemoryRequest),
-					},
-				},
-				ReadinessProbe: createProbe("/healthz"),
-				LivenessProbe:  createProbe("/readyz"),
-				StartupProbe:   createStartupProbe(multiNodeProberConfig),
-				Args: []string{
-					"--vllm-endpoint",
-					fmt.Sprintf("%s:%s", url.String(), constants.InferenceServiceDefaultHttpPort),
-					"--addr",
-					"0.0.0.0:8080",
-				},
-				Ports: []corev1.ContainerPort{
-					{
-						Name:          "http",
-						ContainerPort: constants.MultiNodeProberContainerPort,
-					},
-				},
+				Name:          "http",
+				ContainerPort: port,
 			},
 		},
 	}
 }
 
-func createProbe(path string) *corev1.Probe {
+// getProberArgs points the prober at the serving runtime and bounds its checks by timeouts below those of the probes,
+// so that the prober answers before the kubelet gives up on a probe
+func getProberArgs(config *controllerconfig.MultiNodeProberConfig, url *knapis.URL, port int32) []string {
+	args := []string{
+		"--runtime",
+		config.Runtime,
+		"--endpoint",
+		fmt.Sprintf("%s:%s", url.String(), constants.InferenceServiceDefaultHttpPort),
+		"--addr",
+		fmt.Sprintf("0.0.0.0:%d", port),
+	}
+	if config.Runtime == controllerconfig.MultiNodeProberRuntimeRay {
+		args = append(args, "--ray-endpoint", fmt.Sprintf("%s:%d", url.String(), constants.RayDashboardPort))
+	}
+	for _, timeout := range []struct {
+		flag    string
+		seconds int32
+	}{
+		{"--liveness-timeout", config.LivenessTimeoutSeconds},
+		{"--readiness-timeout", config.ReadinessTimeoutSeconds},
+		{"--startup-timeout", config.StartupTimeoutSeconds},
+	} {
+		if timeout.seconds > 0 {
+			args = append(args, timeout.flag, checkTimeout(timeout.seconds).String())
+		}
+	}
+	return args
+}
+
+// checkTimeout returns the timeout of the checks of a probe with the given timeoutSeconds, leaving the prober time to
+// answer
+func checkTimeout(probeTimeoutSeconds int32) time.Duration {
+	probeTimeout := time.Duration(probeTimeoutSeconds) * time.Second
+	margin := probeTimeout / 10
+	if margin < 500*time.Millisecond {
+		margin = 500 * time.Millisecond
+	}
+	if margin > 2*time.Second {
+		margin = 2 * time.Second
+	}
+	return probeTimeout - margin
+}
+
+func createProbe(path string, timeoutSeconds int32, port int32) *corev1.Probe {
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Port: intstr.IntOrString{
-					IntVal: constants.MultiNodeProberContainerPort,
+					IntVal: port,
 				},
 				Path: path,
 			},
 		},
-		TimeoutSeconds:   5,
+		TimeoutSeconds:   timeoutSeconds,
 		PeriodSeconds:    30,
 		SuccessThreshold: 1,
 		FailureThreshold: 3,
 	}
 }
 
-func createStartupProbe(config *controllerconfig.MultiNodeProberConfig) *corev1.Probe {
+func createStartupProbe(config *controllerconfig.MultiNodeProberConfig, port int32) *corev1.Probe {
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Port: intstr.IntOrString{
-					IntVal: constants.MultiNodeProberContainerPort,
+					IntVal: port,
 				},
 				Path: "/startupz",
 			},
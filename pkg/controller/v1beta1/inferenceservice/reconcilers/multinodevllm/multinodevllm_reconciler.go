@@ -48,6 +48,10 @@ func NewMultiNodeVllmReconciler(client client.Client,
 	if err != nil {
 		return nil, err
 	}
+	multinodeProberConfig, err = multinodeProberConfig.ForComponent(componentMeta.Annotations)
+	if err != nil {
+		return nil, err
+	}
 
 	var enabled bool
 	istioSidecarInjection, ok := componentMeta.Labels[constants.IstioSidecarInjectionLabel]
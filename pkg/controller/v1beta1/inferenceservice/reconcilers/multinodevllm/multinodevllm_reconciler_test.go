@@ -3,6 +3,7 @@ package multinodevllm
 import (
 	"context"
 	"testing"
+	"time"
 
 	ray "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/stretchr/testify/assert"
@@ -17,11 +18,13 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	knapis "knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	kfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
 )
 
 func TestMultiNodeVLLMReconciler_Reconcile(t *testing.T) {
@@ -314,6 +317,97 @@ func TestCreateRawURL(t *testing.T) {
 	}
 }
 
+func TestGetProberArgs(t *testing.T) {
+	url := &knapis.URL{Scheme: "http", Host: "llama-0.default.svc.cluster.local"}
+	tests := []struct {
+		name     string
+		config   *controllerconfig.MultiNodeProberConfig
+		wantArgs []string
+	}{
+		{
+			name:   "vllm runtime with default timeouts",
+			config: &controllerconfig.MultiNodeProberConfig{Runtime: controllerconfig.MultiNodeProberRuntimeVLLM},
+			wantArgs: []string{
+				"--runtime", "vllm",
+				"--endpoint", "http://llama-0.default.svc.cluster.local:8080",
+				"--addr", "0.0.0.0:8080",
+			},
+		},
+		{
+			name: "ray runtime checks the dashboard of the head",
+			config: &controllerconfig.MultiNodeProberConfig{
+				Runtime:                 controllerconfig.MultiNodeProberRuntimeRay,
+				LivenessTimeoutSeconds:  5,
+				ReadinessTimeoutSeconds: 10,
+				StartupTimeoutSeconds:   60,
+			},
+			wantArgs: []string{
+				"--runtime", "ray",
+				"--endpoint", "http://llama-0.default.svc.cluster.local:8080",
+				"--addr", "0.0.0.0:8080",
+				"--ray-endpoint", "http://llama-0.default.svc.cluster.local:8265",
+				"--liveness-timeout", "4.5s",
+				"--readiness-timeout", "9s",
+				"--startup-timeout", "58s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantArgs, getProberArgs(tt.config, url, constants.MultiNodeProberContainerPort))
+		})
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	tests := []struct {
+		seconds int32
+		want    time.Duration
+	}{
+		{seconds: 1, want: 500 * time.Millisecond},
+		{seconds: 5, want: 4500 * time.Millisecond},
+		{seconds: 10, want: 9 * time.Second},
+		{seconds: 100, want: 98 * time.Second},
+	}
+	for _, tt := range tests {
+		timeout := checkTimeout(tt.seconds)
+		assert.Equal(t, tt.want, timeout)
+		assert.Less(t, timeout, time.Duration(tt.seconds)*time.Second)
+	}
+}
+
+func TestProberSidecar(t *testing.T) {
+	config := &controllerconfig.MultiNodeProberConfig{
+		Image:                   "ome/prober:v1",
+		CPURequest:              "100m",
+		MemoryRequest:           "128Mi",
+		CPULimit:                "200m",
+		MemoryLimit:             "256Mi",
+		StartupTimeoutSeconds:   5,
+		Runtime:                 controllerconfig.MultiNodeProberRuntimeSGLang,
+		LivenessTimeoutSeconds:  5,
+		ReadinessTimeoutSeconds: 5,
+	}
+
+	container := ProberSidecar(config)
+
+	assert.Equal(t, constants.MultiNodeProberContainerName, container.Name)
+	assert.Equal(t, []string{
+		"--runtime", "sglang",
+		"--endpoint", "http://localhost:8080",
+		"--addr", "0.0.0.0:8089",
+		"--liveness-timeout", "4.5s",
+		"--readiness-timeout", "4.5s",
+		"--startup-timeout", "4.5s",
+	}, container.Args)
+	require.NotNil(t, container.ReadinessProbe)
+	assert.Equal(t, "/readyz", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, int32(constants.MultiNodeProberSidecarPort), container.ReadinessProbe.HTTPGet.Port.IntVal)
+	assert.Equal(t, int32(constants.MultiNodeProberSidecarPort), container.StartupProbe.HTTPGet.Port.IntVal)
+	assert.Nil(t, container.LivenessProbe)
+}
+
 func TestMultiNodeVllmReconciler_ReconcileWithIstioSidecar(t *testing.T) {
 	s := scheme.Scheme
 	_ = v1beta1.AddToScheme(s)
@@ -0,0 +1,294 @@
+package rbg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	knapis "knative.dev/pkg/apis"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress/services"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
+)
+
+var log = ctrl.Log.WithName("RBGReconciler")
+
+// RBGReconciler reconciles a RoleBasedGroup together with the Services and HorizontalPodAutoscalers of its roles
+type RBGReconciler struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	RBG      *RoleBasedGroup
+	Ratios   *RoleRatios
+	Services map[v1beta1.ComponentType]*service.ServiceReconciler
+	HPAs     map[v1beta1.ComponentType]*hpa.HPAReconciler
+	URLs     map[v1beta1.ComponentType]*knapis.URL
+	// staleHPAs are the autoscalers of roles that follow another role
+	staleHPAs []types.NamespacedName
+}
+
+// NewRBGReconciler renders the RoleBasedGroup of the given components. Roles whose replicas follow another
+// role through the ratios are not autoscaled.
+func NewRBGReconciler(client client.Client,
+	clientset kubernetes.Interface,
+	scheme *runtime.Scheme,
+	groupMeta metav1.ObjectMeta,
+	configs []RoleConfig,
+	ratios *RoleRatios) (*RBGReconciler, error) {
+
+	ingressConfig, err := controllerconfig.NewIngressConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RBGReconciler{
+		client:   client,
+		scheme:   scheme,
+		RBG:      &RoleBasedGroup{ObjectMeta: groupMeta},
+		Ratios:   ratios,
+		Services: map[v1beta1.ComponentType]*service.ServiceReconciler{},
+		HPAs:     map[v1beta1.ComponentType]*hpa.HPAReconciler{},
+		URLs:     map[v1beta1.ComponentType]*knapis.URL{},
+	}
+	r.RBG.SetGroupVersionKind(GroupVersionKind)
+
+	for _, componentType := range roleOrder {
+		for _, config := range configs {
+			if config.ComponentType != componentType {
+				continue
+			}
+			autoscaled := !ratios.Follows(string(componentType)) && config.ComponentExtensionSpec != nil
+			role, err := BuildRole(config, roleDependencies(componentType, configs), autoscaled)
+			if err != nil {
+				return nil, err
+			}
+			r.RBG.Spec.Roles = append(r.RBG.Spec.Roles, *role)
+
+			var selector map[string]string
+			if config.DeploymentMode == constants.MultiNode {
+				selector = map[string]string{
+					constants.RawDeploymentAppLabel: constants.GetRawServiceLabel(config.ObjectMeta.Name),
+					MultiNodeLeaderLabel:            MultiNodeLeaderLabelValue,
+				}
+			}
+			r.Services[componentType] = service.NewServiceReconciler(client, scheme, config.ObjectMeta, config.ComponentExtensionSpec, config.PodSpec, selector)
+
+			if autoscaled {
+				autoscaler := hpa.NewHPAReconciler(client, scheme, config.ObjectMeta, config.ComponentExtensionSpec)
+				autoscaler.HPA.Spec.ScaleTargetRef.APIVersion = GroupVersion.String()
+				autoscaler.HPA.Spec.ScaleTargetRef.Kind = ScalingAdapterKind
+				autoscaler.HPA.Spec.ScaleTargetRef.Name = ScalingAdapterName(groupMeta.Name, role.Name)
+				r.HPAs[componentType] = autoscaler
+			} else {
+				r.staleHPAs = append(r.staleHPAs, types.NamespacedName{Namespace: config.ObjectMeta.Namespace, Name: config.ObjectMeta.Name})
+			}
+
+			url := &knapis.URL{Scheme: "http"}
+			url.Host, err = services.NewDomainService().GenerateDomainName(config.ObjectMeta.Name, config.ObjectMeta, ingressConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed creating host name: %w", err)
+			}
+			r.URLs[componentType] = url
+		}
+	}
+	return r, nil
+}
+
+// ScalingAdapterName returns the name of the RoleBasedGroupScalingAdapter RBG creates for a role
+func ScalingAdapterName(group, role string) string {
+	return group + "-" + role
+}
+
+// SetControllerReferences sets the owner of the RoleBasedGroup and the resources of its roles
+func (r *RBGReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	// The owner reference is set on an unstructured copy, since RoleBasedGroup is not a registered type
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersionKind)
+	obj.SetNamespace(r.RBG.Namespace)
+	obj.SetName(r.RBG.Name)
+	obj.SetOwnerReferences(r.RBG.OwnerReferences)
+	if err := controllerutil.SetControllerReference(owner, obj, scheme); err != nil {
+		return err
+	}
+	r.RBG.OwnerReferences = obj.GetOwnerReferences()
+
+	for _, svc := range r.Services {
+		if err := controllerutil.SetControllerReference(owner, svc.Service, scheme); err != nil {
+			return err
+		}
+	}
+	for _, autoscaler := range r.HPAs {
+		if err := autoscaler.SetControllerReferences(owner, scheme); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reconcile creates or updates the RoleBasedGroup and the resources of its roles, and returns the group
+// with its latest status
+func (r *RBGReconciler) Reconcile() (*RoleBasedGroup, error) {
+	group, err := r.reconcileRBG()
+	if err != nil {
+		return nil, err
+	}
+	for _, componentType := range roleOrder {
+		if svc, ok := r.Services[componentType]; ok {
+			if _, err := svc.Reconcile(); err != nil {
+				return nil, err
+			}
+		}
+		if autoscaler, ok := r.HPAs[componentType]; ok {
+			if _, err := autoscaler.Reconcile(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := r.deleteStaleHPAs(); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// deleteStaleHPAs deletes the autoscalers the owner of the group created for roles that are no longer autoscaled
+func (r *RBGReconciler) deleteStaleHPAs() error {
+	owner := metav1.GetControllerOfNoCopy(r.RBG)
+	for _, name := range r.staleHPAs {
+		existing := &autoscalingv2.HorizontalPodAutoscaler{}
+		if err := r.client.Get(context.TODO(), name, existing); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		controller := metav1.GetControllerOfNoCopy(existing)
+		if owner == nil || controller == nil || controller.UID != owner.UID {
+			continue
+		}
+		log.Info("Deleting HPA of role that follows another role", "namespace", name.Namespace, "name", name.Name)
+		if err := r.client.Delete(context.TODO(), existing); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RBGReconciler) reconcileRBG() (*RoleBasedGroup, error) {
+	existingObj := &unstructured.Unstructured{}
+	existingObj.SetGroupVersionKind(GroupVersionKind)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: r.RBG.Namespace, Name: r.RBG.Name}, existingObj)
+	if err != nil && !errors.IsNotFound(err) {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("RoleBasedGroup CRD is not installed: %w", err)
+		}
+		return nil, err
+	}
+
+	var existing *RoleBasedGroup
+	if err == nil {
+		if existing, err = FromUnstructured(existingObj); err != nil {
+			return nil, err
+		}
+		r.preserveReplicas(existing)
+	}
+	r.Ratios.Apply(&r.RBG.Spec)
+
+	hash, err := specHash(r.RBG.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if r.RBG.Annotations == nil {
+		r.RBG.Annotations = map[string]string{}
+	}
+	r.RBG.Annotations[constants.RoleBasedGroupSpecHash] = hash
+
+	if existing != nil && existing.Annotations[constants.RoleBasedGroupSpecHash] == hash && replicasEqual(existing.Spec, r.RBG.Spec) {
+		return existing, nil
+	}
+
+	checkResult := constants.CheckResultCreate
+	if existing != nil {
+		checkResult = constants.CheckResultUpdate
+		r.RBG.ResourceVersion = existing.ResourceVersion
+	}
+	log.Info("Reconciling RoleBasedGroup", "namespace", r.RBG.Namespace, "name", r.RBG.Name, "checkResult", checkResult.String())
+
+	obj, err := r.RBG.ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+	// Status is owned by the RBG controller
+	unstructured.RemoveNestedField(obj.Object, "status")
+	if checkResult == constants.CheckResultCreate {
+		err = r.client.Create(context.TODO(), obj)
+	} else {
+		err = r.client.Update(context.TODO(), obj)
+	}
+	if err != nil {
+		log.Error(err, "Failed to reconcile RoleBasedGroup", "namespace", r.RBG.Namespace, "name", r.RBG.Name)
+		return nil, err
+	}
+
+	if existing != nil {
+		r.RBG.Status = existing.Status
+	}
+	return r.RBG, nil
+}
+
+// preserveReplicas keeps the replicas autoscalers set on roles with a scaling adapter
+func (r *RBGReconciler) preserveReplicas(existing *RoleBasedGroup) {
+	for i := range r.RBG.Spec.Roles {
+		role := &r.RBG.Spec.Roles[i]
+		if role.ScalingAdapter == nil || !role.ScalingAdapter.Enable {
+			continue
+		}
+		if current := existing.Spec.GetRole(role.Name); current != nil && current.Replicas != nil {
+			replicas := *current.Replicas
+			role.Replicas = &replicas
+		}
+	}
+}
+
+// specHash hashes the spec without replicas, which autoscalers and role ratios change
+func specHash(spec RoleBasedGroupSpec) (string, error) {
+	roles := make([]RoleSpec, len(spec.Roles))
+	copy(roles, spec.Roles)
+	for i := range roles {
+		roles[i].Replicas = nil
+	}
+	data, err := json.Marshal(RoleBasedGroupSpec{Roles: roles})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+func replicasEqual(existing, desired RoleBasedGroupSpec) bool {
+	for _, role := range desired.Roles {
+		current := existing.GetRole(role.Name)
+		if current == nil || current.Replicas == nil || role.Replicas == nil {
+			return false
+		}
+		if *current.Replicas != *role.Replicas {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package rbg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func newTestClient(t *testing.T, objs ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(GroupVersionKind, meta.RESTScopeNamespace)
+	mapper.Add(autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+
+	return fakeclient.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...).Build(), scheme
+}
+
+func newTestClientset() *fake.Clientset {
+	return fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local",
+				"ingressDomain": "svc.cluster.local",
+				"domainTemplate": "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
+			}`,
+		},
+	})
+}
+
+func getRBG(t *testing.T, c client.Client) *RoleBasedGroup {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersionKind)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama"}, obj))
+	group, err := FromUnstructured(obj)
+	require.NoError(t, err)
+	return group
+}
+
+func TestRBGReconciler(t *testing.T) {
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"}}
+	configs := []RoleConfig{
+		testRoleConfig(v1beta1.RouterComponent, constants.RawDeployment),
+		testRoleConfig(v1beta1.EngineComponent, constants.RawDeployment),
+		testRoleConfig(v1beta1.DecoderComponent, constants.MultiNode),
+	}
+	ratios := &RoleRatios{Anchor: "engine", Ratios: map[string]int32{"engine": 2, "decoder": 1}}
+
+	c, scheme := newTestClient(t, isvc)
+	newReconciler := func() *RBGReconciler {
+		r, err := NewRBGReconciler(c, newTestClientset(), scheme, metav1.ObjectMeta{Name: "llama", Namespace: "default"}, configs, ratios)
+		require.NoError(t, err)
+		require.NoError(t, r.SetControllerReferences(isvc, scheme))
+		return r
+	}
+
+	// Roles are created in rollout order with their dependencies
+	r := newReconciler()
+	_, err := r.Reconcile()
+	require.NoError(t, err)
+
+	group := getRBG(t, c)
+	assert.True(t, metav1.IsControlledBy(group, isvc))
+	require.Len(t, group.Spec.Roles, 3)
+	assert.Equal(t, "engine", group.Spec.Roles[0].Name)
+	assert.Equal(t, []string{"engine"}, group.Spec.Roles[1].Dependencies)
+	assert.Equal(t, []string{"engine", "decoder"}, group.Spec.Roles[2].Dependencies)
+	assert.Equal(t, int32(2), *group.Spec.GetRole("engine").Replicas)
+	assert.Equal(t, int32(1), *group.Spec.GetRole("decoder").Replicas)
+	assert.Nil(t, group.Spec.GetRole("decoder").ScalingAdapter)
+	assert.NotEmpty(t, group.Annotations[constants.RoleBasedGroupSpecHash])
+	assert.Equal(t, "http://llama-engine.default.svc.cluster.local", r.URLs[v1beta1.EngineComponent].String())
+
+	engineHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-engine"}, engineHPA))
+	assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "workloads.x-k8s.io/v1alpha1",
+		Kind:       ScalingAdapterKind,
+		Name:       "llama-engine",
+	}, engineHPA.Spec.ScaleTargetRef)
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-decoder"}, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	decoderService := &corev1.Service{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-decoder"}, decoderService))
+	assert.Equal(t, map[string]string{"app": "llama-decoder", MultiNodeLeaderLabel: MultiNodeLeaderLabelValue}, decoderService.Spec.Selector)
+
+	// An unchanged group is not updated
+	resourceVersion := group.ResourceVersion
+	_, err = newReconciler().Reconcile()
+	require.NoError(t, err)
+	assert.Equal(t, resourceVersion, getRBG(t, c).ResourceVersion)
+
+	// Replicas set by the autoscaler are kept, and the following roles keep their ratio
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GroupVersionKind)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama"}, obj))
+	roles, _, _ := unstructured.NestedSlice(obj.Object, "spec", "roles")
+	require.NoError(t, unstructured.SetNestedField(roles[0].(map[string]interface{}), int64(5), "replicas"))
+	require.NoError(t, unstructured.SetNestedSlice(obj.Object, roles, "spec", "roles"))
+	require.NoError(t, c.Update(context.TODO(), obj))
+
+	_, err = newReconciler().Reconcile()
+	require.NoError(t, err)
+	group = getRBG(t, c)
+	assert.Equal(t, int32(5), *group.Spec.GetRole("engine").Replicas)
+	assert.Equal(t, int32(3), *group.Spec.GetRole("decoder").Replicas)
+	assert.Equal(t, int32(2), *group.Spec.GetRole("router").Replicas)
+}
+
+func TestRBGReconciler_DeletesHPAOfFollowingRole(t *testing.T) {
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default", UID: "isvc-uid"}}
+	stale := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "llama-decoder",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       "InferenceService",
+				Name:       "llama",
+				UID:        "isvc-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 4},
+	}
+	c, scheme := newTestClient(t, isvc, stale)
+
+	configs := []RoleConfig{
+		testRoleConfig(v1beta1.EngineComponent, constants.RawDeployment),
+		testRoleConfig(v1beta1.DecoderComponent, constants.RawDeployment),
+	}
+	ratios := &RoleRatios{Anchor: "engine", Ratios: map[string]int32{"engine": 1, "decoder": 1}}
+	r, err := NewRBGReconciler(c, newTestClientset(), scheme, metav1.ObjectMeta{Name: "llama", Namespace: "default"}, configs, ratios)
+	require.NoError(t, err)
+	require.NoError(t, r.SetControllerReferences(isvc, scheme))
+
+	_, err = r.Reconcile()
+	require.NoError(t, err)
+
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-decoder"}, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
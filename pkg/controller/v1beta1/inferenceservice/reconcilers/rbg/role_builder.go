@@ -0,0 +1,236 @@
+package rbg
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// RoleConfig is the rendered configuration of a component deployed as a role of a RoleBasedGroup
+type RoleConfig struct {
+	ComponentType  v1beta1.ComponentType
+	DeploymentMode constants.DeploymentModeType
+	ObjectMeta     metav1.ObjectMeta
+	// PodSpec is the pod spec of the component, or of the leader pods of a MultiNode component
+	PodSpec                *corev1.PodSpec
+	WorkerPodSpec          *corev1.PodSpec
+	WorkerSize             int
+	ComponentExtensionSpec *v1beta1.ComponentExtensionSpec
+}
+
+// MultiNodeLeaderLabel selects the leader pods of MultiNode roles, like the labels of LeaderWorkerSet deployments
+const (
+	MultiNodeLeaderLabel      = "ray.io/node-type"
+	MultiNodeLeaderLabelValue = "head"
+)
+
+// roleOrder is the order roles are listed and rolled out in. Each role depends on the roles before it.
+var roleOrder = []v1beta1.ComponentType{
+	v1beta1.EngineComponent,
+	v1beta1.DecoderComponent,
+	v1beta1.RouterComponent,
+}
+
+// roleDependencies returns the roles of the configs a role waits for before it is created or rolled out
+func roleDependencies(role v1beta1.ComponentType, configs []RoleConfig) []string {
+	present := map[v1beta1.ComponentType]bool{}
+	for _, config := range configs {
+		present[config.ComponentType] = true
+	}
+	var dependencies []string
+	for _, componentType := range roleOrder {
+		if componentType == role {
+			break
+		}
+		if present[componentType] {
+			dependencies = append(dependencies, string(componentType))
+		}
+	}
+	return dependencies
+}
+
+// BuildRole renders the role of a component. Roles with a scaling adapter are scaled by an autoscaler through
+// the RoleBasedGroupScalingAdapter RBG creates for them.
+func BuildRole(config RoleConfig, dependencies []string, scalingAdapter bool) (*RoleSpec, error) {
+	if config.PodSpec == nil {
+		return nil, fmt.Errorf("pod spec of %s role is nil", config.ComponentType)
+	}
+
+	replicas := int32(constants.DefaultMinReplicas)
+	if config.ComponentExtensionSpec != nil && config.ComponentExtensionSpec.MinReplicas != nil {
+		replicas = int32(*config.ComponentExtensionSpec.MinReplicas)
+	}
+
+	role := &RoleSpec{
+		Name:            string(config.ComponentType),
+		Replicas:        &replicas,
+		Dependencies:    dependencies,
+		RolloutStrategy: rolloutStrategy(config.ComponentExtensionSpec),
+	}
+	if scalingAdapter {
+		role.ScalingAdapter = &ScalingAdapter{Enable: true}
+	}
+
+	podMeta := podObjectMeta(config.ObjectMeta)
+	switch config.DeploymentMode {
+	case constants.RawDeployment:
+		role.Workload = WorkloadSpec{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"}
+		podMeta.Labels[constants.RawDeploymentAppLabel] = constants.GetRawServiceLabel(config.ObjectMeta.Name)
+		role.Template = corev1.PodTemplateSpec{ObjectMeta: podMeta, Spec: *config.PodSpec}
+	case constants.MultiNode:
+		// Worker pods use the role template, and leader pods patch it with the leader pod spec
+		workerPodSpec := config.WorkerPodSpec
+		if workerPodSpec == nil {
+			workerPodSpec = config.PodSpec
+		}
+		workerMeta := *podMeta.DeepCopy()
+		utils.RemovePodAnnotations(&workerMeta, []string{
+			constants.PrometheusPathAnnotationKey,
+			constants.PrometheusPortAnnotationKey,
+			constants.PrometheusScrapeAnnotationKey,
+		})
+		role.Workload = WorkloadSpec{APIVersion: lws.GroupVersion.String(), Kind: constants.LWSKind}
+		role.Template = corev1.PodTemplateSpec{ObjectMeta: workerMeta, Spec: *workerPodSpec}
+
+		podMeta.Labels[constants.RawDeploymentAppLabel] = constants.GetRawServiceLabel(config.ObjectMeta.Name)
+		podMeta.Labels[MultiNodeLeaderLabel] = MultiNodeLeaderLabelValue
+		leaderPatch, err := rawTemplate(corev1.PodTemplateSpec{ObjectMeta: podMeta, Spec: *config.PodSpec})
+		if err != nil {
+			return nil, err
+		}
+		// The size of a group counts the leader as well as the workers
+		size := int32(config.WorkerSize + 1)
+		role.LeaderWorkerSet = &LeaderWorkerSetSpec{Size: &size, PatchLeaderTemplate: leaderPatch}
+	default:
+		return nil, fmt.Errorf("deployment mode %s is not supported for %s role of a RoleBasedGroup", config.DeploymentMode, config.ComponentType)
+	}
+	return role, nil
+}
+
+// podObjectMeta returns the metadata of the pods of a component
+func podObjectMeta(componentMeta metav1.ObjectMeta) metav1.ObjectMeta {
+	podMeta := metav1.ObjectMeta{
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+	for k, v := range componentMeta.Labels {
+		podMeta.Labels[k] = v
+	}
+	for k, v := range componentMeta.Annotations {
+		podMeta.Annotations[k] = v
+	}
+	utils.SetPodLabelsFromAnnotations(&podMeta)
+	return podMeta
+}
+
+// rolloutStrategy maps the rolling update of the component deployment strategy onto the role
+func rolloutStrategy(componentExt *v1beta1.ComponentExtensionSpec) *RolloutStrategy {
+	if componentExt == nil || componentExt.DeploymentStrategy == nil ||
+		componentExt.DeploymentStrategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+		return nil
+	}
+	strategy := &RolloutStrategy{Type: string(appsv1.RollingUpdateDeploymentStrategyType)}
+	if rollingUpdate := componentExt.DeploymentStrategy.RollingUpdate; rollingUpdate != nil {
+		strategy.RollingUpdate = &RollingUpdate{
+			MaxUnavailable: rollingUpdate.MaxUnavailable,
+			MaxSurge:       rollingUpdate.MaxSurge,
+		}
+	}
+	return strategy
+}
+
+func rawTemplate(template corev1.PodTemplateSpec) (*runtime.RawExtension, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: data}, nil
+}
+
+// RoleRatios keeps the replicas of roles in proportion to the replicas of an anchor role. The anchor is the
+// engine when it is listed, otherwise the first listed role in rollout order.
+type RoleRatios struct {
+	Anchor string
+	Ratios map[string]int32
+}
+
+// ParseRoleRatios parses a ratio annotation such as "engine=2,decoder=1" for the given roles.
+// It returns nil when no ratio is set.
+func ParseRoleRatios(value string, roles []string) (*RoleRatios, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	known := map[string]bool{}
+	for _, role := range roles {
+		known[role] = true
+	}
+
+	ratios := &RoleRatios{Ratios: map[string]int32{}}
+	for _, entry := range strings.Split(value, ",") {
+		name, ratio, found := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid role ratio %q, expected <role>=<ratio>", entry)
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("role ratio for %s, which is not deployed", name)
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(ratio), 10, 32)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid ratio %q for role %s, expected a positive integer", ratio, name)
+		}
+		ratios.Ratios[name] = int32(n)
+	}
+	if len(ratios.Ratios) < 2 {
+		return nil, fmt.Errorf("role ratio %q must list at least two roles", value)
+	}
+
+	for _, componentType := range roleOrder {
+		if _, ok := ratios.Ratios[string(componentType)]; ok {
+			ratios.Anchor = string(componentType)
+			break
+		}
+	}
+	return ratios, nil
+}
+
+// Follows reports whether the replicas of the role are derived from the anchor role
+func (r *RoleRatios) Follows(role string) bool {
+	if r == nil || role == r.Anchor {
+		return false
+	}
+	_, ok := r.Ratios[role]
+	return ok
+}
+
+// Apply sets the replicas of the following roles from the replicas of the anchor role, rounding up
+func (r *RoleRatios) Apply(spec *RoleBasedGroupSpec) {
+	if r == nil {
+		return
+	}
+	anchor := spec.GetRole(r.Anchor)
+	if anchor == nil || anchor.Replicas == nil {
+		return
+	}
+	for i := range spec.Roles {
+		role := &spec.Roles[i]
+		if !r.Follows(role.Name) {
+			continue
+		}
+		replicas := int32(math.Ceil(float64(*anchor.Replicas) * float64(r.Ratios[role.Name]) / float64(r.Ratios[r.Anchor])))
+		role.Replicas = &replicas
+	}
+}
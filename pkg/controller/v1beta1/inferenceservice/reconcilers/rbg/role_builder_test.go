@@ -0,0 +1,216 @@
+package rbg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func testRoleConfig(componentType v1beta1.ComponentType, mode constants.DeploymentModeType) RoleConfig {
+	config := RoleConfig{
+		ComponentType:  componentType,
+		DeploymentMode: mode,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "llama-" + string(componentType),
+			Namespace:   "default",
+			Labels:      map[string]string{constants.OMEComponentLabel: string(componentType)},
+			Annotations: map[string]string{constants.PrometheusScrapeAnnotationKey: "true"},
+		},
+		PodSpec: &corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ome-container", Image: "sglang:leader"}},
+		},
+		ComponentExtensionSpec: &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(2), MaxReplicas: 4},
+	}
+	if mode == constants.MultiNode {
+		config.WorkerPodSpec = &corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ome-container", Image: "sglang:worker"}},
+		}
+		config.WorkerSize = 3
+	}
+	return config
+}
+
+func TestBuildRole(t *testing.T) {
+	maxSurge := intstr.FromString("25%")
+
+	tests := []struct {
+		name           string
+		config         RoleConfig
+		dependencies   []string
+		scalingAdapter bool
+		expectErr      bool
+		validate       func(t *testing.T, role *RoleSpec)
+	}{
+		{
+			name:           "raw deployment role",
+			config:         testRoleConfig(v1beta1.EngineComponent, constants.RawDeployment),
+			scalingAdapter: true,
+			validate: func(t *testing.T, role *RoleSpec) {
+				assert.Equal(t, "engine", role.Name)
+				assert.Equal(t, int32(2), *role.Replicas)
+				assert.Equal(t, WorkloadSpec{APIVersion: "apps/v1", Kind: "Deployment"}, role.Workload)
+				assert.Equal(t, "llama-engine", role.Template.Labels[constants.RawDeploymentAppLabel])
+				assert.Equal(t, "engine", role.Template.Labels[constants.OMEComponentLabel])
+				assert.Equal(t, "sglang:leader", role.Template.Spec.Containers[0].Image)
+				assert.Equal(t, &ScalingAdapter{Enable: true}, role.ScalingAdapter)
+				assert.Nil(t, role.LeaderWorkerSet)
+			},
+		},
+		{
+			name:         "multi-node role patches the leader onto the worker template",
+			config:       testRoleConfig(v1beta1.DecoderComponent, constants.MultiNode),
+			dependencies: []string{"engine"},
+			validate: func(t *testing.T, role *RoleSpec) {
+				assert.Equal(t, []string{"engine"}, role.Dependencies)
+				assert.Equal(t, WorkloadSpec{APIVersion: "leaderworkerset.x-k8s.io/v1", Kind: constants.LWSKind}, role.Workload)
+				assert.Equal(t, "sglang:worker", role.Template.Spec.Containers[0].Image)
+				assert.NotContains(t, role.Template.Labels, constants.RawDeploymentAppLabel)
+				assert.NotContains(t, role.Template.Annotations, constants.PrometheusScrapeAnnotationKey)
+				assert.Nil(t, role.ScalingAdapter)
+
+				require.NotNil(t, role.LeaderWorkerSet)
+				assert.Equal(t, int32(4), *role.LeaderWorkerSet.Size)
+				leader := corev1.PodTemplateSpec{}
+				require.NoError(t, json.Unmarshal(role.LeaderWorkerSet.PatchLeaderTemplate.Raw, &leader))
+				assert.Equal(t, "sglang:leader", leader.Spec.Containers[0].Image)
+				assert.Equal(t, "llama-decoder", leader.Labels[constants.RawDeploymentAppLabel])
+				assert.Equal(t, MultiNodeLeaderLabelValue, leader.Labels[MultiNodeLeaderLabel])
+				assert.Equal(t, "true", leader.Annotations[constants.PrometheusScrapeAnnotationKey])
+			},
+		},
+		{
+			name: "rolling update strategy",
+			config: func() RoleConfig {
+				config := testRoleConfig(v1beta1.RouterComponent, constants.RawDeployment)
+				config.ComponentExtensionSpec.DeploymentStrategy = &appsv1.DeploymentStrategy{
+					Type:          appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+				}
+				return config
+			}(),
+			validate: func(t *testing.T, role *RoleSpec) {
+				assert.Equal(t, &RolloutStrategy{
+					Type:          "RollingUpdate",
+					RollingUpdate: &RollingUpdate{MaxSurge: &maxSurge},
+				}, role.RolloutStrategy)
+			},
+		},
+		{
+			name:      "serverless is not supported",
+			config:    testRoleConfig(v1beta1.EngineComponent, constants.Serverless),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := BuildRole(tt.config, tt.dependencies, tt.scalingAdapter)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, role)
+		})
+	}
+}
+
+func TestRoleDependencies(t *testing.T) {
+	configs := []RoleConfig{
+		{ComponentType: v1beta1.RouterComponent},
+		{ComponentType: v1beta1.EngineComponent},
+		{ComponentType: v1beta1.DecoderComponent},
+	}
+	assert.Nil(t, roleDependencies(v1beta1.EngineComponent, configs))
+	assert.Equal(t, []string{"engine"}, roleDependencies(v1beta1.DecoderComponent, configs))
+	assert.Equal(t, []string{"engine", "decoder"}, roleDependencies(v1beta1.RouterComponent, configs))
+	assert.Equal(t, []string{"engine"}, roleDependencies(v1beta1.RouterComponent, configs[:2]))
+}
+
+func TestParseRoleRatios(t *testing.T) {
+	roles := []string{"engine", "decoder", "router"}
+
+	tests := []struct {
+		name      string
+		value     string
+		expected  *RoleRatios
+		expectErr bool
+	}{
+		{
+			name:  "no ratio",
+			value: "",
+		},
+		{
+			name:     "engine anchors the ratio",
+			value:    "decoder=1, engine=2",
+			expected: &RoleRatios{Anchor: "engine", Ratios: map[string]int32{"engine": 2, "decoder": 1}},
+		},
+		{
+			name:     "decoder anchors the ratio without engine",
+			value:    "router=1,decoder=4",
+			expected: &RoleRatios{Anchor: "decoder", Ratios: map[string]int32{"decoder": 4, "router": 1}},
+		},
+		{
+			name:      "single role",
+			value:     "engine=1",
+			expectErr: true,
+		},
+		{
+			name:      "unknown role",
+			value:     "engine=1,prefill=2",
+			expectErr: true,
+		},
+		{
+			name:      "invalid ratio",
+			value:     "engine=0,decoder=1",
+			expectErr: true,
+		},
+		{
+			name:      "missing ratio",
+			value:     "engine,decoder=1",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratios, err := ParseRoleRatios(tt.value, roles)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ratios)
+		})
+	}
+}
+
+func TestRoleRatiosApply(t *testing.T) {
+	ratios := &RoleRatios{Anchor: "engine", Ratios: map[string]int32{"engine": 2, "decoder": 1, "router": 3}}
+	spec := RoleBasedGroupSpec{Roles: []RoleSpec{
+		{Name: "engine", Replicas: ptr.To(int32(5))},
+		{Name: "decoder", Replicas: ptr.To(int32(1))},
+		{Name: "router", Replicas: ptr.To(int32(1))},
+	}}
+
+	ratios.Apply(&spec)
+
+	assert.Equal(t, int32(5), *spec.GetRole("engine").Replicas)
+	assert.Equal(t, int32(3), *spec.GetRole("decoder").Replicas)
+	assert.Equal(t, int32(8), *spec.GetRole("router").Replicas)
+	assert.True(t, ratios.Follows("decoder"))
+	assert.False(t, ratios.Follows("engine"))
+
+	var noRatios *RoleRatios
+	assert.False(t, noRatios.Follows("decoder"))
+}
@@ -0,0 +1,147 @@
+package rbg
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// The RoleBasedGroup API is not vendored, so the fields OME sets are mirrored here and converted to
+// unstructured objects when talking to the API server.
+
+// GroupVersion is the API group and version of RoleBasedGroups
+var GroupVersion = schema.GroupVersion{Group: "workloads.x-k8s.io", Version: "v1alpha1"}
+
+// GroupVersionKind of RoleBasedGroups
+var GroupVersionKind = GroupVersion.WithKind(constants.RoleBasedGroupKind)
+
+// ScalingAdapterKind is the kind of the scale target RBG creates for roles with a scaling adapter
+const ScalingAdapterKind = "RoleBasedGroupScalingAdapter"
+
+// RoleBasedGroup deploys a set of roles as one workload
+type RoleBasedGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleBasedGroupSpec   `json:"spec,omitempty"`
+	Status RoleBasedGroupStatus `json:"status,omitempty"`
+}
+
+// RoleBasedGroupSpec lists the roles of the group
+type RoleBasedGroupSpec struct {
+	Roles []RoleSpec `json:"roles"`
+}
+
+// RoleSpec describes one role of the group. RBG names the workload of a role {group}-{role}.
+type RoleSpec struct {
+	Name     string `json:"name"`
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Dependencies are the roles that must be ready before this role is created or rolled out
+	Dependencies    []string               `json:"dependencies,omitempty"`
+	Workload        WorkloadSpec           `json:"workload,omitempty"`
+	RolloutStrategy *RolloutStrategy       `json:"rolloutStrategy,omitempty"`
+	Template        corev1.PodTemplateSpec `json:"template"`
+	LeaderWorkerSet *LeaderWorkerSetSpec   `json:"leaderWorkerSet,omitempty"`
+	ScalingAdapter  *ScalingAdapter        `json:"scalingAdapter,omitempty"`
+}
+
+// WorkloadSpec is the kind of workload RBG creates for a role
+type WorkloadSpec struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// RolloutStrategy controls how the workload of a role is updated
+type RolloutStrategy struct {
+	Type          string         `json:"type,omitempty"`
+	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdate bounds the pods of a role that are unavailable or surged during a rollout
+type RollingUpdate struct {
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// LeaderWorkerSetSpec configures roles deployed as LeaderWorkerSets. The leader pods are the role template
+// patched with PatchLeaderTemplate, and the worker pods the role template patched with PatchWorkerTemplate.
+type LeaderWorkerSetSpec struct {
+	Size                *int32                `json:"size,omitempty"`
+	PatchLeaderTemplate *runtime.RawExtension `json:"patchLeaderTemplate,omitempty"`
+	PatchWorkerTemplate *runtime.RawExtension `json:"patchWorkerTemplate,omitempty"`
+}
+
+// ScalingAdapter makes RBG create a RoleBasedGroupScalingAdapter named {group}-{role} that autoscalers target
+type ScalingAdapter struct {
+	Enable bool `json:"enable"`
+}
+
+// RoleBasedGroupStatus reports the readiness of the group and its roles
+type RoleBasedGroupStatus struct {
+	Conditions   []metav1.Condition `json:"conditions,omitempty"`
+	RoleStatuses []RoleStatus       `json:"roleStatuses,omitempty"`
+}
+
+// RoleStatus reports the replicas of a role
+type RoleStatus struct {
+	Name          string `json:"name"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	Replicas      int32  `json:"replicas"`
+}
+
+// RoleBasedGroupReady is the condition RBG sets once all roles are ready
+const RoleBasedGroupReady = "Ready"
+
+// GetRole returns the role with the given name, or nil
+func (s *RoleBasedGroupSpec) GetRole(name string) *RoleSpec {
+	for i := range s.Roles {
+		if s.Roles[i].Name == name {
+			return &s.Roles[i]
+		}
+	}
+	return nil
+}
+
+// GetRoleStatus returns the status of the role with the given name, or nil
+func (s *RoleBasedGroupStatus) GetRoleStatus(name string) *RoleStatus {
+	for i := range s.RoleStatuses {
+		if s.RoleStatuses[i].Name == name {
+			return &s.RoleStatuses[i]
+		}
+	}
+	return nil
+}
+
+// ToUnstructured converts the group to an unstructured object
+func (g *RoleBasedGroup) ToUnstructured() (*unstructured.Unstructured, error) {
+	g.SetGroupVersionKind(GroupVersionKind)
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// FromUnstructured converts an unstructured object to a group
+func FromUnstructured(obj *unstructured.Unstructured) (*RoleBasedGroup, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	group := &RoleBasedGroup{}
+	if err := json.Unmarshal(data, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
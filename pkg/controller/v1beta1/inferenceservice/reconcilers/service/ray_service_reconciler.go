@@ -67,10 +67,10 @@ func buildRayDefaultPorts() []corev1.ServicePort {
 	return []corev1.ServicePort{
 		{
 			Name: "dashboard",
-			Port: 8265,
+			Port: constants.RayDashboardPort,
 			TargetPort: intstr.IntOrString{
 				Type:   intstr.Int,
-				IntVal: 8265,
+				IntVal: constants.RayDashboardPort,
 			},
 		},
 		{
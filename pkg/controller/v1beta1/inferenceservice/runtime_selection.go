@@ -0,0 +1,71 @@
+package inferenceservice
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	knapis "knative.dev/pkg/apis"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+const (
+	// RuntimeAutoSelectedReason is used when the runtime was chosen by the runtime selector.
+	RuntimeAutoSelectedReason = "AutoSelected"
+	// RuntimeUserSpecifiedReason is used when the runtime was named in the InferenceService spec.
+	RuntimeUserSpecifiedReason = "UserSpecified"
+	// NoCompatibleRuntimeReason is used when no runtime supports the model.
+	NoCompatibleRuntimeReason = "NoCompatibleRuntime"
+)
+
+// setRuntimeSelectionStatus records the runtime selection explanation on the InferenceService status,
+// sets the RuntimeSelected condition and emits an event when the selected runtime changes.
+func (r *InferenceServiceReconciler) setRuntimeSelectionStatus(isvc *v1beta1.InferenceService, explanation *runtimeselector.SelectionExplanation) {
+	previous := ""
+	if isvc.Status.RuntimeSelection != nil {
+		previous = isvc.Status.RuntimeSelection.Runtime
+	}
+
+	isvc.Status.RuntimeSelection = explanation.ToStatus(
+		runtimeselector.DefaultExplanationCandidateLimit,
+		runtimeselector.DefaultExplanationRejectionLimit,
+	)
+
+	if explanation.Selected == nil {
+		isvc.Status.SetCondition(v1beta1.RuntimeSelected, &knapis.Condition{
+			Type:    v1beta1.RuntimeSelected,
+			Status:  v1.ConditionFalse,
+			Reason:  NoCompatibleRuntimeReason,
+			Message: explanation.Summary(),
+		})
+		return
+	}
+
+	isvc.Status.SetCondition(v1beta1.RuntimeSelected, &knapis.Condition{
+		Type:    v1beta1.RuntimeSelected,
+		Status:  v1.ConditionTrue,
+		Reason:  RuntimeAutoSelectedReason,
+		Message: explanation.Summary(),
+	})
+
+	if previous != explanation.Selected.Name {
+		r.Recorder.Event(isvc, v1.EventTypeNormal, "RuntimeSelected", explanation.Summary())
+	}
+}
+
+// setUserSpecifiedRuntimeStatus records a runtime that was explicitly named in the InferenceService spec.
+func (r *InferenceServiceReconciler) setUserSpecifiedRuntimeStatus(isvc *v1beta1.InferenceService, rtName string, isCluster bool) {
+	isvc.Status.RuntimeSelection = &v1beta1.RuntimeSelectionStatus{
+		Runtime:          rtName,
+		IsClusterRuntime: isCluster,
+		UserSpecified:    true,
+		Reason:           "runtime specified in InferenceService spec",
+	}
+	isvc.Status.SetCondition(v1beta1.RuntimeSelected, &knapis.Condition{
+		Type:    v1beta1.RuntimeSelected,
+		Status:  v1.ConditionTrue,
+		Reason:  RuntimeUserSpecifiedReason,
+		Message: fmt.Sprintf("runtime %s specified in InferenceService spec and validated against the model", rtName),
+	})
+}
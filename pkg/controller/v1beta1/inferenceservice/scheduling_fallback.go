@@ -0,0 +1,237 @@
+package inferenceservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+const (
+	// SchedulingFallbackReason is used when the controller abandons an unschedulable runtime and accelerator class combination.
+	SchedulingFallbackReason = "SchedulingFallback"
+	// SchedulingFallbackExhaustedReason is used when every compatible combination has been abandoned.
+	SchedulingFallbackExhaustedReason = "SchedulingFallbackExhausted"
+)
+
+// schedulingChoice is the outcome of walking the ranked runtimes while skipping abandoned combinations.
+type schedulingChoice struct {
+	// runtimeIndex is the index of the chosen runtime in the ranked candidates
+	runtimeIndex int
+	// excludedClasses are the accelerator classes already abandoned for the chosen runtime
+	excludedClasses []string
+	// skipReasons explains why each higher ranked runtime was skipped
+	skipReasons map[string]string
+	// exhausted is true when every combination was abandoned and the top-ranked one is used again
+	exhausted bool
+}
+
+// schedulingFallbackEnabled returns whether the InferenceService may fall back to other runtimes
+// or accelerator classes when its pods cannot be scheduled.
+func schedulingFallbackEnabled(isvc *v1beta1.InferenceService, fallbackConfig *controllerconfig.SchedulingFallbackConfig) bool {
+	if fallbackConfig == nil || !fallbackConfig.Enabled {
+		return false
+	}
+	return isvc.Annotations[constants.DisableSchedulingFallbackAnnotationKey] != "true"
+}
+
+// activeSchedulingFallback returns the fallback status if it applies to the current generation.
+// Attempts recorded for an older spec are ignored so that spec changes start from the top-ranked choice.
+func activeSchedulingFallback(isvc *v1beta1.InferenceService) *v1beta1.SchedulingFallbackStatus {
+	fallback := isvc.Status.SchedulingFallback
+	if fallback == nil || fallback.ObservedGeneration != isvc.Generation {
+		return nil
+	}
+	return fallback
+}
+
+// abandonedAcceleratorClasses returns the accelerator classes abandoned for a runtime, and whether
+// the runtime itself was abandoned because it had no accelerator class to fall back from.
+func abandonedAcceleratorClasses(attempts []v1beta1.SchedulingAttempt, runtime string) ([]string, bool) {
+	var classes []string
+	for _, attempt := range attempts {
+		if attempt.Runtime != runtime {
+			continue
+		}
+		if attempt.AcceleratorClass == "" {
+			return nil, true
+		}
+		classes = append(classes, attempt.AcceleratorClass)
+	}
+	return classes, false
+}
+
+// withExcludedAcceleratorClasses returns a copy of the InferenceService whose accelerator constraints
+// exclude the given classes, so that policy-based accelerator selection picks the next best class.
+func withExcludedAcceleratorClasses(isvc *v1beta1.InferenceService, excluded []string) *v1beta1.InferenceService {
+	if len(excluded) == 0 {
+		return isvc
+	}
+	target := isvc.DeepCopy()
+	if target.Spec.AcceleratorSelector == nil {
+		target.Spec.AcceleratorSelector = &v1beta1.AcceleratorSelector{}
+	}
+	if target.Spec.AcceleratorSelector.Constraints == nil {
+		target.Spec.AcceleratorSelector.Constraints = &v1beta1.AcceleratorConstraints{}
+	}
+	constraints := target.Spec.AcceleratorSelector.Constraints
+	constraints.ExcludedClasses = append(constraints.ExcludedClasses, excluded...)
+	return target
+}
+
+// chooseSchedulableRuntime walks the ranked runtimes and returns the first one that still has an
+// engine accelerator class that has not been abandoned for it. When every combination has been
+// abandoned, the top-ranked runtime is chosen again and the fallback is marked exhausted.
+func (r *InferenceServiceReconciler) chooseSchedulableRuntime(ctx context.Context, isvc *v1beta1.InferenceService, candidates []runtimeselector.RuntimeMatch, fallbackConfig *controllerconfig.SchedulingFallbackConfig) (*schedulingChoice, error) {
+	fallback := activeSchedulingFallback(isvc)
+	if !schedulingFallbackEnabled(isvc, fallbackConfig) || fallback == nil || len(fallback.Attempts) == 0 {
+		return &schedulingChoice{}, nil
+	}
+
+	skipReasons := make(map[string]string)
+	for i, candidate := range candidates {
+		excluded, runtimeAbandoned := abandonedAcceleratorClasses(fallback.Attempts, candidate.Name)
+		if runtimeAbandoned {
+			skipReasons[candidate.Name] = "pods could not be scheduled"
+			continue
+		}
+		if len(excluded) == 0 {
+			return &schedulingChoice{runtimeIndex: i, skipReasons: skipReasons}, nil
+		}
+
+		_, acName, err := r.AcceleratorClassSelector.GetAcceleratorClass(ctx, withExcludedAcceleratorClasses(isvc, excluded), candidate.Spec, v1beta1.EngineComponent)
+		if err != nil {
+			return nil, err
+		}
+		// Either every class was abandoned, or the requested class cannot be changed
+		if acName == "" || slices.Contains(excluded, acName) {
+			skipReasons[candidate.Name] = fmt.Sprintf("pods could not be scheduled on accelerator classes %v", excluded)
+			continue
+		}
+		return &schedulingChoice{runtimeIndex: i, excludedClasses: excluded, skipReasons: skipReasons}, nil
+	}
+
+	r.markSchedulingFallbackExhausted(isvc)
+	return &schedulingChoice{exhausted: true}, nil
+}
+
+// markSchedulingFallbackExhausted records that no combination could be scheduled and emits an event the first time.
+func (r *InferenceServiceReconciler) markSchedulingFallbackExhausted(isvc *v1beta1.InferenceService) {
+	fallback := activeSchedulingFallback(isvc)
+	if fallback == nil || fallback.Exhausted {
+		return
+	}
+	fallback.Exhausted = true
+	r.Recorder.Eventf(isvc, v1.EventTypeWarning, SchedulingFallbackExhaustedReason,
+		"All %d runtime and accelerator class combinations failed to schedule, using the top-ranked runtime", len(fallback.Attempts))
+}
+
+// checkSchedulingFallback abandons the current runtime and accelerator class combination when all of its
+// pods have been unschedulable for longer than the configured timeout. It returns a requeue result so
+// that the next reconcile selects the next best combination, or waits for the timeout to elapse.
+func (r *InferenceServiceReconciler) checkSchedulingFallback(ctx context.Context, isvc *v1beta1.InferenceService, rtName, acName string, fallbackConfig *controllerconfig.SchedulingFallbackConfig) (ctrl.Result, error) {
+	if !schedulingFallbackEnabled(isvc, fallbackConfig) {
+		return ctrl.Result{}, nil
+	}
+
+	var notBefore time.Time
+	if fallback := activeSchedulingFallback(isvc); fallback != nil {
+		if fallback.Exhausted {
+			return ctrl.Result{}, nil
+		}
+		// Pods left over from an abandoned combination must not count against the current one
+		if n := len(fallback.Attempts); n > 0 {
+			notBefore = fallback.Attempts[n-1].AbandonedTime.Time
+		}
+	}
+
+	since, message, err := r.unschedulableSince(ctx, isvc, rtName, notBefore)
+	if err != nil || since == nil {
+		return ctrl.Result{}, err
+	}
+
+	timeout := time.Duration(fallbackConfig.UnschedulableTimeoutSeconds) * time.Second
+	if waited := time.Since(since.Time); waited < timeout {
+		return ctrl.Result{RequeueAfter: timeout - waited}, nil
+	}
+
+	r.recordSchedulingAttempt(isvc, rtName, acName, message)
+	r.Log.Info("Pods unschedulable past timeout, falling back", "namespace", isvc.Namespace, "inferenceService", isvc.Name,
+		"runtime", rtName, "acceleratorClass", acName, "timeout", timeout)
+	r.Recorder.Eventf(isvc, v1.EventTypeWarning, SchedulingFallbackReason,
+		"Pods for runtime %s on accelerator class %q were unschedulable for more than %s, falling back to the next candidate: %s",
+		rtName, acName, timeout, message)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// recordSchedulingAttempt appends an abandoned combination to the InferenceService status.
+func (r *InferenceServiceReconciler) recordSchedulingAttempt(isvc *v1beta1.InferenceService, rtName, acName, message string) {
+	fallback := activeSchedulingFallback(isvc)
+	if fallback == nil {
+		fallback = &v1beta1.SchedulingFallbackStatus{ObservedGeneration: isvc.Generation}
+		isvc.Status.SchedulingFallback = fallback
+	}
+	fallback.Attempts = append(fallback.Attempts, v1beta1.SchedulingAttempt{
+		Runtime:          rtName,
+		AcceleratorClass: acName,
+		Reason:           message,
+		AbandonedTime:    metav1.Now(),
+	})
+}
+
+// unschedulableSince returns the earliest time the runtime's pods were marked unschedulable together with the
+// scheduler message. It returns nil unless every pod created after notBefore is pending as unschedulable.
+func (r *InferenceServiceReconciler) unschedulableSince(ctx context.Context, isvc *v1beta1.InferenceService, rtName string, notBefore time.Time) (*metav1.Time, string, error) {
+	selector := labels.Set{
+		constants.InferenceServicePodLabelKey: isvc.Name,
+		constants.ServingRuntimeLabelKey:      rtName,
+	}.String()
+	pods, err := r.Clientset.CoreV1().Pods(isvc.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list pods for scheduling fallback: %w", err)
+	}
+
+	var since *metav1.Time
+	message := ""
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.CreationTimestamp.Time.Before(notBefore) {
+			continue
+		}
+		condition := unschedulableCondition(pod)
+		if condition == nil {
+			// At least one pod was scheduled, so the combination has capacity
+			return nil, "", nil
+		}
+		if since == nil || condition.LastTransitionTime.Before(since) {
+			transition := condition.LastTransitionTime
+			since = &transition
+			message = condition.Message
+		}
+	}
+	return since, message, nil
+}
+
+// unschedulableCondition returns the PodScheduled condition of a pending pod the scheduler could not place.
+func unschedulableCondition(pod *v1.Pod) *v1.PodCondition {
+	if pod.Status.Phase != v1.PodPending {
+		return nil
+	}
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason == v1.PodReasonUnschedulable {
+			return condition
+		}
+	}
+	return nil
+}
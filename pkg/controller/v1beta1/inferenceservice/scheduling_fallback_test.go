@@ -0,0 +1,278 @@
+package inferenceservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/acceleratorclassselector"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
+)
+
+func fallbackTestPod(name, runtime string, created time.Time, unschedulableSince *time.Time) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "test-isvc",
+				constants.ServingRuntimeLabelKey:      runtime,
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if unschedulableSince != nil {
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             corev1.PodReasonUnschedulable,
+			Message:            "0/4 nodes are available: 4 Insufficient nvidia.com/gpu.",
+			LastTransitionTime: metav1.NewTime(*unschedulableSince),
+		}}
+	}
+	return pod
+}
+
+func TestCheckSchedulingFallback(t *testing.T) {
+	now := time.Now()
+	longAgo := now.Add(-time.Hour)
+	recently := now.Add(-time.Minute)
+	fallbackConfig := &controllerconfig.SchedulingFallbackConfig{Enabled: true, UnschedulableTimeoutSeconds: 600}
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		fallbackStatus   *v1beta1.SchedulingFallbackStatus
+		config           *controllerconfig.SchedulingFallbackConfig
+		pods             []*corev1.Pod
+		expectFallback   bool
+		expectRequeueSet bool
+	}{
+		{
+			name:           "unschedulable past timeout falls back",
+			config:         fallbackConfig,
+			pods:           []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo), fallbackTestPod("p2", "rt-a", longAgo, &recently)},
+			expectFallback: true,
+		},
+		{
+			name:             "unschedulable within timeout requeues",
+			config:           fallbackConfig,
+			pods:             []*corev1.Pod{fallbackTestPod("p1", "rt-a", recently, &recently)},
+			expectRequeueSet: true,
+		},
+		{
+			name:   "scheduled pod means capacity exists",
+			config: fallbackConfig,
+			pods:   []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo), fallbackTestPod("p2", "rt-a", longAgo, nil)},
+		},
+		{
+			name:   "pods of other runtimes are ignored",
+			config: fallbackConfig,
+			pods:   []*corev1.Pod{fallbackTestPod("p1", "rt-b", longAgo, &longAgo)},
+		},
+		{
+			name:        "opt-out annotation",
+			annotations: map[string]string{constants.DisableSchedulingFallbackAnnotationKey: "true"},
+			config:      fallbackConfig,
+			pods:        []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo)},
+		},
+		{
+			name:   "disabled in config",
+			config: &controllerconfig.SchedulingFallbackConfig{Enabled: false, UnschedulableTimeoutSeconds: 600},
+			pods:   []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo)},
+		},
+		{
+			name:   "pods older than the last fallback are ignored",
+			config: fallbackConfig,
+			fallbackStatus: &v1beta1.SchedulingFallbackStatus{
+				ObservedGeneration: 1,
+				Attempts:           []v1beta1.SchedulingAttempt{{Runtime: "rt-b", AbandonedTime: metav1.NewTime(recently)}},
+			},
+			pods: []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo)},
+		},
+		{
+			name:   "exhausted fallback stops checking",
+			config: fallbackConfig,
+			fallbackStatus: &v1beta1.SchedulingFallbackStatus{
+				ObservedGeneration: 1,
+				Exhausted:          true,
+			},
+			pods: []*corev1.Pod{fallbackTestPod("p1", "rt-a", longAgo, &longAgo)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			for _, pod := range tt.pods {
+				_, err := clientset.CoreV1().Pods("default").Create(context.TODO(), pod, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &InferenceServiceReconciler{
+				Clientset: clientset,
+				Log:       ctrl.Log.WithName("test"),
+				Recorder:  recorder,
+			}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default", Generation: 1, Annotations: tt.annotations},
+				Status:     v1beta1.InferenceServiceStatus{SchedulingFallback: tt.fallbackStatus},
+			}
+			attemptsBefore := 0
+			if tt.fallbackStatus != nil {
+				attemptsBefore = len(tt.fallbackStatus.Attempts)
+			}
+
+			result, err := r.checkSchedulingFallback(context.TODO(), isvc, "rt-a", "a100", tt.config)
+			require.NoError(t, err)
+
+			if tt.expectFallback {
+				assert.True(t, result.Requeue)
+				require.NotNil(t, isvc.Status.SchedulingFallback)
+				attempts := isvc.Status.SchedulingFallback.Attempts
+				require.Len(t, attempts, attemptsBefore+1)
+				assert.Equal(t, "rt-a", attempts[len(attempts)-1].Runtime)
+				assert.Equal(t, "a100", attempts[len(attempts)-1].AcceleratorClass)
+				assert.Contains(t, attempts[len(attempts)-1].Reason, "Insufficient nvidia.com/gpu")
+				assert.Equal(t, int64(1), isvc.Status.SchedulingFallback.ObservedGeneration)
+				assert.Contains(t, <-recorder.Events, SchedulingFallbackReason)
+				return
+			}
+
+			assert.False(t, result.Requeue)
+			assert.Equal(t, tt.expectRequeueSet, result.RequeueAfter > 0)
+			if isvc.Status.SchedulingFallback != nil {
+				assert.Len(t, isvc.Status.SchedulingFallback.Attempts, attemptsBefore)
+			}
+			assert.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestChooseSchedulableRuntime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	fakeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&v1beta1.AcceleratorClass{ObjectMeta: metav1.ObjectMeta{Name: "a100"}},
+			&v1beta1.AcceleratorClass{ObjectMeta: metav1.ObjectMeta{Name: "h100"}},
+		).
+		Build()
+
+	candidates := []runtimeselector.RuntimeMatch{
+		{RuntimeSelection: runtimeselector.RuntimeSelection{
+			Name: "rt-a",
+			Spec: &v1beta1.ServingRuntimeSpec{
+				AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"a100", "h100"}},
+			},
+		}},
+		{RuntimeSelection: runtimeselector.RuntimeSelection{
+			Name: "rt-b",
+			Spec: &v1beta1.ServingRuntimeSpec{},
+		}},
+	}
+	fallbackConfig := &controllerconfig.SchedulingFallbackConfig{Enabled: true, UnschedulableTimeoutSeconds: 600}
+	attempt := func(runtime, ac string) v1beta1.SchedulingAttempt {
+		return v1beta1.SchedulingAttempt{Runtime: runtime, AcceleratorClass: ac, AbandonedTime: metav1.Now()}
+	}
+
+	tests := []struct {
+		name             string
+		generation       int64
+		attempts         []v1beta1.SchedulingAttempt
+		expectIndex      int
+		expectExcluded   []string
+		expectExhausted  bool
+		expectSkipReason string
+	}{
+		{
+			name:        "no attempts keeps top-ranked runtime",
+			generation:  1,
+			expectIndex: 0,
+		},
+		{
+			name:           "next accelerator class for the same runtime",
+			generation:     1,
+			attempts:       []v1beta1.SchedulingAttempt{attempt("rt-a", "a100")},
+			expectIndex:    0,
+			expectExcluded: []string{"a100"},
+		},
+		{
+			name:             "next runtime once all accelerator classes are abandoned",
+			generation:       1,
+			attempts:         []v1beta1.SchedulingAttempt{attempt("rt-a", "a100"), attempt("rt-a", "h100")},
+			expectIndex:      1,
+			expectSkipReason: "pods could not be scheduled on accelerator classes [a100 h100]",
+		},
+		{
+			name:            "exhausted when every combination is abandoned",
+			generation:      1,
+			attempts:        []v1beta1.SchedulingAttempt{attempt("rt-a", "a100"), attempt("rt-a", "h100"), attempt("rt-b", "")},
+			expectIndex:     0,
+			expectExhausted: true,
+		},
+		{
+			name:        "attempts from an older generation are ignored",
+			generation:  2,
+			attempts:    []v1beta1.SchedulingAttempt{attempt("rt-a", "a100"), attempt("rt-a", "h100")},
+			expectIndex: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &InferenceServiceReconciler{
+				Recorder:                 recorder,
+				AcceleratorClassSelector: acceleratorclassselector.New(fakeClient),
+			}
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default", Generation: tt.generation},
+				Spec: v1beta1.InferenceServiceSpec{
+					AcceleratorSelector: &v1beta1.AcceleratorSelector{Policy: v1beta1.FirstAvailablePolicy},
+				},
+				Status: v1beta1.InferenceServiceStatus{
+					SchedulingFallback: &v1beta1.SchedulingFallbackStatus{ObservedGeneration: 1, Attempts: tt.attempts},
+				},
+			}
+
+			choice, err := r.chooseSchedulableRuntime(context.TODO(), isvc, candidates, fallbackConfig)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectIndex, choice.runtimeIndex)
+			assert.Equal(t, tt.expectExcluded, choice.excludedClasses)
+			assert.Equal(t, tt.expectExhausted, choice.exhausted)
+			assert.Equal(t, tt.expectExhausted, isvc.Status.SchedulingFallback.Exhausted)
+			if tt.expectSkipReason != "" {
+				assert.Equal(t, tt.expectSkipReason, choice.skipReasons["rt-a"])
+			}
+			if tt.expectExhausted {
+				assert.Contains(t, <-recorder.Events, SchedulingFallbackExhaustedReason)
+			}
+
+			// The excluded classes steer policy-based accelerator selection to the next class
+			if len(choice.excludedClasses) > 0 {
+				_, acName, err := r.AcceleratorClassSelector.GetAcceleratorClass(context.TODO(),
+					withExcludedAcceleratorClasses(isvc, choice.excludedClasses), candidates[choice.runtimeIndex].Spec, v1beta1.EngineComponent)
+				require.NoError(t, err)
+				assert.Equal(t, "h100", acName)
+				assert.Nil(t, isvc.Spec.AcceleratorSelector.Constraints, "the original InferenceService must not be modified")
+			}
+		})
+	}
+}
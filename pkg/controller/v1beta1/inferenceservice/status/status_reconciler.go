@@ -1,15 +1,18 @@
 package status
 
 import (
+	"fmt"
 	"reflect"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	"knative.dev/pkg/apis"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
 )
 
 // Constants for magic numbers and string literals
@@ -81,6 +84,76 @@ func (sr *StatusReconciler) PropagateMultiNodeStatus(
 	status.ObservedGeneration = lws.Generation
 }
 
+// RolloutStatus is the state of the stable and canary workloads of a RawDeployment or MultiNode component
+type RolloutStatus struct {
+	// LatestCreatedRevision is the revision of the desired pod template
+	LatestCreatedRevision string
+	// StableRevision is the revision of the stable workload
+	StableRevision string
+	// StableReady reports whether the stable workload finished rolling out its revision
+	StableReady bool
+	// CanaryRevision is the revision of the canary workload, empty when the component has no canary
+	CanaryRevision string
+	// CanaryReady reports whether the canary workload finished rolling out its revision
+	CanaryReady bool
+	// CanaryPercent is the percent of traffic routed to the canary workload
+	CanaryPercent int64
+}
+
+// PropagateRolloutStatus propagates the revisions and traffic split of the stable and canary workloads of a
+// RawDeployment or MultiNode component
+func (sr *StatusReconciler) PropagateRolloutStatus(
+	status *v1beta1.InferenceServiceStatus,
+	component v1beta1.ComponentType,
+	rollout RolloutStatus) {
+
+	statusSpec := sr.initializeComponentStatus(status, component)
+
+	statusSpec.LatestCreatedRevision = rollout.LatestCreatedRevision
+	switch {
+	case rollout.StableReady && rollout.StableRevision == rollout.LatestCreatedRevision:
+		statusSpec.LatestReadyRevision = rollout.StableRevision
+	case rollout.CanaryReady && rollout.CanaryRevision == rollout.LatestCreatedRevision:
+		statusSpec.LatestReadyRevision = rollout.CanaryRevision
+	}
+
+	if rollout.CanaryRevision == "" {
+		// track the last revision that's fully rolled out
+		if rollout.StableReady && statusSpec.LatestRolledoutRevision != rollout.StableRevision {
+			statusSpec.PreviousRolledoutRevision = statusSpec.LatestRolledoutRevision
+			statusSpec.LatestRolledoutRevision = rollout.StableRevision
+		}
+		statusSpec.Traffic = []knservingv1.TrafficTarget{
+			{
+				RevisionName:   rollout.StableRevision,
+				LatestRevision: ptr.To(rollout.StableRevision == rollout.LatestCreatedRevision),
+				Percent:        ptr.To(int64(FullTrafficPercent)),
+			},
+		}
+	} else {
+		// The stable workload keeps serving the last rolled out revision while the canary is analyzed
+		previousRevision := statusSpec.LatestRolledoutRevision
+		if previousRevision == "" {
+			previousRevision = rollout.StableRevision
+		}
+		statusSpec.Traffic = []knservingv1.TrafficTarget{
+			{
+				Tag:            constants.PreviousTrafficTag,
+				RevisionName:   previousRevision,
+				LatestRevision: ptr.To(false),
+				Percent:        ptr.To(FullTrafficPercent - rollout.CanaryPercent),
+			},
+			{
+				Tag:            constants.CanaryTrafficTag,
+				RevisionName:   rollout.CanaryRevision,
+				LatestRevision: ptr.To(true),
+				Percent:        ptr.To(rollout.CanaryPercent),
+			},
+		}
+	}
+	status.Components[component] = statusSpec
+}
+
 // PropagateMultiNodeRayVLLMStatus propagates status from multiple deployments
 func (sr *StatusReconciler) PropagateMultiNodeRayVLLMStatus(
 	status *v1beta1.InferenceServiceStatus,
@@ -114,6 +187,35 @@ func (sr *StatusReconciler) PropagateMultiNodeRayVLLMStatus(
 	status.ObservedGeneration = firstDeployment.Status.ObservedGeneration
 }
 
+// PropagateRoleStatus propagates status from a role of a RoleBasedGroup
+func (sr *StatusReconciler) PropagateRoleStatus(
+	status *v1beta1.InferenceServiceStatus,
+	component v1beta1.ComponentType,
+	readyReplicas int32,
+	replicas int32,
+	generation int64,
+	url *apis.URL) {
+
+	statusSpec := sr.initializeComponentStatus(status, component)
+
+	readyCondition := sr.getReadyConditionsMap()[component]
+	condition := &apis.Condition{
+		Type:   readyCondition,
+		Status: v1.ConditionTrue,
+	}
+	if replicas == 0 || readyReplicas < replicas {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = "RoleNotReady"
+		condition.Message = fmt.Sprintf("%d of %d %s replicas are ready", readyReplicas, replicas, component)
+	} else {
+		statusSpec.URL = url
+	}
+	sr.setCondition(status, readyCondition, condition)
+
+	status.Components[component] = statusSpec
+	status.ObservedGeneration = generation
+}
+
 // PropagateStatus propagates status from Knative Service
 func (sr *StatusReconciler) PropagateStatus(
 	status *v1beta1.InferenceServiceStatus,
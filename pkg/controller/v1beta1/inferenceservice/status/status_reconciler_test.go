@@ -1286,3 +1286,125 @@ func TestPropagateMultiNodeRayVLLMStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagateRoleStatus(t *testing.T) {
+	url := &apis.URL{Scheme: "http", Host: "llama-engine.default.svc.cluster.local"}
+
+	tests := []struct {
+		name            string
+		readyReplicas   int32
+		replicas        int32
+		expectedStatus  corev1.ConditionStatus
+		expectedURL     *apis.URL
+		expectedMessage string
+	}{
+		{
+			name:           "all replicas ready",
+			readyReplicas:  2,
+			replicas:       2,
+			expectedStatus: corev1.ConditionTrue,
+			expectedURL:    url,
+		},
+		{
+			name:            "replicas not ready",
+			readyReplicas:   1,
+			replicas:        2,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "1 of 2 engine replicas are ready",
+		},
+		{
+			name:            "role not created yet",
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "0 of 0 engine replicas are ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := NewStatusReconciler()
+			status := &v1beta1.InferenceServiceStatus{}
+
+			sr.PropagateRoleStatus(status, v1beta1.EngineComponent, tt.readyReplicas, tt.replicas, 3, url)
+
+			condition := status.GetCondition(v1beta1.EngineReady)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+			assert.Equal(t, tt.expectedURL, status.Components[v1beta1.EngineComponent].URL)
+			assert.Equal(t, int64(3), status.ObservedGeneration)
+		})
+	}
+}
+
+func TestPropagateRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name             string
+		componentStatus  v1beta1.ComponentStatusSpec
+		rollout          RolloutStatus
+		expectedReady    string
+		expectedLatest   string
+		expectedPrevious string
+		expectedTraffic  []knservingv1.TrafficTarget
+	}{
+		{
+			name:            "first revision rolled out",
+			rollout:         RolloutStatus{LatestCreatedRevision: "llama-1", StableRevision: "llama-1", StableReady: true},
+			expectedReady:   "llama-1",
+			expectedLatest:  "llama-1",
+			expectedTraffic: []knservingv1.TrafficTarget{{RevisionName: "llama-1", LatestRevision: ptr.To(true), Percent: ptr.To(int64(100))}},
+		},
+		{
+			name:            "stable revision rolling out",
+			componentStatus: v1beta1.ComponentStatusSpec{LatestRolledoutRevision: "llama-1", LatestReadyRevision: "llama-1"},
+			rollout:         RolloutStatus{LatestCreatedRevision: "llama-2", StableRevision: "llama-2"},
+			expectedReady:   "llama-1",
+			expectedLatest:  "llama-1",
+			expectedTraffic: []knservingv1.TrafficTarget{{RevisionName: "llama-2", LatestRevision: ptr.To(true), Percent: ptr.To(int64(100))}},
+		},
+		{
+			name:            "canary receives traffic",
+			componentStatus: v1beta1.ComponentStatusSpec{LatestRolledoutRevision: "llama-1", LatestReadyRevision: "llama-1"},
+			rollout: RolloutStatus{
+				LatestCreatedRevision: "llama-2",
+				StableRevision:        "llama-1",
+				StableReady:           true,
+				CanaryRevision:        "llama-2",
+				CanaryReady:           true,
+				CanaryPercent:         10,
+			},
+			expectedReady:  "llama-2",
+			expectedLatest: "llama-1",
+			expectedTraffic: []knservingv1.TrafficTarget{
+				{Tag: "prev", RevisionName: "llama-1", LatestRevision: ptr.To(false), Percent: ptr.To(int64(90))},
+				{Tag: "canary", RevisionName: "llama-2", LatestRevision: ptr.To(true), Percent: ptr.To(int64(10))},
+			},
+		},
+		{
+			name:             "canary promoted",
+			componentStatus:  v1beta1.ComponentStatusSpec{LatestRolledoutRevision: "llama-1", LatestReadyRevision: "llama-2"},
+			rollout:          RolloutStatus{LatestCreatedRevision: "llama-2", StableRevision: "llama-2", StableReady: true},
+			expectedReady:    "llama-2",
+			expectedLatest:   "llama-2",
+			expectedPrevious: "llama-1",
+			expectedTraffic:  []knservingv1.TrafficTarget{{RevisionName: "llama-2", LatestRevision: ptr.To(true), Percent: ptr.To(int64(100))}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := NewStatusReconciler()
+			status := &v1beta1.InferenceServiceStatus{
+				Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{v1beta1.EngineComponent: tt.componentStatus},
+			}
+
+			sr.PropagateRolloutStatus(status, v1beta1.EngineComponent, tt.rollout)
+
+			componentStatus := status.Components[v1beta1.EngineComponent]
+			assert.Equal(t, tt.rollout.LatestCreatedRevision, componentStatus.LatestCreatedRevision)
+			assert.Equal(t, tt.expectedReady, componentStatus.LatestReadyRevision)
+			assert.Equal(t, tt.expectedLatest, componentStatus.LatestRolledoutRevision)
+			assert.Equal(t, tt.expectedPrevious, componentStatus.PreviousRolledoutRevision)
+			assert.Equal(t, tt.expectedTraffic, componentStatus.Traffic)
+		})
+	}
+}
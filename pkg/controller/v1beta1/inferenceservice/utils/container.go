@@ -1,11 +1,56 @@
 package utils
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/sgl-project/ome/pkg/constants"
 )
 
+// RuntimeFamily is the serving engine run by a component, which names the metrics it exports
+type RuntimeFamily string
+
+const (
+	RuntimeFamilySGLang RuntimeFamily = "sglang"
+	RuntimeFamilyVLLM   RuntimeFamily = "vllm"
+)
+
+// GetRuntimeFamily returns the serving engine run by the main container of the pod spec, recognized from its image,
+// command and args. It defaults to SGLang.
+func GetRuntimeFamily(podSpec *v1.PodSpec) RuntimeFamily {
+	if family, ok := DetectRuntimeFamily(podSpec); ok {
+		return family
+	}
+	return RuntimeFamilySGLang
+}
+
+// DetectRuntimeFamily returns the serving engine run by the main container of the pod spec, recognized from its
+// image, command and args, and false when it is neither SGLang nor vLLM.
+func DetectRuntimeFamily(podSpec *v1.PodSpec) (RuntimeFamily, bool) {
+	if podSpec == nil || len(podSpec.Containers) == 0 {
+		return "", false
+	}
+	container := podSpec.Containers[0]
+	for _, c := range podSpec.Containers {
+		if c.Name == constants.MainContainerName {
+			container = c
+			break
+		}
+	}
+	fields := append([]string{container.Image}, container.Command...)
+	fields = append(fields, container.Args...)
+	description := strings.ToLower(strings.Join(fields, " "))
+	switch {
+	case strings.Contains(description, "vllm"):
+		return RuntimeFamilyVLLM, true
+	case strings.Contains(description, "sglang"):
+		return RuntimeFamilySGLang, true
+	default:
+		return "", false
+	}
+}
+
 func AppendVolumeMount(container *v1.Container, volumeMount *v1.VolumeMount) {
 	container.VolumeMounts = append(container.VolumeMounts, *volumeMount)
 }
@@ -14,8 +14,9 @@ func DetermineEngineDeploymentMode(engine *v1beta1.EngineSpec) constants.Deploym
 		return constants.RawDeployment
 	}
 
-	// Check for deployment mode annotation (e.g., MultiNodeRayVLLM)
-	if mode, found := GetDeploymentModeFromAnnotations(engine.Annotations); found {
+	// Check for deployment mode annotation (e.g., MultiNodeRayVLLM). RoleBasedGroup selects how all
+	// components are deployed together rather than the mode of the engine.
+	if mode, found := GetDeploymentModeFromAnnotations(engine.Annotations); found && mode != constants.RoleBasedGroup {
 		return mode
 	}
 
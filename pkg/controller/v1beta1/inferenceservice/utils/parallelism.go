@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
+	"github.com/sgl-project/ome/pkg/utils"
+)
+
+const (
+	bytesPerGB = 1024 * 1024 * 1024
+
+	// defaultDtypeBytes is used when the model does not report its torch dtype, as most checkpoints are bf16
+	defaultDtypeBytes = 2.0
+)
+
+// ParallelismPlan is the number of accelerators and nodes needed to serve a model on an accelerator class
+type ParallelismPlan struct {
+	// TensorParallelSize is the number of accelerators each pod shards the model across
+	TensorParallelSize int64
+	// Nodes is the number of pods, one per node, the model is split across
+	Nodes int64
+	// WeightsGB is the estimated memory used by the model weights
+	WeightsGB float64
+	// KVCacheGB is the estimated KV cache memory for a single sequence of the maximum token length
+	KVCacheGB float64
+	// RequiredMemoryGB is the total accelerator memory needed for weights and KV cache
+	RequiredMemoryGB float64
+	// ResourceName is the extended resource the accelerators of each pod are requested with
+	ResourceName string
+}
+
+// MultiNode returns whether the plan needs more than one node
+func (p *ParallelismPlan) MultiNode() bool {
+	return p.Nodes > 1
+}
+
+// SingleNode returns a copy of the plan capped to a single node
+func (p *ParallelismPlan) SingleNode() *ParallelismPlan {
+	plan := *p
+	plan.Nodes = 1
+	return &plan
+}
+
+// modelConfiguration holds the fields the model agent records in BaseModelSpec.ModelConfiguration
+type modelConfiguration struct {
+	TorchDtype     string `json:"torch_dtype"`
+	ModelSizeBytes int64  `json:"model_size_bytes"`
+	ContextLength  int64  `json:"context_length"`
+	ParameterCount string `json:"parameter_count"`
+	modelconfig.AttentionConfig
+}
+
+// ComputeParallelism computes the minimum tensor parallelism needed to fit the model weights and the KV cache for
+// MaxTokens into the accelerator memory. The KV cache is sized from the attention geometry the model agent records in
+// the model configuration, and the plan fails when it is unknown. Tensor parallelism is rounded up to a power of two and capped at
+// acceleratorsPerNode, and the remaining memory requirement is covered by spreading the model across nodes.
+func ComputeParallelism(model *v1beta1.BaseModelSpec, accelerator *v1beta1.AcceleratorClassSpec, acceleratorsPerNode int64, memoryUtilization float64) (*ParallelismPlan, error) {
+	if model == nil {
+		return nil, fmt.Errorf("base model is not specified")
+	}
+	if accelerator == nil || accelerator.Capabilities.MemoryGB == nil || accelerator.Capabilities.MemoryGB.Value() <= 0 {
+		return nil, fmt.Errorf("accelerator memory is not specified")
+	}
+	if acceleratorsPerNode <= 0 {
+		return nil, fmt.Errorf("accelerators per node must be positive, got %d", acceleratorsPerNode)
+	}
+
+	var config modelConfiguration
+	if len(model.ModelConfiguration.Raw) > 0 {
+		if err := json.Unmarshal(model.ModelConfiguration.Raw, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse model configuration: %w", err)
+		}
+	}
+
+	dtypeBytes := defaultDtypeBytes
+	if size, ok := modelconfig.DtypeSizeBytes[strings.ToLower(config.TorchDtype)]; ok {
+		dtypeBytes = size
+	}
+
+	params := parseParameterCount(config.ParameterCount)
+	if model.ModelParameterSize != nil {
+		if size := parseParameterCount(*model.ModelParameterSize); size > 0 {
+			params = size
+		}
+	}
+	if params == 0 && config.ModelSizeBytes > 0 {
+		params = float64(config.ModelSizeBytes) / dtypeBytes
+	}
+	if params == 0 {
+		return nil, fmt.Errorf("model parameter size is unknown")
+	}
+
+	// Quantized weights are loaded at the quantized width regardless of the checkpoint dtype
+	var weightBytes float64
+	if quantBytes, ok := quantizationBytes(model.Quantization); ok {
+		weightBytes = params * quantBytes
+	} else if config.ModelSizeBytes > 0 {
+		weightBytes = float64(config.ModelSizeBytes)
+	} else {
+		weightBytes = params * dtypeBytes
+	}
+
+	tokens := config.ContextLength
+	if model.MaxTokens != nil && *model.MaxTokens > 0 {
+		tokens = int64(*model.MaxTokens)
+	}
+	bytesPerToken, err := kvCacheBytesPerToken(&config.AttentionConfig, dtypeBytes)
+	if err != nil {
+		return nil, err
+	}
+	kvCacheBytes := float64(tokens) * bytesPerToken
+
+	plan := &ParallelismPlan{
+		WeightsGB: weightBytes / bytesPerGB,
+		KVCacheGB: kvCacheBytes / bytesPerGB,
+	}
+	plan.RequiredMemoryGB = plan.WeightsGB + plan.KVCacheGB
+
+	usableGB := float64(accelerator.Capabilities.MemoryGB.Value()) / bytesPerGB * memoryUtilization
+	accelerators := int64(math.Ceil(plan.RequiredMemoryGB / usableGB))
+	if accelerators < 1 {
+		accelerators = 1
+	}
+
+	tp := nextPowerOfTwo(accelerators)
+	if tp > acceleratorsPerNode {
+		plan.TensorParallelSize = acceleratorsPerNode
+		plan.Nodes = (accelerators + acceleratorsPerNode - 1) / acceleratorsPerNode
+	} else {
+		plan.TensorParallelSize = tp
+		plan.Nodes = 1
+	}
+	return plan, nil
+}
+
+// AcceleratorsPerNode returns the number of accelerators on each node of the class as reported by its status,
+// or the given default when the status has not been populated.
+func AcceleratorsPerNode(acceleratorClass *v1beta1.AcceleratorClass, defaultPerNode int64) int64 {
+	if acceleratorClass == nil {
+		return defaultPerNode
+	}
+	status := acceleratorClass.Status
+	if len(status.Nodes) == 0 || status.TotalAccelerators <= 0 {
+		return defaultPerNode
+	}
+	if perNode := int64(status.TotalAccelerators) / int64(len(status.Nodes)); perNode > 0 {
+		return perNode
+	}
+	return defaultPerNode
+}
+
+// ApplyParallelismPlan returns copies of the supported model format and accelerator class with the plan applied.
+// The accelerator resource requests are set to the tensor parallel size. A single-node plan is recorded as the
+// TensorParallelismOverride of the accelerator class so that it is applied to the runtime arguments by
+// MergeRuntimeArgumentsOverride. A multi-node plan is not, since the leader and worker templates of the serving runtime
+// derive their parallelism from the accelerators of each pod and the size of the group, e.g. through PARALLELISM_SIZE.
+// An explicit TensorParallelismOverride in the serving runtime always takes precedence.
+func ApplyParallelismPlan(plan *ParallelismPlan, format *v1beta1.SupportedModelFormat, accelerator *v1beta1.AcceleratorClassSpec, acceleratorName string) (*v1beta1.SupportedModelFormat, *v1beta1.AcceleratorClassSpec) {
+	if plan == nil || acceleratorName == "" || HasTensorParallelismOverride(format, acceleratorName) {
+		return format, accelerator
+	}
+
+	tp := plan.TensorParallelSize
+	formatCopy := format
+	if !plan.MultiNode() {
+		if format != nil {
+			formatCopy = format.DeepCopy()
+		} else {
+			formatCopy = &v1beta1.SupportedModelFormat{}
+		}
+		if formatCopy.AcceleratorConfig == nil {
+			formatCopy.AcceleratorConfig = map[string]*v1beta1.AcceleratorModelConfig{}
+		}
+		modelConfig := formatCopy.AcceleratorConfig[acceleratorName]
+		if modelConfig == nil {
+			modelConfig = &v1beta1.AcceleratorModelConfig{}
+			formatCopy.AcceleratorConfig[acceleratorName] = modelConfig
+		}
+		modelConfig.TensorParallelismOverride = &v1beta1.TensorParallelismConfig{TensorParallelSize: &tp}
+	}
+
+	var acceleratorCopy *v1beta1.AcceleratorClassSpec
+	if accelerator != nil {
+		acceleratorCopy = accelerator.DeepCopy()
+		if plan.ResourceName != "" {
+			quantity := *resource.NewQuantity(tp, resource.DecimalSI)
+			i := slices.IndexFunc(acceleratorCopy.Resources, func(res v1beta1.AcceleratorResource) bool {
+				return res.Name == plan.ResourceName
+			})
+			if i >= 0 {
+				acceleratorCopy.Resources[i].Quantity = quantity
+			} else {
+				acceleratorCopy.Resources = append(acceleratorCopy.Resources,
+					v1beta1.AcceleratorResource{Name: plan.ResourceName, Quantity: quantity})
+			}
+		}
+	}
+	return formatCopy, acceleratorCopy
+}
+
+// AcceleratorResourceName returns the extended resource the accelerators are requested with. It is the accelerator
+// resource listed by the accelerator class, or else the one the containers of the component already request.
+// An empty string is returned when neither names one.
+func AcceleratorResourceName(accelerator *v1beta1.AcceleratorClassSpec, containers ...*v1.Container) string {
+	if accelerator != nil {
+		for _, res := range accelerator.Resources {
+			if utils.IsAcceleratorResource(res.Name) {
+				return res.Name
+			}
+		}
+	}
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+		var names []string
+		for _, list := range []v1.ResourceList{container.Resources.Limits, container.Resources.Requests} {
+			for name := range list {
+				if utils.IsAcceleratorResource(string(name)) {
+					names = append(names, string(name))
+				}
+			}
+		}
+		if len(names) > 0 {
+			return slices.Min(names)
+		}
+	}
+	return ""
+}
+
+// HasTensorParallelismOverride returns whether the serving runtime sets tensor parallelism for the accelerator class
+func HasTensorParallelismOverride(format *v1beta1.SupportedModelFormat, acceleratorName string) bool {
+	if format == nil {
+		return false
+	}
+	config := format.GetAcceleratorConfig(acceleratorName)
+	return config != nil && config.TensorParallelismOverride != nil
+}
+
+// parseParameterCount parses parameter counts such as "70B", "7.6B", "1.5T" or "500M"
+func parseParameterCount(size string) float64 {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	if size == "" {
+		return 0
+	}
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(size, "T"):
+		multiplier = 1e12
+	case strings.HasSuffix(size, "B"):
+		multiplier = 1e9
+	case strings.HasSuffix(size, "M"):
+		multiplier = 1e6
+	case strings.HasSuffix(size, "K"):
+		multiplier = 1e3
+	}
+	value, err := strconv.ParseFloat(strings.TrimRight(size, "TBMK"), 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+	return value * multiplier
+}
+
+// quantizationBytes returns the bytes per parameter of a quantized model
+func quantizationBytes(quantization *v1beta1.ModelQuantization) (float64, bool) {
+	if quantization == nil {
+		return 0, false
+	}
+	switch *quantization {
+	case v1beta1.ModelQuantizationFP8, v1beta1.ModelQuantizationFbgemmFP8:
+		return 1, true
+	case v1beta1.ModelQuantizationINT4:
+		return 0.5, true
+	}
+	return 0, false
+}
+
+// kvCacheBytesPerToken returns the KV cache size of a single token, a key and a value per KV head in every layer.
+// Multi-head latent attention caches a single compressed latent and rotary key per layer instead.
+func kvCacheBytesPerToken(attention *modelconfig.AttentionConfig, dtypeBytes float64) (float64, error) {
+	if attention.NumLayers <= 0 {
+		return 0, fmt.Errorf("model configuration does not report the number of layers")
+	}
+	if attention.KVLoraRank > 0 {
+		return float64(attention.NumLayers*(attention.KVLoraRank+attention.QKRopeHeadDim)) * dtypeBytes, nil
+	}
+	if attention.NumKeyValueHeads <= 0 || attention.HeadDim <= 0 {
+		return 0, fmt.Errorf("model configuration does not report the KV heads and head dimension")
+	}
+	return 2 * float64(attention.NumLayers*attention.NumKeyValueHeads*attention.HeadDim) * dtypeBytes, nil
+}
+
+// nextPowerOfTwo returns the smallest power of two greater than or equal to n
+func nextPowerOfTwo(n int64) int64 {
+	p := int64(1)
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
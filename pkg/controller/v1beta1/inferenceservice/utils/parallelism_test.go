@@ -0,0 +1,381 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+func TestComputeParallelism(t *testing.T) {
+	h100 := &v1beta1.AcceleratorClassSpec{
+		Capabilities: v1beta1.AcceleratorCapabilities{MemoryGB: resource.NewQuantity(80*bytesPerGB, resource.BinarySI)},
+	}
+	paramSize := func(size string) *string { return &size }
+	maxTokens := func(tokens int32) *int32 { return &tokens }
+	quantization := func(q v1beta1.ModelQuantization) *v1beta1.ModelQuantization { return &q }
+	attention := func(layers, kvHeads, headDim int) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"num_hidden_layers":%d,"num_key_value_heads":%d,"head_dim":%d}`, layers, kvHeads, headDim))}
+	}
+
+	tests := []struct {
+		name                string
+		model               *v1beta1.BaseModelSpec
+		accelerator         *v1beta1.AcceleratorClassSpec
+		acceleratorsPerNode int64
+		expectTP            int64
+		expectNodes         int64
+		expectWeightsGB     float64
+		expectError         bool
+	}{
+		{
+			name:                "small model fits on one accelerator",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(32, 8, 128), ModelParameterSize: paramSize("8B"), MaxTokens: maxTokens(8192)},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            1,
+			expectNodes:         1,
+			expectWeightsGB:     14.9,
+		},
+		{
+			name:                "70B bf16 needs two accelerators",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(80, 8, 128), ModelParameterSize: paramSize("70B"), MaxTokens: maxTokens(8192)},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            2,
+			expectNodes:         1,
+			expectWeightsGB:     130.4,
+		},
+		{
+			name: "fp8 quantization halves the weights",
+			model: &v1beta1.BaseModelSpec{
+				ModelConfiguration: attention(80, 8, 128),
+				ModelParameterSize: paramSize("70B"),
+				MaxTokens:          maxTokens(8192),
+				Quantization:       quantization(v1beta1.ModelQuantizationFP8),
+			},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            1,
+			expectNodes:         1,
+			expectWeightsGB:     65.2,
+		},
+		{
+			name:                "KV cache headroom raises the tensor parallel size",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(64, 8, 128), ModelParameterSize: paramSize("32B"), MaxTokens: maxTokens(131072)},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            2,
+			expectNodes:         1,
+			expectWeightsGB:     59.6,
+		},
+		{
+			name:                "405B needs more than one node",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(126, 8, 128), ModelParameterSize: paramSize("405B"), MaxTokens: maxTokens(8192)},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            8,
+			expectNodes:         2,
+			expectWeightsGB:     754.4,
+		},
+		{
+			name:                "pipeline parallelism follows accelerators per node",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(126, 8, 128), ModelParameterSize: paramSize("405B"), MaxTokens: maxTokens(8192)},
+			accelerator:         h100,
+			acceleratorsPerNode: 4,
+			expectTP:            4,
+			expectNodes:         3,
+			expectWeightsGB:     754.4,
+		},
+		{
+			name: "model configuration provides size and dtype",
+			model: &v1beta1.BaseModelSpec{
+				ModelConfiguration: runtime.RawExtension{Raw: []byte(`{"torch_dtype":"float32","model_size_bytes":120000000000,"context_length":4096,"parameter_count":"30B",` +
+					`"num_hidden_layers":48,"num_key_value_heads":8,"head_dim":128}`)},
+			},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            2,
+			expectNodes:         1,
+			expectWeightsGB:     111.8,
+		},
+		{
+			name: "multi-head latent attention caches a compressed latent per layer",
+			model: &v1beta1.BaseModelSpec{
+				ModelConfiguration: runtime.RawExtension{Raw: []byte(`{"num_hidden_layers":61,"kv_lora_rank":512,"qk_rope_head_dim":64}`)},
+				ModelParameterSize: paramSize("671B"),
+				MaxTokens:          maxTokens(163840),
+				Quantization:       quantization(v1beta1.ModelQuantizationFP8),
+			},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectTP:            8,
+			expectNodes:         2,
+			expectWeightsGB:     624.9,
+		},
+		{
+			name:                "unknown attention geometry",
+			model:               &v1beta1.BaseModelSpec{ModelParameterSize: paramSize("8B"), MaxTokens: maxTokens(8192)},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectError:         true,
+		},
+		{
+			name:                "unknown KV heads",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: attention(32, 0, 128), ModelParameterSize: paramSize("8B")},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectError:         true,
+		},
+		{
+			name:                "unknown model size",
+			model:               &v1beta1.BaseModelSpec{},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectError:         true,
+		},
+		{
+			name:                "unknown accelerator memory",
+			model:               &v1beta1.BaseModelSpec{ModelParameterSize: paramSize("8B")},
+			accelerator:         &v1beta1.AcceleratorClassSpec{},
+			acceleratorsPerNode: 8,
+			expectError:         true,
+		},
+		{
+			name:                "invalid model configuration",
+			model:               &v1beta1.BaseModelSpec{ModelConfiguration: runtime.RawExtension{Raw: []byte(`{`)}},
+			accelerator:         h100,
+			acceleratorsPerNode: 8,
+			expectError:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := ComputeParallelism(tt.model, tt.accelerator, tt.acceleratorsPerNode, 0.9)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectTP, plan.TensorParallelSize)
+			assert.Equal(t, tt.expectNodes, plan.Nodes)
+			assert.Equal(t, tt.expectNodes > 1, plan.MultiNode())
+			assert.InDelta(t, tt.expectWeightsGB, plan.WeightsGB, 0.1)
+			assert.InDelta(t, plan.WeightsGB+plan.KVCacheGB, plan.RequiredMemoryGB, 0.001)
+		})
+	}
+}
+
+func TestAcceleratorsPerNode(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    *v1beta1.AcceleratorClass
+		expected int64
+	}{
+		{
+			name:     "nil class uses default",
+			expected: 8,
+		},
+		{
+			name:     "empty status uses default",
+			class:    &v1beta1.AcceleratorClass{},
+			expected: 8,
+		},
+		{
+			name: "derived from status",
+			class: &v1beta1.AcceleratorClass{
+				Status: v1beta1.AcceleratorClassStatus{Nodes: []string{"n1", "n2"}, TotalAccelerators: 8},
+			},
+			expected: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AcceleratorsPerNode(tt.class, 8))
+		})
+	}
+}
+
+func TestApplyParallelismPlan(t *testing.T) {
+	explicitTP := int64(8)
+	accelerator := &v1beta1.AcceleratorClassSpec{
+		Resources: []v1beta1.AcceleratorResource{
+			{Name: constants.NvidiaGPUResourceType, Quantity: resource.MustParse("8")},
+			{Name: "rdma/ib", Quantity: resource.MustParse("1")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		plan            *ParallelismPlan
+		format          *v1beta1.SupportedModelFormat
+		acceleratorName string
+		expectApplied   bool
+		expectOverride  bool
+		expectResource  string
+	}{
+		{
+			name:            "nil format gets an override",
+			plan:            &ParallelismPlan{TensorParallelSize: 4, Nodes: 1, ResourceName: constants.NvidiaGPUResourceType},
+			acceleratorName: "h100",
+			expectApplied:   true,
+			expectOverride:  true,
+		},
+		{
+			name: "existing accelerator config keeps its runtime args",
+			plan: &ParallelismPlan{TensorParallelSize: 4, Nodes: 1, ResourceName: constants.NvidiaGPUResourceType},
+			format: &v1beta1.SupportedModelFormat{
+				AcceleratorConfig: map[string]*v1beta1.AcceleratorModelConfig{
+					"h100": {RuntimeArgsOverride: []string{"--mem-frac=0.9"}},
+				},
+			},
+			acceleratorName: "h100",
+			expectApplied:   true,
+			expectOverride:  true,
+		},
+		{
+			name:            "multi-node plan only sets the accelerator resources",
+			plan:            &ParallelismPlan{TensorParallelSize: 4, Nodes: 2, ResourceName: constants.NvidiaGPUResourceType},
+			acceleratorName: "h100",
+			expectApplied:   true,
+		},
+		{
+			name:            "resource missing from the accelerator class is added",
+			plan:            &ParallelismPlan{TensorParallelSize: 4, Nodes: 1, ResourceName: "amd.com/gpu"},
+			acceleratorName: "mi300x",
+			expectApplied:   true,
+			expectOverride:  true,
+			expectResource:  "amd.com/gpu",
+		},
+		{
+			name: "explicit override takes precedence",
+			plan: &ParallelismPlan{TensorParallelSize: 4, Nodes: 1, ResourceName: constants.NvidiaGPUResourceType},
+			format: &v1beta1.SupportedModelFormat{
+				AcceleratorConfig: map[string]*v1beta1.AcceleratorModelConfig{
+					"h100": {TensorParallelismOverride: &v1beta1.TensorParallelismConfig{TensorParallelSize: &explicitTP}},
+				},
+			},
+			acceleratorName: "h100",
+		},
+		{
+			name: "no accelerator class",
+			plan: &ParallelismPlan{TensorParallelSize: 4, Nodes: 1, ResourceName: constants.NvidiaGPUResourceType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var original *v1beta1.SupportedModelFormat
+			if tt.format != nil {
+				original = tt.format.DeepCopy()
+			}
+
+			format, ac := ApplyParallelismPlan(tt.plan, tt.format, accelerator, tt.acceleratorName)
+			assert.Equal(t, original, tt.format, "the input format must not be modified")
+			assert.Equal(t, "8", accelerator.Resources[0].Quantity.String(), "the input accelerator must not be modified")
+
+			if !tt.expectApplied {
+				assert.Same(t, tt.format, format)
+				assert.Same(t, accelerator, ac)
+				return
+			}
+			if tt.expectResource != "" {
+				require.Len(t, ac.Resources, 3)
+				assert.Equal(t, tt.expectResource, ac.Resources[2].Name)
+				assert.Equal(t, "4", ac.Resources[2].Quantity.String())
+				assert.Equal(t, "8", ac.Resources[0].Quantity.String())
+			} else {
+				require.Len(t, ac.Resources, 2)
+				assert.Equal(t, "4", ac.Resources[0].Quantity.String())
+			}
+			assert.Equal(t, "1", ac.Resources[1].Quantity.String())
+			if !tt.expectOverride {
+				assert.False(t, HasTensorParallelismOverride(format, tt.acceleratorName))
+				return
+			}
+			override := format.GetAcceleratorConfig(tt.acceleratorName).TensorParallelismOverride
+			require.NotNil(t, override)
+			assert.Equal(t, int64(4), *override.TensorParallelSize)
+			assert.Nil(t, override.PipelineParallelSize)
+			if tt.format != nil {
+				assert.Equal(t, []string{"--mem-frac=0.9"}, format.GetAcceleratorConfig(tt.acceleratorName).RuntimeArgsOverride)
+			}
+		})
+	}
+}
+
+func TestAcceleratorResourceName(t *testing.T) {
+	amd := &v1beta1.AcceleratorClassSpec{
+		Resources: []v1beta1.AcceleratorResource{
+			{Name: "rdma/ib", Quantity: resource.MustParse("1")},
+			{Name: "amd.com/gpu", Quantity: resource.MustParse("8")},
+		},
+	}
+	rdmaOnly := &v1beta1.AcceleratorClassSpec{
+		Resources: []v1beta1.AcceleratorResource{{Name: "rdma/ib", Quantity: resource.MustParse("1")}},
+	}
+	gpuContainer := &v1.Container{Resources: v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8"), constants.NvidiaGPUResourceType: resource.MustParse("8")},
+	}}
+	requestContainer := &v1.Container{Resources: v1.ResourceRequirements{
+		Requests: v1.ResourceList{"gpu.intel.com/i915": resource.MustParse("1")},
+	}}
+	cpuContainer := &v1.Container{Resources: v1.ResourceRequirements{
+		Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+	}}
+
+	tests := []struct {
+		name        string
+		accelerator *v1beta1.AcceleratorClassSpec
+		containers  []*v1.Container
+		expected    string
+	}{
+		{
+			name:        "accelerator class resource",
+			accelerator: amd,
+			containers:  []*v1.Container{gpuContainer},
+			expected:    "amd.com/gpu",
+		},
+		{
+			name:        "container limits when the class lists none",
+			accelerator: rdmaOnly,
+			containers:  []*v1.Container{nil, cpuContainer, gpuContainer},
+			expected:    constants.NvidiaGPUResourceType,
+		},
+		{
+			name:       "container requests",
+			containers: []*v1.Container{requestContainer},
+			expected:   "gpu.intel.com/i915",
+		},
+		{
+			name:        "no accelerator resource",
+			accelerator: rdmaOnly,
+			containers:  []*v1.Container{cpuContainer},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AcceleratorResourceName(tt.accelerator, tt.containers...))
+		})
+	}
+}
+
+func TestParallelismPlanSingleNode(t *testing.T) {
+	plan := &ParallelismPlan{TensorParallelSize: 8, Nodes: 3, RequiredMemoryGB: 1500}
+
+	single := plan.SingleNode()
+	assert.Equal(t, int64(1), single.Nodes)
+	assert.Equal(t, int64(8), single.TensorParallelSize)
+	assert.Equal(t, 1500.0, single.RequiredMemoryGB)
+	assert.Equal(t, int64(3), plan.Nodes, "the plan must not be modified")
+}
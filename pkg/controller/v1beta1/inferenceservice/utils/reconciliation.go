@@ -35,6 +35,25 @@ func GetBaseModel(cl client.Client, name string, namespace string) (*v1beta1.Bas
 	return nil, nil, goerrors.New("No BaseModel or ClusterBaseModel with the name: " + name)
 }
 
+// GetBaseModelStatus retrieves the status of a BaseModel or ClusterBaseModel by name, looked up the same way as GetBaseModel.
+func GetBaseModelStatus(cl client.Client, name string, namespace string) (*v1beta1.ModelStatusSpec, error) {
+	baseModel := &v1beta1.BaseModel{}
+	err := cl.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: namespace}, baseModel)
+	if err == nil {
+		return &baseModel.Status, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	clusterBaseModel := &v1beta1.ClusterBaseModel{}
+	err = cl.Get(context.TODO(), client.ObjectKey{Name: name}, clusterBaseModel)
+	if err == nil {
+		return &clusterBaseModel.Status, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return nil, goerrors.New("No BaseModel or ClusterBaseModel with the name: " + name)
+}
+
 // GetFineTunedWeight Get the fine-tuned weight from the given fine-tuned weight name.
 func GetFineTunedWeight(cl client.Client, name string) (*v1beta1.FineTunedWeight, error) {
 	fineTunedWeight := &v1beta1.FineTunedWeight{}
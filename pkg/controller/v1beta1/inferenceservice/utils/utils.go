@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -112,17 +113,7 @@ func AddNodeSelectorForModelReadyNode(podSpec *corev1.PodSpec, baseModelMeta *me
 		return
 	}
 
-	// Determine if this is a ClusterBaseModel or BaseModel based on namespace
-	var labelKey string
-	isClusterScoped := baseModelMeta.Namespace == ""
-
-	if isClusterScoped {
-		// ClusterBaseModel
-		labelKey = constants.GetClusterBaseModelLabel(baseModelMeta.Name)
-	} else {
-		// BaseModel (namespace-scoped)
-		labelKey = constants.GetBaseModelLabel(baseModelMeta.Namespace, baseModelMeta.Name)
-	}
+	labelKey := modelReadyNodeLabel(baseModelMeta)
 
 	// Initialize node selector if nil
 	if podSpec.NodeSelector == nil {
@@ -134,3 +125,45 @@ func AddNodeSelectorForModelReadyNode(podSpec *corev1.PodSpec, baseModelMeta *me
 		podSpec.NodeSelector[labelKey] = "Ready"
 	}
 }
+
+// AddPreferredNodeAffinityForModelReadyNode adds a preferred node affinity to the pod spec for nodes where the
+// base model is ready, so pods can still be scheduled onto nodes the model is being pulled onto.
+// The affinity term is only added once.
+func AddPreferredNodeAffinityForModelReadyNode(podSpec *corev1.PodSpec, baseModelMeta *metav1.ObjectMeta) {
+	if podSpec == nil || baseModelMeta == nil {
+		return
+	}
+
+	term := corev1.PreferredSchedulingTerm{
+		Weight: 100,
+		Preference: corev1.NodeSelectorTerm{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      modelReadyNodeLabel(baseModelMeta),
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"Ready"},
+			}},
+		},
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	for _, existing := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if equality.Semantic.DeepEqual(existing, term) {
+			return
+		}
+	}
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, term)
+}
+
+// modelReadyNodeLabel returns the node label reporting the status of a ClusterBaseModel (empty namespace) or BaseModel
+func modelReadyNodeLabel(baseModelMeta *metav1.ObjectMeta) string {
+	if baseModelMeta.Namespace == "" {
+		return constants.GetClusterBaseModelLabel(baseModelMeta.Name)
+	}
+	return constants.GetBaseModelLabel(baseModelMeta.Namespace, baseModelMeta.Name)
+}
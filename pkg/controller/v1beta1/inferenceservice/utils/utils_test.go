@@ -1978,3 +1978,87 @@ func TestAddNodeSelectorForReadyModel(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRuntimeFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		podSpec  *v1.PodSpec
+		expected RuntimeFamily
+	}{
+		{
+			name:     "nil pod spec",
+			podSpec:  nil,
+			expected: RuntimeFamilySGLang,
+		},
+		{
+			name: "sglang image",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: constants.MainContainerName, Image: "docker.io/lmsysorg/sglang:v0.4.6", Command: []string{"python3", "-m", "sglang.launch_server"}},
+			}},
+			expected: RuntimeFamilySGLang,
+		},
+		{
+			name: "vllm image",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: constants.MainContainerName, Image: "docker.io/vllm/vllm-openai:v0.9.0"},
+			}},
+			expected: RuntimeFamilyVLLM,
+		},
+		{
+			name: "vllm command in the main container",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: "sidecar", Image: "busybox"},
+				{Name: constants.MainContainerName, Image: "registry.local/engine:v1", Command: []string{"python3", "-m", "vllm.entrypoints.openai.api_server"}},
+			}},
+			expected: RuntimeFamilyVLLM,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetRuntimeFamily(tt.podSpec))
+		})
+	}
+}
+
+func TestDetectRuntimeFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		podSpec  *v1.PodSpec
+		expected RuntimeFamily
+		detected bool
+	}{
+		{
+			name:    "nil pod spec",
+			podSpec: nil,
+		},
+		{
+			name: "sglang command",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: constants.MainContainerName, Image: "registry.local/engine:v1", Command: []string{"python3", "-m", "sglang.launch_server"}},
+			}},
+			expected: RuntimeFamilySGLang,
+			detected: true,
+		},
+		{
+			name: "vllm image",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: constants.MainContainerName, Image: "docker.io/vllm/vllm-openai:v0.9.0"},
+			}},
+			expected: RuntimeFamilyVLLM,
+			detected: true,
+		},
+		{
+			name: "other runtime",
+			podSpec: &v1.PodSpec{Containers: []v1.Container{
+				{Name: constants.MainContainerName, Image: "nvcr.io/nvidia/tritonserver:24.05-trtllm-python-py3", Command: []string{"tritonserver"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, detected := DetectRuntimeFamily(tt.podSpec)
+			assert.Equal(t, tt.expected, family)
+			assert.Equal(t, tt.detected, detected)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbg"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+	isvcutils "github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// RBGStrategyName is the name of the RoleBasedGroup workload strategy
+const RBGStrategyName = "RoleBasedGroup"
+
+// RBGStrategy deploys all components of an InferenceService as the roles of one RoleBasedGroup named after
+// the InferenceService. It applies when the deployment mode annotation is RoleBasedGroup.
+type RBGStrategy struct {
+	client        client.Client
+	clientset     kubernetes.Interface
+	scheme        *runtime.Scheme
+	statusManager *status.StatusReconciler
+	log           logr.Logger
+}
+
+var _ WorkloadStrategy = &RBGStrategy{}
+
+// NewRBGStrategy creates the RoleBasedGroup workload strategy
+func NewRBGStrategy(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme, statusManager *status.StatusReconciler, log logr.Logger) *RBGStrategy {
+	return &RBGStrategy{
+		client:        client,
+		clientset:     clientset,
+		scheme:        scheme,
+		statusManager: statusManager,
+		log:           log,
+	}
+}
+
+// GetStrategyName implements WorkloadStrategy interface
+func (s *RBGStrategy) GetStrategyName() string {
+	return RBGStrategyName
+}
+
+// IsApplicable implements WorkloadStrategy interface
+func (s *RBGStrategy) IsApplicable(isvc *v1beta1.InferenceService, annotations map[string]string) bool {
+	mode, found := isvcutils.GetDeploymentModeFromAnnotations(annotations)
+	return found && mode == constants.RoleBasedGroup
+}
+
+// ValidateDeploymentModes implements WorkloadStrategy interface. Roles are Deployments or LeaderWorkerSets.
+func (s *RBGStrategy) ValidateDeploymentModes(modes *ComponentDeploymentModes) error {
+	for component, mode := range map[v1beta1.ComponentType]constants.DeploymentModeType{
+		v1beta1.EngineComponent:  modes.Engine,
+		v1beta1.DecoderComponent: modes.Decoder,
+		v1beta1.RouterComponent:  modes.Router,
+	} {
+		if mode != "" && mode != constants.RawDeployment && mode != constants.MultiNode {
+			return fmt.Errorf("%s deployment mode %s is not supported in a RoleBasedGroup, only %s and %s are",
+				component, mode, constants.RawDeployment, constants.MultiNode)
+		}
+	}
+	return nil
+}
+
+// ReconcileWorkload implements WorkloadStrategy interface
+func (s *RBGStrategy) ReconcileWorkload(ctx context.Context, request *WorkloadReconcileRequest) (ctrl.Result, error) {
+	isvc := request.InferenceService
+
+	configs, err := s.roleConfigs(isvc, request.Components)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	roles := make([]string, 0, len(configs))
+	for _, config := range configs {
+		roles = append(roles, string(config.ComponentType))
+	}
+	ratios, err := rbg.ParseRoleRatios(isvc.Annotations[constants.RoleBasedGroupRoleRatio], roles)
+	if err != nil {
+		return ctrl.Result{}, reconcile.TerminalError(errors.Wrapf(err, "invalid %s annotation", constants.RoleBasedGroupRoleRatio))
+	}
+
+	// The workloads of the roles have the same names as the component workloads
+	deleting, err := s.deleteComponentWorkloads(ctx, isvc, configs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{RequeueAfter: workloadCleanupRequeueInterval}, nil
+	}
+
+	groupMeta := metav1.ObjectMeta{
+		Name:      isvc.Name,
+		Namespace: isvc.Namespace,
+		Labels:    map[string]string{constants.InferenceServicePodLabelKey: isvc.Name},
+	}
+	reconciler, err := rbg.NewRBGReconciler(s.client, s.clientset, s.scheme, groupMeta, configs, ratios)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to render RoleBasedGroup")
+	}
+	if err := reconciler.SetControllerReferences(isvc, s.scheme); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to set controller references")
+	}
+	group, err := reconciler.Reconcile()
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile RoleBasedGroup")
+	}
+
+	if err := s.propagateStatus(isvc, group, configs, reconciler); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// roleConfigs renders the role of each component, reconciling the resources the roles depend on
+func (s *RBGStrategy) roleConfigs(isvc *v1beta1.InferenceService, componentList []components.Component) ([]rbg.RoleConfig, error) {
+	configs := make([]rbg.RoleConfig, 0, len(componentList))
+	for _, component := range componentList {
+		extractor, ok := component.(components.ComponentConfigExtractor)
+		if !ok {
+			return nil, fmt.Errorf("component %T cannot be deployed as a role of a RoleBasedGroup", component)
+		}
+		componentType := extractor.GetComponentType()
+
+		objectMeta, err := extractor.GetObjectMeta(isvc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to reconcile %s object metadata", componentType)
+		}
+		if err := extractor.ReconcileDependencies(isvc, objectMeta); err != nil {
+			return nil, errors.Wrapf(err, "failed to reconcile %s dependencies", componentType)
+		}
+		podSpec, err := extractor.GetPodSpec(isvc, &objectMeta)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to reconcile %s pod spec", componentType)
+		}
+
+		config := rbg.RoleConfig{
+			ComponentType:          componentType,
+			DeploymentMode:         extractor.GetDeploymentMode(),
+			ObjectMeta:             objectMeta,
+			PodSpec:                podSpec,
+			ComponentExtensionSpec: extractor.GetComponentSpec(),
+		}
+		if config.DeploymentMode == constants.MultiNode {
+			if config.WorkerPodSpec, err = extractor.GetWorkerPodSpec(isvc, &objectMeta); err != nil {
+				return nil, errors.Wrapf(err, "failed to reconcile %s worker pod spec", componentType)
+			}
+			config.WorkerSize = extractor.GetWorkerSize()
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// deleteComponentWorkloads deletes the Deployments and LeaderWorkerSets the components were deployed as, and
+// reports whether any of them still exists
+func (s *RBGStrategy) deleteComponentWorkloads(ctx context.Context, isvc *v1beta1.InferenceService, configs []rbg.RoleConfig) (bool, error) {
+	deleting := false
+	for _, config := range configs {
+		name := config.ObjectMeta.Name
+		exists, err := deleteControlledObject(ctx, s.client, s.log, isvc,
+			types.NamespacedName{Namespace: isvc.Namespace, Name: name}, &appsv1.Deployment{})
+		if err != nil {
+			return false, err
+		}
+		deleting = deleting || exists
+
+		exists, err = deleteControlledObject(ctx, s.client, s.log, isvc,
+			types.NamespacedName{Namespace: isvc.Namespace, Name: constants.LWSName(name)}, &lws.LeaderWorkerSet{})
+		if err != nil {
+			return false, err
+		}
+		deleting = deleting || exists
+	}
+	return deleting, nil
+}
+
+// propagateStatus propagates the readiness of each role and the model status of its pods
+func (s *RBGStrategy) propagateStatus(isvc *v1beta1.InferenceService, group *rbg.RoleBasedGroup, configs []rbg.RoleConfig, reconciler *rbg.RBGReconciler) error {
+	for _, config := range configs {
+		var readyReplicas, replicas int32
+		if roleStatus := group.Status.GetRoleStatus(string(config.ComponentType)); roleStatus != nil {
+			readyReplicas, replicas = roleStatus.ReadyReplicas, roleStatus.Replicas
+		}
+		s.statusManager.PropagateRoleStatus(&isvc.Status, config.ComponentType, readyReplicas, replicas,
+			isvc.Generation, reconciler.URLs[config.ComponentType])
+
+		// Leader pods of MultiNode roles carry the app label as well
+		pods, err := isvcutils.ListPodsByLabel(s.client, isvc.Namespace, constants.RawDeploymentAppLabel, constants.GetRawServiceLabel(config.ObjectMeta.Name))
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %s pods by label", config.ComponentType)
+		}
+		s.statusManager.PropagateModelStatus(&isvc.Status, isvc.Status.Components[config.ComponentType], pods, true)
+	}
+	return nil
+}
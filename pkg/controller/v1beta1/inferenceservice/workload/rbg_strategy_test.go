@@ -0,0 +1,197 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbg"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+)
+
+// fakeComponent renders a component without reconciling it
+type fakeComponent struct {
+	componentType  v1beta1.ComponentType
+	deploymentMode constants.DeploymentModeType
+	dependencies   bool
+}
+
+var _ components.ComponentConfigExtractor = &fakeComponent{}
+
+func (f *fakeComponent) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	panic("components of a RoleBasedGroup are not reconciled individually")
+}
+
+func (f *fakeComponent) GetComponentType() v1beta1.ComponentType { return f.componentType }
+
+func (f *fakeComponent) GetComponentSpec() *v1beta1.ComponentExtensionSpec {
+	return &v1beta1.ComponentExtensionSpec{MinReplicas: ptr.To(1), MaxReplicas: 2}
+}
+
+func (f *fakeComponent) GetServiceSuffix() string { return "-" + string(f.componentType) }
+
+func (f *fakeComponent) ValidateSpec() error { return nil }
+
+func (f *fakeComponent) GetDeploymentMode() constants.DeploymentModeType { return f.deploymentMode }
+
+func (f *fakeComponent) GetObjectMeta(isvc *v1beta1.InferenceService) (metav1.ObjectMeta, error) {
+	return metav1.ObjectMeta{
+		Name:      isvc.Name + "-" + string(f.componentType),
+		Namespace: isvc.Namespace,
+		Labels:    map[string]string{constants.OMEComponentLabel: string(f.componentType)},
+	}, nil
+}
+
+func (f *fakeComponent) ReconcileDependencies(isvc *v1beta1.InferenceService, objectMeta metav1.ObjectMeta) error {
+	f.dependencies = true
+	return nil
+}
+
+func (f *fakeComponent) GetPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*corev1.PodSpec, error) {
+	return &corev1.PodSpec{Containers: []corev1.Container{{Name: "ome-container", Image: string(f.componentType)}}}, nil
+}
+
+func (f *fakeComponent) GetWorkerPodSpec(isvc *v1beta1.InferenceService, objectMeta *metav1.ObjectMeta) (*corev1.PodSpec, error) {
+	if f.deploymentMode != constants.MultiNode {
+		return nil, nil
+	}
+	return &corev1.PodSpec{Containers: []corev1.Container{{Name: "ome-container", Image: string(f.componentType) + "-worker"}}}, nil
+}
+
+func (f *fakeComponent) GetWorkerSize() int { return 1 }
+
+func newTestClientset() *fake.Clientset {
+	return fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "istio-ingressgateway.istio-system.svc.cluster.local",
+				"ingressDomain": "svc.cluster.local",
+				"domainTemplate": "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}"
+			}`,
+		},
+	})
+}
+
+func getRoleBasedGroup(t *testing.T, c client.Client) *rbg.RoleBasedGroup {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(rbg.GroupVersionKind)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama"}, obj))
+	group, err := rbg.FromUnstructured(obj)
+	require.NoError(t, err)
+	return group
+}
+
+func TestRBGStrategy_ReconcileWorkload(t *testing.T) {
+	isvc := testInferenceService(map[string]string{
+		constants.DeploymentMode:          string(constants.RoleBasedGroup),
+		constants.RoleBasedGroupRoleRatio: "engine=1,decoder=2",
+	})
+	isvc.Generation = 3
+	c, scheme := newTestClient(t, isvc)
+	strategy := NewRBGStrategy(c, newTestClientset(), scheme, status.NewStatusReconciler(), logr.Discard())
+
+	engine := &fakeComponent{componentType: v1beta1.EngineComponent, deploymentMode: constants.RawDeployment}
+	decoder := &fakeComponent{componentType: v1beta1.DecoderComponent, deploymentMode: constants.MultiNode}
+	router := &fakeComponent{componentType: v1beta1.RouterComponent, deploymentMode: constants.RawDeployment}
+	request := &WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components:       []components.Component{engine, decoder, router},
+	}
+
+	_, err := strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	assert.True(t, router.dependencies)
+
+	group := getRoleBasedGroup(t, c)
+	assert.True(t, metav1.IsControlledBy(group, isvc))
+	require.Len(t, group.Spec.Roles, 3)
+	assert.Equal(t, int32(2), *group.Spec.GetRole("decoder").Replicas)
+	assert.Equal(t, constants.LWSKind, group.Spec.GetRole("decoder").Workload.Kind)
+	assert.Equal(t, []string{"engine", "decoder"}, group.Spec.GetRole("router").Dependencies)
+
+	// Roles are not ready until RBG reports ready replicas
+	condition := isvc.Status.GetCondition(v1beta1.EngineReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(rbg.GroupVersionKind)
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama"}, obj))
+	require.NoError(t, unstructured.SetNestedSlice(obj.Object, []interface{}{
+		map[string]interface{}{"name": "engine", "readyReplicas": int64(1), "replicas": int64(1)},
+		map[string]interface{}{"name": "decoder", "readyReplicas": int64(1), "replicas": int64(2)},
+	}, "status", "roleStatuses"))
+	require.NoError(t, c.Update(context.TODO(), obj))
+
+	_, err = strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	condition = isvc.Status.GetCondition(v1beta1.EngineReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "http://llama-engine.default.svc.cluster.local", isvc.Status.Components[v1beta1.EngineComponent].URL.String())
+	condition = isvc.Status.GetCondition(v1beta1.DecoderReady)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, int64(3), isvc.Status.ObservedGeneration)
+}
+
+func TestRBGStrategy_DeletesComponentWorkloads(t *testing.T) {
+	isvc := testInferenceService(map[string]string{constants.DeploymentMode: string(constants.RoleBasedGroup)})
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:            "llama-engine",
+		Namespace:       "default",
+		OwnerReferences: controlledBy(isvc),
+	}}
+	c, scheme := newTestClient(t, isvc, deployment)
+	strategy := NewRBGStrategy(c, newTestClientset(), scheme, status.NewStatusReconciler(), logr.Discard())
+	request := &WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components:       []components.Component{&fakeComponent{componentType: v1beta1.EngineComponent, deploymentMode: constants.RawDeployment}},
+	}
+
+	// The group waits for the engine deployment to be deleted
+	result, err := strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	assert.Equal(t, workloadCleanupRequeueInterval, result.RequeueAfter)
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama-engine"}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	getRoleBasedGroup(t, c)
+}
+
+func TestRBGStrategy_InvalidRoleRatio(t *testing.T) {
+	isvc := testInferenceService(map[string]string{
+		constants.DeploymentMode:          string(constants.RoleBasedGroup),
+		constants.RoleBasedGroupRoleRatio: "engine=1,decoder=2",
+	})
+	c, scheme := newTestClient(t, isvc)
+	strategy := NewRBGStrategy(c, newTestClientset(), scheme, status.NewStatusReconciler(), logr.Discard())
+
+	_, err := strategy.ReconcileWorkload(context.TODO(), &WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components:       []components.Component{&fakeComponent{componentType: v1beta1.EngineComponent, deploymentMode: constants.RawDeployment}},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, reconcile.TerminalError(nil))
+}
@@ -0,0 +1,88 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbg"
+)
+
+// SingleComponentStrategyName is the name of the default workload strategy
+const SingleComponentStrategyName = "SingleComponent"
+
+// workloadCleanupRequeueInterval is how often reconciliation waits for the workloads of another strategy to be deleted
+const workloadCleanupRequeueInterval = 5 * time.Second
+
+// SingleComponentStrategy deploys each component as its own Deployment, LeaderWorkerSet, RayCluster or
+// Knative Service through the Reconcile method of the component
+type SingleComponentStrategy struct {
+	client client.Client
+	log    logr.Logger
+}
+
+var _ WorkloadStrategy = &SingleComponentStrategy{}
+
+// NewSingleComponentStrategy creates the default workload strategy
+func NewSingleComponentStrategy(client client.Client, log logr.Logger) *SingleComponentStrategy {
+	return &SingleComponentStrategy{client: client, log: log}
+}
+
+// GetStrategyName implements WorkloadStrategy interface
+func (s *SingleComponentStrategy) GetStrategyName() string {
+	return SingleComponentStrategyName
+}
+
+// IsApplicable implements WorkloadStrategy interface, the strategy deploys every InferenceService
+func (s *SingleComponentStrategy) IsApplicable(isvc *v1beta1.InferenceService, annotations map[string]string) bool {
+	return true
+}
+
+// ValidateDeploymentModes implements WorkloadStrategy interface, the components validate their own modes
+func (s *SingleComponentStrategy) ValidateDeploymentModes(modes *ComponentDeploymentModes) error {
+	return nil
+}
+
+// ReconcileWorkload implements WorkloadStrategy interface
+func (s *SingleComponentStrategy) ReconcileWorkload(ctx context.Context, request *WorkloadReconcileRequest) (ctrl.Result, error) {
+	isvc := request.InferenceService
+
+	// The workloads of a RoleBasedGroup have the same names as the component workloads
+	deleting, err := s.deleteRoleBasedGroup(ctx, isvc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		return ctrl.Result{RequeueAfter: workloadCleanupRequeueInterval}, nil
+	}
+
+	for _, reconciler := range request.Components {
+		result, err := reconciler.Reconcile(isvc)
+		if err != nil {
+			s.log.Error(err, "Failed to reconcile component",
+				"component", fmt.Sprintf("%T", reconciler),
+				"namespace", isvc.Namespace,
+				"inferenceService", isvc.Name)
+			return result, err
+		}
+		if result.Requeue || result.RequeueAfter > 0 {
+			return result, nil
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteRoleBasedGroup deletes the RoleBasedGroup the InferenceService was deployed as, and reports whether it
+// still exists
+func (s *SingleComponentStrategy) deleteRoleBasedGroup(ctx context.Context, isvc *v1beta1.InferenceService) (bool, error) {
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(rbg.GroupVersionKind)
+	return deleteControlledObject(ctx, s.client, s.log, isvc, types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, group)
+}
@@ -0,0 +1,111 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+)
+
+// WorkloadStrategy deploys the components of an InferenceService as workloads
+type WorkloadStrategy interface {
+	// GetStrategyName returns the name of the strategy
+	GetStrategyName() string
+
+	// IsApplicable reports whether the strategy deploys the InferenceService
+	IsApplicable(isvc *v1beta1.InferenceService, annotations map[string]string) bool
+
+	// ValidateDeploymentModes checks that the strategy supports the deployment modes of the components
+	ValidateDeploymentModes(modes *ComponentDeploymentModes) error
+
+	// ReconcileWorkload creates or updates the workloads of the components and propagates their status
+	ReconcileWorkload(ctx context.Context, request *WorkloadReconcileRequest) (ctrl.Result, error)
+}
+
+// ComponentDeploymentModes are the deployment modes of the components of an InferenceService.
+// Modes of absent components are empty.
+type ComponentDeploymentModes struct {
+	Engine  constants.DeploymentModeType
+	Decoder constants.DeploymentModeType
+	Router  constants.DeploymentModeType
+}
+
+// WorkloadReconcileRequest is the input of a workload strategy
+type WorkloadReconcileRequest struct {
+	InferenceService *v1beta1.InferenceService
+	// Components are the components to deploy, in the order engine, decoder, router
+	Components      []components.Component
+	DeploymentModes *ComponentDeploymentModes
+}
+
+// WorkloadStrategyManager selects the workload strategy of an InferenceService
+type WorkloadStrategyManager struct {
+	strategies      []WorkloadStrategy
+	defaultStrategy WorkloadStrategy
+	log             logr.Logger
+}
+
+// NewWorkloadStrategyManager creates a manager that falls back to the given default strategy
+func NewWorkloadStrategyManager(defaultStrategy WorkloadStrategy, log logr.Logger) *WorkloadStrategyManager {
+	return &WorkloadStrategyManager{
+		defaultStrategy: defaultStrategy,
+		log:             log,
+	}
+}
+
+// RegisterStrategy adds a strategy. Strategies are checked in the order they are registered.
+func (m *WorkloadStrategyManager) RegisterStrategy(strategy WorkloadStrategy) {
+	m.strategies = append(m.strategies, strategy)
+}
+
+// SelectStrategy returns the first applicable strategy, or the default strategy, after validating the
+// deployment modes of the components against it
+func (m *WorkloadStrategyManager) SelectStrategy(isvc *v1beta1.InferenceService, annotations map[string]string, modes *ComponentDeploymentModes) (WorkloadStrategy, error) {
+	selected := m.defaultStrategy
+	for _, strategy := range m.strategies {
+		if strategy.IsApplicable(isvc, annotations) {
+			selected = strategy
+			break
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("no workload strategy applies to InferenceService %s/%s", isvc.Namespace, isvc.Name)
+	}
+	if err := selected.ValidateDeploymentModes(modes); err != nil {
+		return nil, fmt.Errorf("workload strategy %s: %w", selected.GetStrategyName(), err)
+	}
+	m.log.V(1).Info("Selected workload strategy", "namespace", isvc.Namespace, "inferenceService", isvc.Name, "strategy", selected.GetStrategyName())
+	return selected, nil
+}
+
+// deleteControlledObject deletes an object the InferenceService controls, and reports whether it still exists.
+// Workloads of one strategy are deleted before another strategy creates workloads with the same names.
+func deleteControlledObject(ctx context.Context, c client.Client, log logr.Logger, isvc *v1beta1.InferenceService, key types.NamespacedName, obj client.Object) (bool, error) {
+	if err := c.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !metav1.IsControlledBy(obj, isvc) {
+		return false, nil
+	}
+	if obj.GetDeletionTimestamp() == nil {
+		log.Info("Deleting workload of another workload strategy", "namespace", key.Namespace, "name", key.Name, "type", fmt.Sprintf("%T", obj))
+		// Foreground deletion keeps the object until the objects it owns are gone
+		if err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
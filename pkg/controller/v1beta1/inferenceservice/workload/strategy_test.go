@@ -0,0 +1,179 @@
+package workload
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	lws "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/components"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/reconcilers/rbg"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/inferenceservice/status"
+)
+
+func newTestClient(t *testing.T, objs ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, lws.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(rbg.GroupVersionKind, meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.Add(lws.GroupVersion.WithKind(constants.LWSKind), meta.RESTScopeNamespace)
+	mapper.Add(autoscalingv2.SchemeGroupVersion.WithKind("HorizontalPodAutoscaler"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Service"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+
+	return fakeclient.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(objs...).Build(), scheme
+}
+
+func testInferenceService(annotations map[string]string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "llama",
+			Namespace:   "default",
+			UID:         "isvc-uid",
+			Annotations: annotations,
+		},
+	}
+}
+
+// controlledBy returns an owner reference that makes the InferenceService the controller of an object
+func controlledBy(isvc *v1beta1.InferenceService) []metav1.OwnerReference {
+	return []metav1.OwnerReference{*metav1.NewControllerRef(isvc, v1beta1.SchemeGroupVersion.WithKind("InferenceService"))}
+}
+
+type recordingComponent struct {
+	name     string
+	result   ctrl.Result
+	recorded *[]string
+}
+
+func (c *recordingComponent) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, error) {
+	*c.recorded = append(*c.recorded, c.name)
+	return c.result, nil
+}
+
+func TestWorkloadStrategyManager_SelectStrategy(t *testing.T) {
+	c, scheme := newTestClient(t)
+	manager := NewWorkloadStrategyManager(NewSingleComponentStrategy(c, logr.Discard()), logr.Discard())
+	manager.RegisterStrategy(NewRBGStrategy(c, nil, scheme, status.NewStatusReconciler(), logr.Discard()))
+
+	rbgAnnotations := map[string]string{constants.DeploymentMode: string(constants.RoleBasedGroup)}
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		modes        *ComponentDeploymentModes
+		expectedName string
+		expectErr    bool
+	}{
+		{
+			name:         "default strategy",
+			modes:        &ComponentDeploymentModes{Engine: constants.Serverless},
+			expectedName: SingleComponentStrategyName,
+		},
+		{
+			name:         "component deployment mode annotation",
+			annotations:  map[string]string{constants.DeploymentMode: string(constants.RawDeployment)},
+			modes:        &ComponentDeploymentModes{Engine: constants.RawDeployment},
+			expectedName: SingleComponentStrategyName,
+		},
+		{
+			name:         "role based group",
+			annotations:  rbgAnnotations,
+			modes:        &ComponentDeploymentModes{Engine: constants.RawDeployment, Decoder: constants.MultiNode, Router: constants.RawDeployment},
+			expectedName: RBGStrategyName,
+		},
+		{
+			name:        "role based group with serverless engine",
+			annotations: rbgAnnotations,
+			modes:       &ComponentDeploymentModes{Engine: constants.Serverless},
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := manager.SelectStrategy(testInferenceService(tt.annotations), tt.annotations, tt.modes)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, strategy.GetStrategyName())
+		})
+	}
+}
+
+func TestSingleComponentStrategy_ReconcileWorkload(t *testing.T) {
+	isvc := testInferenceService(nil)
+	c, _ := newTestClient(t, isvc)
+	strategy := NewSingleComponentStrategy(c, logr.Discard())
+
+	var recorded []string
+	request := &WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components: []components.Component{
+			&recordingComponent{name: "engine", recorded: &recorded},
+			&recordingComponent{name: "decoder", result: ctrl.Result{Requeue: true}, recorded: &recorded},
+			&recordingComponent{name: "router", recorded: &recorded},
+		},
+	}
+
+	// Components are reconciled in order until one requeues
+	result, err := strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	assert.True(t, result.Requeue)
+	assert.Equal(t, []string{"engine", "decoder"}, recorded)
+}
+
+func TestSingleComponentStrategy_DeletesRoleBasedGroup(t *testing.T) {
+	isvc := testInferenceService(nil)
+	group := &unstructured.Unstructured{}
+	group.SetGroupVersionKind(rbg.GroupVersionKind)
+	group.SetNamespace("default")
+	group.SetName("llama")
+	group.SetOwnerReferences(controlledBy(isvc))
+	c, _ := newTestClient(t, isvc, group)
+	strategy := NewSingleComponentStrategy(c, logr.Discard())
+
+	var recorded []string
+	request := &WorkloadReconcileRequest{
+		InferenceService: isvc,
+		Components:       []components.Component{&recordingComponent{name: "engine", recorded: &recorded}},
+	}
+
+	// Components wait for the group and its workloads to be deleted
+	result, err := strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	assert.Equal(t, workloadCleanupRequeueInterval, result.RequeueAfter)
+	assert.Empty(t, recorded)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(rbg.GroupVersionKind)
+	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "llama"}, existing)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = strategy.ReconcileWorkload(context.TODO(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"engine"}, recorded)
+}
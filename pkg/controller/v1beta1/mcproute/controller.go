@@ -0,0 +1,329 @@
+package mcproute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/mcp/gateway"
+)
+
+// +kubebuilder:rbac:groups=ome.io,resources=mcproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ome.io,resources=mcproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ome.io,resources=mcproutes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ome.io,resources=mcpservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// apiKeyResyncPeriod is how often routes re-read their API key Secrets, which are not watched
+const apiKeyResyncPeriod = 5 * time.Minute
+
+// Condition reasons of MCPRoutes
+const (
+	reasonReady                = "Ready"
+	reasonNoReadyBackend       = "NoReadyBackend"
+	reasonGatewayNotReady      = "GatewayNotReady"
+	reasonGatewayNotConfigured = "GatewayNotConfigured"
+	reasonInvalidRoute         = "InvalidRoute"
+)
+
+// MCPRouteReconciler reports the state of MCPRoutes and runs an MCP gateway in every namespace with routes.
+// The gateway serves all routes of its namespace from a route table rendered into a Secret.
+type MCPRouteReconciler struct {
+	client.Client
+	Clientset kubernetes.Interface
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+}
+
+func (r *MCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("mcproute", req.NamespacedName)
+
+	mcpConfig, err := controllerconfig.NewMCPConfig(r.Clientset)
+	if err != nil {
+		log.Error(err, "failed to load MCP config")
+		return ctrl.Result{}, err
+	}
+
+	routes := &v1beta1.MCPRouteList{}
+	if err := r.List(ctx, routes, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	var active []*v1beta1.MCPRoute
+	var current *v1beta1.MCPRoute
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !route.DeletionTimestamp.IsZero() {
+			continue
+		}
+		active = append(active, route)
+		if route.Name == req.Name {
+			current = route
+		}
+	}
+
+	if len(active) == 0 {
+		log.Info("No MCPRoutes left in namespace, removing the MCP gateway")
+		return ctrl.Result{}, r.deleteGateway(ctx, req.Namespace)
+	}
+
+	table := &gateway.RouteTable{}
+	var currentRoute *resolvedRoute
+	for _, route := range active {
+		resolved, err := resolveRoute(ctx, r.Client, r.Clientset, route)
+		if err != nil {
+			log.Error(err, "failed to resolve MCPRoute", "route", route.Name)
+			return ctrl.Result{}, err
+		}
+		table.Routes = append(table.Routes, resolved.route)
+		if route == current {
+			currentRoute = resolved
+		}
+	}
+	sort.Slice(table.Routes, func(i, j int) bool { return table.Routes[i].Name < table.Routes[j].Name })
+
+	if mcpConfig.GatewayImage == "" {
+		if current != nil {
+			return ctrl.Result{}, r.updateStatus(ctx, current, currentRoute, mcpConfig, metav1.ConditionFalse,
+				reasonGatewayNotConfigured, "The mcp gatewayImage is not configured")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	gatewayDeployment, err := r.reconcileGateway(ctx, req.Namespace, table, mcpConfig)
+	if err != nil {
+		log.Error(err, "failed to reconcile MCP gateway")
+		return ctrl.Result{}, err
+	}
+	if current == nil {
+		return ctrl.Result{}, nil
+	}
+
+	conditionStatus, reason, message := routeReadiness(currentRoute, gatewayDeployment)
+	if err := r.updateStatus(ctx, current, currentRoute, mcpConfig, conditionStatus, reason, message); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(routeSecretNames(current)) > 0 {
+		return ctrl.Result{RequeueAfter: apiKeyResyncPeriod}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// routeReadiness summarizes whether a route can serve requests
+func routeReadiness(route *resolvedRoute, gatewayDeployment *appsv1.Deployment) (metav1.ConditionStatus, string, string) {
+	if len(route.problems) > 0 {
+		return metav1.ConditionFalse, reasonInvalidRoute, strings.Join(route.problems, "; ")
+	}
+	if gatewayDeployment.Status.ReadyReplicas == 0 {
+		return metav1.ConditionFalse, reasonGatewayNotReady, "Waiting for the MCP gateway to become ready"
+	}
+	for _, backend := range route.route.Backends {
+		if backend.Ready {
+			return metav1.ConditionTrue, reasonReady, "Route is served by the MCP gateway"
+		}
+	}
+	return metav1.ConditionFalse, reasonNoReadyBackend, "None of the route backends is ready"
+}
+
+// reconcileGateway renders the route table and ensures the gateway Deployment and Service of the namespace
+func (r *MCPRouteReconciler) reconcileGateway(ctx context.Context, namespace string, table *gateway.RouteTable,
+	mcpConfig *controllerconfig.MCPConfig) (*appsv1.Deployment, error) {
+	data, err := json.Marshal(table)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.reconcileRoutesSecret(ctx, namespace, data); err != nil {
+		return nil, fmt.Errorf("failed to reconcile gateway routes: %w", err)
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: constants.MCPGatewayName, Namespace: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = gatewayLabels()
+		desired := gatewayServiceSpec(mcpConfig)
+		service.Spec.Selector = desired.Selector
+		service.Spec.Ports = desired.Ports
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to reconcile gateway service: %w", err)
+	}
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: constants.MCPGatewayName, Namespace: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = gatewayLabels()
+		deployment.Spec = gatewayDeploymentSpec(mcpConfig)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to reconcile gateway deployment: %w", err)
+	}
+	return deployment, nil
+}
+
+// reconcileRoutesSecret writes the route table of a namespace
+func (r *MCPRouteReconciler) reconcileRoutesSecret(ctx context.Context, namespace string, data []byte) error {
+	secrets := r.Clientset.CoreV1().Secrets(namespace)
+	existing, err := secrets.Get(ctx, constants.MCPGatewayRoutesSecretName, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		_, err = secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.MCPGatewayRoutesSecretName,
+				Namespace: namespace,
+				Labels:    gatewayLabels(),
+			},
+			Data: map[string][]byte{constants.MCPGatewayRoutesFileName: data},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(existing.Data[constants.MCPGatewayRoutesFileName], data) {
+		return nil
+	}
+	existing.Data = map[string][]byte{constants.MCPGatewayRoutesFileName: data}
+	_, err = secrets.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteGateway removes the gateway of a namespace without routes
+func (r *MCPRouteReconciler) deleteGateway(ctx context.Context, namespace string) error {
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: constants.MCPGatewayName, Namespace: namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: constants.MCPGatewayName, Namespace: namespace}},
+	} {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierr.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		// Only remove resources the controller created
+		if obj.GetLabels()[constants.MCPGatewayLabelKey] != constants.MCPGatewayName {
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	secrets := r.Clientset.CoreV1().Secrets(namespace)
+	secret, err := secrets.Get(ctx, constants.MCPGatewayRoutesSecretName, metav1.GetOptions{})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if secret.Labels[constants.MCPGatewayLabelKey] != constants.MCPGatewayName {
+		return nil
+	}
+	return client.IgnoreNotFound(secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{}))
+}
+
+func (r *MCPRouteReconciler) updateStatus(ctx context.Context, route *v1beta1.MCPRoute, resolved *resolvedRoute,
+	mcpConfig *controllerconfig.MCPConfig, conditionStatus metav1.ConditionStatus, reason, message string) error {
+	latest := &v1beta1.MCPRoute{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: route.Namespace, Name: route.Name}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	desired := latest.DeepCopy()
+	desired.Status.ObservedGeneration = latest.Generation
+	desired.Status.GatewayURL = gateway.GatewayURL(route.Namespace, route.Name, mcpConfig.GatewayPort)
+	desired.Status.BackendStatuses = resolved.backendStatuses
+	meta.SetStatusCondition(&desired.Status.Conditions, metav1.Condition{
+		Type:               v1beta1.MCPConditionReady,
+		Status:             conditionStatus,
+		ObservedGeneration: latest.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if equality.Semantic.DeepEqual(latest.Status, desired.Status) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, desired, client.MergeFrom(latest)); err != nil && !apierr.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager wires the controller. Changes to servers and the gateway Deployment requeue the routes
+// of their namespace.
+func (r *MCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isGateway := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[constants.MCPGatewayLabelKey] == constants.MCPGatewayName
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.MCPRoute{}).
+		Watches(
+			&v1beta1.MCPServer{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.routeRequests(ctx, obj.GetNamespace(), func(route *v1beta1.MCPRoute) bool {
+					return containsName(routeServerNames(route), obj.GetName())
+				})
+			}),
+		).
+		Watches(
+			&appsv1.Deployment{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.routeRequests(ctx, obj.GetNamespace(), nil)
+			}),
+			builder.WithPredicates(isGateway),
+		).
+		Complete(r)
+}
+
+// routeRequests returns reconcile requests for the routes of a namespace accepted by the filter
+func (r *MCPRouteReconciler) routeRequests(ctx context.Context, namespace string, filter func(*v1beta1.MCPRoute) bool) []reconcile.Request {
+	routes := &v1beta1.MCPRouteList{}
+	if err := r.List(ctx, routes, client.InNamespace(namespace)); err != nil {
+		r.Log.Error(err, "failed to list MCPRoutes", "namespace", namespace)
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if filter != nil && !filter(route) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: route.Namespace,
+			Name:      route.Name,
+		}})
+	}
+	return requests
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package mcproute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/mcp/gateway"
+)
+
+const testNamespace = "team"
+
+func newReconciler(g *WithT, mcpConfig string, objs []client.Object, secrets ...runtime.Object) (*MCPRouteReconciler, client.Client, kubernetes.Interface) {
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1beta1.MCPRoute{}).
+		Build()
+	clientset := fake.NewSimpleClientset(append(secrets, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data:       map[string]string{"mcp": mcpConfig},
+	})...)
+	return &MCPRouteReconciler{
+		Client:    c,
+		Clientset: clientset,
+		Log:       ctrl.Log.WithName("MCPRouteTest"),
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+	}, c, clientset
+}
+
+func readyServer(name, url string, ready bool) *v1beta1.MCPServer {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &v1beta1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: v1beta1.MCPServerSpec{
+			Transport:   v1beta1.MCPTransportStreamableHTTP,
+			Remote:      &v1beta1.RemoteMCPServer{URL: url},
+			ToolsFilter: []string{"search_*"},
+		},
+		Status: v1beta1.MCPServerStatus{
+			URL:        url,
+			Conditions: []metav1.Condition{{Type: v1beta1.MCPConditionReady, Status: status, Reason: "Test"}},
+		},
+	}
+}
+
+func reconcileRoute(g *WithT, r *MCPRouteReconciler, name string) {
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: name}})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestMCPRoute_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	route := &v1beta1.MCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: testNamespace, Generation: 2},
+		Spec: v1beta1.MCPRouteSpec{
+			BackendRefs: []v1beta1.MCPBackendRef{
+				{ServerRef: corev1.LocalObjectReference{Name: "stable"}, Weight: ptr.To(int32(90))},
+				{ServerRef: corev1.LocalObjectReference{Name: "canary"}, Weight: ptr.To(int32(10))},
+			},
+			Matches: []v1beta1.MCPRouteMatch{{
+				Tools:       []string{"db_*"},
+				BackendRefs: []v1beta1.MCPBackendRef{{ServerRef: corev1.LocalObjectReference{Name: "database"}}},
+			}},
+			MCPRoutePolicy: v1beta1.MCPRoutePolicy{
+				Authentication: &v1beta1.MCPAuthentication{APIKey: &v1beta1.APIKeyAuthentication{
+					SecretRefs: []corev1.SecretKeySelector{{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "keys"},
+						Key:                  "key",
+					}},
+				}},
+			},
+		},
+	}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: testNamespace},
+		Data:       map[string][]byte{"key": []byte("s3cret")},
+	}
+	r, c, clientset := newReconciler(g, `{"gatewayImage": "ome/mcp-gateway:v1", "gatewayReplicas": 2}`,
+		[]client.Object{
+			route,
+			readyServer("stable", "http://stable.team.svc.cluster.local:8080/mcp", true),
+			readyServer("canary", "http://canary.team.svc.cluster.local:8080/mcp", false),
+		}, keys)
+
+	reconcileRoute(g, r, "tools")
+
+	// The route table holds the resolved backends and keys
+	secret, err := clientset.CoreV1().Secrets(testNamespace).Get(ctx, constants.MCPGatewayRoutesSecretName, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	table := &gateway.RouteTable{}
+	g.Expect(json.Unmarshal(secret.Data[constants.MCPGatewayRoutesFileName], table)).To(Succeed())
+	g.Expect(table.Routes).To(HaveLen(1))
+	resolved := table.Routes[0]
+	g.Expect(resolved.Backends).To(Equal([]gateway.Backend{
+		{Name: "stable", URL: "http://stable.team.svc.cluster.local:8080/mcp", Weight: 90, Ready: true, Tools: []string{"search_*"}},
+		{Name: "canary", URL: "http://canary.team.svc.cluster.local:8080/mcp", Weight: 10, Ready: false, Tools: []string{"search_*"}},
+	}))
+	g.Expect(resolved.Matches).To(HaveLen(1))
+	g.Expect(resolved.Matches[0].Backends).To(Equal([]gateway.Backend{{Name: "database", Weight: 1}}))
+	g.Expect(resolved.Authentication.APIKey.Header).To(Equal("X-API-Key"))
+	g.Expect(resolved.Authentication.APIKey.Keys).To(Equal(map[string]string{"s3cret": "apikey:keys"}))
+
+	// The namespace gateway is deployed
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: constants.MCPGatewayName}, deployment)).To(Succeed())
+	g.Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+	g.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("ome/mcp-gateway:v1"))
+	g.Expect(deployment.Spec.Template.Spec.Volumes[0].Secret.SecretName).To(Equal(constants.MCPGatewayRoutesSecretName))
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: constants.MCPGatewayName}, &corev1.Service{})).To(Succeed())
+
+	updated := &v1beta1.MCPRoute{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "tools"}, updated)).To(Succeed())
+	g.Expect(updated.Status.ObservedGeneration).To(Equal(int64(2)))
+	g.Expect(updated.Status.GatewayURL).To(Equal("http://mcp-gateway.team.svc.cluster.local:8080/routes/team/tools"))
+	g.Expect(updated.Status.BackendStatuses).To(HaveLen(3))
+	g.Expect(updated.Status.BackendStatuses[0].ServerRef.Name).To(Equal("canary"))
+	g.Expect(updated.Status.BackendStatuses[0].Message).To(Equal("MCPServer is not ready"))
+	g.Expect(updated.Status.BackendStatuses[1].ServerRef.Name).To(Equal("database"))
+	g.Expect(updated.Status.BackendStatuses[1].Message).To(ContainSubstring("not found"))
+	g.Expect(updated.Status.BackendStatuses[2].Ready).To(BeTrue())
+	ready := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.MCPConditionReady)
+	g.Expect(ready).NotTo(BeNil())
+	g.Expect(ready.Reason).To(Equal(reasonGatewayNotReady))
+
+	// Ready once the gateway is
+	deployment.Status.ReadyReplicas = 1
+	g.Expect(c.Status().Update(ctx, deployment)).To(Succeed())
+	reconcileRoute(g, r, "tools")
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "tools"}, updated)).To(Succeed())
+	g.Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta1.MCPConditionReady)).To(BeTrue())
+
+	// The gateway is removed with the last route
+	g.Expect(c.Delete(ctx, updated)).To(Succeed())
+	reconcileRoute(g, r, "tools")
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: constants.MCPGatewayName}, &appsv1.Deployment{})).NotTo(Succeed())
+	g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: constants.MCPGatewayName}, &corev1.Service{})).NotTo(Succeed())
+	_, err = clientset.CoreV1().Secrets(testNamespace).Get(ctx, constants.MCPGatewayRoutesSecretName, metav1.GetOptions{})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestMCPRoute_Reconcile_Problems(t *testing.T) {
+	route := &v1beta1.MCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: testNamespace},
+		Spec: v1beta1.MCPRouteSpec{
+			BackendRefs: []v1beta1.MCPBackendRef{{ServerRef: corev1.LocalObjectReference{Name: "stable"}}},
+			MCPRoutePolicy: v1beta1.MCPRoutePolicy{
+				Authentication: &v1beta1.MCPAuthentication{APIKey: &v1beta1.APIKeyAuthentication{
+					Header: "Authorization",
+					SecretRefs: []corev1.SecretKeySelector{{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+						Key:                  "key",
+					}},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mcpConfig  string
+		wantReason string
+		wantDeploy bool
+	}{
+		{
+			name:       "gateway image not configured",
+			mcpConfig:  `{}`,
+			wantReason: reasonGatewayNotConfigured,
+		},
+		{
+			name:       "missing API key secret",
+			mcpConfig:  `{"gatewayImage": "ome/mcp-gateway:v1"}`,
+			wantReason: reasonInvalidRoute,
+			wantDeploy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+			r, c, _ := newReconciler(g, tt.mcpConfig, []client.Object{
+				route.DeepCopy(),
+				readyServer("stable", "http://stable.team.svc.cluster.local:8080/mcp", true),
+			})
+			reconcileRoute(g, r, "tools")
+
+			updated := &v1beta1.MCPRoute{}
+			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "tools"}, updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.MCPConditionReady)
+			g.Expect(ready).NotTo(BeNil())
+			g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(ready.Reason).To(Equal(tt.wantReason))
+
+			err := c.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: constants.MCPGatewayName}, &appsv1.Deployment{})
+			if tt.wantDeploy {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
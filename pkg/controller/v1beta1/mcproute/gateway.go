@@ -0,0 +1,111 @@
+package mcproute
+
+import (
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+const (
+	// gatewayRoutesMountPath is where the route table Secret is mounted in gateway pods
+	gatewayRoutesMountPath = "/etc/mcp-gateway"
+	gatewayRoutesVolume    = "routes"
+	gatewayPortName        = "mcp"
+)
+
+// gatewayDeploymentSpec renders the gateway Deployment of a namespace
+func gatewayDeploymentSpec(config *controllerconfig.MCPConfig) appsv1.DeploymentSpec {
+	labels := gatewayLabels()
+	port := config.GatewayPort
+	return appsv1.DeploymentSpec{
+		Replicas: ptr.To(config.GatewayReplicas),
+		Selector: &metav1.LabelSelector{MatchLabels: labels},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: corev1.PodSpec{
+				AutomountServiceAccountToken: ptr.To(false),
+				SecurityContext: &corev1.PodSecurityContext{
+					RunAsNonRoot: ptr.To(true),
+				},
+				Containers: []corev1.Container{{
+					Name:  constants.MCPGatewayName,
+					Image: config.GatewayImage,
+					Args: []string{
+						"--port", strconv.Itoa(int(port)),
+						"--routes-file", gatewayRoutesMountPath + "/" + constants.MCPGatewayRoutesFileName,
+					},
+					Ports: []corev1.ContainerPort{{
+						Name:          gatewayPortName,
+						ContainerPort: port,
+						Protocol:      corev1.ProtocolTCP,
+					}},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(port)},
+						},
+					},
+					Resources: gatewayResources(config),
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+						ReadOnlyRootFilesystem:   ptr.To(true),
+					},
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      gatewayRoutesVolume,
+						MountPath: gatewayRoutesMountPath,
+						ReadOnly:  true,
+					}},
+				}},
+				Volumes: []corev1.Volume{{
+					Name: gatewayRoutesVolume,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: constants.MCPGatewayRoutesSecretName},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// gatewayServiceSpec renders the gateway Service of a namespace
+func gatewayServiceSpec(config *controllerconfig.MCPConfig) corev1.ServiceSpec {
+	return corev1.ServiceSpec{
+		Selector: gatewayLabels(),
+		Ports: []corev1.ServicePort{{
+			Name:       gatewayPortName,
+			Port:       config.GatewayPort,
+			TargetPort: intstr.FromInt32(config.GatewayPort),
+			Protocol:   corev1.ProtocolTCP,
+		}},
+	}
+}
+
+// gatewayResources returns the configured resources of the gateway, skipping invalid quantities
+func gatewayResources(config *controllerconfig.MCPConfig) corev1.ResourceRequirements {
+	requirements := corev1.ResourceRequirements{}
+	set := func(list *corev1.ResourceList, name corev1.ResourceName, value string) {
+		if value == "" {
+			return
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[name] = quantity
+	}
+	set(&requirements.Requests, corev1.ResourceCPU, config.CPURequest)
+	set(&requirements.Requests, corev1.ResourceMemory, config.MemoryRequest)
+	set(&requirements.Limits, corev1.ResourceCPU, config.CPULimit)
+	set(&requirements.Limits, corev1.ResourceMemory, config.MemoryLimit)
+	return requirements
+}
@@ -0,0 +1,204 @@
+package mcproute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/mcp/gateway"
+)
+
+// resolvedRoute is a route with its backends and secrets resolved for the gateway
+type resolvedRoute struct {
+	route           gateway.Route
+	backendStatuses []v1beta1.MCPBackendStatus
+	// problems that keep the route from working as specified
+	problems []string
+}
+
+// resolveRoute looks up the MCPServers and API key Secrets of a route
+func resolveRoute(ctx context.Context, c client.Client, clientset kubernetes.Interface, route *v1beta1.MCPRoute) (*resolvedRoute, error) {
+	resolved := &resolvedRoute{route: gateway.Route{
+		Namespace:     route.Namespace,
+		Name:          route.Name,
+		Authorization: route.Spec.Authorization,
+		RateLimit:     route.Spec.RateLimit,
+		Filters:       route.Spec.Filters,
+	}}
+
+	servers := map[string]*serverBackend{}
+	lookup := func(refs []v1beta1.MCPBackendRef) ([]gateway.Backend, error) {
+		backends := make([]gateway.Backend, 0, len(refs))
+		for _, ref := range refs {
+			backend, ok := servers[ref.ServerRef.Name]
+			if !ok {
+				var err error
+				backend, err = lookupServer(ctx, c, route.Namespace, ref.ServerRef.Name)
+				if err != nil {
+					return nil, err
+				}
+				servers[ref.ServerRef.Name] = backend
+			}
+			b := backend.backend
+			b.Weight = ptr.Deref(ref.Weight, 1)
+			backends = append(backends, b)
+		}
+		return backends, nil
+	}
+
+	var err error
+	if resolved.route.Backends, err = lookup(route.Spec.BackendRefs); err != nil {
+		return nil, err
+	}
+	for _, match := range route.Spec.Matches {
+		backends := resolved.route.Backends
+		if len(match.BackendRefs) > 0 {
+			if backends, err = lookup(match.BackendRefs); err != nil {
+				return nil, err
+			}
+		}
+		resolved.route.Matches = append(resolved.route.Matches, gateway.Match{
+			Tools:     match.Tools,
+			ToolMatch: match.ToolMatch,
+			Method:    ptr.Deref(match.Method, ""),
+			Headers:   match.Headers,
+			Backends:  backends,
+		})
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		backend := servers[name]
+		resolved.backendStatuses = append(resolved.backendStatuses, v1beta1.MCPBackendStatus{
+			ServerRef: corev1.LocalObjectReference{Name: name},
+			Ready:     backend.backend.Ready,
+			Endpoint:  backend.backend.URL,
+			Message:   backend.message,
+		})
+	}
+
+	if auth := route.Spec.Authentication; auth != nil {
+		resolved.route.Authentication = &gateway.Authentication{JWT: auth.JWT}
+		if auth.OIDC != nil {
+			resolved.route.Authentication.OIDC = &gateway.OIDC{Issuer: auth.OIDC.Issuer, ClientID: auth.OIDC.ClientID}
+		}
+		if auth.APIKey != nil {
+			apiKey, problems, err := resolveAPIKeys(ctx, clientset, route.Namespace, auth.APIKey)
+			if err != nil {
+				return nil, err
+			}
+			resolved.route.Authentication.APIKey = apiKey
+			resolved.problems = append(resolved.problems, problems...)
+		}
+	}
+	return resolved, nil
+}
+
+type serverBackend struct {
+	backend gateway.Backend
+	message string
+}
+
+// lookupServer resolves an MCPServer to a gateway backend. Missing or unready servers become unready backends.
+func lookupServer(ctx context.Context, c client.Client, namespace, name string) (*serverBackend, error) {
+	server := &v1beta1.MCPServer{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, server); err != nil {
+		if apierr.IsNotFound(err) {
+			return &serverBackend{
+				backend: gateway.Backend{Name: name},
+				message: fmt.Sprintf("MCPServer %q not found", name),
+			}, nil
+		}
+		return nil, err
+	}
+
+	backend := &serverBackend{backend: gateway.Backend{
+		Name:  name,
+		URL:   server.Status.URL,
+		Tools: server.Spec.ToolsFilter,
+	}}
+	switch {
+	case server.Spec.Transport == v1beta1.MCPTransportSSE:
+		backend.message = "the gateway only proxies streamable-http and stdio servers"
+	case server.Status.URL == "":
+		backend.message = "MCPServer has no URL yet"
+	case !meta.IsStatusConditionTrue(server.Status.Conditions, v1beta1.MCPConditionReady):
+		backend.message = "MCPServer is not ready"
+	default:
+		backend.backend.Ready = true
+	}
+	return backend, nil
+}
+
+// resolveAPIKeys reads the API keys of a route. Each key authenticates as "apikey:<secret name>".
+// Secrets are read from the API server rather than the cache, so the manager never caches Secrets.
+func resolveAPIKeys(ctx context.Context, clientset kubernetes.Interface, namespace string, auth *v1beta1.APIKeyAuthentication) (*gateway.APIKey, []string, error) {
+	header := auth.Header
+	if header == "" {
+		header = "X-API-Key"
+	}
+	apiKey := &gateway.APIKey{Header: header, Keys: map[string]string{}}
+	var problems []string
+	for _, ref := range auth.SecretRefs {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierr.IsNotFound(err) {
+				problems = append(problems, fmt.Sprintf("API key Secret %q not found", ref.Name))
+				continue
+			}
+			return nil, nil, err
+		}
+		key, ok := secret.Data[ref.Key]
+		if !ok || len(key) == 0 {
+			problems = append(problems, fmt.Sprintf("API key Secret %q has no key %q", ref.Name, ref.Key))
+			continue
+		}
+		apiKey.Keys[string(key)] = "apikey:" + ref.Name
+	}
+	return apiKey, problems, nil
+}
+
+// routeServerNames returns the MCPServers a route references
+func routeServerNames(route *v1beta1.MCPRoute) []string {
+	var names []string
+	for _, ref := range route.Spec.BackendRefs {
+		names = append(names, ref.ServerRef.Name)
+	}
+	for _, match := range route.Spec.Matches {
+		for _, ref := range match.BackendRefs {
+			names = append(names, ref.ServerRef.Name)
+		}
+	}
+	return names
+}
+
+// routeSecretNames returns the Secrets a route reads API keys from
+func routeSecretNames(route *v1beta1.MCPRoute) []string {
+	var names []string
+	if auth := route.Spec.Authentication; auth != nil && auth.APIKey != nil {
+		for _, ref := range auth.APIKey.SecretRefs {
+			names = append(names, ref.Name)
+		}
+	}
+	return names
+}
+
+// gatewayLabels are the labels of the gateway resources of a namespace
+func gatewayLabels() map[string]string {
+	return map[string]string{constants.MCPGatewayLabelKey: constants.MCPGatewayName}
+}
@@ -0,0 +1,337 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+// +kubebuilder:rbac:groups=ome.io,resources=mcpservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ome.io,resources=mcpservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ome.io,resources=mcpservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+
+// Condition reasons of MCPServers
+const (
+	reasonRemote                   = "Remote"
+	reasonAvailable                = "Available"
+	reasonDeploying                = "Deploying"
+	reasonInvalidSpec              = "InvalidSpec"
+	reasonInvalidPermissionProfile = "InvalidPermissionProfile"
+)
+
+// errInvalidSpec marks spec errors that are reported on the status instead of being retried
+var errInvalidSpec = errors.New("invalid spec")
+
+// MCPServerReconciler deploys hosted MCP servers and reports the endpoint of every MCPServer
+type MCPServerReconciler struct {
+	client.Client
+	Clientset kubernetes.Interface
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
+	Recorder  record.EventRecorder
+}
+
+func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("mcpserver", req.NamespacedName)
+
+	server := &v1beta1.MCPServer{}
+	if err := r.Get(ctx, req.NamespacedName, server); err != nil {
+		if apierr.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "failed to get MCPServer")
+		return ctrl.Result{}, err
+	}
+	if !server.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	status := server.Status.DeepCopy()
+	status.ObservedGeneration = server.Generation
+
+	var err error
+	if server.Spec.Remote != nil {
+		err = r.reconcileRemote(ctx, server, status)
+	} else {
+		err = r.reconcileHosted(ctx, server, status)
+	}
+	if err != nil && !errors.Is(err, errInvalidSpec) {
+		log.Error(err, "failed to reconcile MCPServer")
+		return ctrl.Result{}, err
+	}
+	if err != nil {
+		r.Recorder.Event(server, corev1.EventTypeWarning, "InvalidSpec", err.Error())
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, server, status)
+}
+
+// reconcileRemote reports the URL of a remote server and removes resources left from a hosted spec
+func (r *MCPServerReconciler) reconcileRemote(ctx context.Context, server *v1beta1.MCPServer, status *v1beta1.MCPServerStatus) error {
+	if err := r.deleteHostedResources(ctx, server); err != nil {
+		return err
+	}
+	status.URL = server.Spec.Remote.URL
+	status.ReadyReplicas = 0
+	status.NetworkEnforcement = nil
+	setReady(status, server.Generation, metav1.ConditionTrue, reasonRemote, "Remote server is reachable at its URL")
+	return nil
+}
+
+func (r *MCPServerReconciler) reconcileHosted(ctx context.Context, server *v1beta1.MCPServer, status *v1beta1.MCPServerStatus) error {
+	profile, err := r.resolvePermissionProfile(ctx, server)
+	if err != nil {
+		if errors.Is(err, errInvalidSpec) {
+			setReady(status, server.Generation, metav1.ConditionFalse, reasonInvalidPermissionProfile, err.Error())
+		}
+		return err
+	}
+
+	var gatewayImage string
+	if transport(server) == v1beta1.MCPTransportStdio {
+		mcpConfig, err := controllerconfig.NewMCPConfig(r.Clientset)
+		if err != nil {
+			return fmt.Errorf("failed to load MCP config: %w", err)
+		}
+		gatewayImage = mcpConfig.GatewayImage
+	}
+	hosted, err := buildHostedServer(server, profile, gatewayImage)
+	if err != nil {
+		setReady(status, server.Generation, metav1.ConditionFalse, reasonInvalidSpec, err.Error())
+		return err
+	}
+
+	if err := r.reconcileServiceAccount(ctx, server, hosted); err != nil {
+		return err
+	}
+	if err := r.reconcileRBAC(ctx, server, hosted); err != nil {
+		return err
+	}
+	if err := r.reconcileNetworkPolicy(ctx, server, hosted); err != nil {
+		return err
+	}
+	deployment, err := r.reconcileDeployment(ctx, server, hosted)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileService(ctx, server, hosted); err != nil {
+		return err
+	}
+
+	status.URL = hosted.url
+	status.ReadyReplicas = deployment.Status.ReadyReplicas
+	status.NetworkEnforcement = hosted.networkEnforcement
+	switch {
+	case deployment.Status.ReadyReplicas > 0:
+		message := hosted.networkMessage
+		if message == "" {
+			message = "Server is available"
+		}
+		setReady(status, server.Generation, metav1.ConditionTrue, reasonAvailable, message)
+	case hosted.replicas == 0:
+		setReady(status, server.Generation, metav1.ConditionFalse, reasonDeploying, "Server is scaled to zero")
+	default:
+		setReady(status, server.Generation, metav1.ConditionFalse, reasonDeploying, "Waiting for server replicas to become ready")
+	}
+	return nil
+}
+
+func (r *MCPServerReconciler) reconcileServiceAccount(ctx context.Context, server *v1beta1.MCPServer, hosted *hostedServer) error {
+	if !hosted.ownServiceAccount {
+		return r.deleteOwned(ctx, server, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}})
+	}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = hosted.labels
+		return controllerutil.SetControllerReference(server, sa, r.Scheme)
+	})
+	return err
+}
+
+func (r *MCPServerReconciler) reconcileRBAC(ctx context.Context, server *v1beta1.MCPServer, hosted *hostedServer) error {
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	if len(hosted.rules) == 0 {
+		if err := r.deleteOwned(ctx, server, binding); err != nil {
+			return err
+		}
+		return r.deleteOwned(ctx, server, role)
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		role.Labels = hosted.labels
+		role.Rules = hosted.rules
+		return controllerutil.SetControllerReference(server, role, r.Scheme)
+	}); err != nil {
+		return err
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		binding.Labels = hosted.labels
+		binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name}
+		binding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      hosted.serviceAccountName,
+			Namespace: server.Namespace,
+		}}
+		return controllerutil.SetControllerReference(server, binding, r.Scheme)
+	})
+	return err
+}
+
+func (r *MCPServerReconciler) reconcileNetworkPolicy(ctx context.Context, server *v1beta1.MCPServer, hosted *hostedServer) error {
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	if hosted.egress == nil {
+		return r.deleteOwned(ctx, server, policy)
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Labels = hosted.labels
+		policy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: hosted.selector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      hosted.egress,
+		}
+		return controllerutil.SetControllerReference(server, policy, r.Scheme)
+	})
+	return err
+}
+
+func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, server *v1beta1.MCPServer, hosted *hostedServer) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		deployment.Labels = hosted.labels
+		deployment.Spec.Replicas = &hosted.replicas
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: hosted.selector}
+		deployment.Spec.Template = hosted.template
+		if hosted.replicas == 1 && transport(server) == v1beta1.MCPTransportStdio {
+			// A stdio server holds its session state in a single process, never run two side by side
+			deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		}
+		return controllerutil.SetControllerReference(server, deployment, r.Scheme)
+	})
+	return deployment, err
+}
+
+func (r *MCPServerReconciler) reconcileService(ctx context.Context, server *v1beta1.MCPServer, hosted *hostedServer) error {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		service.Labels = hosted.labels
+		service.Spec.Selector = hosted.selector
+		service.Spec.Ports = []corev1.ServicePort{hosted.servicePort}
+		return controllerutil.SetControllerReference(server, service, r.Scheme)
+	})
+	return err
+}
+
+// deleteHostedResources removes the resources of a server that is no longer hosted
+func (r *MCPServerReconciler) deleteHostedResources(ctx context.Context, server *v1beta1.MCPServer) error {
+	objectMeta := metav1.ObjectMeta{Name: server.Name, Namespace: server.Namespace}
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{ObjectMeta: objectMeta},
+		&corev1.Service{ObjectMeta: objectMeta},
+		&networkingv1.NetworkPolicy{ObjectMeta: objectMeta},
+		&rbacv1.RoleBinding{ObjectMeta: objectMeta},
+		&rbacv1.Role{ObjectMeta: objectMeta},
+		&corev1.ServiceAccount{ObjectMeta: objectMeta},
+	} {
+		if err := r.deleteOwned(ctx, server, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOwned deletes an object if it exists and is controlled by the server
+func (r *MCPServerReconciler) deleteOwned(ctx context.Context, server *v1beta1.MCPServer, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, server) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+func (r *MCPServerReconciler) updateStatus(ctx context.Context, server *v1beta1.MCPServer, status *v1beta1.MCPServerStatus) error {
+	latest := &v1beta1.MCPServer{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: server.Namespace, Name: server.Name}, latest); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	desired := latest.DeepCopy()
+	desired.Status = *status
+	if equalStatus(latest.Status, desired.Status) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, desired, client.MergeFrom(latest)); err != nil && !apierr.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager wires the controller and watches the resources it creates for hosted servers
+func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.MCPServer{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Owns(&corev1.ServiceAccount{}).
+		Complete(r)
+}
+
+func transport(server *v1beta1.MCPServer) v1beta1.MCPTransportType {
+	if server.Spec.Transport == "" {
+		return v1beta1.MCPTransportStdio
+	}
+	return server.Spec.Transport
+}
+
+func setReady(status *v1beta1.MCPServerStatus, generation int64, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1beta1.MCPConditionReady,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// equalStatus compares statuses ignoring condition transition times
+func equalStatus(a, b v1beta1.MCPServerStatus) bool {
+	a = *a.DeepCopy()
+	b = *b.DeepCopy()
+	for i := range a.Conditions {
+		a.Conditions[i].LastTransitionTime = metav1.Time{}
+	}
+	for i := range b.Conditions {
+		b.Conditions[i].LastTransitionTime = metav1.Time{}
+	}
+	return equality.Semantic.DeepEqual(a, b)
+}
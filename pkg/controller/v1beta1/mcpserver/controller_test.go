@@ -0,0 +1,339 @@
+package mcpserver
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlclientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+const testNamespace = "team"
+
+func newReconciler(g *WithT, objs ...client.Object) (*MCPServerReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(networkingv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+
+	c := ctrlclientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&v1beta1.MCPServer{}).
+		Build()
+	clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.OMENamespace},
+		Data: map[string]string{"mcp": `{
+			"gatewayImage": "ome/mcp-gateway:v1",
+			"allowedKubeResources": [{"apiGroups": [""], "resources": ["configmaps", "pods"], "verbs": ["get", "list", "watch"]}]
+		}`},
+	})
+	return &MCPServerReconciler{
+		Client:    c,
+		Clientset: clientset,
+		Log:       ctrl.Log.WithName("MCPServerTest"),
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+	}, c
+}
+
+func newHostedServer(name string, transport v1beta1.MCPTransportType, profile *v1beta1.PermissionProfileSource) *v1beta1.MCPServer {
+	return &v1beta1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Generation: 1},
+		Spec: v1beta1.MCPServerSpec{
+			Transport:         transport,
+			PermissionProfile: profile,
+			Hosted: &v1beta1.HostedMCPServer{
+				Replicas: ptr.To(int32(3)),
+				PodSpec: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:    constants.MCPServerContainerName,
+					Image:   "ghcr.io/example/github-mcp:latest",
+					Command: []string{"github-mcp-server"},
+					Args:    []string{"stdio"},
+				}}}},
+			},
+		},
+	}
+}
+
+func reconcileServer(g *WithT, r *MCPServerReconciler, c client.Client, name string) *v1beta1.MCPServer {
+	ctx := context.TODO()
+	key := types.NamespacedName{Namespace: testNamespace, Name: name}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	g.Expect(err).NotTo(HaveOccurred())
+	server := &v1beta1.MCPServer{}
+	g.Expect(c.Get(ctx, key, server)).To(Succeed())
+	return server
+}
+
+func TestMCPServer_Reconcile_Remote(t *testing.T) {
+	g := NewWithT(t)
+	server := &v1beta1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "search", Namespace: testNamespace, Generation: 1},
+		Spec: v1beta1.MCPServerSpec{
+			Transport: v1beta1.MCPTransportStreamableHTTP,
+			Remote:    &v1beta1.RemoteMCPServer{URL: "https://mcp.example.com/mcp"},
+		},
+	}
+	r, c := newReconciler(g, server)
+
+	updated := reconcileServer(g, r, c, "search")
+	g.Expect(updated.Status.URL).To(Equal("https://mcp.example.com/mcp"))
+	g.Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta1.MCPConditionReady)).To(BeTrue())
+	g.Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "search"}, &appsv1.Deployment{})).NotTo(Succeed())
+}
+
+func TestMCPServer_Reconcile_HostedStdio(t *testing.T) {
+	g := NewWithT(t)
+	server := newHostedServer("github", v1beta1.MCPTransportStdio,
+		&v1beta1.PermissionProfileSource{Builtin: ptr.To(v1beta1.BuiltinPermissionProfileNone)})
+	r, c := newReconciler(g, server)
+	ctx := context.TODO()
+	key := types.NamespacedName{Namespace: testNamespace, Name: "github"}
+
+	updated := reconcileServer(g, r, c, "github")
+	g.Expect(updated.Status.URL).To(Equal("http://github.team.svc.cluster.local:8080/mcp"))
+	g.Expect(updated.Status.NetworkEnforcement).To(Equal(ptr.To(v1beta1.NetworkEnforcementNetworkPolicy)))
+	ready := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.MCPConditionReady)
+	g.Expect(ready).NotTo(BeNil())
+	g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(ready.Reason).To(Equal(reasonDeploying))
+
+	deployment := &appsv1.Deployment{}
+	g.Expect(c.Get(ctx, key, deployment)).To(Succeed())
+	g.Expect(*deployment.Spec.Replicas).To(Equal(int32(1)), "stdio servers run a single replica")
+	g.Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+	podSpec := deployment.Spec.Template.Spec
+	g.Expect(podSpec.ServiceAccountName).To(Equal("github"))
+	g.Expect(podSpec.AutomountServiceAccountToken).To(Equal(ptr.To(false)))
+	g.Expect(podSpec.InitContainers).To(HaveLen(1))
+	g.Expect(podSpec.InitContainers[0].Image).To(Equal("ome/mcp-gateway:v1"))
+	g.Expect(podSpec.Containers[0].Command).To(Equal([]string{
+		"/ome-mcp/mcp-gateway", "stdio-bridge", "--port", "8080", "--path", "/mcp", "--", "github-mcp-server", "stdio",
+	}))
+	g.Expect(podSpec.Containers[0].Args).To(BeEmpty())
+	g.Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(constants.MCPServerLabelKey, "github"))
+
+	service := &corev1.Service{}
+	g.Expect(c.Get(ctx, key, service)).To(Succeed())
+	g.Expect(service.Spec.Ports).To(HaveLen(1))
+	g.Expect(service.Spec.Ports[0].Port).To(Equal(int32(8080)))
+	g.Expect(service.Spec.Selector).To(Equal(map[string]string{constants.MCPServerLabelKey: "github"}))
+
+	g.Expect(c.Get(ctx, key, &corev1.ServiceAccount{})).To(Succeed())
+	g.Expect(c.Get(ctx, key, &rbacv1.Role{})).NotTo(Succeed())
+
+	policy := &networkingv1.NetworkPolicy{}
+	g.Expect(c.Get(ctx, key, policy)).To(Succeed())
+	g.Expect(policy.Spec.Egress).To(HaveLen(1), "only DNS is allowed")
+
+	// Becomes ready once the deployment has ready replicas
+	deployment.Status.ReadyReplicas = 1
+	g.Expect(c.Status().Update(ctx, deployment)).To(Succeed())
+	updated = reconcileServer(g, r, c, "github")
+	g.Expect(updated.Status.ReadyReplicas).To(Equal(int32(1)))
+	g.Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, v1beta1.MCPConditionReady)).To(BeTrue())
+}
+
+func TestMCPServer_Reconcile_PermissionProfiles(t *testing.T) {
+	postgres := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "data"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "postgres"}},
+	}
+	profileConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: testNamespace},
+		Data: map[string]string{"reader": `
+allow:
+- kubeResources:
+    apiGroups: [""]
+    resources: ["pods"]
+    verbs: ["get", "list"]
+`},
+	}
+
+	tests := []struct {
+		name            string
+		profile         *v1beta1.PermissionProfileSource
+		wantReason      string
+		wantEnforcement *v1beta1.NetworkEnforcementMode
+		wantPolicy      bool
+		wantEgressRules int
+		wantRoleRules   int
+	}{
+		{
+			name:       "network profile leaves egress unrestricted",
+			profile:    &v1beta1.PermissionProfileSource{Builtin: ptr.To(v1beta1.BuiltinPermissionProfileNetwork)},
+			wantReason: reasonDeploying,
+		},
+		{
+			name: "kube resources and in-cluster hosts",
+			profile: &v1beta1.PermissionProfileSource{Inline: &v1beta1.PermissionProfileSpec{Allow: []v1beta1.PermissionRule{
+				{KubeResources: &v1beta1.KubeResourcePermission{
+					APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"},
+				}},
+				{Network: &v1beta1.NetworkPermission{
+					AllowHost: []string{"postgres.data.svc.cluster.local"},
+					AllowCIDR: []string{"10.0.0.0/8"},
+				}},
+			}}},
+			wantReason:      reasonDeploying,
+			wantEnforcement: ptr.To(v1beta1.NetworkEnforcementNetworkPolicy),
+			wantPolicy:      true,
+			wantEgressRules: 3,
+			wantRoleRules:   1,
+		},
+		{
+			name: "external hosts cannot be enforced",
+			profile: &v1beta1.PermissionProfileSource{Inline: &v1beta1.PermissionProfileSpec{Allow: []v1beta1.PermissionRule{
+				{Network: &v1beta1.NetworkPermission{AllowHost: []string{"api.github.com"}}},
+			}}},
+			wantReason:      reasonDeploying,
+			wantEnforcement: ptr.To(v1beta1.NetworkEnforcementNone),
+		},
+		{
+			name:            "profile from a ConfigMap",
+			profile:         &v1beta1.PermissionProfileSource{ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "profiles"}, Key: "reader"}},
+			wantReason:      reasonDeploying,
+			wantEnforcement: ptr.To(v1beta1.NetworkEnforcementNetworkPolicy),
+			wantPolicy:      true,
+			wantEgressRules: 1,
+			wantRoleRules:   1,
+		},
+		{
+			name:       "missing ConfigMap key",
+			profile:    &v1beta1.PermissionProfileSource{ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "profiles"}, Key: "writer"}},
+			wantReason: reasonInvalidPermissionProfile,
+		},
+		{
+			name: "kube resources the MCP config does not allow are rejected",
+			profile: &v1beta1.PermissionProfileSource{Inline: &v1beta1.PermissionProfileSpec{Allow: []v1beta1.PermissionRule{
+				{KubeResources: &v1beta1.KubeResourcePermission{
+					APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"get"},
+				}},
+			}}},
+			wantReason: reasonInvalidPermissionProfile,
+		},
+		{
+			name: "wildcard verbs the MCP config does not allow are rejected",
+			profile: &v1beta1.PermissionProfileSource{Inline: &v1beta1.PermissionProfileSpec{Allow: []v1beta1.PermissionRule{
+				{KubeResources: &v1beta1.KubeResourcePermission{
+					APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"},
+				}},
+			}}},
+			wantReason: reasonInvalidPermissionProfile,
+		},
+		{
+			name: "other namespaces are rejected",
+			profile: &v1beta1.PermissionProfileSource{Inline: &v1beta1.PermissionProfileSpec{Allow: []v1beta1.PermissionRule{
+				{KubeResources: &v1beta1.KubeResourcePermission{
+					APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, Namespaces: []string{"kube-system"},
+				}},
+			}}},
+			wantReason: reasonInvalidPermissionProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			server := newHostedServer("tools", v1beta1.MCPTransportStreamableHTTP, tt.profile)
+			r, c := newReconciler(g, server, postgres, profileConfigMap)
+			ctx := context.TODO()
+			key := types.NamespacedName{Namespace: testNamespace, Name: "tools"}
+
+			updated := reconcileServer(g, r, c, "tools")
+			ready := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.MCPConditionReady)
+			g.Expect(ready).NotTo(BeNil())
+			g.Expect(ready.Reason).To(Equal(tt.wantReason))
+			g.Expect(updated.Status.NetworkEnforcement).To(Equal(tt.wantEnforcement))
+
+			if tt.wantReason == reasonInvalidPermissionProfile {
+				g.Expect(c.Get(ctx, key, &appsv1.Deployment{})).NotTo(Succeed())
+				return
+			}
+
+			deployment := &appsv1.Deployment{}
+			g.Expect(c.Get(ctx, key, deployment)).To(Succeed())
+			g.Expect(*deployment.Spec.Replicas).To(Equal(int32(3)))
+			g.Expect(deployment.Spec.Template.Spec.InitContainers).To(BeEmpty())
+
+			policy := &networkingv1.NetworkPolicy{}
+			err := c.Get(ctx, key, policy)
+			if tt.wantPolicy {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(policy.Spec.Egress).To(HaveLen(tt.wantEgressRules))
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+
+			role := &rbacv1.Role{}
+			err = c.Get(ctx, key, role)
+			if tt.wantRoleRules > 0 {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(role.Rules).To(HaveLen(tt.wantRoleRules))
+				binding := &rbacv1.RoleBinding{}
+				g.Expect(c.Get(ctx, key, binding)).To(Succeed())
+				g.Expect(binding.Subjects[0].Name).To(Equal("tools"))
+				g.Expect(deployment.Spec.Template.Spec.AutomountServiceAccountToken).To(BeNil())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMCPServer_Reconcile_InvalidSpec(t *testing.T) {
+	g := NewWithT(t)
+	server := newHostedServer("broken", v1beta1.MCPTransportStdio, nil)
+	server.Spec.Hosted.PodSpec.Spec.Containers[0].Name = "server"
+	r, c := newReconciler(g, server)
+
+	updated := reconcileServer(g, r, c, "broken")
+	ready := meta.FindStatusCondition(updated.Status.Conditions, v1beta1.MCPConditionReady)
+	g.Expect(ready).NotTo(BeNil())
+	g.Expect(ready.Reason).To(Equal(reasonInvalidSpec))
+	g.Expect(ready.Message).To(ContainSubstring(constants.MCPServerContainerName))
+}
+
+func TestParseServiceHost(t *testing.T) {
+	tests := []struct {
+		host          string
+		wantName      string
+		wantNamespace string
+		wantOK        bool
+	}{
+		{host: "postgres", wantName: "postgres", wantNamespace: testNamespace, wantOK: true},
+		{host: "postgres.data", wantName: "postgres", wantNamespace: "data", wantOK: true},
+		{host: "postgres.data.svc", wantName: "postgres", wantNamespace: "data", wantOK: true},
+		{host: "postgres.data.svc.cluster.local", wantName: "postgres", wantNamespace: "data", wantOK: true},
+		{host: "api.github.com", wantOK: false},
+		{host: "10.0.0.1", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			g := NewWithT(t)
+			name, namespace, ok := parseServiceHost(tt.host, testNamespace)
+			g.Expect(ok).To(Equal(tt.wantOK))
+			g.Expect(name).To(Equal(tt.wantName))
+			g.Expect(namespace).To(Equal(tt.wantNamespace))
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+)
+
+// namespaceNameLabel is set on every namespace by the API server
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// permissions are the resolved permissions of a hosted server
+type permissions struct {
+	rules []rbacv1.PolicyRule
+
+	egress             []networkingv1.NetworkPolicyEgressRule
+	networkEnforcement *v1beta1.NetworkEnforcementMode
+	networkMessage     string
+}
+
+// resolvePermissionProfile turns the permission profile of a server into RBAC rules and egress rules.
+// Servers without a profile keep the access of their pod template.
+func (r *MCPServerReconciler) resolvePermissionProfile(ctx context.Context, server *v1beta1.MCPServer) (*permissions, error) {
+	source := server.Spec.PermissionProfile
+	if source == nil {
+		return &permissions{}, nil
+	}
+
+	var spec *v1beta1.PermissionProfileSpec
+	switch {
+	case source.Builtin != nil:
+		switch *source.Builtin {
+		case v1beta1.BuiltinPermissionProfileNetwork:
+			return &permissions{}, nil
+		default:
+			spec = &v1beta1.PermissionProfileSpec{}
+		}
+	case source.Inline != nil:
+		spec = source.Inline
+	case source.ConfigMap != nil:
+		var err error
+		spec, err = r.loadPermissionProfile(ctx, server.Namespace, source.ConfigMap)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return &permissions{}, nil
+	}
+
+	perms := &permissions{}
+	var cidrs, hosts []string
+	for _, rule := range spec.Allow {
+		if kube := rule.KubeResources; kube != nil {
+			for _, ns := range kube.Namespaces {
+				if ns != server.Namespace {
+					return nil, fmt.Errorf("%w: access to namespace %q is not supported, servers may only access their own namespace",
+						errInvalidSpec, ns)
+				}
+			}
+			perms.rules = append(perms.rules, rbacv1.PolicyRule{
+				APIGroups: kube.APIGroups,
+				Resources: kube.Resources,
+				Verbs:     kube.Verbs,
+			})
+		}
+		if network := rule.Network; network != nil {
+			cidrs = append(cidrs, network.AllowCIDR...)
+			hosts = append(hosts, network.AllowHost...)
+		}
+	}
+
+	if len(perms.rules) > 0 {
+		mcpConfig, err := controllerconfig.NewMCPConfig(r.Clientset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MCP config: %w", err)
+		}
+		if err := checkAllowedRules(mcpConfig.AllowedKubeResources, perms.rules); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.planEgress(ctx, server.Namespace, cidrs, hosts, perms); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// checkAllowedRules rejects the rules of a profile which the allowed rules of the MCP config do not cover.
+// Anyone creating a server writes its profile, so profiles may only grant the access administrators allowed.
+func checkAllowedRules(allowed, rules []rbacv1.PolicyRule) error {
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					if !rulesAllow(allowed, group, resource, verb) {
+						return fmt.Errorf("%w: %q access to %q in API group %q is not allowed for MCP servers",
+							errInvalidSpec, verb, resource, group)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// rulesAllow reports whether one of the rules allows a verb on a resource
+func rulesAllow(rules []rbacv1.PolicyRule, group, resource, verb string) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 || len(rule.NonResourceURLs) > 0 {
+			continue
+		}
+		if matchesRuleValue(rule.APIGroups, group) && matchesRuleValue(rule.Resources, resource) && matchesRuleValue(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRuleValue reports whether the values of a rule contain a value or the wildcard
+func matchesRuleValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == rbacv1.ResourceAll || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MCPServerReconciler) loadPermissionProfile(ctx context.Context, namespace string, ref *corev1.ConfigMapKeySelector) (*v1beta1.PermissionProfileSpec, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, cm); err != nil {
+		if apierr.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: permission profile ConfigMap %q not found", errInvalidSpec, ref.Name)
+		}
+		return nil, err
+	}
+	data, ok := cm.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: permission profile ConfigMap %q has no key %q", errInvalidSpec, ref.Name, ref.Key)
+	}
+	spec := &v1beta1.PermissionProfileSpec{}
+	if err := yaml.UnmarshalStrict([]byte(data), spec); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse permission profile in ConfigMap %q: %v", errInvalidSpec, ref.Name, err)
+	}
+	return spec, nil
+}
+
+// planEgress restricts egress to DNS and the allowed destinations with a NetworkPolicy. A NetworkPolicy
+// can only select CIDRs and pods, so when a host cannot be resolved to the pods behind an in-cluster
+// Service no policy is created and the status reports that egress is not enforced.
+func (r *MCPServerReconciler) planEgress(ctx context.Context, namespace string, cidrs, hosts []string, perms *permissions) error {
+	egress := []networkingv1.NetworkPolicyEgressRule{dnsEgressRule()}
+
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%w: invalid CIDR %q", errInvalidSpec, cidr)
+		}
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}},
+		})
+	}
+
+	var unenforceable []string
+	for _, host := range hosts {
+		peer, err := r.servicePeer(ctx, namespace, host)
+		if err != nil {
+			return err
+		}
+		if peer == nil {
+			unenforceable = append(unenforceable, host)
+			continue
+		}
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{*peer}})
+	}
+
+	if len(unenforceable) > 0 {
+		sort.Strings(unenforceable)
+		perms.networkEnforcement = ptr.To(v1beta1.NetworkEnforcementNone)
+		perms.networkMessage = fmt.Sprintf("Egress is not restricted: hosts %s cannot be enforced with a NetworkPolicy",
+			strings.Join(unenforceable, ", "))
+		return nil
+	}
+	perms.egress = egress
+	perms.networkEnforcement = ptr.To(v1beta1.NetworkEnforcementNetworkPolicy)
+	return nil
+}
+
+// servicePeer returns the pods behind an in-cluster Service host such as "db.team.svc.cluster.local",
+// or nil when the host is not an in-cluster Service with a selector
+func (r *MCPServerReconciler) servicePeer(ctx context.Context, namespace, host string) (*networkingv1.NetworkPolicyPeer, error) {
+	name, serviceNamespace, ok := parseServiceHost(host, namespace)
+	if !ok {
+		return nil, nil
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: serviceNamespace, Name: name}, svc); err != nil {
+		if apierr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	return &networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{MatchLabels: svc.Spec.Selector},
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{namespaceNameLabel: serviceNamespace},
+		},
+	}, nil
+}
+
+// parseServiceHost returns the Service a host names: "name", "name.namespace", "name.namespace.svc"
+// or "name.namespace.svc.cluster.local"
+func parseServiceHost(host, namespace string) (name, serviceNamespace string, ok bool) {
+	if net.ParseIP(host) != nil {
+		return "", "", false
+	}
+	host = strings.TrimSuffix(host, ".cluster.local")
+	parts := strings.Split(host, ".")
+	switch {
+	case len(parts) == 1:
+		return parts[0], namespace, parts[0] != ""
+	case len(parts) == 2:
+		return parts[0], parts[1], parts[0] != "" && parts[1] != ""
+	case len(parts) == 3 && parts[2] == "svc":
+		return parts[0], parts[1], parts[0] != "" && parts[1] != ""
+	default:
+		return "", "", false
+	}
+}
+
+func dnsEgressRule() networkingv1.NetworkPolicyEgressRule {
+	dnsPort := intstr.FromInt32(53)
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolUDP), Port: &dnsPort},
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: &dnsPort},
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package mcpserver
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+const (
+	// bridgeVolumeName is the volume the stdio bridge binary is installed into
+	bridgeVolumeName = "ome-mcp"
+	// bridgeMountPath is where the stdio bridge volume is mounted
+	bridgeMountPath = "/ome-mcp"
+	// bridgeInstallContainerName is the init container installing the stdio bridge
+	bridgeInstallContainerName = "mcp-bridge-install"
+	// servicePortName is the name of the MCP port of the server container and Service
+	servicePortName = "mcp"
+)
+
+// hostedServer is the desired state of the resources of a hosted server
+type hostedServer struct {
+	labels   map[string]string
+	selector map[string]string
+	template corev1.PodTemplateSpec
+	replicas int32
+
+	servicePort corev1.ServicePort
+	url         string
+
+	ownServiceAccount  bool
+	serviceAccountName string
+	rules              []rbacv1.PolicyRule
+
+	// egress rules of the NetworkPolicy, nil when egress is not restricted by a NetworkPolicy
+	egress             []networkingv1.NetworkPolicyEgressRule
+	networkEnforcement *v1beta1.NetworkEnforcementMode
+	networkMessage     string
+}
+
+// buildHostedServer renders the pod template, Service and permissions of a hosted server
+func buildHostedServer(server *v1beta1.MCPServer, perms *permissions, gatewayImage string) (*hostedServer, error) {
+	hosted := server.Spec.Hosted
+	template := *hosted.PodSpec.DeepCopy()
+
+	containerIdx := -1
+	for i := range template.Spec.Containers {
+		if template.Spec.Containers[i].Name == constants.MCPServerContainerName {
+			containerIdx = i
+			break
+		}
+	}
+	if containerIdx < 0 {
+		return nil, fmt.Errorf("%w: the pod template has no %q container", errInvalidSpec, constants.MCPServerContainerName)
+	}
+	container := &template.Spec.Containers[containerIdx]
+
+	port := serverPort(hosted, container)
+	path := hosted.Path
+	if path == "" {
+		path = constants.MCPDefaultServerPath
+	}
+	replicas := ptr.Deref(hosted.Replicas, 1)
+
+	selector := map[string]string{constants.MCPServerLabelKey: server.Name}
+	labels := map[string]string{}
+	for k, v := range server.Labels {
+		labels[k] = v
+	}
+	for k, v := range selector {
+		labels[k] = v
+	}
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	for k, v := range selector {
+		template.Labels[k] = v
+	}
+
+	if transport(server) == v1beta1.MCPTransportStdio {
+		if gatewayImage == "" {
+			return nil, fmt.Errorf("%w: the mcp gatewayImage must be configured to host stdio servers", errInvalidSpec)
+		}
+		if len(container.Command) == 0 {
+			return nil, fmt.Errorf("%w: stdio servers must set the command of the %q container", errInvalidSpec,
+				constants.MCPServerContainerName)
+		}
+		injectStdioBridge(&template.Spec, container, gatewayImage, port, path)
+		// A stdio process serves one client, a second replica would split sessions across processes
+		if replicas > 1 {
+			replicas = 1
+		}
+	}
+	ensureContainerPort(container, port)
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port)}},
+		}
+	}
+
+	result := &hostedServer{
+		labels:   labels,
+		selector: selector,
+		replicas: replicas,
+		servicePort: corev1.ServicePort{
+			Name:       servicePortName,
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		url:                fmt.Sprintf("http://%s.%s.%s:%d%s", server.Name, server.Namespace, constants.ClusterLocalDomain, port, path),
+		serviceAccountName: template.Spec.ServiceAccountName,
+		rules:              perms.rules,
+		egress:             perms.egress,
+		networkEnforcement: perms.networkEnforcement,
+		networkMessage:     perms.networkMessage,
+	}
+	if result.serviceAccountName == "" {
+		result.ownServiceAccount = true
+		result.serviceAccountName = server.Name
+		template.Spec.ServiceAccountName = server.Name
+	}
+	// Servers only get API credentials when their permission profile grants API access
+	if len(perms.rules) == 0 && template.Spec.AutomountServiceAccountToken == nil {
+		template.Spec.AutomountServiceAccountToken = ptr.To(false)
+	}
+	result.template = template
+	return result, nil
+}
+
+// injectStdioBridge runs the server command behind the stdio bridge, installed by an init container
+func injectStdioBridge(podSpec *corev1.PodSpec, container *corev1.Container, gatewayImage string, port int32, path string) {
+	bridge := bridgeMountPath + "/" + constants.MCPGatewayName
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         bridgeVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:         bridgeInstallContainerName,
+		Image:        gatewayImage,
+		Command:      []string{"/" + constants.MCPGatewayName, "install", bridge},
+		VolumeMounts: []corev1.VolumeMount{{Name: bridgeVolumeName, MountPath: bridgeMountPath}},
+	})
+
+	command := []string{bridge, "stdio-bridge", "--port", strconv.Itoa(int(port)), "--path", path, "--"}
+	command = append(command, container.Command...)
+	command = append(command, container.Args...)
+	container.Command = command
+	container.Args = nil
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      bridgeVolumeName,
+		MountPath: bridgeMountPath,
+		ReadOnly:  true,
+	})
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(port)}},
+		}
+	}
+}
+
+// serverPort returns the port the server is reached on
+func serverPort(hosted *v1beta1.HostedMCPServer, container *corev1.Container) int32 {
+	if hosted.Port != nil {
+		return *hosted.Port
+	}
+	if len(container.Ports) > 0 {
+		return container.Ports[0].ContainerPort
+	}
+	return constants.MCPDefaultServerPort
+}
+
+func ensureContainerPort(container *corev1.Container, port int32) {
+	for _, p := range container.Ports {
+		if p.ContainerPort == port {
+			return
+		}
+	}
+	container.Ports = append(container.Ports, corev1.ContainerPort{
+		Name:          servicePortName,
+		ContainerPort: port,
+		Protocol:      corev1.ProtocolTCP,
+	})
+}
@@ -0,0 +1,446 @@
+package hub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultBlobStoreDirName is the directory of the blob store under a models root directory
+const DefaultBlobStoreDirName = ".blobs"
+
+// ErrLinkUnsupported is returned when a file can neither be hard linked nor reflinked, e.g. across file systems
+var ErrLinkUnsupported = errors.New("neither hard links nor reflinks are supported")
+
+// BlobStore is a content-addressed store of the LFS files of repositories, keyed by their SHA256. Files are placed
+// into model directories as hard links to the blobs, or reflinks where hard links are not possible, so models
+// sharing files, e.g. revisions of a repository or fine-tunes sharing shards, store them once.
+//
+// Each file placed from the store is recorded as a reference of its blob. References are released when a model
+// directory is deleted, and GC removes the blobs without references. A blob store is meant to be used by a single
+// process, which may use it concurrently.
+//
+// Layout under the root directory:
+//
+//	blobs/<sha256>        the blobs, read-only
+//	refs/<sha256>/<key>   a reference per file placed from the blob, holding the path and identity of the file
+//	tmp/                  files being copied into the store
+type BlobStore struct {
+	root string
+	mu   sync.Mutex
+}
+
+// BlobStoreGCResult reports the outcome of a garbage collection
+type BlobStoreGCResult struct {
+	RemovedBlobs int
+	FreedBytes   int64
+	// PrunedRefs is the number of references whose file no longer exists or no longer holds the blob
+	PrunedRefs int
+}
+
+// NewBlobStore opens the blob store rooted at root, creating it if needed
+func NewBlobStore(root string) (*BlobStore, error) {
+	if root == "" {
+		return nil, errors.New("blob store root cannot be empty")
+	}
+	for _, dir := range []string{"blobs", "refs", "tmp"} {
+		if err := EnsureDir(filepath.Join(root, dir)); err != nil {
+			return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+		}
+	}
+	return &BlobStore{root: root}, nil
+}
+
+// Root returns the root directory of the store
+func (s *BlobStore) Root() string {
+	return s.root
+}
+
+// Has reports whether the store holds the blob of a SHA256
+func (s *BlobStore) Has(sha string) bool {
+	return IsSHA256(sha) && FileExists(s.blobPath(sha))
+}
+
+// Link places the blob of a SHA256 at target, replacing any file there, and records the reference. It reports false
+// when the store does not hold the blob. Blobs which can be neither hard linked nor reflinked are copied.
+func (s *BlobStore) Link(sha, target string) (bool, error) {
+	if !IsSHA256(sha) {
+		return false, fmt.Errorf("invalid SHA256 %q", sha)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blobPath := s.blobPath(sha)
+	if !FileExists(blobPath) {
+		return false, nil
+	}
+	if err := EnsureDir(filepath.Dir(target)); err != nil {
+		return false, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := placeFile(blobPath, target, true); err != nil {
+		return false, err
+	}
+	if err := s.addRef(sha, target); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Adopt moves a downloaded file into the store: the file becomes a link to the blob of its SHA256, which is created
+// from the file when the store does not hold it yet. The content of the file is verified against the SHA256 before
+// it becomes a blob.
+func (s *BlobStore) Adopt(sha, target string) error {
+	return s.adopt(sha, target, true)
+}
+
+// adopt adopts a file into the store, verifying its content only when asked, for files which are not verified yet
+func (s *BlobStore) adopt(sha, target string, verify bool) error {
+	if !IsSHA256(sha) {
+		return fmt.Errorf("invalid SHA256 %q", sha)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blobPath := s.blobPath(sha)
+	if FileExists(blobPath) {
+		// Replace the file with the blob unless it already is the blob
+		if !sameFile(blobPath, target) {
+			if err := placeFile(blobPath, target, false); err != nil {
+				return err
+			}
+		}
+		return s.addRef(sha, target)
+	}
+
+	if verify {
+		if err := VerifyChecksum(target, sha); err != nil {
+			return err
+		}
+	}
+
+	// Link the file into the store through a temporary file, so that a blob is either complete or absent
+	tmpPath := filepath.Join(s.root, "tmp", sha)
+	_ = os.Remove(tmpPath)
+	if err := linkFile(target, tmpPath); err != nil {
+		return err
+	}
+	// Blobs are shared, protect them from writes through one of their links
+	if err := os.Chmod(tmpPath, 0444); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to protect blob: %w", err)
+	}
+	if err := os.Rename(tmpPath, blobPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return s.addRef(sha, target)
+}
+
+// Detach removes the file at target unless it is the blob of a SHA256, and reports whether it did. Downloads run it
+// first: Xet downloads write files in place, which would otherwise write through a hard link into a blob other models
+// share, and HTTP downloads would keep the file of another revision.
+func (s *BlobStore) Detach(sha, target string) (bool, error) {
+	if !IsSHA256(sha) {
+		return false, fmt.Errorf("invalid SHA256 %q", sha)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := os.Lstat(target); os.IsNotExist(err) {
+		return false, nil
+	}
+	if identity, ok := s.refIdentity(sha, target); ok && s.holdsBlob(sha, target, identity) {
+		return false, nil
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to detach %s: %w", target, err)
+	}
+	return true, nil
+}
+
+// RefCount returns the number of references to the blob of a SHA256
+func (s *BlobStore) RefCount(sha string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.refsDir(sha))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read references: %w", err)
+	}
+	return len(entries), nil
+}
+
+// Release drops the references of the files under dir, typically before or after deleting a model directory, and
+// returns their number. Blobs are only removed by GC.
+func (s *BlobStore) Release(dir string) (int, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	released := 0
+	err = s.walkRefs(func(sha, refPath, target, _ string) error {
+		if target == dir || strings.HasPrefix(target, dir+string(filepath.Separator)) {
+			if err := os.Remove(refPath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to release reference: %w", err)
+			}
+			released++
+		}
+		return nil
+	})
+	return released, err
+}
+
+// GC removes the blobs without references. References whose file was deleted or replaced without being released
+// are pruned first, so that deleting a model directory is enough for its blobs to be collected.
+func (s *BlobStore) GC() (*BlobStoreGCResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &BlobStoreGCResult{}
+
+	// Prune stale references
+	err := s.walkRefs(func(sha, refPath, target, identity string) error {
+		if s.holdsBlob(sha, target, identity) {
+			return nil
+		}
+		if err := os.Remove(refPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune reference: %w", err)
+		}
+		result.PrunedRefs++
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// Remove the blobs left without references
+	blobs, err := os.ReadDir(filepath.Join(s.root, "blobs"))
+	if err != nil {
+		return result, fmt.Errorf("failed to read blobs: %w", err)
+	}
+	for _, blob := range blobs {
+		sha := blob.Name()
+		refs, err := os.ReadDir(s.refsDir(sha))
+		if err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("failed to read references: %w", err)
+		}
+		if len(refs) > 0 {
+			continue
+		}
+
+		var size int64
+		if info, err := blob.Info(); err == nil {
+			size = info.Size()
+		}
+		if err := os.Remove(s.blobPath(sha)); err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("failed to remove blob %s: %w", sha, err)
+		}
+		_ = os.Remove(s.refsDir(sha))
+		result.RemovedBlobs++
+		result.FreedBytes += size
+	}
+
+	// Remove the leftovers of interrupted adoptions
+	if tmpFiles, err := os.ReadDir(filepath.Join(s.root, "tmp")); err == nil {
+		for _, tmpFile := range tmpFiles {
+			_ = os.Remove(filepath.Join(s.root, "tmp", tmpFile.Name()))
+		}
+	}
+	return result, nil
+}
+
+// holdsBlob reports whether the file of a reference still holds the blob. Hard links are the blob itself, reflinks
+// and copies must still be the file the reference recorded, as any write or replacement changes their identity.
+func (s *BlobStore) holdsBlob(sha, target, identity string) bool {
+	blobInfo, err := os.Stat(s.blobPath(sha))
+	if err != nil {
+		return false
+	}
+	targetInfo, err := os.Lstat(target)
+	if err != nil || !targetInfo.Mode().IsRegular() {
+		return false
+	}
+	return os.SameFile(blobInfo, targetInfo) || (identity != "" && fileIdentity(targetInfo) == identity)
+}
+
+// addRef records a reference to the blob of a SHA256, along with the identity of the file placed from it
+func (s *BlobStore) addRef(sha, target string) error {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", target, err)
+	}
+	if err := EnsureDir(s.refsDir(sha)); err != nil {
+		return fmt.Errorf("failed to create references directory: %w", err)
+	}
+	ref := target + "\n" + fileIdentity(info)
+	if err := os.WriteFile(s.refPath(sha, target), []byte(ref), 0644); err != nil {
+		return fmt.Errorf("failed to record reference: %w", err)
+	}
+	return nil
+}
+
+// refIdentity returns the identity the reference of a file to the blob of a SHA256 recorded, and whether there is one
+func (s *BlobStore) refIdentity(sha, target string) (string, bool) {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return "", false
+	}
+	ref, err := os.ReadFile(s.refPath(sha, target))
+	if err != nil {
+		return "", false
+	}
+	_, identity, _ := strings.Cut(string(ref), "\n")
+	return identity, true
+}
+
+// walkRefs calls fn with every reference of the store
+func (s *BlobStore) walkRefs(fn func(sha, refPath, target, identity string) error) error {
+	shas, err := os.ReadDir(filepath.Join(s.root, "refs"))
+	if err != nil {
+		return fmt.Errorf("failed to read references: %w", err)
+	}
+	for _, shaDir := range shas {
+		sha := shaDir.Name()
+		refs, err := os.ReadDir(s.refsDir(sha))
+		if err != nil {
+			continue
+		}
+		for _, ref := range refs {
+			refPath := filepath.Join(s.refsDir(sha), ref.Name())
+			ref, err := os.ReadFile(refPath)
+			if err != nil {
+				continue
+			}
+			target, identity, _ := strings.Cut(string(ref), "\n")
+			if err := fn(sha, refPath, target, identity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *BlobStore) blobPath(sha string) string {
+	return filepath.Join(s.root, "blobs", strings.ToLower(sha))
+}
+
+func (s *BlobStore) refsDir(sha string) string {
+	return filepath.Join(s.root, "refs", strings.ToLower(sha))
+}
+
+// refPath is the path of the reference of an absolute file path to the blob of a SHA256
+func (s *BlobStore) refPath(sha, target string) string {
+	key := sha256.Sum256([]byte(target))
+	return filepath.Join(s.refsDir(sha), hex.EncodeToString(key[:16]))
+}
+
+// blobStoreFromContext returns the blob store of the hub configuration of the context, nil when none is configured
+func blobStoreFromContext(ctx context.Context) *BlobStore {
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		return hubConfig.BlobStore
+	}
+	return nil
+}
+
+// linkFromBlobStore links a file of a snapshot from the blob store when the store holds it, and reports whether it
+// did. Otherwise any other file at its path is detached, the download must not write into it
+func linkFromBlobStore(ctx context.Context, config *DownloadConfig, file RepoFile) bool {
+	store := blobStoreFromContext(ctx)
+	if store == nil || file.LFS == nil || config.LocalDir == "" {
+		return false
+	}
+	target := filepath.Join(config.LocalDir, file.Path)
+	if config.ForceDownload {
+		// Even the blob itself is downloaded again, into a file of its own
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			logBlobStoreError(ctx, file.Path, "Failed to detach file from the blob store", err)
+		}
+		return false
+	}
+	linked, err := store.Link(file.LFS.OID, target)
+	if err != nil {
+		logBlobStoreError(ctx, file.Path, "Failed to link file from the blob store", err)
+	}
+	if err == nil && linked {
+		return true
+	}
+	if _, err := store.Detach(file.LFS.OID, target); err != nil {
+		logBlobStoreError(ctx, file.Path, "Failed to detach file from the blob store", err)
+	}
+	return false
+}
+
+// logBlobStoreError logs a blob store failure, which only costs the sharing of a file and never fails its download
+func logBlobStoreError(ctx context.Context, filename, msg string, err error) {
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+		hubConfig.Logger.
+			WithField("filename", filename).
+			WithError(err).
+			Warn(msg)
+	}
+}
+
+// placeFile replaces target with a link to src through a temporary file, copying src when it cannot be linked and
+// allowCopy is set
+func placeFile(src, target string, allowCopy bool) error {
+	tmpPath := target + ".blobtmp"
+	_ = os.Remove(tmpPath)
+
+	err := linkFile(src, tmpPath)
+	if errors.Is(err, ErrLinkUnsupported) && allowCopy {
+		err = copyFile(src, tmpPath)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, target); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to place %s: %w", target, err)
+	}
+	return nil
+}
+
+// linkFile creates dst as a hard link to src, or a reflink when hard links are not possible
+func linkFile(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	if err := reflink(src, dst); err != nil {
+		_ = os.Remove(dst)
+		return fmt.Errorf("failed to link %s to %s: %w", dst, src, ErrLinkUnsupported)
+	}
+	return nil
+}
+
+// sameFile reports whether two paths are links to the same file
+func sameFile(a, b string) bool {
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
@@ -0,0 +1,319 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBlobFile writes a file and returns the SHA256 of its content
+func writeBlobFile(t *testing.T, path string, content []byte) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, content, 0644))
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func assertSameFile(t *testing.T, a, b string) {
+	t.Helper()
+	aInfo, err := os.Stat(a)
+	require.NoError(t, err)
+	bInfo, err := os.Stat(b)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(aInfo, bInfo), "%s should be a hard link of %s", a, b)
+}
+
+func TestNewBlobStore(t *testing.T) {
+	_, err := NewBlobStore("")
+	assert.Error(t, err)
+
+	root := filepath.Join(t.TempDir(), DefaultBlobStoreDirName)
+	store, err := NewBlobStore(root)
+	require.NoError(t, err)
+	assert.Equal(t, root, store.Root())
+	for _, dir := range []string{"blobs", "refs", "tmp"} {
+		assert.DirExists(t, filepath.Join(root, dir))
+	}
+}
+
+func TestBlobStoreAdoptAndLink(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+
+	content := []byte("model weights")
+	first := filepath.Join(tmpDir, "model-a", "model.safetensors")
+	sha := writeBlobFile(t, first, content)
+
+	// Nothing to link before the blob is adopted
+	second := filepath.Join(tmpDir, "model-b", "model.safetensors")
+	linked, err := store.Link(sha, second)
+	require.NoError(t, err)
+	assert.False(t, linked)
+	assert.NoFileExists(t, second)
+
+	require.NoError(t, store.Adopt(sha, first))
+	assert.True(t, store.Has(sha))
+	assertSameFile(t, first, store.blobPath(sha))
+
+	// Adopting the same file again does not add a reference
+	require.NoError(t, store.Adopt(sha, first))
+	count, err := store.RefCount(sha)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	linked, err = store.Link(sha, second)
+	require.NoError(t, err)
+	assert.True(t, linked)
+	assertSameFile(t, second, store.blobPath(sha))
+	data, err := os.ReadFile(second)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.NoFileExists(t, second+".blobtmp")
+
+	// A separately downloaded copy is replaced with the blob
+	third := filepath.Join(tmpDir, "model-c", "model.safetensors")
+	writeBlobFile(t, third, content)
+	require.NoError(t, store.Adopt(sha, third))
+	assertSameFile(t, third, store.blobPath(sha))
+
+	count, err = store.RefCount(sha)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestBlobStoreAdoptChecksumMismatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+
+	sha := writeBlobFile(t, filepath.Join(tmpDir, "expected"), []byte("expected"))
+	target := filepath.Join(tmpDir, "model", "model.safetensors")
+	writeBlobFile(t, target, []byte("corrupted"))
+
+	assert.Error(t, store.Adopt(sha, target))
+	assert.False(t, store.Has(sha))
+	count, err := store.RefCount(sha)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestBlobStoreInvalidSHA(t *testing.T) {
+	store, err := NewBlobStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Link("not-a-sha", filepath.Join(t.TempDir(), "file"))
+	assert.Error(t, err)
+	assert.Error(t, store.Adopt("not-a-sha", filepath.Join(t.TempDir(), "file")))
+	assert.False(t, store.Has("not-a-sha"))
+}
+
+func TestBlobStoreReleaseAndGC(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+
+	modelA := filepath.Join(tmpDir, "model-a")
+	modelB := filepath.Join(tmpDir, "model-b")
+	modelAB := filepath.Join(tmpDir, "model-a-b")
+	shared := writeBlobFile(t, filepath.Join(modelA, "shared.safetensors"), []byte("shared weights"))
+	own := writeBlobFile(t, filepath.Join(modelA, "own.safetensors"), []byte("own weights"))
+	require.NoError(t, store.Adopt(shared, filepath.Join(modelA, "shared.safetensors")))
+	require.NoError(t, store.Adopt(own, filepath.Join(modelA, "own.safetensors")))
+	for _, model := range []string{modelB, modelAB} {
+		linked, err := store.Link(shared, filepath.Join(model, "shared.safetensors"))
+		require.NoError(t, err)
+		assert.True(t, linked)
+	}
+
+	// Nothing to collect while all the blobs are referenced
+	result, err := store.GC()
+	require.NoError(t, err)
+	assert.Equal(t, &BlobStoreGCResult{}, result)
+
+	// Releasing a directory does not release its siblings sharing its prefix
+	require.NoError(t, os.RemoveAll(modelA))
+	released, err := store.Release(modelA)
+	require.NoError(t, err)
+	assert.Equal(t, 2, released)
+	count, err := store.RefCount(shared)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	result, err = store.GC()
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.RemovedBlobs)
+	assert.Equal(t, int64(len("own weights")), result.FreedBytes)
+	assert.False(t, store.Has(own))
+	assert.True(t, store.Has(shared))
+
+	// Deleted directories which were not released are pruned
+	require.NoError(t, os.RemoveAll(modelB))
+	require.NoError(t, os.RemoveAll(modelAB))
+	result, err = store.GC()
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.PrunedRefs)
+	assert.Equal(t, 1, result.RemovedBlobs)
+	assert.False(t, store.Has(shared))
+}
+
+func TestBlobStoreDetach(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+
+	modelA := filepath.Join(tmpDir, "model-a", "model.safetensors")
+	sha := writeBlobFile(t, modelA, []byte("weights"))
+	require.NoError(t, store.Adopt(sha, modelA))
+	modelB := filepath.Join(tmpDir, "model-b", "model.safetensors")
+	otherSHA := writeBlobFile(t, modelB, []byte("other weights"))
+
+	// The blob itself is kept
+	detached, err := store.Detach(sha, modelA)
+	require.NoError(t, err)
+	assert.False(t, detached)
+	assert.FileExists(t, modelA)
+
+	// Any other file is removed, a link to another blob as well
+	detached, err = store.Detach(otherSHA, modelA)
+	require.NoError(t, err)
+	assert.True(t, detached)
+	assert.NoFileExists(t, modelA)
+	assert.True(t, store.Has(sha))
+
+	detached, err = store.Detach(sha, modelB)
+	require.NoError(t, err)
+	assert.True(t, detached)
+	assert.NoFileExists(t, modelB)
+
+	detached, err = store.Detach(sha, modelB)
+	require.NoError(t, err)
+	assert.False(t, detached)
+}
+
+func TestBlobStoreGCReplacedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+
+	target := filepath.Join(tmpDir, "model", "model.safetensors")
+	sha := writeBlobFile(t, target, []byte("weights"))
+	require.NoError(t, store.Adopt(sha, target))
+
+	// A file of the same size replacing the link no longer holds the blob
+	require.NoError(t, os.Remove(target))
+	writeBlobFile(t, target, []byte("WEIGHTS"))
+	result, err := store.GC()
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.PrunedRefs)
+	assert.Equal(t, 1, result.RemovedBlobs)
+	assert.False(t, store.Has(sha))
+}
+
+func TestSnapshotDownloadBlobStoreRevisionChange(t *testing.T) {
+	// The mock server lists the new revision of the file, the Xet downloader writes it in place like xet-core does
+	newContent := make([]byte, 100)
+	newSum := sha256.Sum256(newContent)
+	newSHA := hex.EncodeToString(newSum[:])
+	testFiles := []RepoFile{
+		{Path: "model.safetensors", Size: 100, Type: "file", XetHash: "hash", LFS: &LFSInfo{OID: newSHA, Size: 100}},
+	}
+	server := createMockRepoAndFileServer(t, testFiles, 200)
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+	downloader := &fakeXetDownloader{}
+	hubConfig := &HubConfig{MaxWorkers: 1, BlobStore: store, EnableXet: true, XetDownloader: downloader}
+	ctx := context.WithValue(context.Background(), HubConfigKey, hubConfig)
+
+	// Both models share the file of the previous revision
+	oldContent := bytes.Repeat([]byte("a"), 100)
+	modelA := filepath.Join(tmpDir, "model-a")
+	modelB := filepath.Join(tmpDir, "model-b")
+	oldSHA := writeBlobFile(t, filepath.Join(modelA, "model.safetensors"), oldContent)
+	require.NoError(t, store.Adopt(oldSHA, filepath.Join(modelA, "model.safetensors")))
+	linked, err := store.Link(oldSHA, filepath.Join(modelB, "model.safetensors"))
+	require.NoError(t, err)
+	require.True(t, linked)
+
+	// The second model moves to the new revision
+	_, err = SnapshotDownload(ctx, &DownloadConfig{
+		RepoID:     "test/repo",
+		LocalDir:   modelB,
+		Endpoint:   server.URL,
+		MaxWorkers: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"model.safetensors"}, downloader.downloaded())
+
+	// The shared blob and the first model are untouched
+	for _, path := range []string{store.blobPath(oldSHA), filepath.Join(modelA, "model.safetensors")} {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, oldContent, data, "%s should hold the previous revision", path)
+	}
+	data, err := os.ReadFile(filepath.Join(modelB, "model.safetensors"))
+	require.NoError(t, err)
+	assert.Equal(t, newContent, data)
+	assertSameFile(t, filepath.Join(modelB, "model.safetensors"), store.blobPath(newSHA))
+
+	// The reference of the second model to the previous revision is pruned
+	result, err := store.GC()
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.PrunedRefs)
+	assert.Equal(t, 0, result.RemovedBlobs)
+	count, err := store.RefCount(oldSHA)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestSnapshotDownloadBlobStore(t *testing.T) {
+	// The mock server serves files of 100 zero bytes
+	content := make([]byte, 100)
+	sum := sha256.Sum256(content)
+	sha := hex.EncodeToString(sum[:])
+	testFiles := []RepoFile{
+		{Path: "config.json", Size: 100, Type: "file"},
+		{Path: "model.safetensors", Size: 100, Type: "file", LFS: &LFSInfo{OID: sha, Size: 100}},
+	}
+
+	server := createMockRepoAndFileServer(t, testFiles, 200)
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	store, err := NewBlobStore(filepath.Join(tmpDir, DefaultBlobStoreDirName))
+	require.NoError(t, err)
+	hubConfig := &HubConfig{MaxWorkers: 2, BlobStore: store}
+	ctx := context.WithValue(context.Background(), HubConfigKey, hubConfig)
+
+	// The first model populates the store from the downloaded file
+	modelA := filepath.Join(tmpDir, "model-a")
+	writeBlobFile(t, filepath.Join(modelA, "model.safetensors"), content)
+	require.NoError(t, store.Adopt(sha, filepath.Join(modelA, "model.safetensors")))
+
+	// The second model links it instead of downloading it
+	modelB := filepath.Join(tmpDir, "model-b")
+	_, err = SnapshotDownload(ctx, &DownloadConfig{
+		RepoID:     "test/repo",
+		LocalDir:   modelB,
+		Endpoint:   server.URL,
+		MaxWorkers: 2,
+	})
+	require.NoError(t, err)
+
+	assertSameFile(t, filepath.Join(modelB, "model.safetensors"), filepath.Join(modelA, "model.safetensors"))
+	assert.FileExists(t, filepath.Join(modelB, "config.json"))
+	count, err := store.RefCount(sha)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -49,6 +49,17 @@ type HubConfig struct {
 	LogLevel            string              `mapstructure:"log_level"`
 	ProgressDisplayMode ProgressDisplayMode `mapstructure:"progress_display_mode"`
 	EnableProgress      bool                `mapstructure:"enable_progress"`
+	EnableXet           bool                `mapstructure:"enable_xet"`
+	// XetDownloader downloads the files stored on Xet, see WithXetDownloader
+	XetDownloader XetDownloader
+	// BlobStoreDir is the root of the blob store shared by local directory downloads, see WithBlobStore
+	BlobStoreDir string `mapstructure:"blob_store_dir"`
+	BlobStore    *BlobStore
+	// Endpoints are the ordered endpoints requests fail over between, Endpoint alone is used when empty. They are
+	// read from the endpoints key, a list or a string parsed by ParseEndpoints
+	Endpoints []HubEndpoint `mapstructure:"-"`
+	// EndpointHealth tracks the failures of the endpoints, the health shared by the process when nil
+	EndpointHealth *EndpointHealth
 }
 
 // defaultHubConfig returns a default configuration
@@ -74,6 +85,7 @@ func defaultHubConfig() *HubConfig {
 		Token:               GetHfToken(),
 		ProgressDisplayMode: getProgressModeFromEnv(),
 		EnableProgress:      true,
+		EnableXet:           true,
 	}
 }
 
@@ -152,6 +164,20 @@ func WithEndpoint(endpoint string) HubOption {
 	}
 }
 
+// WithEndpoints specifies the ordered endpoints requests fail over between, such as a mirror of the Hub followed by
+// the Hub itself
+func WithEndpoints(endpoints ...HubEndpoint) HubOption {
+	return func(c *HubConfig) error {
+		for _, endpoint := range endpoints {
+			if endpoint.URL == "" {
+				return errors.New("endpoint URL cannot be empty")
+			}
+		}
+		c.Endpoints = endpoints
+		return nil
+	}
+}
+
 // WithCacheDir specifies the cache directory
 func WithCacheDir(cacheDir string) HubOption {
 	return func(c *HubConfig) error {
@@ -286,6 +312,13 @@ func WithViper(v *viper.Viper) HubOption {
 		if v.IsSet("cache_dir") {
 			c.CacheDir = v.GetString("cache_dir")
 		}
+		if v.IsSet("endpoints") {
+			endpoints, err := endpointsFromViper(v.Get("endpoints"))
+			if err != nil {
+				return err
+			}
+			c.Endpoints = endpoints
+		}
 
 		return nil
 	}
@@ -341,6 +374,7 @@ func (c *HubConfig) ToDownloadConfig() *DownloadConfig {
 		Token:       c.Token,
 		CacheDir:    c.CacheDir,
 		Endpoint:    c.Endpoint,
+		Endpoints:   c.Endpoints,
 		EtagTimeout: c.EtagTimeout,
 		Headers:     BuildHeaders(c.Token, c.UserAgent, nil),
 		MaxWorkers:  c.MaxWorkers,
@@ -350,6 +384,32 @@ func (c *HubConfig) ToDownloadConfig() *DownloadConfig {
 	}
 }
 
+// WithXetDownloader routes the snapshot downloads of files stored on Xet through the given downloader, falling back
+// to HTTP when it fails
+func WithXetDownloader(downloader XetDownloader) HubOption {
+	return func(c *HubConfig) error {
+		c.XetDownloader = downloader
+		return nil
+	}
+}
+
+// WithXet enables or disables Xet downloads
+func WithXet(enabled bool) HubOption {
+	return func(c *HubConfig) error {
+		c.EnableXet = enabled
+		return nil
+	}
+}
+
+// WithBlobStore shares the LFS files downloaded to local directories through a content-addressed blob store: files
+// already in the store are linked instead of downloaded, downloaded files are moved into it
+func WithBlobStore(store *BlobStore) HubOption {
+	return func(c *HubConfig) error {
+		c.BlobStore = store
+		return nil
+	}
+}
+
 // WithDetailedLogs enables or disables detailed logging
 func WithDetailedLogs(enabled bool) HubOption {
 	return func(c *HubConfig) error {
@@ -111,6 +111,7 @@ const (
 	EnvHfHubCache           = "HF_HUB_CACHE"
 	EnvHfHubOffline         = "HF_HUB_OFFLINE"
 	EnvHfHubDisableProgress = "HF_HUB_DISABLE_PROGRESS_BARS"
+	EnvHfHubDisableXet      = "HF_HUB_DISABLE_XET"
 )
 
 // Repo URL prefixes for different types
@@ -108,6 +108,11 @@ func HfHubDownload(ctx context.Context, config *DownloadConfig) (string, error)
 		config.EtagTimeout = DefaultEtagTimeout
 	}
 
+	return withEndpointFailover(ctx, config, hfHubDownload)
+}
+
+// hfHubDownload downloads a file from the endpoint of the configuration
+func hfHubDownload(ctx context.Context, config *DownloadConfig) (string, error) {
 	// If local_dir is specified, download to local directory
 	if config.LocalDir != "" {
 		return hfHubDownloadToLocalDir(ctx, config)
@@ -262,11 +267,34 @@ func hfHubDownloadToLocalDir(ctx context.Context, config *DownloadConfig) (strin
 		return "", err
 	}
 
+	// LFS files are shared with the other models through the blob store, their etag is their SHA256
+	store := blobStoreFromContext(ctx)
+	if store != nil && IsSHA256(metadata.Etag) {
+		linked, err := store.Link(metadata.Etag, filePath)
+		if err == nil && linked {
+			return filePath, nil
+		}
+		if err != nil {
+			logBlobStoreError(ctx, config.Filename, "Failed to link file from the blob store", err)
+		}
+		// A file of another revision would be kept by the download
+		if _, err := store.Detach(metadata.Etag, filePath); err != nil {
+			logBlobStoreError(ctx, config.Filename, "Failed to detach file from the blob store", err)
+		}
+	}
+
 	// Download the file
 	if err := downloadToTmpAndMove(ctx, config, metadata, filePath); err != nil {
 		return "", fmt.Errorf("failed to download file: %w", err)
 	}
 
+	// The download was verified against the etag, adopt it without verifying it again
+	if store != nil && IsSHA256(metadata.Etag) {
+		if err := store.adopt(metadata.Etag, filePath, false); err != nil {
+			logBlobStoreError(ctx, config.Filename, "Failed to move file into the blob store", err)
+		}
+	}
+
 	return filePath, nil
 }
 
@@ -450,9 +478,9 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 		if err != nil {
 			lastErr = err
 
-			// Check if this is the last attempt
-			if attempt == maxRetries {
-				return fmt.Errorf("failed to perform request after %d attempts: %w", maxRetries+1, lastErr)
+			// Check if this is the last attempt, or if another endpoint can serve the request
+			if attempt == maxRetries || canFailOver(ctx) {
+				return fmt.Errorf("failed to perform request after %d attempts: %w", attempt+1, lastErr)
 			}
 
 			// Wait with exponential backoff before retrying
@@ -483,10 +511,11 @@ func httpDownload(ctx context.Context, config *DownloadConfig, metadata *FileMet
 
 		// Check status code
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			lastErr = fmt.Errorf("HTTP error %d: %s", resp.StatusCode, resp.Status)
+			lastErr = newStatusError(resp)
 
-			// Check if this error is retryable
-			if !retryableHTTPError(nil, resp.StatusCode) || attempt == maxRetries {
+			// Check if this error is retryable, against this endpoint
+			if !retryableHTTPError(nil, resp.StatusCode) || attempt == maxRetries ||
+				(canFailOver(ctx) && failoverStatus(resp.StatusCode)) {
 				return lastErr
 			}
 
@@ -589,6 +618,11 @@ func GetHfFileMetadata(ctx context.Context, repoID, filename string, opts ...Dow
 		opt(config)
 	}
 
+	return withEndpointFailover(ctx, config, getHfFileMetadata)
+}
+
+// getHfFileMetadata fetches metadata for a file from the endpoint of the configuration
+func getHfFileMetadata(ctx context.Context, config *DownloadConfig) (*FileMetadata, error) {
 	// Construct URL
 	url, err := HfHubURL(config.RepoID, config.Filename, config)
 	if err != nil {
@@ -619,7 +653,7 @@ func GetHfFileMetadata(ctx context.Context, repoID, filename string, opts ...Dow
 
 	// Check status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, resp.Status)
+		return nil, newStatusError(resp)
 	}
 
 	// Extract metadata
@@ -735,9 +769,9 @@ func getMetadataOrCatchError(ctx context.Context, config *DownloadConfig, storag
 		if err != nil {
 			lastErr = err
 
-			// Check if this is the last attempt
-			if attempt == maxRetries {
-				return nil, fmt.Errorf("failed to perform HEAD request after %d attempts: %w", maxRetries+1, err)
+			// Check if this is the last attempt, or if another endpoint can serve the request
+			if attempt == maxRetries || canFailOver(ctx) {
+				return nil, fmt.Errorf("failed to perform HEAD request after %d attempts: %w", attempt+1, err)
 			}
 
 			// Wait with exponential backoff before retrying
@@ -780,8 +814,9 @@ func getMetadataOrCatchError(ctx context.Context, config *DownloadConfig, storag
 		if resp.StatusCode != http.StatusOK {
 			lastErr = handleHTTPError(resp, config.RepoID, config.RepoType, config.Revision, config.Filename)
 
-			// Check if this error is retryable
-			if !retryableHTTPError(nil, resp.StatusCode) || attempt == maxRetries {
+			// Check if this error is retryable, against this endpoint
+			if !retryableHTTPError(nil, resp.StatusCode) || attempt == maxRetries ||
+				(canFailOver(ctx) && failoverStatus(resp.StatusCode)) {
 				return nil, lastErr
 			}
 
@@ -0,0 +1,297 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpointCooldown is how long an endpoint which failed is tried after the healthy endpoints
+	DefaultEndpointCooldown = time.Minute
+	// maxEndpointCooldown caps the cooldown of the endpoints failing repeatedly
+	maxEndpointCooldown = 10 * time.Minute
+)
+
+// HubEndpoint is an endpoint serving the Hub API, huggingface.co or a mirror or proxy of it
+type HubEndpoint struct {
+	URL string `mapstructure:"url" json:"url"`
+	// Token authenticates the requests to the endpoint, the token of the download is used when empty
+	Token string `mapstructure:"token" json:"token,omitempty"`
+}
+
+// ParseEndpoints parses an ordered list of endpoints, either a JSON array of endpoints or comma-separated URLs
+func ParseEndpoints(s string) ([]HubEndpoint, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var endpoints []HubEndpoint
+	if strings.HasPrefix(s, "[") {
+		if err := json.Unmarshal([]byte(s), &endpoints); err != nil {
+			return nil, fmt.Errorf("invalid endpoints: %w", err)
+		}
+	} else {
+		for _, url := range strings.Split(s, ",") {
+			endpoints = append(endpoints, HubEndpoint{URL: url})
+		}
+	}
+
+	for i := range endpoints {
+		endpoints[i].URL = strings.TrimSuffix(strings.TrimSpace(endpoints[i].URL), "/")
+		if endpoints[i].URL == "" {
+			return nil, errors.New("invalid endpoints: endpoint URL cannot be empty")
+		}
+	}
+	return endpoints, nil
+}
+
+// endpointsFromViper decodes the endpoints of a viper configuration: a string parsed by ParseEndpoints, from
+// environment variables or flags, or a list of URLs or endpoints, from configuration files
+func endpointsFromViper(value interface{}) ([]HubEndpoint, error) {
+	if s, ok := value.(string); ok {
+		return ParseEndpoints(s)
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid endpoints: expected a list or a string, got %T", value)
+	}
+	var urls []string
+	for _, item := range items {
+		url, ok := item.(string)
+		if !ok {
+			// A list of endpoints rather than URLs
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid endpoints: %w", err)
+			}
+			return ParseEndpoints(string(data))
+		}
+		urls = append(urls, url)
+	}
+	return ParseEndpoints(strings.Join(urls, ","))
+}
+
+// EndpointHealth tracks the failures of the Hub endpoints. An endpoint which failed is tried after the healthy
+// endpoints until its cooldown expires, the cooldown doubling with each consecutive failure. Endpoints are never
+// skipped: when all of them failed, they are tried in the order they recover.
+type EndpointHealth struct {
+	mu       sync.Mutex
+	cooldown time.Duration
+	failures map[string]*endpointFailure
+}
+
+// endpointFailure records the consecutive failures of an endpoint
+type endpointFailure struct {
+	count int
+	until time.Time
+}
+
+// defaultEndpointHealth is the health shared by the downloads whose hub configuration does not have its own
+var defaultEndpointHealth = NewEndpointHealth(DefaultEndpointCooldown)
+
+// NewEndpointHealth creates an endpoint health tracker with the given cooldown
+func NewEndpointHealth(cooldown time.Duration) *EndpointHealth {
+	if cooldown <= 0 {
+		cooldown = DefaultEndpointCooldown
+	}
+	return &EndpointHealth{
+		cooldown: cooldown,
+		failures: make(map[string]*endpointFailure),
+	}
+}
+
+// DefaultEndpointHealth returns the endpoint health shared by the process
+func DefaultEndpointHealth() *EndpointHealth {
+	return defaultEndpointHealth
+}
+
+// Healthy reports whether an endpoint is not cooling down after a failure
+func (h *EndpointHealth) Healthy(url string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failure, ok := h.failures[url]
+	return !ok || time.Now().After(failure.until)
+}
+
+// ReportFailure records a failure of an endpoint
+func (h *EndpointHealth) ReportFailure(url string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	failure, ok := h.failures[url]
+	if !ok {
+		failure = &endpointFailure{}
+		h.failures[url] = failure
+	}
+	failure.count++
+	cooldown := h.cooldown << (failure.count - 1)
+	if cooldown <= 0 || cooldown > maxEndpointCooldown {
+		cooldown = maxEndpointCooldown
+	}
+	failure.until = time.Now().Add(cooldown)
+}
+
+// ReportSuccess records a success of an endpoint, which clears its failures
+func (h *EndpointHealth) ReportSuccess(url string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.failures, url)
+}
+
+// Order returns the endpoints in the order to try them: the healthy endpoints in their given order, then the
+// endpoints cooling down, the first to recover first
+func (h *EndpointHealth) Order(endpoints []HubEndpoint) []HubEndpoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	var healthy, unhealthy []HubEndpoint
+	for _, endpoint := range endpoints {
+		if failure, ok := h.failures[endpoint.URL]; ok && now.Before(failure.until) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return h.failures[unhealthy[i].URL].until.Before(h.failures[unhealthy[j].URL].until)
+	})
+	return append(healthy, unhealthy...)
+}
+
+// endpointHealthFromContext returns the endpoint health of the hub configuration of the context, the shared one
+// when it has none
+func endpointHealthFromContext(ctx context.Context) *EndpointHealth {
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.EndpointHealth != nil {
+		return hubConfig.EndpointHealth
+	}
+	return defaultEndpointHealth
+}
+
+// failoverContextKey marks the contexts of the requests which have another endpoint to fail over to
+type failoverContextKey struct{}
+
+// canFailOver reports whether a request has another endpoint to fail over to, in which case it returns its
+// failover errors right away instead of retrying them against the same endpoint
+func canFailOver(ctx context.Context) bool {
+	failover, _ := ctx.Value(failoverContextKey{}).(bool)
+	return failover
+}
+
+// failoverStatus reports whether an HTTP status makes requests fail over to the next endpoint
+func failoverStatus(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
+
+// statusError is an HTTP error response, for the requests which do not map it to a Hub error
+type statusError struct {
+	statusCode int
+	status     string
+}
+
+func newStatusError(resp *http.Response) *statusError {
+	return &statusError{statusCode: resp.StatusCode, status: resp.Status}
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP error %d: %s", e.statusCode, e.status)
+}
+
+func (e *statusError) httpStatus() int {
+	return e.statusCode
+}
+
+func (e *HTTPError) httpStatus() int {
+	return e.StatusCode
+}
+
+// isFailoverError reports whether an error of an endpoint makes requests fail over to the next endpoint: server
+// errors, rate limiting and network errors
+func isFailoverError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var statusErr interface{ httpStatus() int }
+	if errors.As(err, &statusErr) {
+		return failoverStatus(statusErr.httpStatus())
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// forEndpoint returns a copy of the download configuration sending its requests to the given endpoint
+func (c *DownloadConfig) forEndpoint(endpoint HubEndpoint) *DownloadConfig {
+	config := *c
+	config.Endpoint = endpoint.URL
+	if endpoint.Token != "" {
+		config.Token = endpoint.Token
+	}
+	config.Endpoints = nil
+	return &config
+}
+
+// withEndpointFailover sends a request to the endpoints of a download in order, healthy endpoints first, failing
+// over to the next endpoint on server errors, rate limiting and network errors. Downloads without endpoints send
+// it to their single endpoint.
+func withEndpointFailover[T any](ctx context.Context, config *DownloadConfig, request func(context.Context, *DownloadConfig) (T, error)) (T, error) {
+	if len(config.Endpoints) == 0 {
+		return request(ctx, config)
+	}
+
+	health := endpointHealthFromContext(ctx)
+	endpoints := health.Order(config.Endpoints)
+
+	var result T
+	var err error
+	for i, endpoint := range endpoints {
+		last := i == len(endpoints)-1
+		requestCtx := ctx
+		if !last {
+			requestCtx = context.WithValue(ctx, failoverContextKey{}, true)
+		}
+
+		result, err = request(requestCtx, config.forEndpoint(endpoint))
+		if err == nil {
+			health.ReportSuccess(endpoint.URL)
+			return result, nil
+		}
+		if ctx.Err() != nil || !isFailoverError(err) {
+			return result, err
+		}
+
+		health.ReportFailure(endpoint.URL)
+		if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil && !last {
+			hubConfig.Logger.
+				WithField("endpoint", endpoint.URL).
+				WithField("next_endpoint", endpoints[i+1].URL).
+				WithField("repo_id", config.RepoID).
+				WithError(err).
+				Warn("Hub endpoint failed, failing over to the next endpoint")
+		}
+	}
+	return result, err
+}
+
+// firstEndpoint returns the endpoint a download tries first, for the requests which cannot fail over
+func firstEndpoint(ctx context.Context, config *DownloadConfig) HubEndpoint {
+	if len(config.Endpoints) == 0 {
+		return HubEndpoint{URL: config.Endpoint, Token: config.Token}
+	}
+	endpoint := endpointHealthFromContext(ctx).Order(config.Endpoints)[0]
+	if endpoint.Token == "" {
+		endpoint.Token = config.Token
+	}
+	return endpoint
+}
@@ -0,0 +1,345 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []HubEndpoint
+		wantErr  bool
+	}{
+		{
+			name:  "empty",
+			input: " ",
+		},
+		{
+			name:  "comma-separated URLs",
+			input: "https://hf-mirror.internal/, https://huggingface.co",
+			expected: []HubEndpoint{
+				{URL: "https://hf-mirror.internal"},
+				{URL: "https://huggingface.co"},
+			},
+		},
+		{
+			name:  "JSON endpoints",
+			input: `[{"url": "https://hf-mirror.internal", "token": "mirror-token"}, {"url": "https://huggingface.co"}]`,
+			expected: []HubEndpoint{
+				{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+				{URL: "https://huggingface.co"},
+			},
+		},
+		{
+			name:    "empty URL",
+			input:   "https://hf-mirror.internal,,https://huggingface.co",
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			input:   `[{"url": }]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, err := ParseEndpoints(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, endpoints)
+		})
+	}
+}
+
+func TestWithViperEndpoints(t *testing.T) {
+	expected := []HubEndpoint{
+		{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+		{URL: "https://huggingface.co"},
+	}
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "string",
+			value: `[{"url": "https://hf-mirror.internal", "token": "mirror-token"}, {"url": "https://huggingface.co"}]`,
+		},
+		{
+			name: "list of endpoints",
+			value: []interface{}{
+				map[string]interface{}{"url": "https://hf-mirror.internal", "token": "mirror-token"},
+				map[string]interface{}{"url": "https://huggingface.co"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("endpoints", tt.value)
+
+			config, err := NewHubConfig(WithViper(v))
+			require.NoError(t, err)
+			assert.Equal(t, expected, config.Endpoints)
+			assert.Equal(t, expected, config.ToDownloadConfig().Endpoints)
+		})
+	}
+
+	t.Run("list of URLs", func(t *testing.T) {
+		v := viper.New()
+		v.Set("endpoints", []interface{}{"https://hf-mirror.internal", "https://huggingface.co"})
+
+		config, err := NewHubConfig(WithViper(v))
+		require.NoError(t, err)
+		assert.Equal(t, []HubEndpoint{{URL: "https://hf-mirror.internal"}, {URL: "https://huggingface.co"}}, config.Endpoints)
+	})
+}
+
+func TestWithEndpoints(t *testing.T) {
+	_, err := NewHubConfig(WithEndpoints(HubEndpoint{URL: ""}))
+	assert.Error(t, err)
+
+	config, err := NewHubConfig(WithEndpoints(HubEndpoint{URL: "https://hf-mirror.internal"}))
+	require.NoError(t, err)
+	assert.Equal(t, []HubEndpoint{{URL: "https://hf-mirror.internal"}}, config.Endpoints)
+}
+
+func TestEndpointHealth(t *testing.T) {
+	mirror := HubEndpoint{URL: "https://hf-mirror.internal"}
+	proxy := HubEndpoint{URL: "https://hf-proxy.internal"}
+	hub := HubEndpoint{URL: DefaultEndpoint}
+	endpoints := []HubEndpoint{mirror, proxy, hub}
+
+	health := NewEndpointHealth(time.Minute)
+	assert.Equal(t, endpoints, health.Order(endpoints))
+
+	// Failed endpoints are tried last, the first to recover first
+	health.ReportFailure(mirror.URL)
+	health.ReportFailure(mirror.URL)
+	health.ReportFailure(proxy.URL)
+	assert.False(t, health.Healthy(mirror.URL))
+	assert.True(t, health.Healthy(hub.URL))
+	assert.Equal(t, []HubEndpoint{hub, proxy, mirror}, health.Order(endpoints))
+
+	health.ReportSuccess(mirror.URL)
+	assert.True(t, health.Healthy(mirror.URL))
+	assert.Equal(t, []HubEndpoint{mirror, hub, proxy}, health.Order(endpoints))
+
+	// Endpoints recover once their cooldown expires
+	health = NewEndpointHealth(time.Millisecond)
+	health.ReportFailure(mirror.URL)
+	assert.Eventually(t, func() bool { return health.Healthy(mirror.URL) }, time.Second, time.Millisecond)
+	assert.Equal(t, endpoints, health.Order(endpoints))
+
+	// Cooldowns are capped
+	health = NewEndpointHealth(time.Minute)
+	for i := 0; i < 100; i++ {
+		health.ReportFailure(mirror.URL)
+	}
+	assert.WithinDuration(t, time.Now().Add(maxEndpointCooldown), health.failures[mirror.URL].until, time.Second)
+}
+
+func TestIsFailoverError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "server error", err: &statusError{statusCode: http.StatusBadGateway}, expected: true},
+		{name: "wrapped server error", err: fmt.Errorf("failed to download file: %w", NewHTTPError("bad gateway", 502, nil)), expected: true},
+		{name: "rate limited", err: NewRateLimitError(nil, 0), expected: true},
+		{name: "not found", err: NewEntryNotFoundError("test/repo", RepoTypeModel, "main", "config.json", nil), expected: false},
+		{name: "network error", err: fmt.Errorf("failed to perform request: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), expected: true},
+		{name: "canceled", err: context.Canceled, expected: false},
+		{name: "other error", err: errors.New("checksum mismatch"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isFailoverError(tt.err))
+		})
+	}
+}
+
+// createFailingServer creates a server answering every request with the given status, counting them
+func createFailingServer(t *testing.T, statusCode int, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.WriteHeader(statusCode)
+	}))
+}
+
+func TestEndpointFailover(t *testing.T) {
+	testFiles := []RepoFile{
+		{Path: "config.json", Size: 100, Type: "file"},
+	}
+
+	newContext := func(health *EndpointHealth) context.Context {
+		hubConfig := &HubConfig{
+			MaxRetries:          3,
+			RetryInterval:       time.Millisecond,
+			MaxWorkers:          2,
+			DisableProgressBars: true,
+			EndpointHealth:      health,
+		}
+		return context.WithValue(context.Background(), HubConfigKey, hubConfig)
+	}
+
+	t.Run("list repo files fails over and skips the failed endpoint", func(t *testing.T) {
+		var mirrorRequests int32
+		mirror := createFailingServer(t, http.StatusServiceUnavailable, &mirrorRequests)
+		defer mirror.Close()
+		server := createMockRepoAndFileServer(t, testFiles, http.StatusOK)
+		defer server.Close()
+
+		health := NewEndpointHealth(time.Hour)
+		ctx := newContext(health)
+		config := &DownloadConfig{
+			RepoID:    "test/repo",
+			Endpoints: []HubEndpoint{{URL: mirror.URL}, {URL: server.URL}},
+		}
+
+		files, err := ListRepoFiles(ctx, config)
+		require.NoError(t, err)
+		assert.Len(t, files, 1)
+		// The mirror is not retried when another endpoint can serve the request
+		assert.Equal(t, int32(1), atomic.LoadInt32(&mirrorRequests))
+		assert.False(t, health.Healthy(mirror.URL))
+
+		// The failed mirror is tried last
+		_, err = ListRepoFiles(ctx, config)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&mirrorRequests))
+	})
+
+	t.Run("rate limited endpoints fail over", func(t *testing.T) {
+		var mirrorRequests int32
+		mirror := createFailingServer(t, http.StatusTooManyRequests, &mirrorRequests)
+		defer mirror.Close()
+		server := createMockRepoAndFileServer(t, testFiles, http.StatusOK)
+		defer server.Close()
+
+		_, err := ListRepoFiles(newContext(NewEndpointHealth(time.Hour)), &DownloadConfig{
+			RepoID:    "test/repo",
+			Endpoints: []HubEndpoint{{URL: mirror.URL}, {URL: server.URL}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&mirrorRequests))
+	})
+
+	t.Run("client errors do not fail over", func(t *testing.T) {
+		var mirrorRequests, serverRequests int32
+		mirror := createFailingServer(t, http.StatusNotFound, &mirrorRequests)
+		defer mirror.Close()
+		server := createFailingServer(t, http.StatusOK, &serverRequests)
+		defer server.Close()
+
+		health := NewEndpointHealth(time.Hour)
+		_, err := ListRepoFiles(newContext(health), &DownloadConfig{
+			RepoID:    "test/repo",
+			Endpoints: []HubEndpoint{{URL: mirror.URL}, {URL: server.URL}},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&serverRequests))
+		assert.True(t, health.Healthy(mirror.URL))
+	})
+
+	t.Run("the last endpoint is retried", func(t *testing.T) {
+		var mirrorRequests, serverRequests int32
+		mirror := createFailingServer(t, http.StatusBadGateway, &mirrorRequests)
+		defer mirror.Close()
+		server := createFailingServer(t, http.StatusBadGateway, &serverRequests)
+		defer server.Close()
+
+		_, err := ListRepoFiles(newContext(NewEndpointHealth(time.Hour)), &DownloadConfig{
+			RepoID:    "test/repo",
+			Endpoints: []HubEndpoint{{URL: mirror.URL}, {URL: server.URL}},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&mirrorRequests))
+		assert.Equal(t, int32(4), atomic.LoadInt32(&serverRequests)) // Initial + 3 retries
+	})
+
+	t.Run("endpoints use their own token", func(t *testing.T) {
+		var mirrorRequests int32
+		mirror := createFailingServer(t, http.StatusInternalServerError, &mirrorRequests)
+		defer mirror.Close()
+		var authorizations []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorizations = append(authorizations, r.Header.Get(AuthorizationHeader))
+			w.Header().Set(HuggingfaceHeaderXRepoCommit, "abc123")
+			w.Header().Set(HuggingfaceHeaderXLinkedEtag, "def456")
+			w.Header().Set(HuggingfaceHeaderXLinkedSize, "100")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		metadata, err := GetHfFileMetadata(newContext(NewEndpointHealth(time.Hour)), "test/repo", "config.json",
+			func(config *DownloadConfig) error {
+				config.Token = "download-token"
+				config.Endpoints = []HubEndpoint{{URL: mirror.URL, Token: "mirror-token"}, {URL: server.URL, Token: "hub-token"}}
+				return nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "def456", metadata.Etag)
+		assert.Equal(t, []string{"Bearer hub-token"}, authorizations)
+	})
+
+	t.Run("downloads fail over", func(t *testing.T) {
+		var mirrorRequests int32
+		mirror := createFailingServer(t, http.StatusServiceUnavailable, &mirrorRequests)
+		defer mirror.Close()
+		server := createMockRepoAndFileServer(t, testFiles, http.StatusOK)
+		defer server.Close()
+
+		localDir := t.TempDir()
+		filePath, err := HfHubDownload(newContext(NewEndpointHealth(time.Hour)), &DownloadConfig{
+			RepoID:    "test/repo",
+			Filename:  "config.json",
+			LocalDir:  localDir,
+			Endpoints: []HubEndpoint{{URL: mirror.URL}, {URL: server.URL}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(localDir, "config.json"), filePath)
+		size, err := GetFileSize(filePath)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), size)
+	})
+
+	t.Run("unreachable endpoints fail over", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+		server := createMockRepoAndFileServer(t, testFiles, http.StatusOK)
+		defer server.Close()
+
+		localDir := t.TempDir()
+		_, err := SnapshotDownload(newContext(NewEndpointHealth(time.Hour)), &DownloadConfig{
+			RepoID:    "test/repo",
+			LocalDir:  localDir,
+			Endpoints: []HubEndpoint{{URL: unreachable.URL}, {URL: server.URL}},
+		})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(localDir, "config.json"))
+	})
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package hub
+
+import (
+	"fmt"
+	"os"
+)
+
+// fileIdentity identifies the content of a file by its size and modification time, inodes are not available
+func fileIdentity(info os.FileInfo) string {
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
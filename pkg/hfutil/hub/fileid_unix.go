@@ -0,0 +1,17 @@
+//go:build unix
+
+package hub
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileIdentity identifies the content of a file by its inode, size and modification time, which any write changes
+func fileIdentity(info os.FileInfo) string {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return fmt.Sprintf("%d:%d:%d:%d", uint64(st.Dev), uint64(st.Ino), info.Size(), info.ModTime().UnixNano())
+	}
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
@@ -18,6 +18,8 @@ type HubClientParams struct {
 	Logger logging.Interface `name:"hub_logger"`
 	// Alternative logger option
 	AnotherLogger logging.Interface `name:"another_log" optional:"true"`
+	// XetDownloader downloads the files stored on Xet, e.g. xet.HubDownloaderModule
+	XetDownloader XetDownloader `optional:"true"`
 }
 
 // HubClient represents the enhanced Hub client with dependency injection support
@@ -32,6 +34,14 @@ func NewHubClient(config *HubConfig) (*HubClient, error) {
 		return nil, fmt.Errorf("invalid hub config: %w", err)
 	}
 
+	if config.BlobStore == nil && config.BlobStoreDir != "" {
+		store, err := NewBlobStore(config.BlobStoreDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open blob store: %w", err)
+		}
+		config.BlobStore = store
+	}
+
 	return &HubClient{
 		config: config,
 		logger: config.Logger,
@@ -165,6 +175,14 @@ func WithDownloadToken(token string) DownloadOption {
 	}
 }
 
+// WithDownloadEndpoints sets the ordered endpoints the download fails over between
+func WithDownloadEndpoints(endpoints ...HubEndpoint) DownloadOption {
+	return func(config *DownloadConfig) error {
+		config.Endpoints = endpoints
+		return nil
+	}
+}
+
 // Module provides the fx module for dependency injection
 var Module = fx.Provide(
 	func(v *viper.Viper, params HubClientParams) (*HubClient, error) {
@@ -179,6 +197,7 @@ var Module = fx.Provide(
 		config, err := NewHubConfig(
 			WithViper(v),
 			WithLogger(logger),
+			WithXetDownloader(params.XetDownloader),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error creating hub config: %+v", err)
@@ -6,15 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"sync"
 	"time"
 )
 
 // RepoFile represents a file in a repository
 type RepoFile struct {
-	Path string `json:"path"`
-	Size int64  `json:"size"`
-	Type string `json:"type"` // "file" or "directory"
+	Path string   `json:"path"`
+	Size int64    `json:"size"`
+	Type string   `json:"type"` // "file" or "directory"
+	OID  string   `json:"oid,omitempty"`
+	LFS  *LFSInfo `json:"lfs,omitempty"`
+	// XetHash is set for the files stored on Xet
+	XetHash string `json:"xetHash,omitempty"`
 }
 
 // ListRepoFiles lists all files in a repository
@@ -23,6 +28,11 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 		return nil, fmt.Errorf("repo_id cannot be empty")
 	}
 
+	return withEndpointFailover(ctx, config, listRepoFiles)
+}
+
+// listRepoFiles lists all files in a repository from the endpoint of the configuration
+func listRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, error) {
 	// Set defaults
 	repoType := config.RepoType
 	if repoType == "" {
@@ -80,8 +90,8 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 
 		resp, err := client.Do(req)
 		if err != nil {
-			// Network errors are retryable
-			if attempt < maxRetries {
+			// Network errors are retryable, unless another endpoint can serve the request
+			if attempt < maxRetries && !canFailOver(ctx) {
 				delay := exponentialBackoffWithJitter(attempt+1, retryInterval, 60*time.Second)
 				select {
 				case <-time.After(delay):
@@ -111,8 +121,8 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 				retryAfter = exponentialBackoffWithJitter(attempt+1, retryInterval, 300*time.Second) // Max 5 minutes
 			}
 
-			// Only retry if we haven't exhausted attempts
-			if attempt < maxRetries {
+			// Only retry if we haven't exhausted attempts and no other endpoint can serve the request
+			if attempt < maxRetries && !canFailOver(ctx) {
 				select {
 				case <-time.After(retryAfter):
 					continue
@@ -123,7 +133,7 @@ func ListRepoFiles(ctx context.Context, config *DownloadConfig) ([]RepoFile, err
 		}
 
 		// Handle other HTTP errors with retry for server errors
-		if resp.StatusCode >= 500 && attempt < maxRetries {
+		if resp.StatusCode >= 500 && attempt < maxRetries && !canFailOver(ctx) {
 			delay := exponentialBackoffWithJitter(attempt+1, retryInterval, 60*time.Second)
 			select {
 			case <-time.After(delay):
@@ -146,6 +156,8 @@ type downloadTask struct {
 	file   RepoFile
 	config *DownloadConfig
 	index  int
+	// xet downloads the file when it is stored on Xet
+	xet XetDownloader
 }
 
 // downloadResult represents the result of a download task
@@ -201,6 +213,16 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 
 	fileCount := len(filesToDownload)
 
+	// Route the files stored on Xet through the Xet downloader when one is configured
+	xetDownloader := xetDownloaderFromContext(ctx)
+	if xetDownloader != nil && IsXetRepo(filesToDownload) {
+		if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+			hubConfig.Logger.
+				WithField("repo_id", config.RepoID).
+				Info("Repository is stored on Xet, downloading its Xet files by chunks")
+		}
+	}
+
 	// Create overall progress for snapshot download
 	snapshotProgress := NewProgress(fmt.Sprintf("Downloading %s", config.RepoID), totalSize, enableProgress)
 
@@ -235,6 +257,7 @@ func SnapshotDownload(ctx context.Context, config *DownloadConfig) (string, erro
 				file:   file,
 				config: &fileConfig,
 				index:  i,
+				xet:    xetDownloader,
 			}:
 			case <-ctx.Done():
 				return
@@ -324,8 +347,8 @@ func downloadWorker(ctx context.Context, workerID int, taskChan <-chan downloadT
 
 			startTime := time.Now()
 
-			// Perform the download (HfHubDownload handles its own progress per file)
-			filePath, err := HfHubDownload(ctx, task.config)
+			// Perform the download (downloads handle their own progress per file)
+			filePath, err := downloadRepoFile(ctx, workerID, task)
 
 			duration := time.Since(startTime)
 
@@ -361,6 +384,37 @@ func downloadWorker(ctx context.Context, workerID int, taskChan <-chan downloadT
 	}
 }
 
+// downloadRepoFile downloads a file of a snapshot, through the Xet downloader of the task when the file is stored on
+// Xet. Xet failures fall back to HTTP
+func downloadRepoFile(ctx context.Context, workerID int, task downloadTask) (string, error) {
+	// Files already in the blob store are linked without downloading them. HTTP downloads check the store
+	// themselves, once they know the SHA256 of the file
+	if linkFromBlobStore(ctx, task.config, task.file) {
+		return filepath.Join(task.config.LocalDir, task.file.Path), nil
+	}
+
+	if task.xet != nil && task.file.XetHash != "" {
+		filePath, err := xetDownload(ctx, task.xet, task.config, task.file)
+		if err == nil {
+			adoptXetFile(ctx, task.file, filePath)
+			return filePath, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok && hubConfig.Logger != nil {
+			hubConfig.Logger.
+				WithField("worker_id", workerID).
+				WithField("file", task.file.Path).
+				WithError(err).
+				Warn("Xet download failed, falling back to HTTP")
+		}
+	}
+
+	// HfHubDownload handles its own progress per file
+	return HfHubDownload(ctx, task.config)
+}
+
 // FilterByPatterns filters files based on allow and ignore patterns
 func FilterByPatterns(files []RepoFile, allowPatterns, ignorePatterns []string) []RepoFile {
 	var filtered []RepoFile
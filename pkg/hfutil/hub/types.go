@@ -98,6 +98,9 @@ type DownloadConfig struct {
 	EtagTimeout time.Duration
 	Headers     map[string]string
 	Endpoint    string
+	// Endpoints are tried in order instead of Endpoint, failing over to the next one on server errors, rate
+	// limiting and network errors
+	Endpoints []HubEndpoint
 
 	// Concurrent downloads (for snapshots)
 	MaxWorkers int
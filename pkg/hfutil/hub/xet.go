@@ -0,0 +1,121 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// XetDownloader downloads files stored on Xet, the chunk-based storage backend of the Hub. Files are reconstructed
+// from content-defined chunks, which are deduplicated against a local chunk cache, so revisions and fine-tunes
+// sharing most of their weights only download the chunks that changed.
+//
+// The downloader is implemented by pkg/xet on top of the xet-core library (see xet.NewHubDownloader). The hub only
+// depends on this interface, so that it keeps building without cgo.
+type XetDownloader interface {
+	// DownloadFile downloads a file of a repository into req.LocalDir and returns its path. The downloaded bytes are
+	// reported to progress. Implementations must be safe for concurrent use.
+	DownloadFile(ctx context.Context, req *XetFileRequest, progress Progress) (string, error)
+}
+
+// XetFileRequest describes a file to download from Xet
+type XetFileRequest struct {
+	Endpoint string
+	Token    string
+	RepoID   string
+	RepoType string
+	Revision string
+	Filename string
+	LocalDir string
+	// Size is the size of the file in bytes
+	Size int64
+	// XetHash is the hash of the file on Xet
+	XetHash string
+}
+
+// IsXetDisabled checks if Xet downloads are disabled through the environment
+func IsXetDisabled() bool {
+	return isTrue(os.Getenv(EnvHfHubDisableXet))
+}
+
+// IsXetRepo reports whether a repository stores files on Xet, given its files
+func IsXetRepo(files []RepoFile) bool {
+	for _, file := range files {
+		if file.XetHash != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// xetDownloaderFromContext returns the Xet downloader of the hub configuration of the context, nil when none is
+// configured or Xet downloads are disabled
+func xetDownloaderFromContext(ctx context.Context) XetDownloader {
+	hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig)
+	if !ok || !hubConfig.EnableXet || IsXetDisabled() {
+		return nil
+	}
+	return hubConfig.XetDownloader
+}
+
+// xetDownload downloads a file of a snapshot through the Xet downloader, reporting progress like HTTP downloads do
+func xetDownload(ctx context.Context, downloader XetDownloader, config *DownloadConfig, file RepoFile) (string, error) {
+	enableProgress := true
+	if hubConfig, ok := ctx.Value(HubConfigKey).(*HubConfig); ok {
+		enableProgress = hubConfig.ShouldEnableProgress()
+	}
+
+	repoType := config.RepoType
+	if repoType == "" {
+		repoType = RepoTypeModel
+	}
+	revision := config.Revision
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	// Xet downloads cannot fail over, they go to the healthiest endpoint and fall back to HTTP downloads which do
+	endpoint := firstEndpoint(ctx, config)
+	if endpoint.URL == "" {
+		endpoint.URL = DefaultEndpoint
+	}
+
+	progress := NewProgress(file.Path, file.Size, enableProgress)
+	filePath, err := downloader.DownloadFile(ctx, &XetFileRequest{
+		Endpoint: endpoint.URL,
+		Token:    endpoint.Token,
+		RepoID:   config.RepoID,
+		RepoType: repoType,
+		Revision: revision,
+		Filename: file.Path,
+		LocalDir: config.LocalDir,
+		Size:     file.Size,
+		XetHash:  file.XetHash,
+	}, progress)
+	if err != nil {
+		return "", err
+	}
+	progress.Finish()
+
+	// Chunks are verified as they are downloaded, check that the reconstructed file is complete
+	size, err := GetFileSize(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+	if size != file.Size {
+		_ = os.Remove(filePath)
+		return "", fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", file.Path, file.Size, size)
+	}
+	return filePath, nil
+}
+
+// adoptXetFile moves a file downloaded from Xet into the blob store. Chunks are verified, not the files, so the
+// file is verified against the SHA256 of its LFS pointer first
+func adoptXetFile(ctx context.Context, file RepoFile, filePath string) {
+	store := blobStoreFromContext(ctx)
+	if store == nil || file.LFS == nil {
+		return
+	}
+	if err := store.Adopt(file.LFS.OID, filePath); err != nil {
+		logBlobStoreError(ctx, file.Path, "Failed to move file into the blob store", err)
+	}
+}
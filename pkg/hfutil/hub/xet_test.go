@@ -0,0 +1,138 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeXetDownloader writes files of the requested size, or fails
+type fakeXetDownloader struct {
+	mu       sync.Mutex
+	requests []XetFileRequest
+	err      error
+	size     int64
+}
+
+func (d *fakeXetDownloader) DownloadFile(ctx context.Context, req *XetFileRequest, progress Progress) (string, error) {
+	d.mu.Lock()
+	d.requests = append(d.requests, *req)
+	d.mu.Unlock()
+	if d.err != nil {
+		return "", d.err
+	}
+
+	size := req.Size
+	if d.size > 0 {
+		size = d.size
+	}
+	filePath := filepath.Join(req.LocalDir, req.Filename)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filePath, make([]byte, size), 0644); err != nil {
+		return "", err
+	}
+	progress.Update(size)
+	return filePath, nil
+}
+
+func (d *fakeXetDownloader) downloaded() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var files []string
+	for _, req := range d.requests {
+		files = append(files, req.Filename)
+	}
+	return files
+}
+
+func TestIsXetRepo(t *testing.T) {
+	assert.False(t, IsXetRepo(nil))
+	assert.False(t, IsXetRepo([]RepoFile{{Path: "config.json", Type: "file"}}))
+	assert.True(t, IsXetRepo([]RepoFile{
+		{Path: "config.json", Type: "file"},
+		{Path: "model.safetensors", Type: "file", XetHash: "abc"},
+	}))
+}
+
+func TestSnapshotDownloadXet(t *testing.T) {
+	// The mock server serves files of 100 bytes
+	testFiles := []RepoFile{
+		{Path: "config.json", Size: 100, Type: "file"},
+		{Path: "model-00001.safetensors", Size: 100, Type: "file", XetHash: "hash1"},
+		{Path: "model-00002.safetensors", Size: 100, Type: "file", XetHash: "hash2"},
+	}
+
+	tests := []struct {
+		name        string
+		downloader  *fakeXetDownloader
+		disableXet  bool
+		expectedXet []string
+	}{
+		{
+			name:        "xet files are downloaded through xet",
+			downloader:  &fakeXetDownloader{},
+			expectedXet: []string{"model-00001.safetensors", "model-00002.safetensors"},
+		},
+		{
+			name:        "xet failures fall back to http",
+			downloader:  &fakeXetDownloader{err: errors.New("xet unavailable")},
+			expectedXet: []string{"model-00001.safetensors", "model-00002.safetensors"},
+		},
+		{
+			name:        "incomplete xet files fall back to http",
+			downloader:  &fakeXetDownloader{size: 10},
+			expectedXet: []string{"model-00001.safetensors", "model-00002.safetensors"},
+		},
+		{
+			name:       "disabled xet is not used",
+			downloader: &fakeXetDownloader{},
+			disableXet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createMockRepoAndFileServer(t, testFiles, 200)
+			defer server.Close()
+
+			tmpDir := t.TempDir()
+			config := &DownloadConfig{
+				RepoID:     "test/repo",
+				LocalDir:   tmpDir,
+				Endpoint:   server.URL,
+				MaxWorkers: 2,
+			}
+			hubConfig := &HubConfig{
+				MaxWorkers:    2,
+				EnableXet:     !tt.disableXet,
+				XetDownloader: tt.downloader,
+			}
+			ctx := context.WithValue(context.Background(), HubConfigKey, hubConfig)
+
+			result, err := SnapshotDownload(ctx, config)
+			require.NoError(t, err)
+			assert.Equal(t, tmpDir, result)
+
+			assert.ElementsMatch(t, tt.expectedXet, tt.downloader.downloaded())
+			for _, file := range testFiles {
+				size, err := GetFileSize(filepath.Join(tmpDir, file.Path))
+				require.NoError(t, err, "File %s should exist", file.Path)
+				assert.Equal(t, file.Size, size)
+			}
+			for _, req := range tt.downloader.requests {
+				assert.Equal(t, "test/repo", req.RepoID)
+				assert.Equal(t, DefaultRevision, req.Revision)
+				assert.Equal(t, server.URL, req.Endpoint)
+				assert.NotEmpty(t, req.XetHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// AttentionConfig is the attention geometry of a model, which determines the size of its KV cache
+type AttentionConfig struct {
+	// NumLayers is the number of decoder layers, each holding its own KV cache
+	NumLayers int `json:"num_hidden_layers,omitempty"`
+	// NumKeyValueHeads is the number of key and value heads, fewer than the attention heads with grouped-query attention
+	NumKeyValueHeads int `json:"num_key_value_heads,omitempty"`
+	// HeadDim is the dimension of each attention head
+	HeadDim int `json:"head_dim,omitempty"`
+	// KVLoraRank is the rank of the compressed KV cache of multi-head latent attention, such as DeepSeek V3
+	KVLoraRank int `json:"kv_lora_rank,omitempty"`
+	// QKRopeHeadDim is the dimension of the rotary key cached next to the compressed KV of multi-head latent attention
+	QKRopeHeadDim int `json:"qk_rope_head_dim,omitempty"`
+}
+
+// attentionFields holds the config.json fields the attention geometry is read from
+type attentionFields struct {
+	NumHiddenLayers   int              `json:"num_hidden_layers"`
+	NumAttentionHeads int              `json:"num_attention_heads"`
+	NumKeyValueHeads  int              `json:"num_key_value_heads"`
+	HeadDim           int              `json:"head_dim"`
+	HiddenSize        int              `json:"hidden_size"`
+	KVLoraRank        int              `json:"kv_lora_rank"`
+	QKRopeHeadDim     int              `json:"qk_rope_head_dim"`
+	TextConfig        *attentionFields `json:"text_config"`
+}
+
+// LoadAttentionConfig reads the attention geometry from a model config file. Multimodal models keep it in their
+// text_config. As in transformers, the number of KV heads defaults to the number of attention heads and the head
+// dimension to the hidden size divided by the attention heads.
+func LoadAttentionConfig(configPath string) (*AttentionConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model config file '%s': %w", configPath, err)
+	}
+
+	var fields attentionFields
+	if err := json.Unmarshal(SanitizeJSONBytes(data), &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse model config JSON from '%s': %w", configPath, err)
+	}
+	if fields.NumHiddenLayers == 0 && fields.TextConfig != nil {
+		fields = *fields.TextConfig
+	}
+	if fields.NumHiddenLayers <= 0 {
+		return nil, fmt.Errorf("model config '%s' does not report num_hidden_layers", configPath)
+	}
+
+	attention := &AttentionConfig{NumLayers: fields.NumHiddenLayers}
+	if fields.KVLoraRank > 0 {
+		attention.KVLoraRank = fields.KVLoraRank
+		attention.QKRopeHeadDim = fields.QKRopeHeadDim
+		return attention, nil
+	}
+
+	if fields.NumAttentionHeads <= 0 {
+		return nil, fmt.Errorf("model config '%s' does not report num_attention_heads", configPath)
+	}
+	attention.NumKeyValueHeads = fields.NumKeyValueHeads
+	if attention.NumKeyValueHeads <= 0 {
+		attention.NumKeyValueHeads = fields.NumAttentionHeads
+	}
+	attention.HeadDim = fields.HeadDim
+	if attention.HeadDim <= 0 {
+		attention.HeadDim = fields.HiddenSize / fields.NumAttentionHeads
+	}
+	if attention.HeadDim <= 0 {
+		return nil, fmt.Errorf("model config '%s' does not report head_dim or hidden_size", configPath)
+	}
+	return attention, nil
+}
@@ -0,0 +1,81 @@
+package modelconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAttentionConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected AttentionConfig
+	}{
+		{
+			name:     "grouped-query attention derives the head dimension",
+			file:     "llama3_1_405b.json",
+			expected: AttentionConfig{NumLayers: 126, NumKeyValueHeads: 8, HeadDim: 128},
+		},
+		{
+			name:     "multimodal model reads its text config",
+			file:     "llama3_2_11b_vision.json",
+			expected: AttentionConfig{NumLayers: 40, NumKeyValueHeads: 8, HeadDim: 128},
+		},
+		{
+			name:     "explicit head dimension",
+			file:     "gemma3.json",
+			expected: AttentionConfig{NumLayers: 62, NumKeyValueHeads: 16, HeadDim: 128},
+		},
+		{
+			name:     "multi-head attention uses every attention head",
+			file:     "bge_large.json",
+			expected: AttentionConfig{NumLayers: 24, NumKeyValueHeads: 16, HeadDim: 64},
+		},
+		{
+			name:     "multi-head latent attention",
+			file:     "deepseek_v3.json",
+			expected: AttentionConfig{NumLayers: 61, KVLoraRank: 512, QKRopeHeadDim: 64},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attention, err := LoadAttentionConfig(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatalf("Failed to load attention config: %v", err)
+			}
+			if *attention != tt.expected {
+				t.Errorf("Expected attention config %+v but got %+v", tt.expected, *attention)
+			}
+		})
+	}
+}
+
+func TestLoadAttentionConfigUnknown(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "no layers", config: `{"num_attention_heads": 32, "hidden_size": 4096}`},
+		{name: "no attention heads", config: `{"num_hidden_layers": 32, "hidden_size": 4096}`},
+		{name: "no head dimension", config: `{"num_hidden_layers": 32, "num_attention_heads": 32}`},
+		{name: "invalid JSON", config: `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tt.config), 0o600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			if _, err := LoadAttentionConfig(configPath); err == nil {
+				t.Errorf("Expected an error for %s", tt.config)
+			}
+		})
+	}
+
+	if _, err := LoadAttentionConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing config file")
+	}
+}
@@ -0,0 +1,83 @@
+package modelconfig
+
+import (
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// ModelCapabilities determines the capabilities of a BaseModel serving the given model
+func ModelCapabilities(hfModel HuggingFaceModel) []string {
+	var capabilities []string
+	architecture := hfModel.GetArchitecture()
+	modelType := hfModel.GetModelType()
+
+	normalizedArchitecture := strings.ToLower(architecture)
+	normalizedModelType := strings.ToLower(modelType)
+
+	// Tested against 90+ models.
+	if dm, ok := hfModel.(HuggingFaceDiffusionModel); ok {
+		pipeline := dm.GetDiffusionModel()
+		if pipeline == nil {
+			return capabilities
+		}
+		if strings.Contains(normalizedArchitecture, "imageedit") ||
+			strings.Contains(normalizedArchitecture, "pix2pix") ||
+			strings.Contains(normalizedArchitecture, "img2img") ||
+			strings.Contains(normalizedArchitecture, "inpaint") {
+			return append(capabilities, string(v1beta1.ModelCapabilityImageTextToImage))
+		}
+		if strings.Contains(normalizedArchitecture, "image") ||
+			strings.Contains(normalizedArchitecture, "pix") ||
+			strings.Contains(normalizedArchitecture, "stablediffusion") {
+			return append(capabilities, string(v1beta1.ModelCapabilityTextToImage))
+		}
+		if strings.Contains(normalizedArchitecture, "texttovideo") ||
+			strings.Contains(normalizedArchitecture, "t2v") {
+			return append(capabilities, string(v1beta1.ModelCapabilityTextToVideo))
+		}
+		if strings.Contains(normalizedArchitecture, "video") {
+			return append(capabilities, string(v1beta1.ModelCapabilityImageTextToVideo))
+		}
+	}
+
+	// For vision, only support image text capability right now
+	if hfModel.HasVision() {
+		return append(capabilities, string(v1beta1.ModelCapabilityImageTextToText))
+	}
+
+	// Check for omni-model capability
+	if strings.Contains(normalizedArchitecture, "omni") {
+		return append(capabilities,
+			string(v1beta1.ModelCapabilityTextToAudio), string(v1beta1.ModelCapabilityImageTextToAudio),
+			string(v1beta1.ModelCapabilityVideoTextToAudio), string(v1beta1.ModelCapabilityAudioToText),
+			string(v1beta1.ModelCapabilityAudioToAudio))
+	}
+
+	// Check for text embedding capability
+	if hfModel.IsEmbedding() ||
+		strings.Contains(normalizedArchitecture, "embedding") ||
+		strings.Contains(normalizedArchitecture, "sentence") ||
+		strings.Contains(normalizedModelType, "bert") ||
+		// Special case for known embedding models
+		(strings.Contains(normalizedModelType, "mistral") &&
+			strings.Contains(normalizedArchitecture, "mistralmodel")) {
+		return append(capabilities, string(v1beta1.ModelCapabilityEmbedding))
+	}
+
+	// Default to text-to-text capability
+	return append(capabilities, string(v1beta1.ModelCapabilityTextToText))
+}
+
+// ModelQuantization maps the quantization method of the model to the quantization of a BaseModel,
+// empty when the model is not quantized or the method has no counterpart
+func ModelQuantization(hfModel HuggingFaceModel) v1beta1.ModelQuantization {
+	quantType := strings.ToLower(hfModel.GetQuantizationType())
+	switch {
+	case strings.Contains(quantType, "int4"):
+		return v1beta1.ModelQuantizationINT4
+	case strings.Contains(quantType, "fp8"):
+		return v1beta1.ModelQuantizationFP8
+	}
+	return ""
+}
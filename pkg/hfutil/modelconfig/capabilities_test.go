@@ -0,0 +1,55 @@
+package modelconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestModelCapabilities(t *testing.T) {
+	tests := []struct {
+		name                 string
+		configPath           string
+		expectedCapabilities []string
+		expectedQuantization v1beta1.ModelQuantization
+	}{
+		{
+			name:                 "Llama 3.2 1B",
+			configPath:           "testdata/llama3_2_1b.json",
+			expectedCapabilities: []string{string(v1beta1.ModelCapabilityTextToText)},
+		},
+		{
+			name:                 "Qwen2-VL 7B",
+			configPath:           "testdata/qwen2_vl_7b.json",
+			expectedCapabilities: []string{string(v1beta1.ModelCapabilityImageTextToText)},
+		},
+		{
+			name:                 "BGE Large",
+			configPath:           "testdata/bge_large.json",
+			expectedCapabilities: []string{string(v1beta1.ModelCapabilityEmbedding)},
+		},
+		{
+			name:                 "Kimi K2 Instruct",
+			configPath:           "testdata/kimi_k2_instruct.json",
+			expectedCapabilities: []string{string(v1beta1.ModelCapabilityTextToText)},
+			expectedQuantization: v1beta1.ModelQuantizationFP8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := LoadModelConfig(tt.configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+
+			if capabilities := ModelCapabilities(model); !reflect.DeepEqual(capabilities, tt.expectedCapabilities) {
+				t.Errorf("Expected capabilities %v, got %v", tt.expectedCapabilities, capabilities)
+			}
+			if quantization := ModelQuantization(model); quantization != tt.expectedQuantization {
+				t.Errorf("Expected quantization %q, got %q", tt.expectedQuantization, quantization)
+			}
+		})
+	}
+}
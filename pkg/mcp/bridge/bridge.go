@@ -0,0 +1,259 @@
+// Package bridge exposes an MCP server speaking the stdio transport over the streamable HTTP transport.
+package bridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// SessionHeader carries the MCP session ID of streamable HTTP connections
+	SessionHeader = "Mcp-Session-Id"
+
+	maxMessageBytes = 10 << 20
+)
+
+// message is the part of a JSON-RPC message the bridge needs to correlate requests and responses
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+}
+
+func (m *message) isRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// Bridge forwards JSON-RPC messages between HTTP clients and a stdio MCP server.
+// Request IDs are rewritten so clients sharing the server cannot collide.
+type Bridge struct {
+	logger *zap.SugaredLogger
+
+	writeMu sync.Mutex
+	stdin   io.Writer
+
+	mu          sync.Mutex
+	nextID      int64
+	pending     map[string]chan json.RawMessage
+	subscribers map[chan []byte]struct{}
+	sessionID   string
+}
+
+// New creates a bridge writing to the stdin and reading from the stdout of an MCP server
+func New(stdin io.Writer, logger *zap.SugaredLogger) *Bridge {
+	return &Bridge{
+		logger:      logger,
+		stdin:       stdin,
+		pending:     map[string]chan json.RawMessage{},
+		subscribers: map[chan []byte]struct{}{},
+		sessionID:   uuid.NewString(),
+	}
+}
+
+// Serve dispatches the newline delimited messages the server writes to stdout until it is closed
+func (b *Bridge) Serve(stdout io.Reader) error {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageBytes)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		b.dispatch(append([]byte(nil), line...))
+	}
+	return scanner.Err()
+}
+
+// dispatch delivers a server message: responses go to the waiting request, everything else to stream subscribers
+func (b *Bridge) dispatch(raw []byte) {
+	msg := &message{}
+	if err := json.Unmarshal(raw, msg); err != nil {
+		b.logger.Warnw("Ignoring malformed message from server", "error", err)
+		return
+	}
+	if msg.Method == "" && len(msg.ID) > 0 {
+		b.mu.Lock()
+		ch, ok := b.pending[string(msg.ID)]
+		delete(b.pending, string(msg.ID))
+		b.mu.Unlock()
+		if ok {
+			ch <- raw
+			return
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		select {
+		case sub <- raw:
+		default:
+			b.logger.Warn("Dropping server message for slow stream subscriber")
+		}
+	}
+}
+
+// ServeHTTP implements the streamable HTTP transport
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		b.handlePost(w, r)
+	case http.MethodGet:
+		b.handleStream(w, r)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *Bridge) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageBytes))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	body = bytes.TrimSpace(body)
+
+	var batch []json.RawMessage
+	isBatch := len(body) > 0 && body[0] == '['
+	if isBatch {
+		if err := json.Unmarshal(body, &batch); err != nil {
+			http.Error(w, "invalid JSON-RPC batch", http.StatusBadRequest)
+			return
+		}
+	} else {
+		batch = []json.RawMessage{body}
+	}
+
+	var responses []json.RawMessage
+	initialize := false
+	for _, raw := range batch {
+		msg := &message{}
+		if err := json.Unmarshal(raw, msg); err != nil {
+			http.Error(w, "invalid JSON-RPC message", http.StatusBadRequest)
+			return
+		}
+		if msg.Method == "initialize" {
+			initialize = true
+		}
+		if !msg.isRequest() {
+			if err := b.write(raw); err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			continue
+		}
+		resp, err := b.call(r, msg.ID, raw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		responses = append(responses, resp)
+	}
+
+	if initialize {
+		w.Header().Set(SessionHeader, b.sessionID)
+	}
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if isBatch {
+		_ = json.NewEncoder(w).Encode(responses)
+		return
+	}
+	_, _ = w.Write(responses[0])
+}
+
+// call forwards a request under a bridge-assigned ID and waits for its response
+func (b *Bridge) call(r *http.Request, clientID json.RawMessage, raw json.RawMessage) (json.RawMessage, error) {
+	b.mu.Lock()
+	b.nextID++
+	serverID := json.RawMessage(strconv.FormatInt(b.nextID, 10))
+	ch := make(chan json.RawMessage, 1)
+	b.pending[string(serverID)] = ch
+	b.mu.Unlock()
+
+	forwarded, err := replaceID(raw, serverID)
+	if err == nil {
+		err = b.write(forwarded)
+	}
+	if err != nil {
+		b.mu.Lock()
+		delete(b.pending, string(serverID))
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		return replaceID(resp, clientID)
+	case <-r.Context().Done():
+		b.mu.Lock()
+		delete(b.pending, string(serverID))
+		b.mu.Unlock()
+		return nil, r.Context().Err()
+	}
+}
+
+func (b *Bridge) write(raw []byte) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+	if _, err := b.stdin.Write(append(bytes.TrimSpace(raw), '\n')); err != nil {
+		return fmt.Errorf("failed to write to MCP server: %w", err)
+	}
+	return nil
+}
+
+// handleStream sends server-initiated messages to the client as server-sent events
+func (b *Bridge) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	sub := make(chan []byte, 64)
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		b.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-sub:
+			if _, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// replaceID returns a JSON-RPC message with its ID replaced
+func replaceID(raw json.RawMessage, id json.RawMessage) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	fields["id"] = id
+	return json.Marshal(fields)
+}
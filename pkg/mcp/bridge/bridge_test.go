@@ -0,0 +1,88 @@
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// echoServer is a stdio MCP server stub answering every request with the method it received
+func echoServer(t *testing.T, stdin io.Reader, stdout io.WriteCloser) {
+	defer stdout.Close()
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		msg := map[string]json.RawMessage{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		if _, ok := msg["id"]; !ok {
+			_, _ = stdout.Write([]byte(`{"jsonrpc":"2.0","method":"notifications/message","params":{}}` + "\n"))
+			continue
+		}
+		resp, _ := json.Marshal(map[string]json.RawMessage{
+			"jsonrpc": json.RawMessage(`"2.0"`),
+			"id":      msg["id"],
+			"result":  msg["method"],
+		})
+		_, _ = stdout.Write(append(resp, '\n'))
+	}
+}
+
+func TestBridge(t *testing.T) {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	go echoServer(t, stdinReader, stdoutWriter)
+
+	b := New(stdinWriter, zap.NewNop().Sugar())
+	go func() { _ = b.Serve(stdoutReader) }()
+	defer stdinWriter.Close()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		b.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("initialize returns a session", func(t *testing.T) {
+		rec := post(`{"jsonrpc":"2.0","id":"init","method":"initialize"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get(SessionHeader))
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":"init","result":"initialize"}`, rec.Body.String())
+	})
+
+	t.Run("requests keep the client ID", func(t *testing.T) {
+		rec := post(`{"jsonrpc":"2.0","id":7,"method":"tools/list"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(SessionHeader))
+		assert.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":"tools/list"}`, rec.Body.String())
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		rec := post(`[{"jsonrpc":"2.0","id":1,"method":"tools/list"},{"jsonrpc":"2.0","id":1,"method":"prompts/list"}]`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"jsonrpc":"2.0","id":1,"result":"tools/list"},{"jsonrpc":"2.0","id":1,"result":"prompts/list"}]`,
+			rec.Body.String())
+	})
+
+	t.Run("notifications are accepted", func(t *testing.T) {
+		rec := post(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("invalid messages", func(t *testing.T) {
+		rec := post(`not json`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestReplaceID(t *testing.T) {
+	replaced, err := replaceID(json.RawMessage(`{"jsonrpc":"2.0","id":1,"result":{}}`), json.RawMessage(`"abc"`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":"abc","result":{}}`, string(replaced))
+}
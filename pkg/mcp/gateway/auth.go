@@ -0,0 +1,365 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+const (
+	// jwksRefreshInterval is how long fetched signing keys are cached
+	jwksRefreshInterval = 10 * time.Minute
+	// jwksMinRefreshInterval limits refetches triggered by tokens signed with unknown keys
+	jwksMinRefreshInterval = time.Minute
+	// anonymousPrincipal identifies clients of routes without authentication
+	anonymousPrincipal = "anonymous"
+)
+
+var errUnauthenticated = errors.New("unauthenticated")
+
+// identity is the authenticated client of a request
+type identity struct {
+	// principals the client authenticated as, such as "user:alice" and "group:developers"
+	principals []string
+}
+
+// user returns the first principal of the identity
+func (i *identity) user() string {
+	if len(i.principals) == 0 {
+		return anonymousPrincipal
+	}
+	return i.principals[0]
+}
+
+// authenticator verifies the credentials of requests
+type authenticator struct {
+	client *http.Client
+
+	mu   sync.Mutex
+	keys map[string]*keySet
+}
+
+// keySet is a cached JSON Web Key Set
+type keySet struct {
+	keys    map[string]interface{}
+	fetched time.Time
+}
+
+func newAuthenticator(client *http.Client) *authenticator {
+	return &authenticator{client: client, keys: map[string]*keySet{}}
+}
+
+// authenticate verifies a request against the authentication policy of a route.
+// Every configured method must succeed, and the principals of all methods are combined.
+func (a *authenticator) authenticate(ctx context.Context, auth *Authentication, r *http.Request) (*identity, error) {
+	id := &identity{}
+	if auth == nil {
+		return id, nil
+	}
+	if auth.APIKey != nil {
+		principal, err := authenticateAPIKey(auth.APIKey, r)
+		if err != nil {
+			return nil, err
+		}
+		id.principals = append(id.principals, principal)
+	}
+	if auth.JWT != nil {
+		principals, err := a.authenticateToken(ctx, r, auth.JWT.JWKSURI, auth.JWT.Issuer, auth.JWT.Audiences)
+		if err != nil {
+			return nil, err
+		}
+		id.principals = append(id.principals, principals...)
+	}
+	if auth.OIDC != nil {
+		jwksURI, err := a.discoverJWKSURI(ctx, auth.OIDC.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		issuer := auth.OIDC.Issuer
+		principals, err := a.authenticateToken(ctx, r, jwksURI, &issuer, []string{auth.OIDC.ClientID})
+		if err != nil {
+			return nil, err
+		}
+		id.principals = append(id.principals, principals...)
+	}
+	return id, nil
+}
+
+func authenticateAPIKey(apiKey *APIKey, r *http.Request) (string, error) {
+	provided := r.Header.Get(apiKey.Header)
+	if provided == "" {
+		return "", fmt.Errorf("%w: missing %s header", errUnauthenticated, apiKey.Header)
+	}
+	for key, principal := range apiKey.Keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(provided)) == 1 {
+			return principal, nil
+		}
+	}
+	return "", fmt.Errorf("%w: invalid API key", errUnauthenticated)
+}
+
+// authenticateToken verifies the bearer token of a request and returns its principals
+func (a *authenticator) authenticateToken(ctx context.Context, r *http.Request, jwksURI string, issuer *string,
+	audiences []string) ([]string, error) {
+	raw, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || raw == "" {
+		return nil, fmt.Errorf("%w: missing bearer token", errUnauthenticated)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}),
+	}
+	if issuer != nil {
+		options = append(options, jwt.WithIssuer(*issuer))
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return a.signingKey(ctx, jwksURI, kid)
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnauthenticated, err)
+	}
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return nil, fmt.Errorf("%w: token has no expiration", errUnauthenticated)
+	}
+	if !matchAudience(claims, audiences) {
+		return nil, fmt.Errorf("%w: token audience is not accepted", errUnauthenticated)
+	}
+	return tokenPrincipals(claims), nil
+}
+
+func matchAudience(claims jwt.MapClaims, audiences []string) bool {
+	tokenAudiences, err := claims.GetAudience()
+	if err != nil {
+		return false
+	}
+	for _, accepted := range audiences {
+		for _, aud := range tokenAudiences {
+			if aud == accepted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tokenPrincipals returns the subject of a token as a user principal followed by its groups
+func tokenPrincipals(claims jwt.MapClaims) []string {
+	var principals []string
+	if sub, err := claims.GetSubject(); err == nil && sub != "" {
+		principals = append(principals, "user:"+sub)
+	}
+	if groups, ok := claims["groups"].([]interface{}); ok {
+		for _, group := range groups {
+			if name, ok := group.(string); ok && name != "" {
+				principals = append(principals, "group:"+name)
+			}
+		}
+	}
+	return principals
+}
+
+// signingKey returns the key with the given ID from a key set, refetching the set when it is stale or the key is unknown
+func (a *authenticator) signingKey(ctx context.Context, jwksURI, kid string) (interface{}, error) {
+	a.mu.Lock()
+	set := a.keys[jwksURI]
+	a.mu.Unlock()
+
+	if set == nil || time.Since(set.fetched) > jwksRefreshInterval ||
+		(lookupKey(set, kid) == nil && time.Since(set.fetched) > jwksMinRefreshInterval) {
+		fetched, err := a.fetchKeySet(ctx, jwksURI)
+		if err != nil {
+			return nil, err
+		}
+		a.mu.Lock()
+		a.keys[jwksURI] = fetched
+		a.mu.Unlock()
+		set = fetched
+	}
+	if key := lookupKey(set, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// lookupKey returns the key with the given ID, or the only key of the set when the token has no key ID
+func lookupKey(set *keySet, kid string) interface{} {
+	if kid == "" && len(set.keys) == 1 {
+		for _, key := range set.keys {
+			return key
+		}
+	}
+	return set.keys[kid]
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (a *authenticator) fetchKeySet(ctx context.Context, jwksURI string) (*keySet, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := a.getJSON(ctx, jwksURI, &doc); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	set := &keySet{keys: map[string]interface{}{}, fetched: time.Now()}
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			continue
+		}
+		set.keys[jwk.Kid] = key
+	}
+	return set, nil
+}
+
+// parseJSONWebKey converts an RSA or EC JSON Web Key to a public key
+func parseJSONWebKey(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// discoverJWKSURI returns the key set URI advertised by an OIDC provider
+func (a *authenticator) discoverJWKSURI(ctx context.Context, issuer string) (string, error) {
+	var doc struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	url := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := a.getJSON(ctx, url, &doc); err != nil {
+		return "", fmt.Errorf("failed to discover OIDC provider %s: %w", issuer, err)
+	}
+	if doc.JWKSURI == "" {
+		return "", fmt.Errorf("OIDC provider %s does not advertise a jwks_uri", issuer)
+	}
+	return doc.JWKSURI, nil
+}
+
+func (a *authenticator) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// authorize reports whether the identity may perform the method on the tool.
+// Requests are allowed when the route has no authorization policy.
+func authorize(authz *v1beta1.MCPAuthorization, id *identity, method, tool string) bool {
+	if authz == nil {
+		return true
+	}
+	for _, rule := range authz.Rules {
+		if !matchPrincipals(rule.Principals, id.principals) {
+			continue
+		}
+		for _, permission := range rule.Permissions {
+			if !containsString(permission.Actions, method) {
+				continue
+			}
+			// tools/list is not about a single tool, so any tool pattern grants it
+			if tool == "" || matchAnyTool(permission.Tools, tool) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchPrincipals(patterns, principals []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			return true
+		}
+		for _, principal := range principals {
+			if matchWildcard(pattern, principal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
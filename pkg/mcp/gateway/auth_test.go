@@ -0,0 +1,161 @@
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "key-1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	issuer = server.URL
+
+	sign := func(signingKey *rsa.PrivateKey, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "key-1"
+		signed, err := token.SignedString(signingKey)
+		require.NoError(t, err)
+		return signed
+	}
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    issuer,
+			"sub":    "alice",
+			"aud":    "mcp",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"developers"},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		auth           func() *Authentication
+		token          func() string
+		wantErr        bool
+		wantPrincipals []string
+	}{
+		{
+			name: "valid JWT",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys"}}
+			},
+			token:          func() string { return sign(key, validClaims()) },
+			wantPrincipals: []string{"user:alice", "group:developers"},
+		},
+		{
+			name: "valid OIDC token",
+			auth: func() *Authentication {
+				return &Authentication{OIDC: &OIDC{Issuer: issuer, ClientID: "mcp"}}
+			},
+			token:          func() string { return sign(key, validClaims()) },
+			wantPrincipals: []string{"user:alice", "group:developers"},
+		},
+		{
+			name: "wrong audience",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"other"}, JWKSURI: issuer + "/keys"}}
+			},
+			token:   func() string { return sign(key, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			auth: func() *Authentication {
+				wrong := "https://issuer.example.com"
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys", Issuer: &wrong}}
+			},
+			token:   func() string { return sign(key, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "expired token",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys"}}
+			},
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return sign(key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "token without expiration",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys"}}
+			},
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return sign(key, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "token signed with another key",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys"}}
+			},
+			token:   func() string { return sign(otherKey, validClaims()) },
+			wantErr: true,
+		},
+		{
+			name: "missing token",
+			auth: func() *Authentication {
+				return &Authentication{JWT: &v1beta1.JWTAuthentication{Audiences: []string{"mcp"}, JWKSURI: issuer + "/keys"}}
+			},
+			token:   func() string { return "" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAuthenticator(server.Client())
+			req := httptest.NewRequest(http.MethodPost, "/routes/team/tools", nil)
+			if token := tt.token(); token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			id, err := a.authenticate(context.Background(), tt.auth(), req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errUnauthenticated)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrincipals, id.principals)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/constants"
+)
+
+// RouteTable is the routing configuration of the gateway. It is rendered by the MCPRoute
+// controller from all MCPRoutes of a namespace and mounted into the gateway pods.
+type RouteTable struct {
+	Routes []Route `json:"routes"`
+}
+
+// Route is a resolved MCPRoute
+type Route struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Backends receive requests that match no rule
+	Backends []Backend `json:"backends"`
+	// Matches route matching requests to their own backends, in order
+	Matches []Match `json:"matches,omitempty"`
+
+	Authentication *Authentication           `json:"authentication,omitempty"`
+	Authorization  *v1beta1.MCPAuthorization `json:"authorization,omitempty"`
+	RateLimit      *v1beta1.MCPRateLimit     `json:"rateLimit,omitempty"`
+	Filters        []v1beta1.MCPRouteFilter  `json:"filters,omitempty"`
+}
+
+// Backend is a resolved MCPServer
+type Backend struct {
+	// Name of the MCPServer
+	Name string `json:"name"`
+	// URL of the MCP endpoint of the server
+	URL string `json:"url"`
+	// Weight of the backend when splitting traffic
+	Weight int32 `json:"weight"`
+	// Ready is false when the server is missing or has no ready replicas
+	Ready bool `json:"ready"`
+	// Tools restricts the tools that may be called on the backend, empty allows all tools
+	Tools []string `json:"tools,omitempty"`
+}
+
+// Match is a routing rule of a route
+type Match struct {
+	Tools     []string                 `json:"tools,omitempty"`
+	ToolMatch *v1beta1.MCPToolMatcher  `json:"toolMatch,omitempty"`
+	Method    string                   `json:"method,omitempty"`
+	Headers   []v1beta1.MCPHeaderMatch `json:"headers,omitempty"`
+	Backends  []Backend                `json:"backends"`
+}
+
+// Authentication is the authentication policy of a route with secrets resolved
+type Authentication struct {
+	APIKey *APIKey                    `json:"apiKey,omitempty"`
+	JWT    *v1beta1.JWTAuthentication `json:"jwt,omitempty"`
+	OIDC   *OIDC                      `json:"oidc,omitempty"`
+}
+
+// APIKey holds the valid API keys of a route
+type APIKey struct {
+	// Header carrying the API key
+	Header string `json:"header"`
+	// Keys maps each valid API key to the principal it authenticates as
+	Keys map[string]string `json:"keys"`
+}
+
+// OIDC validates bearer ID tokens issued by an OpenID Connect provider for the client
+type OIDC struct {
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"clientID"`
+}
+
+// RoutePath returns the gateway path of a route
+func RoutePath(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", constants.MCPGatewayRoutesPathPrefix, namespace, name)
+}
+
+// GatewayURL returns the URL clients in the cluster use to reach a route through the gateway of its namespace
+func GatewayURL(namespace, routeName string, port int32) string {
+	return fmt.Sprintf("http://%s.%s.%s:%d%s", constants.MCPGatewayName, namespace, constants.ClusterLocalDomain, port,
+		RoutePath(namespace, routeName))
+}
+
+// ParseRoutePath returns the namespace and name of the route a request path is for, and the remaining path
+func ParseRoutePath(path string) (namespace, name, rest string, ok bool) {
+	trimmed, found := strings.CutPrefix(path, constants.MCPGatewayRoutesPathPrefix+"/")
+	if !found {
+		return "", "", "", false
+	}
+	parts := strings.SplitN(trimmed, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", false
+	}
+	if len(parts) == 3 {
+		rest = "/" + parts[2]
+	}
+	return parts[0], parts[1], rest, true
+}
+
+// LoadRouteTable reads a route table from a file
+func LoadRouteTable(path string) (*RouteTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	table := &RouteTable{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("failed to parse route table %s: %w", path, err)
+	}
+	return table, nil
+}
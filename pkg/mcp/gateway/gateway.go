@@ -0,0 +1,351 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+const (
+	// SessionHeader carries the MCP session ID of streamable HTTP connections
+	SessionHeader = "Mcp-Session-Id"
+
+	// maxRequestBodyBytes bounds the JSON-RPC messages the gateway inspects
+	maxRequestBodyBytes = 10 << 20
+	// sessionIdleTimeout is how long an unused session stays pinned to its backend
+	sessionIdleTimeout = time.Hour
+)
+
+// JSON-RPC error codes returned by the gateway
+const (
+	rpcErrorInvalidRequest  = -32600
+	rpcErrorUnauthenticated = -32001
+	rpcErrorForbidden       = -32003
+	rpcErrorRateLimited     = -32029
+	rpcErrorUnavailable     = -32000
+	rpcErrorNotFound        = -32004
+)
+
+// Gateway routes MCP requests to the backends of MCPRoutes
+type Gateway struct {
+	logger *zap.SugaredLogger
+
+	mu     sync.RWMutex
+	routes map[string]*Route
+	// sessions pins MCP sessions to the backend that created them
+	sessions map[string]*session
+
+	auth      *authenticator
+	limiter   *rateLimiter
+	transport http.RoundTripper
+
+	randMu sync.Mutex
+	random *rand.Rand
+}
+
+type session struct {
+	backend  string
+	lastUsed time.Time
+}
+
+// New creates a gateway serving a route table
+func New(table *RouteTable, logger *zap.SugaredLogger) *Gateway {
+	g := &Gateway{
+		logger:    logger,
+		sessions:  map[string]*session{},
+		auth:      newAuthenticator(&http.Client{Timeout: 10 * time.Second}),
+		limiter:   newRateLimiter(time.Now),
+		transport: http.DefaultTransport,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	g.SetRouteTable(table)
+	return g
+}
+
+// SetRouteTable replaces the routes served by the gateway
+func (g *Gateway) SetRouteTable(table *RouteTable) {
+	routes := map[string]*Route{}
+	if table != nil {
+		for i := range table.Routes {
+			route := &table.Routes[i]
+			routes[routeKey(route.Namespace, route.Name)] = route
+		}
+	}
+	g.mu.Lock()
+	g.routes = routes
+	g.mu.Unlock()
+}
+
+// WatchRouteTable reloads the route table whenever the file changes, until the context is done
+func (g *Gateway) WatchRouteTable(ctx context.Context, path string, interval time.Duration) {
+	var lastModified time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastModified = info.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		g.evictSessions(time.Now())
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().After(lastModified) {
+			continue
+		}
+		table, err := LoadRouteTable(path)
+		if err != nil {
+			g.logger.Errorw("Failed to reload route table", "path", path, "error", err)
+			continue
+		}
+		lastModified = info.ModTime()
+		g.SetRouteTable(table)
+		g.logger.Infow("Reloaded route table", "routes", len(table.Routes))
+	}
+}
+
+// ServeHTTP handles MCP requests for /routes/{namespace}/{name}
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace, name, _, ok := ParseRoutePath(r.URL.Path)
+	if !ok {
+		writeRPCError(w, http.StatusNotFound, nil, rpcErrorNotFound, "unknown path")
+		return
+	}
+	g.mu.RLock()
+	route := g.routes[routeKey(namespace, name)]
+	g.mu.RUnlock()
+	if route == nil {
+		writeRPCError(w, http.StatusNotFound, nil, rpcErrorNotFound, fmt.Sprintf("route %s/%s not found", namespace, name))
+		return
+	}
+
+	var body []byte
+	if r.Body != nil && r.Method == http.MethodPost {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(r.Body, maxRequestBodyBytes+1))
+		if err != nil {
+			writeRPCError(w, http.StatusBadRequest, nil, rpcErrorUnavailable, "failed to read request")
+			return
+		}
+		if len(body) > maxRequestBodyBytes {
+			writeRPCError(w, http.StatusRequestEntityTooLarge, nil, rpcErrorUnavailable, "request too large")
+			return
+		}
+	}
+	req, err := parseRPCRequest(body)
+	if err != nil {
+		writeRPCError(w, http.StatusBadRequest, nil, rpcErrorInvalidRequest, err.Error())
+		return
+	}
+	tool := req.Tool()
+
+	id, err := g.auth.authenticate(r.Context(), route.Authentication, r)
+	if err != nil {
+		g.logger.Debugw("Rejected unauthenticated request", "route", name, "namespace", namespace, "error", err)
+		writeRPCError(w, http.StatusUnauthorized, req.ID, rpcErrorUnauthenticated, "unauthenticated")
+		return
+	}
+	if (req.Method == methodToolsCall || req.Method == methodToolsList) &&
+		!authorize(route.Authorization, id, req.Method, tool) {
+		writeRPCError(w, http.StatusForbidden, req.ID, rpcErrorForbidden,
+			fmt.Sprintf("%s is not allowed to call %s", id.user(), describeCall(req.Method, tool)))
+		return
+	}
+
+	principals := append([]string(nil), id.principals...)
+	sort.Strings(principals)
+	if !g.limiter.allow(route.RateLimit, rateLimitRequest{
+		route:     routeKey(namespace, name),
+		namespace: namespace,
+		user:      id.user(),
+		principal: strings.Join(principals, ","),
+		ip:        clientIP(r),
+		tool:      tool,
+	}) {
+		writeRPCError(w, http.StatusTooManyRequests, req.ID, rpcErrorRateLimited, "rate limit exceeded")
+		return
+	}
+
+	backend, ok := g.selectBackend(route, req, r)
+	if !ok {
+		writeRPCError(w, http.StatusServiceUnavailable, req.ID, rpcErrorUnavailable, "no ready backend")
+		return
+	}
+	g.proxy(w, r, route, backend, body)
+}
+
+// selectBackend returns the backend of the request session, or picks one from the matching rule
+func (g *Gateway) selectBackend(route *Route, req *rpcRequest, r *http.Request) (*Backend, bool) {
+	backends := selectBackends(route, req, r.Header)
+	if sessionID := r.Header.Get(SessionHeader); sessionID != "" {
+		g.mu.Lock()
+		s := g.sessions[sessionKey(route, sessionID)]
+		if s != nil {
+			s.lastUsed = time.Now()
+		}
+		g.mu.Unlock()
+		// Sessions stay on their backend as long as it is ready and may serve the request
+		if s != nil {
+			for i := range backends {
+				if backends[i].Name == s.backend && backends[i].Ready {
+					return &backends[i], true
+				}
+			}
+		}
+	}
+	g.randMu.Lock()
+	defer g.randMu.Unlock()
+	return pickBackend(backends, req.Tool(), g.random)
+}
+
+func (g *Gateway) proxy(w http.ResponseWriter, r *http.Request, route *Route, backend *Backend, body []byte) {
+	target, err := url.Parse(backend.URL)
+	if err != nil {
+		writeRPCError(w, http.StatusBadGateway, nil, rpcErrorUnavailable, "invalid backend URL")
+		return
+	}
+	sessionID := r.Header.Get(SessionHeader)
+	proxy := &httputil.ReverseProxy{
+		// Stream server-sent events as they arrive
+		FlushInterval: -1,
+		Transport:     g.transport,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = target.Scheme
+			pr.Out.URL.Host = target.Host
+			pr.Out.URL.Path = target.Path
+			pr.Out.URL.RawPath = target.RawPath
+			pr.Out.URL.RawQuery = r.URL.RawQuery
+			pr.Out.Host = target.Host
+			pr.SetXForwarded()
+			applyHeaderFilters(pr.Out.Header, route.Filters, v1beta1.MCPRequestHeaderModifier)
+			if body != nil {
+				pr.Out.Body = io.NopCloser(bytes.NewReader(body))
+				pr.Out.ContentLength = int64(len(body))
+			}
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			applyHeaderFilters(resp.Header, route.Filters, v1beta1.MCPResponseHeaderModifier)
+			if created := resp.Header.Get(SessionHeader); created != "" && resp.StatusCode < 300 {
+				g.pinSession(route, created, backend.Name)
+			}
+			if r.Method == http.MethodDelete && sessionID != "" {
+				g.forgetSession(route, sessionID)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			g.logger.Warnw("Backend request failed", "route", route.Name, "namespace", route.Namespace,
+				"backend", backend.Name, "error", err)
+			writeRPCError(w, http.StatusBadGateway, nil, rpcErrorUnavailable, "backend unavailable")
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+func (g *Gateway) pinSession(route *Route, sessionID, backend string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sessions[sessionKey(route, sessionID)] = &session{backend: backend, lastUsed: time.Now()}
+}
+
+func (g *Gateway) forgetSession(route *Route, sessionID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.sessions, sessionKey(route, sessionID))
+}
+
+func (g *Gateway) evictSessions(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, s := range g.sessions {
+		if now.Sub(s.lastUsed) > sessionIdleTimeout {
+			delete(g.sessions, key)
+		}
+	}
+}
+
+// applyHeaderFilters applies the header modifiers of the given filter type
+func applyHeaderFilters(header http.Header, filters []v1beta1.MCPRouteFilter, filterType v1beta1.MCPRouteFilterType) {
+	for _, filter := range filters {
+		if filter.Type != filterType {
+			continue
+		}
+		modifier := filter.RequestHeaderModifier
+		if filterType == v1beta1.MCPResponseHeaderModifier {
+			modifier = filter.ResponseHeaderModifier
+		}
+		if modifier == nil {
+			continue
+		}
+		for _, h := range modifier.Set {
+			header.Set(h.Name, h.Value)
+		}
+		for _, h := range modifier.Add {
+			header.Add(h.Name, h.Value)
+		}
+		for _, name := range modifier.Remove {
+			header.Del(name)
+		}
+	}
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func describeCall(method, tool string) string {
+	if tool != "" {
+		return tool
+	}
+	return method
+}
+
+func routeKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func sessionKey(route *Route, sessionID string) string {
+	return routeKey(route.Namespace, route.Name) + "/" + sessionID
+}
+
+func writeRPCError(w http.ResponseWriter, status int, id json.RawMessage, code int, message string) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,445 @@
+package gateway
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// newBackend starts an MCP server stub that reports its name and the request it received
+func newBackend(t *testing.T, name string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Backend", name)
+		w.Header().Set("X-Seen-Tenant", r.Header.Get("X-Tenant"))
+		w.Header().Set("X-Internal", "secret")
+		if r.Header.Get(SessionHeader) == "" {
+			w.Header().Set(SessionHeader, "session-"+name)
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func toolCall(tool string) string {
+	return `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `"}}`
+}
+
+func send(g *Gateway, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	return rec
+}
+
+func rpcErrorCode(t *testing.T, rec *httptest.ResponseRecorder) int {
+	var resp struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Error.Code
+}
+
+func TestGatewayRouting(t *testing.T) {
+	github := newBackend(t, "github")
+	search := newBackend(t, "search")
+
+	g := New(&RouteTable{Routes: []Route{{
+		Namespace: "team",
+		Name:      "tools",
+		Backends:  []Backend{{Name: "github", URL: github.URL + "/mcp", Weight: 1, Ready: true}},
+		Matches: []Match{{
+			Tools:    []string{"search_*"},
+			Backends: []Backend{{Name: "search", URL: search.URL + "/mcp", Weight: 1, Ready: true}},
+		}},
+		Filters: []v1beta1.MCPRouteFilter{
+			{
+				Type: v1beta1.MCPRequestHeaderModifier,
+				RequestHeaderModifier: &v1beta1.MCPHeaderModifier{
+					Set: []v1beta1.MCPHeader{{Name: "X-Tenant", Value: "team"}},
+				},
+			},
+			{
+				Type:                   v1beta1.MCPResponseHeaderModifier,
+				ResponseHeaderModifier: &v1beta1.MCPHeaderModifier{Remove: []string{"X-Internal"}},
+			},
+		},
+	}}}, zap.NewNop().Sugar())
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		wantStatus  int
+		wantBackend string
+	}{
+		{
+			name:        "tool matching a rule goes to the rule backends",
+			path:        "/routes/team/tools",
+			body:        toolCall("search_web"),
+			wantStatus:  http.StatusOK,
+			wantBackend: "search",
+		},
+		{
+			name:        "other tools go to the route backends",
+			path:        "/routes/team/tools",
+			body:        toolCall("create_issue"),
+			wantStatus:  http.StatusOK,
+			wantBackend: "github",
+		},
+		{
+			name:        "non tool requests go to the route backends",
+			path:        "/routes/team/tools",
+			body:        `{"jsonrpc":"2.0","id":1,"method":"initialize"}`,
+			wantStatus:  http.StatusOK,
+			wantBackend: "github",
+		},
+		{
+			name:       "unknown route",
+			path:       "/routes/team/missing",
+			body:       toolCall("search_web"),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "path outside the routes prefix",
+			path:       "/mcp",
+			body:       toolCall("search_web"),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := send(g, tt.path, tt.body, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBackend != "" {
+				assert.Equal(t, tt.wantBackend, rec.Header().Get("X-Backend"))
+				assert.Equal(t, tt.body, rec.Body.String())
+				assert.Equal(t, "team", rec.Header().Get("X-Seen-Tenant"))
+				assert.Empty(t, rec.Header().Get("X-Internal"))
+			}
+		})
+	}
+}
+
+func TestGatewayBackendSelection(t *testing.T) {
+	stable := newBackend(t, "stable")
+	canary := newBackend(t, "canary")
+
+	t.Run("weighted split only uses ready backends", func(t *testing.T) {
+		g := New(&RouteTable{Routes: []Route{{
+			Namespace: "team",
+			Name:      "tools",
+			Backends: []Backend{
+				{Name: "stable", URL: stable.URL, Weight: 90, Ready: false},
+				{Name: "canary", URL: canary.URL, Weight: 10, Ready: true},
+			},
+		}}}, zap.NewNop().Sugar())
+		for i := 0; i < 10; i++ {
+			rec := send(g, "/routes/team/tools", toolCall("echo"), nil)
+			assert.Equal(t, "canary", rec.Header().Get("X-Backend"))
+		}
+	})
+
+	t.Run("weighted split spreads requests", func(t *testing.T) {
+		g := New(&RouteTable{Routes: []Route{{
+			Namespace: "team",
+			Name:      "tools",
+			Backends: []Backend{
+				{Name: "stable", URL: stable.URL, Weight: 1, Ready: true},
+				{Name: "canary", URL: canary.URL, Weight: 1, Ready: true},
+			},
+		}}}, zap.NewNop().Sugar())
+		seen := map[string]int{}
+		for i := 0; i < 200; i++ {
+			rec := send(g, "/routes/team/tools", toolCall("echo"), nil)
+			seen[rec.Header().Get("X-Backend")]++
+		}
+		assert.Greater(t, seen["stable"], 0)
+		assert.Greater(t, seen["canary"], 0)
+	})
+
+	t.Run("sessions stay on their backend", func(t *testing.T) {
+		g := New(&RouteTable{Routes: []Route{{
+			Namespace: "team",
+			Name:      "tools",
+			Backends: []Backend{
+				{Name: "stable", URL: stable.URL, Weight: 1, Ready: true},
+				{Name: "canary", URL: canary.URL, Weight: 1, Ready: true},
+			},
+		}}}, zap.NewNop().Sugar())
+		first := send(g, "/routes/team/tools", `{"jsonrpc":"2.0","id":1,"method":"initialize"}`, nil)
+		sessionID := first.Header().Get(SessionHeader)
+		require.NotEmpty(t, sessionID)
+		for i := 0; i < 20; i++ {
+			rec := send(g, "/routes/team/tools", toolCall("echo"), map[string]string{SessionHeader: sessionID})
+			assert.Equal(t, first.Header().Get("X-Backend"), rec.Header().Get("X-Backend"))
+		}
+	})
+
+	t.Run("no ready backend", func(t *testing.T) {
+		g := New(&RouteTable{Routes: []Route{{
+			Namespace: "team",
+			Name:      "tools",
+			Backends:  []Backend{{Name: "stable", URL: stable.URL, Weight: 1, Ready: false}},
+		}}}, zap.NewNop().Sugar())
+		rec := send(g, "/routes/team/tools", toolCall("echo"), nil)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, rpcErrorUnavailable, rpcErrorCode(t, rec))
+	})
+
+	t.Run("backend tool filter", func(t *testing.T) {
+		g := New(&RouteTable{Routes: []Route{{
+			Namespace: "team",
+			Name:      "tools",
+			Backends: []Backend{
+				{Name: "stable", URL: stable.URL, Weight: 1, Ready: true, Tools: []string{"read_*"}},
+				{Name: "canary", URL: canary.URL, Weight: 1, Ready: true, Tools: []string{"write_*"}},
+			},
+		}}}, zap.NewNop().Sugar())
+		for i := 0; i < 10; i++ {
+			rec := send(g, "/routes/team/tools", toolCall("write_file"), nil)
+			assert.Equal(t, "canary", rec.Header().Get("X-Backend"))
+		}
+	})
+}
+
+func TestGatewayPolicy(t *testing.T) {
+	backend := newBackend(t, "backend")
+
+	g := New(&RouteTable{Routes: []Route{{
+		Namespace: "team",
+		Name:      "tools",
+		Backends:  []Backend{{Name: "backend", URL: backend.URL, Weight: 1, Ready: true}},
+		Authentication: &Authentication{APIKey: &APIKey{
+			Header: "X-API-Key",
+			Keys:   map[string]string{"alice-key": "user:alice", "bob-key": "user:bob"},
+		}},
+		Authorization: &v1beta1.MCPAuthorization{Rules: []v1beta1.MCPAuthorizationRule{
+			{
+				Principals:  []string{"user:alice"},
+				Permissions: []v1beta1.MCPPermission{{Tools: []string{"*"}, Actions: []string{"tools/call", "tools/list"}}},
+			},
+			{
+				Principals:  []string{"user:bob"},
+				Permissions: []v1beta1.MCPPermission{{Tools: []string{"read_*"}, Actions: []string{"tools/call"}}},
+			},
+		}},
+		RateLimit: &v1beta1.MCPRateLimit{Limits: []v1beta1.MCPRateLimitRule{
+			{Dimension: v1beta1.MCPRateLimitByUser, Tools: []string{"expensive"}, Requests: 2, Unit: v1beta1.MCPRateLimitPerHour},
+		}},
+	}}}, zap.NewNop().Sugar())
+
+	tests := []struct {
+		name       string
+		key        string
+		body       string
+		wantStatus int
+		wantCode   int
+	}{
+		{
+			name:       "missing API key",
+			body:       toolCall("read_file"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   rpcErrorUnauthenticated,
+		},
+		{
+			name:       "invalid API key",
+			key:        "mallory-key",
+			body:       toolCall("read_file"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   rpcErrorUnauthenticated,
+		},
+		{
+			name:       "allowed tool",
+			key:        "bob-key",
+			body:       toolCall("read_file"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "tool outside the principal permissions",
+			key:        "bob-key",
+			body:       toolCall("write_file"),
+			wantStatus: http.StatusForbidden,
+			wantCode:   rpcErrorForbidden,
+		},
+		{
+			name:       "action outside the principal permissions",
+			key:        "bob-key",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			wantStatus: http.StatusForbidden,
+			wantCode:   rpcErrorForbidden,
+		},
+		{
+			name:       "methods other than tools are not authorized",
+			key:        "bob-key",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"initialize"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "wildcard permissions",
+			key:        "alice-key",
+			body:       toolCall("write_file"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "batches are rejected rather than skipping authorization",
+			key:        "bob-key",
+			body:       `[{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"write_file"}}]`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   rpcErrorInvalidRequest,
+		},
+		{
+			name:       "case variants of the tool name are rejected",
+			key:        "bob-key",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"write_file","Name":"read_x"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   rpcErrorInvalidRequest,
+		},
+		{
+			name:       "duplicate methods are rejected",
+			key:        "bob-key",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"initialize","method":"tools/call","params":{"name":"write_file"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   rpcErrorInvalidRequest,
+		},
+		{
+			name:       "tool calls without a tool are rejected",
+			key:        "bob-key",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   rpcErrorInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.key != "" {
+				headers["X-API-Key"] = tt.key
+			}
+			rec := send(g, "/routes/team/tools", tt.body, headers)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, rpcErrorCode(t, rec))
+			}
+		})
+	}
+
+	t.Run("rate limit is counted per user and tool", func(t *testing.T) {
+		alice := map[string]string{"X-API-Key": "alice-key"}
+		assert.Equal(t, http.StatusOK, send(g, "/routes/team/tools", toolCall("expensive"), alice).Code)
+		assert.Equal(t, http.StatusOK, send(g, "/routes/team/tools", toolCall("expensive"), alice).Code)
+		rec := send(g, "/routes/team/tools", toolCall("expensive"), alice)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, rpcErrorRateLimited, rpcErrorCode(t, rec))
+		assert.Equal(t, http.StatusOK, send(g, "/routes/team/tools", toolCall("cheap"), alice).Code)
+	})
+}
+
+func TestParseRPCRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantMethod string
+		wantTool   string
+		wantErr    bool
+	}{
+		{name: "empty body", body: ""},
+		{name: "tool call", body: toolCall("read_file"), wantMethod: methodToolsCall, wantTool: "read_file"},
+		{name: "other method", body: `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, wantMethod: methodToolsList},
+		{name: "response to a server request", body: `{"jsonrpc":"2.0","id":1,"result":{}}`},
+		{name: "tool arguments may use any member names", body: `{"method":"tools/call","params":{"name":"read_file","arguments":{"a":1,"A":2}}}`,
+			wantMethod: methodToolsCall, wantTool: "read_file"},
+		{name: "batch", body: `[` + toolCall("write_file") + `]`, wantErr: true},
+		{name: "empty batch", body: ` []`, wantErr: true},
+		{name: "not an object", body: `"tools/call"`, wantErr: true},
+		{name: "invalid JSON", body: `{"method":`, wantErr: true},
+		{name: "trailing object", body: toolCall("read_file") + toolCall("write_file"), wantErr: true},
+		{name: "case variant of the method", body: `{"Method":"tools/call","params":{"name":"write_file"}}`, wantErr: true},
+		{name: "case variant of the params", body: `{"method":"tools/call","params":{"name":"read_x"},"PARAMS":{"name":"write_file"}}`, wantErr: true},
+		{name: "case variant of the tool name", body: `{"method":"tools/call","params":{"name":"write_file","Name":"read_x"}}`, wantErr: true},
+		{name: "unicode case folding of the params", body: `{"method":"tools/call","params":{"name":"read_x"},"param\u017f":{"name":"write_file"}}`, wantErr: true},
+		{name: "duplicate tool name", body: `{"method":"tools/call","params":{"name":"read_x","name":"write_file"}}`, wantErr: true},
+		{name: "tool name is not a string", body: `{"method":"tools/call","params":{"name":["write_file"]}}`, wantErr: true},
+		{name: "tool call without params", body: `{"method":"tools/call"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := parseRPCRequest([]byte(tt.body))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, req.Method)
+			assert.Equal(t, tt.wantTool, req.Tool())
+		})
+	}
+}
+
+func TestParseRoutePath(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantNamespace string
+		wantName      string
+		wantRest      string
+		wantOK        bool
+	}{
+		{path: "/routes/team/tools", wantNamespace: "team", wantName: "tools", wantOK: true},
+		{path: "/routes/team/tools/sse", wantNamespace: "team", wantName: "tools", wantRest: "/sse", wantOK: true},
+		{path: "/routes/team", wantOK: false},
+		{path: "/routes//tools", wantOK: false},
+		{path: "/mcp", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			namespace, name, rest, ok := ParseRoutePath(tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+	assert.Equal(t, "http://mcp-gateway.team.svc.cluster.local:8080/routes/team/tools", GatewayURL("team", "tools", 8080))
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*", name: "anything", want: true},
+		{pattern: "read_file", name: "read_file", want: true},
+		{pattern: "read_file", name: "read_files", want: false},
+		{pattern: "read_*", name: "read_file", want: true},
+		{pattern: "*_file", name: "write_file", want: true},
+		{pattern: "*_file", name: "write_dir", want: false},
+		{pattern: "github_*_issue", name: "github_create_issue", want: true},
+		{pattern: "github_*_issue", name: "github_create_pr", want: false},
+		{pattern: "a*b*c", name: "abc", want: true},
+		{pattern: "a*b*c", name: "acb", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchWildcard(tt.pattern, tt.name))
+		})
+	}
+}
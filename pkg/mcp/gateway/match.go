@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+const (
+	methodToolsCall = "tools/call"
+	methodToolsList = "tools/list"
+)
+
+// rpcRequest is the part of a JSON-RPC request the gateway routes on
+type rpcRequest struct {
+	ID     json.RawMessage
+	Method string
+	// Name is the tool of tools/call requests
+	Name string
+}
+
+// Tool returns the tool a request calls, if any
+func (r *rpcRequest) Tool() string {
+	if r.Method == methodToolsCall {
+		return r.Name
+	}
+	return ""
+}
+
+// errBatchRequest rejects JSON-RPC batches, whose calls would otherwise reach backends without being authorized
+var errBatchRequest = errors.New("batch requests are not supported")
+
+// parseRPCRequest parses the JSON-RPC message in a body, which must be a single JSON object. Messages that are not
+// requests, such as responses to server requests, are routed without a method or tool. The members the gateway reads
+// are decoded strictly, so that it routes and authorizes the message a case-sensitive backend reads.
+func parseRPCRequest(body []byte) (*rpcRequest, error) {
+	req := &rpcRequest{}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return req, nil
+	}
+	if body[0] == '[' {
+		return nil, errBatchRequest
+	}
+
+	members, err := decodeObject(body, "jsonrpc", "id", "method", "params")
+	if err != nil {
+		return nil, err
+	}
+	req.ID = members["id"]
+	if raw, ok := members["method"]; ok {
+		if err := json.Unmarshal(raw, &req.Method); err != nil {
+			return nil, fmt.Errorf("invalid method: %w", err)
+		}
+	}
+	if req.Method != methodToolsCall {
+		return req, nil
+	}
+
+	raw, ok := members["params"]
+	if !ok {
+		return nil, errors.New("tools/call requires params")
+	}
+	params, err := decodeObject(raw, "name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	if err := json.Unmarshal(params["name"], &req.Name); err != nil || req.Name == "" {
+		return nil, errors.New("tools/call requires a tool name")
+	}
+	return req, nil
+}
+
+// decodeObject decodes the members of a JSON object. Objects a case-insensitive decoder would read differently than
+// a case-sensitive one are rejected: duplicate members, members differing only by case, and case variants of the
+// given fields.
+func decodeObject(data []byte, fields ...string) (map[string]json.RawMessage, error) {
+	known := map[string]string{}
+	for _, field := range fields {
+		known[foldKey(field)] = field
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+	members := map[string]json.RawMessage{}
+	folded := map[string]bool{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		key := tok.(string)
+		folding := foldKey(key)
+		if folded[folding] {
+			return nil, fmt.Errorf("duplicate member %q", key)
+		}
+		if field, ok := known[folding]; ok && field != key {
+			return nil, fmt.Errorf("member %q must be spelled %q", key, field)
+		}
+		folded[folding] = true
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		members[key] = value
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	return members, nil
+}
+
+// foldKey returns the case folding of a member name encoding/json matches it by, each rune mapped to the smallest
+// rune it folds to
+func foldKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		smallest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < smallest {
+				smallest = f
+			}
+		}
+		b.WriteRune(smallest)
+	}
+	return b.String()
+}
+
+// selectBackends returns the backends of the first rule matching the request, or the route backends
+func selectBackends(route *Route, req *rpcRequest, header http.Header) []Backend {
+	for i := range route.Matches {
+		if matchRule(&route.Matches[i], req, header) {
+			return route.Matches[i].Backends
+		}
+	}
+	return route.Backends
+}
+
+func matchRule(match *Match, req *rpcRequest, header http.Header) bool {
+	if match.Method != "" && match.Method != req.Method {
+		return false
+	}
+	if len(match.Tools) > 0 || match.ToolMatch != nil {
+		tool := req.Tool()
+		if tool == "" {
+			return false
+		}
+		if len(match.Tools) > 0 && !matchAnyTool(match.Tools, tool) {
+			return false
+		}
+		if match.ToolMatch != nil && !matchTool(match.ToolMatch, tool) {
+			return false
+		}
+	}
+	for _, h := range match.Headers {
+		if !matchHeader(h, header.Get(h.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchTool(matcher *v1beta1.MCPToolMatcher, tool string) bool {
+	if matcher.ExactMatch != nil && *matcher.ExactMatch != tool {
+		return false
+	}
+	if matcher.PrefixMatch != nil && !strings.HasPrefix(tool, *matcher.PrefixMatch) {
+		return false
+	}
+	if matcher.RegexMatch != nil {
+		re, err := regexp.Compile(*matcher.RegexMatch)
+		if err != nil || !re.MatchString(tool) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchHeader(match v1beta1.MCPHeaderMatch, value string) bool {
+	switch match.Type {
+	case v1beta1.MCPHeaderMatchPrefix:
+		return strings.HasPrefix(value, match.Value)
+	case v1beta1.MCPHeaderMatchRegex:
+		re, err := regexp.Compile(match.Value)
+		return err == nil && re.MatchString(value)
+	default:
+		return value == match.Value
+	}
+}
+
+// matchAnyTool reports whether a tool matches any of the patterns
+func matchAnyTool(patterns []string, tool string) bool {
+	for _, pattern := range patterns {
+		if matchWildcard(pattern, tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard matches a name against a pattern where "*" matches any sequence of characters
+func matchWildcard(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(name, part)
+		if idx < 0 {
+			return false
+		}
+		name = name[idx+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+// pickBackend picks a ready backend that may serve the tool, weighted by backend weight
+func pickBackend(backends []Backend, tool string, random *rand.Rand) (*Backend, bool) {
+	var candidates []*Backend
+	var total int64
+	for i := range backends {
+		backend := &backends[i]
+		if !backend.Ready || backend.Weight <= 0 {
+			continue
+		}
+		if tool != "" && len(backend.Tools) > 0 && !matchAnyTool(backend.Tools, tool) {
+			continue
+		}
+		candidates = append(candidates, backend)
+		total += int64(backend.Weight)
+	}
+	if len(candidates) == 0 {
+		return nil, false
+	}
+	n := random.Int63n(total)
+	for _, backend := range candidates {
+		n -= int64(backend.Weight)
+		if n < 0 {
+			return backend, true
+		}
+	}
+	return candidates[len(candidates)-1], true
+}
@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// rateLimiter counts requests in fixed windows. Counters are kept in memory, so limits
+// apply per gateway replica.
+type rateLimiter struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastEvict time.Time
+}
+
+type window struct {
+	start time.Time
+	count int32
+}
+
+func newRateLimiter(now func() time.Time) *rateLimiter {
+	return &rateLimiter{now: now, windows: map[string]*window{}}
+}
+
+// rateLimitRequest is what a request is counted by
+type rateLimitRequest struct {
+	route     string
+	namespace string
+	// user is the primary principal of the client
+	user string
+	// principal is the full set of principals of the client
+	principal string
+	ip        string
+	tool      string
+}
+
+// allow counts a request against the limits of a route and reports whether it is within all of them
+func (l *rateLimiter) allow(limits *v1beta1.MCPRateLimit, req rateLimitRequest) bool {
+	if limits == nil {
+		return true
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.evict(now)
+
+	var counted []*window
+	for i, limit := range limits.Limits {
+		if len(limit.Tools) > 0 && (req.tool == "" || !matchAnyTool(limit.Tools, req.tool)) {
+			continue
+		}
+		key := strings.Join([]string{req.route, strconv.Itoa(i), dimensionValue(limit.Dimension, req)}, "\x00")
+		w := l.windows[key]
+		period := unitDuration(limit.Unit)
+		if w == nil || now.Sub(w.start) >= period {
+			w = &window{start: now.Truncate(period)}
+			l.windows[key] = w
+		}
+		if w.count >= limit.Requests {
+			return false
+		}
+		counted = append(counted, w)
+	}
+	// Only requests that pass every limit count against them
+	for _, w := range counted {
+		w.count++
+	}
+	return true
+}
+
+// evict periodically drops windows older than the longest unit
+func (l *rateLimiter) evict(now time.Time) {
+	if now.Sub(l.lastEvict) < time.Minute {
+		return
+	}
+	l.lastEvict = now
+	for key, w := range l.windows {
+		if now.Sub(w.start) > 2*unitDuration(v1beta1.MCPRateLimitPerDay) {
+			delete(l.windows, key)
+		}
+	}
+}
+
+func dimensionValue(dimension v1beta1.MCPRateLimitDimension, req rateLimitRequest) string {
+	switch dimension {
+	case v1beta1.MCPRateLimitByUser:
+		return req.user
+	case v1beta1.MCPRateLimitByPrincipal:
+		return req.principal
+	case v1beta1.MCPRateLimitByIP:
+		return req.ip
+	case v1beta1.MCPRateLimitByTool:
+		return req.tool
+	case v1beta1.MCPRateLimitByNamespace:
+		return req.namespace
+	default:
+		return ""
+	}
+}
+
+func unitDuration(unit v1beta1.MCPRateLimitUnit) time.Duration {
+	switch unit {
+	case v1beta1.MCPRateLimitPerSecond:
+		return time.Second
+	case v1beta1.MCPRateLimitPerHour:
+		return time.Hour
+	case v1beta1.MCPRateLimitPerDay:
+		return 24 * time.Hour
+	default:
+		return time.Minute
+	}
+}
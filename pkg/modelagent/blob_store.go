@@ -0,0 +1,98 @@
+package modelagent
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+)
+
+// listSharedFiles lists the LFS files of a Hugging Face model, the files shared through the blob store. It returns
+// nil when the blob store is disabled or the files cannot be listed, the model is then downloaded without sharing.
+// The token is only sent without endpoints, endpoints carry their own
+func (s *Gopher) listSharedFiles(ctx context.Context, modelID, revision, token string, endpoints []hub.HubEndpoint) []hub.RepoFile {
+	if s.blobStore == nil {
+		return nil
+	}
+	if len(endpoints) > 0 {
+		token = ""
+	}
+
+	files, err := hub.ListRepoFiles(ctx, &hub.DownloadConfig{
+		RepoID:    modelID,
+		Revision:  revision,
+		Token:     token,
+		Endpoint:  s.xetConfig.Endpoint,
+		Endpoints: endpoints,
+	})
+	if err != nil {
+		s.logger.Warnf("Failed to list the files of %s for the blob store, downloading it without sharing: %v", modelID, err)
+		return nil
+	}
+
+	var shared []hub.RepoFile
+	for _, file := range files {
+		if file.Type == "file" && file.LFS != nil {
+			shared = append(shared, file)
+		}
+	}
+	return shared
+}
+
+// linkSharedFiles links the files of a model which the blob store already holds into its directory before it is
+// downloaded. Downloads skip the files already present with the expected size. Any other file at the path of a shared
+// file is removed: the Xet downloader truncates and rewrites files in place, so a link to the blob of another
+// revision would be overwritten along with every model sharing it. Failing to remove one fails the download.
+func (s *Gopher) linkSharedFiles(destPath string, files []hub.RepoFile) error {
+	linked, detached := 0, 0
+	for _, file := range files {
+		target := filepath.Join(destPath, file.Path)
+		ok, err := s.blobStore.Link(file.LFS.OID, target)
+		if err != nil {
+			s.logger.Warnf("Failed to link %s from the blob store: %v", file.Path, err)
+		}
+		if ok {
+			linked++
+			continue
+		}
+		removed, err := s.blobStore.Detach(file.LFS.OID, target)
+		if err != nil {
+			return err
+		}
+		if removed {
+			detached++
+		}
+	}
+	if linked > 0 || detached > 0 {
+		s.logger.Infof("Linked %d and removed %d stale files of %d shared files of %s", linked, detached, len(files), destPath)
+	}
+	return nil
+}
+
+// adoptSharedFiles moves the downloaded files of a model into the blob store, for other models to share them
+func (s *Gopher) adoptSharedFiles(destPath string, files []hub.RepoFile) {
+	for _, file := range files {
+		if err := s.blobStore.Adopt(file.LFS.OID, filepath.Join(destPath, file.Path)); err != nil {
+			s.logger.Warnf("Failed to move %s into the blob store: %v", file.Path, err)
+		}
+	}
+}
+
+// releaseSharedFiles releases the blobs of a deleted model directory and collects the blobs no model uses anymore
+func (s *Gopher) releaseSharedFiles(destPath string) {
+	if s.blobStore == nil {
+		return
+	}
+
+	released, err := s.blobStore.Release(destPath)
+	if err != nil {
+		s.logger.Warnf("Failed to release the blobs of %s: %v", destPath, err)
+	}
+	result, err := s.blobStore.GC()
+	if err != nil {
+		s.logger.Warnf("Failed to collect the unused blobs: %v", err)
+		return
+	}
+	s.logger.Infof("Released %d blob references of %s, removed %d unused blobs (%d bytes)",
+		released, destPath, result.RemovedBlobs, result.FreedBytes)
+}
@@ -75,7 +75,7 @@ func (p *ModelConfigParser) ParseModelConfig(modelDir string, baseModel *v1beta1
 			return nil, fmt.Errorf("failed to parse model_index.json with hf_model_config: %w", loadErr)
 		}
 
-		metadata = p.extractModelMetadataFromHF(hfModel)
+		metadata = p.extractModelMetadataFromHF(hfModel, nil)
 		hasMetadata = true
 	} else {
 		p.logger.Infof("model_index.json not found: %v", err)
@@ -93,8 +93,14 @@ func (p *ModelConfigParser) ParseModelConfig(modelDir string, baseModel *v1beta1
 				return nil, fmt.Errorf("failed to parse config file with hf_model_config: %w", loadErr)
 			}
 
+			// Record the attention geometry, which sizes the KV cache for automatic parallelism
+			attention, attentionErr := modelconfig.LoadAttentionConfig(configPath)
+			if attentionErr != nil {
+				p.logger.Infof("Attention config not found: %v", attentionErr)
+			}
+
 			// Use the HuggingFaceModel interface to extract metadata
-			metadata = p.extractModelMetadataFromHF(hfModel)
+			metadata = p.extractModelMetadataFromHF(hfModel, attention)
 			hasMetadata = true
 			p.logger.Infof("Extracted metadata: %+v", metadata)
 		}
@@ -270,8 +276,9 @@ func (p *ModelConfigParser) updateClusterBaseModel(model *v1beta1.ClusterBaseMod
 	return p.updateModel(model, metadata)
 }
 
-// extractModelMetadataFromHF extracts relevant metadata using the HuggingFaceModel interface
-func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.HuggingFaceModel) ModelMetadata {
+// extractModelMetadataFromHF extracts relevant metadata using the HuggingFaceModel interface.
+// The attention geometry is recorded in the model configuration when it is known.
+func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.HuggingFaceModel, attention *modelconfig.AttentionConfig) ModelMetadata {
 	p.logger.Infof("Extracting metadata from HuggingFace model: type=%s, architecture=%s",
 		hfModel.GetModelType(), hfModel.GetArchitecture())
 
@@ -326,13 +333,9 @@ func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.Huggi
 	quantType := hfModel.GetQuantizationType()
 	if quantType != "" {
 		p.logger.Infof("Detected quantization type: %s", quantType)
-		switch {
-		case strings.Contains(strings.ToLower(quantType), "int4"):
-			metadata.Quantization = v1beta1.ModelQuantizationINT4
-			p.logger.Infof("Setting quantization to INT4")
-		case strings.Contains(strings.ToLower(quantType), "fp8"):
-			metadata.Quantization = v1beta1.ModelQuantizationFP8
-			p.logger.Infof("Setting quantization to FP8")
+		metadata.Quantization = modelconfig.ModelQuantization(hfModel)
+		if metadata.Quantization != "" {
+			p.logger.Infof("Setting quantization to %s", metadata.Quantization)
 		}
 	}
 
@@ -354,6 +357,7 @@ func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.Huggi
 		TransformerVersion string `json:"transformers_version"`
 		TorchDtype         string `json:"torch_dtype"`
 		ModelSizeBytes     int64  `json:"model_size_bytes"`
+		*modelconfig.AttentionConfig
 	}{
 		ModelType:          hfModel.GetModelType(),
 		Architecture:       hfModel.GetArchitecture(),
@@ -364,6 +368,7 @@ func (p *ModelConfigParser) extractModelMetadataFromHF(hfModel modelconfig.Huggi
 		TransformerVersion: hfModel.GetTransformerVersion(),
 		TorchDtype:         hfModel.GetTorchDtype(),
 		ModelSizeBytes:     modelSizeBytes,
+		AttentionConfig:    attention,
 	})
 	if err == nil {
 		metadata.ModelConfiguration = configJSON
@@ -484,7 +489,6 @@ func convertDiffusionComponent(component *modelconfig.DiffusionComponentSpec) *v
 	return &v1beta1.DiffusionComponentSpec{Library: component.Library, Type: component.Type}
 }
 
-// determineModelCapabilitiesFromHF determines the model capabilities based on the HuggingFaceModel
 // shouldSkipConfigParsing checks if config parsing should be skipped for this model
 func (p *ModelConfigParser) shouldSkipConfigParsing(baseModel *v1beta1.BaseModel, clusterBaseModel *v1beta1.ClusterBaseModel) bool {
 	// Check base model annotations
@@ -510,66 +514,9 @@ func (p *ModelConfigParser) shouldSkipConfigParsing(baseModel *v1beta1.BaseModel
 	return false
 }
 
+// determineModelCapabilitiesFromHF determines the model capabilities based on the HuggingFaceModel
 func (p *ModelConfigParser) determineModelCapabilitiesFromHF(hfModel modelconfig.HuggingFaceModel) []string {
-	var capabilities []string
-	architecture := hfModel.GetArchitecture()
-	modelType := hfModel.GetModelType()
-
-	normalizedArchitecture := strings.ToLower(architecture)
-	normalizedModelType := strings.ToLower(modelType)
-
-	// Tested against 90+ models.
-	if dm, ok := hfModel.(modelconfig.HuggingFaceDiffusionModel); ok {
-		pipeline := dm.GetDiffusionModel()
-		if pipeline == nil {
-			return capabilities
-		}
-		if strings.Contains(normalizedArchitecture, "imageedit") ||
-			strings.Contains(normalizedArchitecture, "pix2pix") ||
-			strings.Contains(normalizedArchitecture, "img2img") ||
-			strings.Contains(normalizedArchitecture, "inpaint") {
-			return append(capabilities, string(v1beta1.ModelCapabilityImageTextToImage))
-		}
-		if strings.Contains(normalizedArchitecture, "image") ||
-			strings.Contains(normalizedArchitecture, "pix") ||
-			strings.Contains(normalizedArchitecture, "stablediffusion") {
-			return append(capabilities, string(v1beta1.ModelCapabilityTextToImage))
-		}
-		if strings.Contains(normalizedArchitecture, "texttovideo") ||
-			strings.Contains(normalizedArchitecture, "t2v") {
-			return append(capabilities, string(v1beta1.ModelCapabilityTextToVideo))
-		}
-		if strings.Contains(normalizedArchitecture, "video") {
-			return append(capabilities, string(v1beta1.ModelCapabilityImageTextToVideo))
-		}
-	}
-
-	// For vision, only support image text capability right now
-	if hfModel.HasVision() {
-		return append(capabilities, string(v1beta1.ModelCapabilityImageTextToText))
-	}
-
-	// Check for omni-model capability
-	if strings.Contains(normalizedArchitecture, "omni") {
-		return append(capabilities,
-			string(v1beta1.ModelCapabilityTextToAudio), string(v1beta1.ModelCapabilityImageTextToAudio),
-			string(v1beta1.ModelCapabilityVideoTextToAudio), string(v1beta1.ModelCapabilityAudioToText),
-			string(v1beta1.ModelCapabilityAudioToAudio))
-	}
-
-	// Check for text embedding capability
-	if hfModel.IsEmbedding() ||
-		strings.Contains(normalizedArchitecture, "embedding") ||
-		strings.Contains(normalizedArchitecture, "sentence") ||
-		strings.Contains(normalizedModelType, "bert") ||
-		// Special case for known embedding models
-		(strings.Contains(normalizedModelType, "mistral") &&
-			strings.Contains(normalizedArchitecture, "mistralmodel")) {
-		return append(capabilities, string(v1beta1.ModelCapabilityEmbedding))
-	}
-
-	// Default to text-to-text capability
-	return append(capabilities, string(v1beta1.ModelCapabilityTextToText))
+	return modelconfig.ModelCapabilities(hfModel)
 }
 
 // populateArtifactAttribute returns a pointer to an updated copy of currentModelMetadata
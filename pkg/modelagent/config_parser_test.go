@@ -195,7 +195,7 @@ func TestExtractModelMetadataFromHF(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Call the function under test
-			metadata := parser.extractModelMetadataFromHF(tc.mockModel)
+			metadata := parser.extractModelMetadataFromHF(tc.mockModel, nil)
 
 			// Verify the metadata using the custom validation function
 			if !tc.expectedMetadata(metadata) {
@@ -235,6 +235,23 @@ func TestExtractModelMetadataFromHF(t *testing.T) {
 	}
 }
 
+// TestExtractModelMetadataFromHFAttention tests that the attention geometry is recorded in the model configuration
+func TestExtractModelMetadataFromHFAttention(t *testing.T) {
+	parser := &ModelConfigParser{logger: zap.NewNop().Sugar()}
+
+	attention := &modelconfig.AttentionConfig{NumLayers: 80, NumKeyValueHeads: 8, HeadDim: 128}
+	var config map[string]interface{}
+	assert.NoError(t, json.Unmarshal(parser.extractModelMetadataFromHF(createDefaultMockModel(), attention).ModelConfiguration, &config))
+	assert.Equal(t, 80.0, config["num_hidden_layers"])
+	assert.Equal(t, 8.0, config["num_key_value_heads"])
+	assert.Equal(t, 128.0, config["head_dim"])
+	assert.NotContains(t, config, "kv_lora_rank")
+
+	config = nil
+	assert.NoError(t, json.Unmarshal(parser.extractModelMetadataFromHF(createDefaultMockModel(), nil).ModelConfiguration, &config))
+	assert.NotContains(t, config, "num_hidden_layers")
+}
+
 // TestDetermineModelCapabilitiesFromHF tests the determineModelCapabilitiesFromHF function
 func TestDetermineModelCapabilitiesFromHF(t *testing.T) {
 	// Create a test logger
@@ -20,6 +20,7 @@ import (
 	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
 	omev1beta1lister "github.com/sgl-project/ome/pkg/client/listers/ome/v1beta1"
 	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/sgl-project/ome/pkg/ociobjectstore"
 	"github.com/sgl-project/ome/pkg/principals"
@@ -50,7 +51,9 @@ type Gopher struct {
 	concurrency            int
 	multipartConcurrency   int
 	modelRootDir           string
+	blobStore              *hub.BlobStore
 	xetConfig              *xet.Config
+	hfEndpoints            []hub.HubEndpoint
 	kubeClient             kubernetes.Interface
 	gopherChan             <-chan *GopherTask
 	nodeLabelReconciler    *NodeLabelReconciler
@@ -78,6 +81,8 @@ func NewGopher(
 	multipartConcurrency int,
 	downloadRetry int,
 	modelRootDir string,
+	blobStore *hub.BlobStore,
+	hfEndpoints []hub.HubEndpoint,
 	gopherChan <-chan *GopherTask,
 	nodeLabelReconciler *NodeLabelReconciler,
 	metrics *Metrics,
@@ -96,6 +101,8 @@ func NewGopher(
 		concurrency:            concurrency,
 		multipartConcurrency:   multipartConcurrency,
 		modelRootDir:           modelRootDir,
+		blobStore:              blobStore,
+		hfEndpoints:            hfEndpoints,
 		xetConfig:              xetConfig,
 		kubeClient:             kubeClient,
 		gopherChan:             gopherChan,
@@ -221,6 +228,13 @@ func (s *Gopher) safeParseAndUpdateModelConfig(modelPath string, baseModel *v1be
 		metadata = s.modelConfigParser.populateArtifactAttribute(artifact, metadata)
 	}
 
+	// record the model version the weights were downloaded for, so revisions can be told apart
+	if metadata != nil {
+		if version := modelVersion(baseModel, clusterBaseModel); version != "" {
+			metadata.Artifact.Version = version
+		}
+	}
+
 	// If valid metadata was found, update the ConfigMap while still holding the lock
 	if metadata != nil {
 		op := &ConfigMapMetadataOp{
@@ -585,6 +599,16 @@ func getModelUID(task *GopherTask) string {
 	return ""
 }
 
+// modelVersion returns the version of the model, or an empty string when it has none
+func modelVersion(baseModel *v1beta1.BaseModel, clusterBaseModel *v1beta1.ClusterBaseModel) string {
+	if baseModel != nil && baseModel.Spec.Version != nil {
+		return *baseModel.Spec.Version
+	} else if clusterBaseModel != nil && clusterBaseModel.Spec.Version != nil {
+		return *clusterBaseModel.Spec.Version
+	}
+	return ""
+}
+
 func (s *Gopher) markModelOnNodeFailed(task *GopherTask) {
 	modelInfo := getModelInfoForLogging(task)
 	s.logger.Infof("Marking model %s as Failed on node", modelInfo)
@@ -605,8 +629,9 @@ func (s *Gopher) markModelOnNodeFailed(task *GopherTask) {
 }
 
 // getHuggingFaceToken retrieves authentication token for Hugging Face models.
-// It attempts to get the token from either a Kubernetes secret or direct parameters.
-func (s *Gopher) getHuggingFaceToken(task *GopherTask, baseModelSpec v1beta1.BaseModelSpec, modelInfo string) string {
+// It attempts to get the token from either a Kubernetes secret or direct parameters,
+// and reports whether it came from the secret of the storage key.
+func (s *Gopher) getHuggingFaceToken(task *GopherTask, baseModelSpec v1beta1.BaseModelSpec, modelInfo string) (string, bool) {
 	var hfToken string
 	var namespace string
 
@@ -650,15 +675,19 @@ func (s *Gopher) getHuggingFaceToken(task *GopherTask, baseModelSpec v1beta1.Bas
 		}
 	}
 
+	if hfToken != "" {
+		return hfToken, true
+	}
+
 	// Fallback to parameters if token not found in secret or no secret provided
-	if hfToken == "" && baseModelSpec.Storage.Parameters != nil {
+	if baseModelSpec.Storage.Parameters != nil {
 		if token, exists := (*baseModelSpec.Storage.Parameters)["token"]; exists {
 			hfToken = token
 			s.logger.Infof("Using token from Parameters for model %s", modelInfo)
 		}
 	}
 
-	return hfToken
+	return hfToken, false
 }
 
 func getDestPath(baseModel *v1beta1.BaseModelSpec, modelRootDir string) string {
@@ -903,6 +932,9 @@ func (s *Gopher) deleteModel(destPath string, task *GopherTask) error {
 	startTime := time.Now()
 
 	err := os.RemoveAll(destPath)
+	if err == nil {
+		s.releaseSharedFiles(destPath)
+	}
 
 	// Log deletion time regardless of success or failure
 	deleteTime := time.Since(startTime)
@@ -1018,7 +1050,7 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 		childrenPaths = currentChildren
 
 		// Get Hugging Face token from storage key or parameters
-		hfToken := s.getHuggingFaceToken(task, baseModelSpec, modelInfo)
+		hfToken, storageKeyToken := s.getHuggingFaceToken(task, baseModelSpec, modelInfo)
 
 		s.logger.Infof("Downloading HuggingFace model %s (revision: %s) to %s",
 			hfComponents.ModelID, hfComponents.Branch, destPath)
@@ -1124,10 +1156,29 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 			})
 		}
 
+		// Mirrors and proxies of the Hub are tried in order, failing over to the next one. Endpoints of the
+		// model outside of the agent endpoints only get the token of its storage key
+		var modelToken string
+		if storageKeyToken {
+			modelToken = hfToken
+		}
+		endpoints := s.huggingFaceEndpoints(baseModelSpec, modelInfo, config.Token, modelToken)
+
+		// Link the files other models already downloaded, the download skips them
+		sharedFiles := s.listSharedFiles(ctx, config.RepoID, config.Revision, config.Token, endpoints)
+		if err := s.linkSharedFiles(destPath, sharedFiles); err != nil {
+			s.logger.Errorf("Failed to prepare the shared files of HuggingFace model %s: %v", modelInfo, err)
+			s.metrics.RecordFailedDownload(modelType, namespace, name, "hf_download_error")
+			s.markModelOnNodeFailed(task)
+			return err
+		}
+
 		// Perform snapshot download with progress tracking
 		// Note: Progress is cleared atomically with status update in ReconcileModelStatus
 		// when status becomes Ready/Failed, ensuring the controller sees the final progress
-		downloadPath, err := xet.SnapshotDownloadWithProgress(ctx, config, progressHandler, progressThrottle)
+		downloadPath, err := s.downloadWithFailover(ctx, config, endpoints, modelInfo, func(config *xet.DownloadConfig) (string, error) {
+			return xet.SnapshotDownloadWithProgress(ctx, config, progressHandler, progressThrottle)
+		})
 
 		if err != nil {
 			// Check error type for better handling
@@ -1146,6 +1197,7 @@ func (s *Gopher) processHuggingFaceModel(ctx context.Context, task *GopherTask,
 
 		s.logger.Infof("Successfully downloaded HuggingFace model %s to %s",
 			modelInfo, downloadPath)
+		s.adoptSharedFiles(destPath, sharedFiles)
 		artifact = s.modelConfigParser.buildArtifactAttribute(shaStr, s.configMapReconciler.getModelConfigMapKey(task.BaseModel, task.ClusterBaseModel), destPath, childrenPaths)
 	}
 
@@ -1672,3 +1672,37 @@ func Test_hasChildrenPaths_NonEmptyNoError_ReturnsTrue(t *testing.T) {
 	assert.True(t, hasChildrenPaths([]string{"/child"}, nil))
 	assert.True(t, hasChildrenPaths([]string{"/child1", "/child2"}, nil))
 }
+
+func TestModelVersion(t *testing.T) {
+	version := "v2"
+	tests := []struct {
+		name             string
+		baseModel        *v1beta1.BaseModel
+		clusterBaseModel *v1beta1.ClusterBaseModel
+		expected         string
+	}{
+		{
+			name:      "base model version",
+			baseModel: &v1beta1.BaseModel{Spec: v1beta1.BaseModelSpec{ModelExtensionSpec: v1beta1.ModelExtensionSpec{Version: &version}}},
+			expected:  "v2",
+		},
+		{
+			name:             "cluster base model version",
+			clusterBaseModel: &v1beta1.ClusterBaseModel{Spec: v1beta1.BaseModelSpec{ModelExtensionSpec: v1beta1.ModelExtensionSpec{Version: &version}}},
+			expected:         "v2",
+		},
+		{
+			name:      "model without version",
+			baseModel: &v1beta1.BaseModel{},
+		},
+		{
+			name: "no model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, modelVersion(tt.baseModel, tt.clusterBaseModel))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package modelagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+	"github.com/sgl-project/ome/pkg/xet"
+)
+
+// HuggingFaceEndpointsParameter is the storage parameter listing the Hugging Face endpoints to download a model from,
+// in the format of hub.ParseEndpoints. It overrides the endpoints of the agent.
+const HuggingFaceEndpointsParameter = "endpoints"
+
+// endpointFailurePattern matches the download errors of an unhealthy endpoint: server errors and rate limiting, whose
+// status follows "HTTP" or "status", and network errors. The xet client reports its errors as text only, which also
+// hold numbers such as sizes and shard names.
+var endpointFailurePattern = regexp.MustCompile(
+	`(?i)\b(?:http|status)\b[^0-9\n]{0,32}\b(?:5\d\d|429)\b|rate limit|too many requests|connection refused|connection reset|no such host|timed out|timeout`)
+
+// ApplyHuggingFaceEndpointTokens sets the tokens of the endpoints of the agent from a JSON object mapping endpoint URLs
+// to tokens, read from a Secret. Endpoints listed with a token of their own are rejected, tokens do not belong in the
+// ConfigMap of the endpoints.
+func ApplyHuggingFaceEndpointTokens(endpoints []hub.HubEndpoint, tokens string) ([]hub.HubEndpoint, error) {
+	for _, endpoint := range endpoints {
+		if endpoint.Token != "" {
+			return nil, fmt.Errorf("endpoint %s has a token, endpoint tokens are read from a secret", endpoint.URL)
+		}
+	}
+	if strings.TrimSpace(tokens) == "" {
+		return endpoints, nil
+	}
+
+	var byURL map[string]string
+	if err := json.Unmarshal([]byte(tokens), &byURL); err != nil {
+		return nil, fmt.Errorf("invalid endpoint tokens: %w", err)
+	}
+	result := make([]hub.HubEndpoint, len(endpoints))
+	copy(result, endpoints)
+	for tokenURL, token := range byURL {
+		found := false
+		for i := range result {
+			if result[i].URL == strings.TrimSuffix(strings.TrimSpace(tokenURL), "/") {
+				result[i].Token = token
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid endpoint tokens: %s is not an endpoint", tokenURL)
+		}
+	}
+	return result, nil
+}
+
+// huggingFaceEndpoints returns the ordered endpoints to download a Hugging Face model from, each with the token sent
+// to it: the endpoints of its storage parameters, or else the endpoints of the agent. Nil means the endpoint of the
+// xet configuration alone, with the token of the download.
+//
+// The endpoints of the agent receive the token of the download unless they have their own. The endpoints of a model
+// may point anywhere, so they only receive the token of the download when they are on a host of the agent
+// endpoints, and otherwise the token of the storage key of the model, never the token of the agent.
+func (s *Gopher) huggingFaceEndpoints(baseModelSpec v1beta1.BaseModelSpec, modelInfo, downloadToken, storageKeyToken string) []hub.HubEndpoint {
+	if baseModelSpec.Storage != nil && baseModelSpec.Storage.Parameters != nil {
+		if value, ok := (*baseModelSpec.Storage.Parameters)[HuggingFaceEndpointsParameter]; ok && value != "" {
+			endpoints, err := hub.ParseEndpoints(value)
+			if err == nil {
+				for i := range endpoints {
+					if endpoints[i].Token != "" {
+						s.logger.Warnf("Ignoring the token of Hugging Face endpoint %s of model %s, use the storage key of the model",
+							endpoints[i].URL, modelInfo)
+					}
+					endpoints[i].Token = s.trustedEndpointToken(endpoints[i].URL, downloadToken, storageKeyToken)
+				}
+				return endpoints
+			}
+			s.logger.Warnf("Ignoring the invalid Hugging Face endpoints of model %s: %v", modelInfo, err)
+		}
+	}
+
+	var endpoints []hub.HubEndpoint
+	for _, endpoint := range s.hfEndpoints {
+		if endpoint.Token == "" {
+			endpoint.Token = downloadToken
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+// trustedEndpointToken returns the token to send to an endpoint of a model: the token of the agent endpoint on the
+// same host, or the token of the download for the host of the xet configuration, or else the token of its storage key
+func (s *Gopher) trustedEndpointToken(endpointURL, downloadToken, storageKeyToken string) string {
+	host := endpointHost(endpointURL)
+	if host == "" {
+		return storageKeyToken
+	}
+	for _, endpoint := range s.hfEndpoints {
+		if endpointHost(endpoint.URL) == host {
+			if endpoint.Token != "" {
+				return endpoint.Token
+			}
+			return downloadToken
+		}
+	}
+	if s.xetConfig != nil && endpointHost(s.xetConfig.Endpoint) == host {
+		return downloadToken
+	}
+	return storageKeyToken
+}
+
+// endpointHost returns the scheme and host of an endpoint URL, empty when it has none
+func endpointHost(endpointURL string) string {
+	u, err := url.Parse(endpointURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// isEndpointFailure reports whether a download error is a failure of the endpoint, which makes the download fail over
+// to the next endpoint
+func isEndpointFailure(err error) bool {
+	return err != nil && endpointFailurePattern.MatchString(err.Error())
+}
+
+// downloadWithFailover runs a Hugging Face download against the endpoints in order, healthy endpoints first, failing
+// over to the next endpoint when one fails. Each endpoint is sent its own token only, see huggingFaceEndpoints.
+func (s *Gopher) downloadWithFailover(ctx context.Context, config *xet.DownloadConfig, endpoints []hub.HubEndpoint,
+	modelInfo string, download func(*xet.DownloadConfig) (string, error)) (string, error) {
+	if len(endpoints) == 0 {
+		return download(config)
+	}
+
+	health := hub.DefaultEndpointHealth()
+	endpoints = health.Order(endpoints)
+
+	var downloadPath string
+	var err error
+	for i, endpoint := range endpoints {
+		endpointConfig := *config
+		endpointConfig.Endpoint = endpoint.URL
+		endpointConfig.Token = endpoint.Token
+
+		downloadPath, err = download(&endpointConfig)
+		if err == nil {
+			health.ReportSuccess(endpoint.URL)
+			return downloadPath, nil
+		}
+		if ctx.Err() != nil || !isEndpointFailure(err) {
+			return downloadPath, err
+		}
+
+		health.ReportFailure(endpoint.URL)
+		if i < len(endpoints)-1 {
+			s.logger.Warnf("Hugging Face endpoint %s failed for model %s, failing over to %s: %v",
+				endpoint.URL, modelInfo, endpoints[i+1].URL, err)
+		}
+	}
+	return downloadPath, err
+}
@@ -0,0 +1,210 @@
+package modelagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+	"github.com/sgl-project/ome/pkg/xet"
+)
+
+func TestApplyHuggingFaceEndpointTokens(t *testing.T) {
+	endpoints := []hub.HubEndpoint{{URL: "https://hf-mirror.internal"}, {URL: "https://huggingface.co"}}
+
+	result, err := ApplyHuggingFaceEndpointTokens(endpoints, "")
+	require.NoError(t, err)
+	assert.Equal(t, endpoints, result)
+
+	result, err = ApplyHuggingFaceEndpointTokens(endpoints, `{"https://hf-mirror.internal/": "mirror-token"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []hub.HubEndpoint{{URL: "https://hf-mirror.internal", Token: "mirror-token"}, {URL: "https://huggingface.co"}}, result)
+	assert.Empty(t, endpoints[0].Token, "the endpoints are not modified")
+
+	_, err = ApplyHuggingFaceEndpointTokens(endpoints, `{"https://hf-proxy.internal": "proxy-token"}`)
+	assert.Error(t, err, "tokens of unknown endpoints are rejected")
+	_, err = ApplyHuggingFaceEndpointTokens(endpoints, `["mirror-token"]`)
+	assert.Error(t, err)
+	_, err = ApplyHuggingFaceEndpointTokens([]hub.HubEndpoint{{URL: "https://hf-mirror.internal", Token: "mirror-token"}}, "")
+	assert.Error(t, err, "tokens are not accepted from the endpoints")
+}
+
+func TestHuggingFaceEndpoints(t *testing.T) {
+	agentEndpoints := []hub.HubEndpoint{
+		{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+		{URL: "https://hf-cache.internal"},
+	}
+	s := &Gopher{
+		logger:      zaptest.NewLogger(t).Sugar(),
+		hfEndpoints: agentEndpoints,
+		xetConfig:   &xet.Config{Endpoint: "https://huggingface.co"},
+	}
+
+	specWithParameters := func(parameters map[string]string) v1beta1.BaseModelSpec {
+		return v1beta1.BaseModelSpec{Storage: &v1beta1.StorageSpec{Parameters: &parameters}}
+	}
+
+	tests := []struct {
+		name            string
+		spec            v1beta1.BaseModelSpec
+		storageKeyToken string
+		expected        []hub.HubEndpoint
+	}{
+		{
+			name: "agent endpoints without storage",
+			spec: v1beta1.BaseModelSpec{},
+			expected: []hub.HubEndpoint{
+				{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+				{URL: "https://hf-cache.internal", Token: "download-token"},
+			},
+		},
+		{
+			name: "agent endpoints without the parameter",
+			spec: specWithParameters(map[string]string{"secretKey": "token"}),
+			expected: []hub.HubEndpoint{
+				{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+				{URL: "https://hf-cache.internal", Token: "download-token"},
+			},
+		},
+		{
+			name: "model endpoints on the agent hosts get the agent tokens",
+			spec: specWithParameters(map[string]string{
+				HuggingFaceEndpointsParameter: "https://hf-mirror.internal/v2,https://hf-cache.internal,https://huggingface.co",
+			}),
+			expected: []hub.HubEndpoint{
+				{URL: "https://hf-mirror.internal/v2", Token: "mirror-token"},
+				{URL: "https://hf-cache.internal", Token: "download-token"},
+				{URL: "https://huggingface.co", Token: "download-token"},
+			},
+		},
+		{
+			name: "other model endpoints never get the download token",
+			spec: specWithParameters(map[string]string{
+				HuggingFaceEndpointsParameter: "https://attacker.example,http://hf-cache.internal",
+			}),
+			expected: []hub.HubEndpoint{{URL: "https://attacker.example"}, {URL: "http://hf-cache.internal"}},
+		},
+		{
+			name: "other model endpoints get the token of the storage key",
+			spec: specWithParameters(map[string]string{
+				HuggingFaceEndpointsParameter: "https://hf-proxy.internal",
+			}),
+			storageKeyToken: "model-token",
+			expected:        []hub.HubEndpoint{{URL: "https://hf-proxy.internal", Token: "model-token"}},
+		},
+		{
+			name: "tokens of model endpoints are ignored",
+			spec: specWithParameters(map[string]string{
+				HuggingFaceEndpointsParameter: `[{"url": "https://hf-proxy.internal", "token": "proxy-token"}]`,
+			}),
+			expected: []hub.HubEndpoint{{URL: "https://hf-proxy.internal"}},
+		},
+		{
+			name: "invalid model endpoints are ignored",
+			spec: specWithParameters(map[string]string{HuggingFaceEndpointsParameter: "https://hf-proxy.internal,,"}),
+			expected: []hub.HubEndpoint{
+				{URL: "https://hf-mirror.internal", Token: "mirror-token"},
+				{URL: "https://hf-cache.internal", Token: "download-token"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.huggingFaceEndpoints(tt.spec, "test-model", "download-token", tt.storageKeyToken))
+		})
+	}
+
+	// Without agent endpoints, the model uses the endpoint of the xet configuration
+	s.hfEndpoints = nil
+	assert.Nil(t, s.huggingFaceEndpoints(v1beta1.BaseModelSpec{}, "test-model", "download-token", ""))
+}
+
+func TestIsEndpointFailure(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: errors.New("HTTP status 503 Service Unavailable"), expected: true},
+		{err: errors.New("HTTP 502 Bad Gateway"), expected: true},
+		{err: errors.New("HTTP status server error (500 Internal Server Error) for url (https://hf-mirror.internal)"), expected: true},
+		{err: errors.New("request failed with status: 429"), expected: true},
+		{err: errors.New("rate limit exceeded"), expected: true},
+		{err: errors.New("dial tcp 10.0.0.1:443: connect: connection refused"), expected: true},
+		{err: errors.New("HTTP status 404 Not Found"), expected: false},
+		{err: errors.New("HTTP status 401 Unauthorized"), expected: false},
+		// Numbers which are not an HTTP status
+		{err: errors.New("xet error 99: short read: expected 512 bytes, got 100"), expected: false},
+		{err: errors.New("failed to write model-00001-of-00500.safetensors: no space left on device"), expected: false},
+		{err: errors.New("HTTP status 404 Not Found for model-00042-of-00500.safetensors"), expected: false},
+		{err: errors.New("checksum mismatch for shard 503"), expected: false},
+	}
+
+	for _, tt := range tests {
+		name := "nil"
+		if tt.err != nil {
+			name = tt.err.Error()
+		}
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isEndpointFailure(tt.err))
+		})
+	}
+}
+
+func TestDownloadWithFailover(t *testing.T) {
+	s := &Gopher{logger: zaptest.NewLogger(t).Sugar()}
+
+	t.Run("without endpoints", func(t *testing.T) {
+		var endpoints []string
+		_, err := s.downloadWithFailover(context.Background(), &xet.DownloadConfig{Endpoint: "https://huggingface.co"}, nil, "test-model",
+			func(config *xet.DownloadConfig) (string, error) {
+				endpoints = append(endpoints, config.Endpoint)
+				return "/models/test", nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://huggingface.co"}, endpoints)
+	})
+
+	t.Run("fails over to the next endpoint", func(t *testing.T) {
+		mirror := hub.HubEndpoint{URL: "https://hf-mirror-failover.internal", Token: "mirror-token"}
+		// Endpoints carry their token, the token of the download is not sent to an endpoint without one
+		hf := hub.HubEndpoint{URL: "https://hf-failover.internal"}
+
+		var attempts []string
+		downloadPath, err := s.downloadWithFailover(context.Background(), &xet.DownloadConfig{Token: "model-token"},
+			[]hub.HubEndpoint{mirror, hf}, "test-model",
+			func(config *xet.DownloadConfig) (string, error) {
+				attempts = append(attempts, fmt.Sprintf("%s %s", config.Endpoint, config.Token))
+				if config.Endpoint == mirror.URL {
+					return "", errors.New("HTTP status 502 Bad Gateway")
+				}
+				return "/models/test", nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "/models/test", downloadPath)
+		assert.Equal(t, []string{mirror.URL + " mirror-token", hf.URL + " "}, attempts)
+		assert.False(t, hub.DefaultEndpointHealth().Healthy(mirror.URL))
+	})
+
+	t.Run("other errors do not fail over", func(t *testing.T) {
+		mirror := hub.HubEndpoint{URL: "https://hf-mirror-not-found.internal"}
+		hf := hub.HubEndpoint{URL: "https://hf-not-found.internal"}
+
+		attempts := 0
+		_, err := s.downloadWithFailover(context.Background(), &xet.DownloadConfig{}, []hub.HubEndpoint{mirror, hf}, "test-model",
+			func(config *xet.DownloadConfig) (string, error) {
+				attempts++
+				return "", errors.New("HTTP status 404 Not Found")
+			})
+		assert.Error(t, err)
+		assert.Equal(t, 1, attempts)
+		assert.True(t, hub.DefaultEndpointHealth().Healthy(mirror.URL))
+	})
+}
@@ -68,7 +68,8 @@ type ModelConfig struct {
 
 // Artifact records the information of model artifact, including version (Sha) and storage paths
 type Artifact struct {
-	Sha string `json:"sha"` // sha string fetched from HuggingFace
+	Sha     string `json:"sha"`               // sha string fetched from HuggingFace
+	Version string `json:"version,omitempty"` // version of the model the artifact was downloaded for
 	// parent model name -> parent model artifact storage path
 	// parent name convention is
 	// For ClusterBaseModel: clusterbasemodel.{model_name}
@@ -146,7 +147,7 @@ func ConvertMetadataToModelConfig(metadata ModelMetadata) *ModelConfig {
 
 	// convert artifact
 	var artifact Artifact
-	if metadata.Artifact.Sha != "" || metadata.Artifact.ParentPath != nil || metadata.Artifact.ChildrenPaths != nil {
+	if metadata.Artifact.Sha != "" || metadata.Artifact.Version != "" || metadata.Artifact.ParentPath != nil || metadata.Artifact.ChildrenPaths != nil {
 		currentArtifact := metadata.Artifact
 		// Deep copy ParentPath to avoid aliasing
 		var parent map[string]string
@@ -164,6 +165,7 @@ func ConvertMetadataToModelConfig(metadata ModelMetadata) *ModelConfig {
 		}
 		artifact = Artifact{
 			Sha:           currentArtifact.Sha,
+			Version:       currentArtifact.Version,
 			ParentPath:    parent,
 			ChildrenPaths: children,
 		}
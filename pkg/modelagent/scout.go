@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -241,7 +242,7 @@ func (w *Scout) downloadBaseModel(obj interface{}) {
 		return
 	}
 
-	if w.shouldDownloadModel(baseModel.Spec.Storage) {
+	if w.shouldDownloadModel(baseModel.Spec.Storage) || w.isPrewarmRequested(baseModel.Annotations, baseModel.Spec.Storage) {
 		// Refresh the node info
 		var err error
 		w.nodeInfo, err = w.kubeClient.CoreV1().Nodes().Get(w.ctx, w.nodeName, metav1.GetOptions{})
@@ -286,7 +287,7 @@ func (w *Scout) downloadClusterBaseModel(obj interface{}) {
 		return
 	}
 
-	if w.shouldDownloadModel(clusterBaseModel.Spec.Storage) {
+	if w.shouldDownloadModel(clusterBaseModel.Spec.Storage) || w.isPrewarmRequested(clusterBaseModel.Annotations, clusterBaseModel.Spec.Storage) {
 		// Refresh the node info
 		var err error
 		w.nodeInfo, err = w.kubeClient.CoreV1().Nodes().Get(w.ctx, w.nodeName, metav1.GetOptions{})
@@ -327,7 +328,8 @@ func (w *Scout) updateBaseModel(old, new interface{}) {
 	newBaseModel := new.(*v1beta1.BaseModel)
 
 	if w.shouldDownloadModel(oldBaseModel.Spec.Storage) &&
-		!w.shouldDownloadModel(newBaseModel.Spec.Storage) {
+		!w.shouldDownloadModel(newBaseModel.Spec.Storage) &&
+		!w.isPrewarmRequested(newBaseModel.Annotations, newBaseModel.Spec.Storage) {
 		// shape config changed, delete it from the current node
 		w.logger.Infof("Target shapes excluded BaseModel update: %s in namespace %s, deleting", newBaseModel.GetName(), newBaseModel.GetNamespace())
 		w.deleteBaseModel(new)
@@ -366,6 +368,14 @@ func (w *Scout) updateBaseModel(old, new interface{}) {
 		w.logger.Infof("BaseModel %s needs refresh in namespace %s", newBaseModel.GetName(), newBaseModel.GetNamespace())
 		w.generateDownloadOverrideTaskBasedOnBaseModel(newBaseModel)
 	}
+
+	if w.isNewPrewarmRequest(oldBaseModel.Annotations, newBaseModel.Annotations, newBaseModel.Spec.Storage) {
+		w.logger.Infof("BaseModel %s in namespace %s requested to be pre-warmed on this node", newBaseModel.GetName(), newBaseModel.GetNamespace())
+		w.downloadBaseModel(newBaseModel)
+	} else if w.isPrewarmReleased(oldBaseModel.Annotations, newBaseModel.Annotations, newBaseModel.Spec.Storage) {
+		w.logger.Infof("BaseModel %s in namespace %s is no longer pre-warmed on this node, deleting", newBaseModel.GetName(), newBaseModel.GetNamespace())
+		w.deleteBaseModel(newBaseModel)
+	}
 }
 
 func (w *Scout) updateClusterBaseModel(old, new interface{}) {
@@ -382,7 +392,8 @@ func (w *Scout) updateClusterBaseModel(old, new interface{}) {
 	}
 
 	if w.shouldDownloadModel(oldClusterBaseModel.Spec.Storage) &&
-		!w.shouldDownloadModel(newClusterBaseModel.Spec.Storage) {
+		!w.shouldDownloadModel(newClusterBaseModel.Spec.Storage) &&
+		!w.isPrewarmRequested(newClusterBaseModel.Annotations, newClusterBaseModel.Spec.Storage) {
 		// shape config changed, delete it from the current node
 		w.logger.Infof("Target shapes excluded ClusterBaseModel %s, deleting", newClusterBaseModel.GetName())
 		w.deleteClusterBaseModel(new)
@@ -421,6 +432,14 @@ func (w *Scout) updateClusterBaseModel(old, new interface{}) {
 		w.logger.Infof("ClusterBaseModel %s need refresh", newClusterBaseModel.GetName())
 		w.generateDownloadOverrideTaskBasedOnClusterBaseModel(newClusterBaseModel)
 	}
+
+	if w.isNewPrewarmRequest(oldClusterBaseModel.Annotations, newClusterBaseModel.Annotations, newClusterBaseModel.Spec.Storage) {
+		w.logger.Infof("ClusterBaseModel %s requested to be pre-warmed on this node", newClusterBaseModel.GetName())
+		w.downloadClusterBaseModel(newClusterBaseModel)
+	} else if w.isPrewarmReleased(oldClusterBaseModel.Annotations, newClusterBaseModel.Annotations, newClusterBaseModel.Spec.Storage) {
+		w.logger.Infof("ClusterBaseModel %s is no longer pre-warmed on this node, deleting", newClusterBaseModel.GetName())
+		w.deleteClusterBaseModel(newClusterBaseModel)
+	}
 }
 
 func (w *Scout) deleteBaseModel(obj interface{}) {
@@ -553,6 +572,44 @@ func (w *Scout) shouldDownloadModelInUpdateEvent(storageSpec *v1beta1.StorageSpe
 	return w.shouldDownloadModelCommon(storageSpec, false)
 }
 
+// isPrewarmRequested checks if an InferenceService asked this node to pull the model ahead of scale-out.
+// PVC storage is handled by the BaseModel controller and is never pre-warmed.
+func (w *Scout) isPrewarmRequested(annotations map[string]string, storageSpec *v1beta1.StorageSpec) bool {
+	if storageSpec != nil && storageSpec.StorageUri != nil {
+		storageType, err := storage.GetStorageType(*storageSpec.StorageUri)
+		if err == nil && storageType == storage.StorageTypePVC {
+			return false
+		}
+	}
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, constants.ModelPrewarmNodesAnnotationPrefix) {
+			continue
+		}
+		for _, node := range strings.Split(value, ",") {
+			if strings.TrimSpace(node) == w.nodeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isNewPrewarmRequest checks if an update asks this node to pull a model it is not already selected for by the
+// storage spec
+func (w *Scout) isNewPrewarmRequest(oldAnnotations, newAnnotations map[string]string, storageSpec *v1beta1.StorageSpec) bool {
+	return w.isPrewarmRequested(newAnnotations, storageSpec) &&
+		!w.isPrewarmRequested(oldAnnotations, storageSpec) &&
+		!w.shouldDownloadModel(storageSpec)
+}
+
+// isPrewarmReleased checks if an update withdraws the last pre-warm request of this node for a model it is not
+// selected for by the storage spec, so the weights can be evicted
+func (w *Scout) isPrewarmReleased(oldAnnotations, newAnnotations map[string]string, storageSpec *v1beta1.StorageSpec) bool {
+	return w.isPrewarmRequested(oldAnnotations, storageSpec) &&
+		!w.isPrewarmRequested(newAnnotations, storageSpec) &&
+		!w.shouldDownloadModel(storageSpec)
+}
+
 func (w *Scout) nodeMatchesSelectorTerm(term v1.NodeSelectorTerm) bool {
 	// Check match expressions
 	for _, expr := range term.MatchExpressions {
@@ -899,6 +899,102 @@ func TestShouldDownloadModelFallback(t *testing.T) {
 	}
 }
 
+// Test the isPrewarmRequested, isNewPrewarmRequest and isPrewarmReleased functions
+func TestIsPrewarmRequested(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-node",
+			Labels: map[string]string{"gpu-model": "a10"},
+		},
+	}
+	scout := &Scout{
+		nodeName: "test-node",
+		nodeInfo: testNode,
+		logger:   logger.Sugar(),
+	}
+
+	pvcURI := "pvc://models/llama"
+	ociURI := "oci://n/ns/b/bucket/o/llama"
+	otherNodes := &v1beta1.StorageSpec{StorageUri: &ociURI, NodeSelector: map[string]string{"gpu-model": "h100"}}
+	requested := map[string]string{
+		constants.ModelPrewarmNodesAnnotationPrefix + "isvc-a": "node-a",
+		constants.ModelPrewarmNodesAnnotationPrefix + "isvc-b": "node-a,test-node",
+	}
+	otherRequest := map[string]string{constants.ModelPrewarmNodesAnnotationPrefix + "isvc-a": "node-a"}
+
+	testCases := []struct {
+		name           string
+		oldAnnotations map[string]string
+		newAnnotations map[string]string
+		storageSpec    *v1beta1.StorageSpec
+		requested      bool
+		newRequest     bool
+		released       bool
+	}{
+		{
+			name:           "node listed in the pre-warm annotation",
+			newAnnotations: requested,
+			storageSpec:    otherNodes,
+			requested:      true,
+			newRequest:     true,
+		},
+		{
+			name:           "node not listed in the pre-warm annotation",
+			newAnnotations: map[string]string{constants.ModelPrewarmNodesAnnotationPrefix + "isvc-a": "node-a,test-node-2"},
+			storageSpec:    otherNodes,
+		},
+		{
+			name:           "node listed in an annotation without the pre-warm prefix",
+			newAnnotations: map[string]string{"models.ome.io/other": "test-node"},
+			storageSpec:    otherNodes,
+		},
+		{
+			name:           "last request of the node withdrawn",
+			oldAnnotations: requested,
+			newAnnotations: otherRequest,
+			storageSpec:    otherNodes,
+			released:       true,
+		},
+		{
+			name:           "withdrawn request of a node selected by the storage spec",
+			oldAnnotations: requested,
+			newAnnotations: otherRequest,
+			storageSpec:    &v1beta1.StorageSpec{StorageUri: &ociURI},
+		},
+		{
+			name:        "no pre-warm annotation",
+			storageSpec: otherNodes,
+		},
+		{
+			name:           "node already requested",
+			oldAnnotations: requested,
+			newAnnotations: requested,
+			storageSpec:    otherNodes,
+			requested:      true,
+		},
+		{
+			name:           "node already selected by the storage spec",
+			newAnnotations: requested,
+			storageSpec:    &v1beta1.StorageSpec{StorageUri: &ociURI},
+			requested:      true,
+		},
+		{
+			name:           "pvc storage is never pre-warmed",
+			newAnnotations: requested,
+			storageSpec:    &v1beta1.StorageSpec{StorageUri: &pvcURI},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.requested, scout.isPrewarmRequested(tc.newAnnotations, tc.storageSpec))
+			assert.Equal(t, tc.newRequest, scout.isNewPrewarmRequest(tc.oldAnnotations, tc.newAnnotations, tc.storageSpec))
+			assert.Equal(t, tc.released, scout.isPrewarmReleased(tc.oldAnnotations, tc.newAnnotations, tc.storageSpec))
+		})
+	}
+}
+
 // Test edge cases for the nodeMatchesExpression function
 func TestNodeMatchesExpressionEdgeCases(t *testing.T) {
 	// Create a test logger
@@ -0,0 +1,61 @@
+// Package prometheus queries metrics of OME workloads from a Prometheus server
+package prometheus
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// MetricsClient queries the value of a metric
+type MetricsClient interface {
+	// Query returns the single value of an instant query, and false when the query returned no samples
+	Query(ctx context.Context, query string) (float64, bool, error)
+}
+
+type prometheusClient struct {
+	api promv1.API
+}
+
+// NewClient creates a MetricsClient for the Prometheus server at address
+func NewClient(address string) (MetricsClient, error) {
+	client, err := api.NewClient(api.Config{Address: address})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create Prometheus client for %s", address)
+	}
+	return &prometheusClient{api: promv1.NewAPI(client)}, nil
+}
+
+func (c *prometheusClient) Query(ctx context.Context, query string) (float64, bool, error) {
+	result, _, err := c.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, false, err
+	}
+
+	var value float64
+	switch v := result.(type) {
+	case model.Vector:
+		if len(v) == 0 {
+			return 0, false, nil
+		}
+		if len(v) > 1 {
+			return 0, false, errors.Errorf("query returned %d series instead of one", len(v))
+		}
+		value = float64(v[0].Value)
+	case *model.Scalar:
+		value = float64(v.Value)
+	default:
+		return 0, false, errors.Errorf("unsupported query result type %s", result.Type())
+	}
+
+	// Ratios over windows without requests evaluate to NaN
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false, nil
+	}
+	return value, true, nil
+}
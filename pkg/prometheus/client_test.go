@@ -0,0 +1,64 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		response      string
+		expectedValue float64
+		expectedOK    bool
+		expectErr     bool
+	}{
+		{
+			name:          "single sample",
+			response:      `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.25"]}]}}`,
+			expectedValue: 0.25,
+			expectedOK:    true,
+		},
+		{
+			name:     "no samples",
+			response: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		},
+		{
+			name:     "ratio without requests",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"NaN"]}]}}`,
+		},
+		{
+			name: "several series",
+			response: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"pod":"a"},"value":[1700000000,"1"]},{"metric":{"pod":"b"},"value":[1700000000,"2"]}]}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL)
+			require.NoError(t, err)
+			value, ok, err := client.Query(context.TODO(), "up")
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+const (
+	// DefaultExplanationCandidateLimit is the number of ranked candidates recorded on the InferenceService status.
+	DefaultExplanationCandidateLimit = 5
+
+	// DefaultExplanationRejectionLimit is the number of rejected runtimes recorded on the InferenceService status.
+	DefaultExplanationRejectionLimit = 10
+)
+
+// SelectionExplanation describes how a runtime was chosen for a model.
+type SelectionExplanation struct {
+	// Selected is the winning runtime, nil if no runtime is compatible
+	Selected *RuntimeMatch
+
+	// Candidates contains all compatible runtimes in ranking order
+	Candidates []RuntimeMatch
+
+	// Rejected contains the runtimes that were excluded and why
+	Rejected []RuntimeRejection
+
+	// WinReason explains why the selected runtime ranked above the runner-up
+	WinReason string
+
+	// TotalRuntimes is the number of runtimes that were evaluated
+	TotalRuntimes int
+}
+
+// RuntimeRejection records why a runtime was excluded from auto-selection.
+type RuntimeRejection struct {
+	// Name is the name of the runtime
+	Name string `json:"name"`
+
+	// IsCluster indicates if this is a ClusterServingRuntime
+	IsCluster bool `json:"isCluster"`
+
+	// Reasons contains human-readable reasons for the rejection
+	Reasons []string `json:"reasons"`
+}
+
+// ExplainSelection evaluates all runtimes visible to the InferenceService and
+// returns the ranking along with rejection reasons for the excluded ones.
+// When no runtime is compatible, the explanation is still returned together
+// with a NoRuntimeFoundError.
+func (s *defaultSelector) ExplainSelection(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*SelectionExplanation, error) {
+	namespace := isvc.Namespace
+	logger := log.FromContext(ctx)
+
+	if err := s.validateModel(model); err != nil {
+		return nil, err
+	}
+
+	collection, err := s.fetcher.FetchRuntimes(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runtimes: %w", err)
+	}
+
+	explanation := &SelectionExplanation{
+		TotalRuntimes: len(collection.NamespaceRuntimes) + len(collection.ClusterRuntimes),
+	}
+
+	var namespaceMatches []RuntimeMatch
+	var clusterMatches []RuntimeMatch
+
+	for _, runtime := range collection.NamespaceRuntimes {
+		match, reasons := s.evaluateRuntimeWithReasons(ctx, &runtime.Spec, model, isvc, runtime.Name, false)
+		if match != nil {
+			namespaceMatches = append(namespaceMatches, *match)
+			continue
+		}
+		explanation.Rejected = append(explanation.Rejected, RuntimeRejection{
+			Name:      runtime.Name,
+			IsCluster: false,
+			Reasons:   reasons,
+		})
+	}
+
+	for _, runtime := range collection.ClusterRuntimes {
+		match, reasons := s.evaluateRuntimeWithReasons(ctx, &runtime.Spec, model, isvc, runtime.Name, true)
+		if match != nil {
+			clusterMatches = append(clusterMatches, *match)
+			continue
+		}
+		explanation.Rejected = append(explanation.Rejected, RuntimeRejection{
+			Name:      runtime.Name,
+			IsCluster: true,
+			Reasons:   reasons,
+		})
+	}
+
+	explanation.Candidates = s.rankMatches(ctx, model, isvc, namespaceMatches, clusterMatches)
+
+	if len(explanation.Candidates) == 0 {
+		excludedRuntimes := make(map[string]error)
+		for _, rejection := range explanation.Rejected {
+			if len(rejection.Reasons) > 0 {
+				excludedRuntimes[rejection.Name] = fmt.Errorf("%s", rejection.Reasons[0])
+			}
+		}
+		return explanation, &NoRuntimeFoundError{
+			ModelName:          getModelName(model),
+			ModelFormat:        model.ModelFormat.Name,
+			Namespace:          namespace,
+			ExcludedRuntimes:   excludedRuntimes,
+			TotalRuntimes:      explanation.TotalRuntimes,
+			NamespacedRuntimes: len(collection.NamespaceRuntimes),
+			ClusterRuntimes:    len(collection.ClusterRuntimes),
+		}
+	}
+
+	explanation.Selected = &explanation.Candidates[0]
+	explanation.WinReason = s.explainWin(explanation.Candidates, model)
+
+	logger.V(1).Info("Explained runtime selection",
+		"runtime", explanation.Selected.Name,
+		"candidates", len(explanation.Candidates),
+		"rejected", len(explanation.Rejected),
+		"reason", explanation.WinReason)
+
+	return explanation, nil
+}
+
+// evaluateRuntimeWithReasons evaluates a single runtime like evaluateRuntime and,
+// when the runtime is not selectable, returns the reasons it was excluded.
+func (s *defaultSelector) evaluateRuntimeWithReasons(ctx context.Context, spec *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, name string, isCluster bool) (*RuntimeMatch, []string) {
+	if match := s.evaluateRuntime(ctx, spec, model, isvc, name, isCluster); match != nil {
+		return match, nil
+	}
+
+	if spec.IsDisabled() {
+		return nil, []string{"runtime is disabled"}
+	}
+
+	report, err := s.matcher.GetCompatibilityDetails(spec, model, isvc, name)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("failed to evaluate compatibility: %v", err)}
+	}
+	if !report.IsCompatible {
+		return nil, report.IncompatibilityReasons
+	}
+
+	hasAutoSelect := false
+	for _, format := range spec.SupportedModelFormats {
+		if format.AutoSelect != nil && *format.AutoSelect {
+			hasAutoSelect = true
+			break
+		}
+	}
+	if !hasAutoSelect {
+		return nil, []string{"runtime does not have auto-select enabled for any supported format"}
+	}
+
+	return nil, []string{"no auto-selectable format matches the model format and framework"}
+}
+
+// explainWin describes why the first candidate ranks above the second one,
+// mirroring the ordering applied by GetCompatibleRuntimes and CompareRuntimes.
+func (s *defaultSelector) explainWin(candidates []RuntimeMatch, model *v1beta1.BaseModelSpec) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	winner := candidates[0]
+	if len(candidates) == 1 {
+		return "only compatible runtime"
+	}
+	runnerUp := candidates[1]
+
+	if !winner.IsCluster && runnerUp.IsCluster {
+		return fmt.Sprintf("namespace-scoped runtimes take precedence over cluster-scoped runtime %s", runnerUp.Name)
+	}
+	if winner.Score != runnerUp.Score {
+		return fmt.Sprintf("highest score %d (runner-up %s scored %d)", winner.Score, runnerUp.Name, runnerUp.Score)
+	}
+
+	if scorer, ok := s.scorer.(*DefaultRuntimeScorer); ok && model.ModelParameterSize != nil {
+		if scorer.calculateSizeScore(winner, model) != scorer.calculateSizeScore(runnerUp, model) {
+			return fmt.Sprintf("tied score %d with %s, model size %s is closer to its supported size range",
+				winner.Score, runnerUp.Name, *model.ModelParameterSize)
+		}
+	}
+
+	return fmt.Sprintf("tied score %d with %s, chosen by name ordering", winner.Score, runnerUp.Name)
+}
+
+// SelectFallback selects the candidate at index instead of the top-ranked one. Higher ranked
+// candidates are moved to Rejected using their entry in reasons.
+func (e *SelectionExplanation) SelectFallback(index int, reasons map[string]string) {
+	if index <= 0 || index >= len(e.Candidates) {
+		return
+	}
+	for _, skipped := range e.Candidates[:index] {
+		e.Rejected = append(e.Rejected, RuntimeRejection{
+			Name:      skipped.Name,
+			IsCluster: skipped.IsCluster,
+			Reasons:   []string{reasons[skipped.Name]},
+		})
+	}
+	e.Candidates = e.Candidates[index:]
+	e.Selected = &e.Candidates[0]
+	e.WinReason = fmt.Sprintf("fallback after %d higher ranked runtimes could not be scheduled", index)
+}
+
+// Summary returns a one-line, human-readable explanation suitable for a condition message.
+func (e *SelectionExplanation) Summary() string {
+	if e.Selected == nil {
+		return fmt.Sprintf("no compatible runtime found among %d runtimes", e.TotalRuntimes)
+	}
+	return fmt.Sprintf("%s %s selected: %s; %d compatible, %d rejected",
+		runtimeKind(e.Selected.IsCluster), e.Selected.Name, e.WinReason, len(e.Candidates), len(e.Rejected))
+}
+
+// ToStatus converts the explanation into the InferenceService status representation,
+// keeping at most candidateLimit candidates and rejectionLimit rejections.
+func (e *SelectionExplanation) ToStatus(candidateLimit, rejectionLimit int) *v1beta1.RuntimeSelectionStatus {
+	status := &v1beta1.RuntimeSelectionStatus{
+		TotalRuntimes: int32(e.TotalRuntimes),
+		Reason:        e.WinReason,
+	}
+	if e.Selected != nil {
+		status.Runtime = e.Selected.Name
+		status.IsClusterRuntime = e.Selected.IsCluster
+		status.Score = e.Selected.Score
+	}
+
+	for i, candidate := range e.Candidates {
+		if i >= candidateLimit {
+			break
+		}
+		status.Candidates = append(status.Candidates, v1beta1.RuntimeCandidate{
+			Name:             candidate.Name,
+			IsClusterRuntime: candidate.IsCluster,
+			Score:            candidate.Score,
+			Priority:         candidate.MatchDetails.Priority,
+			Reasons:          describeMatch(candidate.MatchDetails),
+		})
+	}
+
+	for i, rejection := range e.Rejected {
+		if i >= rejectionLimit {
+			break
+		}
+		status.Rejected = append(status.Rejected, v1beta1.RuntimeRejection{
+			Name:             rejection.Name,
+			IsClusterRuntime: rejection.IsCluster,
+			Reasons:          rejection.Reasons,
+		})
+	}
+
+	return status
+}
+
+// describeMatch renders the positive aspects of a match as short reasons.
+func describeMatch(details MatchDetails) []string {
+	var reasons []string
+	if details.FormatMatch {
+		reasons = append(reasons, "model format matches")
+	}
+	if details.FrameworkMatch {
+		reasons = append(reasons, "model framework matches")
+	}
+	if details.SizeMatch {
+		reasons = append(reasons, "model size within supported range")
+	}
+	reasons = append(reasons, fmt.Sprintf("priority %d", details.Priority))
+	return append(reasons, details.Reasons...)
+}
+
+// runtimeKind returns the kind name for a runtime scope.
+func runtimeKind(isCluster bool) string {
+	if isCluster {
+		return "ClusterServingRuntime"
+	}
+	return "ServingRuntime"
+}
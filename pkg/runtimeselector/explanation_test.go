@@ -0,0 +1,210 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func TestExplainSelection(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	runtimes := []*v1beta1.ServingRuntime{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-high", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 10}, AutoSelect: ptr(true), Priority: ptr(int32(2))},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-low", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 10}, AutoSelect: ptr(true)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-no-auto", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors", Weight: 10}, AutoSelect: ptr(false)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-disabled", Namespace: "default"},
+			Spec:       v1beta1.ServingRuntimeSpec{Disabled: ptr(true)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rt-onnx", Namespace: "default"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "onnx"}, AutoSelect: ptr(true)},
+				},
+			},
+		},
+	}
+	for _, rt := range runtimes {
+		require.NoError(t, fakeClient.Create(ctx, rt))
+	}
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	explanation, err := selector.ExplainSelection(ctx, model, isvc)
+	require.NoError(t, err)
+	require.NotNil(t, explanation.Selected)
+
+	assert.Equal(t, "rt-high", explanation.Selected.Name)
+	assert.Equal(t, 5, explanation.TotalRuntimes)
+	assert.Len(t, explanation.Candidates, 2)
+	assert.Equal(t, "rt-low", explanation.Candidates[1].Name)
+	assert.Contains(t, explanation.WinReason, "highest score 20")
+
+	rejected := map[string][]string{}
+	for _, r := range explanation.Rejected {
+		rejected[r.Name] = r.Reasons
+	}
+	assert.Len(t, rejected, 3)
+	assert.Equal(t, []string{"runtime is disabled"}, rejected["rt-disabled"])
+	assert.Equal(t, []string{"runtime does not have auto-select enabled for any supported format"}, rejected["rt-no-auto"])
+	assert.Contains(t, rejected["rt-onnx"][0], "not in supported formats")
+
+	// The explanation must agree with SelectRuntime
+	selection, err := selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, selection.Name, explanation.Selected.Name)
+
+	status := explanation.ToStatus(1, 2)
+	assert.Equal(t, "rt-high", status.Runtime)
+	assert.Equal(t, int64(20), status.Score)
+	assert.Equal(t, int32(5), status.TotalRuntimes)
+	assert.Len(t, status.Candidates, 1)
+	assert.Contains(t, status.Candidates[0].Reasons, "priority 2")
+	assert.Len(t, status.Rejected, 2)
+
+	assert.Contains(t, explanation.Summary(), "ServingRuntime rt-high selected")
+}
+
+func TestExplainSelection_NoRuntimeFound(t *testing.T) {
+	fakeClient := createFakeClient()
+	selector := New(fakeClient)
+	ctx := context.Background()
+
+	rt := &v1beta1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "rt-small"},
+		Spec: v1beta1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1beta1.SupportedModelFormat{
+				{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors"}, AutoSelect: ptr(true)},
+			},
+			ModelSizeRange: &v1beta1.ModelSizeRangeSpec{Min: ptr("1B"), Max: ptr("10B")},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, rt))
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, ModelParameterSize: ptr("70B")}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+
+	explanation, err := selector.ExplainSelection(ctx, model, isvc)
+	assert.True(t, IsNoRuntimeFoundError(err))
+	require.NotNil(t, explanation)
+	assert.Nil(t, explanation.Selected)
+	require.Len(t, explanation.Rejected, 1)
+	assert.True(t, explanation.Rejected[0].IsCluster)
+	assert.Contains(t, explanation.Rejected[0].Reasons[0], "outside supported range")
+	assert.Equal(t, "no compatible runtime found among 1 runtimes", explanation.Summary())
+}
+
+func TestExplainWin(t *testing.T) {
+	selector := New(createFakeClient()).(*defaultSelector)
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}, ModelParameterSize: ptr("7B")}
+
+	match := func(name string, score int64, isCluster bool, sizeRange *v1beta1.ModelSizeRangeSpec) RuntimeMatch {
+		return RuntimeMatch{RuntimeSelection: RuntimeSelection{
+			Name:      name,
+			Score:     score,
+			IsCluster: isCluster,
+			Spec:      &v1beta1.ServingRuntimeSpec{ModelSizeRange: sizeRange},
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		candidates []RuntimeMatch
+		want       string
+	}{
+		{
+			name:       "single candidate",
+			candidates: []RuntimeMatch{match("a", 10, false, nil)},
+			want:       "only compatible runtime",
+		},
+		{
+			name:       "namespace scoped wins",
+			candidates: []RuntimeMatch{match("a", 10, false, nil), match("b", 20, true, nil)},
+			want:       "namespace-scoped runtimes take precedence over cluster-scoped runtime b",
+		},
+		{
+			name:       "higher score",
+			candidates: []RuntimeMatch{match("a", 20, true, nil), match("b", 10, true, nil)},
+			want:       "highest score 20 (runner-up b scored 10)",
+		},
+		{
+			name: "size range tie-break",
+			candidates: []RuntimeMatch{
+				match("a", 10, true, &v1beta1.ModelSizeRangeSpec{Min: ptr("5B"), Max: ptr("10B")}),
+				match("b", 10, true, &v1beta1.ModelSizeRangeSpec{Min: ptr("1B"), Max: ptr("70B")}),
+			},
+			want: "tied score 10 with b, model size 7B is closer to its supported size range",
+		},
+		{
+			name:       "name tie-break",
+			candidates: []RuntimeMatch{match("a", 10, true, nil), match("b", 10, true, nil)},
+			want:       "tied score 10 with b, chosen by name ordering",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selector.explainWin(tt.candidates, model))
+		})
+	}
+}
+
+func TestSelectFallback(t *testing.T) {
+	explanation := &SelectionExplanation{
+		Candidates: []RuntimeMatch{
+			{RuntimeSelection: RuntimeSelection{Name: "rt-a", Score: 30}},
+			{RuntimeSelection: RuntimeSelection{Name: "rt-b", Score: 20, IsCluster: true}},
+			{RuntimeSelection: RuntimeSelection{Name: "rt-c", Score: 10}},
+		},
+		TotalRuntimes: 3,
+	}
+	explanation.Selected = &explanation.Candidates[0]
+
+	explanation.SelectFallback(2, map[string]string{
+		"rt-a": "pods unschedulable",
+		"rt-b": "pods unschedulable on a100",
+	})
+
+	require.NotNil(t, explanation.Selected)
+	assert.Equal(t, "rt-c", explanation.Selected.Name)
+	assert.Len(t, explanation.Candidates, 1)
+	require.Len(t, explanation.Rejected, 2)
+	assert.Equal(t, []string{"pods unschedulable on a100"}, explanation.Rejected[1].Reasons)
+	assert.True(t, explanation.Rejected[1].IsCluster)
+	assert.Equal(t, "fallback after 2 higher ranked runtimes could not be scheduled", explanation.WinReason)
+
+	// Out of range indexes leave the explanation untouched
+	explanation.SelectFallback(5, nil)
+	assert.Equal(t, "rt-c", explanation.Selected.Name)
+}
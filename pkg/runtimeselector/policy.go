@@ -0,0 +1,359 @@
+package runtimeselector
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+// Built-in scoring policy names accepted in PolicyConfig.
+const (
+	DefaultPolicyName             = "default"
+	AcceleratorFitPolicyName      = "acceleratorFit"
+	CostPolicyName                = "cost"
+	NamespacePreferencePolicyName = "namespacePreference"
+)
+
+// ScoringPolicy contributes to the ranking of compatible runtimes.
+// Score returns one value per candidate, in the same order as candidates.
+// Except for the default policy, which returns the RuntimeScorer score,
+// built-in policies return values in [0, 1] so that a policy's weight is
+// the maximum number of points it can add to a runtime's score.
+type ScoringPolicy interface {
+	// Name identifies the policy in configuration and match reasons.
+	Name() string
+
+	// Score calculates the policy's contribution for each candidate.
+	Score(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, candidates []RuntimeMatch) ([]float64, error)
+}
+
+// WeightedPolicy pairs a scoring policy with the weight applied to its score.
+type WeightedPolicy struct {
+	Policy ScoringPolicy
+	Weight float64
+}
+
+// PolicySpec configures a single scoring policy.
+type PolicySpec struct {
+	// Name of a built-in policy
+	Name string `json:"name"`
+
+	// Weight applied to the policy score
+	Weight float64 `json:"weight"`
+}
+
+// PolicyConfig configures the scoring policies used to rank compatible runtimes.
+// It is loaded from the runtimeSelection key of the inferenceservice ConfigMap.
+type PolicyConfig struct {
+	// Policies lists the scoring policies and their weights.
+	// The default policy is added with weight 1 if it is not listed.
+	Policies []PolicySpec `json:"policies,omitempty"`
+
+	// NamespacePreferredRuntimes lists preferred runtimes per namespace, most preferred first.
+	NamespacePreferredRuntimes map[string][]string `json:"namespacePreferredRuntimes,omitempty"`
+
+	// UseSpotPricing makes the cost policy prefer spot pricing when an AcceleratorClass defines it.
+	UseSpotPricing bool `json:"useSpotPricing,omitempty"`
+}
+
+// NewScoringPolicies builds the weighted scoring policies described by the config.
+// A nil or empty config returns no policies, which keeps the default scorer behavior.
+func NewScoringPolicies(c client.Client, config *PolicyConfig) ([]WeightedPolicy, error) {
+	if config == nil || len(config.Policies) == 0 {
+		return nil, nil
+	}
+
+	var policies []WeightedPolicy
+	hasDefault := false
+	seen := make(map[string]struct{})
+	for _, spec := range config.Policies {
+		if _, ok := seen[spec.Name]; ok {
+			return nil, &ConfigurationError{Component: "scoring policy", Message: fmt.Sprintf("policy %q is configured more than once", spec.Name)}
+		}
+		seen[spec.Name] = struct{}{}
+
+		if spec.Weight < 0 {
+			return nil, &ConfigurationError{Component: "scoring policy", Message: fmt.Sprintf("policy %q has negative weight %v", spec.Name, spec.Weight)}
+		}
+
+		var policy ScoringPolicy
+		switch spec.Name {
+		case DefaultPolicyName:
+			hasDefault = true
+			policy = &defaultPolicy{}
+		case AcceleratorFitPolicyName:
+			policy = &AcceleratorFitPolicy{Client: c}
+		case CostPolicyName:
+			policy = &CostPolicy{Client: c, UseSpotPricing: config.UseSpotPricing}
+		case NamespacePreferencePolicyName:
+			policy = &NamespacePreferencePolicy{PreferredRuntimes: config.NamespacePreferredRuntimes}
+		default:
+			return nil, &ConfigurationError{Component: "scoring policy", Message: fmt.Sprintf("unknown policy %q", spec.Name)}
+		}
+		policies = append(policies, WeightedPolicy{Policy: policy, Weight: spec.Weight})
+	}
+
+	if !hasDefault {
+		policies = append([]WeightedPolicy{{Policy: &defaultPolicy{}, Weight: 1}}, policies...)
+	}
+
+	return policies, nil
+}
+
+// applyPolicies re-scores candidates with the configured policies.
+// Without configured policies the RuntimeScorer scores are kept unchanged.
+func (s *defaultSelector) applyPolicies(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, candidates []RuntimeMatch) {
+	if len(s.config.Policies) == 0 || len(candidates) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	totals := make([]float64, len(candidates))
+	for _, wp := range s.config.Policies {
+		scores, err := wp.Policy.Score(ctx, model, isvc, candidates)
+		if err != nil {
+			// A failing policy should not block selection, it simply does not contribute.
+			logger.Error(err, "Scoring policy failed, ignoring its contribution", "policy", wp.Policy.Name())
+			continue
+		}
+		for i := range candidates {
+			contribution := wp.Weight * scores[i]
+			totals[i] += contribution
+			if wp.Policy.Name() != DefaultPolicyName && contribution != 0 {
+				candidates[i].MatchDetails.Reasons = append(candidates[i].MatchDetails.Reasons,
+					fmt.Sprintf("%s policy +%.1f", wp.Policy.Name(), contribution))
+			}
+		}
+	}
+
+	for i := range candidates {
+		candidates[i].Score = int64(math.Round(totals[i]))
+	}
+}
+
+// defaultPolicy exposes the RuntimeScorer score (format/framework weight times priority) as a policy.
+type defaultPolicy struct{}
+
+// Name implements ScoringPolicy.
+func (p *defaultPolicy) Name() string {
+	return DefaultPolicyName
+}
+
+// Score implements ScoringPolicy by returning the base score computed during evaluation.
+func (p *defaultPolicy) Score(_ context.Context, _ *v1beta1.BaseModelSpec, _ *v1beta1.InferenceService, candidates []RuntimeMatch) ([]float64, error) {
+	scores := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		scores[i] = float64(candidate.Score)
+	}
+	return scores, nil
+}
+
+// AcceleratorFitPolicy favors runtimes whose supported AcceleratorClasses fit the model tightly
+// and currently have available nodes.
+type AcceleratorFitPolicy struct {
+	Client client.Client
+}
+
+// Name implements ScoringPolicy.
+func (p *AcceleratorFitPolicy) Name() string {
+	return AcceleratorFitPolicyName
+}
+
+// Score implements ScoringPolicy. A runtime scores the best fit among its accelerator classes,
+// where fit is required memory divided by accelerator memory, and zero if the model does not fit.
+func (p *AcceleratorFitPolicy) Score(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, candidates []RuntimeMatch) ([]float64, error) {
+	classes, err := listAcceleratorClasses(ctx, p.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		for _, ac := range candidateAcceleratorClasses(candidate, isvc, classes) {
+			if fit := acceleratorFit(candidate, model, ac); fit > scores[i] {
+				scores[i] = fit
+			}
+		}
+	}
+	return scores, nil
+}
+
+// acceleratorFit returns how well a model served by a runtime fits on an accelerator class.
+func acceleratorFit(candidate RuntimeMatch, model *v1beta1.BaseModelSpec, ac v1beta1.AcceleratorClass) float64 {
+	if ac.Status.AvailableNodes == 0 && len(ac.Status.Nodes) > 0 {
+		return 0
+	}
+	if ac.Spec.Capabilities.MemoryGB == nil {
+		return 0
+	}
+	capacityGB := float64(ac.Spec.Capabilities.MemoryGB.Value()) / (1024 * 1024 * 1024)
+	if capacityGB <= 0 {
+		return 0
+	}
+
+	requiredGB := requiredMemoryGB(candidate.Spec, model, ac.Name)
+	if requiredGB <= 0 {
+		// Nothing to compare against, every available accelerator fits equally well
+		return 1
+	}
+	if requiredGB > capacityGB {
+		return 0
+	}
+	return requiredGB / capacityGB
+}
+
+// requiredMemoryGB estimates per-accelerator memory for a model on a runtime, preferring the
+// accelerator-specific MinMemoryPerBillionParams over the runtime-wide MinMemory.
+func requiredMemoryGB(spec *v1beta1.ServingRuntimeSpec, model *v1beta1.BaseModelSpec, acceleratorClass string) float64 {
+	if spec == nil {
+		return 0
+	}
+	if model != nil && model.ModelParameterSize != nil {
+		params := parseModelSize(*model.ModelParameterSize) / 1_000_000_000
+		for _, format := range spec.SupportedModelFormats {
+			cfg, ok := format.AcceleratorConfig[acceleratorClass]
+			if !ok || cfg == nil || cfg.MinMemoryPerBillionParams == nil {
+				continue
+			}
+			required := params * float64(*cfg.MinMemoryPerBillionParams)
+			if cfg.TensorParallelismOverride != nil && cfg.TensorParallelismOverride.TensorParallelSize != nil && *cfg.TensorParallelismOverride.TensorParallelSize > 0 {
+				required /= float64(*cfg.TensorParallelismOverride.TensorParallelSize)
+			}
+			return required
+		}
+	}
+	if spec.AcceleratorRequirements != nil && spec.AcceleratorRequirements.MinMemory != nil {
+		return float64(*spec.AcceleratorRequirements.MinMemory)
+	}
+	return 0
+}
+
+// CostPolicy favors runtimes that can run on the cheapest AcceleratorClass according to AcceleratorCost.
+type CostPolicy struct {
+	Client client.Client
+
+	// UseSpotPricing prefers SpotPerHour over PerHour when both are set
+	UseSpotPricing bool
+}
+
+// Name implements ScoringPolicy.
+func (p *CostPolicy) Name() string {
+	return CostPolicyName
+}
+
+// Score implements ScoringPolicy. The cheapest candidate scores 1 and others score
+// cheapest cost divided by their own cost. Runtimes without cost data score 0.
+func (p *CostPolicy) Score(ctx context.Context, _ *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, candidates []RuntimeMatch) ([]float64, error) {
+	classes, err := listAcceleratorClasses(ctx, p.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]float64, len(candidates))
+	minCost := math.MaxFloat64
+	for i, candidate := range candidates {
+		for _, ac := range candidateAcceleratorClasses(candidate, isvc, classes) {
+			cost, ok := acceleratorHourlyCost(ac, p.UseSpotPricing)
+			if !ok {
+				continue
+			}
+			if costs[i] == 0 || cost < costs[i] {
+				costs[i] = cost
+			}
+		}
+		if costs[i] > 0 && costs[i] < minCost {
+			minCost = costs[i]
+		}
+	}
+
+	scores := make([]float64, len(candidates))
+	for i, cost := range costs {
+		if cost > 0 {
+			scores[i] = minCost / cost
+		}
+	}
+	return scores, nil
+}
+
+// acceleratorHourlyCost returns the hourly cost of an AcceleratorClass.
+func acceleratorHourlyCost(ac v1beta1.AcceleratorClass, useSpot bool) (float64, bool) {
+	cost := ac.Spec.Cost
+	if cost == nil {
+		return 0, false
+	}
+	if useSpot && cost.SpotPerHour != nil && cost.SpotPerHour.AsApproximateFloat64() > 0 {
+		return cost.SpotPerHour.AsApproximateFloat64(), true
+	}
+	if cost.PerHour != nil && cost.PerHour.AsApproximateFloat64() > 0 {
+		return cost.PerHour.AsApproximateFloat64(), true
+	}
+	return 0, false
+}
+
+// NamespacePreferencePolicy favors runtimes that platform admins prefer for a namespace.
+type NamespacePreferencePolicy struct {
+	// PreferredRuntimes lists runtime names per namespace, most preferred first
+	PreferredRuntimes map[string][]string
+}
+
+// Name implements ScoringPolicy.
+func (p *NamespacePreferencePolicy) Name() string {
+	return NamespacePreferencePolicyName
+}
+
+// Score implements ScoringPolicy. The first preferred runtime scores 1, and each following
+// one scores proportionally less. Runtimes that are not preferred score 0.
+func (p *NamespacePreferencePolicy) Score(_ context.Context, _ *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, candidates []RuntimeMatch) ([]float64, error) {
+	scores := make([]float64, len(candidates))
+	if isvc == nil {
+		return scores, nil
+	}
+	preferred := p.PreferredRuntimes[isvc.Namespace]
+	for i, candidate := range candidates {
+		for rank, name := range preferred {
+			if name == candidate.Name {
+				scores[i] = float64(len(preferred)-rank) / float64(len(preferred))
+				break
+			}
+		}
+	}
+	return scores, nil
+}
+
+// listAcceleratorClasses lists all AcceleratorClasses from the cache.
+func listAcceleratorClasses(ctx context.Context, c client.Client) ([]v1beta1.AcceleratorClass, error) {
+	if c == nil {
+		return nil, nil
+	}
+	list := &v1beta1.AcceleratorClassList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list AcceleratorClasses: %w", err)
+	}
+	return list.Items, nil
+}
+
+// candidateAcceleratorClasses returns the accelerator classes a runtime could be scheduled on,
+// narrowed to the class explicitly requested by the InferenceService when there is one.
+func candidateAcceleratorClasses(candidate RuntimeMatch, isvc *v1beta1.InferenceService, classes []v1beta1.AcceleratorClass) []v1beta1.AcceleratorClass {
+	requested := ""
+	if isvc != nil && isvc.Spec.AcceleratorSelector != nil && isvc.Spec.AcceleratorSelector.AcceleratorClass != nil {
+		requested = *isvc.Spec.AcceleratorSelector.AcceleratorClass
+	}
+
+	var result []v1beta1.AcceleratorClass
+	for _, ac := range classes {
+		if requested != "" && ac.Name != requested {
+			continue
+		}
+		if candidate.Spec != nil && !candidate.Spec.SupportsAcceleratorClass(ac.Name) {
+			continue
+		}
+		result = append(result, ac)
+	}
+	return result
+}
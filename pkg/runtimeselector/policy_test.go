@@ -0,0 +1,336 @@
+package runtimeselector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+)
+
+func policyCandidate(name string, score int64, spec *v1beta1.ServingRuntimeSpec) RuntimeMatch {
+	if spec == nil {
+		spec = &v1beta1.ServingRuntimeSpec{}
+	}
+	return RuntimeMatch{RuntimeSelection: RuntimeSelection{Name: name, Score: score, Spec: spec}}
+}
+
+func acceleratorClass(name string, memoryGi int64, perHour, spotPerHour string, availableNodes int32) *v1beta1.AcceleratorClass {
+	ac := &v1beta1.AcceleratorClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1beta1.AcceleratorClassSpec{
+			Capabilities: v1beta1.AcceleratorCapabilities{
+				MemoryGB: resource.NewQuantity(memoryGi*1024*1024*1024, resource.BinarySI),
+			},
+		},
+		Status: v1beta1.AcceleratorClassStatus{
+			AvailableNodes: availableNodes,
+			Nodes:          []string{"node-1"},
+		},
+	}
+	if perHour != "" || spotPerHour != "" {
+		ac.Spec.Cost = &v1beta1.AcceleratorCost{}
+		if perHour != "" {
+			ac.Spec.Cost.PerHour = ptr(resource.MustParse(perHour))
+		}
+		if spotPerHour != "" {
+			ac.Spec.Cost.SpotPerHour = ptr(resource.MustParse(spotPerHour))
+		}
+	}
+	return ac
+}
+
+func acceleratorSpec(classes ...string) *v1beta1.ServingRuntimeSpec {
+	return &v1beta1.ServingRuntimeSpec{
+		AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: classes},
+	}
+}
+
+func TestNewScoringPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *PolicyConfig
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:      "default policy added implicitly",
+			config:    &PolicyConfig{Policies: []PolicySpec{{Name: CostPolicyName, Weight: 5}}},
+			wantNames: []string{DefaultPolicyName, CostPolicyName},
+		},
+		{
+			name: "explicit default keeps order",
+			config: &PolicyConfig{Policies: []PolicySpec{
+				{Name: CostPolicyName, Weight: 2},
+				{Name: DefaultPolicyName, Weight: 0.5},
+			}},
+			wantNames: []string{CostPolicyName, DefaultPolicyName},
+		},
+		{
+			name:    "unknown policy",
+			config:  &PolicyConfig{Policies: []PolicySpec{{Name: "latency", Weight: 1}}},
+			wantErr: `unknown policy "latency"`,
+		},
+		{
+			name:    "negative weight",
+			config:  &PolicyConfig{Policies: []PolicySpec{{Name: CostPolicyName, Weight: -1}}},
+			wantErr: "negative weight",
+		},
+		{
+			name: "duplicate policy",
+			config: &PolicyConfig{Policies: []PolicySpec{
+				{Name: CostPolicyName, Weight: 1},
+				{Name: CostPolicyName, Weight: 2},
+			}},
+			wantErr: "configured more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies, err := NewScoringPolicies(createFakeClient(), tt.config)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, p := range policies {
+				names = append(names, p.Policy.Name())
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestAcceleratorFitPolicy(t *testing.T) {
+	classes := []*v1beta1.AcceleratorClass{
+		acceleratorClass("a100-40gb", 40, "", "", 2),
+		acceleratorClass("a100-80gb", 80, "", "", 2),
+		acceleratorClass("h100-busy", 80, "", "", 0),
+	}
+
+	tests := []struct {
+		name       string
+		model      *v1beta1.BaseModelSpec
+		isvc       *v1beta1.InferenceService
+		candidates []RuntimeMatch
+		want       []float64
+	}{
+		{
+			name:  "tighter fit scores higher",
+			model: &v1beta1.BaseModelSpec{ModelParameterSize: ptr("10B")},
+			candidates: []RuntimeMatch{
+				policyCandidate("rt-40", 10, &v1beta1.ServingRuntimeSpec{
+					AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"a100-40gb"}, MinMemory: ptr(int64(32))},
+				}),
+				policyCandidate("rt-80", 10, &v1beta1.ServingRuntimeSpec{
+					AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"a100-80gb"}, MinMemory: ptr(int64(32))},
+				}),
+			},
+			want: []float64{0.8, 0.4},
+		},
+		{
+			name:  "per billion params config takes precedence",
+			model: &v1beta1.BaseModelSpec{ModelParameterSize: ptr("10B")},
+			candidates: []RuntimeMatch{
+				policyCandidate("rt", 10, &v1beta1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1beta1.SupportedModelFormat{{
+						AcceleratorConfig: map[string]*v1beta1.AcceleratorModelConfig{
+							"a100-80gb": {MinMemoryPerBillionParams: ptr(int64(6))},
+						},
+					}},
+					AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"a100-80gb"}, MinMemory: ptr(int64(8))},
+				}),
+			},
+			want: []float64{0.75},
+		},
+		{
+			name:  "model does not fit",
+			model: &v1beta1.BaseModelSpec{ModelParameterSize: ptr("70B")},
+			candidates: []RuntimeMatch{
+				policyCandidate("rt", 10, &v1beta1.ServingRuntimeSpec{
+					AcceleratorRequirements: &v1beta1.AcceleratorRequirements{AcceleratorClasses: []string{"a100-40gb"}, MinMemory: ptr(int64(140))},
+				}),
+			},
+			want: []float64{0},
+		},
+		{
+			name:       "no available nodes",
+			model:      &v1beta1.BaseModelSpec{},
+			candidates: []RuntimeMatch{policyCandidate("rt", 10, acceleratorSpec("h100-busy"))},
+			want:       []float64{0},
+		},
+		{
+			name:  "requested accelerator class narrows choice",
+			model: &v1beta1.BaseModelSpec{},
+			isvc: &v1beta1.InferenceService{Spec: v1beta1.InferenceServiceSpec{
+				AcceleratorSelector: &v1beta1.AcceleratorSelector{AcceleratorClass: ptr("h100-busy")},
+			}},
+			candidates: []RuntimeMatch{policyCandidate("rt", 10, nil)},
+			want:       []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := createFakeClient()
+			for _, ac := range classes {
+				require.NoError(t, fakeClient.Create(context.Background(), ac.DeepCopy()))
+			}
+			policy := &AcceleratorFitPolicy{Client: fakeClient}
+			scores, err := policy.Score(context.Background(), tt.model, tt.isvc, tt.candidates)
+			require.NoError(t, err)
+			assert.InDeltaSlice(t, tt.want, scores, 0.001)
+		})
+	}
+}
+
+func TestCostPolicy(t *testing.T) {
+	classes := []*v1beta1.AcceleratorClass{
+		acceleratorClass("cheap", 40, "2", "1", 1),
+		acceleratorClass("expensive", 80, "8", "", 1),
+		acceleratorClass("unpriced", 80, "", "", 1),
+	}
+
+	tests := []struct {
+		name       string
+		useSpot    bool
+		candidates []RuntimeMatch
+		want       []float64
+	}{
+		{
+			name: "cheapest runtime scores highest",
+			candidates: []RuntimeMatch{
+				policyCandidate("rt-cheap", 10, acceleratorSpec("cheap")),
+				policyCandidate("rt-expensive", 10, acceleratorSpec("expensive")),
+			},
+			want: []float64{1, 0.25},
+		},
+		{
+			name:    "spot pricing",
+			useSpot: true,
+			candidates: []RuntimeMatch{
+				policyCandidate("rt-cheap", 10, acceleratorSpec("cheap")),
+				policyCandidate("rt-expensive", 10, acceleratorSpec("expensive")),
+			},
+			want: []float64{1, 0.125},
+		},
+		{
+			name: "runtime uses its cheapest class",
+			candidates: []RuntimeMatch{
+				policyCandidate("rt-any", 10, nil),
+				policyCandidate("rt-expensive", 10, acceleratorSpec("expensive")),
+			},
+			want: []float64{1, 0.25},
+		},
+		{
+			name: "no cost data",
+			candidates: []RuntimeMatch{
+				policyCandidate("rt-unpriced", 10, acceleratorSpec("unpriced")),
+			},
+			want: []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := createFakeClient()
+			for _, ac := range classes {
+				require.NoError(t, fakeClient.Create(context.Background(), ac.DeepCopy()))
+			}
+			policy := &CostPolicy{Client: fakeClient, UseSpotPricing: tt.useSpot}
+			scores, err := policy.Score(context.Background(), &v1beta1.BaseModelSpec{}, nil, tt.candidates)
+			require.NoError(t, err)
+			assert.InDeltaSlice(t, tt.want, scores, 0.001)
+		})
+	}
+}
+
+func TestNamespacePreferencePolicy(t *testing.T) {
+	preferred := map[string][]string{
+		"team-a": {"sglang", "vllm"},
+	}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		candidates []RuntimeMatch
+		want       []float64
+	}{
+		{
+			name:       "ordered preference",
+			namespace:  "team-a",
+			candidates: []RuntimeMatch{policyCandidate("vllm", 10, nil), policyCandidate("sglang", 10, nil), policyCandidate("trtllm", 10, nil)},
+			want:       []float64{0.5, 1, 0},
+		},
+		{
+			name:       "namespace without preferences",
+			namespace:  "team-b",
+			candidates: []RuntimeMatch{policyCandidate("vllm", 10, nil), policyCandidate("sglang", 10, nil)},
+			want:       []float64{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &NamespacePreferencePolicy{PreferredRuntimes: preferred}
+			isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace}}
+			scores, err := policy.Score(context.Background(), &v1beta1.BaseModelSpec{}, isvc, tt.candidates)
+			require.NoError(t, err)
+			assert.InDeltaSlice(t, tt.want, scores, 0.001)
+		})
+	}
+}
+
+func TestSelectRuntimeWithPolicies(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := createFakeClient()
+
+	for _, name := range []string{"rt-a", "rt-b"} {
+		require.NoError(t, fakeClient.Create(ctx, &v1beta1.ServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: v1beta1.ServingRuntimeSpec{
+				SupportedModelFormats: []v1beta1.SupportedModelFormat{
+					{ModelFormat: &v1beta1.ModelFormat{Name: "safetensors"}, AutoSelect: ptr(true)},
+				},
+			},
+		}))
+	}
+
+	model := &v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}}
+	isvc := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}}
+
+	// Without policies the tie is broken by name
+	selection, err := New(fakeClient).SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-a", selection.Name)
+
+	policies, err := NewScoringPolicies(fakeClient, &PolicyConfig{
+		Policies:                   []PolicySpec{{Name: NamespacePreferencePolicyName, Weight: 20}},
+		NamespacePreferredRuntimes: map[string][]string{"team-a": {"rt-b"}},
+	})
+	require.NoError(t, err)
+	config := NewConfig(fakeClient)
+	config.Policies = policies
+	selector := NewWithConfig(config)
+
+	selection, err = selector.SelectRuntime(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-b", selection.Name)
+	assert.Equal(t, int64(30), selection.Score)
+
+	explanation, err := selector.ExplainSelection(ctx, model, isvc)
+	require.NoError(t, err)
+	assert.Equal(t, "rt-b", explanation.Selected.Name)
+	assert.Contains(t, explanation.Selected.MatchDetails.Reasons, "namespacePreference policy +20.0")
+}
@@ -132,12 +132,9 @@ func (s *defaultSelector) GetCompatibleRuntimes(ctx context.Context, model *v1be
 		}
 	}
 
-	// Sort namespace and cluster matches separately
-	s.sortMatches(namespaceMatches, model)
-	s.sortMatches(clusterMatches, model)
-
-	// Append cluster matches after namespace matches (namespace-scoped have priority)
-	matches := append(namespaceMatches, clusterMatches...)
+	// Score with policies, sort each scope and append cluster matches after
+	// namespace matches (namespace-scoped have priority)
+	matches := s.rankMatches(ctx, model, isvc, namespaceMatches, clusterMatches)
 
 	logger.Info("Found compatible runtimes",
 		"model", model.ModelFormat.Name,
@@ -276,6 +273,20 @@ func (s *defaultSelector) evaluateRuntime(ctx context.Context, spec *v1beta1.Ser
 	}
 }
 
+// rankMatches applies the configured scoring policies to all matches, sorts namespace
+// and cluster matches separately, and returns namespace matches before cluster matches.
+func (s *defaultSelector) rankMatches(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, namespaceMatches, clusterMatches []RuntimeMatch) []RuntimeMatch {
+	// Policies normalize against all candidates, so score both scopes together
+	matches := append(namespaceMatches, clusterMatches...)
+	s.applyPolicies(ctx, model, isvc, matches)
+
+	namespaceMatches = matches[:len(namespaceMatches)]
+	clusterMatches = matches[len(namespaceMatches):]
+	s.sortMatches(namespaceMatches, model)
+	s.sortMatches(clusterMatches, model)
+	return matches
+}
+
 // sortMatches sorts runtime matches by score and other criteria.
 func (s *defaultSelector) sortMatches(matches []RuntimeMatch, model *v1beta1.BaseModelSpec) {
 	sort.Slice(matches, func(i, j int) bool {
@@ -16,6 +16,11 @@ type Selector interface {
 	// If no compatible runtime is found, it returns an error.
 	SelectRuntime(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*RuntimeSelection, error)
 
+	// ExplainSelection evaluates all runtimes for a model and returns the ranked
+	// candidates, the rejected runtimes with reasons, and why the winner was chosen.
+	// If no compatible runtime is found, the explanation is returned along with an error.
+	ExplainSelection(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*SelectionExplanation, error)
+
 	// GetCompatibleRuntimes returns all compatible runtimes sorted by priority.
 	// This is useful for debugging and for showing available options.
 	GetCompatibleRuntimes(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService, namespace string) ([]RuntimeMatch, error)
@@ -62,34 +67,34 @@ type RuntimeMatch struct {
 // MatchDetails contains detailed information about runtime-model compatibility.
 type MatchDetails struct {
 	// FormatMatch indicates if the model format is compatible
-	FormatMatch bool
+	FormatMatch bool `json:"formatMatch"`
 
 	// FrameworkMatch indicates if the model framework is compatible
-	FrameworkMatch bool
+	FrameworkMatch bool `json:"frameworkMatch"`
 
 	// SizeMatch indicates if the model size is within the runtime's supported range
-	SizeMatch bool
+	SizeMatch bool `json:"sizeMatch"`
 
 	// ArchitectureMatch indicates if the model architecture is compatible
-	ArchitectureMatch bool
+	ArchitectureMatch bool `json:"architectureMatch"`
 
 	// DiffusionPipelineMatch indicates if the diffusion pipeline metadata is compatible
-	DiffusionPipelineMatch bool
+	DiffusionPipelineMatch bool `json:"diffusionPipelineMatch"`
 
 	// QuantizationMatch indicates if the model quantization is compatible
-	QuantizationMatch bool
+	QuantizationMatch bool `json:"quantizationMatch"`
 
 	// Priority is the runtime's priority for this model format
-	Priority int32
+	Priority int32 `json:"priority"`
 
 	// Weight is the total weight used in scoring
-	Weight int64
+	Weight int64 `json:"weight"`
 
 	// AutoSelectEnabled indicates if this runtime can be auto-selected
-	AutoSelectEnabled bool
+	AutoSelectEnabled bool `json:"autoSelectEnabled"`
 
 	// Reasons contains human-readable reasons for match/mismatch
-	Reasons []string
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // RuntimeFetcher abstracts the fetching of runtime resources.
@@ -126,16 +131,16 @@ type RuntimeMatcher interface {
 // CompatibilityReport provides detailed compatibility analysis.
 type CompatibilityReport struct {
 	// IsCompatible indicates overall compatibility
-	IsCompatible bool
+	IsCompatible bool `json:"isCompatible"`
 
 	// MatchDetails provides detailed matching information
-	MatchDetails MatchDetails
+	MatchDetails MatchDetails `json:"matchDetails"`
 
 	// IncompatibilityReasons lists specific reasons why the runtime is incompatible
-	IncompatibilityReasons []string
+	IncompatibilityReasons []string `json:"incompatibilityReasons,omitempty"`
 
 	// Warnings lists non-critical compatibility concerns
-	Warnings []string
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // RuntimeScorer calculates scores for runtime-model pairs.
@@ -169,6 +174,10 @@ type Config struct {
 
 	// ModelFrameworkWeight is the default weight for model framework matching
 	ModelFrameworkWeight int64
+
+	// Policies are the weighted scoring policies used to rank compatible runtimes.
+	// When empty, runtimes are ranked by the RuntimeScorer score alone.
+	Policies []WeightedPolicy
 }
 
 // NewConfig creates a new Config with default values.
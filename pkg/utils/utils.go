@@ -96,6 +96,25 @@ func IsGPUEnabled(requirements v1.ResourceRequirements) bool {
 	return ok
 }
 
+// IsAcceleratorResource returns whether the extended resource name is an accelerator
+func IsAcceleratorResource(name string) bool {
+	switch {
+	// NVIDIA classic
+	case name == constants.NvidiaGPUResourceType:
+		return true
+	// NVIDIA MIG profiles (treat as accelerators; not equivalent to card count)
+	case strings.HasPrefix(name, "nvidia.com/mig-"):
+		return true
+	// AMD (common)
+	case name == "amd.com/gpu":
+		return true
+	// Intel (common plugin exposes under gpu.intel.com/*; skip memory-only resources)
+	case strings.HasPrefix(name, "gpu.intel.com/") && !strings.Contains(name, "memory"):
+		return true
+	}
+	return false
+}
+
 // FirstNonNilError returns the first non nil interface in the slice
 func FirstNonNilError(objects []error) error {
 	for _, object := range objects {
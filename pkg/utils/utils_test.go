@@ -427,6 +427,27 @@ func TestIsGpuEnabled(t *testing.T) {
 	}
 }
 
+func TestIsAcceleratorResource(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		name     string
+		expected bool
+	}{
+		"NvidiaGPU":   {name: constants.NvidiaGPUResourceType, expected: true},
+		"NvidiaMIG":   {name: "nvidia.com/mig-1g.10gb", expected: true},
+		"AmdGPU":      {name: "amd.com/gpu", expected: true},
+		"IntelGPU":    {name: "gpu.intel.com/i915", expected: true},
+		"IntelMemory": {name: "gpu.intel.com/memory.max", expected: false},
+		"RDMA":        {name: "rdma/ib", expected: false},
+		"CPU":         {name: "cpu", expected: false},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(IsAcceleratorResource(scenario.name)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
 func TestFirstNonNilError(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	scenarios := map[string]struct {
@@ -7,7 +7,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"text/template"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -102,11 +104,29 @@ func (v *InferenceServiceValidator) validateInferenceService(ctx context.Context
 		return allWarnings, err
 	}
 
+	if err := validateLLMScaleMetrics(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	if err := validatePDAutoscaling(isvc); err != nil {
+		return allWarnings, err
+	}
+
 	// New validation logic for Engine/Decoder architecture
 	if err := validateEngineDecoderConfiguration(isvc); err != nil {
 		return allWarnings, err
 	}
 
+	if err := validateCanaryAnalysis(isvc); err != nil {
+		return allWarnings, err
+	}
+
+	warnings, err := validateMCPConfiguration(isvc)
+	if err != nil {
+		return allWarnings, err
+	}
+	allWarnings = append(allWarnings, warnings...)
+
 	// Validate that referenced model exists (for new Engine architecture using isvc.Spec.Model)
 	if err := v.validateModelExists(ctx, isvc); err != nil {
 		return allWarnings, err
@@ -131,6 +151,47 @@ func validateInferenceServiceName(isvc *v1beta1.InferenceService) error {
 	return nil
 }
 
+// validateCanaryAnalysis validates the canary analysis thresholds and query templates of the components
+func validateCanaryAnalysis(isvc *v1beta1.InferenceService) error {
+	components := map[v1beta1.ComponentType]*v1beta1.ComponentExtensionSpec{}
+	if isvc.Spec.Engine != nil {
+		components[v1beta1.EngineComponent] = &isvc.Spec.Engine.ComponentExtensionSpec
+	}
+	if isvc.Spec.Decoder != nil {
+		components[v1beta1.DecoderComponent] = &isvc.Spec.Decoder.ComponentExtensionSpec
+	}
+	if isvc.Spec.Router != nil {
+		components[v1beta1.RouterComponent] = &isvc.Spec.Router.ComponentExtensionSpec
+	}
+
+	for component, spec := range components {
+		analysis := spec.CanaryAnalysis
+		if analysis == nil {
+			continue
+		}
+		if analysis.MaxErrorRateIncrease != "" {
+			increase, err := strconv.ParseFloat(analysis.MaxErrorRateIncrease, 64)
+			if err != nil || increase < 0 || increase > 1 {
+				return fmt.Errorf("invalid %s canary analysis maxErrorRateIncrease %q: must be a number between 0 and 1",
+					component, analysis.MaxErrorRateIncrease)
+			}
+		}
+		if analysis.Queries == nil {
+			continue
+		}
+		for name, query := range map[string]string{
+			"errorRate":        analysis.Queries.ErrorRate,
+			"timeToFirstToken": analysis.Queries.TimeToFirstToken,
+			"throughput":       analysis.Queries.Throughput,
+		} {
+			if _, err := template.New(name).Parse(query); err != nil {
+				return fmt.Errorf("invalid %s canary analysis %s query: %v", component, name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Validation of isvc autoscaler class
 func validateInferenceServiceAutoscaler(isvc *v1beta1.InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -162,6 +223,84 @@ func validateInferenceServiceAutoscaler(isvc *v1beta1.InferenceService) error {
 	return nil
 }
 
+// validateLLMScaleMetrics validates that the components scaled on the serving engine metrics are scaled by KEDA, which
+// is the only autoscaler reading them
+func validateLLMScaleMetrics(isvc *v1beta1.InferenceService) error {
+	components := map[v1beta1.ComponentType]*v1beta1.ComponentExtensionSpec{
+		v1beta1.PredictorComponent: &isvc.Spec.Predictor.ComponentExtensionSpec,
+	}
+	if isvc.Spec.Engine != nil {
+		components[v1beta1.EngineComponent] = &isvc.Spec.Engine.ComponentExtensionSpec
+	}
+	if isvc.Spec.Decoder != nil {
+		components[v1beta1.DecoderComponent] = &isvc.Spec.Decoder.ComponentExtensionSpec
+	}
+	if isvc.Spec.Router != nil {
+		components[v1beta1.RouterComponent] = &isvc.Spec.Router.ComponentExtensionSpec
+	}
+
+	annotations := isvc.ObjectMeta.Annotations
+	for component, spec := range components {
+		if spec.ScaleMetric == nil || !spec.ScaleMetric.IsLLMMetric() {
+			continue
+		}
+		if class, ok := annotations[constants.AutoscalerClass]; ok && constants.AutoscalerClassType(class) != constants.AutoscalerClassKEDA {
+			return fmt.Errorf("%s scale metric [%s] requires the [%s] autoscaler class, got [%s]",
+				component, *spec.ScaleMetric, constants.AutoscalerClassKEDA, class)
+		}
+		if constants.DeploymentModeType(annotations[constants.DeploymentMode]) == constants.Serverless {
+			return fmt.Errorf("%s scale metric [%s] is not supported in %s deployment mode",
+				component, *spec.ScaleMetric, constants.Serverless)
+		}
+	}
+	return nil
+}
+
+// validatePDAutoscaling validates the ratio band and targets of the coordinated autoscaling of the engine and decoder
+func validatePDAutoscaling(isvc *v1beta1.InferenceService) error {
+	spec := isvc.Spec.PDAutoscaling
+	if spec == nil {
+		return nil
+	}
+	if isvc.Spec.Engine == nil || isvc.Spec.Decoder == nil {
+		return fmt.Errorf("pdAutoscaling requires both engine and decoder")
+	}
+	for component, minReplicas := range map[v1beta1.ComponentType]*int{
+		v1beta1.EngineComponent:  isvc.Spec.Engine.MinReplicas,
+		v1beta1.DecoderComponent: isvc.Spec.Decoder.MinReplicas,
+	} {
+		if minReplicas != nil && *minReplicas == 0 {
+			return fmt.Errorf("pdAutoscaling does not scale the %s to zero, minReplicas must be at least 1", component)
+		}
+	}
+
+	values := map[string]float64{}
+	for name, value := range map[string]string{
+		"minRatio":                 spec.MinRatio,
+		"maxRatio":                 spec.MaxRatio,
+		"prefillQueueDepth":        spec.PrefillQueueDepth,
+		"decodeKVCacheUtilization": spec.DecodeKVCacheUtilization,
+	} {
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("invalid pdAutoscaling %s %q: must be a positive number", name, value)
+		}
+		values[name] = parsed
+	}
+	if minRatio, ok := values["minRatio"]; ok {
+		if maxRatio, ok := values["maxRatio"]; ok && minRatio > maxRatio {
+			return fmt.Errorf("invalid pdAutoscaling ratio band: minRatio %q is greater than maxRatio %q", spec.MinRatio, spec.MaxRatio)
+		}
+	}
+	if utilization, ok := values["decodeKVCacheUtilization"]; ok && utilization > 1 {
+		return fmt.Errorf("invalid pdAutoscaling decodeKVCacheUtilization %q: must be a fraction between 0 and 1", spec.DecodeKVCacheUtilization)
+	}
+	return nil
+}
+
 // Validate of autoscaler HPA metrics
 func validateHPAMetrics(metric v1beta1.ScaleMetric) error {
 	for _, item := range constants.AutoscalerAllowedMetricsList {
@@ -346,6 +485,36 @@ func validateEngineDecoderConfiguration(isvc *v1beta1.InferenceService) error {
 	return nil
 }
 
+// validateMCPConfiguration validates the MCP servers and route of an InferenceService
+func validateMCPConfiguration(isvc *v1beta1.InferenceService) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	seen := map[string]bool{}
+	for _, server := range isvc.Spec.MCPServers {
+		if seen[server.ServerRef.Name] {
+			return warnings, fmt.Errorf("MCP server %s is listed more than once", server.ServerRef.Name)
+		}
+		seen[server.ServerRef.Name] = true
+	}
+
+	config := isvc.Spec.MCPRoute
+	if config == nil {
+		return warnings, nil
+	}
+	if config.RouteRef == nil {
+		if len(isvc.Spec.MCPServers) == 0 {
+			warnings = append(warnings, "mcpRoute is specified without mcpServers or routeRef; it will be ignored")
+		}
+		return warnings, nil
+	}
+	if len(isvc.Spec.MCPServers) > 0 {
+		return warnings, fmt.Errorf("mcpServers and mcpRoute.routeRef are mutually exclusive")
+	}
+	if len(config.Matches) > 0 || !equality.Semantic.DeepEqual(config.MCPRoutePolicy, v1beta1.MCPRoutePolicy{}) {
+		warnings = append(warnings, fmt.Sprintf("mcpRoute.routeRef is set; matches and policies are taken from MCPRoute %s and the ones specified are ignored", config.RouteRef.Name))
+	}
+	return warnings, nil
+}
+
 // validateModelExists validates that the referenced model (BaseModel or ClusterBaseModel) exists
 func (v *InferenceServiceValidator) validateModelExists(ctx context.Context, isvc *v1beta1.InferenceService) error {
 	// Check new architecture model reference (isvc.Spec.Model)
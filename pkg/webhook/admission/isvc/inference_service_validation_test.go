@@ -507,6 +507,73 @@ func TestInferenceService_EngineDecoderValidation(t *testing.T) {
 	}
 }
 
+func TestValidateMCPConfiguration(t *testing.T) {
+	search := v1beta1.MCPServerReference{ServerRef: v1.LocalObjectReference{Name: "search"}}
+	routeRef := &v1.LocalObjectReference{Name: "shared"}
+
+	tests := []struct {
+		name         string
+		mcpServers   []v1beta1.MCPServerReference
+		mcpRoute     *v1beta1.MCPRouteConfig
+		wantErr      string
+		wantWarnings int
+	}{
+		{
+			name: "no MCP configuration",
+		},
+		{
+			name:       "servers with route policy",
+			mcpServers: []v1beta1.MCPServerReference{search},
+			mcpRoute:   &v1beta1.MCPRouteConfig{MCPRoutePolicy: v1beta1.MCPRoutePolicy{RateLimit: &v1beta1.MCPRateLimit{}}},
+		},
+		{
+			name:     "route reference",
+			mcpRoute: &v1beta1.MCPRouteConfig{RouteRef: routeRef},
+		},
+		{
+			name:       "duplicate servers",
+			mcpServers: []v1beta1.MCPServerReference{search, search},
+			wantErr:    "listed more than once",
+		},
+		{
+			name:       "servers and route reference",
+			mcpServers: []v1beta1.MCPServerReference{search},
+			mcpRoute:   &v1beta1.MCPRouteConfig{RouteRef: routeRef},
+			wantErr:    "mutually exclusive",
+		},
+		{
+			name:         "route reference ignores policies",
+			mcpRoute:     &v1beta1.MCPRouteConfig{RouteRef: routeRef, MCPRoutePolicy: v1beta1.MCPRoutePolicy{RateLimit: &v1beta1.MCPRateLimit{}}},
+			wantWarnings: 1,
+		},
+		{
+			name:         "route policy without servers",
+			mcpRoute:     &v1beta1.MCPRouteConfig{MCPRoutePolicy: v1beta1.MCPRoutePolicy{RateLimit: &v1beta1.MCPRateLimit{}}},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-isvc", Namespace: "default"},
+				Spec: v1beta1.InferenceServiceSpec{
+					MCPServers: tt.mcpServers,
+					MCPRoute:   tt.mcpRoute,
+				},
+			}
+
+			warnings, err := validateMCPConfiguration(isvc)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, warnings, tt.wantWarnings)
+		})
+	}
+}
+
 func TestHasFullRunnerConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -2502,3 +2569,190 @@ func TestInferenceService_KEDAAutoscalerIntegration(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestValidateCanaryAnalysis(t *testing.T) {
+	tests := []struct {
+		name        string
+		analysis    *v1beta1.CanaryAnalysisSpec
+		expectedErr string
+	}{
+		{
+			name: "no canary analysis",
+		},
+		{
+			name: "valid canary analysis",
+			analysis: &v1beta1.CanaryAnalysisSpec{
+				StepPercent:          20,
+				MaxErrorRateIncrease: "0.01",
+				Queries: &v1beta1.CanaryAnalysisQueries{
+					Throughput: `sum(rate(tokens_total{app="{{.App}}"}[{{.Window}}]))`,
+				},
+			},
+		},
+		{
+			name:        "error rate increase is not a number",
+			analysis:    &v1beta1.CanaryAnalysisSpec{MaxErrorRateIncrease: "one percent"},
+			expectedErr: "invalid engine canary analysis maxErrorRateIncrease",
+		},
+		{
+			name:        "error rate increase is not a fraction",
+			analysis:    &v1beta1.CanaryAnalysisSpec{MaxErrorRateIncrease: "5"},
+			expectedErr: "must be a number between 0 and 1",
+		},
+		{
+			name: "query is not a valid template",
+			analysis: &v1beta1.CanaryAnalysisSpec{
+				Queries: &v1beta1.CanaryAnalysisQueries{ErrorRate: `errors{app="{{.App"}`},
+			},
+			expectedErr: "invalid engine canary analysis errorRate query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Engine: &v1beta1.EngineSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{CanaryAnalysis: tt.analysis},
+					},
+				},
+			}
+			err := validateCanaryAnalysis(isvc)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestValidateLLMScaleMetrics(t *testing.T) {
+	kvCache := v1beta1.MetricKVCache
+	cpu := v1beta1.MetricCPU
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		scaleMetric *v1beta1.ScaleMetric
+		expectedErr string
+	}{
+		{
+			name: "no scale metric",
+		},
+		{
+			name:        "resource metric with HPA",
+			annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassHPA)},
+			scaleMetric: &cpu,
+		},
+		{
+			name:        "LLM metric with the default autoscaler class",
+			scaleMetric: &kvCache,
+		},
+		{
+			name:        "LLM metric with KEDA",
+			annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassKEDA)},
+			scaleMetric: &kvCache,
+		},
+		{
+			name:        "LLM metric with HPA",
+			annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassHPA)},
+			scaleMetric: &kvCache,
+			expectedErr: "engine scale metric [kv-cache] requires the [keda] autoscaler class, got [hpa]",
+		},
+		{
+			name:        "LLM metric in Serverless mode",
+			annotations: map[string]string{constants.DeploymentMode: string(constants.Serverless)},
+			scaleMetric: &kvCache,
+			expectedErr: "engine scale metric [kv-cache] is not supported in Serverless deployment mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec: v1beta1.InferenceServiceSpec{
+					Engine: &v1beta1.EngineSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{ScaleMetric: tt.scaleMetric},
+					},
+				},
+			}
+			err := validateLLMScaleMetrics(isvc)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidatePDAutoscaling(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        *v1beta1.PDAutoscalingSpec
+		noDecoder   bool
+		minReplicas *int
+		expectedErr string
+	}{
+		{
+			name: "no coordinated autoscaling",
+		},
+		{
+			name: "valid ratio band and targets",
+			spec: &v1beta1.PDAutoscalingSpec{MinRatio: "0.5", MaxRatio: "2", PrefillQueueDepth: "10", DecodeKVCacheUtilization: "0.75"},
+		},
+		{
+			name:        "engine without decoder",
+			spec:        &v1beta1.PDAutoscalingSpec{},
+			noDecoder:   true,
+			expectedErr: "pdAutoscaling requires both engine and decoder",
+		},
+		{
+			name:        "component scaled to zero",
+			spec:        &v1beta1.PDAutoscalingSpec{},
+			minReplicas: GetIntReference(0),
+			expectedErr: "minReplicas must be at least 1",
+		},
+		{
+			name:        "ratio is not a number",
+			spec:        &v1beta1.PDAutoscalingSpec{MinRatio: "half"},
+			expectedErr: `invalid pdAutoscaling minRatio "half": must be a positive number`,
+		},
+		{
+			name:        "inverted ratio band",
+			spec:        &v1beta1.PDAutoscalingSpec{MinRatio: "2", MaxRatio: "1"},
+			expectedErr: `invalid pdAutoscaling ratio band: minRatio "2" is greater than maxRatio "1"`,
+		},
+		{
+			name:        "KV cache utilization is not a fraction",
+			spec:        &v1beta1.PDAutoscalingSpec{DecodeKVCacheUtilization: "80"},
+			expectedErr: `invalid pdAutoscaling decodeKVCacheUtilization "80": must be a fraction between 0 and 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				Spec: v1beta1.InferenceServiceSpec{
+					Engine: &v1beta1.EngineSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{MinReplicas: tt.minReplicas},
+					},
+					Decoder:       &v1beta1.DecoderSpec{},
+					PDAutoscaling: tt.spec,
+				},
+			}
+			if tt.noDecoder {
+				isvc.Spec.Decoder = nil
+			}
+			err := validatePDAutoscaling(isvc)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
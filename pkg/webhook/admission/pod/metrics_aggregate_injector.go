@@ -14,8 +14,20 @@ import (
 const (
 	defaultKserveContainerPrometheusPort = "8080"
 	MetricsAggregatorConfigMapKeyName    = "metricsAggregator"
+	// metricsPortName is the name of the port of a sidecar whose metrics are aggregated with the ome-container metrics
+	metricsPortName = "metrics"
 )
 
+// metricsAggregateTarget is an endpoint scraped by queue-proxy in addition to its own metrics, see cmd/qpext
+type metricsAggregateTarget struct {
+	Name           string            `json:"name"`
+	Host           string            `json:"host,omitempty"`
+	Port           string            `json:"port"`
+	Path           string            `json:"path,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	TimeoutSeconds float64           `json:"timeoutSeconds,omitempty"`
+}
+
 type MetricsAggregator struct {
 	EnableMetricAggregation  string `json:"enableMetricAggregation"`
 	EnablePrometheusScraping string `json:"enablePrometheusScraping"`
@@ -34,7 +46,7 @@ func newMetricsAggregator(configMap *v1.ConfigMap) (*MetricsAggregator, error) {
 	return ma, nil
 }
 
-func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
+func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) error {
 	for i, container := range pod.Spec.Containers {
 		if container.Name == "queue-proxy" {
 			// The ome-container prometheus port/path is inherited from the ClusterServingRuntime YAML.
@@ -54,6 +66,19 @@ func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsPortEnvVarKey, Value: omeContainerPromPort})
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsPathEnvVarKey, Value: omeContainerPromPath})
 
+			// Sidecars and multi-node workers are scraped as additional targets
+			targets, err := getMetricsAggregateTargets(pod, omeContainerPromPort, omeContainerPromPath)
+			if err != nil {
+				return err
+			}
+			if len(targets) > 1 {
+				targetsJSON, err := json.Marshal(targets)
+				if err != nil {
+					return err
+				}
+				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.ContainerPrometheusMetricsTargetsEnvVarKey, Value: string(targetsJSON)})
+			}
+
 			// Set the port that queue-proxy will use to expose the aggregate metrics.
 			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)})
 
@@ -64,6 +89,43 @@ func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
 			})
 		}
 	}
+	return nil
+}
+
+// getMetricsAggregateTargets returns the ome-container, the containers exposing a port named metrics, labeled with their
+// container name, and the targets listed in the prometheus.ome.io/targets annotation. The ome-container metrics are not
+// labeled, so that they keep the series they have when it is the only target.
+func getMetricsAggregateTargets(pod *v1.Pod, omeContainerPromPort, omeContainerPromPath string) ([]metricsAggregateTarget, error) {
+	targets := []metricsAggregateTarget{{Name: constants.MainContainerName, Port: omeContainerPromPort, Path: omeContainerPromPath}}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == "queue-proxy" || container.Name == constants.MainContainerName {
+			continue
+		}
+		for _, port := range container.Ports {
+			if port.Name == metricsPortName {
+				targets = append(targets, metricsAggregateTarget{
+					Name:   container.Name,
+					Port:   strconv.Itoa(int(port.ContainerPort)),
+					Path:   constants.DefaultPrometheusPath,
+					Labels: map[string]string{"container": container.Name},
+				})
+			}
+		}
+	}
+
+	if value, ok := pod.ObjectMeta.Annotations[constants.ContainerPrometheusTargetsKey]; ok {
+		var annotated []metricsAggregateTarget
+		if err := json.Unmarshal([]byte(value), &annotated); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", constants.ContainerPrometheusTargetsKey, err)
+		}
+		for i, target := range annotated {
+			if target.Port == "" {
+				return nil, fmt.Errorf("invalid %s annotation: target %d has no port", constants.ContainerPrometheusTargetsKey, i)
+			}
+		}
+		targets = append(targets, annotated...)
+	}
+	return targets, nil
 }
 
 // InjectMetricsAggregator looks for the annotations to enable aggregate ome-container and queue-proxy metrics and
@@ -79,7 +141,9 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 		enableMetricAggregation = ma.EnableMetricAggregation
 	}
 	if enableMetricAggregation == "true" {
-		setMetricAggregationEnvVarsAndPorts(pod)
+		if err := setMetricAggregationEnvVarsAndPorts(pod); err != nil {
+			return err
+		}
 	}
 
 	// Handle setting the pod prometheus annotations
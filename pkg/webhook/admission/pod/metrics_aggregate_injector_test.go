@@ -1,9 +1,11 @@
 package pod
 
 import (
+	"encoding/json"
 	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmp"
@@ -307,3 +309,61 @@ func TestInjectMetricsAggregator(t *testing.T) {
 		}
 	}
 }
+
+func TestGetMetricsAggregateTargets(t *testing.T) {
+	scenarios := map[string]struct {
+		pod             *v1.Pod
+		expectedTargets string
+		expectedErr     bool
+	}{
+		"OmeContainerOnly": {
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: constants.MainContainerName}, {Name: "queue-proxy"}},
+				},
+			},
+			expectedTargets: `[{"name":"ome-container","port":"8080","path":"/metrics"}]`,
+		},
+		"SidecarsAndWorkers": {
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						constants.ContainerPrometheusTargetsKey: `[{"name":"worker-1","host":"llama-0-1.llama","port":"8080","labels":{"node":"1"}}]`,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Name: constants.MainContainerName, Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 8080}}},
+						{Name: "queue-proxy", Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 9091}}},
+						{Name: "serving-agent", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8000}, {Name: "metrics", ContainerPort: 9000}}},
+					},
+				},
+			},
+			expectedTargets: `[{"name":"ome-container","port":"8080","path":"/metrics"},` +
+				`{"name":"serving-agent","port":"9000","path":"/metrics","labels":{"container":"serving-agent"}},` +
+				`{"name":"worker-1","host":"llama-0-1.llama","port":"8080","labels":{"node":"1"}}]`,
+		},
+		"InvalidAnnotation": {
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{constants.ContainerPrometheusTargetsKey: `[{"name":"worker-1"}]`},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			targets, err := getMetricsAggregateTargets(scenario.pod, "8080", constants.DefaultPrometheusPath)
+			if scenario.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			targetsJSON, err := json.Marshal(targets)
+			assert.NoError(t, err)
+			assert.JSONEq(t, scenario.expectedTargets, string(targetsJSON))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package xet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
+)
+
+// hubProgressThrottle is the interval of the progress updates forwarded to the hub
+const hubProgressThrottle = 200 * time.Millisecond
+
+// HubDownloader downloads the files that hub.SnapshotDownload finds stored on Xet. Chunks are deduplicated against
+// the chunk cache under the cache directory of its configuration, which is shared by all the downloads.
+type HubDownloader struct {
+	config Config
+}
+
+var _ hub.XetDownloader = (*HubDownloader)(nil)
+
+// NewHubDownloader creates a hub downloader with the given configuration, the endpoint and token of each request
+// taking precedence over the configured ones
+func NewHubDownloader(config *Config) *HubDownloader {
+	if config == nil {
+		config = defaultConfig()
+	}
+	return &HubDownloader{config: *config}
+}
+
+// DownloadFile downloads a file with its own client: the progress callback of a client covers all its operations,
+// one client per file keeps the progress of concurrent downloads apart
+func (d *HubDownloader) DownloadFile(ctx context.Context, req *hub.XetFileRequest, progress hub.Progress) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("download request cannot be nil")
+	}
+
+	config := d.config
+	if req.Endpoint != "" {
+		config.Endpoint = req.Endpoint
+	}
+	if req.Token != "" {
+		config.Token = req.Token
+	}
+
+	client, err := NewClient(&config)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if progress != nil {
+		var mu sync.Mutex
+		var reported uint64
+		if err := client.SetProgressHandler(func(update ProgressUpdate) {
+			mu.Lock()
+			defer mu.Unlock()
+			if update.CompletedBytes > reported {
+				progress.Update(int64(update.CompletedBytes - reported))
+				reported = update.CompletedBytes
+			}
+		}, hubProgressThrottle); err != nil {
+			return "", fmt.Errorf("failed to set progress handler: %w", err)
+		}
+	}
+
+	return client.DownloadFileWithContext(ctx, &DownloadRequest{
+		RepoID:   req.RepoID,
+		RepoType: req.RepoType,
+		Revision: req.Revision,
+		Filename: req.Filename,
+		LocalDir: req.LocalDir,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sgl-project/ome/pkg/hfutil/hub"
 	"github.com/sgl-project/ome/pkg/logging"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
@@ -57,3 +58,20 @@ var Module = fx.Options(
 		})
 	}),
 )
+
+// HubDownloaderModule provides the hub client with a Xet downloader, so that hub snapshots download the files stored
+// on Xet by chunks
+var HubDownloaderModule = fx.Provide(
+	func(v *viper.Viper, params HubParams) (hub.XetDownloader, error) {
+		config, err := NewConfig(
+			WithViper(v),
+			WithAppParams(params),
+			WithLogger(params.Logger),
+			WithDefaults(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error creating hub config: %+v", err)
+		}
+		return NewHubDownloader(config), nil
+	},
+)
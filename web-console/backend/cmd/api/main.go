@@ -6,12 +6,16 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/go-logr/zapr"
 	"github.com/sgl-project/ome/web-console/backend/internal/api"
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"go.uber.org/zap"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func main() {
@@ -22,6 +26,9 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// The runtime and accelerator class selectors shared with the controller log through controller-runtime
+	ctrllog.SetLogger(zapr.NewLogger(logger))
+
 	logger.Info("Starting OME Web Console API Server")
 
 	// Initialize Kubernetes client
@@ -30,6 +37,18 @@ func main() {
 		logger.Fatal("Failed to create Kubernetes client", zap.Error(err))
 	}
 
+	// Restrict the console to a set of namespaces
+	if namespaces := os.Getenv("ALLOWED_NAMESPACES"); namespaces != "" {
+		k8sClient.SetAllowedNamespaces(strings.Split(namespaces, ","))
+		logger.Info("Restricting the console to namespaces", zap.String("namespaces", namespaces))
+	}
+
+	// Initialize authentication
+	authenticator, err := auth.NewAuthenticator(context.Background(), auth.ConfigFromEnv(), k8sClient.Clientset, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize authentication", zap.Error(err))
+	}
+
 	// Setup informers with event handlers
 	k8sClient.SetupInformers()
 
@@ -41,7 +60,7 @@ func main() {
 	logger.Info("Informers started and caches synced successfully")
 
 	// Create API server
-	server := api.NewServer(k8sClient, logger)
+	server := api.NewServer(k8sClient, authenticator, logger)
 
 	// Setup routes
 	router := server.SetupRoutes()
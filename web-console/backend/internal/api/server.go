@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
 	"github.com/sgl-project/ome/web-console/backend/internal/handlers"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"github.com/sgl-project/ome/web-console/backend/internal/middleware"
@@ -14,15 +15,18 @@ import (
 
 // Server wraps the HTTP server and dependencies
 type Server struct {
-	k8sClient *k8s.Client
-	logger    *zap.Logger
+	k8sClient     *k8s.Client
+	authenticator auth.Authenticator
+	logger        *zap.Logger
 }
 
 // NewServer creates a new API server instance
-func NewServer(k8sClient *k8s.Client, logger *zap.Logger) *Server {
+// A nil authenticator disables authentication, requests then act with the service account of the console
+func NewServer(k8sClient *k8s.Client, authenticator auth.Authenticator, logger *zap.Logger) *Server {
 	return &Server{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:     k8sClient,
+		authenticator: authenticator,
+		logger:        logger,
 	}
 }
 
@@ -60,8 +64,10 @@ func (s *Server) SetupRoutes() *gin.Engine {
 		})
 	})
 
-	// API v1 routes
+	// API v1 routes, acting as the authenticated user. Mutations are audited, including the unauthenticated ones.
 	v1 := router.Group("/api/v1")
+	v1.Use(middleware.Audit(s.logger))
+	v1.Use(middleware.Authenticate(s.authenticator, s.logger))
 	{
 		// ClusterBaseModel endpoints (cluster-scoped)
 		modelsHandler := handlers.NewModelsHandler(s.k8sClient, s.logger)
@@ -121,6 +127,9 @@ func (s *Server) SetupRoutes() *gin.Engine {
 			services.PUT("/:name", servicesHandler.Update)
 			services.DELETE("/:name", servicesHandler.Delete)
 			services.GET("/:name/status", servicesHandler.GetStatus)
+			services.GET("/:name/pods", servicesHandler.Pods)
+			services.GET("/:name/logs", servicesHandler.Logs)
+			services.GET("/:name/metrics", servicesHandler.Metrics)
 		}
 
 		// Accelerators endpoints
@@ -129,6 +138,34 @@ func (s *Server) SetupRoutes() *gin.Engine {
 		{
 			accelerators.GET("", acceleratorsHandler.List)
 			accelerators.GET("/:name", acceleratorsHandler.Get)
+			accelerators.POST("", acceleratorsHandler.Create)
+			accelerators.PUT("/:name", acceleratorsHandler.Update)
+			accelerators.DELETE("/:name", acceleratorsHandler.Delete)
+		}
+
+		// FineTunedWeight endpoints (cluster-scoped)
+		fineTunedWeightsHandler := handlers.NewFineTunedWeightsHandler(s.k8sClient, s.logger)
+		fineTunedWeights := v1.Group("/finetunedweights")
+		{
+			fineTunedWeights.GET("", fineTunedWeightsHandler.List)
+			fineTunedWeights.GET("/:name", fineTunedWeightsHandler.Get)
+			fineTunedWeights.POST("", fineTunedWeightsHandler.Create)
+			fineTunedWeights.PUT("/:name", fineTunedWeightsHandler.Update)
+			fineTunedWeights.DELETE("/:name", fineTunedWeightsHandler.Delete)
+		}
+
+		// BenchmarkJob endpoints
+		benchmarksHandler := handlers.NewBenchmarksHandler(s.k8sClient, s.logger)
+		benchmarks := v1.Group("/benchmarks")
+		{
+			benchmarks.GET("", benchmarksHandler.List)
+			benchmarks.GET("/:name", benchmarksHandler.Get)
+			benchmarks.POST("", benchmarksHandler.Create)
+			benchmarks.PUT("/:name", benchmarksHandler.Update)
+			benchmarks.DELETE("/:name", benchmarksHandler.Delete)
+			benchmarks.GET("/:name/status", benchmarksHandler.GetStatus)
+			benchmarks.GET("/:name/results", benchmarksHandler.ListResults)
+			benchmarks.GET("/:name/results/*file", benchmarksHandler.GetResult)
 		}
 
 		// Validation endpoints
@@ -141,7 +178,7 @@ func (s *Server) SetupRoutes() *gin.Engine {
 		}
 
 		// HuggingFace integration endpoints
-		hfHandler := handlers.NewHuggingFaceHandler(s.logger)
+		hfHandler := handlers.NewHuggingFaceHandler(s.k8sClient, s.logger)
 		hf := v1.Group("/huggingface")
 		{
 			hf.GET("/models/search", hfHandler.SearchModels)
@@ -149,6 +186,7 @@ func (s *Server) SetupRoutes() *gin.Engine {
 			hf.GET("/models/:modelId/:modelName/info", hfHandler.GetModelInfo)
 			hf.GET("/models/:modelId/config", hfHandler.GetModelConfig)
 			hf.GET("/models/:modelId/:modelName/config", hfHandler.GetModelConfig)
+			hf.POST("/import", hfHandler.ImportModel)
 		}
 
 		// Server-Sent Events endpoint for real-time updates
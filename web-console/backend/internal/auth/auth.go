@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ModeNone disables authentication, the console acts with its own service account
+	ModeNone = "none"
+	// ModeOIDC verifies OIDC ID tokens issued by the configured issuer
+	ModeOIDC = "oidc"
+	// ModeToken verifies bearer tokens with the Kubernetes TokenReview API
+	ModeToken = "token"
+)
+
+// ErrUnauthenticated is returned when a token is missing or cannot be verified
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// User is the authenticated caller, impersonated against the Kubernetes API
+type User struct {
+	Name   string              `json:"name"`
+	UID    string              `json:"uid,omitempty"`
+	Groups []string            `json:"groups,omitempty"`
+	Extra  map[string][]string `json:"extra,omitempty"`
+}
+
+// Authenticator verifies a bearer token and returns the user it belongs to
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*User, error)
+}
+
+// Config holds the authentication settings of the console
+type Config struct {
+	Mode string
+
+	// OIDC settings, matching the --oidc-* flags of the API server so the impersonated users match the RBAC bindings
+	IssuerURL      string
+	ClientID       string
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+// ConfigFromEnv reads the authentication settings from the environment
+func ConfigFromEnv() Config {
+	config := Config{
+		Mode:           strings.ToLower(os.Getenv("AUTH_MODE")),
+		IssuerURL:      os.Getenv("OIDC_ISSUER_URL"),
+		ClientID:       os.Getenv("OIDC_CLIENT_ID"),
+		UsernameClaim:  os.Getenv("OIDC_USERNAME_CLAIM"),
+		UsernamePrefix: os.Getenv("OIDC_USERNAME_PREFIX"),
+		GroupsClaim:    os.Getenv("OIDC_GROUPS_CLAIM"),
+		GroupsPrefix:   os.Getenv("OIDC_GROUPS_PREFIX"),
+	}
+	if config.Mode == "" {
+		config.Mode = ModeNone
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "email"
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	return config
+}
+
+// NewAuthenticator creates the authenticator of the configured mode, nil when authentication is disabled
+func NewAuthenticator(ctx context.Context, config Config, clientset kubernetes.Interface, logger *zap.Logger) (Authenticator, error) {
+	switch config.Mode {
+	case ModeNone:
+		logger.Warn("Authentication is disabled, all requests act with the service account of the console")
+		return nil, nil
+	case ModeOIDC:
+		if config.IssuerURL == "" || config.ClientID == "" {
+			return nil, fmt.Errorf("OIDC_ISSUER_URL and OIDC_CLIENT_ID are required with AUTH_MODE=%s", ModeOIDC)
+		}
+		return NewOIDCAuthenticator(ctx, config, logger)
+	case ModeToken:
+		return NewTokenReviewAuthenticator(clientset, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_MODE %q, expected one of %s, %s or %s", config.Mode, ModeNone, ModeOIDC, ModeToken)
+	}
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of the context carrying the authenticated user
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the authenticated user of the context, nil when authentication is disabled
+func UserFrom(ctx context.Context) *User {
+	user, _ := ctx.Value(userKey{}).(*User)
+	return user
+}
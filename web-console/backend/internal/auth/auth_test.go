@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected Config
+	}{
+		{
+			name: "defaults",
+			expected: Config{
+				Mode:          ModeNone,
+				UsernameClaim: "email",
+				GroupsClaim:   "groups",
+			},
+		},
+		{
+			name: "oidc settings",
+			env: map[string]string{
+				"AUTH_MODE":            "OIDC",
+				"OIDC_ISSUER_URL":      "https://issuer.example.com",
+				"OIDC_CLIENT_ID":       "ome-console",
+				"OIDC_USERNAME_CLAIM":  "sub",
+				"OIDC_USERNAME_PREFIX": "oidc:",
+				"OIDC_GROUPS_CLAIM":    "roles",
+				"OIDC_GROUPS_PREFIX":   "oidc:",
+			},
+			expected: Config{
+				Mode:           ModeOIDC,
+				IssuerURL:      "https://issuer.example.com",
+				ClientID:       "ome-console",
+				UsernameClaim:  "sub",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "roles",
+				GroupsPrefix:   "oidc:",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"AUTH_MODE", "OIDC_ISSUER_URL", "OIDC_CLIENT_ID", "OIDC_USERNAME_CLAIM",
+				"OIDC_USERNAME_PREFIX", "OIDC_GROUPS_CLAIM", "OIDC_GROUPS_PREFIX"} {
+				t.Setenv(key, tt.env[key])
+			}
+			assert.Equal(t, tt.expected, ConfigFromEnv())
+		})
+	}
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		expectedError bool
+		expectedNil   bool
+	}{
+		{
+			name:        "authentication disabled",
+			config:      Config{Mode: ModeNone},
+			expectedNil: true,
+		},
+		{
+			name:   "token review",
+			config: Config{Mode: ModeToken},
+		},
+		{
+			name:          "oidc without issuer",
+			config:        Config{Mode: ModeOIDC, ClientID: "ome-console"},
+			expectedError: true,
+		},
+		{
+			name:          "oidc without client id",
+			config:        Config{Mode: ModeOIDC, IssuerURL: "https://issuer.example.com"},
+			expectedError: true,
+		},
+		{
+			name:          "unsupported mode",
+			config:        Config{Mode: "basic"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator, err := NewAuthenticator(context.Background(), tt.config, fake.NewSimpleClientset(), zap.NewNop())
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedNil {
+				assert.Nil(t, authenticator)
+			} else {
+				assert.NotNil(t, authenticator)
+			}
+		})
+	}
+}
+
+func TestUserContext(t *testing.T) {
+	assert.Nil(t, UserFrom(context.Background()))
+
+	user := &User{Name: "alice", Groups: []string{"ml-team"}}
+	ctx := WithUser(context.Background(), user)
+	assert.Same(t, user, UserFrom(ctx))
+}
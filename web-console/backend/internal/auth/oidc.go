@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// jwksRefreshInterval bounds how often the signing keys are fetched again for an unknown key ID
+const jwksRefreshInterval = 30 * time.Second
+
+// OIDCAuthenticator verifies ID tokens signed by the keys the OIDC issuer publishes
+type OIDCAuthenticator struct {
+	config     Config
+	jwksURL    string
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	lastRefresh time.Time
+}
+
+// NewOIDCAuthenticator discovers the signing keys of the issuer
+func NewOIDCAuthenticator(ctx context.Context, config Config, logger *zap.Logger) (*OIDCAuthenticator, error) {
+	a := &OIDCAuthenticator{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		keys:       map[string]interface{}{},
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(config.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := a.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC issuer %s: %w", config.IssuerURL, err)
+	}
+	if discovery.Issuer != config.IssuerURL {
+		return nil, fmt.Errorf("OIDC issuer %q does not match the discovered issuer %q", config.IssuerURL, discovery.Issuer)
+	}
+	a.jwksURL = discovery.JWKSURI
+
+	if err := a.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	logger.Info("OIDC authentication enabled", zap.String("issuer", config.IssuerURL), zap.Int("keys", len(a.keys)))
+	return a, nil
+}
+
+// Authenticate verifies the signature, issuer, audience and expiry of the ID token
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) (*User, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return a.key(ctx, kid)
+	},
+		jwt.WithIssuer(a.config.IssuerURL),
+		jwt.WithAudience(a.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	name, ok := claims[a.config.UsernameClaim].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", ErrUnauthenticated, a.config.UsernameClaim)
+	}
+	// Like the API server, an email is only trusted when the issuer verified it
+	if a.config.UsernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return nil, fmt.Errorf("%w: email %q is not verified", ErrUnauthenticated, name)
+		}
+	}
+
+	user := &User{Name: a.config.UsernamePrefix + name}
+	if sub, ok := claims["sub"].(string); ok {
+		user.UID = sub
+	}
+	switch groups := claims[a.config.GroupsClaim].(type) {
+	case string:
+		user.Groups = []string{a.config.GroupsPrefix + groups}
+	case []interface{}:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				user.Groups = append(user.Groups, a.config.GroupsPrefix+g)
+			}
+		}
+	}
+	return user, nil
+}
+
+// key returns the signing key of the key ID, fetching the keys again when the issuer rotated them
+func (a *OIDCAuthenticator) key(ctx context.Context, kid string) (interface{}, error) {
+	a.mu.RLock()
+	key, ok := a.lookup(kid)
+	stale := time.Since(a.lastRefresh) > jwksRefreshInterval
+	a.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if stale {
+		if err := a.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+		a.mu.RLock()
+		key, ok = a.lookup(kid)
+		a.mu.RUnlock()
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup finds the key of the key ID, or the only key when the token has no key ID. The lock must be held.
+func (a *OIDCAuthenticator) lookup(kid string) (interface{}, bool) {
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, true
+		}
+	}
+	key, ok := a.keys[kid]
+	return key, ok
+}
+
+// refreshKeys fetches the JSON Web Key Set of the issuer
+func (a *OIDCAuthenticator) refreshKeys(ctx context.Context) error {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := a.getJSON(ctx, a.jwksURL, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch OIDC signing keys: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			a.logger.Warn("Skipping OIDC signing key", zap.String("kid", jwk.Kid), zap.Error(err))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = keys
+	a.lastRefresh = time.Now()
+	return nil
+}
+
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// jsonWebKey is a public key of a JSON Web Key Set
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testKeyID = "test-key"
+
+// testIssuer serves the discovery document and signing keys of an OIDC issuer
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// issuer overrides the issuer of the discovery document when set
+	issuer string
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer := &testIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		discovered := issuer.issuer
+		if discovered == "" {
+			discovered = issuer.server.URL
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   discovered,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		encode := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{"kid": testKeyID, "kty": "RSA", "use": "sig", "n": encode(key.N), "e": encode(big.NewInt(int64(key.E)))},
+				{"kid": "encryption-key", "kty": "RSA", "use": "enc", "n": encode(key.N), "e": encode(big.NewInt(int64(key.E)))},
+			},
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) config() Config {
+	return Config{
+		Mode:           ModeOIDC,
+		IssuerURL:      i.server.URL,
+		ClientID:       "ome-console",
+		UsernameClaim:  "email",
+		UsernamePrefix: "oidc:",
+		GroupsClaim:    "groups",
+		GroupsPrefix:   "oidc:",
+	}
+}
+
+// claims returns the claims of a valid ID token, overridden by the given claims
+func (i *testIssuer) claims(overrides jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":            i.server.URL,
+		"aud":            "ome-console",
+		"sub":            "user-1234",
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"groups":         []string{"ml-team", "admins"},
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+	return claims
+}
+
+func (i *testIssuer) sign(t *testing.T, claims jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(i.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestNewOIDCAuthenticator(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator, err := NewOIDCAuthenticator(context.Background(), issuer.config(), zap.NewNop())
+	require.NoError(t, err)
+	// Only signing keys are used
+	assert.Len(t, authenticator.keys, 1)
+	assert.Contains(t, authenticator.keys, testKeyID)
+
+	issuer.issuer = "https://other-issuer.example.com"
+	_, err = NewOIDCAuthenticator(context.Background(), issuer.config(), zap.NewNop())
+	assert.Error(t, err, "the discovered issuer must match the configured one")
+}
+
+func TestOIDCAuthenticate(t *testing.T) {
+	issuer := newTestIssuer(t)
+	authenticator, err := NewOIDCAuthenticator(context.Background(), issuer.config(), zap.NewNop())
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.claims(nil)).SignedString(otherKey)
+	require.NoError(t, err)
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, issuer.claims(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError bool
+		expectedUser  *User
+	}{
+		{
+			name:  "valid token",
+			token: issuer.sign(t, issuer.claims(nil), testKeyID),
+			expectedUser: &User{
+				Name:   "oidc:alice@example.com",
+				UID:    "user-1234",
+				Groups: []string{"oidc:ml-team", "oidc:admins"},
+			},
+		},
+		{
+			name:  "token without key id signed by the only key",
+			token: issuer.sign(t, issuer.claims(jwt.MapClaims{"groups": "ml-team"}), ""),
+			expectedUser: &User{
+				Name:   "oidc:alice@example.com",
+				UID:    "user-1234",
+				Groups: []string{"oidc:ml-team"},
+			},
+		},
+		{
+			name:          "malformed token",
+			token:         "not-a-jwt",
+			expectedError: true,
+		},
+		{
+			name:          "token signed by another key",
+			token:         forged,
+			expectedError: true,
+		},
+		{
+			name:          "unsigned token",
+			token:         unsigned,
+			expectedError: true,
+		},
+		{
+			name:          "unknown key id",
+			token:         issuer.sign(t, issuer.claims(nil), "rotated-key"),
+			expectedError: true,
+		},
+		{
+			name:          "expired token",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}), testKeyID),
+			expectedError: true,
+		},
+		{
+			name:          "token without expiry",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"exp": nil}), testKeyID),
+			expectedError: true,
+		},
+		{
+			name:          "token of another client",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"aud": "other-client"}), testKeyID),
+			expectedError: true,
+		},
+		{
+			name:          "token of another issuer",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"iss": "https://other-issuer.example.com"}), testKeyID),
+			expectedError: true,
+		},
+		{
+			name:          "unverified email",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"email_verified": false}), testKeyID),
+			expectedError: true,
+		},
+		{
+			name:          "missing username claim",
+			token:         issuer.sign(t, issuer.claims(jwt.MapClaims{"email": nil}), testKeyID),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := authenticator.Authenticate(context.Background(), tt.token)
+			if tt.expectedError {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedUser, user)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// tokenReviewCacheTTL is how long the user of a reviewed token is remembered, to avoid a review per request
+const tokenReviewCacheTTL = time.Minute
+
+// TokenReviewAuthenticator verifies bearer tokens with the TokenReview API, accepting any token the API server
+// accepts: service account tokens, and OIDC tokens when the API server is configured for them
+type TokenReviewAuthenticator struct {
+	clientset kubernetes.Interface
+	logger    *zap.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedUser
+}
+
+type cachedUser struct {
+	user    *User
+	expires time.Time
+}
+
+// NewTokenReviewAuthenticator creates a TokenReviewAuthenticator
+func NewTokenReviewAuthenticator(clientset kubernetes.Interface, logger *zap.Logger) *TokenReviewAuthenticator {
+	logger.Info("Bearer token authentication enabled with the TokenReview API")
+	return &TokenReviewAuthenticator{
+		clientset: clientset,
+		logger:    logger,
+		cache:     map[string]cachedUser{},
+	}
+}
+
+// Authenticate reviews the token with the API server
+func (a *TokenReviewAuthenticator) Authenticate(ctx context.Context, token string) (*User, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	a.mu.Lock()
+	cached, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.user, nil
+	}
+
+	review, err := a.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, review.Status.Error)
+	}
+
+	info := review.Status.User
+	user := &User{Name: info.Username, UID: info.UID, Groups: info.Groups}
+	if len(info.Extra) > 0 {
+		user.Extra = make(map[string][]string, len(info.Extra))
+		for k, v := range info.Extra {
+			user.Extra[k] = v
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	for k, v := range a.cache {
+		if now.After(v.expires) {
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = cachedUser{user: user, expires: now.Add(tokenReviewCacheTTL)}
+	return user, nil
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// reviewingClientset answers TokenReviews with the status of the reviewed token and counts the reviews
+func reviewingClientset(statuses map[string]authenticationv1.TokenReviewStatus, err error, reviews *int) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		if err != nil {
+			return true, nil, err
+		}
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		review.Status = statuses[review.Spec.Token]
+		return true, review, nil
+	})
+	return clientset
+}
+
+func TestTokenReviewAuthenticate(t *testing.T) {
+	statuses := map[string]authenticationv1.TokenReviewStatus{
+		"valid-token": {
+			Authenticated: true,
+			User: authenticationv1.UserInfo{
+				Username: "system:serviceaccount:default:console-user",
+				UID:      "1234",
+				Groups:   []string{"system:serviceaccounts", "ml-team"},
+				Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"read"}},
+			},
+		},
+		"expired-token": {Authenticated: false, Error: "token has expired"},
+	}
+
+	tests := []struct {
+		name                 string
+		token                string
+		reviewErr            error
+		expectedError        bool
+		expectedUnauthorized bool
+		expectedUser         *User
+	}{
+		{
+			name:  "valid token",
+			token: "valid-token",
+			expectedUser: &User{
+				Name:   "system:serviceaccount:default:console-user",
+				UID:    "1234",
+				Groups: []string{"system:serviceaccounts", "ml-team"},
+				Extra:  map[string][]string{"scopes": {"read"}},
+			},
+		},
+		{
+			name:                 "rejected token",
+			token:                "expired-token",
+			expectedError:        true,
+			expectedUnauthorized: true,
+		},
+		{
+			name:          "token review unavailable",
+			token:         "valid-token",
+			reviewErr:     errors.New("connection refused"),
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews := 0
+			authenticator := NewTokenReviewAuthenticator(reviewingClientset(statuses, tt.reviewErr, &reviews), zap.NewNop())
+
+			user, err := authenticator.Authenticate(context.Background(), tt.token)
+			if tt.expectedError {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedUnauthorized, errors.Is(err, ErrUnauthenticated))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedUser, user)
+		})
+	}
+}
+
+func TestTokenReviewCache(t *testing.T) {
+	statuses := map[string]authenticationv1.TokenReviewStatus{
+		"valid-token": {Authenticated: true, User: authenticationv1.UserInfo{Username: "alice"}},
+	}
+	reviews := 0
+	authenticator := NewTokenReviewAuthenticator(reviewingClientset(statuses, nil, &reviews), zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		user, err := authenticator.Authenticate(context.Background(), "valid-token")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.Name)
+	}
+	assert.Equal(t, 1, reviews, "a reviewed token is remembered")
+
+	// Rejected tokens are reviewed again
+	for i := 0; i < 2; i++ {
+		_, err := authenticator.Authenticate(context.Background(), "unknown-token")
+		assert.ErrorIs(t, err, ErrUnauthenticated)
+	}
+	assert.Equal(t, 3, reviews)
+
+	// Tokens are cached by their hash, never in the clear
+	for key := range authenticator.cache {
+		assert.NotContains(t, key, "valid-token")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // AcceleratorsHandler handles HTTP requests for AcceleratorClass resources
@@ -29,7 +30,7 @@ func (h *AcceleratorsHandler) List(c *gin.Context) {
 	accelerators, err := h.k8sClient.ListAcceleratorClasses(ctx)
 	if err != nil {
 		h.logger.Error("Failed to list accelerators", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to list accelerators",
 			"details": err.Error(),
 		})
@@ -50,7 +51,7 @@ func (h *AcceleratorsHandler) Get(c *gin.Context) {
 	accelerator, err := h.k8sClient.GetAcceleratorClass(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get accelerator", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Accelerator not found",
 			"details": err.Error(),
 		})
@@ -59,3 +60,107 @@ func (h *AcceleratorsHandler) Get(c *gin.Context) {
 
 	c.JSON(http.StatusOK, accelerator.Object)
 }
+
+// Create handles POST /api/v1/accelerators
+func (h *AcceleratorsHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var acceleratorData map[string]interface{}
+	if err := c.ShouldBindJSON(&acceleratorData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	accelerator := &unstructured.Unstructured{Object: acceleratorData}
+
+	// Set GVK if not present
+	if accelerator.GetAPIVersion() == "" {
+		accelerator.SetAPIVersion("ome.io/v1beta1")
+	}
+	if accelerator.GetKind() == "" {
+		accelerator.SetKind("AcceleratorClass")
+	}
+
+	created, err := h.k8sClient.CreateAcceleratorClass(ctx, accelerator)
+	if err != nil {
+		h.logger.Error("Failed to create accelerator", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to create accelerator",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Accelerator created successfully", zap.String("name", created.GetName()))
+	c.JSON(http.StatusCreated, created.Object)
+}
+
+// Update handles PUT /api/v1/accelerators/:name
+func (h *AcceleratorsHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	var acceleratorData map[string]interface{}
+	if err := c.ShouldBindJSON(&acceleratorData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	accelerator := &unstructured.Unstructured{Object: acceleratorData}
+
+	// Ensure name matches
+	accelerator.SetName(name)
+
+	// Set GVK if not present
+	if accelerator.GetAPIVersion() == "" {
+		accelerator.SetAPIVersion("ome.io/v1beta1")
+	}
+	if accelerator.GetKind() == "" {
+		accelerator.SetKind("AcceleratorClass")
+	}
+
+	updated, err := h.k8sClient.UpdateAcceleratorClass(ctx, accelerator)
+	if err != nil {
+		h.logger.Error("Failed to update accelerator", zap.String("name", name), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to update accelerator",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Accelerator updated successfully", zap.String("name", name))
+	c.JSON(http.StatusOK, updated.Object)
+}
+
+// Delete handles DELETE /api/v1/accelerators/:name
+func (h *AcceleratorsHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	err := h.k8sClient.DeleteAcceleratorClass(ctx, name)
+	if err != nil {
+		h.logger.Error("Failed to delete accelerator", zap.String("name", name), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to delete accelerator",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Accelerator deleted successfully", zap.String("name", name))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Accelerator deleted successfully",
+		"name":    name,
+	})
+}
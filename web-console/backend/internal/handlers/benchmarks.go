@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/pkg/storage"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"github.com/sgl-project/ome/web-console/backend/internal/services"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// BenchmarksHandler handles HTTP requests for BenchmarkJob resources
+type BenchmarksHandler struct {
+	k8sClient *k8s.Client
+	logger    *zap.Logger
+	results   *services.BenchmarkResultsService
+}
+
+// NewBenchmarksHandler creates a new BenchmarksHandler
+func NewBenchmarksHandler(k8sClient *k8s.Client, logger *zap.Logger) *BenchmarksHandler {
+	return &BenchmarksHandler{
+		k8sClient: k8sClient,
+		logger:    logger,
+		results:   services.NewBenchmarkResultsService(k8sClient, logger),
+	}
+}
+
+// List handles GET /api/v1/benchmarks
+func (h *BenchmarksHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	namespace := c.Query("namespace") // Optional query parameter
+
+	jobs, err := h.k8sClient.ListBenchmarkJobs(ctx, namespace)
+	if err != nil {
+		h.logger.Error("Failed to list benchmarks", zap.String("namespace", namespace), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to list benchmarks",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": jobs.Items,
+		"total": len(jobs.Items),
+	})
+}
+
+// Get handles GET /api/v1/benchmarks/:name
+func (h *BenchmarksHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	job, err := h.k8sClient.GetBenchmarkJob(ctx, namespace, name)
+	if err != nil {
+		h.logger.Error("Failed to get benchmark",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
+			"error":   "Benchmark not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, job.Object)
+}
+
+// Create handles POST /api/v1/benchmarks
+func (h *BenchmarksHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var jobData map[string]interface{}
+	if err := c.ShouldBindJSON(&jobData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	job := &unstructured.Unstructured{Object: jobData}
+
+	// Set GVK if not present
+	if job.GetAPIVersion() == "" {
+		job.SetAPIVersion("ome.io/v1beta1")
+	}
+	if job.GetKind() == "" {
+		job.SetKind("BenchmarkJob")
+	}
+
+	// Get namespace from metadata or use default
+	namespace := job.GetNamespace()
+	if namespace == "" {
+		namespace = "default"
+		job.SetNamespace(namespace)
+	}
+
+	created, err := h.k8sClient.CreateBenchmarkJob(ctx, namespace, job)
+	if err != nil {
+		h.logger.Error("Failed to create benchmark", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to create benchmark",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Benchmark created successfully",
+		zap.String("name", created.GetName()),
+		zap.String("namespace", namespace))
+	c.JSON(http.StatusCreated, created.Object)
+}
+
+// Update handles PUT /api/v1/benchmarks/:name
+func (h *BenchmarksHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	var jobData map[string]interface{}
+	if err := c.ShouldBindJSON(&jobData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	job := &unstructured.Unstructured{Object: jobData}
+
+	// Ensure name matches
+	job.SetName(name)
+
+	// Set GVK if not present
+	if job.GetAPIVersion() == "" {
+		job.SetAPIVersion("ome.io/v1beta1")
+	}
+	if job.GetKind() == "" {
+		job.SetKind("BenchmarkJob")
+	}
+
+	// Get namespace from metadata or use default
+	namespace := job.GetNamespace()
+	if namespace == "" {
+		namespace = "default"
+		job.SetNamespace(namespace)
+	}
+
+	updated, err := h.k8sClient.UpdateBenchmarkJob(ctx, namespace, job)
+	if err != nil {
+		h.logger.Error("Failed to update benchmark",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to update benchmark",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Benchmark updated successfully",
+		zap.String("name", name),
+		zap.String("namespace", namespace))
+	c.JSON(http.StatusOK, updated.Object)
+}
+
+// Delete handles DELETE /api/v1/benchmarks/:name
+func (h *BenchmarksHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	err := h.k8sClient.DeleteBenchmarkJob(ctx, namespace, name)
+	if err != nil {
+		h.logger.Error("Failed to delete benchmark",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to delete benchmark",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Benchmark deleted successfully",
+		zap.String("name", name),
+		zap.String("namespace", namespace))
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Benchmark deleted successfully",
+		"name":      name,
+		"namespace": namespace,
+	})
+}
+
+// GetStatus handles GET /api/v1/benchmarks/:name/status
+func (h *BenchmarksHandler) GetStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	job, err := h.k8sClient.GetBenchmarkJob(ctx, namespace, name)
+	if err != nil {
+		h.logger.Error("Failed to get benchmark status",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
+			"error":   "Benchmark not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Extract status from the benchmark
+	status, found, err := unstructured.NestedMap(job.Object, "status")
+	if err != nil || !found {
+		c.JSON(http.StatusOK, gin.H{
+			"status": map[string]interface{}{},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": status,
+	})
+}
+
+// ListResults handles GET /api/v1/benchmarks/:name/results
+func (h *BenchmarksHandler) ListResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	results, err := h.results.ListResults(ctx, namespace, name)
+	if err != nil {
+		h.logger.Error("Failed to list benchmark results",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(resultsErrorStatus(err), gin.H{
+			"error":   "Failed to list benchmark results",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"storageUri": results.StorageURI,
+		"items":      results.Files,
+		"total":      len(results.Files),
+	})
+}
+
+// GetResult handles GET /api/v1/benchmarks/:name/results/*file
+// The file is named relative to the output location, as listed by ListResults
+func (h *BenchmarksHandler) GetResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+	file := strings.TrimPrefix(c.Param("file"), "/")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	reader, err := h.results.GetResult(ctx, namespace, name, file)
+	if err != nil {
+		h.logger.Error("Failed to get benchmark result",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.String("file", file),
+			zap.Error(err))
+		c.JSON(resultsErrorStatus(err), gin.H{
+			"error":   "Failed to get benchmark result",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer reader.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(file))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+}
+
+// resultsErrorStatus returns the HTTP status of an error reading the results from the output location of a benchmark
+func resultsErrorStatus(err error) int {
+	switch {
+	case storage.IsNotFound(err):
+		return http.StatusNotFound
+	case storage.IsInvalidPath(err):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrLocationNotAllowed):
+		return http.StatusForbidden
+	case storage.IsNotSupported(err), errors.Is(err, storage.ErrInvalidConfig):
+		return http.StatusUnprocessableEntity
+	default:
+		return errorStatus(err, http.StatusBadGateway)
+	}
+}
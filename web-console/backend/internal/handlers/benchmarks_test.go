@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newTestClient(t *testing.T, objects ...runtime.Object) *k8s.Client {
+	client, err := k8s.NewFakeClient(zap.NewNop(), objects...)
+	require.NoError(t, err)
+	t.Cleanup(client.Stop)
+	return client
+}
+
+// serve sends the request to the router and returns the response, the body is encoded as JSON unless it is a string
+func serve(router http.Handler, method, target string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		encoded, _ := json.Marshal(b)
+		reader = bytes.NewReader(encoded)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	return body
+}
+
+func testBenchmarkJob(namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"outputLocation": map[string]interface{}{"storageUri": "oci://n/ns/b/bucket/o/benchmarks"},
+		},
+	}}
+	if status != nil {
+		job.Object["status"] = status
+	}
+	job.SetAPIVersion("ome.io/v1beta1")
+	job.SetKind("BenchmarkJob")
+	job.SetNamespace(namespace)
+	job.SetName(name)
+	return job
+}
+
+func newBenchmarksRouter(client *k8s.Client) *gin.Engine {
+	h := NewBenchmarksHandler(client, zap.NewNop())
+	router := gin.New()
+	benchmarks := router.Group("/api/v1/benchmarks")
+	benchmarks.GET("", h.List)
+	benchmarks.GET("/:name", h.Get)
+	benchmarks.POST("", h.Create)
+	benchmarks.PUT("/:name", h.Update)
+	benchmarks.DELETE("/:name", h.Delete)
+	benchmarks.GET("/:name/status", h.GetStatus)
+	benchmarks.GET("/:name/results", h.ListResults)
+	benchmarks.GET("/:name/results/*file", h.GetResult)
+	return router
+}
+
+func TestBenchmarksList(t *testing.T) {
+	client := newTestClient(t,
+		testBenchmarkJob("default", "llama-bench", nil),
+		testBenchmarkJob("team-a", "mistral-bench", nil))
+	router := newBenchmarksRouter(client)
+
+	w := serve(router, http.MethodGet, "/api/v1/benchmarks", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, float64(2), decode(t, w)["total"])
+
+	w = serve(router, http.MethodGet, "/api/v1/benchmarks?namespace=team-a", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	body := decode(t, w)
+	assert.Equal(t, float64(1), body["total"])
+	item := body["items"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "mistral-bench", item["metadata"].(map[string]interface{})["name"])
+
+	client.SetAllowedNamespaces([]string{"default"})
+	w = serve(router, http.MethodGet, "/api/v1/benchmarks?namespace=team-a", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestBenchmarksGet(t *testing.T) {
+	router := newBenchmarksRouter(newTestClient(t, testBenchmarkJob("default", "llama-bench", nil)))
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{name: "existing benchmark", target: "/api/v1/benchmarks/llama-bench", expectedStatus: http.StatusOK},
+		{name: "namespace defaults to default", target: "/api/v1/benchmarks/llama-bench?namespace=", expectedStatus: http.StatusOK},
+		{name: "missing benchmark", target: "/api/v1/benchmarks/missing", expectedStatus: http.StatusNotFound},
+		{name: "other namespace", target: "/api/v1/benchmarks/llama-bench?namespace=team-a", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestBenchmarksCreate(t *testing.T) {
+	tests := []struct {
+		name              string
+		namespaces        []string
+		body              interface{}
+		expectedStatus    int
+		expectedNamespace string
+	}{
+		{
+			name:              "benchmark defaults to the default namespace",
+			body:              testBenchmarkJob("", "llama-bench", nil).Object,
+			expectedStatus:    http.StatusCreated,
+			expectedNamespace: "default",
+		},
+		{
+			name: "group, version and kind are set",
+			body: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "llama-bench", "namespace": "team-a"},
+				"spec":     map[string]interface{}{},
+			},
+			expectedStatus:    http.StatusCreated,
+			expectedNamespace: "team-a",
+		},
+		{
+			name:           "invalid body",
+			body:           "{not json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "namespace outside of the console",
+			namespaces:     []string{"default"},
+			body:           testBenchmarkJob("team-a", "llama-bench", nil).Object,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "existing benchmark",
+			body:           testBenchmarkJob("default", "existing-bench", nil).Object,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, testBenchmarkJob("default", "existing-bench", nil))
+			client.SetAllowedNamespaces(tt.namespaces)
+			router := newBenchmarksRouter(client)
+
+			w := serve(router, http.MethodPost, "/api/v1/benchmarks", tt.body)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+			created, err := client.DynamicClient.Resource(k8s.BenchmarkJobGVR).Namespace(tt.expectedNamespace).
+				Get(context.Background(), "llama-bench", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "ome.io/v1beta1", created.GetAPIVersion())
+			assert.Equal(t, "BenchmarkJob", created.GetKind())
+		})
+	}
+}
+
+func TestBenchmarksUpdate(t *testing.T) {
+	client := newTestClient(t, testBenchmarkJob("default", "llama-bench", nil))
+	router := newBenchmarksRouter(client)
+
+	// The name of the path wins over the one of the body
+	job := testBenchmarkJob("", "other-bench", nil)
+	job.Object["spec"].(map[string]interface{})["numConcurrency"] = []interface{}{int64(4)}
+	w := serve(router, http.MethodPut, "/api/v1/benchmarks/llama-bench", job.Object)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	updated, err := client.DynamicClient.Resource(k8s.BenchmarkJobGVR).Namespace("default").
+		Get(context.Background(), "llama-bench", metav1.GetOptions{})
+	require.NoError(t, err)
+	concurrency, _, _ := unstructured.NestedSlice(updated.Object, "spec", "numConcurrency")
+	assert.Equal(t, []interface{}{float64(4)}, concurrency)
+
+	w = serve(router, http.MethodPut, "/api/v1/benchmarks/missing", testBenchmarkJob("", "", nil).Object)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(router, http.MethodPut, "/api/v1/benchmarks/llama-bench", "[]")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBenchmarksDelete(t *testing.T) {
+	client := newTestClient(t, testBenchmarkJob("default", "llama-bench", nil))
+	router := newBenchmarksRouter(client)
+
+	w := serve(router, http.MethodDelete, "/api/v1/benchmarks/llama-bench", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "default", decode(t, w)["namespace"])
+	_, err := client.DynamicClient.Resource(k8s.BenchmarkJobGVR).Namespace("default").
+		Get(context.Background(), "llama-bench", metav1.GetOptions{})
+	assert.Error(t, err)
+
+	w = serve(router, http.MethodDelete, "/api/v1/benchmarks/llama-bench", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBenchmarksGetStatus(t *testing.T) {
+	router := newBenchmarksRouter(newTestClient(t,
+		testBenchmarkJob("default", "running-bench", map[string]interface{}{"state": "Running"}),
+		testBenchmarkJob("default", "new-bench", nil)))
+
+	w := serve(router, http.MethodGet, "/api/v1/benchmarks/running-bench/status", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, map[string]interface{}{"state": "Running"}, decode(t, w)["status"])
+
+	w = serve(router, http.MethodGet, "/api/v1/benchmarks/new-bench/status", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, map[string]interface{}{}, decode(t, w)["status"])
+
+	w = serve(router, http.MethodGet, "/api/v1/benchmarks/missing/status", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBenchmarksResults(t *testing.T) {
+	// Without BENCHMARK_RESULTS_LOCATIONS no output location is read
+	t.Setenv("BENCHMARK_RESULTS_LOCATIONS", "")
+	router := newBenchmarksRouter(newTestClient(t, testBenchmarkJob("default", "llama-bench", nil)))
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{name: "location not allowed", target: "/api/v1/benchmarks/llama-bench/results", expectedStatus: http.StatusForbidden},
+		{name: "file of a location not allowed", target: "/api/v1/benchmarks/llama-bench/results/result.json", expectedStatus: http.StatusForbidden},
+		{name: "missing benchmark", target: "/api/v1/benchmarks/missing/results", expectedStatus: http.StatusNotFound},
+		{name: "path traversal", target: "/api/v1/benchmarks/llama-bench/results/plots/%2E%2E/%2E%2E/secret.json", expectedStatus: http.StatusBadRequest},
+		{name: "root of the output location", target: "/api/v1/benchmarks/llama-bench/results/", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// errorStatus returns the HTTP status of a Kubernetes API error, so callers see when RBAC or the namespace scope of
+// the console denied them, and the fallback status otherwise
+func errorStatus(err error, fallback int) int {
+	switch {
+	case apierrors.IsForbidden(err):
+		return http.StatusForbidden
+	case apierrors.IsUnauthorized(err):
+		return http.StatusUnauthorized
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	case apierrors.IsAlreadyExists(err), apierrors.IsConflict(err):
+		return http.StatusConflict
+	case apierrors.IsInvalid(err):
+		return http.StatusUnprocessableEntity
+	default:
+		return fallback
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // defaultAllowedOrigins is used when CORS_ALLOWED_ORIGINS is not set
@@ -47,6 +49,15 @@ func NewEventsHandler(k8sClient *k8s.Client, logger *zap.Logger) *EventsHandler
 	}
 }
 
+// canWatch reports whether the user of the request may watch the resource of the event
+func (h *EventsHandler) canWatch(ctx context.Context, event k8s.ResourceEvent) bool {
+	err := h.k8sClient.Authorize(ctx, "watch", event.GVR, event.Namespace, "")
+	if err != nil && !apierrors.IsForbidden(err) {
+		h.logger.Warn("Failed to authorize event", zap.String("resource", event.Resource), zap.Error(err))
+	}
+	return err == nil
+}
+
 // Stream handles SSE connections for real-time Kubernetes resource updates
 func (h *EventsHandler) Stream(c *gin.Context) {
 	// Validate and set CORS origin header
@@ -86,6 +97,11 @@ func (h *EventsHandler) Stream(c *gin.Context) {
 				return
 			}
 
+			// Only send the events of resources the user may watch
+			if !h.canWatch(c.Request.Context(), event) {
+				continue
+			}
+
 			// Marshal event to JSON
 			data, err := json.Marshal(event)
 			if err != nil {
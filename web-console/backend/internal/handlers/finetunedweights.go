@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FineTunedWeightsHandler handles HTTP requests for FineTunedWeight resources
+type FineTunedWeightsHandler struct {
+	k8sClient *k8s.Client
+	logger    *zap.Logger
+}
+
+// NewFineTunedWeightsHandler creates a new FineTunedWeightsHandler
+func NewFineTunedWeightsHandler(k8sClient *k8s.Client, logger *zap.Logger) *FineTunedWeightsHandler {
+	return &FineTunedWeightsHandler{
+		k8sClient: k8sClient,
+		logger:    logger,
+	}
+}
+
+// List handles GET /api/v1/finetunedweights
+func (h *FineTunedWeightsHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	weights, err := h.k8sClient.ListFineTunedWeights(ctx)
+	if err != nil {
+		h.logger.Error("Failed to list fine-tuned weights", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to list fine-tuned weights",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": weights.Items,
+		"total": len(weights.Items),
+	})
+}
+
+// Get handles GET /api/v1/finetunedweights/:name
+func (h *FineTunedWeightsHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	weight, err := h.k8sClient.GetFineTunedWeight(ctx, name)
+	if err != nil {
+		h.logger.Error("Failed to get fine-tuned weight", zap.String("name", name), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
+			"error":   "Fine-tuned weight not found",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, weight.Object)
+}
+
+// Create handles POST /api/v1/finetunedweights
+func (h *FineTunedWeightsHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var weightData map[string]interface{}
+	if err := c.ShouldBindJSON(&weightData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	weight := &unstructured.Unstructured{Object: weightData}
+
+	// Set GVK if not present
+	if weight.GetAPIVersion() == "" {
+		weight.SetAPIVersion("ome.io/v1beta1")
+	}
+	if weight.GetKind() == "" {
+		weight.SetKind("FineTunedWeight")
+	}
+
+	created, err := h.k8sClient.CreateFineTunedWeight(ctx, weight)
+	if err != nil {
+		h.logger.Error("Failed to create fine-tuned weight", zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to create fine-tuned weight",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Fine-tuned weight created successfully", zap.String("name", created.GetName()))
+	c.JSON(http.StatusCreated, created.Object)
+}
+
+// Update handles PUT /api/v1/finetunedweights/:name
+func (h *FineTunedWeightsHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	var weightData map[string]interface{}
+	if err := c.ShouldBindJSON(&weightData); err != nil {
+		h.logger.Error("Failed to parse request body", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Create unstructured object
+	weight := &unstructured.Unstructured{Object: weightData}
+
+	// Ensure name matches
+	weight.SetName(name)
+
+	// Set GVK if not present
+	if weight.GetAPIVersion() == "" {
+		weight.SetAPIVersion("ome.io/v1beta1")
+	}
+	if weight.GetKind() == "" {
+		weight.SetKind("FineTunedWeight")
+	}
+
+	updated, err := h.k8sClient.UpdateFineTunedWeight(ctx, weight)
+	if err != nil {
+		h.logger.Error("Failed to update fine-tuned weight", zap.String("name", name), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to update fine-tuned weight",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Fine-tuned weight updated successfully", zap.String("name", name))
+	c.JSON(http.StatusOK, updated.Object)
+}
+
+// Delete handles DELETE /api/v1/finetunedweights/:name
+func (h *FineTunedWeightsHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	err := h.k8sClient.DeleteFineTunedWeight(ctx, name)
+	if err != nil {
+		h.logger.Error("Failed to delete fine-tuned weight", zap.String("name", name), zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to delete fine-tuned weight",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info("Fine-tuned weight deleted successfully", zap.String("name", name))
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Fine-tuned weight deleted successfully",
+		"name":    name,
+	})
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"github.com/sgl-project/ome/web-console/backend/internal/services"
 	"github.com/sgl-project/ome/web-console/backend/pkg/huggingface"
 	"go.uber.org/zap"
 )
@@ -12,13 +15,16 @@ import (
 // HuggingFaceHandler handles HTTP requests for HuggingFace API integration
 type HuggingFaceHandler struct {
 	hfClient *huggingface.Client
+	importer *services.ModelImporter
 	logger   *zap.Logger
 }
 
 // NewHuggingFaceHandler creates a new HuggingFaceHandler
-func NewHuggingFaceHandler(logger *zap.Logger) *HuggingFaceHandler {
+func NewHuggingFaceHandler(k8sClient *k8s.Client, logger *zap.Logger) *HuggingFaceHandler {
+	hfClient := huggingface.NewClient()
 	return &HuggingFaceHandler{
-		hfClient: huggingface.NewClient(),
+		hfClient: hfClient,
+		importer: services.NewModelImporter(k8sClient, hfClient, logger),
 		logger:   logger,
 	}
 }
@@ -139,3 +145,63 @@ func (h *HuggingFaceHandler) GetModelConfig(c *gin.Context) {
 		"config": config,
 	})
 }
+
+// ImportModel handles POST /api/v1/huggingface/import
+// Fills a BaseModel or ClusterBaseModel from the configuration and safetensors headers of a repository and proposes
+// the runtimes and accelerator classes it would be served with. With dryRun the model is only proposed
+func (h *HuggingFaceHandler) ImportModel(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request services.ModelImport
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	if request.Repo == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Missing 'repo' field in request body",
+		})
+		return
+	}
+
+	result, err := h.importer.Import(ctx, request)
+	if err != nil {
+		h.logger.Error("Failed to import HuggingFace model",
+			zap.String("repo", request.Repo),
+			zap.String("revision", request.Revision),
+			zap.Error(err))
+		c.JSON(importErrorStatus(err), gin.H{
+			"error":   "Failed to import model",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if !result.Created {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	h.logger.Info("Model imported successfully",
+		zap.String("repo", request.Repo),
+		zap.String("kind", result.Model.GetKind()),
+		zap.String("name", result.Model.GetName()))
+	c.JSON(http.StatusCreated, result)
+}
+
+// importErrorStatus returns the HTTP status of an import error, Hugging Face errors are told apart from the ones of
+// the Kubernetes API
+func importErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, huggingface.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, huggingface.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrUnsupportedModel):
+		return http.StatusUnprocessableEntity
+	default:
+		return errorStatus(err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/services"
+	"github.com/sgl-project/ome/web-console/backend/pkg/huggingface"
+)
+
+func TestImportModelValidation(t *testing.T) {
+	h := NewHuggingFaceHandler(newTestClient(t), zap.NewNop())
+	router := gin.New()
+	router.POST("/api/v1/huggingface/import", h.ImportModel)
+
+	tests := []struct {
+		name string
+		body interface{}
+	}{
+		{name: "invalid body", body: "{"},
+		{name: "missing repository", body: map[string]interface{}{"kind": "BaseModel"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodPost, "/api/v1/huggingface/import", tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestImportErrorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "missing repository", err: fmt.Errorf("failed to read: %w", huggingface.ErrNotFound), expected: http.StatusNotFound},
+		{name: "gated repository", err: fmt.Errorf("failed to read: %w", huggingface.ErrUnauthorized), expected: http.StatusForbidden},
+		{name: "unsupported model", err: fmt.Errorf("no config.json: %w", services.ErrUnsupportedModel), expected: http.StatusUnprocessableEntity},
+		{
+			name:     "existing model",
+			err:      apierrors.NewAlreadyExists(schema.GroupResource{Group: "ome.io", Resource: "clusterbasemodels"}, "llama"),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "forbidden namespace",
+			err:      apierrors.NewForbidden(schema.GroupResource{Group: "ome.io", Resource: "basemodels"}, "llama", errors.New("denied")),
+			expected: http.StatusForbidden,
+		},
+		{name: "other error", err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, importErrorStatus(tt.err))
+		})
+	}
+}
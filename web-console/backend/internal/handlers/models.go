@@ -39,7 +39,7 @@ func (h *ModelsHandler) List(c *gin.Context) {
 			h.logger.Error("Failed to list base models",
 				zap.String("namespace", namespace),
 				zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 				"error":   "Failed to list base models",
 				"details": err.Error(),
 			})
@@ -58,7 +58,7 @@ func (h *ModelsHandler) List(c *gin.Context) {
 	models, err := h.k8sClient.ListClusterBaseModels(ctx)
 	if err != nil {
 		h.logger.Error("Failed to list models", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to list models",
 			"details": err.Error(),
 		})
@@ -79,7 +79,7 @@ func (h *ModelsHandler) Get(c *gin.Context) {
 	model, err := h.k8sClient.GetClusterBaseModel(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get model", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Model not found",
 			"details": err.Error(),
 		})
@@ -130,12 +130,12 @@ func (h *ModelsHandler) Create(c *gin.Context) {
 		secretName := model.GetName() + "-hf-token"
 		namespace := "ome" // ClusterBaseModels use ome namespace
 
-		if err := h.k8sClient.CreateHuggingFaceTokenSecret(ctx, secretName, namespace, requestBody.HuggingfaceToken); err != nil {
+		if err := h.k8sClient.CreateHuggingFaceTokenSecret(ctx, secretName, namespace, requestBody.HuggingfaceToken, nil); err != nil {
 			h.logger.Error("Failed to create HuggingFace token secret",
 				zap.String("secretName", secretName),
 				zap.String("namespace", namespace),
 				zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 				"error":   "Failed to create HuggingFace token secret",
 				"details": err.Error(),
 			})
@@ -165,7 +165,7 @@ func (h *ModelsHandler) Create(c *gin.Context) {
 	created, err := h.k8sClient.CreateClusterBaseModel(ctx, model)
 	if err != nil {
 		h.logger.Error("Failed to create model", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create model",
 			"details": err.Error(),
 		})
@@ -208,7 +208,7 @@ func (h *ModelsHandler) Update(c *gin.Context) {
 	updated, err := h.k8sClient.UpdateClusterBaseModel(ctx, model)
 	if err != nil {
 		h.logger.Error("Failed to update model", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to update model",
 			"details": err.Error(),
 		})
@@ -227,7 +227,7 @@ func (h *ModelsHandler) Delete(c *gin.Context) {
 	err := h.k8sClient.DeleteClusterBaseModel(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to delete model", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to delete model",
 			"details": err.Error(),
 		})
@@ -249,7 +249,7 @@ func (h *ModelsHandler) GetStatus(c *gin.Context) {
 	model, err := h.k8sClient.GetClusterBaseModel(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get model status", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Model not found",
 			"details": err.Error(),
 		})
@@ -280,7 +280,7 @@ func (h *ModelsHandler) ListBaseModels(c *gin.Context) {
 	models, err := h.k8sClient.ListBaseModels(ctx, namespace)
 	if err != nil {
 		h.logger.Error("Failed to list base models", zap.String("namespace", namespace), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to list base models",
 			"details": err.Error(),
 		})
@@ -305,7 +305,7 @@ func (h *ModelsHandler) GetBaseModel(c *gin.Context) {
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Base model not found",
 			"details": err.Error(),
 		})
@@ -349,7 +349,7 @@ func (h *ModelsHandler) CreateBaseModel(c *gin.Context) {
 		h.logger.Error("Failed to create base model",
 			zap.String("namespace", namespace),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create base model",
 			"details": err.Error(),
 		})
@@ -399,7 +399,7 @@ func (h *ModelsHandler) UpdateBaseModel(c *gin.Context) {
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to update base model",
 			"details": err.Error(),
 		})
@@ -424,7 +424,7 @@ func (h *ModelsHandler) DeleteBaseModel(c *gin.Context) {
 			zap.String("namespace", namespace),
 			zap.String("name", name),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to delete base model",
 			"details": err.Error(),
 		})
@@ -460,7 +460,7 @@ func (h *ModelsHandler) GetEvents(c *gin.Context) {
 	events, err := h.k8sClient.GetClusterBaseModelEvents(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get model events", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to get model events",
 			"details": err.Error(),
 		})
@@ -516,10 +516,19 @@ func (h *ModelsHandler) GetProgress(c *gin.Context) {
 	ctx := c.Request.Context()
 	modelName := c.Param("name")
 
+	// The status ConfigMaps are read with the service account of the console, so check access to the model first
+	if err := h.k8sClient.Authorize(ctx, "get", k8s.ClusterBaseModelGVR, "", modelName); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to get model progress",
+			"details": err.Error(),
+		})
+		return
+	}
+
 	configMaps, err := h.k8sClient.GetModelStatusConfigMaps(ctx)
 	if err != nil {
 		h.logger.Error("Failed to get model status ConfigMaps", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to get model progress",
 			"details": err.Error(),
 		})
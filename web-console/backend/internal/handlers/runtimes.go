@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"github.com/sgl-project/ome/web-console/backend/internal/services"
 	"go.uber.org/zap"
@@ -61,7 +62,7 @@ func (h *RuntimesHandler) List(c *gin.Context) {
 			h.logger.Error("Failed to list serving runtimes",
 				zap.String("namespace", namespace),
 				zap.Error(err))
-			c.JSON(http.StatusInternalServerError, gin.H{
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 				"error":   "Failed to list serving runtimes",
 				"details": err.Error(),
 			})
@@ -80,7 +81,7 @@ func (h *RuntimesHandler) List(c *gin.Context) {
 	runtimes, err := h.k8sClient.ListClusterServingRuntimes(ctx)
 	if err != nil {
 		h.logger.Error("Failed to list runtimes", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to list runtimes",
 			"details": err.Error(),
 		})
@@ -101,7 +102,7 @@ func (h *RuntimesHandler) Get(c *gin.Context) {
 	runtime, err := h.k8sClient.GetClusterServingRuntime(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get runtime", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Runtime not found",
 			"details": err.Error(),
 		})
@@ -139,7 +140,7 @@ func (h *RuntimesHandler) Create(c *gin.Context) {
 	created, err := h.k8sClient.CreateClusterServingRuntime(ctx, runtime)
 	if err != nil {
 		h.logger.Error("Failed to create runtime", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create runtime",
 			"details": err.Error(),
 		})
@@ -182,7 +183,7 @@ func (h *RuntimesHandler) Update(c *gin.Context) {
 	updated, err := h.k8sClient.UpdateClusterServingRuntime(ctx, runtime)
 	if err != nil {
 		h.logger.Error("Failed to update runtime", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to update runtime",
 			"details": err.Error(),
 		})
@@ -201,7 +202,7 @@ func (h *RuntimesHandler) Delete(c *gin.Context) {
 	err := h.k8sClient.DeleteClusterServingRuntime(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to delete runtime", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to delete runtime",
 			"details": err.Error(),
 		})
@@ -361,7 +362,7 @@ func (h *RuntimesHandler) FetchYAML(c *gin.Context) {
 	req, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, safeURL.String(), nil) //nolint:gosec
 	if err != nil {
 		h.logger.Error("Failed to create request", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create request",
 			"details": err.Error(),
 		})
@@ -374,7 +375,7 @@ func (h *RuntimesHandler) FetchYAML(c *gin.Context) {
 	resp, err := client.Do(req)
 	if err != nil {
 		h.logger.Error("Failed to fetch URL", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to fetch URL",
 			"details": err.Error(),
 		})
@@ -396,7 +397,7 @@ func (h *RuntimesHandler) FetchYAML(c *gin.Context) {
 	content, err := io.ReadAll(limitedReader)
 	if err != nil {
 		h.logger.Error("Failed to read response body", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to read response",
 			"details": err.Error(),
 		})
@@ -409,59 +410,51 @@ func (h *RuntimesHandler) FetchYAML(c *gin.Context) {
 	})
 }
 
-// FindCompatibleRuntimes handles GET /api/v1/runtimes/compatible?format=<format>&framework=<framework>
+// FindCompatibleRuntimes handles GET /api/v1/runtimes/compatible?model=<model>
+// Ranks the runtimes like the controller does for an InferenceService of the model, see modelQuery
 func (h *RuntimesHandler) FindCompatibleRuntimes(c *gin.Context) {
 	ctx := c.Request.Context()
-	modelFormat := c.Query("format")
-	modelFramework := c.Query("framework")
-
-	if modelFormat == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Model format is required",
-		})
+	query, ok := modelQuery(c)
+	if !ok {
 		return
 	}
 
-	matches, err := h.intelligence.FindCompatibleRuntimes(ctx, modelFormat, modelFramework)
+	selection, err := h.intelligence.FindCompatibleRuntimes(ctx, query)
 	if err != nil {
 		h.logger.Error("Failed to find compatible runtimes",
-			zap.String("format", modelFormat),
-			zap.String("framework", modelFramework),
+			zap.String("model", query.Model),
+			zap.String("namespace", query.Namespace),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to find compatible runtimes",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"matches": matches,
-		"total":   len(matches),
-	})
+	c.JSON(http.StatusOK, selection)
 }
 
-// CheckCompatibility handles GET /api/v1/runtimes/:name/compatibility?format=<format>&framework=<framework>
+// CheckCompatibility handles GET /api/v1/runtimes/:name/compatibility?model=<model>
 func (h *RuntimesHandler) CheckCompatibility(c *gin.Context) {
 	ctx := c.Request.Context()
 	name := c.Param("name")
-	modelFormat := c.Query("format")
-	modelFramework := c.Query("framework")
-
-	if modelFormat == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Model format is required",
-		})
+	query, ok := modelQuery(c)
+	if !ok {
 		return
 	}
 
-	check, err := h.intelligence.CheckCompatibility(ctx, name, modelFormat, modelFramework)
+	check, err := h.intelligence.CheckCompatibility(ctx, name, query)
 	if err != nil {
 		h.logger.Error("Failed to check compatibility",
 			zap.String("runtime", name),
-			zap.String("format", modelFormat),
+			zap.String("model", query.Model),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := errorStatus(err, http.StatusInternalServerError)
+		if runtimeselector.IsRuntimeNotFoundError(err) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
 			"error":   "Failed to check compatibility",
 			"details": err.Error(),
 		})
@@ -471,26 +464,26 @@ func (h *RuntimesHandler) CheckCompatibility(c *gin.Context) {
 	c.JSON(http.StatusOK, check)
 }
 
-// GetRecommendation handles GET /api/v1/runtimes/recommend?format=<format>&framework=<framework>
+// GetRecommendation handles GET /api/v1/runtimes/recommend?model=<model>
+// Returns the runtime the controller would select for an InferenceService of the model
 func (h *RuntimesHandler) GetRecommendation(c *gin.Context) {
 	ctx := c.Request.Context()
-	modelFormat := c.Query("format")
-	modelFramework := c.Query("framework")
-
-	if modelFormat == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Model format is required",
-		})
+	query, ok := modelQuery(c)
+	if !ok {
 		return
 	}
 
-	recommendation, err := h.intelligence.GetRecommendation(ctx, modelFormat, modelFramework)
+	recommendation, err := h.intelligence.GetRecommendation(ctx, query)
 	if err != nil {
 		h.logger.Error("Failed to get recommendation",
-			zap.String("format", modelFormat),
-			zap.String("framework", modelFramework),
+			zap.String("model", query.Model),
+			zap.String("namespace", query.Namespace),
 			zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		status := errorStatus(err, http.StatusInternalServerError)
+		if runtimeselector.IsNoRuntimeFoundError(err) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
 			"error":   "No compatible runtime found",
 			"details": err.Error(),
 		})
@@ -500,6 +493,35 @@ func (h *RuntimesHandler) GetRecommendation(c *gin.Context) {
 	c.JSON(http.StatusOK, recommendation)
 }
 
+// modelQuery reads the InferenceService runtimes are selected for from the query parameters:
+// model, kind (ClusterBaseModel or BaseModel), namespace, acceleratorClass and acceleratorPolicy
+func modelQuery(c *gin.Context) (services.ModelQuery, bool) {
+	query := services.ModelQuery{
+		Model:             c.Query("model"),
+		ModelKind:         c.Query("kind"),
+		Namespace:         c.Query("namespace"),
+		AcceleratorClass:  c.Query("acceleratorClass"),
+		AcceleratorPolicy: c.Query("acceleratorPolicy"),
+	}
+	if query.Namespace == "" {
+		query.Namespace = "default"
+	}
+
+	if query.Model == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Model is required",
+		})
+		return query, false
+	}
+	if query.ModelKind != "" && query.ModelKind != "ClusterBaseModel" && query.ModelKind != "BaseModel" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Model kind must be ClusterBaseModel or BaseModel",
+		})
+		return query, false
+	}
+	return query, true
+}
+
 // ValidateConfiguration handles POST /api/v1/runtimes/validate
 func (h *RuntimesHandler) ValidateConfiguration(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -519,7 +541,7 @@ func (h *RuntimesHandler) ValidateConfiguration(c *gin.Context) {
 	errors, warnings, err := h.intelligence.ValidateRuntimeConfiguration(ctx, runtime)
 	if err != nil {
 		h.logger.Error("Failed to validate configuration", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to validate configuration",
 			"details": err.Error(),
 		})
@@ -556,7 +578,7 @@ func (h *RuntimesHandler) Clone(c *gin.Context) {
 	runtime, err := h.k8sClient.GetClusterServingRuntime(ctx, name)
 	if err != nil {
 		h.logger.Error("Failed to get runtime for cloning", zap.String("name", name), zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Runtime not found",
 			"details": err.Error(),
 		})
@@ -581,7 +603,7 @@ func (h *RuntimesHandler) Clone(c *gin.Context) {
 			zap.String("original", name),
 			zap.String("new", req.NewName),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create cloned runtime",
 			"details": err.Error(),
 		})
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func toUnstructured(t *testing.T, obj runtime.Object) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: content}
+}
+
+func testClusterServingRuntime(name, format string, priority int32) *v1beta1.ClusterServingRuntime {
+	autoSelect := true
+	return &v1beta1.ClusterServingRuntime{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "ome.io/v1beta1", Kind: "ClusterServingRuntime"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1beta1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1beta1.SupportedModelFormat{
+				{ModelFormat: &v1beta1.ModelFormat{Name: format}, AutoSelect: &autoSelect, Priority: &priority},
+			},
+		},
+	}
+}
+
+func newRecommendationsRouter(t *testing.T) *gin.Engine {
+	client := newTestClient(t,
+		toUnstructured(t, &v1beta1.ClusterBaseModel{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "ome.io/v1beta1", Kind: "ClusterBaseModel"},
+			ObjectMeta: metav1.ObjectMeta{Name: "llama-3-8b"},
+			Spec:       v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "safetensors"}},
+		}),
+		toUnstructured(t, &v1beta1.BaseModel{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "ome.io/v1beta1", Kind: "BaseModel"},
+			ObjectMeta: metav1.ObjectMeta{Name: "tiny-gguf", Namespace: "team-a"},
+			Spec:       v1beta1.BaseModelSpec{ModelFormat: v1beta1.ModelFormat{Name: "gguf"}},
+		}),
+		toUnstructured(t, testClusterServingRuntime("srt-preferred", "safetensors", 2)),
+		toUnstructured(t, testClusterServingRuntime("srt-fallback", "safetensors", 1)),
+		toUnstructured(t, testClusterServingRuntime("onnx-runtime", "onnx", 1)),
+	)
+	h := NewRuntimesHandler(client, zap.NewNop())
+	router := gin.New()
+	runtimes := router.Group("/api/v1/runtimes")
+	runtimes.GET("/compatible", h.FindCompatibleRuntimes)
+	runtimes.GET("/recommend", h.GetRecommendation)
+	runtimes.POST("/validate", h.ValidateConfiguration)
+	runtimes.GET("/:name/compatibility", h.CheckCompatibility)
+	return router
+}
+
+func TestRuntimesGetRecommendation(t *testing.T) {
+	router := newRecommendationsRouter(t)
+
+	tests := []struct {
+		name            string
+		target          string
+		expectedStatus  int
+		expectedRuntime string
+	}{
+		{
+			name:            "runtime of highest priority",
+			target:          "/api/v1/runtimes/recommend?model=llama-3-8b",
+			expectedStatus:  http.StatusOK,
+			expectedRuntime: "srt-preferred",
+		},
+		{
+			name:           "no runtime supports the model",
+			target:         "/api/v1/runtimes/recommend?model=tiny-gguf&kind=BaseModel&namespace=team-a",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing model",
+			target:         "/api/v1/runtimes/recommend?model=missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "model is required",
+			target:         "/api/v1/runtimes/recommend",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported model kind",
+			target:         "/api/v1/runtimes/recommend?model=llama-3-8b&kind=FineTunedWeight",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedRuntime == "" {
+				return
+			}
+			body := decode(t, w)
+			assert.Equal(t, true, body["isCluster"])
+			assert.NotEmpty(t, body["recommendation"])
+			runtime := body["runtime"].(map[string]interface{})
+			assert.Equal(t, tt.expectedRuntime, runtime["metadata"].(map[string]interface{})["name"])
+		})
+	}
+}
+
+func TestRuntimesFindCompatibleRuntimes(t *testing.T) {
+	router := newRecommendationsRouter(t)
+
+	w := serve(router, http.MethodGet, "/api/v1/runtimes/compatible?model=llama-3-8b", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	body := decode(t, w)
+	assert.Equal(t, float64(3), body["total"])
+
+	var names []string
+	for _, match := range body["matches"].([]interface{}) {
+		runtime := match.(map[string]interface{})["runtime"].(map[string]interface{})
+		names = append(names, runtime["metadata"].(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"srt-preferred", "srt-fallback"}, names, "matches are in the order the controller ranks them")
+	require.Len(t, body["rejected"], 1)
+
+	// A model no runtime supports lists the rejected runtimes rather than failing
+	w = serve(router, http.MethodGet, "/api/v1/runtimes/compatible?model=tiny-gguf&kind=BaseModel&namespace=team-a", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	body = decode(t, w)
+	assert.Empty(t, body["matches"])
+	assert.Len(t, body["rejected"], 3)
+
+	w = serve(router, http.MethodGet, "/api/v1/runtimes/compatible", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRuntimesCheckCompatibility(t *testing.T) {
+	router := newRecommendationsRouter(t)
+
+	tests := []struct {
+		name               string
+		target             string
+		expectedStatus     int
+		expectedCompatible bool
+	}{
+		{
+			name:               "compatible runtime",
+			target:             "/api/v1/runtimes/srt-fallback/compatibility?model=llama-3-8b",
+			expectedStatus:     http.StatusOK,
+			expectedCompatible: true,
+		},
+		{
+			name:           "incompatible runtime",
+			target:         "/api/v1/runtimes/onnx-runtime/compatibility?model=llama-3-8b",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing runtime",
+			target:         "/api/v1/runtimes/missing/compatibility?model=llama-3-8b",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "model is required",
+			target:         "/api/v1/runtimes/srt-fallback/compatibility",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			body := decode(t, w)
+			assert.Equal(t, tt.expectedCompatible, body["isCompatible"])
+			assert.Equal(t, true, body["isCluster"])
+		})
+	}
+}
+
+func TestRuntimesValidateConfiguration(t *testing.T) {
+	router := newRecommendationsRouter(t)
+
+	tests := []struct {
+		name             string
+		body             interface{}
+		expectedStatus   int
+		expectedValid    bool
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{
+			name: "valid runtime",
+			body: map[string]interface{}{"spec": map[string]interface{}{
+				"supportedModelFormats": []interface{}{map[string]interface{}{"name": "safetensors"}},
+				"protocolVersions":      []interface{}{"openAI"},
+				"containers":            []interface{}{map[string]interface{}{"name": "ome-container", "image": "sglang"}},
+			}},
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+		},
+		{
+			name: "container without image",
+			body: map[string]interface{}{"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "ome-container"}},
+			}},
+			expectedStatus:   http.StatusOK,
+			expectedErrors:   1,
+			expectedWarnings: 2,
+		},
+		{
+			name:           "missing spec",
+			body:           map[string]interface{}{},
+			expectedStatus: http.StatusOK,
+			expectedErrors: 1,
+		},
+		{
+			name:           "invalid body",
+			body:           "not json",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodPost, "/api/v1/runtimes/validate", tt.body)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			body := decode(t, w)
+			assert.Equal(t, tt.expectedValid, body["valid"])
+			// Empty lists are encoded as null
+			errors, _ := body["errors"].([]interface{})
+			warnings, _ := body["warnings"].([]interface{})
+			assert.Len(t, errors, tt.expectedErrors)
+			assert.Len(t, warnings, tt.expectedWarnings)
+		})
+	}
+}
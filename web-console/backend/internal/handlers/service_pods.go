@@ -0,0 +1,464 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/web-console/backend/internal/services"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
+)
+
+const (
+	// maxLogStreams bounds the containers whose logs a single request streams
+	maxLogStreams = 16
+	// defaultTailLines is the number of past log lines sent before following the logs
+	defaultTailLines = 100
+	// maxLogLineSize bounds a log line, longer lines end the stream of their container
+	maxLogLineSize = 1024 * 1024
+)
+
+// ServicePod is a pod of an InferenceService with its place in the service
+type ServicePod struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Phase     string `json:"phase"`
+	Node      string `json:"node,omitempty"`
+	// Role is leader or worker for the pods of a LeaderWorkerSet, empty otherwise
+	Role        string   `json:"role,omitempty"`
+	GroupIndex  string   `json:"groupIndex,omitempty"`
+	WorkerIndex string   `json:"workerIndex,omitempty"`
+	Containers  []string `json:"containers"`
+	Ready       bool     `json:"ready"`
+	Restarts    int32    `json:"restarts"`
+}
+
+// logLine is a line of the logs of a container, sent as a log event
+type logLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Line      string `json:"line"`
+}
+
+// logError reports a container whose logs cannot be streamed, sent as an error event
+type logError struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Error     string `json:"error"`
+}
+
+// Pods handles GET /api/v1/services/:name/pods
+func (h *ServicesHandler) Pods(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+	component := c.Query("component") // Optional query parameter
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if !validComponent(component, true) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid component",
+			"details": fmt.Sprintf("component %q is not one of router, engine or decoder", component),
+		})
+		return
+	}
+
+	pods, err := h.servicePods(ctx, namespace, name, component)
+	if err != nil {
+		h.logger.Error("Failed to list service pods",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to list service pods",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	items := make([]ServicePod, 0, len(pods))
+	for i := range pods {
+		items = append(items, servicePod(&pods[i]))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+// Logs handles GET /api/v1/services/:name/logs, streaming the logs of the pods of a component over SSE. The pods
+// are narrowed with the pod and worker query parameters, worker selecting the leader, the workers or a worker index
+// of LeaderWorkerSet pods
+func (h *ServicesHandler) Logs(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+	component := c.DefaultQuery("component", string(constants.Engine))
+	podName := c.Query("pod")
+	containerName := c.Query("container")
+	worker := c.Query("worker")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	opts, err := logOptions(c)
+	if err == nil && !validComponent(component, false) {
+		err = fmt.Errorf("component %q is not one of router, engine or decoder", component)
+	}
+	if err == nil && !validWorker(worker) {
+		err = fmt.Errorf("worker %q is not leader, workers or a worker index", worker)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid log request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	pods, err := h.servicePods(ctx, namespace, name, component)
+	if err != nil {
+		h.logger.Error("Failed to list service pods",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+			"error":   "Failed to list service pods",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Select the containers to stream
+	var targets []logError
+	for i := range pods {
+		pod := &pods[i]
+		if (podName != "" && pod.Name != podName) || !matchesWorker(pod, worker) {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if containerName == "" || container.Name == containerName {
+				targets = append(targets, logError{Pod: pod.Name, Container: container.Name})
+			}
+		}
+	}
+	if len(targets) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "No matching containers",
+			"details": fmt.Sprintf("no container of the %s pods of service %s matches the request", component, name),
+		})
+		return
+	}
+	if len(targets) > maxLogStreams {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Too many containers",
+			"details": fmt.Sprintf("the request matches %d containers, select at most %d with pod, worker or container", len(targets), maxLogStreams),
+		})
+		return
+	}
+
+	// Open the streams before answering, so that a user who may not read the logs gets an error status. Other
+	// failures, e.g. containers still waiting to start, are sent as error events
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	streams := make([]io.ReadCloser, len(targets))
+	defer func() {
+		for _, stream := range streams {
+			if stream != nil {
+				stream.Close()
+			}
+		}
+	}()
+	for i := range targets {
+		containerOpts := *opts
+		containerOpts.Container = targets[i].Container
+		streams[i], err = h.k8sClient.StreamPodLogs(streamCtx, namespace, targets[i].Pod, &containerOpts)
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			h.logger.Error("Failed to stream pod logs",
+				zap.String("pod", targets[i].Pod),
+				zap.String("namespace", namespace),
+				zap.Error(err))
+			c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
+				"error":   "Failed to stream pod logs",
+				"details": err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			targets[i].Error = err.Error()
+		}
+	}
+
+	// Validate and set CORS origin header
+	origin := c.GetHeader("Origin")
+	if origin != "" && isOriginAllowed(origin) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	// Set headers for SSE
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+
+	// Followed logs outlive the write timeout of the server
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Warn("Failed to clear the write deadline of the log stream", zap.Error(err))
+	}
+
+	lines := make(chan logLine)
+	failures := make(chan logError, len(targets))
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		if stream == nil {
+			failures <- targets[i]
+			continue
+		}
+		wg.Add(1)
+		go func(target logError, stream io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+			for scanner.Scan() {
+				select {
+				case lines <- logLine{Pod: target.Pod, Container: target.Container, Line: scanner.Text()}:
+				case <-streamCtx.Done():
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && streamCtx.Err() == nil {
+				target.Error = err.Error()
+				failures <- target
+			}
+		}(targets[i], stream)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	h.logger.Info("Log stream client connected",
+		zap.String("name", name),
+		zap.String("namespace", namespace),
+		zap.Int("containers", len(targets)),
+		zap.String("client_ip", c.ClientIP()))
+
+	// Send initial connection confirmation
+	fmt.Fprintf(c.Writer, "event: connected\ndata: {\"message\": \"Connected to log stream\"}\n\n")
+	c.Writer.Flush()
+
+	// Create a ticker for periodic keep-alive messages
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				// All streams ended, e.g. without following the logs
+				h.sendFailures(c, failures)
+				fmt.Fprintf(c.Writer, "event: end\ndata: {}\n\n")
+				c.Writer.Flush()
+				return
+			}
+			h.sendEvent(c, "log", line)
+
+		case failure := <-failures:
+			h.sendEvent(c, "error", failure)
+
+		case <-ticker.C:
+			// Send keep-alive ping
+			fmt.Fprintf(c.Writer, "event: ping\ndata: {\"timestamp\": \"%s\"}\n\n", time.Now().Format(time.RFC3339))
+			c.Writer.Flush()
+
+		case <-ctx.Done():
+			// Client disconnected
+			h.logger.Info("Log stream client disconnected", zap.String("client_ip", c.ClientIP()))
+			return
+		}
+	}
+}
+
+// Metrics handles GET /api/v1/services/:name/metrics
+func (h *ServicesHandler) Metrics(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+	namespace := c.Query("namespace")
+	component := c.DefaultQuery("component", string(constants.Engine))
+	window := c.DefaultQuery("window", "5m")
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if !validComponent(component, false) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid component",
+			"details": fmt.Sprintf("component %q is not one of router, engine or decoder", component),
+		})
+		return
+	}
+
+	metrics, err := h.metrics.GetMetrics(ctx, namespace, name, component, window)
+	if err != nil {
+		h.logger.Error("Failed to get service metrics",
+			zap.String("name", name),
+			zap.String("namespace", namespace),
+			zap.Error(err))
+		status := errorStatus(err, http.StatusBadGateway)
+		if errors.Is(err, services.ErrInvalidWindow) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to get service metrics",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// servicePods lists the pods of a component of an InferenceService the user of the request may get
+func (h *ServicesHandler) servicePods(ctx context.Context, namespace, name, component string) ([]corev1.Pod, error) {
+	if _, err := h.k8sClient.GetInferenceService(ctx, namespace, name); err != nil {
+		return nil, err
+	}
+	pods, err := h.k8sClient.ListInferenceServicePods(ctx, namespace, name, component)
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// sendEvent sends an SSE event with a JSON payload
+func (h *ServicesHandler) sendEvent(c *gin.Context, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Error("Failed to marshal event", zap.Error(err))
+		return
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, data)
+	c.Writer.Flush()
+}
+
+// sendFailures sends the pending failures of the log streams
+func (h *ServicesHandler) sendFailures(c *gin.Context, failures <-chan logError) {
+	for {
+		select {
+		case failure := <-failures:
+			h.sendEvent(c, "error", failure)
+		default:
+			return
+		}
+	}
+}
+
+// logOptions parses the tailLines, follow and timestamps query parameters
+func logOptions(c *gin.Context) (*corev1.PodLogOptions, error) {
+	tailLines, err := strconv.ParseInt(c.DefaultQuery("tailLines", strconv.Itoa(defaultTailLines)), 10, 64)
+	if err != nil || tailLines < 0 {
+		return nil, fmt.Errorf("tailLines %q is not a non-negative integer", c.Query("tailLines"))
+	}
+	follow, err := strconv.ParseBool(c.DefaultQuery("follow", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("follow %q is not a boolean", c.Query("follow"))
+	}
+	timestamps, err := strconv.ParseBool(c.DefaultQuery("timestamps", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("timestamps %q is not a boolean", c.Query("timestamps"))
+	}
+	return &corev1.PodLogOptions{
+		TailLines:  &tailLines,
+		Follow:     follow,
+		Timestamps: timestamps,
+	}, nil
+}
+
+// validComponent reports whether component names a component of an InferenceService, or is empty when allowed
+func validComponent(component string, allowEmpty bool) bool {
+	switch constants.InferenceServiceComponent(component) {
+	case constants.Router, constants.Engine, constants.Decoder:
+		return true
+	case "":
+		return allowEmpty
+	}
+	return false
+}
+
+// validWorker reports whether worker is a worker selection: empty, leader, workers or a worker index
+func validWorker(worker string) bool {
+	switch worker {
+	case "", "leader", "workers":
+		return true
+	}
+	index, err := strconv.Atoi(worker)
+	return err == nil && index >= 0
+}
+
+// matchesWorker reports whether a pod matches a worker selection. Only LeaderWorkerSet pods match a selection, the
+// leader having the worker index 0
+func matchesWorker(pod *corev1.Pod, worker string) bool {
+	if worker == "" {
+		return true
+	}
+	index, ok := pod.Labels[lwsspec.WorkerIndexLabelKey]
+	if !ok {
+		return false
+	}
+	switch worker {
+	case "leader":
+		return index == "0"
+	case "workers":
+		return index != "0"
+	}
+	return index == worker
+}
+
+// servicePod summarizes a pod of an InferenceService
+func servicePod(pod *corev1.Pod) ServicePod {
+	result := ServicePod{
+		Name:        pod.Name,
+		Component:   pod.Labels[constants.OMEComponentLabel],
+		Phase:       string(pod.Status.Phase),
+		Node:        pod.Spec.NodeName,
+		GroupIndex:  pod.Labels[lwsspec.GroupIndexLabelKey],
+		WorkerIndex: pod.Labels[lwsspec.WorkerIndexLabelKey],
+		Containers:  make([]string, 0, len(pod.Spec.Containers)),
+	}
+	switch result.WorkerIndex {
+	case "":
+	case "0":
+		result.Role = "leader"
+	default:
+		result.Role = "worker"
+	}
+	for _, container := range pod.Spec.Containers {
+		result.Containers = append(result.Containers, container.Name)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			result.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		result.Restarts += status.RestartCount
+	}
+	return result
+}
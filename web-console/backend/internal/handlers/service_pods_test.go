@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	lwsspec "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+)
+
+func testInferenceService(namespace, name string) *unstructured.Unstructured {
+	isvc := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	isvc.SetAPIVersion("ome.io/v1beta1")
+	isvc.SetKind("InferenceService")
+	isvc.SetNamespace(namespace)
+	isvc.SetName(name)
+	return isvc
+}
+
+// testPod is a running pod of a component of an InferenceService, a LeaderWorkerSet pod when workerIndex is set
+func testPod(service, component, name, workerIndex string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: service,
+				constants.OMEComponentLabel:           component,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: containers[0], RestartCount: 1}},
+		},
+	}
+	if workerIndex != "" {
+		pod.Labels[lwsspec.GroupIndexLabelKey] = "0"
+		pod.Labels[lwsspec.WorkerIndexLabelKey] = workerIndex
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func newServicesRouter(t *testing.T, objects ...runtime.Object) (*gin.Engine, *k8s.Client) {
+	objects = append([]runtime.Object{
+		testInferenceService("default", "llama"),
+		testPod("llama", "engine", "llama-engine-0", "0", "ome-container", "prober"),
+		testPod("llama", "engine", "llama-engine-0-1", "1", "ome-container"),
+		testPod("llama", "router", "llama-router-abc", "", "router"),
+		testPod("other", "engine", "other-engine-0", "", "ome-container"),
+	}, objects...)
+	client := newTestClient(t, objects...)
+	h := NewServicesHandler(client, zap.NewNop())
+	router := gin.New()
+	router.GET("/api/v1/services/:name/pods", h.Pods)
+	router.GET("/api/v1/services/:name/logs", h.Logs)
+	router.GET("/api/v1/services/:name/metrics", h.Metrics)
+	return router, client
+}
+
+func TestServicePods(t *testing.T) {
+	router, _ := newServicesRouter(t)
+
+	w := serve(router, http.MethodGet, "/api/v1/services/llama/pods", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Items []ServicePod `json:"items"`
+		Total int          `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 3, body.Total)
+
+	w = serve(router, http.MethodGet, "/api/v1/services/llama/pods?component=engine", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Items, 2)
+	pods := map[string]ServicePod{}
+	for _, pod := range body.Items {
+		pods[pod.Name] = pod
+	}
+	assert.Equal(t, ServicePod{
+		Name:        "llama-engine-0",
+		Component:   "engine",
+		Phase:       "Running",
+		Role:        "leader",
+		GroupIndex:  "0",
+		WorkerIndex: "0",
+		Containers:  []string{"ome-container", "prober"},
+		Ready:       true,
+		Restarts:    1,
+	}, pods["llama-engine-0"])
+	assert.Equal(t, "worker", pods["llama-engine-0-1"].Role)
+
+	w = serve(router, http.MethodGet, "/api/v1/services/llama/pods?component=worker", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(router, http.MethodGet, "/api/v1/services/missing/pods", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// sseEvent is an event of a server-sent event stream
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readEvents(t *testing.T, body string) []sseEvent {
+	var events []sseEvent
+	var event sseEvent
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.data = strings.TrimPrefix(line, "data: ")
+		case line == "":
+			events = append(events, event)
+			event = sseEvent{}
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestServiceLogs(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		expectedContainers []string
+	}{
+		{
+			name:               "all containers of the engine",
+			query:              "",
+			expectedContainers: []string{"llama-engine-0/ome-container", "llama-engine-0/prober", "llama-engine-0-1/ome-container"},
+		},
+		{
+			name:               "leader of the engine",
+			query:              "?worker=leader&container=ome-container",
+			expectedContainers: []string{"llama-engine-0/ome-container"},
+		},
+		{
+			name:               "workers of the engine",
+			query:              "?worker=workers",
+			expectedContainers: []string{"llama-engine-0-1/ome-container"},
+		},
+		{
+			name:               "pod of the router",
+			query:              "?component=router&pod=llama-router-abc&follow=false&tailLines=10",
+			expectedContainers: []string{"llama-router-abc/router"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newServicesRouter(t)
+
+			// The logs of the fake clientset end after a line, so the stream ends even when following the logs
+			w := serve(router, http.MethodGet, "/api/v1/services/llama/logs"+tt.query, nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+			events := readEvents(t, w.Body.String())
+			require.GreaterOrEqual(t, len(events), 2)
+			assert.Equal(t, "connected", events[0].name)
+			assert.Equal(t, sseEvent{name: "end", data: "{}"}, events[len(events)-1], "the stream ends once all logs are sent")
+
+			var containers []string
+			for _, event := range events[1 : len(events)-1] {
+				require.Equal(t, "log", event.name, event.data)
+				var line logLine
+				require.NoError(t, json.Unmarshal([]byte(event.data), &line))
+				assert.Equal(t, "fake logs", line.Line)
+				containers = append(containers, line.Pod+"/"+line.Container)
+			}
+			assert.ElementsMatch(t, tt.expectedContainers, containers)
+		})
+	}
+}
+
+func TestServiceLogsErrors(t *testing.T) {
+	manyContainers := make([]string, maxLogStreams+1)
+	for i := range manyContainers {
+		manyContainers[i] = fmt.Sprintf("container-%d", i)
+	}
+	router, client := newServicesRouter(t,
+		testInferenceService("team-a", "mistral"),
+		testPod("crowded", "engine", "crowded-engine-0", "", manyContainers...),
+		testInferenceService("default", "crowded"))
+	client.SetAllowedNamespaces([]string{"default"})
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{name: "invalid tail lines", target: "/api/v1/services/llama/logs?tailLines=-1", expectedStatus: http.StatusBadRequest},
+		{name: "invalid follow", target: "/api/v1/services/llama/logs?follow=sometimes", expectedStatus: http.StatusBadRequest},
+		{name: "invalid timestamps", target: "/api/v1/services/llama/logs?timestamps=1s", expectedStatus: http.StatusBadRequest},
+		{name: "invalid component", target: "/api/v1/services/llama/logs?component=worker", expectedStatus: http.StatusBadRequest},
+		{name: "invalid worker", target: "/api/v1/services/llama/logs?worker=-1", expectedStatus: http.StatusBadRequest},
+		{name: "missing service", target: "/api/v1/services/missing/logs", expectedStatus: http.StatusNotFound},
+		{name: "namespace outside of the console", target: "/api/v1/services/mistral/logs?namespace=team-a", expectedStatus: http.StatusForbidden},
+		{name: "missing pod", target: "/api/v1/services/llama/logs?pod=llama-engine-9", expectedStatus: http.StatusNotFound},
+		{name: "missing container", target: "/api/v1/services/llama/logs?container=sidecar", expectedStatus: http.StatusNotFound},
+		{name: "worker of a pod outside of a LeaderWorkerSet", target: "/api/v1/services/llama/logs?component=router&worker=leader", expectedStatus: http.StatusNotFound},
+		{name: "too many containers", target: "/api/v1/services/crowded/logs", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.NotEqual(t, "text/event-stream", w.Header().Get("Content-Type"))
+		})
+	}
+}
+
+// testPrometheus answers instant queries with the value of the first matching pattern, and no series otherwise
+type testPrometheus struct {
+	mu      sync.Mutex
+	queries []string
+	values  map[string]string
+}
+
+func (p *testPrometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	query := r.Form.Get("query")
+	p.mu.Lock()
+	p.queries = append(p.queries, query)
+	p.mu.Unlock()
+
+	result := []interface{}{}
+	for pattern, value := range p.values {
+		if strings.Contains(query, pattern) {
+			result = append(result, map[string]interface{}{"metric": map[string]string{}, "value": []interface{}{1700000000, value}})
+			break
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data":   map[string]interface{}{"resultType": "vector", "result": result},
+	})
+}
+
+func TestServiceMetrics(t *testing.T) {
+	prometheus := &testPrometheus{values: map[string]string{
+		"num_requests_total":       "2.5",
+		"histogram_quantile(0.5,":  "0.12",
+		"histogram_quantile(0.95,": "0.3",
+	}}
+	server := httptest.NewServer(prometheus)
+	t.Cleanup(server.Close)
+	t.Setenv("PROMETHEUS_URL", server.URL)
+	router, _ := newServicesRouter(t)
+
+	w := serve(router, http.MethodGet, "/api/v1/services/llama/metrics?window=10m", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	body := decode(t, w)
+	assert.Equal(t, "engine", body["component"])
+	assert.Equal(t, "10m", body["window"])
+	assert.Equal(t, 2.5, body["requestRate"])
+	assert.Equal(t, 0.12, body["ttftP50"])
+	assert.Equal(t, 0.3, body["ttftP95"])
+	assert.Nil(t, body["gpuUtilization"], "metrics without samples are null")
+
+	require.Len(t, prometheus.queries, 4)
+	for _, query := range prometheus.queries {
+		assert.Contains(t, query, `namespace="default"`)
+		assert.Contains(t, query, "[10m]")
+	}
+	assert.Contains(t, prometheus.queries[0], `app=~"llama-engine|llama-engine-canary"`)
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{name: "invalid window", target: "/api/v1/services/llama/metrics?window=5m])", expectedStatus: http.StatusBadRequest},
+		{name: "invalid component", target: "/api/v1/services/llama/metrics?component=worker", expectedStatus: http.StatusBadRequest},
+		{name: "missing service", target: "/api/v1/services/missing/metrics", expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, tt.target, nil)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	// Prometheus failures are reported as a bad gateway
+	server.Close()
+	w = serve(router, http.MethodGet, "/api/v1/services/llama/metrics", nil)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"github.com/sgl-project/ome/web-console/backend/internal/services"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -12,6 +13,7 @@ import (
 // ServicesHandler handles HTTP requests for InferenceService resources
 type ServicesHandler struct {
 	k8sClient *k8s.Client
+	metrics   *services.ServiceMetricsService
 	logger    *zap.Logger
 }
 
@@ -19,6 +21,7 @@ type ServicesHandler struct {
 func NewServicesHandler(k8sClient *k8s.Client, logger *zap.Logger) *ServicesHandler {
 	return &ServicesHandler{
 		k8sClient: k8sClient,
+		metrics:   services.NewServiceMetricsService(k8sClient, logger),
 		logger:    logger,
 	}
 }
@@ -31,7 +34,7 @@ func (h *ServicesHandler) List(c *gin.Context) {
 	services, err := h.k8sClient.ListInferenceServices(ctx, namespace)
 	if err != nil {
 		h.logger.Error("Failed to list services", zap.String("namespace", namespace), zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to list services",
 			"details": err.Error(),
 		})
@@ -60,7 +63,7 @@ func (h *ServicesHandler) Get(c *gin.Context) {
 			zap.String("name", name),
 			zap.String("namespace", namespace),
 			zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Service not found",
 			"details": err.Error(),
 		})
@@ -105,7 +108,7 @@ func (h *ServicesHandler) Create(c *gin.Context) {
 	created, err := h.k8sClient.CreateInferenceService(ctx, namespace, service)
 	if err != nil {
 		h.logger.Error("Failed to create service", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to create service",
 			"details": err.Error(),
 		})
@@ -160,7 +163,7 @@ func (h *ServicesHandler) Update(c *gin.Context) {
 			zap.String("name", name),
 			zap.String("namespace", namespace),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to update service",
 			"details": err.Error(),
 		})
@@ -189,7 +192,7 @@ func (h *ServicesHandler) Delete(c *gin.Context) {
 			zap.String("name", name),
 			zap.String("namespace", namespace),
 			zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{
 			"error":   "Failed to delete service",
 			"details": err.Error(),
 		})
@@ -222,7 +225,7 @@ func (h *ServicesHandler) GetStatus(c *gin.Context) {
 			zap.String("name", name),
 			zap.String("namespace", namespace),
 			zap.Error(err))
-		c.JSON(http.StatusNotFound, gin.H{
+		c.JSON(errorStatus(err, http.StatusNotFound), gin.H{
 			"error":   "Service not found",
 			"details": err.Error(),
 		})
@@ -3,6 +3,7 @@ package k8s
 import (
 	"context"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -19,6 +20,10 @@ var (
 
 // ListAcceleratorClasses returns all AcceleratorClasses in the cluster from cache
 func (c *Client) ListAcceleratorClasses(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", AcceleratorClassGVR, "", ""); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(AcceleratorClassGVR).Lister()
 	objs, err := lister.List(labels.Everything())
@@ -39,6 +44,10 @@ func (c *Client) ListAcceleratorClasses(ctx context.Context) (*unstructured.Unst
 
 // GetAcceleratorClass returns a specific AcceleratorClass by name from cache
 func (c *Client) GetAcceleratorClass(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", AcceleratorClassGVR, "", name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(AcceleratorClassGVR).Lister()
 	obj, err := lister.Get(name)
@@ -47,3 +56,30 @@ func (c *Client) GetAcceleratorClass(ctx context.Context, name string) (*unstruc
 	}
 	return obj.(*unstructured.Unstructured), nil
 }
+
+// CreateAcceleratorClass creates a new AcceleratorClass
+func (c *Client) CreateAcceleratorClass(ctx context.Context, accelerator *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(AcceleratorClassGVR).Create(ctx, accelerator, metav1.CreateOptions{})
+}
+
+// UpdateAcceleratorClass updates an existing AcceleratorClass
+func (c *Client) UpdateAcceleratorClass(ctx context.Context, accelerator *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(AcceleratorClassGVR).Update(ctx, accelerator, metav1.UpdateOptions{})
+}
+
+// DeleteAcceleratorClass deletes an AcceleratorClass by name
+func (c *Client) DeleteAcceleratorClass(ctx context.Context, name string) error {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(AcceleratorClassGVR).Delete(ctx, name, metav1.DeleteOptions{})
+}
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// BenchmarkJob GVR
+	BenchmarkJobGVR = schema.GroupVersionResource{
+		Group:    "ome.io",
+		Version:  "v1beta1",
+		Resource: "benchmarkjobs",
+	}
+)
+
+// ListBenchmarkJobs returns all BenchmarkJobs in the specified namespace from cache
+// If namespace is empty, lists across all the namespaces the user of the request may list
+func (c *Client) ListBenchmarkJobs(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
+	if namespace != "" {
+		if err := c.Authorize(ctx, "list", BenchmarkJobGVR, namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use lister instead of direct API call
+	var objs []runtime.Object
+	var err error
+
+	if namespace == "" {
+		// List across all namespaces
+		lister := c.DynamicInformerFactory.ForResource(BenchmarkJobGVR).Lister()
+		objs, err = lister.List(labels.Everything())
+	} else {
+		// List in specific namespace
+		lister := c.DynamicInformerFactory.ForResource(BenchmarkJobGVR).Lister().ByNamespace(namespace)
+		objs, err = lister.List(labels.Everything())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the items of the namespaces the user may list
+	items, err := c.listAuthorized(ctx, BenchmarkJobGVR, objs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unstructured.UnstructuredList{
+		Items: items,
+	}, nil
+}
+
+// GetBenchmarkJob returns a specific BenchmarkJob by name and namespace from cache
+func (c *Client) GetBenchmarkJob(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", BenchmarkJobGVR, namespace, name); err != nil {
+		return nil, err
+	}
+
+	// Use lister instead of direct API call
+	lister := c.DynamicInformerFactory.ForResource(BenchmarkJobGVR).Lister().ByNamespace(namespace)
+	obj, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*unstructured.Unstructured), nil
+}
+
+// CreateBenchmarkJob creates a new BenchmarkJob
+func (c *Client) CreateBenchmarkJob(ctx context.Context, namespace string, job *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if err := c.checkNamespace(BenchmarkJobGVR, namespace, job.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(BenchmarkJobGVR).Namespace(namespace).Create(ctx, job, metav1.CreateOptions{})
+}
+
+// UpdateBenchmarkJob updates an existing BenchmarkJob
+func (c *Client) UpdateBenchmarkJob(ctx context.Context, namespace string, job *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if err := c.checkNamespace(BenchmarkJobGVR, namespace, job.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(BenchmarkJobGVR).Namespace(namespace).Update(ctx, job, metav1.UpdateOptions{})
+}
+
+// DeleteBenchmarkJob deletes a BenchmarkJob by name and namespace
+func (c *Client) DeleteBenchmarkJob(ctx context.Context, namespace, name string) error {
+	if err := c.checkNamespace(BenchmarkJobGVR, namespace, name); err != nil {
+		return err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(BenchmarkJobGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
@@ -27,11 +28,15 @@ type ResourceEvent struct {
 	Resource string      `json:"resource"` // "models", "runtimes", "services", etc.
 	Name     string      `json:"name"`
 	Data     interface{} `json:"data,omitempty"`
+
+	// Namespace and GVR of the resource, to only send events to the users who may watch it
+	Namespace string                      `json:"namespace,omitempty"`
+	GVR       schema.GroupVersionResource `json:"-"`
 }
 
 // Client wraps Kubernetes client functionality with informers
 type Client struct {
-	Clientset              *kubernetes.Clientset
+	Clientset              kubernetes.Interface
 	DynamicClient          dynamic.Interface
 	Config                 *rest.Config
 	Logger                 *zap.Logger
@@ -39,6 +44,11 @@ type Client struct {
 	InformerFactory        informers.SharedInformerFactory
 	Broadcaster            *EventBroadcaster
 	stopCh                 chan struct{}
+
+	// allowedNamespaces restricts the console to these namespaces when not empty
+	allowedNamespaces map[string]struct{}
+	// access remembers the access review decisions of the users the console impersonates
+	access *accessCache
 }
 
 // NewClient creates a new Kubernetes client
@@ -97,6 +107,7 @@ func NewClient(logger *zap.Logger) (*Client, error) {
 		InformerFactory:        informerFactory,
 		Broadcaster:            broadcaster,
 		stopCh:                 make(chan struct{}),
+		access:                 &accessCache{decisions: map[string]accessDecision{}},
 	}, nil
 }
 
@@ -0,0 +1,61 @@
+package k8s
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// fakeListKinds are the list kinds of the resources the console reads with the dynamic client
+var fakeListKinds = map[schema.GroupVersionResource]string{
+	ClusterBaseModelGVR:      "ClusterBaseModelList",
+	BaseModelGVR:             "BaseModelList",
+	ClusterServingRuntimeGVR: "ClusterServingRuntimeList",
+	ServingRuntimeGVR:        "ServingRuntimeList",
+	InferenceServiceGVR:      "InferenceServiceList",
+	AcceleratorClassGVR:      "AcceleratorClassList",
+	FineTunedWeightGVR:       "FineTunedWeightList",
+	BenchmarkJobGVR:          "BenchmarkJobList",
+}
+
+// NewFakeClient creates a client backed by fake clientsets, with its informers started and synced, for tests of the
+// handlers and services. Unstructured objects are served by the dynamic client and the others by the typed clientset.
+// Requests without a user act with the fake clientsets; call Stop to stop the informers.
+func NewFakeClient(logger *zap.Logger, objects ...runtime.Object) (*Client, error) {
+	var typed, custom []runtime.Object
+	for _, obj := range objects {
+		if _, ok := obj.(*unstructured.Unstructured); ok {
+			custom = append(custom, obj)
+		} else {
+			typed = append(typed, obj)
+		}
+	}
+
+	clientset := fake.NewSimpleClientset(typed...)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), fakeListKinds, custom...)
+	c := &Client{
+		Clientset:              clientset,
+		DynamicClient:          dynamicClient,
+		Config:                 &rest.Config{Host: "https://kubernetes.default.svc"},
+		Logger:                 logger,
+		DynamicInformerFactory: dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 30*time.Second),
+		InformerFactory:        informers.NewSharedInformerFactory(clientset, 30*time.Second),
+		Broadcaster:            NewEventBroadcaster(),
+		stopCh:                 make(chan struct{}),
+		access:                 &accessCache{decisions: map[string]accessDecision{}},
+	}
+	c.SetupInformers()
+	if err := c.StartInformers(); err != nil {
+		c.Stop()
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// FineTunedWeight GVR
+	FineTunedWeightGVR = schema.GroupVersionResource{
+		Group:    "ome.io",
+		Version:  "v1beta1",
+		Resource: "finetunedweights",
+	}
+)
+
+// ListFineTunedWeights returns all FineTunedWeights in the cluster from cache
+func (c *Client) ListFineTunedWeights(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", FineTunedWeightGVR, "", ""); err != nil {
+		return nil, err
+	}
+
+	// Use lister instead of direct API call
+	lister := c.DynamicInformerFactory.ForResource(FineTunedWeightGVR).Lister()
+	objs, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to UnstructuredList
+	items := make([]unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		items[i] = *obj.(*unstructured.Unstructured)
+	}
+
+	return &unstructured.UnstructuredList{
+		Items: items,
+	}, nil
+}
+
+// GetFineTunedWeight returns a specific FineTunedWeight by name from cache
+func (c *Client) GetFineTunedWeight(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", FineTunedWeightGVR, "", name); err != nil {
+		return nil, err
+	}
+
+	// Use lister instead of direct API call
+	lister := c.DynamicInformerFactory.ForResource(FineTunedWeightGVR).Lister()
+	obj, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*unstructured.Unstructured), nil
+}
+
+// CreateFineTunedWeight creates a new FineTunedWeight
+func (c *Client) CreateFineTunedWeight(ctx context.Context, weight *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(FineTunedWeightGVR).Create(ctx, weight, metav1.CreateOptions{})
+}
+
+// UpdateFineTunedWeight updates an existing FineTunedWeight
+func (c *Client) UpdateFineTunedWeight(ctx context.Context, weight *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(FineTunedWeightGVR).Update(ctx, weight, metav1.UpdateOptions{})
+}
+
+// DeleteFineTunedWeight deletes a FineTunedWeight by name
+func (c *Client) DeleteFineTunedWeight(ctx context.Context, name string) error {
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(FineTunedWeightGVR).Delete(ctx, name, metav1.DeleteOptions{})
+}
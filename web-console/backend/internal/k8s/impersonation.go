@@ -0,0 +1,211 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// accessCacheTTL is how long an access review decision is remembered
+const accessCacheTTL = 30 * time.Second
+
+// NamespaceGVR is the GVR of namespaces, for access reviews
+var NamespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// accessCache remembers the access review decisions of users
+type accessCache struct {
+	mu        sync.Mutex
+	decisions map[string]accessDecision
+}
+
+type accessDecision struct {
+	allowed bool
+	expires time.Time
+}
+
+func (a *accessCache) get(key string) (bool, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	decision, ok := a.decisions[key]
+	if !ok || time.Now().After(decision.expires) {
+		return false, false
+	}
+	return decision.allowed, true
+}
+
+func (a *accessCache) set(key string, allowed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	for k, decision := range a.decisions {
+		if now.After(decision.expires) {
+			delete(a.decisions, k)
+		}
+	}
+	a.decisions[key] = accessDecision{allowed: allowed, expires: now.Add(accessCacheTTL)}
+}
+
+// SetAllowedNamespaces restricts the console to the namespaces. An empty list allows all namespaces.
+func (c *Client) SetAllowedNamespaces(namespaces []string) {
+	c.allowedNamespaces = make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			c.allowedNamespaces[namespace] = struct{}{}
+		}
+	}
+}
+
+// NamespaceAllowed reports whether the namespace is within the namespaces the console is restricted to
+func (c *Client) NamespaceAllowed(namespace string) bool {
+	if len(c.allowedNamespaces) == 0 {
+		return true
+	}
+	_, ok := c.allowedNamespaces[namespace]
+	return ok
+}
+
+// checkNamespace returns a forbidden error when the namespace is outside of the namespaces of the console
+func (c *Client) checkNamespace(gvr schema.GroupVersionResource, namespace, name string) error {
+	if c.NamespaceAllowed(namespace) {
+		return nil
+	}
+	return apierrors.NewForbidden(gvr.GroupResource(), name,
+		fmt.Errorf("namespace %q is not managed by the console", namespace))
+}
+
+// dynamicClient returns the dynamic client impersonating the user of the request
+func (c *Client) dynamicClient(ctx context.Context) (dynamic.Interface, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return c.DynamicClient, nil
+	}
+	return dynamic.NewForConfig(c.impersonationConfig(user))
+}
+
+// clientset returns the typed clientset impersonating the user of the request
+func (c *Client) clientset(ctx context.Context) (kubernetes.Interface, error) {
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return c.Clientset, nil
+	}
+	return kubernetes.NewForConfig(c.impersonationConfig(user))
+}
+
+// impersonationConfig returns a copy of the config of the console impersonating the user. The transports are shared
+// with the console since client-go caches them by TLS configuration.
+func (c *Client) impersonationConfig(user *auth.User) *rest.Config {
+	config := rest.CopyConfig(c.Config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: user.Name,
+		UID:      user.UID,
+		Groups:   user.Groups,
+		Extra:    user.Extra,
+	}
+	return config
+}
+
+// Authorize checks that the user of the request may perform the verb on the resource, with a SubjectAccessReview.
+// Reads are served from the informer caches of the console, so they are authorized here rather than by the API
+// server. Requests without a user act with the service account of the console and are always allowed.
+func (c *Client) Authorize(ctx context.Context, verb string, gvr schema.GroupVersionResource, namespace, name string) error {
+	if namespace != "" {
+		if err := c.checkNamespace(gvr, namespace, name); err != nil {
+			return err
+		}
+	}
+	user := auth.UserFrom(ctx)
+	if user == nil {
+		return nil
+	}
+
+	allowed, err := c.allowed(ctx, user, verb, gvr, namespace, name)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierrors.NewForbidden(gvr.GroupResource(), name,
+			fmt.Errorf("user %q cannot %s %s in namespace %q", user.Name, verb, gvr.Resource, namespace))
+	}
+	return nil
+}
+
+func (c *Client) allowed(ctx context.Context, user *auth.User, verb string, gvr schema.GroupVersionResource, namespace, name string) (bool, error) {
+	groups := append([]string{}, user.Groups...)
+	sort.Strings(groups)
+	key := strings.Join([]string{user.Name, strings.Join(groups, ","), verb, gvr.String(), namespace, name}, "|")
+	if allowed, ok := c.access.get(key); ok {
+		return allowed, nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = v
+	}
+	review, err := c.Clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Name,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+				Name:      name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access: %w", err)
+	}
+
+	c.access.set(key, review.Status.Allowed)
+	return review.Status.Allowed, nil
+}
+
+// listAuthorized returns the objects of a cache listing across namespaces that the user of the request may list,
+// within the namespaces of the console
+func (c *Client) listAuthorized(ctx context.Context, gvr schema.GroupVersionResource, objs []runtime.Object) ([]unstructured.Unstructured, error) {
+	clusterWide := len(c.allowedNamespaces) == 0
+	if clusterWide && auth.UserFrom(ctx) != nil {
+		clusterWide = c.Authorize(ctx, "list", gvr, "", "") == nil
+	}
+
+	items := make([]unstructured.Unstructured, 0, len(objs))
+	namespaces := map[string]bool{}
+	for _, obj := range objs {
+		u := obj.(*unstructured.Unstructured)
+		if !clusterWide {
+			allowed, ok := namespaces[u.GetNamespace()]
+			if !ok {
+				err := c.Authorize(ctx, "list", gvr, u.GetNamespace(), "")
+				if err != nil && !apierrors.IsForbidden(err) {
+					return nil, err
+				}
+				allowed = err == nil
+				namespaces[u.GetNamespace()] = allowed
+			}
+			if !allowed {
+				continue
+			}
+		}
+		items = append(items, *u)
+	}
+	return items, nil
+}
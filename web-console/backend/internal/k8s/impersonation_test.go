@@ -0,0 +1,286 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+)
+
+var testGVR = schema.GroupVersionResource{Group: "ome.io", Version: "v1beta1", Resource: "inferenceservices"}
+
+// accessReviewer decides SubjectAccessReviews with allow and records the reviews it answered
+type accessReviewer struct {
+	allow   func(spec authorizationv1.SubjectAccessReviewSpec) bool
+	reviews []authorizationv1.SubjectAccessReviewSpec
+}
+
+func newTestClient(reviewer *accessReviewer, namespaces ...string) *Client {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviewer.reviews = append(reviewer.reviews, review.Spec)
+		review.Status.Allowed = reviewer.allow(review.Spec)
+		return true, review, nil
+	})
+	c := &Client{
+		Clientset: clientset,
+		Config:    &rest.Config{Host: "https://kubernetes.default.svc", BearerToken: "console-token"},
+		access:    &accessCache{decisions: map[string]accessDecision{}},
+	}
+	c.SetAllowedNamespaces(namespaces)
+	return c
+}
+
+func inNamespace(namespaces ...string) func(authorizationv1.SubjectAccessReviewSpec) bool {
+	return func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+		for _, namespace := range namespaces {
+			if spec.ResourceAttributes.Namespace == namespace {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestImpersonationConfig(t *testing.T) {
+	c := newTestClient(&accessReviewer{})
+	user := &auth.User{
+		Name:   "alice@example.com",
+		UID:    "user-1234",
+		Groups: []string{"ml-team"},
+		Extra:  map[string][]string{"scopes": {"read"}},
+	}
+
+	config := c.impersonationConfig(user)
+
+	assert.Equal(t, rest.ImpersonationConfig{
+		UserName: "alice@example.com",
+		UID:      "user-1234",
+		Groups:   []string{"ml-team"},
+		Extra:    map[string][]string{"scopes": {"read"}},
+	}, config.Impersonate)
+	// The console credentials authenticate the impersonation, and its own config is left as is
+	assert.Equal(t, "console-token", config.BearerToken)
+	assert.Equal(t, c.Config.Host, config.Host)
+	assert.Empty(t, c.Config.Impersonate.UserName)
+}
+
+func TestAuthorize(t *testing.T) {
+	alice := &auth.User{Name: "alice", Groups: []string{"ml-team"}}
+
+	tests := []struct {
+		name            string
+		namespaces      []string
+		user            *auth.User
+		allow           func(authorizationv1.SubjectAccessReviewSpec) bool
+		namespace       string
+		expectedAllowed bool
+		expectedReviews int
+	}{
+		{
+			name:            "without a user the console acts with its service account",
+			allow:           inNamespace(),
+			namespace:       "default",
+			expectedAllowed: true,
+		},
+		{
+			name:            "namespace outside of the console",
+			namespaces:      []string{"team-a"},
+			user:            alice,
+			allow:           inNamespace("team-b"),
+			namespace:       "team-b",
+			expectedAllowed: false,
+		},
+		{
+			name:            "namespace outside of the console without a user",
+			namespaces:      []string{"team-a"},
+			allow:           inNamespace("team-b"),
+			namespace:       "team-b",
+			expectedAllowed: false,
+		},
+		{
+			name:            "allowed by access review",
+			namespaces:      []string{"team-a"},
+			user:            alice,
+			allow:           inNamespace("team-a"),
+			namespace:       "team-a",
+			expectedAllowed: true,
+			expectedReviews: 1,
+		},
+		{
+			name:            "denied by access review",
+			user:            alice,
+			allow:           inNamespace("team-a"),
+			namespace:       "team-b",
+			expectedAllowed: false,
+			expectedReviews: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewer := &accessReviewer{allow: tt.allow}
+			c := newTestClient(reviewer, tt.namespaces...)
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = auth.WithUser(ctx, tt.user)
+			}
+
+			err := c.Authorize(ctx, "get", testGVR, tt.namespace, "llama")
+			if tt.expectedAllowed {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, apierrors.IsForbidden(err), "expected forbidden, got %v", err)
+			}
+			require.Len(t, reviewer.reviews, tt.expectedReviews)
+			if tt.expectedReviews > 0 {
+				spec := reviewer.reviews[0]
+				assert.Equal(t, tt.user.Name, spec.User)
+				assert.Equal(t, tt.user.Groups, spec.Groups)
+				assert.Equal(t, &authorizationv1.ResourceAttributes{
+					Namespace: tt.namespace,
+					Verb:      "get",
+					Group:     "ome.io",
+					Version:   "v1beta1",
+					Resource:  "inferenceservices",
+					Name:      "llama",
+				}, spec.ResourceAttributes)
+			}
+		})
+	}
+}
+
+func TestAuthorizeCache(t *testing.T) {
+	// Only members of the admins group may get the InferenceService
+	reviewer := &accessReviewer{allow: func(spec authorizationv1.SubjectAccessReviewSpec) bool {
+		for _, group := range spec.Groups {
+			if group == "admins" {
+				return true
+			}
+		}
+		return false
+	}}
+	c := newTestClient(reviewer)
+	authorize := func(user *auth.User) error {
+		return c.Authorize(auth.WithUser(context.Background(), user), "get", testGVR, "default", "llama")
+	}
+
+	require.NoError(t, authorize(&auth.User{Name: "alice", Groups: []string{"ml-team", "admins"}}))
+	require.NoError(t, authorize(&auth.User{Name: "alice", Groups: []string{"admins", "ml-team"}}))
+	assert.Len(t, reviewer.reviews, 1, "decisions are cached regardless of the order of the groups")
+
+	// The same user with other groups is reviewed again, rather than reusing the decision of the admins group
+	err := authorize(&auth.User{Name: "alice", Groups: []string{"ml-team"}})
+	assert.True(t, apierrors.IsForbidden(err))
+	assert.Len(t, reviewer.reviews, 2)
+
+	// Decisions are per verb
+	err = c.Authorize(auth.WithUser(context.Background(), &auth.User{Name: "alice", Groups: []string{"admins"}}),
+		"delete", testGVR, "default", "llama")
+	require.NoError(t, err)
+	assert.Len(t, reviewer.reviews, 3)
+}
+
+func TestListAuthorized(t *testing.T) {
+	alice := &auth.User{Name: "alice", Groups: []string{"ml-team"}}
+	objs := []runtime.Object{
+		testObject("team-a", "llama"),
+		testObject("team-a", "mistral"),
+		testObject("team-b", "qwen"),
+		testObject("team-c", "deepseek"),
+	}
+
+	tests := []struct {
+		name            string
+		namespaces      []string
+		user            *auth.User
+		allow           func(authorizationv1.SubjectAccessReviewSpec) bool
+		expectedNames   []string
+		expectedReviews []string
+	}{
+		{
+			name:          "without a user all objects are listed",
+			allow:         inNamespace(),
+			expectedNames: []string{"llama", "mistral", "qwen", "deepseek"},
+		},
+		{
+			name:            "cluster-wide list permission",
+			user:            alice,
+			allow:           func(authorizationv1.SubjectAccessReviewSpec) bool { return true },
+			expectedNames:   []string{"llama", "mistral", "qwen", "deepseek"},
+			expectedReviews: []string{""},
+		},
+		{
+			name:            "list permission in some namespaces",
+			user:            alice,
+			allow:           inNamespace("team-a", "team-c"),
+			expectedNames:   []string{"llama", "mistral", "deepseek"},
+			expectedReviews: []string{"", "team-a", "team-b", "team-c"},
+		},
+		{
+			name:            "console restricted to namespaces",
+			namespaces:      []string{"team-a", "team-b"},
+			user:            alice,
+			allow:           func(authorizationv1.SubjectAccessReviewSpec) bool { return true },
+			expectedNames:   []string{"llama", "mistral", "qwen"},
+			expectedReviews: []string{"team-a", "team-b"},
+		},
+		{
+			name:          "console restricted to namespaces without a user",
+			namespaces:    []string{"team-b"},
+			allow:         inNamespace(),
+			expectedNames: []string{"qwen"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewer := &accessReviewer{allow: tt.allow}
+			c := newTestClient(reviewer, tt.namespaces...)
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = auth.WithUser(ctx, tt.user)
+			}
+
+			items, err := c.listAuthorized(ctx, testGVR, objs)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.GetName())
+			}
+			assert.Equal(t, tt.expectedNames, names)
+
+			reviewed := make([]string, 0, len(reviewer.reviews))
+			for _, review := range reviewer.reviews {
+				assert.Equal(t, "list", review.ResourceAttributes.Verb)
+				reviewed = append(reviewed, review.ResourceAttributes.Namespace)
+			}
+			if tt.expectedReviews == nil {
+				tt.expectedReviews = []string{}
+			}
+			assert.Equal(t, tt.expectedReviews, reviewed, "each namespace is reviewed once")
+		})
+	}
+}
+
+func testObject(namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("ome.io/v1beta1")
+	u.SetKind("InferenceService")
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
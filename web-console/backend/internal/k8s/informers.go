@@ -28,6 +28,12 @@ func (c *Client) SetupInformers() {
 	// Setup AcceleratorClass informer
 	c.setupAcceleratorClassInformer()
 
+	// Setup FineTunedWeight informer
+	c.setupFineTunedWeightInformer()
+
+	// Setup BenchmarkJob informer
+	c.setupBenchmarkJobInformer()
+
 	// Setup Namespace informer
 	c.setupNamespaceInformer()
 
@@ -43,29 +49,35 @@ func (c *Client) setupClusterBaseModelInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterBaseModel added", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "models",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterBaseModelGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterBaseModel updated", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "models",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterBaseModelGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterBaseModel deleted", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "models",
-				Name:     u.GetName(),
+				Type:      "delete",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterBaseModelGVR,
+				Name:      u.GetName(),
 			})
 		},
 	})
@@ -84,29 +96,35 @@ func (c *Client) setupBaseModelInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("BaseModel added", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "models",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       BaseModelGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("BaseModel updated", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "models",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       BaseModelGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("BaseModel deleted", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "models",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
+				Type:      "delete",
+				Resource:  "models",
+				Namespace: u.GetNamespace(),
+				GVR:       BaseModelGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
 			})
 		},
 	})
@@ -125,29 +143,35 @@ func (c *Client) setupClusterServingRuntimeInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterServingRuntime added", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "runtimes",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterServingRuntimeGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterServingRuntime updated", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "runtimes",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterServingRuntimeGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ClusterServingRuntime deleted", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "runtimes",
-				Name:     u.GetName(),
+				Type:      "delete",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ClusterServingRuntimeGVR,
+				Name:      u.GetName(),
 			})
 		},
 	})
@@ -166,29 +190,35 @@ func (c *Client) setupServingRuntimeInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ServingRuntime added", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "runtimes",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ServingRuntimeGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("ServingRuntime updated", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "runtimes",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ServingRuntimeGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("ServingRuntime deleted", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "runtimes",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
+				Type:      "delete",
+				Resource:  "runtimes",
+				Namespace: u.GetNamespace(),
+				GVR:       ServingRuntimeGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
 			})
 		},
 	})
@@ -207,29 +237,35 @@ func (c *Client) setupInferenceServiceInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("InferenceService added", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "services",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "services",
+				Namespace: u.GetNamespace(),
+				GVR:       InferenceServiceGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("InferenceService updated", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "services",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "services",
+				Namespace: u.GetNamespace(),
+				GVR:       InferenceServiceGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("InferenceService deleted", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "services",
-				Name:     u.GetNamespace() + "/" + u.GetName(),
+				Type:      "delete",
+				Resource:  "services",
+				Namespace: u.GetNamespace(),
+				GVR:       InferenceServiceGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
 			})
 		},
 	})
@@ -248,29 +284,35 @@ func (c *Client) setupAcceleratorClassInformer() {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("AcceleratorClass added", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "add",
-				Resource: "accelerators",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "add",
+				Resource:  "accelerators",
+				Namespace: u.GetNamespace(),
+				GVR:       AcceleratorClassGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			u := newObj.(*unstructured.Unstructured)
 			c.Logger.Debug("AcceleratorClass updated", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "update",
-				Resource: "accelerators",
-				Name:     u.GetName(),
-				Data:     u.Object,
+				Type:      "update",
+				Resource:  "accelerators",
+				Namespace: u.GetNamespace(),
+				GVR:       AcceleratorClassGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
 			})
 		},
 		DeleteFunc: func(obj interface{}) {
 			u := obj.(*unstructured.Unstructured)
 			c.Logger.Debug("AcceleratorClass deleted", zap.String("name", u.GetName()))
 			c.Broadcaster.Broadcast(ResourceEvent{
-				Type:     "delete",
-				Resource: "accelerators",
-				Name:     u.GetName(),
+				Type:      "delete",
+				Resource:  "accelerators",
+				Namespace: u.GetNamespace(),
+				GVR:       AcceleratorClassGVR,
+				Name:      u.GetName(),
 			})
 		},
 	})
@@ -280,6 +322,100 @@ func (c *Client) setupAcceleratorClassInformer() {
 	}
 }
 
+// setupFineTunedWeightInformer sets up the FineTunedWeight informer
+func (c *Client) setupFineTunedWeightInformer() {
+	informer := c.DynamicInformerFactory.ForResource(FineTunedWeightGVR).Informer()
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			u := obj.(*unstructured.Unstructured)
+			c.Logger.Debug("FineTunedWeight added", zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "add",
+				Resource:  "finetunedweights",
+				Namespace: u.GetNamespace(),
+				GVR:       FineTunedWeightGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
+			})
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			u := newObj.(*unstructured.Unstructured)
+			c.Logger.Debug("FineTunedWeight updated", zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "update",
+				Resource:  "finetunedweights",
+				Namespace: u.GetNamespace(),
+				GVR:       FineTunedWeightGVR,
+				Name:      u.GetName(),
+				Data:      u.Object,
+			})
+		},
+		DeleteFunc: func(obj interface{}) {
+			u := obj.(*unstructured.Unstructured)
+			c.Logger.Debug("FineTunedWeight deleted", zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "delete",
+				Resource:  "finetunedweights",
+				Namespace: u.GetNamespace(),
+				GVR:       FineTunedWeightGVR,
+				Name:      u.GetName(),
+			})
+		},
+	})
+
+	if err != nil {
+		c.Logger.Error("Failed to add event handler for FineTunedWeight", zap.Error(err))
+	}
+}
+
+// setupBenchmarkJobInformer sets up the BenchmarkJob informer
+func (c *Client) setupBenchmarkJobInformer() {
+	informer := c.DynamicInformerFactory.ForResource(BenchmarkJobGVR).Informer()
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			u := obj.(*unstructured.Unstructured)
+			c.Logger.Debug("BenchmarkJob added", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "add",
+				Resource:  "benchmarks",
+				Namespace: u.GetNamespace(),
+				GVR:       BenchmarkJobGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
+			})
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			u := newObj.(*unstructured.Unstructured)
+			c.Logger.Debug("BenchmarkJob updated", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "update",
+				Resource:  "benchmarks",
+				Namespace: u.GetNamespace(),
+				GVR:       BenchmarkJobGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+				Data:      u.Object,
+			})
+		},
+		DeleteFunc: func(obj interface{}) {
+			u := obj.(*unstructured.Unstructured)
+			c.Logger.Debug("BenchmarkJob deleted", zap.String("namespace", u.GetNamespace()), zap.String("name", u.GetName()))
+			c.Broadcaster.Broadcast(ResourceEvent{
+				Type:      "delete",
+				Resource:  "benchmarks",
+				Namespace: u.GetNamespace(),
+				GVR:       BenchmarkJobGVR,
+				Name:      u.GetNamespace() + "/" + u.GetName(),
+			})
+		},
+	})
+
+	if err != nil {
+		c.Logger.Error("Failed to add event handler for BenchmarkJob", zap.Error(err))
+	}
+}
+
 // setupNamespaceInformer sets up the Namespace informer
 func (c *Client) setupNamespaceInformer() {
 	informer := c.InformerFactory.Core().V1().Namespaces().Informer()
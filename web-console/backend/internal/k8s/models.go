@@ -28,6 +28,10 @@ var (
 
 // ListClusterBaseModels returns all ClusterBaseModels in the cluster from cache
 func (c *Client) ListClusterBaseModels(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", ClusterBaseModelGVR, "", ""); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ClusterBaseModelGVR).Lister()
 	objs, err := lister.List(labels.Everything())
@@ -48,6 +52,10 @@ func (c *Client) ListClusterBaseModels(ctx context.Context) (*unstructured.Unstr
 
 // GetClusterBaseModel returns a specific ClusterBaseModel by name from cache
 func (c *Client) GetClusterBaseModel(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", ClusterBaseModelGVR, "", name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ClusterBaseModelGVR).Lister()
 	obj, err := lister.Get(name)
@@ -59,21 +67,37 @@ func (c *Client) GetClusterBaseModel(ctx context.Context, name string) (*unstruc
 
 // CreateClusterBaseModel creates a new ClusterBaseModel
 func (c *Client) CreateClusterBaseModel(ctx context.Context, model *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ClusterBaseModelGVR).Create(ctx, model, metav1.CreateOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ClusterBaseModelGVR).Create(ctx, model, metav1.CreateOptions{})
 }
 
 // UpdateClusterBaseModel updates an existing ClusterBaseModel
 func (c *Client) UpdateClusterBaseModel(ctx context.Context, model *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ClusterBaseModelGVR).Update(ctx, model, metav1.UpdateOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ClusterBaseModelGVR).Update(ctx, model, metav1.UpdateOptions{})
 }
 
 // DeleteClusterBaseModel deletes a ClusterBaseModel by name
 func (c *Client) DeleteClusterBaseModel(ctx context.Context, name string) error {
-	return c.DynamicClient.Resource(ClusterBaseModelGVR).Delete(ctx, name, metav1.DeleteOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(ClusterBaseModelGVR).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // ListBaseModels returns all BaseModels in a namespace from cache
 func (c *Client) ListBaseModels(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", BaseModelGVR, namespace, ""); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(BaseModelGVR).Lister().ByNamespace(namespace)
 	objs, err := lister.List(labels.Everything())
@@ -94,6 +118,10 @@ func (c *Client) ListBaseModels(ctx context.Context, namespace string) (*unstruc
 
 // GetBaseModel returns a specific BaseModel by name and namespace from cache
 func (c *Client) GetBaseModel(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", BaseModelGVR, namespace, name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(BaseModelGVR).Lister().ByNamespace(namespace)
 	obj, err := lister.Get(name)
@@ -105,17 +133,38 @@ func (c *Client) GetBaseModel(ctx context.Context, namespace, name string) (*uns
 
 // CreateBaseModel creates a new BaseModel in a namespace
 func (c *Client) CreateBaseModel(ctx context.Context, namespace string, model *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(BaseModelGVR).Namespace(namespace).Create(ctx, model, metav1.CreateOptions{})
+	if err := c.checkNamespace(BaseModelGVR, namespace, model.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(BaseModelGVR).Namespace(namespace).Create(ctx, model, metav1.CreateOptions{})
 }
 
 // UpdateBaseModel updates an existing BaseModel in a namespace
 func (c *Client) UpdateBaseModel(ctx context.Context, namespace string, model *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(BaseModelGVR).Namespace(namespace).Update(ctx, model, metav1.UpdateOptions{})
+	if err := c.checkNamespace(BaseModelGVR, namespace, model.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(BaseModelGVR).Namespace(namespace).Update(ctx, model, metav1.UpdateOptions{})
 }
 
 // DeleteBaseModel deletes a BaseModel by name and namespace
 func (c *Client) DeleteBaseModel(ctx context.Context, namespace, name string) error {
-	return c.DynamicClient.Resource(BaseModelGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err := c.checkNamespace(BaseModelGVR, namespace, name); err != nil {
+		return err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(BaseModelGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // GetClusterBaseModelEvents returns K8s events for a ClusterBaseModel
@@ -124,15 +173,26 @@ func (c *Client) GetClusterBaseModelEvents(ctx context.Context, name string) (*c
 	// For cluster-scoped resources, K8s stores events in the "default" namespace
 	// The events are linked via involvedObject with kind=ClusterBaseModel
 	fieldSelector := "involvedObject.kind=ClusterBaseModel,involvedObject.name=" + name
-	return c.Clientset.CoreV1().Events("default").List(ctx, metav1.ListOptions{
+	clientset, err := c.clientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.CoreV1().Events("default").List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	})
 }
 
 // GetBaseModelEvents returns K8s events for a namespace-scoped BaseModel
 func (c *Client) GetBaseModelEvents(ctx context.Context, namespace, name string) (*corev1.EventList, error) {
+	if err := c.checkNamespace(BaseModelGVR, namespace, name); err != nil {
+		return nil, err
+	}
 	fieldSelector := "involvedObject.kind=BaseModel,involvedObject.name=" + name
-	return c.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+	clientset, err := c.clientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	})
 }
@@ -146,6 +206,7 @@ const (
 
 // GetModelStatusConfigMaps returns all ConfigMaps with model status data
 // These ConfigMaps are created by model-agent daemonsets and contain download progress
+// They are read with the service account of the console, callers authorize access to the model first
 func (c *Client) GetModelStatusConfigMaps(ctx context.Context) (*corev1.ConfigMapList, error) {
 	return c.Clientset.CoreV1().ConfigMaps(OMENamespace).List(ctx, metav1.ListOptions{
 		LabelSelector: ModelStatusConfigMapLabel + "=true",
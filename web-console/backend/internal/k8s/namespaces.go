@@ -4,10 +4,12 @@ import (
 	"context"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
-// ListNamespaces returns all namespaces in the cluster from cache
+// ListNamespaces returns the namespaces of the console from cache
+// Users who may not list namespaces get the namespaces where they may list InferenceServices
 func (c *Client) ListNamespaces(ctx context.Context) (*corev1.NamespaceList, error) {
 	// Use lister instead of direct API call
 	lister := c.InformerFactory.Core().V1().Namespaces().Lister()
@@ -16,20 +18,41 @@ func (c *Client) ListNamespaces(ctx context.Context) (*corev1.NamespaceList, err
 		return nil, err
 	}
 
+	err = c.Authorize(ctx, "list", NamespaceGVR, "", "")
+	if err != nil && !apierrors.IsForbidden(err) {
+		return nil, err
+	}
+	listAll := err == nil
+
 	// Convert to NamespaceList
-	return &corev1.NamespaceList{
-		Items: func() []corev1.Namespace {
-			items := make([]corev1.Namespace, len(namespaces))
-			for i, ns := range namespaces {
-				items[i] = *ns
+	items := make([]corev1.Namespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if !c.NamespaceAllowed(ns.Name) {
+			continue
+		}
+		if !listAll {
+			err := c.Authorize(ctx, "list", InferenceServiceGVR, ns.Name, "")
+			if apierrors.IsForbidden(err) {
+				continue
 			}
-			return items
-		}(),
-	}, nil
+			if err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, *ns)
+	}
+	return &corev1.NamespaceList{Items: items}, nil
 }
 
 // GetNamespace returns a specific namespace by name from cache
 func (c *Client) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	if err := c.Authorize(ctx, "get", NamespaceGVR, "", name); err != nil {
+		return nil, err
+	}
+	if err := c.checkNamespace(NamespaceGVR, name, name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.InformerFactory.Core().V1().Namespaces().Lister()
 	return lister.Get(name)
@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"context"
+	"io"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// Pod GVR
+	PodGVR = schema.GroupVersionResource{
+		Version:  "v1",
+		Resource: "pods",
+	}
+)
+
+// ListInferenceServicePods returns the pods of an InferenceService, only the ones of the component when it is set.
+// Pods are not cached by the console, they are listed as the user of the request
+func (c *Client) ListInferenceServicePods(ctx context.Context, namespace, name, component string) (*corev1.PodList, error) {
+	if err := c.checkNamespace(PodGVR, namespace, ""); err != nil {
+		return nil, err
+	}
+	clientset, err := c.clientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := labels.Set{constants.InferenceServicePodLabelKey: name}
+	if component != "" {
+		selector[constants.OMEComponentLabel] = component
+	}
+	return clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.AsSelector().String(),
+	})
+}
+
+// StreamPodLogs streams the logs of a container of a pod as the user of the request. The caller closes the stream
+func (c *Client) StreamPodLogs(ctx context.Context, namespace, name string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+	if err := c.checkNamespace(PodGVR, namespace, name); err != nil {
+		return nil, err
+	}
+	clientset, err := c.clientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.CoreV1().Pods(namespace).GetLogs(name, opts).Stream(ctx)
+}
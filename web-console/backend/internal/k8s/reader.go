@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// readerScheme holds the typed OME objects the cache reader serves
+var readerScheme = runtime.NewScheme()
+
+func init() {
+	if err := v1beta1.AddToScheme(readerScheme); err != nil {
+		panic(err)
+	}
+}
+
+// readerResources maps the kinds the cache reader serves to their resource, and whether they are namespaced
+var readerResources = map[string]struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}{
+	"ClusterServingRuntime": {ClusterServingRuntimeGVR, false},
+	"ServingRuntime":        {ServingRuntimeGVR, true},
+	"ClusterBaseModel":      {ClusterBaseModelGVR, false},
+	"BaseModel":             {BaseModelGVR, true},
+	"AcceleratorClass":      {AcceleratorClassGVR, false},
+}
+
+// cacheReader serves typed OME objects from the informer cache, authorized for the user of the request in the
+// context of each call. It lets the controller's selectors run in the console; they only get and list objects, so
+// the embedded client is left nil and writing panics
+type cacheReader struct {
+	client.Client
+	c *Client
+}
+
+// Reader returns a controller-runtime client reading runtimes, models and accelerator classes from the informer
+// cache, as the user of the request may read them
+func (c *Client) Reader() client.Client {
+	return &cacheReader{c: c}
+}
+
+// Scheme returns the scheme of the typed objects the reader serves
+func (r *cacheReader) Scheme() *runtime.Scheme {
+	return readerScheme
+}
+
+// Get reads an object from the cache
+func (r *cacheReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, readerScheme)
+	if err != nil {
+		return err
+	}
+	resource, ok := readerResources[gvk.Kind]
+	if !ok {
+		return fmt.Errorf("reading %s is not supported", gvk.Kind)
+	}
+
+	namespace := ""
+	if resource.namespaced {
+		namespace = key.Namespace
+	}
+	if err := r.c.Authorize(ctx, "get", resource.gvr, namespace, key.Name); err != nil {
+		return err
+	}
+
+	lister := r.c.DynamicInformerFactory.ForResource(resource.gvr).Lister()
+	var cached runtime.Object
+	if resource.namespaced {
+		cached, err = lister.ByNamespace(namespace).Get(key.Name)
+	} else {
+		cached, err = lister.Get(key.Name)
+	}
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(cached.(*unstructured.Unstructured).Object, obj)
+}
+
+// List reads the objects of a list from the cache, across namespaces only the ones of the namespaces the user may list
+func (r *cacheReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, readerScheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	resource, ok := readerResources[gvk.Kind]
+	if !ok {
+		return fmt.Errorf("listing %s is not supported", gvk.Kind)
+	}
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	selector := labels.Everything()
+	if listOpts.LabelSelector != nil {
+		selector = listOpts.LabelSelector
+	}
+
+	namespace := ""
+	if resource.namespaced {
+		namespace = listOpts.Namespace
+	}
+	if namespace != "" || !resource.namespaced {
+		if err := r.c.Authorize(ctx, "list", resource.gvr, namespace, ""); err != nil {
+			return err
+		}
+	}
+
+	lister := r.c.DynamicInformerFactory.ForResource(resource.gvr).Lister()
+	var objs []runtime.Object
+	if namespace != "" {
+		objs, err = lister.ByNamespace(namespace).List(selector)
+	} else {
+		objs, err = lister.List(selector)
+	}
+	if err != nil {
+		return err
+	}
+
+	var items []unstructured.Unstructured
+	if resource.namespaced && namespace == "" {
+		if items, err = r.c.listAuthorized(ctx, resource.gvr, objs); err != nil {
+			return err
+		}
+	} else {
+		for _, obj := range objs {
+			items = append(items, *obj.(*unstructured.Unstructured))
+		}
+	}
+
+	typed := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		obj, err := readerScheme.New(gvk)
+		if err != nil {
+			return err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, obj); err != nil {
+			return err
+		}
+		typed = append(typed, obj)
+	}
+	return meta.SetList(list, typed)
+}
@@ -27,6 +27,10 @@ var (
 
 // ListClusterServingRuntimes returns all ClusterServingRuntimes in the cluster from cache
 func (c *Client) ListClusterServingRuntimes(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", ClusterServingRuntimeGVR, "", ""); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ClusterServingRuntimeGVR).Lister()
 	objs, err := lister.List(labels.Everything())
@@ -47,6 +51,10 @@ func (c *Client) ListClusterServingRuntimes(ctx context.Context) (*unstructured.
 
 // GetClusterServingRuntime returns a specific ClusterServingRuntime by name from cache
 func (c *Client) GetClusterServingRuntime(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", ClusterServingRuntimeGVR, "", name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ClusterServingRuntimeGVR).Lister()
 	obj, err := lister.Get(name)
@@ -58,21 +66,37 @@ func (c *Client) GetClusterServingRuntime(ctx context.Context, name string) (*un
 
 // CreateClusterServingRuntime creates a new ClusterServingRuntime
 func (c *Client) CreateClusterServingRuntime(ctx context.Context, runtime *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ClusterServingRuntimeGVR).Create(ctx, runtime, metav1.CreateOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ClusterServingRuntimeGVR).Create(ctx, runtime, metav1.CreateOptions{})
 }
 
 // UpdateClusterServingRuntime updates an existing ClusterServingRuntime
 func (c *Client) UpdateClusterServingRuntime(ctx context.Context, runtime *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ClusterServingRuntimeGVR).Update(ctx, runtime, metav1.UpdateOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ClusterServingRuntimeGVR).Update(ctx, runtime, metav1.UpdateOptions{})
 }
 
 // DeleteClusterServingRuntime deletes a ClusterServingRuntime by name
 func (c *Client) DeleteClusterServingRuntime(ctx context.Context, name string) error {
-	return c.DynamicClient.Resource(ClusterServingRuntimeGVR).Delete(ctx, name, metav1.DeleteOptions{})
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(ClusterServingRuntimeGVR).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 // ListServingRuntimes returns all ServingRuntimes in a namespace from cache
 func (c *Client) ListServingRuntimes(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
+	if err := c.Authorize(ctx, "list", ServingRuntimeGVR, namespace, ""); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ServingRuntimeGVR).Lister().ByNamespace(namespace)
 	objs, err := lister.List(labels.Everything())
@@ -93,6 +117,10 @@ func (c *Client) ListServingRuntimes(ctx context.Context, namespace string) (*un
 
 // GetServingRuntime returns a specific ServingRuntime by name and namespace from cache
 func (c *Client) GetServingRuntime(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", ServingRuntimeGVR, namespace, name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(ServingRuntimeGVR).Lister().ByNamespace(namespace)
 	obj, err := lister.Get(name)
@@ -104,15 +132,36 @@ func (c *Client) GetServingRuntime(ctx context.Context, namespace, name string)
 
 // CreateServingRuntime creates a new ServingRuntime in a namespace
 func (c *Client) CreateServingRuntime(ctx context.Context, namespace string, runtime *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ServingRuntimeGVR).Namespace(namespace).Create(ctx, runtime, metav1.CreateOptions{})
+	if err := c.checkNamespace(ServingRuntimeGVR, namespace, runtime.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ServingRuntimeGVR).Namespace(namespace).Create(ctx, runtime, metav1.CreateOptions{})
 }
 
 // UpdateServingRuntime updates an existing ServingRuntime in a namespace
 func (c *Client) UpdateServingRuntime(ctx context.Context, namespace string, runtime *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(ServingRuntimeGVR).Namespace(namespace).Update(ctx, runtime, metav1.UpdateOptions{})
+	if err := c.checkNamespace(ServingRuntimeGVR, namespace, runtime.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(ServingRuntimeGVR).Namespace(namespace).Update(ctx, runtime, metav1.UpdateOptions{})
 }
 
 // DeleteServingRuntime deletes a ServingRuntime by name and namespace
 func (c *Client) DeleteServingRuntime(ctx context.Context, namespace, name string) error {
-	return c.DynamicClient.Resource(ServingRuntimeGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err := c.checkNamespace(ServingRuntimeGVR, namespace, name); err != nil {
+		return err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(ServingRuntimeGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
@@ -5,10 +5,12 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// CreateHuggingFaceTokenSecret creates a Kubernetes secret containing a HuggingFace token
-func (c *Client) CreateHuggingFaceTokenSecret(ctx context.Context, secretName, namespace, token string) error {
+// CreateHuggingFaceTokenSecret creates a Kubernetes secret containing a HuggingFace token. When owner is set, the
+// secret is garbage collected with it
+func (c *Client) CreateHuggingFaceTokenSecret(ctx context.Context, secretName, namespace, token string, owner *unstructured.Unstructured) error {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -24,6 +26,19 @@ func (c *Client) CreateHuggingFaceTokenSecret(ctx context.Context, secretName, n
 		},
 	}
 
-	_, err := c.Clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if owner != nil {
+		secret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: owner.GetAPIVersion(),
+			Kind:       owner.GetKind(),
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+		}}
+	}
+
+	clientset, err := c.clientset(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
 	return err
 }
@@ -20,8 +20,14 @@ var (
 )
 
 // ListInferenceServices returns all InferenceServices in the specified namespace from cache
-// If namespace is empty, lists across all namespaces
+// If namespace is empty, lists across all the namespaces the user of the request may list
 func (c *Client) ListInferenceServices(ctx context.Context, namespace string) (*unstructured.UnstructuredList, error) {
+	if namespace != "" {
+		if err := c.Authorize(ctx, "list", InferenceServiceGVR, namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	// Use lister instead of direct API call
 	var objs []runtime.Object
 	var err error
@@ -40,10 +46,10 @@ func (c *Client) ListInferenceServices(ctx context.Context, namespace string) (*
 		return nil, err
 	}
 
-	// Convert to UnstructuredList
-	items := make([]unstructured.Unstructured, len(objs))
-	for i, obj := range objs {
-		items[i] = *obj.(*unstructured.Unstructured)
+	// Keep the items of the namespaces the user may list
+	items, err := c.listAuthorized(ctx, InferenceServiceGVR, objs)
+	if err != nil {
+		return nil, err
 	}
 
 	return &unstructured.UnstructuredList{
@@ -53,6 +59,10 @@ func (c *Client) ListInferenceServices(ctx context.Context, namespace string) (*
 
 // GetInferenceService returns a specific InferenceService by name and namespace from cache
 func (c *Client) GetInferenceService(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := c.Authorize(ctx, "get", InferenceServiceGVR, namespace, name); err != nil {
+		return nil, err
+	}
+
 	// Use lister instead of direct API call
 	lister := c.DynamicInformerFactory.ForResource(InferenceServiceGVR).Lister().ByNamespace(namespace)
 	obj, err := lister.Get(name)
@@ -64,15 +74,36 @@ func (c *Client) GetInferenceService(ctx context.Context, namespace, name string
 
 // CreateInferenceService creates a new InferenceService
 func (c *Client) CreateInferenceService(ctx context.Context, namespace string, service *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(InferenceServiceGVR).Namespace(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err := c.checkNamespace(InferenceServiceGVR, namespace, service.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(InferenceServiceGVR).Namespace(namespace).Create(ctx, service, metav1.CreateOptions{})
 }
 
 // UpdateInferenceService updates an existing InferenceService
 func (c *Client) UpdateInferenceService(ctx context.Context, namespace string, service *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	return c.DynamicClient.Resource(InferenceServiceGVR).Namespace(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	if err := c.checkNamespace(InferenceServiceGVR, namespace, service.GetName()); err != nil {
+		return nil, err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Resource(InferenceServiceGVR).Namespace(namespace).Update(ctx, service, metav1.UpdateOptions{})
 }
 
 // DeleteInferenceService deletes an InferenceService by name and namespace
 func (c *Client) DeleteInferenceService(ctx context.Context, namespace, name string) error {
-	return c.DynamicClient.Resource(InferenceServiceGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err := c.checkNamespace(InferenceServiceGVR, namespace, name); err != nil {
+		return err
+	}
+	client, err := c.dynamicClient(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Resource(InferenceServiceGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
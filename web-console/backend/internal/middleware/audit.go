@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+	"go.uber.org/zap"
+)
+
+// Audit returns a gin middleware recording every mutation with the user who requested it and its outcome
+func Audit(logger *zap.Logger) gin.HandlerFunc {
+	auditLogger := logger.Named("audit")
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		user := "unauthenticated"
+		var groups []string
+		if u := auth.UserFrom(c.Request.Context()); u != nil {
+			user = u.Name
+			groups = u.Groups
+		}
+		namespace := c.Param("namespace")
+		if namespace == "" {
+			namespace = c.Query("namespace")
+		}
+
+		auditLogger.Info("Mutation",
+			zap.String("user", user),
+			zap.Strings("groups", groups),
+			zap.String("method", c.Request.Method),
+			zap.String("route", c.FullPath()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("namespace", namespace),
+			zap.String("name", c.Param("name")),
+			zap.Int("status", c.Writer.Status()),
+			zap.Bool("succeeded", c.Writer.Status() < http.StatusBadRequest),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+		)
+	}
+}
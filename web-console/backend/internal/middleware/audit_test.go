@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+)
+
+func TestAudit(t *testing.T) {
+	alice := &auth.User{Name: "alice", Groups: []string{"ml-team"}}
+
+	tests := []struct {
+		name              string
+		method            string
+		path              string
+		user              *auth.User
+		status            int
+		expectedRecorded  bool
+		expectedUser      string
+		expectedNamespace string
+		expectedName      string
+		expectedSucceeded bool
+	}{
+		{
+			name:   "reads are not recorded",
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/default/services/llama",
+			user:   alice,
+			status: http.StatusOK,
+		},
+		{
+			name:              "successful mutation",
+			method:            http.MethodDelete,
+			path:              "/api/v1/namespaces/default/services/llama",
+			user:              alice,
+			status:            http.StatusNoContent,
+			expectedRecorded:  true,
+			expectedUser:      "alice",
+			expectedNamespace: "default",
+			expectedName:      "llama",
+			expectedSucceeded: true,
+		},
+		{
+			name:              "forbidden mutation",
+			method:            http.MethodPut,
+			path:              "/api/v1/namespaces/team-a/services/llama",
+			user:              alice,
+			status:            http.StatusForbidden,
+			expectedRecorded:  true,
+			expectedUser:      "alice",
+			expectedNamespace: "team-a",
+			expectedName:      "llama",
+		},
+		{
+			name:              "mutation without authentication",
+			method:            http.MethodPost,
+			path:              "/api/v1/services?namespace=default",
+			status:            http.StatusCreated,
+			expectedRecorded:  true,
+			expectedUser:      "unauthenticated",
+			expectedNamespace: "default",
+			expectedSucceeded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.user != nil {
+					c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), tt.user))
+				}
+				c.Next()
+			})
+			router.Use(Audit(zap.New(core)))
+			handler := func(c *gin.Context) { c.Status(tt.status) }
+			router.GET("/api/v1/namespaces/:namespace/services/:name", handler)
+			router.PUT("/api/v1/namespaces/:namespace/services/:name", handler)
+			router.DELETE("/api/v1/namespaces/:namespace/services/:name", handler)
+			router.POST("/api/v1/services", handler)
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			entries := logs.FilterMessage("Mutation").All()
+			if !tt.expectedRecorded {
+				assert.Empty(t, entries)
+				return
+			}
+			require.Len(t, entries, 1)
+			assert.Equal(t, "audit", entries[0].LoggerName)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, tt.expectedUser, fields["user"])
+			assert.Equal(t, tt.method, fields["method"])
+			assert.Equal(t, tt.expectedNamespace, fields["namespace"])
+			assert.Equal(t, tt.expectedName, fields["name"])
+			assert.Equal(t, int64(tt.status), fields["status"])
+			assert.Equal(t, tt.expectedSucceeded, fields["succeeded"])
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+	"go.uber.org/zap"
+)
+
+// UserKey is the gin context key of the authenticated user
+const UserKey = "user"
+
+// Authenticate returns a gin middleware verifying the bearer token of each request and adding the authenticated user
+// to the request context, so the Kubernetes client impersonates it. The token is read from the Authorization header,
+// or from the access_token query parameter for EventSource connections which cannot set headers. Without an
+// authenticator, requests pass through and act with the service account of the console.
+func Authenticate(authenticator auth.Authenticator, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticator == nil {
+			c.Next()
+			return
+		}
+
+		token := bearerToken(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Missing bearer token",
+			})
+			return
+		}
+
+		user, err := authenticator.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			status := http.StatusUnauthorized
+			if !errors.Is(err, auth.ErrUnauthenticated) {
+				status = http.StatusServiceUnavailable
+			}
+			logger.Warn("Authentication failed",
+				zap.String("path", c.Request.URL.Path),
+				zap.String("client_ip", c.ClientIP()),
+				zap.Error(err))
+			c.AbortWithStatusJSON(status, gin.H{
+				"error": "Authentication failed",
+			})
+			return
+		}
+
+		c.Set(UserKey, user)
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+func bearerToken(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); header != "" {
+		if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return c.Query("access_token")
+}
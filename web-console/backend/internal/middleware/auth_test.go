@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/auth"
+)
+
+// fakeAuthenticator accepts the tokens of its users, and fails every token with err when set
+type fakeAuthenticator struct {
+	users map[string]*auth.User
+	err   error
+}
+
+func (f *fakeAuthenticator) Authenticate(_ context.Context, token string) (*auth.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	user, ok := f.users[token]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown token", auth.ErrUnauthenticated)
+	}
+	return user, nil
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestAuthenticate(t *testing.T) {
+	alice := &auth.User{Name: "alice", Groups: []string{"ml-team"}}
+	authenticator := &fakeAuthenticator{users: map[string]*auth.User{"valid-token": alice}}
+
+	tests := []struct {
+		name           string
+		authenticator  auth.Authenticator
+		header         string
+		query          string
+		expectedStatus int
+		expectedUser   string
+	}{
+		{
+			name:           "authentication disabled",
+			authenticator:  nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing token",
+			authenticator:  authenticator,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "malformed authorization header",
+			authenticator:  authenticator,
+			header:         "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "bearer scheme without token",
+			authenticator:  authenticator,
+			header:         "Bearer ",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			authenticator:  authenticator,
+			header:         "Bearer forged-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "valid token",
+			authenticator:  authenticator,
+			header:         "Bearer valid-token",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "alice",
+		},
+		{
+			name:           "valid token in the query of an EventSource",
+			authenticator:  authenticator,
+			query:          "access_token=valid-token",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "alice",
+		},
+		{
+			name:           "authorization header takes precedence over the query",
+			authenticator:  authenticator,
+			header:         "Basic dXNlcjpwYXNz",
+			query:          "access_token=valid-token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "authenticator unavailable",
+			authenticator:  &fakeAuthenticator{err: errors.New("failed to review token: connection refused")},
+			header:         "Bearer valid-token",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Authenticate(tt.authenticator, zap.NewNop()))
+			var gotUser *auth.User
+			router.GET("/api/v1/models", func(c *gin.Context) {
+				gotUser = auth.UserFrom(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/models?"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUser == "" {
+				assert.Nil(t, gotUser)
+				return
+			}
+			if assert.NotNil(t, gotUser) {
+				assert.Equal(t, tt.expectedUser, gotUser.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		expected string
+	}{
+		{
+			name:     "empty query",
+			rawQuery: "",
+			expected: "",
+		},
+		{
+			name:     "query without token",
+			rawQuery: "namespace=default&watch=true",
+			expected: "namespace=default&watch=true",
+		},
+		{
+			name:     "access token",
+			rawQuery: "access_token=secret-token&namespace=default",
+			expected: "access_token=REDACTED&namespace=default",
+		},
+		{
+			name:     "repeated access token",
+			rawQuery: "access_token=first&access_token=second",
+			expected: "access_token=REDACTED",
+		},
+		{
+			name:     "unparsable query is dropped",
+			rawQuery: "access_token=secret%zz",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactQuery(tt.rawQuery))
+		})
+	}
+}
+
+func TestLoggerRedactsAccessToken(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	router := gin.New()
+	router.Use(Logger(zap.New(core)))
+	router.GET("/api/v1/events", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?access_token=secret-token", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.FilterMessage("HTTP Request").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "/api/v1/events", fields["path"])
+	assert.Equal(t, "access_token=REDACTED", fields["query"])
+	assert.Equal(t, int64(http.StatusOK), fields["status"])
+	assert.NotContains(t, fields["query"], "secret-token")
+}
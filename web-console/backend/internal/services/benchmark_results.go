@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/logging"
+	"github.com/sgl-project/ome/pkg/storage"
+	// Register the storage providers the results can be read from
+	_ "github.com/sgl-project/ome/pkg/storage/providers/oci"
+	_ "github.com/sgl-project/ome/pkg/storage/providers/s3"
+	utilstorage "github.com/sgl-project/ome/pkg/utils/storage"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ErrLocationNotAllowed is returned when the output location of a BenchmarkJob is outside of the locations the console
+// reads results from
+var ErrLocationNotAllowed = errors.New("output location is not allowed by BENCHMARK_RESULTS_LOCATIONS")
+
+// BenchmarkResultsService reads the results BenchmarkJobs upload to their output location. The results are read with
+// the credentials of the console, so only output locations below BENCHMARK_RESULTS_LOCATIONS are read
+type BenchmarkResultsService struct {
+	k8sClient *k8s.Client
+	factory   storage.Factory
+	locations []*storageLocation
+	logger    *zap.Logger
+}
+
+// NewBenchmarkResultsService creates a new benchmark results service reading from the locations of
+// BENCHMARK_RESULTS_LOCATIONS
+func NewBenchmarkResultsService(k8sClient *k8s.Client, logger *zap.Logger) *BenchmarkResultsService {
+	storage.InitGlobalFactory(logging.ForZap(logger))
+	locations, err := parseAllowedLocations(os.Getenv("BENCHMARK_RESULTS_LOCATIONS"))
+	if err != nil {
+		logger.Warn("Failed to parse the benchmark results locations, results are unavailable", zap.Error(err))
+	}
+	return &BenchmarkResultsService{
+		k8sClient: k8sClient,
+		factory:   storage.GetGlobalFactory(),
+		locations: locations,
+		logger:    logger,
+	}
+}
+
+// allowed tells whether results may be read from the location
+func (s *BenchmarkResultsService) allowed(location *storageLocation) bool {
+	for _, allowed := range s.locations {
+		if allowed.contains(location) {
+			return true
+		}
+	}
+	return false
+}
+
+// BenchmarkResults lists the result files of a BenchmarkJob
+type BenchmarkResults struct {
+	StorageURI string       `json:"storageUri"`
+	Files      []ResultFile `json:"files"`
+}
+
+// ResultFile is a file uploaded by a BenchmarkJob, named relative to its output location
+type ResultFile struct {
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified,omitempty"`
+}
+
+// resultLocation is the storage and object prefix a BenchmarkJob uploads its results to
+type resultLocation struct {
+	config  storage.Config
+	uri     string
+	storage *storageLocation
+	// object returns the URI of an object key understood by the storage provider
+	object func(key string) string
+}
+
+// key returns the object key of a file named relative to the prefix
+func (l *resultLocation) key(name string) string {
+	if l.storage.prefix == "" {
+		return name
+	}
+	return l.storage.prefix + "/" + name
+}
+
+// ListResults lists the result files of a BenchmarkJob
+func (s *BenchmarkResultsService) ListResults(ctx context.Context, namespace, name string) (*BenchmarkResults, error) {
+	location, store, err := s.open(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := store.List(ctx, location.object(location.key("")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list results in %s: %w", location.uri, err)
+	}
+
+	results := &BenchmarkResults{StorageURI: location.uri, Files: []ResultFile{}}
+	for _, object := range objects {
+		if object.IsDir {
+			continue
+		}
+		results.Files = append(results.Files, ResultFile{
+			Name:         strings.TrimPrefix(object.Name, location.key("")),
+			Size:         object.Size,
+			LastModified: object.LastModified,
+		})
+	}
+	sort.Slice(results.Files, func(i, j int) bool {
+		return results.Files[i].Name < results.Files[j].Name
+	})
+	return results, nil
+}
+
+// GetResult opens a result file of a BenchmarkJob, the file is named relative to the output location
+func (s *BenchmarkResultsService) GetResult(ctx context.Context, namespace, name, file string) (io.ReadCloser, error) {
+	cleaned := path.Clean("/" + file)
+	if file == "" || cleaned == "/" || cleaned[1:] != strings.TrimPrefix(file, "/") {
+		return nil, fmt.Errorf("result file %q: %w", file, storage.ErrInvalidPath)
+	}
+
+	location, store, err := s.open(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := store.Get(ctx, location.object(location.key(cleaned[1:])))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result %s in %s: %w", file, location.uri, err)
+	}
+	return reader, nil
+}
+
+// open authorizes the user to get the BenchmarkJob and creates the storage of its output location
+func (s *BenchmarkResultsService) open(ctx context.Context, namespace, name string) (*resultLocation, storage.Storage, error) {
+	job, err := s.k8sClient.GetBenchmarkJob(ctx, namespace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location, err := outputLocation(job)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !s.allowed(location.storage) {
+		return nil, nil, fmt.Errorf("results in %s: %w", location.uri, ErrLocationNotAllowed)
+	}
+
+	store, err := s.factory.CreateStorage(ctx, location.config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to access results in %s: %w", location.uri, err)
+	}
+	return location, store, nil
+}
+
+// outputLocation resolves where the BenchmarkJob uploads its results, the storage parameters are the ones genai-bench
+// is started with. Only the authentication type is taken from the parameters, the credentials are the console's own
+func outputLocation(job *unstructured.Unstructured) (*resultLocation, error) {
+	benchmarkJob := &v1beta1.BenchmarkJob{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(job.Object, benchmarkJob); err != nil {
+		return nil, fmt.Errorf("failed to convert BenchmarkJob %s: %w", job.GetName(), err)
+	}
+
+	spec := benchmarkJob.Spec.OutputLocation
+	if spec == nil || spec.StorageUri == nil {
+		return nil, fmt.Errorf("BenchmarkJob %s has no output location: %w", job.GetName(), storage.ErrInvalidConfig)
+	}
+	uri := *spec.StorageUri
+	params := map[string]string{}
+	if spec.Parameters != nil {
+		params = *spec.Parameters
+	}
+
+	parsed, err := parseStorageLocation(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	location := &resultLocation{uri: uri, storage: parsed}
+	switch parsed.provider {
+	case storage.ProviderOCI:
+		location.config = storage.Config{
+			Provider:   storage.ProviderOCI,
+			Namespace:  parsed.namespace,
+			Bucket:     parsed.bucket,
+			Region:     params["region"],
+			AuthConfig: ociAuthConfig(params),
+		}
+		location.object = func(key string) string {
+			return fmt.Sprintf("oci://%s/%s/%s", parsed.namespace, parsed.bucket, key)
+		}
+	case storage.ProviderS3:
+		region := parsed.region
+		if params["aws_region"] != "" {
+			region = params["aws_region"]
+		}
+		location.config = storage.Config{
+			Provider:   storage.ProviderS3,
+			Bucket:     parsed.bucket,
+			Region:     region,
+			AuthConfig: s3AuthConfig(region),
+		}
+		location.object = func(key string) string {
+			return key
+		}
+	}
+
+	// genai-bench writes into the result folder below the prefix when one is set
+	if benchmarkJob.Spec.ResultFolderName != nil && *benchmarkJob.Spec.ResultFolderName != "" {
+		parsed.prefix = strings.Trim(storage.JoinPath(parsed.prefix, *benchmarkJob.Spec.ResultFolderName), "/")
+		location.uri = strings.TrimSuffix(uri, "/") + "/" + *benchmarkJob.Spec.ResultFolderName
+	}
+	return location, nil
+}
+
+// storageLocation is a bucket and object prefix of OCI Object Storage or S3
+type storageLocation struct {
+	provider  storage.Provider
+	namespace string
+	bucket    string
+	prefix    string
+	region    string
+}
+
+// parseStorageLocation parses an OCI or S3 storage URI
+func parseStorageLocation(uri string) (*storageLocation, error) {
+	storageType, err := storage.GetStorageTypeFromURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, storage.ErrInvalidConfig)
+	}
+
+	switch storageType {
+	case storage.TypeOCI:
+		components, err := utilstorage.ParseOCIStorageURI(uri)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, storage.ErrInvalidConfig)
+		}
+		return &storageLocation{
+			provider:  storage.ProviderOCI,
+			namespace: components.Namespace,
+			bucket:    components.Bucket,
+			prefix:    strings.Trim(components.Prefix, "/"),
+		}, nil
+	case storage.TypeS3:
+		components, err := utilstorage.ParseS3StorageURI(uri)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, storage.ErrInvalidConfig)
+		}
+		return &storageLocation{
+			provider: storage.ProviderS3,
+			bucket:   components.Bucket,
+			prefix:   strings.Trim(components.Prefix, "/"),
+			region:   components.Region,
+		}, nil
+	default:
+		return nil, fmt.Errorf("reading results from %s storage: %w", storageType, storage.ErrNotSupported)
+	}
+}
+
+// contains tells whether the other location is the same bucket and at or below the prefix of this one
+func (l *storageLocation) contains(other *storageLocation) bool {
+	if l.provider != other.provider || l.namespace != other.namespace || l.bucket != other.bucket {
+		return false
+	}
+	return l.prefix == "" || other.prefix == l.prefix || strings.HasPrefix(other.prefix, l.prefix+"/")
+}
+
+// parseAllowedLocations parses the comma-separated storage URIs results may be read from
+func parseAllowedLocations(value string) ([]*storageLocation, error) {
+	var locations []*storageLocation
+	for _, uri := range strings.Split(value, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		location, err := parseStorageLocation(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid results location %q: %w", uri, err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// ociAuthConfig maps the auth parameter of genai-bench to the OCI authentication of the storage provider. A user
+// principal is read from the default config file and profile of the console, never from a path of the job
+func ociAuthConfig(params map[string]string) *storage.AuthConfig {
+	authConfig := &storage.AuthConfig{Provider: "oci", Type: "OCIInstancePrincipal", Region: params["region"]}
+	switch params["auth"] {
+	case "user_principal", "security_token":
+		authConfig.Type = "OCIUserPrincipal"
+		authConfig.Extra = map[string]interface{}{
+			"user_principal": map[string]interface{}{
+				"use_session_token": params["auth"] == "security_token",
+			},
+		}
+	case "resource_principal":
+		authConfig.Type = "OCIResourcePrincipal"
+	case "oke_workload_identity":
+		authConfig.Type = "OCIOkeWorkloadIdentity"
+	}
+	return authConfig
+}
+
+// s3AuthConfig uses the default AWS credential chain of the console
+func s3AuthConfig(region string) *storage.AuthConfig {
+	return &storage.AuthConfig{Provider: "aws", Type: "default", Region: region}
+}
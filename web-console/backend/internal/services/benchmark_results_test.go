@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sgl-project/ome/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+)
+
+// fakeStorage serves the objects of a bucket, keyed by the URI the storage provider is given
+type fakeStorage struct {
+	storage.Storage
+	objects map[string]string
+	gets    []string
+}
+
+func (s *fakeStorage) Get(_ context.Context, uri string) (io.ReadCloser, error) {
+	s.gets = append(s.gets, uri)
+	content, ok := s.objects[uri]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func (s *fakeStorage) List(_ context.Context, uri string, _ ...storage.ListOption) ([]storage.ObjectInfo, error) {
+	var objects []storage.ObjectInfo
+	for object, content := range s.objects {
+		if strings.HasPrefix(object, uri) {
+			objects = append(objects, storage.ObjectInfo{Name: strings.TrimPrefix(object, "oci://ns/bucket/"), Size: int64(len(content))})
+		}
+	}
+	return objects, nil
+}
+
+// fakeFactory creates the fake storage and records the configs it was asked for
+type fakeFactory struct {
+	storage *fakeStorage
+	configs []storage.Config
+}
+
+func (f *fakeFactory) CreateStorage(_ context.Context, config storage.Config) (storage.Storage, error) {
+	f.configs = append(f.configs, config)
+	return f.storage, nil
+}
+
+func (f *fakeFactory) SupportedProviders() []storage.Provider {
+	return []storage.Provider{storage.ProviderOCI, storage.ProviderS3}
+}
+
+func benchmarkJob(uri string, params map[string]interface{}, resultFolder string) *unstructured.Unstructured {
+	outputLocation := map[string]interface{}{"storageUri": uri}
+	if params != nil {
+		outputLocation["parameters"] = params
+	}
+	spec := map[string]interface{}{"outputLocation": outputLocation}
+	if resultFolder != "" {
+		spec["resultFolderName"] = resultFolder
+	}
+	job := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	job.SetAPIVersion("ome.io/v1beta1")
+	job.SetKind("BenchmarkJob")
+	job.SetNamespace("default")
+	job.SetName("llama-bench")
+	return job
+}
+
+func TestOutputLocation(t *testing.T) {
+	tests := []struct {
+		name             string
+		job              *unstructured.Unstructured
+		expectedError    error
+		expectedURI      string
+		expectedLocation *storageLocation
+		expectedObject   string
+	}{
+		{
+			name:             "oci output location",
+			job:              benchmarkJob("oci://n/ns/b/bucket/o/benchmarks/", map[string]interface{}{"region": "us-ashburn-1"}, ""),
+			expectedURI:      "oci://n/ns/b/bucket/o/benchmarks/",
+			expectedLocation: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks"},
+			expectedObject:   "oci://ns/bucket/benchmarks/result.json",
+		},
+		{
+			name:             "oci output location with a result folder",
+			job:              benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", nil, "run-1"),
+			expectedURI:      "oci://n/ns/b/bucket/o/benchmarks/run-1",
+			expectedLocation: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks/run-1"},
+			expectedObject:   "oci://ns/bucket/benchmarks/run-1/result.json",
+		},
+		{
+			name:             "s3 output location with a region",
+			job:              benchmarkJob("s3://bucket@us-west-2/benchmarks", nil, ""),
+			expectedURI:      "s3://bucket@us-west-2/benchmarks",
+			expectedLocation: &storageLocation{provider: storage.ProviderS3, bucket: "bucket", prefix: "benchmarks", region: "us-west-2"},
+			expectedObject:   "benchmarks/result.json",
+		},
+		{
+			name:             "s3 output location at the root of the bucket",
+			job:              benchmarkJob("s3://bucket", nil, "run-1"),
+			expectedURI:      "s3://bucket/run-1",
+			expectedLocation: &storageLocation{provider: storage.ProviderS3, bucket: "bucket", prefix: "run-1"},
+			expectedObject:   "run-1/result.json",
+		},
+		{
+			name:          "pvc output location",
+			job:           benchmarkJob("pvc://results/benchmarks", nil, ""),
+			expectedError: storage.ErrNotSupported,
+		},
+		{
+			name:          "malformed oci output location",
+			job:           benchmarkJob("oci://bucket/benchmarks", nil, ""),
+			expectedError: storage.ErrInvalidConfig,
+		},
+		{
+			name:          "missing output location",
+			job:           &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}},
+			expectedError: storage.ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := outputLocation(tt.job)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedURI, location.uri)
+			assert.Equal(t, tt.expectedLocation, location.storage)
+			assert.Equal(t, tt.expectedObject, location.object(location.key("result.json")))
+		})
+	}
+}
+
+func TestAuthConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      *unstructured.Unstructured
+		expected *storage.AuthConfig
+	}{
+		{
+			name:     "oci instance principal by default",
+			job:      benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", map[string]interface{}{"region": "us-ashburn-1"}, ""),
+			expected: &storage.AuthConfig{Provider: "oci", Type: "OCIInstancePrincipal", Region: "us-ashburn-1"},
+		},
+		{
+			name: "oci user principal ignores the config file and profile of the job",
+			job: benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", map[string]interface{}{
+				"auth":        "security_token",
+				"config_file": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+				"profile":     "ADMIN",
+			}, ""),
+			expected: &storage.AuthConfig{Provider: "oci", Type: "OCIUserPrincipal", Extra: map[string]interface{}{
+				"user_principal": map[string]interface{}{"use_session_token": true},
+			}},
+		},
+		{
+			name:     "oci workload identity",
+			job:      benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", map[string]interface{}{"auth": "oke_workload_identity"}, ""),
+			expected: &storage.AuthConfig{Provider: "oci", Type: "OCIOkeWorkloadIdentity"},
+		},
+		{
+			name: "s3 ignores the access keys of the job",
+			job: benchmarkJob("s3://bucket@us-west-2/benchmarks", map[string]interface{}{
+				"aws_region":            "eu-west-1",
+				"aws_access_key_id":     "AKIA",
+				"aws_secret_access_key": "secret",
+			}, ""),
+			expected: &storage.AuthConfig{Provider: "aws", Type: "default", Region: "eu-west-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := outputLocation(tt.job)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, location.config.AuthConfig)
+		})
+	}
+}
+
+func TestParseAllowedLocations(t *testing.T) {
+	locations, err := parseAllowedLocations(" oci://n/ns/b/bucket/o/benchmarks/ ,s3://results,")
+	require.NoError(t, err)
+	assert.Equal(t, []*storageLocation{
+		{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks"},
+		{provider: storage.ProviderS3, bucket: "results"},
+	}, locations)
+
+	locations, err = parseAllowedLocations("")
+	require.NoError(t, err)
+	assert.Empty(t, locations)
+
+	_, err = parseAllowedLocations("oci://n/ns/b/bucket/o/benchmarks,pvc://results")
+	assert.Error(t, err)
+}
+
+func TestStorageLocationContains(t *testing.T) {
+	allowed := &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks"}
+
+	tests := []struct {
+		name     string
+		location *storageLocation
+		expected bool
+	}{
+		{
+			name:     "same prefix",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks"},
+			expected: true,
+		},
+		{
+			name:     "below the prefix",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks/run-1"},
+			expected: true,
+		},
+		{
+			name:     "prefix sharing the first characters",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket", prefix: "benchmarks-private"},
+		},
+		{
+			name:     "root of the bucket",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "bucket"},
+		},
+		{
+			name:     "other bucket",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "ns", bucket: "secrets", prefix: "benchmarks"},
+		},
+		{
+			name:     "other namespace",
+			location: &storageLocation{provider: storage.ProviderOCI, namespace: "other", bucket: "bucket", prefix: "benchmarks"},
+		},
+		{
+			name:     "other provider",
+			location: &storageLocation{provider: storage.ProviderS3, bucket: "bucket", prefix: "benchmarks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, allowed.contains(tt.location))
+		})
+	}
+
+	bucket := &storageLocation{provider: storage.ProviderS3, bucket: "results"}
+	assert.True(t, bucket.contains(&storageLocation{provider: storage.ProviderS3, bucket: "results", prefix: "any/prefix"}))
+}
+
+func newTestResultsService(t *testing.T, locations string, jobs ...*unstructured.Unstructured) (*BenchmarkResultsService, *fakeFactory) {
+	objects := make([]runtime.Object, 0, len(jobs))
+	for _, job := range jobs {
+		objects = append(objects, job)
+	}
+	k8sClient, err := k8s.NewFakeClient(zap.NewNop(), objects...)
+	require.NoError(t, err)
+	t.Cleanup(k8sClient.Stop)
+
+	allowed, err := parseAllowedLocations(locations)
+	require.NoError(t, err)
+	factory := &fakeFactory{storage: &fakeStorage{objects: map[string]string{
+		"oci://ns/bucket/benchmarks/run-1/result.json":     `{"ttft": 0.1}`,
+		"oci://ns/bucket/benchmarks/run-1/plots/ttft.png":  "png",
+		"oci://ns/bucket/benchmarks/run-1-secret/key.json": "secret",
+	}}}
+	return &BenchmarkResultsService{
+		k8sClient: k8sClient,
+		factory:   factory,
+		locations: allowed,
+		logger:    zap.NewNop(),
+	}, factory
+}
+
+func TestGetResult(t *testing.T) {
+	service, factory := newTestResultsService(t, "oci://n/ns/b/bucket/o/benchmarks",
+		benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", nil, "run-1"))
+
+	tests := []struct {
+		name          string
+		file          string
+		expectedError error
+		expectedBody  string
+	}{
+		{name: "result file", file: "result.json", expectedBody: `{"ttft": 0.1}`},
+		{name: "nested result file", file: "plots/ttft.png", expectedBody: "png"},
+		{name: "missing result file", file: "missing.json", expectedError: storage.ErrNotFound},
+		{name: "empty file", file: "", expectedError: storage.ErrInvalidPath},
+		{name: "root of the output location", file: "/", expectedError: storage.ErrInvalidPath},
+		{name: "parent directory", file: "../run-1-secret/key.json", expectedError: storage.ErrInvalidPath},
+		{name: "parent directory within the path", file: "plots/../../run-1-secret/key.json", expectedError: storage.ErrInvalidPath},
+		{name: "current directory within the path", file: "plots/./ttft.png", expectedError: storage.ErrInvalidPath},
+		{name: "duplicate separators", file: "plots//ttft.png", expectedError: storage.ErrInvalidPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory.storage.gets = nil
+			reader, err := service.GetResult(context.Background(), "default", "llama-bench", tt.file)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				if tt.expectedError == storage.ErrInvalidPath {
+					assert.Empty(t, factory.storage.gets, "invalid paths are never read")
+				}
+				return
+			}
+			require.NoError(t, err)
+			defer reader.Close()
+			body, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, string(body))
+		})
+	}
+}
+
+func TestResultsLocationNotAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		locations string
+		job       *unstructured.Unstructured
+	}{
+		{
+			name: "no allowed locations",
+			job:  benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", nil, "run-1"),
+		},
+		{
+			name:      "other bucket",
+			locations: "oci://n/ns/b/bucket/o/benchmarks",
+			job:       benchmarkJob("oci://n/ns/b/secrets/o/benchmarks", nil, "run-1"),
+		},
+		{
+			name:      "result folder outside of the allowed prefix",
+			locations: "oci://n/ns/b/bucket/o/benchmarks/run-1",
+			job:       benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", nil, "run-1-secret"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, factory := newTestResultsService(t, tt.locations, tt.job)
+
+			_, err := service.ListResults(context.Background(), "default", "llama-bench")
+			assert.ErrorIs(t, err, ErrLocationNotAllowed)
+			_, err = service.GetResult(context.Background(), "default", "llama-bench", "key.json")
+			assert.ErrorIs(t, err, ErrLocationNotAllowed)
+			assert.Empty(t, factory.configs, "no storage is created for a location that is not allowed")
+		})
+	}
+}
+
+func TestListResults(t *testing.T) {
+	service, factory := newTestResultsService(t, "oci://n/ns/b/bucket/o/benchmarks",
+		benchmarkJob("oci://n/ns/b/bucket/o/benchmarks", map[string]interface{}{"region": "us-ashburn-1"}, "run-1"))
+
+	results, err := service.ListResults(context.Background(), "default", "llama-bench")
+	require.NoError(t, err)
+	assert.Equal(t, "oci://n/ns/b/bucket/o/benchmarks/run-1", results.StorageURI)
+	assert.Equal(t, []ResultFile{
+		{Name: "plots/ttft.png", Size: 3},
+		{Name: "result.json", Size: 13},
+	}, results.Files)
+
+	require.Len(t, factory.configs, 1)
+	assert.Equal(t, storage.ProviderOCI, factory.configs[0].Provider)
+	assert.Equal(t, "ns", factory.configs[0].Namespace)
+	assert.Equal(t, "bucket", factory.configs[0].Bucket)
+	assert.Equal(t, "us-ashburn-1", factory.configs[0].Region)
+}
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/hfutil/modelconfig"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"github.com/sgl-project/ome/web-console/backend/pkg/huggingface"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// clusterModelSecretNamespace holds the Hugging Face token secrets of ClusterBaseModels
+	clusterModelSecretNamespace = "ome"
+	// maxHeaderFetches bounds the safetensors headers fetched at the same time
+	maxHeaderFetches = 8
+)
+
+// invalidNameChars are the characters replaced when deriving a model name from a repository name
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ErrUnsupportedModel is returned when a repository holds no model the console can import
+var ErrUnsupportedModel = errors.New("unsupported model")
+
+// ModelImporter imports Hugging Face models as BaseModels and ClusterBaseModels. It reads the configuration and the
+// safetensors headers of a repository, without downloading the weights, and fills the spec the way the model agent
+// does once it has downloaded the model
+type ModelImporter struct {
+	k8sClient *k8s.Client
+	hfClient  *huggingface.Client
+	runtimes  *RuntimeIntelligenceService
+	logger    *zap.Logger
+}
+
+// NewModelImporter creates a new model importer
+func NewModelImporter(k8sClient *k8s.Client, hfClient *huggingface.Client, logger *zap.Logger) *ModelImporter {
+	return &ModelImporter{
+		k8sClient: k8sClient,
+		hfClient:  hfClient,
+		runtimes:  NewRuntimeIntelligenceService(k8sClient, logger),
+		logger:    logger,
+	}
+}
+
+// ModelImport describes the import of a Hugging Face model
+type ModelImport struct {
+	// Repo is the Hugging Face repository of the model, as organization/name
+	Repo string `json:"repo"`
+	// Revision is the branch, tag or commit imported, main when empty
+	Revision string `json:"revision,omitempty"`
+	// Name of the model, derived from the repository when empty
+	Name string `json:"name,omitempty"`
+	// Kind is ClusterBaseModel or BaseModel, ClusterBaseModel when empty
+	Kind string `json:"kind,omitempty"`
+	// Namespace of a BaseModel, and of the InferenceServices runtimes are proposed for
+	Namespace string `json:"namespace,omitempty"`
+	// Path the model is stored at on the nodes
+	Path string `json:"path,omitempty"`
+	// Vendor of the model, the organization of the repository when empty
+	Vendor string `json:"vendor,omitempty"`
+	// Version of the model
+	Version string `json:"version,omitempty"`
+	// NodeSelector restricts the nodes the model is downloaded to
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// HuggingfaceToken reads private and gated repositories, it is stored in a secret the model agent downloads with
+	HuggingfaceToken string `json:"huggingfaceToken,omitempty"`
+	// AcceleratorPolicy selects the accelerator class proposed with each runtime, BestFit when empty
+	AcceleratorPolicy string `json:"acceleratorPolicy,omitempty"`
+	// DryRun only proposes the model and its runtimes, nothing is created
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// ImportResult is the model of an import, with the runtimes the controller would select for it
+type ImportResult struct {
+	Model    *unstructured.Unstructured `json:"model"`
+	Runtimes *RuntimeSelection          `json:"runtimes,omitempty"`
+	// Commit the model was read at
+	Commit   string   `json:"commit,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Created  bool     `json:"created"`
+}
+
+// Import reads a Hugging Face model, proposes its runtimes and accelerator classes and, unless it is a dry run,
+// creates it
+func (s *ModelImporter) Import(ctx context.Context, request ModelImport) (*ImportResult, error) {
+	if request.Revision == "" {
+		request.Revision = "main"
+	}
+	if request.Kind == "" {
+		request.Kind = "ClusterBaseModel"
+	}
+	if request.Kind != "ClusterBaseModel" && request.Kind != "BaseModel" {
+		return nil, fmt.Errorf("model kind %q: %w", request.Kind, ErrUnsupportedModel)
+	}
+	if request.Namespace == "" {
+		request.Namespace = "default"
+	}
+	if request.Name == "" {
+		request.Name = modelName(request.Repo)
+	}
+	if request.AcceleratorPolicy == "" {
+		request.AcceleratorPolicy = string(v1beta1.BestFitPolicy)
+	}
+
+	hfClient := s.hfClient.WithToken(request.HuggingfaceToken)
+	info, err := hfClient.GetModelRevision(ctx, request.Repo, request.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s@%s: %w", request.Repo, request.Revision, err)
+	}
+	// Read all the files at the commit of the revision, in case it moves while they are fetched
+	commit := request.Revision
+	if info.SHA != "" {
+		commit = info.SHA
+	}
+
+	hfModel, cleanup, err := s.loadModelConfig(ctx, hfClient, request.Repo, commit, info.Siblings)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	spec := modelSpec(hfModel, request, commit)
+	result := &ImportResult{Commit: info.SHA}
+
+	// The model is still imported when runtimes cannot be ranked, e.g. when the user may not read them
+	query := ModelQuery{
+		Model:             request.Name,
+		ModelKind:         request.Kind,
+		Namespace:         request.Namespace,
+		AcceleratorPolicy: request.AcceleratorPolicy,
+	}
+	if result.Runtimes, err = s.runtimes.RankRuntimes(ctx, spec, query); err != nil {
+		s.logger.Warn("Failed to propose runtimes for the imported model",
+			zap.String("repo", request.Repo),
+			zap.Error(err))
+		result.Warnings = append(result.Warnings, fmt.Sprintf("no runtimes proposed: %v", err))
+	} else if len(result.Runtimes.Matches) == 0 {
+		result.Warnings = append(result.Warnings, "no runtime can serve the model")
+	}
+
+	if result.Model, err = modelObject(request, spec); err != nil {
+		return nil, err
+	}
+	if request.DryRun {
+		return result, nil
+	}
+
+	// The model names the secret of its token, which is created once the model exists to own it
+	secretNamespace, secretName := tokenSecret(request)
+	if request.HuggingfaceToken != "" {
+		if err := unstructured.SetNestedField(result.Model.Object, secretName, "spec", "storage", "key"); err != nil {
+			return nil, err
+		}
+	}
+	if request.Kind == "ClusterBaseModel" {
+		result.Model, err = s.k8sClient.CreateClusterBaseModel(ctx, result.Model)
+	} else {
+		result.Model, err = s.k8sClient.CreateBaseModel(ctx, request.Namespace, result.Model)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if request.HuggingfaceToken != "" {
+		err := s.k8sClient.CreateHuggingFaceTokenSecret(ctx, secretName, secretNamespace, request.HuggingfaceToken, result.Model)
+		if err != nil {
+			// Without its token the model cannot be downloaded, it is removed so the import can be retried
+			if deleteErr := s.deleteModel(ctx, request); deleteErr != nil {
+				s.logger.Error("Failed to remove the model of a failed import",
+					zap.String("kind", request.Kind),
+					zap.String("name", request.Name),
+					zap.Error(deleteErr))
+			}
+			return nil, fmt.Errorf("failed to create the Hugging Face token secret %s/%s: %w", secretNamespace, secretName, err)
+		}
+	}
+	result.Created = true
+	return result, nil
+}
+
+// loadModelConfig fetches config.json and the safetensors headers of a repository into a temporary directory, laid
+// out like the downloaded model, and loads the configuration from it. The caller removes the directory with cleanup
+func (s *ModelImporter) loadModelConfig(ctx context.Context, hfClient *huggingface.Client, repo, commit string, siblings []huggingface.FileSibling) (modelconfig.HuggingFaceModel, func(), error) {
+	files := map[string]bool{}
+	for _, sibling := range siblings {
+		files[sibling.Filename] = true
+	}
+	if !files["config.json"] {
+		return nil, nil, fmt.Errorf("%s has no config.json, only transformers models can be imported: %w", repo, ErrUnsupportedModel)
+	}
+
+	dir, err := os.MkdirTemp("", "model-import-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create a directory for the model configuration: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			s.logger.Warn("Failed to remove the model configuration", zap.String("dir", dir), zap.Error(err))
+		}
+	}
+
+	// config_sentence_transformers.json marks the embedding variants of some architectures
+	for _, name := range []string{"config.json", "model.safetensors.index.json", "config_sentence_transformers.json"} {
+		if !files[name] {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := hfClient.DownloadFile(ctx, repo, commit, name, &buf); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to download %s of %s: %w", name, repo, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o600); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	shards, err := safetensorsFiles(dir, siblings)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	if err := s.fetchHeaders(ctx, hfClient, repo, commit, dir, shards); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	hfModel, err := modelconfig.LoadModelConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to load the configuration of %s: %w", repo, err)
+	}
+	return hfModel, cleanup, nil
+}
+
+// safetensorsFiles returns the safetensors files the parameters are counted from: the shards of the index when the
+// repository has one, the safetensors files of its root otherwise
+func safetensorsFiles(dir string, siblings []huggingface.FileSibling) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "model.safetensors.index.json"))
+	if os.IsNotExist(err) {
+		var files []string
+		for _, sibling := range siblings {
+			if !strings.Contains(sibling.Filename, "/") && strings.HasSuffix(sibling.Filename, ".safetensors") {
+				files = append(files, sibling.Filename)
+			}
+		}
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the safetensors index: %w", err)
+	}
+
+	var index struct {
+		WeightMap map[string]string `json:"weight_map"`
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse the safetensors index: %w", err)
+	}
+	seen := map[string]bool{}
+	var files []string
+	for _, shard := range index.WeightMap {
+		// The shards are stored next to the index, the parser of modelconfig reads them from there
+		if shard == "" || seen[shard] || strings.Contains(shard, "/") {
+			continue
+		}
+		seen[shard] = true
+		files = append(files, shard)
+	}
+	return files, nil
+}
+
+// fetchHeaders writes the headers of safetensors files to the directory, under their names. The parser of modelconfig
+// only reads the header of a safetensors file, so the tensors are not downloaded
+func (s *ModelImporter) fetchHeaders(ctx context.Context, hfClient *huggingface.Client, repo, commit, dir string, files []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	slots := make(chan struct{}, maxHeaderFetches)
+	for _, file := range files {
+		wg.Add(1)
+		go func(file string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			header, err := hfClient.GetSafetensorsHeader(ctx, repo, commit, file)
+			if err != nil {
+				fail(fmt.Errorf("failed to read the header of %s of %s: %w", file, repo, err))
+				return
+			}
+			if err := os.WriteFile(filepath.Join(dir, file), header, 0o600); err != nil {
+				fail(fmt.Errorf("failed to write the header of %s: %w", file, err))
+			}
+		}(file)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// modelSpec fills the spec of an imported model from its configuration, like the model agent does after downloading it.
+// The storage is pinned to the commit the configuration was read at, so the agent downloads the weights it describes
+func modelSpec(hfModel modelconfig.HuggingFaceModel, request ModelImport, commit string) *v1beta1.BaseModelSpec {
+	formatVersion := "1.0.0"
+	spec := &v1beta1.BaseModelSpec{
+		ModelFormat:       v1beta1.ModelFormat{Name: "safetensors", Version: &formatVersion},
+		ModelFramework:    &v1beta1.ModelFrameworkSpec{Name: "transformers"},
+		ModelCapabilities: modelconfig.ModelCapabilities(hfModel),
+		Storage: &v1beta1.StorageSpec{
+			StorageUri:   stringPtr(fmt.Sprintf("hf://%s@%s", request.Repo, commit)),
+			NodeSelector: request.NodeSelector,
+		},
+	}
+	if version := hfModel.GetTransformerVersion(); version != "" {
+		spec.ModelFramework.Version = &version
+	}
+	if modelType := hfModel.GetModelType(); modelType != "" {
+		spec.ModelType = &modelType
+	}
+	if architecture := hfModel.GetArchitecture(); architecture != "" {
+		spec.ModelArchitecture = &architecture
+	}
+	if count := hfModel.GetParameterCount(); count > 0 {
+		spec.ModelParameterSize = stringPtr(modelconfig.FormatParamCount(count))
+	}
+	if contextLength := hfModel.GetContextLength(); contextLength > 0 {
+		maxTokens := int32(contextLength)
+		spec.MaxTokens = &maxTokens
+	}
+	if quantization := modelconfig.ModelQuantization(hfModel); quantization != "" {
+		spec.Quantization = &quantization
+	}
+
+	spec.DisplayName = &request.Repo
+	vendor := request.Vendor
+	if vendor == "" {
+		vendor, _, _ = strings.Cut(request.Repo, "/")
+	}
+	spec.Vendor = &vendor
+	if request.Version != "" {
+		spec.Version = &request.Version
+	}
+	if request.Path != "" {
+		spec.Storage.Path = &request.Path
+	}
+	return spec
+}
+
+// modelObject builds the BaseModel or ClusterBaseModel of an import
+func modelObject(request ModelImport, spec *v1beta1.BaseModelSpec) (*unstructured.Unstructured, error) {
+	meta := metav1.ObjectMeta{Name: request.Name}
+	var obj runtime.Object
+	if request.Kind == "ClusterBaseModel" {
+		obj = &v1beta1.ClusterBaseModel{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: request.Kind},
+			ObjectMeta: meta,
+			Spec:       *spec,
+		}
+	} else {
+		meta.Namespace = request.Namespace
+		obj = &v1beta1.BaseModel{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: request.Kind},
+			ObjectMeta: meta,
+			Spec:       *spec,
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s: %w", request.Kind, request.Name, err)
+	}
+	model := &unstructured.Unstructured{Object: content}
+	// The status is left to the model controller, and the model configuration to the model agent
+	unstructured.RemoveNestedField(model.Object, "status")
+	unstructured.RemoveNestedField(model.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(model.Object, "spec", "modelConfiguration")
+	return model, nil
+}
+
+// tokenSecret returns the namespace and name of the secret holding the Hugging Face token of an import
+func tokenSecret(request ModelImport) (string, string) {
+	namespace := clusterModelSecretNamespace
+	if request.Kind == "BaseModel" {
+		namespace = request.Namespace
+	}
+	return namespace, request.Name + "-hf-token"
+}
+
+// deleteModel deletes the model of an import
+func (s *ModelImporter) deleteModel(ctx context.Context, request ModelImport) error {
+	if request.Kind == "ClusterBaseModel" {
+		return s.k8sClient.DeleteClusterBaseModel(ctx, request.Name)
+	}
+	return s.k8sClient.DeleteBaseModel(ctx, request.Namespace, request.Name)
+}
+
+// modelName derives a model name from a repository, e.g. meta-llama/Llama-3.1-8B-Instruct becomes
+// meta-llama-llama-3.1-8b-instruct
+func modelName(repo string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(repo), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.Trim(name, "-.")
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"github.com/sgl-project/ome/web-console/backend/pkg/huggingface"
+)
+
+const testCommit = "0123456789abcdef0123456789abcdef01234567"
+
+// testHub serves the repositories of a Hugging Face hub. The main branch of each repository resolves to testCommit,
+// and files are only served at the commit so an import reading them at the branch fails
+type testHub struct {
+	server *httptest.Server
+	// files of each repository, config.json and safetensors files
+	repos map[string]map[string][]byte
+	// gated repositories are only served with the token
+	gated map[string]string
+}
+
+func newTestHub(t *testing.T) *testHub {
+	header := []byte(`{"model.embed_tokens.weight":{"dtype":"BF16","shape":[1000,64],"data_offsets":[0,128000]}}`)
+	safetensors := make([]byte, 8, 8+len(header))
+	binary.LittleEndian.PutUint64(safetensors, uint64(len(header)))
+	safetensors = append(safetensors, header...)
+	config, err := json.Marshal(map[string]interface{}{
+		"architectures":           []string{"LlamaForCausalLM"},
+		"model_type":              "llama",
+		"hidden_size":             64,
+		"intermediate_size":       256,
+		"num_hidden_layers":       2,
+		"num_attention_heads":     4,
+		"num_key_value_heads":     4,
+		"max_position_embeddings": 8192,
+		"vocab_size":              1000,
+		"torch_dtype":             "bfloat16",
+		"transformers_version":    "4.45.0",
+	})
+	require.NoError(t, err)
+
+	hub := &testHub{
+		repos: map[string]map[string][]byte{
+			"meta-llama/Tiny-Llama":  {"config.json": config, "model.safetensors": safetensors},
+			"meta-llama/Gated-Llama": {"config.json": config, "model.safetensors": safetensors},
+			"openai/whisper-gguf":    {"model.gguf": []byte("gguf")},
+		},
+		gated: map[string]string{"meta-llama/Gated-Llama": "hf_secret"},
+	}
+	hub.server = httptest.NewServer(http.HandlerFunc(hub.serve))
+	t.Cleanup(hub.server.Close)
+	return hub
+}
+
+func (h *testHub) serve(w http.ResponseWriter, r *http.Request) {
+	var repo, revision, file string
+	if path, ok := strings.CutPrefix(r.URL.Path, "/api/models/"); ok {
+		repo, revision, _ = strings.Cut(path, "/revision/")
+	} else if before, after, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/resolve/"); ok {
+		repo = before
+		revision, file, _ = strings.Cut(after, "/")
+	}
+	files, ok := h.repos[repo]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if token, gated := h.gated[repo]; gated && r.Header.Get("Authorization") != "Bearer "+token {
+		http.Error(w, "gated repository", http.StatusUnauthorized)
+		return
+	}
+
+	if file == "" {
+		if revision != "main" && revision != testCommit {
+			http.NotFound(w, r)
+			return
+		}
+		info := huggingface.ModelInfo{ID: repo, SHA: testCommit}
+		for name := range files {
+			info.Siblings = append(info.Siblings, huggingface.FileSibling{Filename: name})
+		}
+		_ = json.NewEncoder(w).Encode(info)
+		return
+	}
+	content, ok := files[file]
+	if revision != testCommit || !ok {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, file, time.Time{}, bytes.NewReader(content))
+}
+
+func newTestImporter(t *testing.T, hub *testHub, objects ...runtime.Object) (*ModelImporter, *k8s.Client) {
+	k8sClient, err := k8s.NewFakeClient(zap.NewNop(), objects...)
+	require.NoError(t, err)
+	t.Cleanup(k8sClient.Stop)
+	return &ModelImporter{
+		k8sClient: k8sClient,
+		hfClient:  huggingface.NewClient().WithBaseURL(hub.server.URL + "/api"),
+		runtimes:  NewRuntimeIntelligenceService(k8sClient, zap.NewNop()),
+		logger:    zap.NewNop(),
+	}, k8sClient
+}
+
+func TestImportDryRun(t *testing.T) {
+	importer, k8sClient := newTestImporter(t, newTestHub(t))
+
+	result, err := importer.Import(context.Background(), ModelImport{Repo: "meta-llama/Tiny-Llama", DryRun: true})
+	require.NoError(t, err)
+	assert.False(t, result.Created)
+	assert.Equal(t, testCommit, result.Commit)
+
+	model := result.Model
+	assert.Equal(t, "ClusterBaseModel", model.GetKind())
+	assert.Equal(t, "meta-llama-tiny-llama", model.GetName())
+	// The storage is pinned to the commit the spec was read at, not to the moving branch
+	storageURI, _, _ := unstructured.NestedString(model.Object, "spec", "storage", "storageUri")
+	assert.Equal(t, "hf://meta-llama/Tiny-Llama@"+testCommit, storageURI)
+	architecture, _, _ := unstructured.NestedString(model.Object, "spec", "modelArchitecture")
+	assert.Equal(t, "LlamaForCausalLM", architecture)
+	vendor, _, _ := unstructured.NestedString(model.Object, "spec", "vendor")
+	assert.Equal(t, "meta-llama", vendor)
+	_, found, _ := unstructured.NestedString(model.Object, "spec", "storage", "key")
+	assert.False(t, found)
+	assert.Contains(t, result.Warnings, "no runtime can serve the model")
+
+	models, err := k8sClient.DynamicClient.Resource(k8s.ClusterBaseModelGVR).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, models.Items, "a dry run creates nothing")
+}
+
+func TestImportCreatesTokenSecretOwnedByModel(t *testing.T) {
+	importer, k8sClient := newTestImporter(t, newTestHub(t))
+	ctx := context.Background()
+
+	result, err := importer.Import(ctx, ModelImport{
+		Repo:             "meta-llama/Gated-Llama",
+		Name:             "gated-llama",
+		Kind:             "BaseModel",
+		Namespace:        "team-a",
+		HuggingfaceToken: "hf_secret",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Created)
+
+	model, err := k8sClient.DynamicClient.Resource(k8s.BaseModelGVR).Namespace("team-a").Get(ctx, "gated-llama", metav1.GetOptions{})
+	require.NoError(t, err)
+	key, _, _ := unstructured.NestedString(model.Object, "spec", "storage", "key")
+	assert.Equal(t, "gated-llama-hf-token", key)
+
+	secret, err := k8sClient.Clientset.CoreV1().Secrets("team-a").Get(ctx, "gated-llama-hf-token", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "hf_secret", secret.StringData["token"])
+	assert.Equal(t, []metav1.OwnerReference{{APIVersion: "ome.io/v1beta1", Kind: "BaseModel", Name: "gated-llama"}},
+		secret.OwnerReferences)
+}
+
+func TestImportRemovesModelWhenTokenSecretFails(t *testing.T) {
+	existing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gated-llama-hf-token", Namespace: "ome"}}
+	importer, k8sClient := newTestImporter(t, newTestHub(t), existing)
+	ctx := context.Background()
+
+	_, err := importer.Import(ctx, ModelImport{
+		Repo:             "meta-llama/Gated-Llama",
+		Name:             "gated-llama",
+		HuggingfaceToken: "hf_secret",
+	})
+	require.Error(t, err)
+	assert.True(t, apierrors.IsAlreadyExists(err))
+
+	_, err = k8sClient.DynamicClient.Resource(k8s.ClusterBaseModelGVR).Get(ctx, "gated-llama", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "the model of a failed import is removed")
+}
+
+func TestImportErrors(t *testing.T) {
+	importer, k8sClient := newTestImporter(t, newTestHub(t))
+
+	tests := []struct {
+		name          string
+		request       ModelImport
+		expectedError error
+	}{
+		{
+			name:          "missing repository",
+			request:       ModelImport{Repo: "meta-llama/Missing"},
+			expectedError: huggingface.ErrNotFound,
+		},
+		{
+			name:          "missing revision",
+			request:       ModelImport{Repo: "meta-llama/Tiny-Llama", Revision: "v2"},
+			expectedError: huggingface.ErrNotFound,
+		},
+		{
+			name:          "gated repository without token",
+			request:       ModelImport{Repo: "meta-llama/Gated-Llama"},
+			expectedError: huggingface.ErrUnauthorized,
+		},
+		{
+			name:          "repository without config.json",
+			request:       ModelImport{Repo: "openai/whisper-gguf"},
+			expectedError: ErrUnsupportedModel,
+		},
+		{
+			name:          "unsupported model kind",
+			request:       ModelImport{Repo: "meta-llama/Tiny-Llama", Kind: "FineTunedWeight"},
+			expectedError: ErrUnsupportedModel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := importer.Import(context.Background(), tt.request)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+
+	secrets, err := k8sClient.Clientset.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, secrets.Items)
+}
+
+func TestModelName(t *testing.T) {
+	assert.Equal(t, "meta-llama-llama-3.1-8b-instruct", modelName("meta-llama/Llama-3.1-8B-Instruct"))
+	assert.Equal(t, "org-model", modelName("_org/model_"))
+	assert.Len(t, modelName(strings.Repeat("a", 100)), 63)
+}
@@ -3,253 +3,276 @@ package services
 import (
 	"context"
 	"fmt"
-	"sort"
-	"strings"
 
+	"github.com/sgl-project/ome/pkg/acceleratorclassselector"
+	"github.com/sgl-project/ome/pkg/apis/ome/v1beta1"
+	"github.com/sgl-project/ome/pkg/controller/v1beta1/controllerconfig"
+	"github.com/sgl-project/ome/pkg/runtimeselector"
 	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// RuntimeIntelligenceService provides intelligent runtime matching and recommendations
+// RuntimeIntelligenceService finds the runtimes of a model with the runtime and accelerator class selectors of the
+// controller, so the console recommends the runtime an InferenceService of the model would get
 type RuntimeIntelligenceService struct {
-	k8sClient *k8s.Client
-	logger    *zap.Logger
+	k8sClient    *k8s.Client
+	reader       client.Client
+	selector     runtimeselector.Selector
+	matcher      runtimeselector.RuntimeMatcher
+	accelerators acceleratorclassselector.Selector
+	logger       *zap.Logger
 }
 
-// NewRuntimeIntelligenceService creates a new runtime intelligence service
+// NewRuntimeIntelligenceService creates a new runtime intelligence service, ranking runtimes with the scoring
+// policies the controller is configured with
 func NewRuntimeIntelligenceService(k8sClient *k8s.Client, logger *zap.Logger) *RuntimeIntelligenceService {
+	reader := k8sClient.Reader()
+	config := runtimeselector.NewConfig(reader)
+
+	policyConfig, err := controllerconfig.NewRuntimeSelectionConfig(k8sClient.Clientset)
+	if err != nil {
+		logger.Warn("Failed to load the runtime selection configuration, ranking runtimes with the default scorer",
+			zap.Error(err))
+	} else if config.Policies, err = runtimeselector.NewScoringPolicies(reader, policyConfig); err != nil {
+		logger.Warn("Invalid runtime scoring policies, ranking runtimes with the default scorer", zap.Error(err))
+	}
+
 	return &RuntimeIntelligenceService{
-		k8sClient: k8sClient,
-		logger:    logger,
+		k8sClient:    k8sClient,
+		reader:       reader,
+		selector:     runtimeselector.NewWithConfig(config),
+		matcher:      runtimeselector.NewDefaultRuntimeMatcher(config),
+		accelerators: acceleratorclassselector.New(reader),
+		logger:       logger,
 	}
 }
 
-// RuntimeMatch represents a runtime that matches a model with a compatibility score
+// ModelQuery describes the InferenceService runtimes are selected for
+type ModelQuery struct {
+	// Model is the name of the BaseModel or ClusterBaseModel
+	Model string
+	// ModelKind is BaseModel or ClusterBaseModel, ClusterBaseModel when empty
+	ModelKind string
+	// Namespace of the InferenceService, its ServingRuntimes are candidates next to the ClusterServingRuntimes
+	Namespace string
+	// AcceleratorClass and AcceleratorPolicy are the accelerator selector of the InferenceService
+	AcceleratorClass  string
+	AcceleratorPolicy string
+}
+
+// RuntimeMatch is a runtime the controller may select for a model, with the accelerator class it would use
 type RuntimeMatch struct {
-	Runtime        *unstructured.Unstructured `json:"runtime"`
-	Score          int                        `json:"score"`
-	CompatibleWith []string                   `json:"compatibleWith"`
-	Reasons        []string                   `json:"reasons"`
-	Warnings       []string                   `json:"warnings,omitempty"`
-	Recommendation string                     `json:"recommendation"`
+	Runtime          *unstructured.Unstructured   `json:"runtime"`
+	IsCluster        bool                         `json:"isCluster"`
+	Score            int64                        `json:"score"`
+	MatchDetails     runtimeselector.MatchDetails `json:"matchDetails"`
+	AcceleratorClass string                       `json:"acceleratorClass,omitempty"`
+	Recommendation   string                       `json:"recommendation,omitempty"`
+}
+
+// RuntimeSelection lists the runtimes of a model in the order the controller ranks them, and why the others are
+// excluded
+type RuntimeSelection struct {
+	Matches  []RuntimeMatch                     `json:"matches"`
+	Rejected []runtimeselector.RuntimeRejection `json:"rejected"`
+	Total    int                                `json:"total"`
 }
 
-// CompatibilityCheck represents the result of a compatibility check
+// CompatibilityCheck is the compatibility report of a runtime for a model
 type CompatibilityCheck struct {
-	Compatible bool     `json:"compatible"`
-	Reasons    []string `json:"reasons"`
-	Warnings   []string `json:"warnings,omitempty"`
-	Score      int      `json:"score"`
+	Runtime   string `json:"runtime"`
+	IsCluster bool   `json:"isCluster"`
+	runtimeselector.CompatibilityReport
+	AcceleratorClass string `json:"acceleratorClass,omitempty"`
 }
 
-// FindCompatibleRuntimes finds all runtimes compatible with a given model
-func (s *RuntimeIntelligenceService) FindCompatibleRuntimes(ctx context.Context, modelFormat string, modelFramework string) ([]RuntimeMatch, error) {
-	// Get all cluster-scoped runtimes
-	runtimes, err := s.k8sClient.ListClusterServingRuntimes(ctx)
+// FindCompatibleRuntimes ranks the runtimes the user may read for a model, like the controller does
+func (s *RuntimeIntelligenceService) FindCompatibleRuntimes(ctx context.Context, query ModelQuery) (*RuntimeSelection, error) {
+	model, isvc, err := s.resolve(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list runtimes: %w", err)
-	}
-
-	var matches []RuntimeMatch
-	for _, runtime := range runtimes.Items {
-		match := s.evaluateRuntimeCompatibility(&runtime, modelFormat, modelFramework)
-		if match.Score > 0 {
-			matches = append(matches, match)
-		}
+		return nil, err
 	}
 
-	// Sort by score (highest first)
-	sort.Slice(matches, func(i, j int) bool {
-		return matches[i].Score > matches[j].Score
-	})
+	return s.selectRuntimes(ctx, model, isvc)
+}
 
-	return matches, nil
+// RankRuntimes ranks the runtimes the user may read for a model spec, like the controller will once a model with the
+// spec exists. The model of the query names the model to be
+func (s *RuntimeIntelligenceService) RankRuntimes(ctx context.Context, model *v1beta1.BaseModelSpec, query ModelQuery) (*RuntimeSelection, error) {
+	return s.selectRuntimes(ctx, model, inferenceService(query))
 }
 
-// CheckCompatibility checks if a specific runtime is compatible with a model
-func (s *RuntimeIntelligenceService) CheckCompatibility(ctx context.Context, runtimeName string, modelFormat string, modelFramework string) (*CompatibilityCheck, error) {
-	runtime, err := s.k8sClient.GetClusterServingRuntime(ctx, runtimeName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get runtime: %w", err)
+// selectRuntimes ranks the runtimes of a model for an InferenceService
+func (s *RuntimeIntelligenceService) selectRuntimes(ctx context.Context, model *v1beta1.BaseModelSpec, isvc *v1beta1.InferenceService) (*RuntimeSelection, error) {
+	explanation, err := s.selector.ExplainSelection(ctx, model, isvc)
+	if err != nil && !runtimeselector.IsNoRuntimeFoundError(err) {
+		return nil, fmt.Errorf("failed to select runtimes: %w", err)
 	}
 
-	match := s.evaluateRuntimeCompatibility(runtime, modelFormat, modelFramework)
-
-	return &CompatibilityCheck{
-		Compatible: match.Score > 0,
-		Reasons:    match.Reasons,
-		Warnings:   match.Warnings,
-		Score:      match.Score,
-	}, nil
+	selection := &RuntimeSelection{
+		Matches:  []RuntimeMatch{},
+		Rejected: []runtimeselector.RuntimeRejection{},
+		Total:    explanation.TotalRuntimes,
+	}
+	for i, candidate := range explanation.Candidates {
+		match, err := s.runtimeMatch(ctx, candidate, isvc)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			match.Recommendation = explanation.WinReason
+		}
+		selection.Matches = append(selection.Matches, *match)
+	}
+	selection.Rejected = append(selection.Rejected, explanation.Rejected...)
+	return selection, nil
 }
 
-// GetRecommendation gets the best runtime recommendation for a model
-func (s *RuntimeIntelligenceService) GetRecommendation(ctx context.Context, modelFormat string, modelFramework string) (*RuntimeMatch, error) {
-	matches, err := s.FindCompatibleRuntimes(ctx, modelFormat, modelFramework)
+// GetRecommendation returns the runtime the controller would select for a model
+func (s *RuntimeIntelligenceService) GetRecommendation(ctx context.Context, query ModelQuery) (*RuntimeMatch, error) {
+	model, isvc, err := s.resolve(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("no compatible runtimes found for format=%s, framework=%s", modelFormat, modelFramework)
+	explanation, err := s.selector.ExplainSelection(ctx, model, isvc)
+	if err != nil {
+		return nil, err
 	}
 
-	// Return the highest scored match
-	best := matches[0]
-	best.Recommendation = "Best match based on compatibility score"
-	return &best, nil
+	match, err := s.runtimeMatch(ctx, *explanation.Selected, isvc)
+	if err != nil {
+		return nil, err
+	}
+	match.Recommendation = explanation.WinReason
+	return match, nil
 }
 
-// evaluateRuntimeCompatibility evaluates how well a runtime matches a model
-func (s *RuntimeIntelligenceService) evaluateRuntimeCompatibility(runtime *unstructured.Unstructured, modelFormat string, modelFramework string) RuntimeMatch {
-	match := RuntimeMatch{
-		Runtime:        runtime,
-		Score:          0,
-		CompatibleWith: []string{},
-		Reasons:        []string{},
-		Warnings:       []string{},
+// CheckCompatibility reports whether a runtime can serve a model. Like for an InferenceService naming its runtime,
+// a ServingRuntime of the namespace takes precedence over a ClusterServingRuntime of the same name
+func (s *RuntimeIntelligenceService) CheckCompatibility(ctx context.Context, runtimeName string, query ModelQuery) (*CompatibilityCheck, error) {
+	model, isvc, err := s.resolve(ctx, query)
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract runtime spec
-	spec, found, err := unstructured.NestedMap(runtime.Object, "spec")
-	if !found || err != nil {
-		match.Warnings = append(match.Warnings, "Runtime spec not found or invalid")
-		return match
+	spec, isCluster, err := s.selector.GetRuntime(ctx, runtimeName, query.Namespace)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check supported model formats
-	supportedFormats, found, err := unstructured.NestedSlice(spec, "supportedModelFormats")
-	if !found || err != nil {
-		match.Warnings = append(match.Warnings, "No supported model formats specified")
-	} else {
-		formatMatched := false
-		for _, format := range supportedFormats {
-			formatMap, ok := format.(map[string]interface{})
-			if !ok {
-				continue
-			}
-
-			name, _ := formatMap["name"].(string)
-			version, _ := formatMap["version"].(string)
-
-			// Check if format matches
-			if matchesFormat(modelFormat, name, version) {
-				formatMatched = true
-				match.Score += 50 // High score for format match
-				match.CompatibleWith = append(match.CompatibleWith, fmt.Sprintf("%s:%s", name, version))
-				match.Reasons = append(match.Reasons, fmt.Sprintf("Supports model format %s", name))
-				break
-			}
-		}
-
-		if !formatMatched {
-			match.Warnings = append(match.Warnings, fmt.Sprintf("Model format %s may not be supported", modelFormat))
-		}
+	report, err := s.matcher.GetCompatibilityDetails(spec, model, isvc, runtimeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check compatibility: %w", err)
 	}
 
-	// Check if runtime supports multi-model (bonus points)
-	multiModel, found, err := unstructured.NestedBool(spec, "multiModel")
-	if found && err == nil && multiModel {
-		match.Score += 10
-		match.Reasons = append(match.Reasons, "Supports multi-model serving")
+	check := &CompatibilityCheck{
+		Runtime:             runtimeName,
+		IsCluster:           isCluster,
+		CompatibilityReport: *report,
 	}
-
-	// Check if runtime is disabled
-	disabled, found, err := unstructured.NestedBool(spec, "disabled")
-	if found && err == nil && disabled {
-		match.Score = 0
-		match.Warnings = append(match.Warnings, "Runtime is disabled")
-		return match
+	if report.IsCompatible {
+		if check.AcceleratorClass, err = s.acceleratorClass(ctx, isvc, spec); err != nil {
+			check.Warnings = append(check.Warnings, err.Error())
+		}
 	}
+	return check, nil
+}
 
-	// Check protocol versions (bonus for HTTP/REST support)
-	protocolVersions, found, err := unstructured.NestedSlice(spec, "protocolVersions")
-	if found && err == nil {
-		for _, pv := range protocolVersions {
-			pvMap, ok := pv.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			name, _ := pvMap["name"].(string)
-			if strings.Contains(strings.ToLower(name), "http") || strings.Contains(strings.ToLower(name), "rest") {
-				match.Score += 5
-				match.Reasons = append(match.Reasons, "Supports HTTP/REST protocol")
-				break
-			}
-		}
+// resolve reads the model of the query and builds the InferenceService the selectors evaluate runtimes for
+func (s *RuntimeIntelligenceService) resolve(ctx context.Context, query ModelQuery) (*v1beta1.BaseModelSpec, *v1beta1.InferenceService, error) {
+	kind := query.ModelKind
+	if kind == "" {
+		kind = "ClusterBaseModel"
 	}
 
-	// Framework compatibility (if specified)
-	if modelFramework != "" {
-		// Check built-in adapters or container configurations for framework hints
-		containers, found, err := unstructured.NestedSlice(spec, "containers")
-		if found && err == nil {
-			for _, container := range containers {
-				containerMap, ok := container.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				image, _ := containerMap["image"].(string)
-				if matchesFramework(modelFramework, image) {
-					match.Score += 20
-					match.Reasons = append(match.Reasons, fmt.Sprintf("Container image supports %s framework", modelFramework))
-					break
-				}
-			}
+	var model *v1beta1.BaseModelSpec
+	switch kind {
+	case "ClusterBaseModel":
+		clusterBaseModel := &v1beta1.ClusterBaseModel{}
+		if err := s.reader.Get(ctx, client.ObjectKey{Name: query.Model}, clusterBaseModel); err != nil {
+			return nil, nil, err
 		}
+		model = &clusterBaseModel.Spec
+	case "BaseModel":
+		baseModel := &v1beta1.BaseModel{}
+		if err := s.reader.Get(ctx, client.ObjectKey{Namespace: query.Namespace, Name: query.Model}, baseModel); err != nil {
+			return nil, nil, err
+		}
+		model = &baseModel.Spec
+	default:
+		return nil, nil, fmt.Errorf("unsupported model kind %q", kind)
 	}
 
-	return match
+	return model, inferenceService(query), nil
 }
 
-// matchesFormat checks if a model format matches a runtime's supported format
-func matchesFormat(modelFormat, runtimeFormat, runtimeVersion string) bool {
-	modelFormatLower := strings.ToLower(modelFormat)
-	runtimeFormatLower := strings.ToLower(runtimeFormat)
-
-	// Exact match
-	if modelFormatLower == runtimeFormatLower {
-		return true
+// inferenceService builds the InferenceService of a query, the selectors evaluate runtimes for it
+func inferenceService(query ModelQuery) *v1beta1.InferenceService {
+	kind := query.ModelKind
+	if kind == "" {
+		kind = "ClusterBaseModel"
 	}
-
-	// Handle common format aliases
-	formatAliases := map[string][]string{
-		"pytorch":     {"torch", "pt", "pth"},
-		"tensorflow":  {"tf", "savedmodel"},
-		"onnx":        {"onnx"},
-		"safetensors": {"safetensor", "st"},
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: query.Namespace},
+		Spec: v1beta1.InferenceServiceSpec{
+			Model: &v1beta1.ModelRef{Name: query.Model, Kind: &kind},
+		},
 	}
-
-	for canonical, aliases := range formatAliases {
-		if modelFormatLower == canonical || contains(aliases, modelFormatLower) {
-			if runtimeFormatLower == canonical || contains(aliases, runtimeFormatLower) {
-				return true
-			}
+	if query.AcceleratorClass != "" || query.AcceleratorPolicy != "" {
+		isvc.Spec.AcceleratorSelector = &v1beta1.AcceleratorSelector{
+			Policy: v1beta1.AcceleratorSelectionPolicy(query.AcceleratorPolicy),
+		}
+		if query.AcceleratorClass != "" {
+			isvc.Spec.AcceleratorSelector.AcceleratorClass = &query.AcceleratorClass
 		}
 	}
-
-	return false
+	return isvc
 }
 
-// matchesFramework checks if a framework is supported by a container image
-func matchesFramework(framework, containerImage string) bool {
-	frameworkLower := strings.ToLower(framework)
-	imageLower := strings.ToLower(containerImage)
+// runtimeMatch reads the runtime of a candidate and the accelerator class the engine would use with it
+func (s *RuntimeIntelligenceService) runtimeMatch(ctx context.Context, candidate runtimeselector.RuntimeMatch, isvc *v1beta1.InferenceService) (*RuntimeMatch, error) {
+	var runtime *unstructured.Unstructured
+	var err error
+	if candidate.IsCluster {
+		runtime, err = s.k8sClient.GetClusterServingRuntime(ctx, candidate.Name)
+	} else {
+		runtime, err = s.k8sClient.GetServingRuntime(ctx, isvc.Namespace, candidate.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The controller fails to reconcile the InferenceService with a missing accelerator class, the runtime is still
+	// listed with the class it names
+	acceleratorClass, err := s.acceleratorClass(ctx, isvc, candidate.Spec)
+	if err != nil {
+		s.logger.Warn("Failed to select an accelerator class",
+			zap.String("runtime", candidate.Name),
+			zap.Error(err))
+	}
 
-	// Check if framework name appears in image
-	return strings.Contains(imageLower, frameworkLower)
+	return &RuntimeMatch{
+		Runtime:          runtime,
+		IsCluster:        candidate.IsCluster,
+		Score:            candidate.Score,
+		MatchDetails:     candidate.MatchDetails,
+		AcceleratorClass: acceleratorClass,
+	}, nil
 }
 
-// contains checks if a slice contains a string
-func contains(slice []string, str string) bool {
-	for _, s := range slice {
-		if s == str {
-			return true
-		}
+// acceleratorClass returns the accelerator class the engine of the InferenceService would use with a runtime, empty
+// when the runtime has no accelerator requirements or the InferenceService selects none. The name is also returned
+// when the selected class cannot be read
+func (s *RuntimeIntelligenceService) acceleratorClass(ctx context.Context, isvc *v1beta1.InferenceService, spec *v1beta1.ServingRuntimeSpec) (string, error) {
+	_, name, err := s.accelerators.GetAcceleratorClass(ctx, isvc, spec, v1beta1.EngineComponent)
+	if err != nil {
+		return name, fmt.Errorf("failed to select an accelerator class: %w", err)
 	}
-	return false
+	return name, nil
 }
 
 // ValidateRuntimeConfiguration validates a runtime configuration before creation
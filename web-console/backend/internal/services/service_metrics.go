@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/sgl-project/ome/pkg/constants"
+	"github.com/sgl-project/ome/pkg/prometheus"
+	"github.com/sgl-project/ome/web-console/backend/internal/k8s"
+	"go.uber.org/zap"
+)
+
+// defaultPrometheusURL is the Prometheus server queried when PROMETHEUS_URL is not set, the same default the
+// controller scales and analyzes InferenceServices with
+const defaultPrometheusURL = "http://prometheus-operated.monitoring.svc.cluster.local:9090"
+
+// windowPattern matches the Prometheus durations accepted as metric windows
+var windowPattern = regexp.MustCompile(`^[1-9][0-9]*[smhd]$`)
+
+// ErrInvalidWindow is returned for metric windows which are not Prometheus durations
+var ErrInvalidWindow = errors.New("invalid window, expected a duration such as 5m")
+
+// Serving metric queries, formatted with the namespace, the app label of the component pods and the window. SGLang
+// and vLLM name their metrics differently, a component only exports the ones of its engine
+const (
+	requestRateQuery = `sum(rate({__name__=~"sglang:num_requests_total|vllm:request_success_total",namespace="%s",app=~"%s"}[%s]))`
+	ttftQuery        = `histogram_quantile(%s, sum by (le) (rate({__name__=~"sglang:time_to_first_token_seconds_bucket|vllm:time_to_first_token_seconds_bucket",namespace="%s",app=~"%s"}[%s])))`
+	// GPU utilization is exported by the DCGM exporter, labelled with the pods using the GPUs
+	gpuUtilizationQuery = `avg(avg_over_time(DCGM_FI_DEV_GPU_UTIL{namespace="%s",pod=~"%s-.*"}[%s]))`
+)
+
+// ServiceMetricsService queries Prometheus for the serving metrics of InferenceServices
+type ServiceMetricsService struct {
+	k8sClient *k8s.Client
+	client    prometheus.MetricsClient
+	address   string
+	logger    *zap.Logger
+}
+
+// NewServiceMetricsService creates a new service metrics service querying the Prometheus server of PROMETHEUS_URL
+func NewServiceMetricsService(k8sClient *k8s.Client, logger *zap.Logger) *ServiceMetricsService {
+	address := os.Getenv("PROMETHEUS_URL")
+	if address == "" {
+		address = defaultPrometheusURL
+	}
+	client, err := prometheus.NewClient(address)
+	if err != nil {
+		logger.Warn("Failed to create the Prometheus client, service metrics are unavailable", zap.Error(err))
+	}
+	return &ServiceMetricsService{
+		k8sClient: k8sClient,
+		client:    client,
+		address:   address,
+		logger:    logger,
+	}
+}
+
+// ServiceMetrics are the serving metrics of a component of an InferenceService over a window. A metric is nil when
+// Prometheus has no samples of it, e.g. without requests in the window
+type ServiceMetrics struct {
+	Component string `json:"component"`
+	Window    string `json:"window"`
+	// RequestRate is in requests per second
+	RequestRate *float64 `json:"requestRate"`
+	// TTFTP50 and TTFTP95 are quantiles of the time to first token, in seconds
+	TTFTP50 *float64 `json:"ttftP50"`
+	TTFTP95 *float64 `json:"ttftP95"`
+	// GPUUtilization is the average utilization of the GPUs of the component, in percent
+	GPUUtilization *float64 `json:"gpuUtilization"`
+}
+
+// GetMetrics queries the serving metrics of a component of an InferenceService the user of the request may get
+func (s *ServiceMetricsService) GetMetrics(ctx context.Context, namespace, name, component, window string) (*ServiceMetrics, error) {
+	if !windowPattern.MatchString(window) {
+		return nil, fmt.Errorf("%q: %w", window, ErrInvalidWindow)
+	}
+	if _, err := s.k8sClient.GetInferenceService(ctx, namespace, name); err != nil {
+		return nil, err
+	}
+	if s.client == nil {
+		return nil, fmt.Errorf("no Prometheus client for %s", s.address)
+	}
+
+	// Canary pods are labelled with the canary workload of the component, both serve the traffic of the service.
+	// The names are those of an existing InferenceService, so they hold no characters to escape in the queries
+	componentName := name + "-" + component
+	app := componentName + "|" + constants.CanaryServiceName(componentName)
+
+	metrics := &ServiceMetrics{Component: component, Window: window}
+	queries := []struct {
+		value **float64
+		query string
+	}{
+		{&metrics.RequestRate, fmt.Sprintf(requestRateQuery, namespace, app, window)},
+		{&metrics.TTFTP50, fmt.Sprintf(ttftQuery, "0.5", namespace, app, window)},
+		{&metrics.TTFTP95, fmt.Sprintf(ttftQuery, "0.95", namespace, app, window)},
+		{&metrics.GPUUtilization, fmt.Sprintf(gpuUtilizationQuery, namespace, componentName, window)},
+	}
+	for _, q := range queries {
+		value, ok, err := s.client.Query(ctx, q.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", s.address, err)
+		}
+		if ok {
+			*q.value = &value
+		}
+	}
+	return metrics, nil
+}
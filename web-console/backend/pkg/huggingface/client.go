@@ -19,6 +19,7 @@ const (
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	token      string
 }
 
 // NewClient creates a new HuggingFace API client
@@ -0,0 +1,192 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// maxFileSize bounds the configuration files downloaded from a repository
+	maxFileSize = 10 * 1024 * 1024
+	// maxHeaderSize bounds safetensors headers, like the safetensors parser of modelconfig does
+	maxHeaderSize = 10 * 1024 * 1024
+	// headerPrefetch is fetched with the header length, most safetensors headers fit in it
+	headerPrefetch = 64 * 1024
+)
+
+var (
+	// ErrNotFound is returned when a repository, revision or file does not exist
+	ErrNotFound = errors.New("not found on Hugging Face")
+	// ErrUnauthorized is returned when a repository is private or gated and the token does not grant access to it
+	ErrUnauthorized = errors.New("access denied by Hugging Face")
+)
+
+// WithToken returns a client authenticating its requests with a Hugging Face token, to read private and gated
+// repositories
+func (c *Client) WithToken(token string) *Client {
+	client := *c
+	client.token = token
+	return &client
+}
+
+// WithBaseURL returns a client of the Hugging Face API at baseURL, e.g. a mirror of the hub
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	client := *c
+	client.baseURL = strings.TrimSuffix(baseURL, "/")
+	return &client
+}
+
+// GetModelRevision retrieves the information of a model at a revision, a branch, tag or commit, with the size of
+// its files
+func (c *Client) GetModelRevision(ctx context.Context, modelID, revision string) (*ModelInfo, error) {
+	endpoint := fmt.Sprintf("%s/models/%s/revision/%s?blobs=true", c.baseURL, escapePath(modelID), url.PathEscape(revision))
+
+	resp, err := c.get(ctx, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var info ModelInfo
+	if err := decodeJSON(resp.Body, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// DownloadFile writes a file of a model repository at a revision to w
+func (c *Client) DownloadFile(ctx context.Context, modelID, revision, filename string, w io.Writer) error {
+	resp, err := c.get(ctx, c.fileURL(modelID, revision, filename), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", filename, err)
+	}
+	if n > maxFileSize {
+		return fmt.Errorf("%s exceeds the maximum size of %d bytes", filename, maxFileSize)
+	}
+	return nil
+}
+
+// GetSafetensorsHeader retrieves the header of a safetensors file without its tensors: the 8 byte header length
+// followed by the JSON header, which is all the safetensors parser of modelconfig reads
+func (c *Client) GetSafetensorsHeader(ctx context.Context, modelID, revision, filename string) ([]byte, error) {
+	fileURL := c.fileURL(modelID, revision, filename)
+
+	prefix, err := c.getRange(ctx, fileURL, 0, headerPrefetch-1)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefix) < 8 {
+		return nil, fmt.Errorf("%s is too short to be a safetensors file", filename)
+	}
+
+	headerLen := binary.LittleEndian.Uint64(prefix[:8])
+	if headerLen > maxHeaderSize {
+		return nil, fmt.Errorf("header length %d of %s exceeds the maximum size of %d bytes", headerLen, filename, maxHeaderSize)
+	}
+	size := 8 + int(headerLen)
+	if len(prefix) >= size {
+		return prefix[:size], nil
+	}
+
+	rest, err := c.getRange(ctx, fileURL, int64(len(prefix)), int64(size-1))
+	if err != nil {
+		return nil, err
+	}
+	header := append(prefix, rest...)
+	if len(header) < size {
+		return nil, fmt.Errorf("%s ends within its header", filename)
+	}
+	return header[:size], nil
+}
+
+// getRange retrieves the bytes first to last of a file. Servers ignoring the range answer with the whole file, only
+// the range is read from it
+func (c *Client) getRange(ctx context.Context, fileURL string, first, last int64) ([]byte, error) {
+	resp, err := c.get(ctx, fileURL, fmt.Sprintf("bytes=%d-%d", first, last))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body := io.Reader(resp.Body)
+	if resp.StatusCode == http.StatusOK && first > 0 {
+		if _, err := io.CopyN(io.Discard, body, first); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", fileURL, err)
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(body, last-first+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fileURL, err)
+	}
+	return data, nil
+}
+
+// get executes a GET request, optionally for a byte range, and checks its status. The caller closes the body
+func (c *Client) get(ctx context.Context, endpoint, byteRange string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %w", endpoint, ErrNotFound)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %w", endpoint, ErrUnauthorized)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+// fileURL returns the download URL of a file of a model repository at a revision
+func (c *Client) fileURL(modelID, revision, filename string) string {
+	return fmt.Sprintf("%s/%s/resolve/%s/%s", strings.TrimSuffix(c.baseURL, "/api"), escapePath(modelID),
+		url.PathEscape(revision), escapePath(filename))
+}
+
+// escapePath escapes the segments of a repository id or file path, keeping the slashes between them
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// decodeJSON decodes a JSON response body
+func decodeJSON(body io.Reader, v interface{}) error {
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
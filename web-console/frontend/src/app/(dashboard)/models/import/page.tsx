@@ -1,16 +1,18 @@
 'use client'
 
-import { useState, useEffect } from 'react'
+import { useState, useEffect, useMemo } from 'react'
 import { useRouter } from 'next/navigation'
 import Link from 'next/link'
 import {
   useHuggingFaceSearch,
   useHuggingFaceModelInfo,
-  useHuggingFaceModelConfig,
+  useHuggingFaceImportPreview,
+  useHuggingFaceImport,
 } from '@/lib/hooks/useHuggingFace'
 import { useNamespaces } from '@/lib/hooks/useNamespaces'
 import {
   ModelScope,
+  type HuggingFaceImportRequest,
   type HuggingFaceModelSearchResult,
   type HuggingFaceSearchParams,
 } from '@/lib/types/model'
@@ -18,6 +20,18 @@ import { Spinner } from '@/components/ui/Spinner'
 
 type WizardStep = 'search' | 'scope' | 'review' | 'importing'
 
+// parseNodeSelector parses comma-separated key=value pairs
+function parseNodeSelector(value: string): Record<string, string> | undefined {
+  const selector: Record<string, string> = {}
+  for (const pair of value.split(',')) {
+    const [key, ...rest] = pair.split('=')
+    if (key.trim()) {
+      selector[key.trim()] = rest.join('=').trim()
+    }
+  }
+  return Object.keys(selector).length > 0 ? selector : undefined
+}
+
 export default function ImportModelPage() {
   const router = useRouter()
 
@@ -29,6 +43,8 @@ export default function ImportModelPage() {
   const [modelScope, setModelScope] = useState<ModelScope>(ModelScope.Cluster)
   const [namespace, setNamespace] = useState('default')
   const [modelName, setModelName] = useState('')
+  const [revision, setRevision] = useState('main')
+  const [nodeSelector, setNodeSelector] = useState('')
   const [storagePath, setStoragePath] = useState('')
   const [vendor, setVendor] = useState('')
   const [version, setVersion] = useState('')
@@ -44,9 +60,42 @@ export default function ImportModelPage() {
   const { data: modelInfo, isLoading: isLoadingInfo } = useHuggingFaceModelInfo(
     selectedModel?.modelId || null
   )
-  const { data: modelConfig, isLoading: isLoadingConfig } = useHuggingFaceModelConfig(
-    selectedModel?.modelId || null
-  )
+
+  // The import is previewed on the review step, the model is filled from its configuration and
+  // safetensors headers and the runtimes the controller would select are proposed
+  const importRequest = useMemo<HuggingFaceImportRequest | null>(() => {
+    if (step !== 'review' || !selectedModel) return null
+    return {
+      repo: selectedModel.modelId,
+      revision: revision.trim() || undefined,
+      name: modelName,
+      kind: modelScope === ModelScope.Cluster ? 'ClusterBaseModel' : 'BaseModel',
+      namespace: modelScope === ModelScope.Namespace ? namespace : undefined,
+      path: storagePath.trim() || undefined,
+      vendor: vendor.trim() || undefined,
+      version: version.trim() || undefined,
+      nodeSelector: parseNodeSelector(nodeSelector),
+      huggingfaceToken: huggingfaceToken || undefined,
+    }
+  }, [
+    step,
+    selectedModel,
+    revision,
+    modelName,
+    modelScope,
+    namespace,
+    storagePath,
+    vendor,
+    version,
+    nodeSelector,
+    huggingfaceToken,
+  ])
+  const {
+    data: preview,
+    isLoading: isLoadingPreview,
+    error: previewError,
+  } = useHuggingFaceImportPreview(importRequest)
+  const importModel = useHuggingFaceImport()
   const { data: namespacesData, isLoading: namespacesLoading } = useNamespaces()
 
   // Set default namespace when namespaces load
@@ -119,75 +168,14 @@ export default function ImportModelPage() {
   }
 
   const handleImport = async () => {
-    if (!selectedModel || !modelInfo) return
+    if (!importRequest) return
 
     setStep('importing')
     setError(null)
 
     try {
-      // Detect model format from siblings
-      const detectedFormat = modelInfo.detectedFormat || 'safetensors'
-
-      // Build storage spec with hf:// URI scheme
-      // Note: The backend automatically adds storage.key when huggingfaceToken is provided
-      const storageSpec: { storageUri: string; path?: string } = {
-        storageUri: `hf://${selectedModel.modelId}`,
-      }
-      if (storagePath.trim()) {
-        storageSpec.path = storagePath.trim()
-      }
-
-      // Build model spec
-      const modelSpec: Record<string, unknown> = {
-        modelFormat: {
-          name: detectedFormat,
-        },
-        modelType: modelConfig?.model_type || 'text-generation',
-        modelArchitecture: modelConfig?.architectures?.[0] || '',
-        storage: storageSpec,
-        displayName: selectedModel.modelId,
-      }
-
-      // Add optional fields if provided
-      if (vendor.trim()) {
-        modelSpec.vendor = vendor.trim()
-      }
-      if (version.trim()) {
-        modelSpec.version = version.trim()
-      }
-
-      // Prepare model data based on scope
-      const modelData = {
-        apiVersion: 'ome.io/v1beta1',
-        kind: modelScope === ModelScope.Cluster ? 'ClusterBaseModel' : 'BaseModel',
-        metadata: {
-          name: modelName,
-          ...(modelScope === ModelScope.Namespace && { namespace }),
-        },
-        spec: modelSpec,
-      }
-
-      // Call appropriate API endpoint
-      const apiUrl =
-        modelScope === ModelScope.Cluster
-          ? `${process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080'}/api/v1/models`
-          : `${process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080'}/api/v1/namespaces/${namespace}/models`
-
-      const response = await fetch(apiUrl, {
-        method: 'POST',
-        headers: {
-          'Content-Type': 'application/json',
-        },
-        body: JSON.stringify({
-          model: modelData,
-          huggingfaceToken: huggingfaceToken || undefined,
-        }),
-      })
-
-      if (!response.ok) {
-        const errorData = await response.json()
-        throw new Error(errorData.details || 'Failed to import model')
-      }
+      // The backend stores the token in a secret and sets storage.key when one is provided
+      await importModel.mutateAsync(importRequest)
 
       // Success - redirect to models list
       router.push('/models')
@@ -426,6 +414,22 @@ export default function ImportModelPage() {
                 </p>
               </div>
 
+              {/* Revision */}
+              <div>
+                <label htmlFor="revision" className="block text-sm font-medium text-gray-700">
+                  Revision
+                </label>
+                <input
+                  type="text"
+                  id="revision"
+                  value={revision}
+                  onChange={(e) => setRevision(e.target.value)}
+                  className="mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-blue-500"
+                  placeholder="main"
+                />
+                <p className="mt-1 text-sm text-gray-500">Branch, tag or commit to import</p>
+              </div>
+
               {/* Vendor and Version Row */}
               <div className="grid grid-cols-2 gap-4">
                 <div>
@@ -477,6 +481,24 @@ export default function ImportModelPage() {
                 </p>
               </div>
 
+              {/* Node Selector */}
+              <div>
+                <label htmlFor="nodeSelector" className="block text-sm font-medium text-gray-700">
+                  Node Selector
+                </label>
+                <input
+                  type="text"
+                  id="nodeSelector"
+                  value={nodeSelector}
+                  onChange={(e) => setNodeSelector(e.target.value)}
+                  className="mt-1 block w-full rounded-md border border-gray-300 px-3 py-2 shadow-sm focus:border-blue-500 focus:outline-none focus:ring-blue-500"
+                  placeholder="node.kubernetes.io/instance-type=BM.GPU.H100.8"
+                />
+                <p className="mt-1 text-sm text-gray-500">
+                  Optional. Comma-separated key=value labels of the nodes the model is downloaded to
+                </p>
+              </div>
+
               {/* HuggingFace Token */}
               <div>
                 <label htmlFor="hfToken" className="block text-sm font-medium text-gray-700">
@@ -525,8 +547,18 @@ export default function ImportModelPage() {
           <div className="rounded-lg bg-white p-6 shadow">
             <h2 className="mb-4 text-lg font-medium text-gray-900">Review and Import</h2>
 
-            {isLoadingInfo || isLoadingConfig ? (
-              <div className="text-center text-gray-500">Loading model information...</div>
+            {isLoadingInfo || isLoadingPreview ? (
+              <div className="text-center text-gray-500">
+                Reading the model configuration and proposing runtimes...
+              </div>
+            ) : previewError ? (
+              <div className="rounded-lg bg-red-50 p-4">
+                <p className="text-sm text-red-800">
+                  {previewError instanceof Error
+                    ? previewError.message
+                    : 'Failed to read the model'}
+                </p>
+              </div>
             ) : (
               <div className="space-y-4">
                 {/* Model Information */}
@@ -562,7 +594,7 @@ export default function ImportModelPage() {
                     <div>
                       <dt className="text-gray-500">Storage URI</dt>
                       <dd className="font-medium text-gray-900 font-mono text-xs">
-                        hf://{selectedModel.modelId}
+                        {preview?.model.spec.storage?.storageUri}
                       </dd>
                     </div>
                     {storagePath && (
@@ -595,67 +627,126 @@ export default function ImportModelPage() {
                         </dd>
                       </div>
                     )}
-                    {modelConfig?.architectures && modelConfig.architectures.length > 0 && (
-                      <div>
-                        <dt className="text-gray-500">Architecture</dt>
-                        <dd className="font-medium text-gray-900">
-                          {modelConfig.architectures[0]}
-                        </dd>
-                      </div>
-                    )}
                   </dl>
                 </div>
 
-                {/* Auto-detected Config */}
-                {modelConfig && (
+                {/* Detected Configuration */}
+                {preview && (
                   <div className="rounded-lg border border-gray-200 p-4">
-                    <h3 className="font-medium text-gray-900 mb-2">Auto-detected Configuration</h3>
+                    <h3 className="font-medium text-gray-900 mb-2">Detected Configuration</h3>
                     <dl className="grid grid-cols-2 gap-3 text-sm">
-                      {modelConfig.model_type && (
+                      {preview.model.spec.modelType && (
                         <div>
                           <dt className="text-gray-500">Model Type</dt>
-                          <dd className="font-medium text-gray-900">{modelConfig.model_type}</dd>
+                          <dd className="font-medium text-gray-900">
+                            {preview.model.spec.modelType}
+                          </dd>
+                        </div>
+                      )}
+                      {preview.model.spec.modelArchitecture && (
+                        <div>
+                          <dt className="text-gray-500">Architecture</dt>
+                          <dd className="font-medium text-gray-900">
+                            {preview.model.spec.modelArchitecture}
+                          </dd>
                         </div>
                       )}
-                      {modelConfig.hidden_size && (
+                      {preview.model.spec.modelParameterSize && (
                         <div>
-                          <dt className="text-gray-500">Hidden Size</dt>
-                          <dd className="font-medium text-gray-900">{modelConfig.hidden_size}</dd>
+                          <dt className="text-gray-500">Parameter Size</dt>
+                          <dd className="font-medium text-gray-900">
+                            {preview.model.spec.modelParameterSize}
+                          </dd>
                         </div>
                       )}
-                      {modelConfig.num_hidden_layers && (
+                      {preview.model.spec.quantization && (
                         <div>
-                          <dt className="text-gray-500">Layers</dt>
+                          <dt className="text-gray-500">Quantization</dt>
                           <dd className="font-medium text-gray-900">
-                            {modelConfig.num_hidden_layers}
+                            {preview.model.spec.quantization}
                           </dd>
                         </div>
                       )}
-                      {modelConfig.num_attention_heads && (
+                      {preview.model.spec.maxTokens && (
                         <div>
-                          <dt className="text-gray-500">Attention Heads</dt>
+                          <dt className="text-gray-500">Context Length</dt>
                           <dd className="font-medium text-gray-900">
-                            {modelConfig.num_attention_heads}
+                            {preview.model.spec.maxTokens.toLocaleString()}
                           </dd>
                         </div>
                       )}
-                      {modelConfig.vocab_size && (
+                      {preview.model.spec.modelCapabilities && (
                         <div>
-                          <dt className="text-gray-500">Vocab Size</dt>
+                          <dt className="text-gray-500">Capabilities</dt>
                           <dd className="font-medium text-gray-900">
-                            {modelConfig.vocab_size.toLocaleString()}
+                            {preview.model.spec.modelCapabilities.join(', ')}
                           </dd>
                         </div>
                       )}
-                      {modelConfig.torch_dtype && (
+                      {preview.commit && (
                         <div>
-                          <dt className="text-gray-500">Data Type</dt>
-                          <dd className="font-medium text-gray-900">{modelConfig.torch_dtype}</dd>
+                          <dt className="text-gray-500">Commit</dt>
+                          <dd className="font-medium text-gray-900 font-mono text-xs">
+                            {preview.commit}
+                          </dd>
                         </div>
                       )}
                     </dl>
                   </div>
                 )}
+
+                {/* Proposed Runtimes */}
+                {preview && (
+                  <div className="rounded-lg border border-gray-200 p-4">
+                    <h3 className="font-medium text-gray-900 mb-2">Proposed Runtimes</h3>
+                    {preview.warnings?.map((warning) => (
+                      <p key={warning} className="mb-2 text-sm text-amber-600">
+                        {warning}
+                      </p>
+                    ))}
+                    <ul className="space-y-2 text-sm">
+                      {preview.runtimes?.matches.map((match, index) => (
+                        <li
+                          key={`${match.isCluster}-${match.runtime.metadata.name}`}
+                          className="flex items-start justify-between"
+                        >
+                          <div>
+                            <span className="font-medium text-gray-900">
+                              {match.runtime.metadata.name}
+                            </span>
+                            {index === 0 && (
+                              <span className="ml-2 rounded bg-green-100 px-2 py-0.5 text-xs text-green-800">
+                                Recommended
+                              </span>
+                            )}
+                            {match.recommendation && (
+                              <p className="text-xs text-gray-500">{match.recommendation}</p>
+                            )}
+                          </div>
+                          <div className="ml-4 text-right text-gray-500">
+                            {match.acceleratorClass && <div>{match.acceleratorClass}</div>}
+                            <div className="text-xs">Score {match.score}</div>
+                          </div>
+                        </li>
+                      ))}
+                    </ul>
+                    {preview.runtimes && preview.runtimes.rejected.length > 0 && (
+                      <details className="mt-3 text-sm">
+                        <summary className="cursor-pointer text-gray-500">
+                          {preview.runtimes.rejected.length} incompatible runtimes
+                        </summary>
+                        <ul className="mt-2 space-y-1">
+                          {preview.runtimes.rejected.map((rejection) => (
+                            <li key={`${rejection.isCluster}-${rejection.name}`}>
+                              <span className="font-medium text-gray-700">{rejection.name}</span>
+                              <span className="text-gray-500">: {rejection.reasons.join('; ')}</span>
+                            </li>
+                          ))}
+                        </ul>
+                      </details>
+                    )}
+                  </div>
+                )}
               </div>
             )}
 
@@ -668,7 +759,7 @@ export default function ImportModelPage() {
               </button>
               <button
                 onClick={handleImport}
-                disabled={isLoadingInfo || isLoadingConfig}
+                disabled={isLoadingInfo || isLoadingPreview || !preview}
                 className="rounded-lg bg-blue-600 px-4 py-2 text-sm font-medium text-white hover:bg-blue-700 disabled:bg-blue-400"
               >
                 Import Model
@@ -19,7 +19,7 @@ import { StatusBadge } from '@/components/ui/StatusBadge'
 import { Spinner } from '@/components/ui/Spinner'
 import Link from 'next/link'
 import type { ClusterBaseModel } from '@/lib/types/model'
-import type { RuntimeMatch } from '@/lib/types/runtime'
+import type { ClusterServingRuntime, RuntimeMatch } from '@/lib/types/runtime'
 
 const deploySchema = z.object({
   name: z
@@ -34,6 +34,25 @@ const deploySchema = z.object({
   maxReplicas: z.number().min(1).max(100).optional(),
 })
 
+// A runtime as listed for the selected model, ranked by the runtime selector of the controller
+type RuntimeOption = {
+  runtime: ClusterServingRuntime
+  score: number
+  reasons: string[]
+  warnings: string[]
+  recommendation: string
+}
+
+// matchReasons describes why a compatible runtime matches the model
+function matchReasons(match: RuntimeMatch): string[] {
+  const reasons: string[] = []
+  if (match.matchDetails.formatMatch) reasons.push('Model format')
+  if (match.matchDetails.frameworkMatch) reasons.push('Model framework')
+  if (match.matchDetails.sizeMatch) reasons.push('Model size')
+  if (match.acceleratorClass) reasons.push(`Accelerator ${match.acceleratorClass}`)
+  return reasons
+}
+
 type DeployFormData = {
   name: string
   namespace: string
@@ -46,20 +65,19 @@ type DeployFormData = {
 
 // Score badge component
 function ScoreBadge({ score }: { score: number }) {
-  const getScoreColor = (s: number) => {
-    if (s >= 80) return 'bg-success/10 text-success border-success/20'
-    if (s >= 60) return 'bg-warning/10 text-warning border-warning/20'
-    return 'bg-muted text-muted-foreground border-border'
-  }
+  const color =
+    score > 0
+      ? 'bg-success/10 text-success border-success/20'
+      : 'bg-muted text-muted-foreground border-border'
 
   return (
     <span
-      className={`inline-flex items-center gap-1 rounded-full px-2 py-0.5 text-xs font-medium border ${getScoreColor(score)}`}
+      className={`inline-flex items-center gap-1 rounded-full px-2 py-0.5 text-xs font-medium border ${color}`}
     >
       <svg className="w-3 h-3" fill="currentColor" viewBox="0 0 20 20">
         <path d="M9.049 2.927c.3-.921 1.603-.921 1.902 0l1.07 3.292a1 1 0 00.95.69h3.462c.969 0 1.371 1.24.588 1.81l-2.8 2.034a1 1 0 00-.364 1.118l1.07 3.292c.3.921-.755 1.688-1.54 1.118l-2.8-2.034a1 1 0 00-1.175 0l-2.8 2.034c-.784.57-1.838-.197-1.539-1.118l1.07-3.292a1 1 0 00-.364-1.118L2.98 8.72c-.783-.57-.38-1.81.588-1.81h3.461a1 1 0 00.951-.69l1.07-3.292z" />
       </svg>
-      {score}%
+      {score}
     </span>
   )
 }
@@ -71,7 +89,7 @@ function RuntimeCard({
   isRecommended,
   onSelect,
 }: {
-  match: RuntimeMatch
+  match: RuntimeOption
   isSelected: boolean
   isRecommended: boolean
   onSelect: () => void
@@ -140,7 +158,7 @@ function RuntimeCard({
       )}
 
       {/* Warnings */}
-      {match.warnings && match.warnings.length > 0 && (
+      {match.warnings.length > 0 && (
         <div className="mt-2 flex flex-wrap gap-1">
           {match.warnings.map((warning, idx) => (
             <span
@@ -203,17 +221,6 @@ export default function DeployServicePage() {
   const { data: runtimesData } = useRuntimes()
   const createService = useCreateService()
 
-  // Extract model format and framework for smart selection
-  const modelFormat = selectedModel?.spec.modelFormat?.name
-  const modelFramework = selectedModel?.spec.modelFramework?.name
-
-  // Smart selection queries - only enabled when a model is selected
-  const { data: compatibleData, isLoading: compatibleLoading } = useCompatibleRuntimes(
-    modelFormat,
-    modelFramework
-  )
-  const { data: recommendedRuntime } = useRuntimeRecommendation(modelFormat, modelFramework)
-
   const {
     register,
     handleSubmit,
@@ -230,6 +237,15 @@ export default function DeployServicePage() {
 
   const watchedRuntime = watch('runtime')
   const watchedModel = watch('model')
+  const watchedNamespace = watch('namespace')
+
+  // Smart selection queries - only enabled when a model is selected, runtimes are ranked like the
+  // controller ranks them for an InferenceService of the model in the namespace
+  const runtimeQuery = selectedModel
+    ? { model: selectedModel.metadata.name, namespace: watchedNamespace }
+    : undefined
+  const { data: compatibleData, isLoading: compatibleLoading } = useCompatibleRuntimes(runtimeQuery)
+  const { data: recommendedRuntime } = useRuntimeRecommendation(runtimeQuery)
 
   // Handle model selection change
   const handleModelChange = (modelName: string) => {
@@ -241,31 +257,41 @@ export default function DeployServicePage() {
   }
 
   // Get all runtimes (compatible ones first, then others)
-  const sortedRuntimes = useMemo(() => {
+  const sortedRuntimes = useMemo((): RuntimeOption[] => {
     if (!runtimesData?.items) return []
     if (!compatibleData?.matches)
       return runtimesData.items.map((r) => ({
         runtime: r,
         score: 0,
-        compatibleWith: [],
         reasons: [],
         warnings: [],
         recommendation: 'Select a model to see compatibility',
       }))
 
+    const compatible = compatibleData.matches.map((m) => ({
+      runtime: m.runtime,
+      score: m.score,
+      reasons: matchReasons(m),
+      warnings: m.matchDetails.reasons ?? [],
+      recommendation: m.recommendation || 'Compatible with the selected model',
+    }))
     const compatibleNames = new Set(compatibleData.matches.map((m) => m.runtime.metadata.name))
     const incompatible = runtimesData.items
       .filter((r) => !compatibleNames.has(r.metadata.name))
-      .map((r) => ({
-        runtime: r,
-        score: 0,
-        compatibleWith: [],
-        reasons: [],
-        warnings: ['Not compatible with selected model'],
-        recommendation: 'This runtime does not support the selected model format',
-      }))
+      .map((r) => {
+        const rejection = compatibleData.rejected.find(
+          (rj) => rj.isCluster && rj.name === r.metadata.name
+        )
+        return {
+          runtime: r,
+          score: 0,
+          reasons: [],
+          warnings: rejection?.reasons ?? ['Not compatible with selected model'],
+          recommendation: 'The controller would not select this runtime for the selected model',
+        }
+      })
 
-    return [...compatibleData.matches, ...incompatible]
+    return [...compatible, ...incompatible]
   }, [runtimesData, compatibleData])
 
   const onSubmit = async (data: DeployFormData) => {
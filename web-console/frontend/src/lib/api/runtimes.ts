@@ -2,6 +2,8 @@ import { apiClient } from './client'
 import {
   ClusterServingRuntime,
   RuntimeMatch,
+  RuntimeQuery,
+  RuntimeRejection,
   CompatibilityCheck,
   RuntimeValidationResult,
 } from '../types/runtime'
@@ -9,6 +11,7 @@ import { ListResponse } from '../types/common'
 
 export interface CompatibleRuntimesResponse {
   matches: RuntimeMatch[]
+  rejected: RuntimeRejection[]
   total: number
 }
 
@@ -44,35 +47,22 @@ export const runtimesApi = {
   },
 
   // Intelligence features
-  findCompatible: async (
-    format: string,
-    framework?: string
-  ): Promise<CompatibleRuntimesResponse> => {
-    const params: Record<string, string> = { format }
-    if (framework) params.framework = framework
+  findCompatible: async (query: RuntimeQuery): Promise<CompatibleRuntimesResponse> => {
     const response = await apiClient.get<CompatibleRuntimesResponse>('/runtimes/compatible', {
-      params,
+      params: query,
     })
     return response.data
   },
 
-  checkCompatibility: async (
-    name: string,
-    format: string,
-    framework?: string
-  ): Promise<CompatibilityCheck> => {
-    const params: Record<string, string> = { format }
-    if (framework) params.framework = framework
+  checkCompatibility: async (name: string, query: RuntimeQuery): Promise<CompatibilityCheck> => {
     const response = await apiClient.get<CompatibilityCheck>(`/runtimes/${name}/compatibility`, {
-      params,
+      params: query,
     })
     return response.data
   },
 
-  getRecommendation: async (format: string, framework?: string): Promise<RuntimeMatch> => {
-    const params: Record<string, string> = { format }
-    if (framework) params.framework = framework
-    const response = await apiClient.get<RuntimeMatch>('/runtimes/recommend', { params })
+  getRecommendation: async (query: RuntimeQuery): Promise<RuntimeMatch> => {
+    const response = await apiClient.get<RuntimeMatch>('/runtimes/recommend', { params: query })
     return response.data
   },
 
@@ -1,5 +1,5 @@
 import { apiClient } from './client'
-import { InferenceService } from '../types/service'
+import { InferenceService, ServiceMetrics, ServicePod } from '../types/service'
 import { ListResponse } from '../types/common'
 
 export const servicesApi = {
@@ -34,4 +34,27 @@ export const servicesApi = {
     const response = await apiClient.get(`/services/${name}/status`, { params })
     return response.data
   },
+
+  getPods: async (
+    name: string,
+    namespace?: string,
+    component?: string
+  ): Promise<ListResponse<ServicePod>> => {
+    const params = { namespace, component }
+    const response = await apiClient.get<ListResponse<ServicePod>>(`/services/${name}/pods`, {
+      params,
+    })
+    return response.data
+  },
+
+  getMetrics: async (
+    name: string,
+    namespace?: string,
+    component?: string,
+    window?: string
+  ): Promise<ServiceMetrics> => {
+    const params = { namespace, component, window }
+    const response = await apiClient.get<ServiceMetrics>(`/services/${name}/metrics`, { params })
+    return response.data
+  },
 }
@@ -1,9 +1,11 @@
-import { useQuery } from '@tanstack/react-query'
+import { useMutation, useQuery } from '@tanstack/react-query'
 import type {
   HuggingFaceModelSearchResult,
   HuggingFaceModelInfoResponse,
   HuggingFaceModelConfig,
   HuggingFaceSearchParams,
+  HuggingFaceImportRequest,
+  HuggingFaceImportResult,
 } from '../types/model'
 
 const API_BASE_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080'
@@ -79,3 +81,41 @@ export function useHuggingFaceModelConfig(modelId: string | null) {
     enabled: Boolean(modelId),
   })
 }
+
+async function importHuggingFaceModel(request: HuggingFaceImportRequest) {
+  const response = await fetch(`${API_BASE_URL}/api/v1/huggingface/import`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+    },
+    body: JSON.stringify(request),
+  })
+
+  if (!response.ok) {
+    const errorData = await response.json()
+    throw new Error(errorData.details || 'Failed to import model')
+  }
+
+  return response.json() as Promise<HuggingFaceImportResult>
+}
+
+// Preview the model an import would create, with the runtimes proposed for it
+export function useHuggingFaceImportPreview(request: HuggingFaceImportRequest | null) {
+  return useQuery({
+    queryKey: ['huggingface', 'import', request],
+    queryFn: async () => {
+      if (!request) throw new Error('Import request is required')
+      return importHuggingFaceModel({ ...request, dryRun: true })
+    },
+    enabled: Boolean(request?.repo),
+    retry: false,
+  })
+}
+
+// Import a HuggingFace model as a BaseModel or ClusterBaseModel
+export function useHuggingFaceImport() {
+  return useMutation({
+    mutationFn: (request: HuggingFaceImportRequest) =>
+      importHuggingFaceModel({ ...request, dryRun: false }),
+  })
+}
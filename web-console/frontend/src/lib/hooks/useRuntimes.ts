@@ -1,6 +1,6 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import { runtimesApi } from '../api/runtimes'
-import { ClusterServingRuntime } from '../types/runtime'
+import { ClusterServingRuntime, RuntimeQuery } from '../types/runtime'
 import {
   createResourceHooks,
   createResourceMutation,
@@ -41,11 +41,11 @@ export function useUpdateRuntime() {
 
 // Runtime Intelligence Hooks (specialized operations not covered by factory)
 
-export function useCompatibleRuntimes(format?: string, framework?: string) {
+export function useCompatibleRuntimes(query?: RuntimeQuery) {
   return useQuery({
-    queryKey: queryKeys.related(RESOURCE_KEY, 'compatible', 'search', format, framework),
-    queryFn: () => runtimesApi.findCompatible(format!, framework),
-    enabled: !!format,
+    queryKey: queryKeys.related(RESOURCE_KEY, 'compatible', 'search', query),
+    queryFn: () => runtimesApi.findCompatible(query!),
+    enabled: !!query?.model,
     staleTime: DEFAULT_QUERY_CONFIG.staleTime,
     gcTime: DEFAULT_QUERY_CONFIG.gcTime,
     retry: DEFAULT_QUERY_CONFIG.retry,
@@ -53,11 +53,11 @@ export function useCompatibleRuntimes(format?: string, framework?: string) {
   })
 }
 
-export function useRuntimeCompatibility(name?: string, format?: string, framework?: string) {
+export function useRuntimeCompatibility(name?: string, query?: RuntimeQuery) {
   return useQuery({
-    queryKey: queryKeys.related(RESOURCE_KEY, name || '', 'compatibility', format, framework),
-    queryFn: () => runtimesApi.checkCompatibility(name!, format!, framework),
-    enabled: !!name && !!format,
+    queryKey: queryKeys.related(RESOURCE_KEY, name || '', 'compatibility', query),
+    queryFn: () => runtimesApi.checkCompatibility(name!, query!),
+    enabled: !!name && !!query?.model,
     staleTime: DEFAULT_QUERY_CONFIG.staleTime,
     gcTime: DEFAULT_QUERY_CONFIG.gcTime,
     retry: DEFAULT_QUERY_CONFIG.retry,
@@ -65,11 +65,11 @@ export function useRuntimeCompatibility(name?: string, format?: string, framewor
   })
 }
 
-export function useRuntimeRecommendation(format?: string, framework?: string) {
+export function useRuntimeRecommendation(query?: RuntimeQuery) {
   return useQuery({
-    queryKey: queryKeys.related(RESOURCE_KEY, 'recommend', 'search', format, framework),
-    queryFn: () => runtimesApi.getRecommendation(format!, framework),
-    enabled: !!format,
+    queryKey: queryKeys.related(RESOURCE_KEY, 'recommend', 'search', query),
+    queryFn: () => runtimesApi.getRecommendation(query!),
+    enabled: !!query?.model,
     staleTime: DEFAULT_QUERY_CONFIG.staleTime,
     gcTime: DEFAULT_QUERY_CONFIG.gcTime,
     retry: DEFAULT_QUERY_CONFIG.retry,
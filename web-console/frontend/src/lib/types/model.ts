@@ -1,5 +1,6 @@
 // Import shared types from common
 import { ObjectMeta, ResourceRequirements } from './common'
+import type { RuntimeMatch, RuntimeRejection } from './runtime'
 
 // Re-export for backwards compatibility
 export type { ResourceRequirements } from './common'
@@ -176,6 +177,33 @@ export interface HuggingFaceModelInfoResponse {
   estimatedSize?: number
 }
 
+export interface HuggingFaceImportRequest {
+  repo: string
+  revision?: string
+  name?: string
+  kind?: 'ClusterBaseModel' | 'BaseModel'
+  namespace?: string
+  path?: string
+  vendor?: string
+  version?: string
+  nodeSelector?: Record<string, string>
+  huggingfaceToken?: string
+  acceleratorPolicy?: string
+  dryRun?: boolean
+}
+
+export interface HuggingFaceImportResult {
+  model: ClusterBaseModel | BaseModel
+  runtimes?: {
+    matches: RuntimeMatch[]
+    rejected: RuntimeRejection[]
+    total: number
+  }
+  commit?: string
+  warnings?: string[]
+  created: boolean
+}
+
 export interface HuggingFaceSearchParams {
   q?: string
   author?: string
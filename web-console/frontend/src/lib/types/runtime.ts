@@ -231,20 +231,51 @@ export interface ServingRuntimeStatus {
 
 // Runtime Intelligence Types
 
+// The InferenceService runtimes are selected for, the way the controller selects them
+export interface RuntimeQuery {
+  model: string
+  kind?: 'ClusterBaseModel' | 'BaseModel'
+  namespace?: string
+  acceleratorClass?: string
+  acceleratorPolicy?: string
+}
+
+export interface MatchDetails {
+  formatMatch: boolean
+  frameworkMatch: boolean
+  sizeMatch: boolean
+  architectureMatch: boolean
+  diffusionPipelineMatch: boolean
+  quantizationMatch: boolean
+  priority: number
+  weight: number
+  autoSelectEnabled: boolean
+  reasons?: string[]
+}
+
 export interface RuntimeMatch {
   runtime: ClusterServingRuntime
+  isCluster: boolean
   score: number
-  compatibleWith: string[]
+  matchDetails: MatchDetails
+  acceleratorClass?: string
+  recommendation?: string
+}
+
+export interface RuntimeRejection {
+  name: string
+  isCluster: boolean
   reasons: string[]
-  warnings?: string[]
-  recommendation: string
 }
 
 export interface CompatibilityCheck {
-  compatible: boolean
-  reasons: string[]
+  runtime: string
+  isCluster: boolean
+  isCompatible: boolean
+  matchDetails: MatchDetails
+  incompatibilityReasons?: string[]
   warnings?: string[]
-  score: number
+  acceleratorClass?: string
 }
 
 export interface RuntimeValidationResult {
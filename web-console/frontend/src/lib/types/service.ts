@@ -70,3 +70,25 @@ export interface InferenceServiceStatus {
     }
   >
 }
+
+export interface ServicePod {
+  name: string
+  component: string
+  phase: string
+  node?: string
+  role?: 'leader' | 'worker'
+  groupIndex?: string
+  workerIndex?: string
+  containers: string[]
+  ready: boolean
+  restarts: number
+}
+
+export interface ServiceMetrics {
+  component: string
+  window: string
+  requestRate: number | null
+  ttftP50: number | null
+  ttftP95: number | null
+  gpuUtilization: number | null
+}